@@ -0,0 +1,95 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package decisions
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// maxDecisionLogLineSize is the maximum size of a decision log line.
+const maxDecisionLogLineSize = 4 * 1024 * 1024
+
+// DecisionLogRequest represents the request of a decision log entry.
+type DecisionLogRequest struct {
+	AuthorizationModel *pdp.AuthorizationModelRequest `json:"authorization_model"`
+	Evaluation         *pdp.EvaluationRequest         `json:"evaluation"`
+}
+
+// DecisionLogEntry represents a decision log entry.
+type DecisionLogEntry struct {
+	Request  DecisionLogRequest      `json:"request"`
+	Response *pdp.EvaluationResponse `json:"response"`
+}
+
+// NewDecisionLogEntry creates a new decision log entry.
+func NewDecisionLogEntry(authzModel *pdp.AuthorizationModelRequest, evaluation *pdp.EvaluationRequest, response *pdp.EvaluationResponse) *DecisionLogEntry {
+	return &DecisionLogEntry{
+		Request: DecisionLogRequest{
+			AuthorizationModel: authzModel,
+			Evaluation:         evaluation,
+		},
+		Response: response,
+	}
+}
+
+// ParseDecisionLogs parses the decision log entries, one json document per line.
+func ParseDecisionLogs(data []byte) ([]DecisionLogEntry, error) {
+	entries := []DecisionLogEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDecisionLogLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var entry DecisionLogEntry
+		if err := json.Unmarshal(text, &entry); err != nil {
+			return nil, errors.Join(fmt.Errorf("decisions: invalid decision log entry at line %d", line), err)
+		}
+		if entry.Request.Evaluation == nil {
+			return nil, fmt.Errorf("decisions: missing evaluation in decision log entry at line %d", line)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Join(errors.New("decisions: failed to read the decision logs"), err)
+	}
+	return entries, nil
+}
+
+// ReplayEntry converts the decision log entry into a replay entry.
+func (e *DecisionLogEntry) ReplayEntry() *pdp.ReplayEntry {
+	entry := &pdp.ReplayEntry{
+		Evaluation: e.Request.Evaluation,
+	}
+	if e.Request.AuthorizationModel != nil {
+		entry.Principal = e.Request.AuthorizationModel.Principal
+		entry.Entities = e.Request.AuthorizationModel.Entities
+	}
+	if e.Response != nil {
+		entry.Decision = e.Response.Decision
+	}
+	return entry
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package decisions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// TestParseDecisionLogs tests the parsing of the decision logs.
+func TestParseDecisionLogs(t *testing.T) {
+	assert := assert.New(t)
+
+	entry := NewDecisionLogEntry(
+		&pdp.AuthorizationModelRequest{ZoneID: 273165098782, PolicyStore: &pdp.PolicyStore{Kind: "ledger", ID: "fd1ac44e4afa4fc4beec622494d3175a"}},
+		&pdp.EvaluationRequest{
+			RequestID: "1234",
+			Subject:   &pdp.Subject{Type: "USER", ID: "amy"},
+			Resource:  &pdp.Resource{Type: "MagicFarmacia::Platform::Order", ID: "order-1"},
+			Action:    &pdp.Action{Name: "MagicFarmacia::Platform::Action::view"},
+		},
+		&pdp.EvaluationResponse{RequestID: "1234", Decision: true},
+	)
+	line, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	data := append(append(line, '\n', '\n'), line...)
+	entries, err := ParseDecisionLogs(data)
+	require.NoError(t, err)
+	assert.Len(entries, 2)
+	assert.Equal("amy", entries[0].Request.Evaluation.Subject.ID)
+	assert.Equal(int64(273165098782), entries[1].Request.AuthorizationModel.ZoneID)
+	assert.True(entries[1].Response.Decision)
+
+	_, err = ParseDecisionLogs([]byte("{\"request\":{}}"))
+	assert.Error(err, "entries without evaluation should be rejected")
+	_, err = ParseDecisionLogs([]byte("not-json"))
+	assert.Error(err, "invalid entries should be rejected")
+}
+
+// TestDecisionLogEntryReplayEntry tests the conversion of a decision log entry into a replay entry.
+func TestDecisionLogEntryReplayEntry(t *testing.T) {
+	assert := assert.New(t)
+
+	entry := NewDecisionLogEntry(
+		&pdp.AuthorizationModelRequest{
+			ZoneID:    273165098782,
+			Principal: &pdp.Principal{Type: "user", ID: "amy"},
+			Entities:  &pdp.Entities{Schema: "cedar", Items: []map[string]any{}},
+		},
+		&pdp.EvaluationRequest{RequestID: "1234"},
+		&pdp.EvaluationResponse{RequestID: "1234", Decision: true},
+	)
+	replayEntry := entry.ReplayEntry()
+	assert.Equal("amy", replayEntry.Principal.ID)
+	assert.Equal("cedar", replayEntry.Entities.Schema)
+	assert.Equal("1234", replayEntry.Evaluation.RequestID)
+	assert.True(replayEntry.Decision)
+
+	entry.Response = nil
+	assert.False(entry.ReplayEntry().Decision, "entries without response should be replayed as deny")
+}
@@ -141,7 +141,7 @@ func (s *Server) Serve(ctx context.Context, onShutdown func()) (bool, error) {
 		logger.Info("TLS enabled", zap.String("mode", string(tlsCfg.Mode)))
 	}
 
-	hostCfg, err := azservices.NewHostConfig(s.config.DisplayName(), s, storageConnector, s.config.Services(), s.config.ServicesFactories(), logger, s.config.AppData(), grpcCreds)
+	hostCfg, err := azservices.NewHostConfig(s.config.DisplayName(), s, storageConnector, s.config.Services(), s.config.ServicesFactories(), logger, s.config.AppData(), s.config.NOTPMaxPacketSize(), s.config.NOTPTraceFile(), grpcCreds)
 	if err != nil {
 		logger.Error("Bootstrapper cannot create the host config", zap.Error(err))
 		s.startLock.Unlock()
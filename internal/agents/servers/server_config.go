@@ -35,6 +35,7 @@ const (
 	flagPrefixServer              = "server"
 	flagSuffixAppData             = "appdata"
 	flagSuffixNOTPMaxPacketSize   = "notp-max-packet-size"
+	flagSuffixNOTPTrace           = "notp-trace"
 	flagSuffixOTelEnabled         = "otel-enabled"
 	flagSuffixOTelEndpoint        = "otel-endpoint"
 	flagSuffixOTelSampleRate      = "otel-sample-rate"
@@ -53,6 +54,7 @@ type ServerConfig struct {
 	logLevel             string
 	appData              string
 	notpMaxPacketSize    int
+	notpTraceFile        string
 	otelEnabled          bool
 	otelEndpoint         string
 	otelSampleRate       float64
@@ -124,6 +126,11 @@ func (c *ServerConfig) NOTPMaxPacketSize() int {
 	return c.notpMaxPacketSize
 }
 
+// NOTPTraceFile returns the file the notp packets are recorded in, empty if tracing is disabled.
+func (c *ServerConfig) NOTPTraceFile() string {
+	return c.notpTraceFile
+}
+
 // OTelEnabled returns whether OpenTelemetry is enabled.
 func (c *ServerConfig) OTelEnabled() bool {
 	return c.otelEnabled
@@ -160,6 +167,7 @@ func (c *ServerConfig) AddFlags(flagSet *flag.FlagSet) error {
 	}
 	flagSet.String(options.FlagName(flagPrefixServer, flagSuffixAppData), "./", "directory to be used as zone data")
 	flagSet.Int(options.FlagName(flagPrefixServer, flagSuffixNOTPMaxPacketSize), 16777216, "notp maximum packet size in bytes (default 16MB)")
+	flagSet.String(options.FlagName(flagPrefixServer, flagSuffixNOTPTrace), "", "record the notp packets in a capture file, which can be printed with 'permguard notp dump'")
 	flagSet.Bool(options.FlagName(flagPrefixServer, flagSuffixOTelEnabled), false, "enable OpenTelemetry tracing and metrics")
 	flagSet.String(options.FlagName(flagPrefixServer, flagSuffixOTelEndpoint), "localhost:4317", "OpenTelemetry collector gRPC endpoint")
 	flagSet.Float64(options.FlagName(flagPrefixServer, flagSuffixOTelSampleRate), 0.1, "OpenTelemetry trace sample rate (0.0 to 1.0)")
@@ -202,6 +210,7 @@ func (c *ServerConfig) InitFromViper(v *viper.Viper) error {
 	if c.notpMaxPacketSize <= 0 {
 		return errors.New("server: invalid notp max packet size")
 	}
+	c.notpTraceFile = v.GetString(options.FlagName(flagPrefixServer, flagSuffixNOTPTrace))
 	c.otelEnabled = v.GetBool(options.FlagName(flagPrefixServer, flagSuffixOTelEnabled))
 	c.otelEndpoint = v.GetString(options.FlagName(flagPrefixServer, flagSuffixOTelEndpoint))
 	if c.otelEnabled && len(c.otelEndpoint) == 0 {
@@ -159,6 +159,9 @@ func (e *Endpoint) GracefulStop(_ context.Context) (bool, error) {
 	logger := e.logger()
 	logger.Debug("Endpoint is stopping")
 	e.grpcServer.GracefulStop()
+	if err := e.ctx.Stop(); err != nil {
+		logger.Error("Endpoint failed to release the resources of its servers", zap.Error(err))
+	}
 	logger.Debug("Endpoint has stopped")
 	return true, nil
 }
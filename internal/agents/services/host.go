@@ -36,13 +36,15 @@ type HostConfig struct {
 	services          []services.ServiceKind
 	servicesFactories map[services.ServiceKind]services.ServiceFactoryProvider
 	appData           string
+	notpMaxPacketSize int
+	notpTraceFile     string
 	grpcCreds         credentials.TransportCredentials
 }
 
 // NewHostConfig creates a new host configuration.
 func NewHostConfig(displayName string, hostable services.Hostable, storageConnector *storage.Connector,
 	services []services.ServiceKind, servicesFactories map[services.ServiceKind]services.ServiceFactoryProvider, logger *zap.Logger, appData string,
-	grpcCreds credentials.TransportCredentials,
+	notpMaxPacketSize int, notpTraceFile string, grpcCreds credentials.TransportCredentials,
 ) (*HostConfig, error) {
 	return &HostConfig{
 		logger:            logger,
@@ -52,6 +54,8 @@ func NewHostConfig(displayName string, hostable services.Hostable, storageConnec
 		services:          services,
 		servicesFactories: servicesFactories,
 		appData:           appData,
+		notpMaxPacketSize: notpMaxPacketSize,
+		notpTraceFile:     notpTraceFile,
 		grpcCreds:         grpcCreds,
 	}, nil
 }
@@ -81,6 +85,16 @@ func (h *HostConfig) AppData() string {
 	return h.appData
 }
 
+// NOTPMaxPacketSize returns the notp maximum packet size in bytes.
+func (h *HostConfig) NOTPMaxPacketSize() int {
+	return h.notpMaxPacketSize
+}
+
+// NOTPTraceFile returns the file the notp packets are recorded in.
+func (h *HostConfig) NOTPTraceFile() string {
+	return h.notpTraceFile
+}
+
 // Host represents the host.
 type Host struct {
 	config   *HostConfig
@@ -90,7 +104,7 @@ type Host struct {
 
 // NewHost creates a new host.
 func NewHost(hostCfg *HostConfig) (*Host, error) {
-	hostCfgReader := services.NewHostConfiguration(hostCfg.AppData())
+	hostCfgReader := services.NewHostConfiguration(hostCfg.AppData(), hostCfg.NOTPMaxPacketSize(), hostCfg.NOTPTraceFile())
 	hostCtx, err := services.NewHostContext(hostCfg.displayName, hostCfg.hostable, hostCfg.logger, hostCfgReader)
 	if err != nil {
 		return nil, err
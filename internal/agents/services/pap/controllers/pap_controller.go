@@ -21,6 +21,7 @@ import (
 
 	"github.com/permguard/permguard/pkg/agents/services"
 	"github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
@@ -54,14 +55,19 @@ func (s PAPController) UpdateLedger(ctx context.Context, ledger *pap.Ledger) (*p
 	return s.storage.UpdateLedger(ctx, ledger)
 }
 
-// DeleteLedger deletes an ledger.
-func (s PAPController) DeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (*pap.Ledger, error) {
-	return s.storage.DeleteLedger(ctx, zoneID, ledgerID)
+// DeleteLedger soft deletes a ledger.
+func (s PAPController) DeleteLedger(ctx context.Context, zoneID int64, ledgerID string, options *models.DeleteOptions) (*pap.Ledger, error) {
+	return s.storage.DeleteLedger(ctx, zoneID, ledgerID, options)
 }
 
-// FetchLedgers gets all ledgers.
-func (s PAPController) FetchLedgers(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any) ([]pap.Ledger, error) {
-	return s.storage.FetchLedgers(ctx, page, pageSize, zoneID, fields)
+// UndeleteLedger restores a soft deleted ledger.
+func (s PAPController) UndeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (*pap.Ledger, error) {
+	return s.storage.UndeleteLedger(ctx, zoneID, ledgerID)
+}
+
+// FetchLedgersPage gets a page of ledgers, sorted and resumed as per the options.
+func (s PAPController) FetchLedgersPage(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any, options *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error) {
+	return s.storage.FetchLedgersPage(ctx, page, pageSize, zoneID, fields, options)
 }
 
 // PushAdvertise handles the push advertise step.
@@ -74,6 +80,11 @@ func (s PAPController) PushTransfer(ctx context.Context, req *pap.PushTransferRe
 	return s.storage.PushTransfer(ctx, req)
 }
 
+// PushCommit stores the objects of a streamed push and updates the ledger ref in a single step.
+func (s PAPController) PushCommit(ctx context.Context, req *pap.PushCommitRequest) (*pap.PushCommitResponse, error) {
+	return s.storage.PushCommit(ctx, req)
+}
+
 // PullState handles the pull state step.
 func (s PAPController) PullState(ctx context.Context, req *pap.PullStateRequest) (*pap.PullStateResponse, error) {
 	return s.storage.PullState(ctx, req)
@@ -85,6 +96,6 @@ func (s PAPController) PullNegotiate(ctx context.Context, req *pap.PullNegotiate
 }
 
 // PullObjects handles the pull objects step.
-func (s PAPController) PullObjects(ctx context.Context, req *pap.PullObjectsRequest) (*pap.PullObjectsResponse, error) {
-	return s.storage.PullObjects(ctx, req)
+func (s PAPController) PullObjects(ctx context.Context, req *pap.PullObjectsRequest, held map[string]struct{}) (*pap.PullObjectsResponse, error) {
+	return s.storage.PullObjects(ctx, req, held)
 }
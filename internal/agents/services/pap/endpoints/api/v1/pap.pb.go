@@ -47,6 +47,12 @@ type LedgerFetchRequest struct {
 	LedgerID      *string                `protobuf:"bytes,4,opt,name=LedgerID,proto3,oneof" json:"LedgerID,omitempty"`
 	Kind          *string                `protobuf:"bytes,5,opt,name=Kind,proto3,oneof" json:"Kind,omitempty"`
 	Name          *string                `protobuf:"bytes,6,opt,name=Name,proto3,oneof" json:"Name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,7,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NamePrefix    *string                `protobuf:"bytes,8,opt,name=NamePrefix,proto3,oneof" json:"NamePrefix,omitempty"`
+	SortBy        *string                `protobuf:"bytes,9,opt,name=SortBy,proto3,oneof" json:"SortBy,omitempty"`
+	SortDesc      *bool                  `protobuf:"varint,10,opt,name=SortDesc,proto3,oneof" json:"SortDesc,omitempty"`
+	Cursor        *string                `protobuf:"bytes,11,opt,name=Cursor,proto3,oneof" json:"Cursor,omitempty"`
+	Deleted       *bool                  `protobuf:"varint,12,opt,name=Deleted,proto3,oneof" json:"Deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,19 +129,154 @@ func (x *LedgerFetchRequest) GetName() string {
 	return ""
 }
 
+func (x *LedgerFetchRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *LedgerFetchRequest) GetNamePrefix() string {
+	if x != nil && x.NamePrefix != nil {
+		return *x.NamePrefix
+	}
+	return ""
+}
+
+func (x *LedgerFetchRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *LedgerFetchRequest) GetSortDesc() bool {
+	if x != nil && x.SortDesc != nil {
+		return *x.SortDesc
+	}
+	return false
+}
+
+func (x *LedgerFetchRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *LedgerFetchRequest) GetDeleted() bool {
+	if x != nil && x.Deleted != nil {
+		return *x.Deleted
+	}
+	return false
+}
+
+// Ledger trusted keys.
+type LedgerTrustedKeys struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerTrustedKeys) Reset() {
+	*x = LedgerTrustedKeys{}
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerTrustedKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerTrustedKeys) ProtoMessage() {}
+
+func (x *LedgerTrustedKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerTrustedKeys.ProtoReflect.Descriptor instead.
+func (*LedgerTrustedKeys) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LedgerTrustedKeys) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Ledger labels.
+type LedgerLabels struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       map[string]string      `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerLabels) Reset() {
+	*x = LedgerLabels{}
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerLabels) ProtoMessage() {}
+
+func (x *LedgerLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerLabels.ProtoReflect.Descriptor instead.
+func (*LedgerLabels) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LedgerLabels) GetEntries() map[string]string {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 // Ledger create request.
 type LedgerCreateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
 	Kind          string                 `protobuf:"bytes,2,opt,name=Kind,proto3" json:"Kind,omitempty"`
 	Name          string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+	TrustedKeys   []string               `protobuf:"bytes,4,rep,name=TrustedKeys,proto3" json:"TrustedKeys,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LedgerCreateRequest) Reset() {
 	*x = LedgerCreateRequest{}
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[1]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +288,7 @@ func (x *LedgerCreateRequest) String() string {
 func (*LedgerCreateRequest) ProtoMessage() {}
 
 func (x *LedgerCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[1]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +301,7 @@ func (x *LedgerCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LedgerCreateRequest.ProtoReflect.Descriptor instead.
 func (*LedgerCreateRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{1}
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LedgerCreateRequest) GetZoneID() int64 {
@@ -184,6 +325,27 @@ func (x *LedgerCreateRequest) GetName() string {
 	return ""
 }
 
+func (x *LedgerCreateRequest) GetTrustedKeys() []string {
+	if x != nil {
+		return x.TrustedKeys
+	}
+	return nil
+}
+
+func (x *LedgerCreateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LedgerCreateRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // Ledger update request.
 type LedgerUpdateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -191,13 +353,16 @@ type LedgerUpdateRequest struct {
 	LedgerID      string                 `protobuf:"bytes,2,opt,name=LedgerID,proto3" json:"LedgerID,omitempty"`
 	Kind          string                 `protobuf:"bytes,3,opt,name=Kind,proto3" json:"Kind,omitempty"`
 	Name          string                 `protobuf:"bytes,4,opt,name=Name,proto3" json:"Name,omitempty"`
+	TrustedKeys   *LedgerTrustedKeys     `protobuf:"bytes,5,opt,name=TrustedKeys,proto3" json:"TrustedKeys,omitempty"`
+	Description   *string                `protobuf:"bytes,6,opt,name=Description,proto3,oneof" json:"Description,omitempty"`
+	Labels        *LedgerLabels          `protobuf:"bytes,7,opt,name=Labels,proto3" json:"Labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LedgerUpdateRequest) Reset() {
 	*x = LedgerUpdateRequest{}
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[2]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -209,7 +374,7 @@ func (x *LedgerUpdateRequest) String() string {
 func (*LedgerUpdateRequest) ProtoMessage() {}
 
 func (x *LedgerUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[2]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +387,7 @@ func (x *LedgerUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LedgerUpdateRequest.ProtoReflect.Descriptor instead.
 func (*LedgerUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{2}
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *LedgerUpdateRequest) GetZoneID() int64 {
@@ -253,18 +418,41 @@ func (x *LedgerUpdateRequest) GetName() string {
 	return ""
 }
 
+func (x *LedgerUpdateRequest) GetTrustedKeys() *LedgerTrustedKeys {
+	if x != nil {
+		return x.TrustedKeys
+	}
+	return nil
+}
+
+func (x *LedgerUpdateRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *LedgerUpdateRequest) GetLabels() *LedgerLabels {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // Ledger delete request.
 type LedgerDeleteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
 	LedgerID      string                 `protobuf:"bytes,2,opt,name=LedgerID,proto3" json:"LedgerID,omitempty"`
+	Force         bool                   `protobuf:"varint,3,opt,name=Force,proto3" json:"Force,omitempty"`
+	Confirmation  string                 `protobuf:"bytes,4,opt,name=Confirmation,proto3" json:"Confirmation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LedgerDeleteRequest) Reset() {
 	*x = LedgerDeleteRequest{}
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[3]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -276,7 +464,7 @@ func (x *LedgerDeleteRequest) String() string {
 func (*LedgerDeleteRequest) ProtoMessage() {}
 
 func (x *LedgerDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[3]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,7 +477,7 @@ func (x *LedgerDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LedgerDeleteRequest.ProtoReflect.Descriptor instead.
 func (*LedgerDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{3}
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *LedgerDeleteRequest) GetZoneID() int64 {
@@ -306,6 +494,73 @@ func (x *LedgerDeleteRequest) GetLedgerID() string {
 	return ""
 }
 
+func (x *LedgerDeleteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *LedgerDeleteRequest) GetConfirmation() string {
+	if x != nil {
+		return x.Confirmation
+	}
+	return ""
+}
+
+// Ledger undelete request.
+type LedgerUndeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
+	LedgerID      string                 `protobuf:"bytes,2,opt,name=LedgerID,proto3" json:"LedgerID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerUndeleteRequest) Reset() {
+	*x = LedgerUndeleteRequest{}
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerUndeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerUndeleteRequest) ProtoMessage() {}
+
+func (x *LedgerUndeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerUndeleteRequest.ProtoReflect.Descriptor instead.
+func (*LedgerUndeleteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LedgerUndeleteRequest) GetZoneID() int64 {
+	if x != nil {
+		return x.ZoneID
+	}
+	return 0
+}
+
+func (x *LedgerUndeleteRequest) GetLedgerID() string {
+	if x != nil {
+		return x.LedgerID
+	}
+	return ""
+}
+
 // Ledger response.
 type LedgerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -316,13 +571,17 @@ type LedgerResponse struct {
 	Kind          string                 `protobuf:"bytes,5,opt,name=Kind,proto3" json:"Kind,omitempty"`
 	Name          string                 `protobuf:"bytes,6,opt,name=Name,proto3" json:"Name,omitempty"`
 	Ref           string                 `protobuf:"bytes,7,opt,name=Ref,proto3" json:"Ref,omitempty"`
+	TrustedKeys   []string               `protobuf:"bytes,8,rep,name=TrustedKeys,proto3" json:"TrustedKeys,omitempty"`
+	Description   string                 `protobuf:"bytes,9,opt,name=Description,proto3" json:"Description,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,10,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=DeletedAt,proto3,oneof" json:"DeletedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LedgerResponse) Reset() {
 	*x = LedgerResponse{}
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[4]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +593,7 @@ func (x *LedgerResponse) String() string {
 func (*LedgerResponse) ProtoMessage() {}
 
 func (x *LedgerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[4]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +606,7 @@ func (x *LedgerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LedgerResponse.ProtoReflect.Descriptor instead.
 func (*LedgerResponse) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{4}
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LedgerResponse) GetLedgerID() string {
@@ -399,6 +658,34 @@ func (x *LedgerResponse) GetRef() string {
 	return ""
 }
 
+func (x *LedgerResponse) GetTrustedKeys() []string {
+	if x != nil {
+		return x.TrustedKeys
+	}
+	return nil
+}
+
+func (x *LedgerResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LedgerResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *LedgerResponse) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 // PackMessage is a pack message containing JSON-encoded request/response data.
 type PackMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -409,7 +696,7 @@ type PackMessage struct {
 
 func (x *PackMessage) Reset() {
 	*x = PackMessage{}
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[5]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -421,7 +708,7 @@ func (x *PackMessage) String() string {
 func (*PackMessage) ProtoMessage() {}
 
 func (x *PackMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[5]
+	mi := &file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +721,7 @@ func (x *PackMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackMessage.ProtoReflect.Descriptor instead.
 func (*PackMessage) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{5}
+	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PackMessage) GetData() []byte {
@@ -448,31 +735,71 @@ var File_internal_agents_services_pap_endpoints_api_v1_pap_proto protoreflect.Fi
 
 const file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDesc = "" +
 	"\n" +
-	"7internal/agents/services/pap/endpoints/api/v1/pap.proto\x12\x19policyadministrationpoint\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\x01\n" +
+	"7internal/agents/services/pap/endpoints/api/v1/pap.proto\x12\x19policyadministrationpoint\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x04\n" +
 	"\x12LedgerFetchRequest\x12\x17\n" +
 	"\x04Page\x18\x01 \x01(\x05H\x00R\x04Page\x88\x01\x01\x12\x1f\n" +
 	"\bPageSize\x18\x02 \x01(\x05H\x01R\bPageSize\x88\x01\x01\x12\x16\n" +
 	"\x06ZoneID\x18\x03 \x01(\x03R\x06ZoneID\x12\x1f\n" +
 	"\bLedgerID\x18\x04 \x01(\tH\x02R\bLedgerID\x88\x01\x01\x12\x17\n" +
 	"\x04Kind\x18\x05 \x01(\tH\x03R\x04Kind\x88\x01\x01\x12\x17\n" +
-	"\x04Name\x18\x06 \x01(\tH\x04R\x04Name\x88\x01\x01B\a\n" +
+	"\x04Name\x18\x06 \x01(\tH\x04R\x04Name\x88\x01\x01\x12Q\n" +
+	"\x06Labels\x18\a \x03(\v29.policyadministrationpoint.LedgerFetchRequest.LabelsEntryR\x06Labels\x12#\n" +
+	"\n" +
+	"NamePrefix\x18\b \x01(\tH\x05R\n" +
+	"NamePrefix\x88\x01\x01\x12\x1b\n" +
+	"\x06SortBy\x18\t \x01(\tH\x06R\x06SortBy\x88\x01\x01\x12\x1f\n" +
+	"\bSortDesc\x18\n" +
+	" \x01(\bH\aR\bSortDesc\x88\x01\x01\x12\x1b\n" +
+	"\x06Cursor\x18\v \x01(\tH\bR\x06Cursor\x88\x01\x01\x12\x1d\n" +
+	"\aDeleted\x18\f \x01(\bH\tR\aDeleted\x88\x01\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
 	"\x05_PageB\v\n" +
 	"\t_PageSizeB\v\n" +
 	"\t_LedgerIDB\a\n" +
 	"\x05_KindB\a\n" +
-	"\x05_Name\"U\n" +
+	"\x05_NameB\r\n" +
+	"\v_NamePrefixB\t\n" +
+	"\a_SortByB\v\n" +
+	"\t_SortDescB\t\n" +
+	"\a_CursorB\n" +
+	"\n" +
+	"\b_Deleted\"'\n" +
+	"\x11LedgerTrustedKeys\x12\x12\n" +
+	"\x04Keys\x18\x01 \x03(\tR\x04Keys\"\x9a\x01\n" +
+	"\fLedgerLabels\x12N\n" +
+	"\aEntries\x18\x01 \x03(\v24.policyadministrationpoint.LedgerLabels.EntriesEntryR\aEntries\x1a:\n" +
+	"\fEntriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa8\x02\n" +
 	"\x13LedgerCreateRequest\x12\x16\n" +
 	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12\x12\n" +
 	"\x04Kind\x18\x02 \x01(\tR\x04Kind\x12\x12\n" +
-	"\x04Name\x18\x03 \x01(\tR\x04Name\"q\n" +
+	"\x04Name\x18\x03 \x01(\tR\x04Name\x12 \n" +
+	"\vTrustedKeys\x18\x04 \x03(\tR\vTrustedKeys\x12 \n" +
+	"\vDescription\x18\x05 \x01(\tR\vDescription\x12R\n" +
+	"\x06Labels\x18\x06 \x03(\v2:.policyadministrationpoint.LedgerCreateRequest.LabelsEntryR\x06Labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb9\x02\n" +
 	"\x13LedgerUpdateRequest\x12\x16\n" +
 	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12\x1a\n" +
 	"\bLedgerID\x18\x02 \x01(\tR\bLedgerID\x12\x12\n" +
 	"\x04Kind\x18\x03 \x01(\tR\x04Kind\x12\x12\n" +
-	"\x04Name\x18\x04 \x01(\tR\x04Name\"I\n" +
+	"\x04Name\x18\x04 \x01(\tR\x04Name\x12N\n" +
+	"\vTrustedKeys\x18\x05 \x01(\v2,.policyadministrationpoint.LedgerTrustedKeysR\vTrustedKeys\x12%\n" +
+	"\vDescription\x18\x06 \x01(\tH\x00R\vDescription\x88\x01\x01\x12?\n" +
+	"\x06Labels\x18\a \x01(\v2'.policyadministrationpoint.LedgerLabelsR\x06LabelsB\x0e\n" +
+	"\f_Description\"\x83\x01\n" +
 	"\x13LedgerDeleteRequest\x12\x16\n" +
 	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12\x1a\n" +
-	"\bLedgerID\x18\x02 \x01(\tR\bLedgerID\"\xf2\x01\n" +
+	"\bLedgerID\x18\x02 \x01(\tR\bLedgerID\x12\x14\n" +
+	"\x05Force\x18\x03 \x01(\bR\x05Force\x12\"\n" +
+	"\fConfirmation\x18\x04 \x01(\tR\fConfirmation\"K\n" +
+	"\x15LedgerUndeleteRequest\x12\x16\n" +
+	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12\x1a\n" +
+	"\bLedgerID\x18\x02 \x01(\tR\bLedgerID\"\x8d\x04\n" +
 	"\x0eLedgerResponse\x12\x1a\n" +
 	"\bLedgerID\x18\x01 \x01(\tR\bLedgerID\x12\x16\n" +
 	"\x06ZoneID\x18\x02 \x01(\x03R\x06ZoneID\x128\n" +
@@ -480,19 +807,32 @@ const file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDesc = ""
 	"\tUpdatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x12\n" +
 	"\x04Kind\x18\x05 \x01(\tR\x04Kind\x12\x12\n" +
 	"\x04Name\x18\x06 \x01(\tR\x04Name\x12\x10\n" +
-	"\x03Ref\x18\a \x01(\tR\x03Ref\"!\n" +
+	"\x03Ref\x18\a \x01(\tR\x03Ref\x12 \n" +
+	"\vTrustedKeys\x18\b \x03(\tR\vTrustedKeys\x12 \n" +
+	"\vDescription\x18\t \x01(\tR\vDescription\x12M\n" +
+	"\x06Labels\x18\n" +
+	" \x03(\v25.policyadministrationpoint.LedgerResponse.LabelsEntryR\x06Labels\x12=\n" +
+	"\tDeletedAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tDeletedAt\x88\x01\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_DeletedAt\"!\n" +
 	"\vPackMessage\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data2\xab\a\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2\x80\t\n" +
 	"\fV1PAPService\x12k\n" +
 	"\fCreateLedger\x12..policyadministrationpoint.LedgerCreateRequest\x1a).policyadministrationpoint.LedgerResponse\"\x00\x12k\n" +
 	"\fUpdateLedger\x12..policyadministrationpoint.LedgerUpdateRequest\x1a).policyadministrationpoint.LedgerResponse\"\x00\x12k\n" +
-	"\fDeleteLedger\x12..policyadministrationpoint.LedgerDeleteRequest\x1a).policyadministrationpoint.LedgerResponse\"\x00\x12l\n" +
+	"\fDeleteLedger\x12..policyadministrationpoint.LedgerDeleteRequest\x1a).policyadministrationpoint.LedgerResponse\"\x00\x12o\n" +
+	"\x0eUndeleteLedger\x120.policyadministrationpoint.LedgerUndeleteRequest\x1a).policyadministrationpoint.LedgerResponse\"\x00\x12l\n" +
 	"\fFetchLedgers\x12-.policyadministrationpoint.LedgerFetchRequest\x1a).policyadministrationpoint.LedgerResponse\"\x000\x01\x12a\n" +
 	"\rPushAdvertise\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00\x12`\n" +
 	"\fPushTransfer\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00\x12]\n" +
 	"\tPullState\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00\x12a\n" +
 	"\rPullNegotiate\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00\x12_\n" +
-	"\vPullObjects\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00B:Z8github.com/permguard/permguard/internal/hosts/api/pap/v1b\x06proto3"
+	"\vPullObjects\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00\x12b\n" +
+	"\n" +
+	"NOTPStream\x12&.policyadministrationpoint.PackMessage\x1a&.policyadministrationpoint.PackMessage\"\x00(\x010\x01B:Z8github.com/permguard/permguard/internal/hosts/api/pap/v1b\x06proto3"
 
 var (
 	file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescOnce sync.Once
@@ -506,42 +846,60 @@ func file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescGZIP()
 	return file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDescData
 }
 
-var file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_internal_agents_services_pap_endpoints_api_v1_pap_proto_goTypes = []any{
 	(*LedgerFetchRequest)(nil),    // 0: policyadministrationpoint.LedgerFetchRequest
-	(*LedgerCreateRequest)(nil),   // 1: policyadministrationpoint.LedgerCreateRequest
-	(*LedgerUpdateRequest)(nil),   // 2: policyadministrationpoint.LedgerUpdateRequest
-	(*LedgerDeleteRequest)(nil),   // 3: policyadministrationpoint.LedgerDeleteRequest
-	(*LedgerResponse)(nil),        // 4: policyadministrationpoint.LedgerResponse
-	(*PackMessage)(nil),           // 5: policyadministrationpoint.PackMessage
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*LedgerTrustedKeys)(nil),     // 1: policyadministrationpoint.LedgerTrustedKeys
+	(*LedgerLabels)(nil),          // 2: policyadministrationpoint.LedgerLabels
+	(*LedgerCreateRequest)(nil),   // 3: policyadministrationpoint.LedgerCreateRequest
+	(*LedgerUpdateRequest)(nil),   // 4: policyadministrationpoint.LedgerUpdateRequest
+	(*LedgerDeleteRequest)(nil),   // 5: policyadministrationpoint.LedgerDeleteRequest
+	(*LedgerUndeleteRequest)(nil), // 6: policyadministrationpoint.LedgerUndeleteRequest
+	(*LedgerResponse)(nil),        // 7: policyadministrationpoint.LedgerResponse
+	(*PackMessage)(nil),           // 8: policyadministrationpoint.PackMessage
+	nil,                           // 9: policyadministrationpoint.LedgerFetchRequest.LabelsEntry
+	nil,                           // 10: policyadministrationpoint.LedgerLabels.EntriesEntry
+	nil,                           // 11: policyadministrationpoint.LedgerCreateRequest.LabelsEntry
+	nil,                           // 12: policyadministrationpoint.LedgerResponse.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
 }
 var file_internal_agents_services_pap_endpoints_api_v1_pap_proto_depIdxs = []int32{
-	6,  // 0: policyadministrationpoint.LedgerResponse.CreatedAt:type_name -> google.protobuf.Timestamp
-	6,  // 1: policyadministrationpoint.LedgerResponse.UpdatedAt:type_name -> google.protobuf.Timestamp
-	1,  // 2: policyadministrationpoint.V1PAPService.CreateLedger:input_type -> policyadministrationpoint.LedgerCreateRequest
-	2,  // 3: policyadministrationpoint.V1PAPService.UpdateLedger:input_type -> policyadministrationpoint.LedgerUpdateRequest
-	3,  // 4: policyadministrationpoint.V1PAPService.DeleteLedger:input_type -> policyadministrationpoint.LedgerDeleteRequest
-	0,  // 5: policyadministrationpoint.V1PAPService.FetchLedgers:input_type -> policyadministrationpoint.LedgerFetchRequest
-	5,  // 6: policyadministrationpoint.V1PAPService.PushAdvertise:input_type -> policyadministrationpoint.PackMessage
-	5,  // 7: policyadministrationpoint.V1PAPService.PushTransfer:input_type -> policyadministrationpoint.PackMessage
-	5,  // 8: policyadministrationpoint.V1PAPService.PullState:input_type -> policyadministrationpoint.PackMessage
-	5,  // 9: policyadministrationpoint.V1PAPService.PullNegotiate:input_type -> policyadministrationpoint.PackMessage
-	5,  // 10: policyadministrationpoint.V1PAPService.PullObjects:input_type -> policyadministrationpoint.PackMessage
-	4,  // 11: policyadministrationpoint.V1PAPService.CreateLedger:output_type -> policyadministrationpoint.LedgerResponse
-	4,  // 12: policyadministrationpoint.V1PAPService.UpdateLedger:output_type -> policyadministrationpoint.LedgerResponse
-	4,  // 13: policyadministrationpoint.V1PAPService.DeleteLedger:output_type -> policyadministrationpoint.LedgerResponse
-	4,  // 14: policyadministrationpoint.V1PAPService.FetchLedgers:output_type -> policyadministrationpoint.LedgerResponse
-	5,  // 15: policyadministrationpoint.V1PAPService.PushAdvertise:output_type -> policyadministrationpoint.PackMessage
-	5,  // 16: policyadministrationpoint.V1PAPService.PushTransfer:output_type -> policyadministrationpoint.PackMessage
-	5,  // 17: policyadministrationpoint.V1PAPService.PullState:output_type -> policyadministrationpoint.PackMessage
-	5,  // 18: policyadministrationpoint.V1PAPService.PullNegotiate:output_type -> policyadministrationpoint.PackMessage
-	5,  // 19: policyadministrationpoint.V1PAPService.PullObjects:output_type -> policyadministrationpoint.PackMessage
-	11, // [11:20] is the sub-list for method output_type
-	2,  // [2:11] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	9,  // 0: policyadministrationpoint.LedgerFetchRequest.Labels:type_name -> policyadministrationpoint.LedgerFetchRequest.LabelsEntry
+	10, // 1: policyadministrationpoint.LedgerLabels.Entries:type_name -> policyadministrationpoint.LedgerLabels.EntriesEntry
+	11, // 2: policyadministrationpoint.LedgerCreateRequest.Labels:type_name -> policyadministrationpoint.LedgerCreateRequest.LabelsEntry
+	1,  // 3: policyadministrationpoint.LedgerUpdateRequest.TrustedKeys:type_name -> policyadministrationpoint.LedgerTrustedKeys
+	2,  // 4: policyadministrationpoint.LedgerUpdateRequest.Labels:type_name -> policyadministrationpoint.LedgerLabels
+	13, // 5: policyadministrationpoint.LedgerResponse.CreatedAt:type_name -> google.protobuf.Timestamp
+	13, // 6: policyadministrationpoint.LedgerResponse.UpdatedAt:type_name -> google.protobuf.Timestamp
+	12, // 7: policyadministrationpoint.LedgerResponse.Labels:type_name -> policyadministrationpoint.LedgerResponse.LabelsEntry
+	13, // 8: policyadministrationpoint.LedgerResponse.DeletedAt:type_name -> google.protobuf.Timestamp
+	3,  // 9: policyadministrationpoint.V1PAPService.CreateLedger:input_type -> policyadministrationpoint.LedgerCreateRequest
+	4,  // 10: policyadministrationpoint.V1PAPService.UpdateLedger:input_type -> policyadministrationpoint.LedgerUpdateRequest
+	5,  // 11: policyadministrationpoint.V1PAPService.DeleteLedger:input_type -> policyadministrationpoint.LedgerDeleteRequest
+	6,  // 12: policyadministrationpoint.V1PAPService.UndeleteLedger:input_type -> policyadministrationpoint.LedgerUndeleteRequest
+	0,  // 13: policyadministrationpoint.V1PAPService.FetchLedgers:input_type -> policyadministrationpoint.LedgerFetchRequest
+	8,  // 14: policyadministrationpoint.V1PAPService.PushAdvertise:input_type -> policyadministrationpoint.PackMessage
+	8,  // 15: policyadministrationpoint.V1PAPService.PushTransfer:input_type -> policyadministrationpoint.PackMessage
+	8,  // 16: policyadministrationpoint.V1PAPService.PullState:input_type -> policyadministrationpoint.PackMessage
+	8,  // 17: policyadministrationpoint.V1PAPService.PullNegotiate:input_type -> policyadministrationpoint.PackMessage
+	8,  // 18: policyadministrationpoint.V1PAPService.PullObjects:input_type -> policyadministrationpoint.PackMessage
+	8,  // 19: policyadministrationpoint.V1PAPService.NOTPStream:input_type -> policyadministrationpoint.PackMessage
+	7,  // 20: policyadministrationpoint.V1PAPService.CreateLedger:output_type -> policyadministrationpoint.LedgerResponse
+	7,  // 21: policyadministrationpoint.V1PAPService.UpdateLedger:output_type -> policyadministrationpoint.LedgerResponse
+	7,  // 22: policyadministrationpoint.V1PAPService.DeleteLedger:output_type -> policyadministrationpoint.LedgerResponse
+	7,  // 23: policyadministrationpoint.V1PAPService.UndeleteLedger:output_type -> policyadministrationpoint.LedgerResponse
+	7,  // 24: policyadministrationpoint.V1PAPService.FetchLedgers:output_type -> policyadministrationpoint.LedgerResponse
+	8,  // 25: policyadministrationpoint.V1PAPService.PushAdvertise:output_type -> policyadministrationpoint.PackMessage
+	8,  // 26: policyadministrationpoint.V1PAPService.PushTransfer:output_type -> policyadministrationpoint.PackMessage
+	8,  // 27: policyadministrationpoint.V1PAPService.PullState:output_type -> policyadministrationpoint.PackMessage
+	8,  // 28: policyadministrationpoint.V1PAPService.PullNegotiate:output_type -> policyadministrationpoint.PackMessage
+	8,  // 29: policyadministrationpoint.V1PAPService.PullObjects:output_type -> policyadministrationpoint.PackMessage
+	8,  // 30: policyadministrationpoint.V1PAPService.NOTPStream:output_type -> policyadministrationpoint.PackMessage
+	20, // [20:31] is the sub-list for method output_type
+	9,  // [9:20] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_internal_agents_services_pap_endpoints_api_v1_pap_proto_init() }
@@ -550,13 +908,15 @@ func file_internal_agents_services_pap_endpoints_api_v1_pap_proto_init() {
 		return
 	}
 	file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[0].OneofWrappers = []any{}
+	file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[4].OneofWrappers = []any{}
+	file_internal_agents_services_pap_endpoints_api_v1_pap_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDesc), len(file_internal_agents_services_pap_endpoints_api_v1_pap_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
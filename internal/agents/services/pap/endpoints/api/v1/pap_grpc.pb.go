@@ -35,15 +35,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	V1PAPService_CreateLedger_FullMethodName  = "/policyadministrationpoint.V1PAPService/CreateLedger"
-	V1PAPService_UpdateLedger_FullMethodName  = "/policyadministrationpoint.V1PAPService/UpdateLedger"
-	V1PAPService_DeleteLedger_FullMethodName  = "/policyadministrationpoint.V1PAPService/DeleteLedger"
-	V1PAPService_FetchLedgers_FullMethodName  = "/policyadministrationpoint.V1PAPService/FetchLedgers"
-	V1PAPService_PushAdvertise_FullMethodName = "/policyadministrationpoint.V1PAPService/PushAdvertise"
-	V1PAPService_PushTransfer_FullMethodName  = "/policyadministrationpoint.V1PAPService/PushTransfer"
-	V1PAPService_PullState_FullMethodName     = "/policyadministrationpoint.V1PAPService/PullState"
-	V1PAPService_PullNegotiate_FullMethodName = "/policyadministrationpoint.V1PAPService/PullNegotiate"
-	V1PAPService_PullObjects_FullMethodName   = "/policyadministrationpoint.V1PAPService/PullObjects"
+	V1PAPService_CreateLedger_FullMethodName   = "/policyadministrationpoint.V1PAPService/CreateLedger"
+	V1PAPService_UpdateLedger_FullMethodName   = "/policyadministrationpoint.V1PAPService/UpdateLedger"
+	V1PAPService_DeleteLedger_FullMethodName   = "/policyadministrationpoint.V1PAPService/DeleteLedger"
+	V1PAPService_UndeleteLedger_FullMethodName = "/policyadministrationpoint.V1PAPService/UndeleteLedger"
+	V1PAPService_FetchLedgers_FullMethodName   = "/policyadministrationpoint.V1PAPService/FetchLedgers"
+	V1PAPService_PushAdvertise_FullMethodName  = "/policyadministrationpoint.V1PAPService/PushAdvertise"
+	V1PAPService_PushTransfer_FullMethodName   = "/policyadministrationpoint.V1PAPService/PushTransfer"
+	V1PAPService_PullState_FullMethodName      = "/policyadministrationpoint.V1PAPService/PullState"
+	V1PAPService_PullNegotiate_FullMethodName  = "/policyadministrationpoint.V1PAPService/PullNegotiate"
+	V1PAPService_PullObjects_FullMethodName    = "/policyadministrationpoint.V1PAPService/PullObjects"
+	V1PAPService_NOTPStream_FullMethodName     = "/policyadministrationpoint.V1PAPService/NOTPStream"
 )
 
 // V1PAPServiceClient is the client API for V1PAPService service.
@@ -58,6 +60,8 @@ type V1PAPServiceClient interface {
 	UpdateLedger(ctx context.Context, in *LedgerUpdateRequest, opts ...grpc.CallOption) (*LedgerResponse, error)
 	// Delete an ledger.
 	DeleteLedger(ctx context.Context, in *LedgerDeleteRequest, opts ...grpc.CallOption) (*LedgerResponse, error)
+	// Undelete an ledger.
+	UndeleteLedger(ctx context.Context, in *LedgerUndeleteRequest, opts ...grpc.CallOption) (*LedgerResponse, error)
 	// Fetch ledgers.
 	FetchLedgers(ctx context.Context, in *LedgerFetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LedgerResponse], error)
 	// PushAdvertise handles the push advertise step.
@@ -70,6 +74,8 @@ type V1PAPServiceClient interface {
 	PullNegotiate(ctx context.Context, in *PackMessage, opts ...grpc.CallOption) (*PackMessage, error)
 	// PullObjects handles the pull objects step.
 	PullObjects(ctx context.Context, in *PackMessage, opts ...grpc.CallOption) (*PackMessage, error)
+	// NOTPStream runs a whole push or pull NOTP flow over a single bidirectional stream.
+	NOTPStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PackMessage, PackMessage], error)
 }
 
 type v1PAPServiceClient struct {
@@ -110,6 +116,16 @@ func (c *v1PAPServiceClient) DeleteLedger(ctx context.Context, in *LedgerDeleteR
 	return out, nil
 }
 
+func (c *v1PAPServiceClient) UndeleteLedger(ctx context.Context, in *LedgerUndeleteRequest, opts ...grpc.CallOption) (*LedgerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LedgerResponse)
+	err := c.cc.Invoke(ctx, V1PAPService_UndeleteLedger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *v1PAPServiceClient) FetchLedgers(ctx context.Context, in *LedgerFetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LedgerResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &V1PAPService_ServiceDesc.Streams[0], V1PAPService_FetchLedgers_FullMethodName, cOpts...)
@@ -179,6 +195,19 @@ func (c *v1PAPServiceClient) PullObjects(ctx context.Context, in *PackMessage, o
 	return out, nil
 }
 
+func (c *v1PAPServiceClient) NOTPStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PackMessage, PackMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &V1PAPService_ServiceDesc.Streams[1], V1PAPService_NOTPStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PackMessage, PackMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type V1PAPService_NOTPStreamClient = grpc.BidiStreamingClient[PackMessage, PackMessage]
+
 // V1PAPServiceServer is the server API for V1PAPService service.
 // All implementations must embed UnimplementedV1PAPServiceServer
 // for forward compatibility.
@@ -191,6 +220,8 @@ type V1PAPServiceServer interface {
 	UpdateLedger(context.Context, *LedgerUpdateRequest) (*LedgerResponse, error)
 	// Delete an ledger.
 	DeleteLedger(context.Context, *LedgerDeleteRequest) (*LedgerResponse, error)
+	// Undelete an ledger.
+	UndeleteLedger(context.Context, *LedgerUndeleteRequest) (*LedgerResponse, error)
 	// Fetch ledgers.
 	FetchLedgers(*LedgerFetchRequest, grpc.ServerStreamingServer[LedgerResponse]) error
 	// PushAdvertise handles the push advertise step.
@@ -203,6 +234,8 @@ type V1PAPServiceServer interface {
 	PullNegotiate(context.Context, *PackMessage) (*PackMessage, error)
 	// PullObjects handles the pull objects step.
 	PullObjects(context.Context, *PackMessage) (*PackMessage, error)
+	// NOTPStream runs a whole push or pull NOTP flow over a single bidirectional stream.
+	NOTPStream(grpc.BidiStreamingServer[PackMessage, PackMessage]) error
 	mustEmbedUnimplementedV1PAPServiceServer()
 }
 
@@ -222,6 +255,9 @@ func (UnimplementedV1PAPServiceServer) UpdateLedger(context.Context, *LedgerUpda
 func (UnimplementedV1PAPServiceServer) DeleteLedger(context.Context, *LedgerDeleteRequest) (*LedgerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLedger not implemented")
 }
+func (UnimplementedV1PAPServiceServer) UndeleteLedger(context.Context, *LedgerUndeleteRequest) (*LedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteLedger not implemented")
+}
 func (UnimplementedV1PAPServiceServer) FetchLedgers(*LedgerFetchRequest, grpc.ServerStreamingServer[LedgerResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method FetchLedgers not implemented")
 }
@@ -240,6 +276,9 @@ func (UnimplementedV1PAPServiceServer) PullNegotiate(context.Context, *PackMessa
 func (UnimplementedV1PAPServiceServer) PullObjects(context.Context, *PackMessage) (*PackMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PullObjects not implemented")
 }
+func (UnimplementedV1PAPServiceServer) NOTPStream(grpc.BidiStreamingServer[PackMessage, PackMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method NOTPStream not implemented")
+}
 func (UnimplementedV1PAPServiceServer) mustEmbedUnimplementedV1PAPServiceServer() {}
 func (UnimplementedV1PAPServiceServer) testEmbeddedByValue()                      {}
 
@@ -315,6 +354,24 @@ func _V1PAPService_DeleteLedger_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _V1PAPService_UndeleteLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LedgerUndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(V1PAPServiceServer).UndeleteLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: V1PAPService_UndeleteLedger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(V1PAPServiceServer).UndeleteLedger(ctx, req.(*LedgerUndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _V1PAPService_FetchLedgers_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(LedgerFetchRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -416,6 +473,13 @@ func _V1PAPService_PullObjects_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _V1PAPService_NOTPStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(V1PAPServiceServer).NOTPStream(&grpc.GenericServerStream[PackMessage, PackMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type V1PAPService_NOTPStreamServer = grpc.BidiStreamingServer[PackMessage, PackMessage]
+
 // V1PAPService_ServiceDesc is the grpc.ServiceDesc for V1PAPService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -435,6 +499,10 @@ var V1PAPService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteLedger",
 			Handler:    _V1PAPService_DeleteLedger_Handler,
 		},
+		{
+			MethodName: "UndeleteLedger",
+			Handler:    _V1PAPService_UndeleteLedger_Handler,
+		},
 		{
 			MethodName: "PushAdvertise",
 			Handler:    _V1PAPService_PushAdvertise_Handler,
@@ -462,6 +530,12 @@ var V1PAPService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _V1PAPService_FetchLedgers_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "NOTPStream",
+			Handler:       _V1PAPService_NOTPStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "internal/agents/services/pap/endpoints/api/v1/pap.proto",
 }
@@ -17,37 +17,88 @@
 package v1
 
 import (
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
 // MapGrpcLedgerResponseToAgentLedger maps the gRPC ledger to the agent ledger.
 func MapGrpcLedgerResponseToAgentLedger(ledger *LedgerResponse) (*pap.Ledger, error) {
 	return &pap.Ledger{
-		LedgerID:  ledger.LedgerID,
-		CreatedAt: ledger.CreatedAt.AsTime(),
-		UpdatedAt: ledger.UpdatedAt.AsTime(),
-		ZoneID:    ledger.ZoneID,
-		Kind:      ledger.Kind,
-		Name:      ledger.Name,
-		Ref:       ledger.Ref,
+		LedgerID:    ledger.LedgerID,
+		CreatedAt:   ledger.CreatedAt.AsTime(),
+		UpdatedAt:   ledger.UpdatedAt.AsTime(),
+		ZoneID:      ledger.ZoneID,
+		Kind:        ledger.Kind,
+		Name:        ledger.Name,
+		Ref:         ledger.Ref,
+		TrustedKeys: ledger.TrustedKeys,
+		Description: MapStringToPointerString(ledger.Description),
+		Labels:      ledger.Labels,
+		DeletedAt:   MapGrpcTimestampToPointerTime(ledger.DeletedAt),
 	}, nil
 }
 
+// MapGrpcLedgerUpdateRequestToAgentLedger maps the gRPC ledger update request to the agent ledger.
+// Trusted keys, description and labels are left unchanged when the request does not carry them.
+func MapGrpcLedgerUpdateRequestToAgentLedger(ledgerRequest *LedgerUpdateRequest) *pap.Ledger {
+	ledger := &pap.Ledger{
+		LedgerID:    ledgerRequest.LedgerID,
+		ZoneID:      ledgerRequest.ZoneID,
+		Name:        ledgerRequest.Name,
+		Description: ledgerRequest.Description,
+	}
+	if ledgerRequest.TrustedKeys != nil {
+		ledger.TrustedKeys = append([]string{}, ledgerRequest.TrustedKeys.Keys...)
+	}
+	if ledgerRequest.Labels != nil {
+		ledger.Labels = map[string]string{}
+		maps.Copy(ledger.Labels, ledgerRequest.Labels.Entries)
+	}
+	return ledger
+}
+
 // MapAgentLedgerToGrpcLedgerResponse maps the agent ledger to the gRPC ledger.
 func MapAgentLedgerToGrpcLedgerResponse(ledger *pap.Ledger) (*LedgerResponse, error) {
 	return &LedgerResponse{
-		LedgerID:  ledger.LedgerID,
-		CreatedAt: timestamppb.New(ledger.CreatedAt),
-		UpdatedAt: timestamppb.New(ledger.UpdatedAt),
-		ZoneID:    ledger.ZoneID,
-		Kind:      ledger.Kind,
-		Name:      ledger.Name,
-		Ref:       ledger.Ref,
+		LedgerID:    ledger.LedgerID,
+		CreatedAt:   timestamppb.New(ledger.CreatedAt),
+		UpdatedAt:   timestamppb.New(ledger.UpdatedAt),
+		ZoneID:      ledger.ZoneID,
+		Kind:        ledger.Kind,
+		Name:        ledger.Name,
+		Ref:         ledger.Ref,
+		TrustedKeys: ledger.TrustedKeys,
+		Description: MapPointerStringToString(ledger.Description),
+		Labels:      ledger.Labels,
+		DeletedAt:   MapPointerTimeToGrpcTimestamp(ledger.DeletedAt),
 	}, nil
 }
 
+// MapPointerTimeToGrpcTimestamp maps a pointer time to a gRPC timestamp, nil times are mapped to nil.
+func MapPointerTimeToGrpcTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// MapGrpcTimestampToPointerTime maps a gRPC timestamp to a pointer time, nil timestamps are mapped to nil.
+func MapGrpcTimestampToPointerTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
 // MapPointerStringToString maps a pointer string to a string.
 func MapPointerStringToString(str *string) string {
 	response := ""
@@ -56,3 +107,52 @@ func MapPointerStringToString(str *string) string {
 	}
 	return response
 }
+
+// MapGrpcFetchOptions maps the gRPC sort and cursor fields to the fetch options.
+func MapGrpcFetchOptions(sortBy *string, sortDesc *bool, cursor *string) *models.FetchOptions {
+	options := &models.FetchOptions{
+		SortBy: MapPointerStringToString(sortBy),
+		Cursor: MapPointerStringToString(cursor),
+	}
+	if sortDesc != nil {
+		options.SortDesc = *sortDesc
+	}
+	return options
+}
+
+// MapFetchPageInfoToGrpcTrailer maps the page info to the trailer of a fetch stream.
+func MapFetchPageInfoToGrpcTrailer(pageInfo *models.FetchPageInfo) metadata.MD {
+	md := metadata.MD{}
+	if pageInfo == nil {
+		return md
+	}
+	md.Set(models.FetchTotalCountKey, strconv.FormatInt(pageInfo.TotalCount, 10))
+	if pageInfo.NextCursor != "" {
+		md.Set(models.FetchNextCursorKey, pageInfo.NextCursor)
+	}
+	return md
+}
+
+// MapGrpcTrailerToFetchPageInfo maps the trailer of a fetch stream to the page info.
+func MapGrpcTrailerToFetchPageInfo(md metadata.MD) (*models.FetchPageInfo, error) {
+	pageInfo := &models.FetchPageInfo{}
+	if values := md.Get(models.FetchTotalCountKey); len(values) > 0 {
+		totalCount, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total count %q in the fetch trailer", values[0])
+		}
+		pageInfo.TotalCount = totalCount
+	}
+	if values := md.Get(models.FetchNextCursorKey); len(values) > 0 {
+		pageInfo.NextCursor = values[0]
+	}
+	return pageInfo, nil
+}
+
+// MapStringToPointerString maps a string to a pointer string, empty strings are mapped to nil.
+func MapStringToPointerString(str string) *string {
+	if str == "" {
+		return nil
+	}
+	return &str
+}
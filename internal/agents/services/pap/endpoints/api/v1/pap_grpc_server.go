@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync"
 
 	"go.opentelemetry.io/otel/attribute"
 	otelcodes "go.opentelemetry.io/otel/codes"
@@ -27,9 +28,11 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	aznotpcapture "github.com/permguard/permguard/notp-protocol/pkg/notp/capture"
 	"github.com/permguard/permguard/pkg/agents/services"
 	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
@@ -47,6 +50,8 @@ func mapStorageError(err error) error {
 		return status.Errorf(codes.Aborted, "%v", err)
 	case errors.Is(err, azstorage.ErrInvalidInput):
 		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, azstorage.ErrConfirmationRequired):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
 	default:
 		return status.Errorf(codes.Internal, "internal error")
 	}
@@ -59,28 +64,36 @@ type PAPService interface {
 	CreateLedger(ctx context.Context, ledger *pap.Ledger) (*pap.Ledger, error)
 	// UpdateLedger updates an ledger.
 	UpdateLedger(ctx context.Context, ledger *pap.Ledger) (*pap.Ledger, error)
-	// DeleteLedger deletes an ledger.
-	DeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (*pap.Ledger, error)
-	// FetchLedgers gets all ledgers.
-	FetchLedgers(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any) ([]pap.Ledger, error)
+	// DeleteLedger soft deletes a ledger.
+	DeleteLedger(ctx context.Context, zoneID int64, ledgerID string, options *models.DeleteOptions) (*pap.Ledger, error)
+	// UndeleteLedger restores a soft deleted ledger.
+	UndeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (*pap.Ledger, error)
+	// FetchLedgersPage gets a page of ledgers, sorted and resumed as per the options.
+	FetchLedgersPage(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any, options *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error)
 	// PushAdvertise handles the push advertise step.
 	PushAdvertise(ctx context.Context, req *pap.PushAdvertiseRequest) (*pap.PushAdvertiseResponse, error)
 	// PushTransfer handles the push transfer step.
 	PushTransfer(ctx context.Context, req *pap.PushTransferRequest) (*pap.PushTransferResponse, error)
+	// PushCommit stores the objects of a streamed push and updates the ledger ref in a single step.
+	PushCommit(ctx context.Context, req *pap.PushCommitRequest) (*pap.PushCommitResponse, error)
 	// PullState handles the pull state step.
 	PullState(ctx context.Context, req *pap.PullStateRequest) (*pap.PullStateResponse, error)
 	// PullNegotiate handles the pull negotiate step.
 	PullNegotiate(ctx context.Context, req *pap.PullNegotiateRequest) (*pap.PullNegotiateResponse, error)
-	// PullObjects handles the pull objects step.
-	PullObjects(ctx context.Context, req *pap.PullObjectsRequest) (*pap.PullObjectsResponse, error)
+	// PullObjects handles the pull objects step, the held set collects the objects held by the client within a pull session.
+	PullObjects(ctx context.Context, req *pap.PullObjectsRequest, held map[string]struct{}) (*pap.PullObjectsResponse, error)
 }
 
 // NewPAPServer creates a new PAP server.
 func NewPAPServer(endpointCtx *services.EndpointContext, service PAPService) (*PAPServer, error) {
-	return &PAPServer{
+	server := &PAPServer{
 		ctx:     endpointCtx,
 		service: service,
-	}, nil
+	}
+	if endpointCtx != nil {
+		endpointCtx.OnStop(server.Close)
+	}
+	return server, nil
 }
 
 // PAPServer is the gRPC server for the PAP.
@@ -88,6 +101,9 @@ type PAPServer struct {
 	UnimplementedV1PAPServiceServer
 	ctx     *services.EndpointContext
 	service PAPService
+	// traceOnce opens the notp trace file shared by the streams on first use.
+	traceOnce   sync.Once
+	traceWriter *aznotpcapture.Writer
 }
 
 // CreateLedger creates a new ledger.
@@ -98,7 +114,7 @@ func (s *PAPServer) CreateLedger(ctx context.Context, ledgerRequest *LedgerCreat
 		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pap.CreateLedger"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", ledgerRequest.ZoneID))
-	ledger, err := s.service.CreateLedger(ctx, &pap.Ledger{ZoneID: ledgerRequest.ZoneID, Name: ledgerRequest.Name, Kind: ledgerRequest.Kind})
+	ledger, err := s.service.CreateLedger(ctx, &pap.Ledger{ZoneID: ledgerRequest.ZoneID, Name: ledgerRequest.Name, Kind: ledgerRequest.Kind, TrustedKeys: ledgerRequest.TrustedKeys, Description: MapStringToPointerString(ledgerRequest.Description), Labels: ledgerRequest.Labels})
 	if err != nil {
 		span.SetStatus(otelcodes.Error, err.Error())
 		return nil, mapStorageError(err)
@@ -114,7 +130,7 @@ func (s *PAPServer) UpdateLedger(ctx context.Context, ledgerRequest *LedgerUpdat
 		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pap.UpdateLedger"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", ledgerRequest.ZoneID), attribute.String("ledger_id", ledgerRequest.LedgerID))
-	ledger, err := s.service.UpdateLedger(ctx, (&pap.Ledger{LedgerID: ledgerRequest.LedgerID, ZoneID: ledgerRequest.ZoneID, Name: ledgerRequest.Name}))
+	ledger, err := s.service.UpdateLedger(ctx, MapGrpcLedgerUpdateRequestToAgentLedger(ledgerRequest))
 	if err != nil {
 		span.SetStatus(otelcodes.Error, err.Error())
 		return nil, mapStorageError(err)
@@ -130,7 +146,23 @@ func (s *PAPServer) DeleteLedger(ctx context.Context, ledgerRequest *LedgerDelet
 		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pap.DeleteLedger"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", ledgerRequest.ZoneID), attribute.String("ledger_id", ledgerRequest.LedgerID))
-	ledger, err := s.service.DeleteLedger(ctx, ledgerRequest.ZoneID, ledgerRequest.LedgerID)
+	ledger, err := s.service.DeleteLedger(ctx, ledgerRequest.ZoneID, ledgerRequest.LedgerID, &models.DeleteOptions{Force: ledgerRequest.Force, Confirmation: ledgerRequest.Confirmation})
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return nil, mapStorageError(err)
+	}
+	return MapAgentLedgerToGrpcLedgerResponse(ledger)
+}
+
+// UndeleteLedger restores a soft deleted ledger.
+func (s *PAPServer) UndeleteLedger(ctx context.Context, ledgerRequest *LedgerUndeleteRequest) (_ *LedgerResponse, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "grpc.pap.UndeleteLedger")
+	defer span.End()
+	defer func() {
+		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pap.UndeleteLedger"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
+	}()
+	span.SetAttributes(attribute.Int64("zone_id", ledgerRequest.ZoneID), attribute.String("ledger_id", ledgerRequest.LedgerID))
+	ledger, err := s.service.UndeleteLedger(ctx, ledgerRequest.ZoneID, ledgerRequest.LedgerID)
 	if err != nil {
 		span.SetStatus(otelcodes.Error, err.Error())
 		return nil, mapStorageError(err)
@@ -158,6 +190,15 @@ func (s *PAPServer) FetchLedgers(ledgerRequest *LedgerFetchRequest, stream grpc.
 	if ledgerRequest.LedgerID != nil {
 		fields[pap.FieldLedgerLedgerID] = *ledgerRequest.LedgerID
 	}
+	if ledgerRequest.NamePrefix != nil {
+		fields[pap.FieldLedgerNamePrefix] = *ledgerRequest.NamePrefix
+	}
+	if ledgerRequest.Deleted != nil {
+		fields[pap.FieldLedgerDeleted] = *ledgerRequest.Deleted
+	}
+	if len(ledgerRequest.Labels) > 0 {
+		fields[pap.FieldLedgerLabels] = ledgerRequest.Labels
+	}
 	page := int32(0)
 	if ledgerRequest.Page != nil {
 		page = *ledgerRequest.Page
@@ -166,11 +207,13 @@ func (s *PAPServer) FetchLedgers(ledgerRequest *LedgerFetchRequest, stream grpc.
 	if ledgerRequest.PageSize != nil {
 		pageSize = *ledgerRequest.PageSize
 	}
-	ledgers, err := s.service.FetchLedgers(ctx, page, pageSize, ledgerRequest.ZoneID, fields)
+	options := MapGrpcFetchOptions(ledgerRequest.SortBy, ledgerRequest.SortDesc, ledgerRequest.Cursor)
+	ledgers, pageInfo, err := s.service.FetchLedgersPage(ctx, page, pageSize, ledgerRequest.ZoneID, fields, options)
 	if err != nil {
 		span.SetStatus(otelcodes.Error, err.Error())
 		return mapStorageError(err)
 	}
+	stream.SetTrailer(MapFetchPageInfoToGrpcTrailer(pageInfo))
 	span.SetAttributes(attribute.Int("result_count", len(ledgers)))
 	for _, ledger := range ledgers {
 		cvtedLedger, err := MapAgentLedgerToGrpcLedgerResponse(&ledger)
@@ -287,7 +330,7 @@ func (s *PAPServer) PullObjects(ctx context.Context, in *PackMessage) (_ *PackMe
 	if err := json.Unmarshal(in.Data, &req); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
 	}
-	resp, err := s.service.PullObjects(ctx, &req)
+	resp, err := s.service.PullObjects(ctx, &req, nil)
 	if err != nil {
 		span.SetStatus(otelcodes.Error, err.Error())
 		return nil, mapStorageError(err)
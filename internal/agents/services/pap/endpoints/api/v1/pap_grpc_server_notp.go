@@ -0,0 +1,441 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	notpstatemachines "github.com/permguard/permguard/internal/transport/notp/statemachines"
+	notppackets "github.com/permguard/permguard/internal/transport/notp/statemachines/packets"
+	aznotpcapture "github.com/permguard/permguard/notp-protocol/pkg/notp/capture"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
+	aznotptransport "github.com/permguard/permguard/notp-protocol/pkg/notp/transport"
+	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+)
+
+const (
+	// notpStreamTimeout is the timeout to wait for each packet of a NOTP stream.
+	notpStreamTimeout = 30 * time.Second
+)
+
+// notpLeaderSession holds the state of a NOTP flow run by the server as leader.
+type notpLeaderSession struct {
+	ctx              context.Context
+	service          PAPService
+	maxPacketSize    int
+	zoneID           int64
+	ledgerID         string
+	refCommit        string
+	serverCommit     string
+	txID             string
+	confirmedObjects int64
+	assembler        *aznotppackets.ChunkAssembler
+	commitIDs        []string
+	haveCommitIDs    []string
+	heldObjects      map[string]struct{}
+	skipObjects      uint64
+	chunks           [][]aznotppackets.Packetable
+	err              error
+}
+
+// notpStreamScope reads the zone and ledger of a NOTP stream from the incoming metadata.
+func notpStreamScope(ctx context.Context) (int64, string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, "", errors.New("notp: missing stream metadata")
+	}
+	zoneIDs := md.Get(notpstatemachines.ZoneIDKey)
+	ledgerIDs := md.Get(notpstatemachines.LedgerIDKey)
+	if len(zoneIDs) != 1 || len(ledgerIDs) != 1 {
+		return 0, "", errors.New("notp: zone id and ledger id are required in the stream metadata")
+	}
+	zoneID, err := strconv.ParseInt(zoneIDs[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("notp: invalid zone id %q", zoneIDs[0])
+	}
+	return zoneID, ledgerIDs[0], nil
+}
+
+// NOTPStream runs a whole push or pull NOTP flow over a single bidirectional stream, with the server acting as leader.
+func (s *PAPServer) NOTPStream(stream grpc.BidiStreamingServer[PackMessage, PackMessage]) (retErr error) {
+	ctx := stream.Context()
+	ctx, span := telemetry.Tracer().Start(ctx, "grpc.pap.NOTPStream")
+	defer span.End()
+	defer func() {
+		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pap.NOTPStream"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
+	}()
+	zoneID, ledgerID, err := notpStreamScope(ctx)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	span.SetAttributes(attribute.Int64("zone_id", zoneID), attribute.String("ledger_id", ledgerID))
+	wireStream, err := aznotptransport.NewWireStream(func(packet *aznotppackets.Packet) error {
+		return stream.Send(&PackMessage{Data: packet.Data})
+	}, func() (*aznotppackets.Packet, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return &aznotppackets.Packet{Data: msg.Data}, nil
+	}, notpStreamTimeout)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create the wire stream: %v", err)
+	}
+	maxPacketSize := s.notpMaxPacketSize()
+	var inspector *aznotptransport.PacketInspector
+	recorder := s.notpTraceRecorder()
+	if recorder != nil {
+		inspector, err = recorder.Inspector()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create the packet inspector: %v", err)
+		}
+	}
+	transportLayer, err := aznotptransport.NewTransportLayer(maxPacketSize, wireStream.TransmitPacket, wireStream.ReceivePacket, inspector)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create the transport layer: %v", err)
+	}
+	assembler, err := notppackets.NewObjectChunkAssembler(maxPacketSize)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create the chunk assembler: %v", err)
+	}
+	session := &notpLeaderSession{
+		ctx:           ctx,
+		service:       s.service,
+		maxPacketSize: maxPacketSize,
+		assembler:     assembler,
+		zoneID:        zoneID,
+		ledgerID:      ledgerID,
+	}
+	stateMachine, err := aznotpstatemachines.NewLeaderStateMachine(session.handle, transportLayer)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create the state machine: %v", err)
+	}
+	if recorder != nil {
+		recorder.Attach(stateMachine)
+	}
+	_, err = stateMachine.Run(nil, aznotpstatemachines.UnknownFlowType)
+	if session.err != nil {
+		err = session.err
+	}
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return mapStorageError(err)
+	}
+	return nil
+}
+
+// notpMaxPacketSize returns the configured notp maximum packet size, falling back to the protocol default.
+func (s *PAPServer) notpMaxPacketSize() int {
+	if s.ctx != nil {
+		if hostReader, err := s.ctx.HostConfigReader(); err == nil && hostReader.NOTPMaxPacketSize() > 0 {
+			return hostReader.NOTPMaxPacketSize()
+		}
+	}
+	return aznotptransport.DefaultMaxPacketSize
+}
+
+// notpTraceRecorder returns a recorder for a stream if the notp trace file is configured, the file is opened once and shared by the streams.
+func (s *PAPServer) notpTraceRecorder() *aznotpcapture.Recorder {
+	s.traceOnce.Do(func() {
+		if s.ctx == nil {
+			return
+		}
+		hostReader, err := s.ctx.HostConfigReader()
+		if err != nil || hostReader.NOTPTraceFile() == "" {
+			return
+		}
+		writer, err := aznotpcapture.OpenFile(hostReader.NOTPTraceFile())
+		if err != nil {
+			s.ctx.Logger().Error(s.ctx.LogMessage("failed to open the notp trace file"), zap.Error(err))
+			return
+		}
+		s.traceWriter = writer
+	})
+	if s.traceWriter == nil {
+		return nil
+	}
+	return s.traceWriter.NewRecorder(aznotpcapture.RoleLeader, uuid.NewString())
+}
+
+// Close closes the notp trace file, it is called once the endpoint has stopped serving the streams.
+func (s *PAPServer) Close() error {
+	// Marks the trace file as initialized, so that it is not opened after the server is closed.
+	s.traceOnce.Do(func() {})
+	if s.traceWriter == nil {
+		return nil
+	}
+	return s.traceWriter.Close()
+}
+
+// handle handles the state packets of the leader state machine.
+func (l *notpLeaderSession) handle(handlerCtx *aznotpstatemachines.HandlerContext, statePacket *aznotpsmpackets.StatePacket, packetables []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+	var handlerReturn *aznotpstatemachines.HostHandlerReturn
+	var err error
+	switch handlerCtx.FlowType() {
+	case aznotpstatemachines.PushFlowType:
+		handlerReturn, err = l.handlePush(statePacket, packetables)
+	case aznotpstatemachines.PullFlowType:
+		handlerReturn, err = l.handlePull(statePacket, packetables)
+	default:
+		return nil, fmt.Errorf("notp: unsupported flow type %d", handlerCtx.FlowType())
+	}
+	if err != nil && l.err == nil {
+		l.err = err
+	}
+	return handlerReturn, err
+}
+
+// handlePush handles the push flow, staging each received chunk in the push transaction and committing it at the end of the stream.
+func (l *notpLeaderSession) handlePush(statePacket *aznotpsmpackets.StatePacket, packetables []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+	handlerReturn := &aznotpstatemachines.HostHandlerReturn{
+		MessageValue: statePacket.MessageValue,
+	}
+	switch statePacket.MessageCode {
+	case aznotpsmpackets.NotifyCurrentObjectStatesMessage:
+		remoteRef, err := readRemoteRefPacket(packetables)
+		if err != nil {
+			return nil, err
+		}
+		var resumeTxID string
+		if len(packetables) > 1 {
+			resume, err := notppackets.ReadTransferResumePacket(packetables[1:])
+			if err != nil {
+				return nil, err
+			}
+			resumeTxID = resume.Token
+		}
+		resp, err := l.service.PushAdvertise(l.ctx, &pap.PushAdvertiseRequest{
+			ZoneID:        l.zoneID,
+			LedgerID:      l.ledgerID,
+			RefCommit:     remoteRef.RefCommit,
+			RefPrevCommit: remoteRef.RefPrevCommit,
+			ResumeTxID:    resumeTxID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.maxPacketSize = notppackets.NegotiateMaxPacketSize(l.maxPacketSize, remoteRef.MaxPacketSize)
+		l.refCommit = remoteRef.RefCommit
+		l.serverCommit = resp.ServerCommit
+		l.txID = resp.TxID
+		l.confirmedObjects = resp.ConfirmedObjects
+		handlerReturn.Packetables = []aznotppackets.Packetable{&notppackets.LocalRefStatePacket{
+			RefCommit:     resp.ServerCommit,
+			HasConflicts:  resp.HasConflicts,
+			IsUpToDate:    resp.IsUpToDate,
+			MaxPacketSize: uint32(l.maxPacketSize),
+		}}
+		if resp.TxID != "" {
+			handlerReturn.Packetables = append(handlerReturn.Packetables, l.transferResumePacket())
+		}
+	case aznotpsmpackets.RespondCurrentStateMessage, aznotpsmpackets.NegotiationRequestMessage:
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = packetables
+	case aznotpsmpackets.ExchangeDataStreamMessage:
+		assembled, err := l.assembler.Assemble(packetables)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := notppackets.ReadObjectStatePackets(assembled)
+		if err != nil {
+			return nil, err
+		}
+		if len(objs) > 0 {
+			resp, err := l.service.PushTransfer(l.ctx, &pap.PushTransferRequest{
+				TxID:     l.txID,
+				ZoneID:   l.zoneID,
+				LedgerID: l.ledgerID,
+				Objects:  objs,
+			})
+			if err != nil {
+				return nil, err
+			}
+			l.confirmedObjects = resp.ConfirmedObjects
+		}
+	case aznotpsmpackets.AcknowledgeDataStreamMessage:
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = []aznotppackets.Packetable{l.transferResumePacket()}
+	case aznotpsmpackets.CommitMessage:
+		if l.assembler.HasPending() {
+			return nil, errors.New("notp: data stream completed with an incomplete object")
+		}
+		resp, err := l.service.PushCommit(l.ctx, &pap.PushCommitRequest{
+			TxID:                 l.txID,
+			ZoneID:               l.zoneID,
+			LedgerID:             l.ledgerID,
+			RemoteCommitID:       l.refCommit,
+			ExpectedServerCommit: l.serverCommit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Committed {
+			handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		}
+	}
+	return handlerReturn, nil
+}
+
+// transferResumePacket returns the packet confirming the objects staged by the push transaction.
+func (l *notpLeaderSession) transferResumePacket() *notppackets.TransferResumePacket {
+	return &notppackets.TransferResumePacket{
+		Token:            l.txID,
+		ConfirmedObjects: uint64(l.confirmedObjects),
+	}
+}
+
+// handlePull handles the pull flow, streaming the objects of the commits in chunks that fit the max packet size.
+func (l *notpLeaderSession) handlePull(statePacket *aznotpsmpackets.StatePacket, packetables []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+	handlerReturn := &aznotpstatemachines.HostHandlerReturn{
+		MessageValue: statePacket.MessageValue,
+	}
+	switch statePacket.MessageCode {
+	case aznotpsmpackets.RequestCurrentObjectsStateMessage:
+		remoteRef, err := readRemoteRefPacket(packetables)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := l.service.PullState(l.ctx, &pap.PullStateRequest{
+			ZoneID:        l.zoneID,
+			LedgerID:      l.ledgerID,
+			RefCommit:     remoteRef.RefCommit,
+			RefPrevCommit: remoteRef.RefPrevCommit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.maxPacketSize = notppackets.NegotiateMaxPacketSize(l.maxPacketSize, remoteRef.MaxPacketSize)
+		handlerReturn.Packetables = []aznotppackets.Packetable{&notppackets.LocalRefStatePacket{
+			RefCommit:       resp.ServerCommit,
+			HasConflicts:    resp.HasConflicts,
+			IsUpToDate:      resp.IsUpToDate,
+			NumberOfCommits: resp.NumberOfCommits,
+			MaxPacketSize:   uint32(l.maxPacketSize),
+		}}
+	case aznotpsmpackets.NegotiationRequestMessage:
+		remoteRef, err := readRemoteRefPacket(packetables)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := l.service.PullNegotiate(l.ctx, &pap.PullNegotiateRequest{
+			ZoneID:         l.zoneID,
+			LedgerID:       l.ledgerID,
+			LocalCommitID:  remoteRef.RefPrevCommit,
+			RemoteCommitID: remoteRef.RefCommit,
+			HaveCommitIDs:  remoteRef.HaveCommits,
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.commitIDs = resp.CommitIDs
+		l.haveCommitIDs = resp.HaveCommitIDs
+		l.heldObjects = map[string]struct{}{}
+		// A resume only applies to a transfer of the same remote commit, otherwise the objects are sent from the start.
+		if len(packetables) > 1 {
+			resume, err := notppackets.ReadTransferResumePacket(packetables[1:])
+			if err != nil {
+				return nil, err
+			}
+			if resume.Token == remoteRef.RefCommit {
+				l.skipObjects = resume.ConfirmedObjects
+			}
+		}
+		handlerReturn.Packetables = []aznotppackets.Packetable{
+			&notppackets.LocalRefStatePacket{
+				RefCommit:       remoteRef.RefCommit,
+				NumberOfCommits: uint32(len(resp.CommitIDs)),
+			},
+			&notppackets.TransferResumePacket{
+				Token:            remoteRef.RefCommit,
+				ConfirmedObjects: l.skipObjects,
+			},
+		}
+	case aznotpsmpackets.RespondCurrentStateMessage, aznotpsmpackets.RespondNegotiationRequestMessage:
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = packetables
+	case aznotpsmpackets.ExchangeDataStreamMessage:
+		chunk, err := l.nextPullChunk()
+		if err != nil {
+			return nil, err
+		}
+		handlerReturn.Packetables = chunk
+		handlerReturn.HasMore = len(l.chunks) > 0 || len(l.commitIDs) > 0
+		handlerReturn.MessageValue = notpstatemachines.DataStreamMessageValue(handlerReturn.HasMore)
+	}
+	return handlerReturn, nil
+}
+
+// nextPullChunk returns the next chunk of objects to pull, skipping the objects already confirmed by the follower.
+func (l *notpLeaderSession) nextPullChunk() ([]aznotppackets.Packetable, error) {
+	for len(l.chunks) == 0 && len(l.commitIDs) > 0 {
+		commitID := l.commitIDs[0]
+		l.commitIDs = l.commitIDs[1:]
+		// The held objects are computed once per session and include the objects of the commits already sent.
+		resp, err := l.service.PullObjects(l.ctx, &pap.PullObjectsRequest{
+			ZoneID:        l.zoneID,
+			LedgerID:      l.ledgerID,
+			CommitID:      commitID,
+			HaveCommitIDs: l.haveCommitIDs,
+		}, l.heldObjects)
+		if err != nil {
+			return nil, err
+		}
+		objs := resp.Objects
+		skip := min(l.skipObjects, uint64(len(objs)))
+		objs = objs[skip:]
+		l.skipObjects -= skip
+		chunks, err := aznotppackets.ChunkPacketables(notppackets.NewObjectStatePackets(objs), l.maxPacketSize)
+		if err != nil {
+			return nil, err
+		}
+		l.chunks = append(l.chunks, chunks...)
+	}
+	if len(l.chunks) == 0 {
+		return nil, nil
+	}
+	chunk := l.chunks[0]
+	l.chunks = l.chunks[1:]
+	return chunk, nil
+}
+
+// readRemoteRefPacket reads the remote ref state packet carried by a state packet.
+func readRemoteRefPacket(packetables []aznotppackets.Packetable) (*notppackets.RemoteRefStatePacket, error) {
+	if len(packetables) == 0 {
+		return nil, errors.New("notp: missing remote ref state packet")
+	}
+	remoteRef := &notppackets.RemoteRefStatePacket{}
+	if err := aznotppackets.ConvertPacketable(packetables[0], remoteRef); err != nil {
+		return nil, err
+	}
+	return remoteRef, nil
+}
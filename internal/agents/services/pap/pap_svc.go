@@ -89,9 +89,26 @@ func (f *Service) Endpoints() ([]services.EndpointInitializer, error) {
 
 // Jobs returns the service background jobs.
 func (f *Service) Jobs() ([]services.JobInitializer, error) {
-	if !f.config.TxCleanupEnabled() {
-		return nil, nil
+	jobs := []services.JobInitializer{}
+	if f.config.TxCleanupEnabled() {
+		job, err := f.txCleanupJob()
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
 	}
+	if f.config.PurgeEnabled() {
+		job, err := f.purgeJob()
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// txCleanupJob returns the job cleaning up the stale transactions.
+func (f *Service) txCleanupJob() (services.JobInitializer, error) {
 	interval := f.config.TxCleanupInterval()
 	maxLifetime := f.config.TxMaxLifetime()
 	job, err := services.NewJobInitializer(
@@ -135,9 +152,53 @@ func (f *Service) Jobs() ([]services.JobInitializer, error) {
 		},
 	)
 	if err != nil {
-		return nil, err
+		return services.JobInitializer{}, err
 	}
-	return []services.JobInitializer{job}, nil
+	return job, nil
+}
+
+// purgeJob returns the job permanently deleting the ledgers deleted for longer than the retention.
+func (f *Service) purgeJob() (services.JobInitializer, error) {
+	interval := f.config.PurgeInterval()
+	retention := f.config.DeletedRetention()
+	return services.NewJobInitializer(
+		f.config.Service(),
+		"deleted-ledgers-purge",
+		func(ctx context.Context, srvCtx *services.ServiceContext, storageConnector *storage.Connector) error {
+			logger := srvCtx.Logger()
+			storageKind := f.config.StorageCentralEngine()
+			centralStorage, err := storageConnector.CentralStorage(storageKind, srvCtx)
+			if err != nil {
+				return err
+			}
+			papStorage, err := centralStorage.PAPCentralStorage()
+			if err != nil {
+				return err
+			}
+			runPurge := func() {
+				purged, err := papStorage.PurgeDeletedLedgers(ctx, retention)
+				if err != nil {
+					logger.Error("Deleted ledgers purge failed", zap.Error(err))
+					return
+				}
+				if purged > 0 {
+					logger.Info("Deleted ledgers purge completed", zap.Int64("purged", purged))
+				}
+			}
+			// Run immediately on startup.
+			runPurge()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					runPurge()
+				}
+			}
+		},
+	)
 }
 
 // ServiceConfigReader returns the service configuration reader.
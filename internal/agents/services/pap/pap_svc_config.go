@@ -40,6 +40,9 @@ const (
 	flagTxCleanupEnabled      = "tx-cleanup-enabled"
 	flagTxCleanupInterval     = "tx-cleanup-interval"
 	flagTxMaxLifetime         = "tx-max-lifetime"
+	flagPurgeEnabled          = "purge-enabled"
+	flagPurgeInterval         = "purge-interval"
+	flagDeletedRetention      = "deleted-retention"
 )
 
 // ServiceConfig holds the configuration for the server.
@@ -53,6 +56,9 @@ type ServiceConfig struct {
 	txCleanupEnabled     bool
 	txCleanupInterval    time.Duration
 	txMaxLifetime        time.Duration
+	purgeEnabled         bool
+	purgeInterval        time.Duration
+	deletedRetention     time.Duration
 }
 
 // NewServiceConfig creates a new server factory configuration.
@@ -72,6 +78,9 @@ func (c *ServiceConfig) AddFlags(flagSet *flag.FlagSet) error {
 	flagSet.Bool(options.FlagName(flagServerPAPPrefix, flagTxCleanupEnabled), true, "enable background cleanup of stale transactions")
 	flagSet.Duration(options.FlagName(flagServerPAPPrefix, flagTxCleanupInterval), 5*time.Minute, "how often the transaction cleanup job runs")
 	flagSet.Duration(options.FlagName(flagServerPAPPrefix, flagTxMaxLifetime), 5*time.Minute, "maximum lifetime for a pending transaction before cleanup")
+	flagSet.Bool(options.FlagName(flagServerPAPPrefix, flagPurgeEnabled), true, "enable background purge of the deleted ledgers past the retention")
+	flagSet.Duration(options.FlagName(flagServerPAPPrefix, flagPurgeInterval), time.Hour, "how often the deleted ledgers purge job runs")
+	flagSet.Duration(options.FlagName(flagServerPAPPrefix, flagDeletedRetention), 30*24*time.Hour, "how long a deleted ledger can be restored before it is purged")
 	return nil
 }
 
@@ -123,6 +132,19 @@ func (c *ServiceConfig) InitFromViper(v *viper.Viper) error {
 	c.config[flagTxCleanupEnabled] = c.txCleanupEnabled
 	c.config[flagTxCleanupInterval] = c.txCleanupInterval
 	c.config[flagTxMaxLifetime] = c.txMaxLifetime
+	// retrieve the deleted ledgers purge settings
+	c.purgeEnabled = v.GetBool(options.FlagName(flagServerPAPPrefix, flagPurgeEnabled))
+	c.purgeInterval = v.GetDuration(options.FlagName(flagServerPAPPrefix, flagPurgeInterval))
+	if c.purgeEnabled && c.purgeInterval <= 0 {
+		return errors.New("pap-service: invalid purge interval")
+	}
+	c.deletedRetention = v.GetDuration(options.FlagName(flagServerPAPPrefix, flagDeletedRetention))
+	if c.purgeEnabled && c.deletedRetention <= 0 {
+		return errors.New("pap-service: invalid deleted retention")
+	}
+	c.config[flagPurgeEnabled] = c.purgeEnabled
+	c.config[flagPurgeInterval] = c.purgeInterval
+	c.config[flagDeletedRetention] = c.deletedRetention
 	return nil
 }
 
@@ -166,6 +188,21 @@ func (c *ServiceConfig) TxMaxLifetime() time.Duration {
 	return c.txMaxLifetime
 }
 
+// PurgeEnabled returns whether the purge of the deleted ledgers is enabled.
+func (c *ServiceConfig) PurgeEnabled() bool {
+	return c.purgeEnabled
+}
+
+// PurgeInterval returns how often the deleted ledgers purge job runs.
+func (c *ServiceConfig) PurgeInterval() time.Duration {
+	return c.purgeInterval
+}
+
+// DeletedRetention returns how long a deleted ledger can be restored before it is purged.
+func (c *ServiceConfig) DeletedRetention() time.Duration {
+	return c.deletedRetention
+}
+
 // Service returns the service kind.
 func (c *ServiceConfig) Service() services.ServiceKind {
 	return c.service
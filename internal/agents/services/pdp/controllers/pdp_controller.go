@@ -65,7 +65,18 @@ func NewPDPController(serviceContext *services.ServiceContext, storage storage.P
 }
 
 // AuthorizationCheck checks if the request is authorized.
-func (s PDPController) AuthorizationCheck(ctx context.Context, request *pdp.AuthorizationCheckWithDefaultsRequest) (_ *pdp.AuthorizationCheckResponse, retErr error) {
+func (s PDPController) AuthorizationCheck(ctx context.Context, request *pdp.AuthorizationCheckWithDefaultsRequest) (*pdp.AuthorizationCheckResponse, error) {
+	return s.authorizationCheck(ctx, request, nil)
+}
+
+// AuthorizationCheckStream checks if the request is authorized emitting the evaluations in order as soon as they are ready.
+// Request errors are reported in the returned response, in which case no evaluation is emitted.
+func (s PDPController) AuthorizationCheckStream(ctx context.Context, request *pdp.AuthorizationCheckWithDefaultsRequest, emit func(index int, evaluation *pdp.EvaluationResponse) error) (*pdp.AuthorizationCheckResponse, error) {
+	return s.authorizationCheck(ctx, request, emit)
+}
+
+// authorizationCheck checks if the request is authorized, emitting the evaluations in order as soon as they are ready if emit is set.
+func (s PDPController) authorizationCheck(ctx context.Context, request *pdp.AuthorizationCheckWithDefaultsRequest, emit func(index int, evaluation *pdp.EvaluationResponse) error) (_ *pdp.AuthorizationCheckResponse, retErr error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "pdp.AuthorizationCheck")
 	defer span.End()
 	start := time.Now()
@@ -91,152 +102,107 @@ func (s PDPController) AuthorizationCheck(ctx context.Context, request *pdp.Auth
 		errMsg := fmt.Sprintf("%s: missing policy store in authorization model", authzen.AuthzErrBadRequestMessage)
 		return pdp.NewAuthorizationCheckErrorResponse(nil, requestID, authzen.AuthzErrBadRequestCode, errMsg, authzen.AuthzErrBadRequestMessage), nil
 	}
-	expReq := authorizationCheckExpandAuthorizationCheckWithDefaults(request)
-	type evalItem struct {
-		listID int
-		value  *pdp.EvaluationResponse
+	semantic := pdp.EvaluationsSemanticExecuteAll
+	if request.Options != nil && len(request.Options.EvaluationsSemantic) > 0 {
+		semantic = request.Options.EvaluationsSemantic
+	}
+	if !pdp.IsValidEvaluationsSemantic(semantic) {
+		errMsg := fmt.Sprintf("%s: invalid evaluations semantic %s", authzen.AuthzErrBadRequestMessage, semantic)
+		return pdp.NewAuthorizationCheckErrorResponse(nil, requestID, authzen.AuthzErrBadRequestCode, errMsg, authzen.AuthzErrBadRequestMessage), nil
+	}
+	maxEvaluations, err := runtime.GetTypedValue[int](cfgReader.Value, "max-evaluations")
+	if err != nil {
+		return nil, errors.Join(errors.New("pdp-service: failed to get max evaluations configuration"), err)
+	}
+	evaluationWorkers, err := runtime.GetTypedValue[int](cfgReader.Value, "evaluation-workers")
+	if err != nil {
+		return nil, errors.Join(errors.New("pdp-service: failed to get evaluation workers configuration"), err)
+	}
+	if len(request.Evaluations) > maxEvaluations {
+		errMsg := fmt.Sprintf("%s: too many evaluations, the maximum is %d", authzen.AuthzErrBadRequestMessage, maxEvaluations)
+		return pdp.NewAuthorizationCheckErrorResponse(nil, requestID, authzen.AuthzErrBadRequestCode, errMsg, authzen.AuthzErrBadRequestMessage), nil
 	}
-	evalItems := []evalItem{}
-	reqEvaluations := []pdp.EvaluationRequest{}
-	reqEvaluationsCounter := 0
-	for _, evaluation := range expReq.Evaluations {
+	expReq := authorizationCheckExpandAuthorizationCheckWithDefaults(request)
+	invalidEvaluations := make([]*pdp.EvaluationResponse, len(expReq.Evaluations))
+	reqEvaluationsSize := 0
+	for i, evaluation := range expReq.Evaluations {
 		input := buildEvaluationInput(request.AuthorizationModel, &evaluation)
 		if errResp := validateEvaluation(evaluation.RequestID, input); errResp != nil {
-			evalItems = append(evalItems, evalItem{listID: -1, value: errResp})
+			invalidEvaluations[i] = errResp
 			continue
 		}
-		evalItems = append(evalItems, evalItem{listID: reqEvaluationsCounter, value: nil})
-		reqEvaluationsCounter++
-		reqEvaluations = append(reqEvaluations, evaluation)
+		reqEvaluationsSize++
 	}
-	reqEvaluationsSize := len(reqEvaluations)
-	expReq.Evaluations = reqEvaluations
-	authzCheckEvaluations := []pdp.EvaluationResponse{}
+	var authzPolicyStore *authzen.PolicyStore
+	var cedarLanguageAbs *cedar.LanguageAbstraction
+	authzModel := expReq.AuthorizationModel
 	if reqEvaluationsSize > 0 {
-		authzModel := expReq.AuthorizationModel
 		loadCtx, loadSpan := telemetry.Tracer().Start(ctx, "pdp.LoadPolicyStore",
 			trace.WithAttributes(
 				attribute.Int64("zone_id", authzModel.ZoneID),
 				attribute.String("policy_store_id", authzModel.PolicyStore.ID)))
-		authzPolicyStore, err2 := s.storage.LoadPolicyStore(loadCtx, authzModel.ZoneID, authzModel.PolicyStore.ID)
+		authzPolicyStore, err = s.storage.LoadPolicyStore(loadCtx, authzModel.ZoneID, authzModel.PolicyStore.ID)
 		loadSpan.End()
-		telemetry.AuthzPolicyLoadTotal.Add(ctx, 1, telemetry.StatusAttr(telemetry.StatusFromErr(err2)))
-		if err2 != nil {
+		telemetry.AuthzPolicyLoadTotal.Add(ctx, 1, telemetry.StatusAttr(telemetry.StatusFromErr(err)))
+		if err != nil {
 			if logger := s.ctx.Logger(); logger != nil {
 				logger.Error("Failed to load policy store for authorization check",
 					zap.Int64("zone_id", authzModel.ZoneID),
 					zap.String("policy_store_id", authzModel.PolicyStore.ID),
 					zap.String("request_id", requestID),
-					zap.Error(err2))
+					zap.Error(err))
 			}
 			errMsg := fmt.Sprintf("%s: authorization check has failed", authzen.AuthzErrInternalErrorMessage)
 			return pdp.NewAuthorizationCheckErrorResponse(nil, requestID, authzen.AuthzErrInternalErrorCode, errMsg, authzen.AuthzErrInternalErrorMessage), nil
 		}
-		cedarLanguageAbs, err2 := cedar.NewCedarLanguageAbstraction()
-		if err2 != nil {
+		cedarLanguageAbs, err = cedar.NewCedarLanguageAbstraction()
+		if err != nil {
 			if logger := s.ctx.Logger(); logger != nil {
 				logger.Error("Failed to create Cedar language abstraction",
 					zap.Int64("zone_id", authzModel.ZoneID),
 					zap.String("policy_store_id", authzModel.PolicyStore.ID),
 					zap.String("request_id", requestID),
-					zap.Error(err2))
+					zap.Error(err))
 			}
 			errMsg := fmt.Sprintf("%s: authorization check has failed", authzen.AuthzErrInternalErrorMessage)
 			return pdp.NewAuthorizationCheckErrorResponse(nil, requestID, authzen.AuthzErrInternalErrorCode, errMsg, authzen.AuthzErrInternalErrorMessage), nil
 		}
 		telemetry.AuthzEvaluationsCount.Record(ctx, int64(reqEvaluationsSize))
-		_, evalSpan := telemetry.Tracer().Start(ctx, "pdp.PolicyEvaluations",
-			trace.WithAttributes(attribute.Int("evaluations_count", reqEvaluationsSize)))
-		authzCheckEvaluations = []pdp.EvaluationResponse{}
-		for _, expandedRequest := range expReq.Evaluations {
-			authzCtx := authzen.AuthorizationModel{}
-			if err := authzCtx.SetSubject(expandedRequest.Subject.Type, expandedRequest.Subject.ID, expandedRequest.Subject.Source, expandedRequest.Subject.Properties); err != nil {
-				if logger := s.ctx.Logger(); logger != nil {
-					logger.Error("Failed to set authorization subject",
-						zap.String("request_id", requestID),
-						zap.Int64("zone_id", authzModel.ZoneID),
-						zap.Error(err))
-				}
-				return nil, err
-			}
-			if err := authzCtx.SetResource(expandedRequest.Resource.Type, expandedRequest.Resource.ID, expandedRequest.Resource.Properties); err != nil {
-				if logger := s.ctx.Logger(); logger != nil {
-					logger.Error("Failed to set authorization resource",
-						zap.String("request_id", requestID),
-						zap.Int64("zone_id", authzModel.ZoneID),
-						zap.Error(err))
-				}
-				return nil, err
-			}
-			if err := authzCtx.SetAction(expandedRequest.Action.Name, expandedRequest.Action.Properties); err != nil {
-				if logger := s.ctx.Logger(); logger != nil {
-					logger.Error("Failed to set authorization action",
-						zap.String("request_id", requestID),
-						zap.Int64("zone_id", authzModel.ZoneID),
-						zap.Error(err))
-				}
-				return nil, err
-			}
-			if err := authzCtx.SetContext(expandedRequest.Context); err != nil {
-				if logger := s.ctx.Logger(); logger != nil {
-					logger.Error("Failed to set authorization context",
-						zap.String("request_id", requestID),
-						zap.Int64("zone_id", authzModel.ZoneID),
-						zap.Error(err))
-				}
-				return nil, err
-			}
-			entities := expReq.AuthorizationModel.Entities
-			if entities != nil {
-				if err := authzCtx.SetEntities(entities.Schema, entities.Items); err != nil {
-					if logger := s.ctx.Logger(); logger != nil {
-						logger.Error("Failed to set authorization entities",
-							zap.String("request_id", requestID),
-							zap.Int64("zone_id", authzModel.ZoneID),
-							zap.Error(err))
-					}
-					return nil, err
-				}
-			}
-			contextID := expandedRequest.ContextID
-			// TODO: Fix manifest refactoring
-			authzResponse, err2 := cedarLanguageAbs.AuthorizationCheck(nil, contextID, authzPolicyStore, &authzCtx)
-			if err2 != nil {
-				evaluation := pdp.NewEvaluationErrorResponse(expandedRequest.RequestID, authzen.AuthzErrInternalErrorCode, err2.Error(), authzen.AuthzErrInternalErrorMessage)
-				authzCheckEvaluations = append(authzCheckEvaluations, *evaluation)
-				continue
-			}
-			if authzResponse == nil {
-				evaluation := pdp.NewEvaluationErrorResponse(expandedRequest.RequestID, authzen.AuthzErrInternalErrorCode, "because of a nil authz response", authzen.AuthzErrInternalErrorMessage)
-				authzCheckEvaluations = append(authzCheckEvaluations, *evaluation)
-				continue
-			}
-			evaluation := &pdp.EvaluationResponse{
-				RequestID: expandedRequest.RequestID,
-				Decision:  authzResponse.Decision(),
-				Context:   authorizationCheckBuildContextResponse(authzResponse),
-			}
-			authzCheckEvaluations = append(authzCheckEvaluations, *evaluation)
-		}
-		evalSpan.End()
-		if len(authzCheckEvaluations) != reqEvaluationsSize {
-			errMsg := fmt.Sprintf("%s: invalid authorization check response size for evaluations", authzen.AuthzErrInternalErrorMessage)
-			return pdp.NewAuthorizationCheckErrorResponse(nil, requestID, authzen.AuthzErrBadRequestCode, errMsg, authzen.AuthzErrBadRequestMessage), nil
-		}
 	}
-	evaluations := []pdp.EvaluationResponse{}
-	for i := range len(evalItems) {
-		evalItem := evalItems[i]
-		if evalItem.listID == -1 {
-			evaluations = append(evaluations, *evalItems[i].value)
-		} else {
-			evaluations = append(evaluations, authzCheckEvaluations[evalItem.listID])
+	_, evalSpan := telemetry.Tracer().Start(ctx, "pdp.PolicyEvaluations",
+		trace.WithAttributes(
+			attribute.Int("evaluations_count", reqEvaluationsSize),
+			attribute.Int("evaluation_workers", evaluationWorkers),
+			attribute.String("evaluations_semantic", semantic)))
+	evaluations, err := runEvaluations(ctx, evaluationWorkers, semantic, len(expReq.Evaluations), func(index int) *pdp.EvaluationResponse {
+		if invalidEvaluations[index] != nil {
+			return invalidEvaluations[index]
+		}
+		expandedRequest := &expReq.Evaluations[index]
+		evaluation, err := authorizationCheckEvaluate(cedarLanguageAbs, authzPolicyStore, authzModel.Entities, expandedRequest)
+		if err != nil {
+			if logger := s.ctx.Logger(); logger != nil {
+				logger.Error("Failed to build the authorization model",
+					zap.String("request_id", expandedRequest.RequestID),
+					zap.Int64("zone_id", authzModel.ZoneID),
+					zap.Error(err))
+			}
+			errMsg := fmt.Sprintf("%s: %s", authzen.AuthzErrBadRequestMessage, err.Error())
+			return pdp.NewEvaluationErrorResponse(expandedRequest.RequestID, authzen.AuthzErrBadRequestCode, errMsg, authzen.AuthzErrBadRequestMessage)
 		}
+		return evaluation
+	}, emit)
+	evalSpan.End()
+	if err != nil {
+		return nil, errors.Join(errors.New("pdp-service: authorization check evaluations have been interrupted"), err)
 	}
 	authzCheckResp := &pdp.AuthorizationCheckResponse{
 		RequestID:   request.RequestID,
 		Evaluations: evaluations,
 	}
+	if authzPolicyStore != nil {
+		authzCheckResp.PolicyStoreVersion = authzPolicyStore.Version()
+	}
 	if len(authzCheckResp.Evaluations) == 1 {
 		firstEval := authzCheckResp.Evaluations[0]
 		authzCheckResp.RequestID = firstEval.RequestID
@@ -244,14 +210,7 @@ func (s PDPController) AuthorizationCheck(ctx context.Context, request *pdp.Auth
 		authzCheckResp.Context = firstEval.Context
 	}
 	if len(authzCheckResp.Evaluations) > 0 {
-		allTrue := true
-		for _, evaluation := range authzCheckResp.Evaluations {
-			if !evaluation.Decision {
-				allTrue = false
-				break
-			}
-		}
-		authzCheckResp.Decision = allTrue
+		authzCheckResp.Decision = evaluationsDecision(semantic, authzCheckResp.Evaluations)
 	}
 	decision := "deny"
 	if authzCheckResp.Decision {
@@ -299,19 +258,11 @@ func (s PDPController) AuthorizationCheck(ctx context.Context, request *pdp.Auth
 	return authzCheckResp, nil
 }
 
-// buildDecisionLogs builds the decision logs.
-func (s PDPController) buildDecisionLogs(req *pdp.AuthorizationCheckRequest, resp *pdp.AuthorizationCheckResponse) []map[string]any {
-	decisionLogs := make([]map[string]any, len(req.Evaluations))
-	for i := range req.Evaluations {
-		reqVal := req.Evaluations[i]
-		respVal := resp.Evaluations[i]
-		decisionMap := map[string]any{}
-		requestMap := map[string]any{}
-		requestMap["authorization_model"] = req.AuthorizationModel
-		requestMap["evaluation"] = reqVal
-		decisionMap["request"] = requestMap
-		decisionMap["response"] = respVal
-		decisionLogs[i] = decisionMap
+// buildDecisionLogs builds the decision logs of the evaluations in the response, which may have been short-circuited.
+func (s PDPController) buildDecisionLogs(req *pdp.AuthorizationCheckRequest, resp *pdp.AuthorizationCheckResponse) []*decisions.DecisionLogEntry {
+	decisionLogs := make([]*decisions.DecisionLogEntry, len(resp.Evaluations))
+	for i := range resp.Evaluations {
+		decisionLogs[i] = decisions.NewDecisionLogEntry(req.AuthorizationModel, &req.Evaluations[i], &resp.Evaluations[i])
 	}
 	return decisionLogs
 }
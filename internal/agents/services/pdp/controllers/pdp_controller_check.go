@@ -17,8 +17,12 @@
 package controllers
 
 import (
+	"errors"
+
 	"github.com/permguard/permguard/common/pkg/extensions/ids"
+	"github.com/permguard/permguard/pkg/authz/languages"
 	"github.com/permguard/permguard/pkg/transport/models/pdp"
+	"github.com/permguard/permguard/plugin/languages/cedar"
 	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
 )
 
@@ -26,6 +30,7 @@ import (
 func authorizationCheckExpandAuthorizationCheckWithDefaults(request *pdp.AuthorizationCheckWithDefaultsRequest) *pdp.AuthorizationCheckRequest {
 	expReq := &pdp.AuthorizationCheckRequest{}
 	expReq.AuthorizationModel = request.AuthorizationModel
+	expReq.Options = request.Options
 
 	if len(request.Evaluations) == 0 {
 		expRequest := pdp.EvaluationRequest{
@@ -110,3 +115,43 @@ func authorizationCheckBuildContextResponse(authzDecision *authzen.Authorization
 	}
 	return ctxResponse
 }
+
+// authorizationCheckEvaluate evaluates an expanded request against the policy store.
+// Errors building the authorization model are returned, evaluation errors are reported in the response.
+func authorizationCheckEvaluate(cedarLanguageAbs *cedar.LanguageAbstraction, authzPolicyStore *authzen.PolicyStore, entities *pdp.Entities, expandedRequest *pdp.EvaluationRequest) (*pdp.EvaluationResponse, error) {
+	authzCtx := authzen.AuthorizationModel{}
+	if err := authzCtx.SetSubject(expandedRequest.Subject.Type, expandedRequest.Subject.ID, expandedRequest.Subject.Source, expandedRequest.Subject.Properties); err != nil {
+		return nil, err
+	}
+	if err := authzCtx.SetResource(expandedRequest.Resource.Type, expandedRequest.Resource.ID, expandedRequest.Resource.Properties); err != nil {
+		return nil, err
+	}
+	if err := authzCtx.SetAction(expandedRequest.Action.Name, expandedRequest.Action.Properties); err != nil {
+		return nil, err
+	}
+	if err := authzCtx.SetContext(expandedRequest.Context); err != nil {
+		return nil, err
+	}
+	if entities != nil {
+		if err := authzCtx.SetEntities(entities.Schema, entities.Items); err != nil {
+			return nil, err
+		}
+	}
+	contextID := expandedRequest.ContextID
+	// TODO: Fix manifest refactoring
+	authzResponse, err := cedarLanguageAbs.AuthorizationCheck(nil, contextID, authzPolicyStore, &authzCtx)
+	if errors.Is(err, languages.ErrSchemaViolation) {
+		return pdp.NewEvaluationErrorResponse(expandedRequest.RequestID, authzen.AuthzErrBadRequestCode, err.Error(), authzen.AuthzErrBadRequestMessage), nil
+	}
+	if err != nil {
+		return pdp.NewEvaluationErrorResponse(expandedRequest.RequestID, authzen.AuthzErrInternalErrorCode, err.Error(), authzen.AuthzErrInternalErrorMessage), nil
+	}
+	if authzResponse == nil {
+		return pdp.NewEvaluationErrorResponse(expandedRequest.RequestID, authzen.AuthzErrInternalErrorCode, "because of a nil authz response", authzen.AuthzErrInternalErrorMessage), nil
+	}
+	return &pdp.EvaluationResponse{
+		RequestID: expandedRequest.RequestID,
+		Decision:  authzResponse.Decision(),
+		Context:   authorizationCheckBuildContextResponse(authzResponse),
+	}, nil
+}
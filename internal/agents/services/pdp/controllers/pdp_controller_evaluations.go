@@ -0,0 +1,117 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// evaluationsShortCircuit returns true if the decision stops the remaining evaluations as per the semantic.
+func evaluationsShortCircuit(semantic string, decision bool) bool {
+	switch semantic {
+	case pdp.EvaluationsSemanticDenyOnFirstDeny:
+		return !decision
+	case pdp.EvaluationsSemanticPermitOnFirstPermit:
+		return decision
+	}
+	return false
+}
+
+// evaluationsDecision returns the overall decision of the evaluations as per the semantic.
+func evaluationsDecision(semantic string, evaluations []pdp.EvaluationResponse) bool {
+	if semantic == pdp.EvaluationsSemanticPermitOnFirstPermit {
+		for _, evaluation := range evaluations {
+			if evaluation.Decision {
+				return true
+			}
+		}
+		return false
+	}
+	for _, evaluation := range evaluations {
+		if !evaluation.Decision {
+			return false
+		}
+	}
+	return true
+}
+
+// runEvaluations runs the evaluations of a batch on a bounded pool of workers.
+// Evaluations are dispatched in order, so that with a short-circuit semantic the result ends with the first stopping
+// evaluation and the following ones are skipped. When set, emit receives the evaluations in order as soon as all the
+// previous ones are ready, up to the stopping one.
+func runEvaluations(ctx context.Context, workers int, semantic string, size int, evaluate func(index int) *pdp.EvaluationResponse, emit func(index int, evaluation *pdp.EvaluationResponse) error) ([]pdp.EvaluationResponse, error) {
+	workers = max(min(workers, size), 1)
+	results := make([]*pdp.EvaluationResponse, size)
+	var next atomic.Int64
+	var stopAt atomic.Int64
+	stopAt.Store(int64(size))
+	var emitMtx sync.Mutex
+	var emitErr error
+	emitted := 0
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				index := next.Add(1) - 1
+				if index >= int64(size) || index > stopAt.Load() || ctx.Err() != nil {
+					return
+				}
+				evaluation := evaluate(int(index))
+				if evaluationsShortCircuit(semantic, evaluation.Decision) {
+					for {
+						current := stopAt.Load()
+						if index >= current || stopAt.CompareAndSwap(current, index) {
+							break
+						}
+					}
+				}
+				emitMtx.Lock()
+				results[index] = evaluation
+				// A stopping evaluation lowers the stop index before it is stored, so the following ones are never emitted.
+				for emit != nil && emitErr == nil && emitted < size && int64(emitted) <= stopAt.Load() && results[emitted] != nil {
+					emitErr = emit(emitted, results[emitted])
+					emitted++
+				}
+				failed := emitErr != nil
+				emitMtx.Unlock()
+				if failed {
+					stopAt.Store(-1)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if emitErr != nil {
+		return nil, emitErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	end := min(stopAt.Load()+1, int64(size))
+	evaluations := make([]pdp.EvaluationResponse, 0, end)
+	for _, evaluation := range results[:end] {
+		evaluations = append(evaluations, *evaluation)
+	}
+	return evaluations, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// TestRunEvaluations tests the evaluations of a batch on a bounded pool of workers.
+func TestRunEvaluations(t *testing.T) {
+	decisions := []bool{true, true, false, true, false, true, true, true}
+	evaluate := func(index int) *pdp.EvaluationResponse {
+		// The first evaluations are the slowest, so that the following ones are ready before them.
+		time.Sleep(time.Duration(len(decisions)-index) * time.Millisecond)
+		return &pdp.EvaluationResponse{RequestID: fmt.Sprintf("req-%d", index), Decision: decisions[index]}
+	}
+	tests := []struct {
+		semantic string
+		workers  int
+		size     int
+		decision bool
+	}{
+		{pdp.EvaluationsSemanticExecuteAll, 1, 8, false},
+		{pdp.EvaluationsSemanticExecuteAll, 4, 8, false},
+		{pdp.EvaluationsSemanticExecuteAll, 64, 8, false},
+		{pdp.EvaluationsSemanticDenyOnFirstDeny, 1, 3, false},
+		{pdp.EvaluationsSemanticDenyOnFirstDeny, 4, 3, false},
+		{pdp.EvaluationsSemanticDenyOnFirstDeny, 64, 3, false},
+		{pdp.EvaluationsSemanticPermitOnFirstPermit, 1, 1, true},
+		{pdp.EvaluationsSemanticPermitOnFirstPermit, 8, 1, true},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("%s-%d", test.semantic, test.workers)
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			var mtx sync.Mutex
+			emitted := []int{}
+			evaluations, err := runEvaluations(context.Background(), test.workers, test.semantic, len(decisions), evaluate, func(index int, evaluation *pdp.EvaluationResponse) error {
+				mtx.Lock()
+				defer mtx.Unlock()
+				assert.Equal(fmt.Sprintf("req-%d", index), evaluation.RequestID, "emitted evaluation mismatch")
+				emitted = append(emitted, index)
+				return nil
+			})
+			require.NoError(t, err, "runEvaluations should not return an error")
+			require.Len(t, evaluations, test.size, "evaluations size mismatch")
+			expected := make([]int, 0, test.size)
+			for i, evaluation := range evaluations {
+				assert.Equal(fmt.Sprintf("req-%d", i), evaluation.RequestID, "evaluations should be in request order")
+				expected = append(expected, i)
+			}
+			assert.Equal(expected, emitted, "evaluations should be emitted in order up to the stopping one")
+			assert.Equal(test.decision, evaluationsDecision(test.semantic, evaluations), "decision mismatch")
+		})
+	}
+}
+
+// TestRunEvaluationsEmitError tests that an emit error stops the evaluations.
+func TestRunEvaluationsEmitError(t *testing.T) {
+	evaluate := func(index int) *pdp.EvaluationResponse {
+		return &pdp.EvaluationResponse{Decision: true}
+	}
+	errEmit := errors.New("stream closed")
+	evaluations, err := runEvaluations(context.Background(), 4, pdp.EvaluationsSemanticExecuteAll, 100, evaluate, func(index int, evaluation *pdp.EvaluationResponse) error {
+		return errEmit
+	})
+	assert.ErrorIs(t, err, errEmit, "runEvaluations should return the emit error")
+	assert.Nil(t, evaluations, "evaluations should be nil")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = runEvaluations(ctx, 4, pdp.EvaluationsSemanticExecuteAll, 100, evaluate, nil)
+	assert.ErrorIs(t, err, context.Canceled, "runEvaluations should return the context error")
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+	"github.com/permguard/permguard/plugin/languages/cedar"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+)
+
+// AuthorizationPartialEvaluation evaluates the authorization with an unknown resource returning the residual condition.
+func (s PDPController) AuthorizationPartialEvaluation(ctx context.Context, request *pdp.AuthorizationPartialEvaluationRequest) (*pdp.AuthorizationPartialEvaluationResponse, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "pdp.AuthorizationPartialEvaluation")
+	defer span.End()
+	if request == nil {
+		return nil, fmt.Errorf("pdp-service: received nil partial evaluation request: %w", azstorage.ErrInvalidInput)
+	}
+	if request.AuthorizationModel == nil {
+		return nil, fmt.Errorf("pdp-service: missing authorization model in request: %w", azstorage.ErrInvalidInput)
+	}
+	evaluation := &pdp.EvaluationRequest{
+		RequestID: request.RequestID,
+		Subject:   request.Subject,
+		Resource:  request.Resource,
+		Action:    request.Action,
+		Context:   request.Context,
+	}
+	input := buildEvaluationInput(request.AuthorizationModel, evaluation)
+	input.UnknownResource = true
+	if errResp := validateEvaluation(request.RequestID, input); errResp != nil {
+		return nil, fmt.Errorf("pdp-service: %s: %w", errResp.Context.ReasonAdmin.Message, azstorage.ErrInvalidInput)
+	}
+	authzModel := request.AuthorizationModel
+	span.SetAttributes(
+		attribute.Int64("zone_id", authzModel.ZoneID),
+		attribute.String("policy_store_id", authzModel.PolicyStore.ID),
+		attribute.Int("unknown_context_count", len(request.UnknownContext)))
+	authzPolicyStore, err := s.storage.LoadPolicyStore(ctx, authzModel.ZoneID, authzModel.PolicyStore.ID)
+	if err != nil {
+		if logger := s.ctx.Logger(); logger != nil {
+			logger.Error("Failed to load policy store for authorization partial evaluation",
+				zap.Int64("zone_id", authzModel.ZoneID),
+				zap.String("policy_store_id", authzModel.PolicyStore.ID),
+				zap.String("request_id", request.RequestID),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+	cedarLanguageAbs, err := cedar.NewCedarLanguageAbstraction()
+	if err != nil {
+		return nil, errors.Join(errors.New("pdp-service: failed to create the cedar language abstraction"), err)
+	}
+	authzCtx := authzen.AuthorizationModel{}
+	if err := authzCtx.SetSubject(request.Subject.Type, request.Subject.ID, request.Subject.Source, request.Subject.Properties); err != nil {
+		return nil, err
+	}
+	if request.Resource != nil {
+		if err := authzCtx.SetResource(request.Resource.Type, "", nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := authzCtx.SetAction(request.Action.Name, request.Action.Properties); err != nil {
+		return nil, err
+	}
+	reqContext := request.Context
+	if reqContext == nil {
+		reqContext = map[string]any{}
+	}
+	if err := authzCtx.SetContext(reqContext); err != nil {
+		return nil, err
+	}
+	if entities := authzModel.Entities; entities != nil {
+		if err := authzCtx.SetEntities(entities.Schema, entities.Items); err != nil {
+			return nil, err
+		}
+	}
+	// TODO: Fix manifest refactoring
+	result, err := cedarLanguageAbs.PartialEvaluation(nil, authzPolicyStore, &authzCtx, request.UnknownContext)
+	if err != nil {
+		return nil, fmt.Errorf("pdp-service: the partial evaluation has failed: %w", errors.Join(azstorage.ErrInvalidInput, err))
+	}
+	span.SetAttributes(attribute.String("decision", result.Decision))
+	return &pdp.AuthorizationPartialEvaluationResponse{
+		RequestID: request.RequestID,
+		Decision:  result.Decision,
+		Residual:  result.Residual,
+		Policies:  result.Policies,
+	}, nil
+}
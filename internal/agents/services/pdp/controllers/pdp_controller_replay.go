@@ -0,0 +1,123 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/permguard/permguard/common/pkg/extensions/ids"
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+	"github.com/permguard/permguard/plugin/languages/cedar"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+)
+
+// AuthorizationReplay re-evaluates logged decisions against the policy store as of a historical commit.
+func (s PDPController) AuthorizationReplay(ctx context.Context, request *pdp.AuthorizationReplayRequest) (*pdp.AuthorizationReplayResponse, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "pdp.AuthorizationReplay")
+	defer span.End()
+	if request == nil {
+		return nil, fmt.Errorf("pdp-service: received nil replay request: %w", azstorage.ErrInvalidInput)
+	}
+	if request.ZoneID <= 0 {
+		return nil, fmt.Errorf("pdp-service: invalid zone id: %w", azstorage.ErrInvalidInput)
+	}
+	if request.PolicyStore == nil || len(strings.TrimSpace(request.PolicyStore.ID)) == 0 {
+		return nil, fmt.Errorf("pdp-service: invalid policy store id: %w", azstorage.ErrInvalidInput)
+	}
+	if len(strings.TrimSpace(request.CommitID)) == 0 {
+		return nil, fmt.Errorf("pdp-service: invalid commit id: %w", azstorage.ErrInvalidInput)
+	}
+	span.SetAttributes(
+		attribute.Int64("zone_id", request.ZoneID),
+		attribute.String("policy_store_id", request.PolicyStore.ID),
+		attribute.String("commit_id", request.CommitID),
+		attribute.Int("entries_count", len(request.Entries)))
+	authzPolicyStore, err := s.storage.LoadPolicyStoreAtCommit(ctx, request.ZoneID, request.PolicyStore.ID, request.CommitID)
+	if err != nil {
+		if logger := s.ctx.Logger(); logger != nil {
+			logger.Error("Failed to load policy store for authorization replay",
+				zap.Int64("zone_id", request.ZoneID),
+				zap.String("policy_store_id", request.PolicyStore.ID),
+				zap.String("commit_id", request.CommitID),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+	cedarLanguageAbs, err := cedar.NewCedarLanguageAbstraction()
+	if err != nil {
+		return nil, errors.Join(errors.New("pdp-service: failed to create the cedar language abstraction"), err)
+	}
+	response := &pdp.AuthorizationReplayResponse{
+		CommitID: request.CommitID,
+		Results:  []pdp.ReplayResult{},
+	}
+	for _, entry := range request.Entries {
+		result := authorizationReplayEntry(cedarLanguageAbs, authzPolicyStore, request, &entry)
+		if result.Diverged {
+			response.Divergences++
+		}
+		response.Results = append(response.Results, *result)
+	}
+	span.SetAttributes(attribute.Int64("divergences", response.Divergences))
+	return response, nil
+}
+
+// authorizationReplayEntry re-evaluates a single logged entry and compares it with the logged decision.
+func authorizationReplayEntry(cedarLanguageAbs *cedar.LanguageAbstraction, authzPolicyStore *authzen.PolicyStore, request *pdp.AuthorizationReplayRequest, entry *pdp.ReplayEntry) *pdp.ReplayResult {
+	result := &pdp.ReplayResult{
+		LoggedDecision: entry.Decision,
+	}
+	if entry.Evaluation == nil {
+		result.Diverged = entry.Decision
+		result.Context = pdp.NewEvaluationErrorResponse("", authzen.AuthzErrBadRequestCode, "missing evaluation in replay entry", authzen.AuthzErrBadRequestMessage).Context
+		return result
+	}
+	evaluation := *entry.Evaluation
+	result.RequestID = evaluation.RequestID
+	if evaluation.ContextID == "" {
+		evaluation.ContextID = ids.GenerateID()
+	}
+	if evaluation.Context == nil {
+		evaluation.Context = map[string]any{}
+	}
+	authzModel := &pdp.AuthorizationModelRequest{
+		ZoneID:      request.ZoneID,
+		PolicyStore: request.PolicyStore,
+		Principal:   entry.Principal,
+		Entities:    entry.Entities,
+	}
+	evalResponse := validateEvaluation(evaluation.RequestID, buildEvaluationInput(authzModel, &evaluation))
+	if evalResponse == nil {
+		var err error
+		evalResponse, err = authorizationCheckEvaluate(cedarLanguageAbs, authzPolicyStore, entry.Entities, &evaluation)
+		if err != nil {
+			evalResponse = pdp.NewEvaluationErrorResponse(evaluation.RequestID, authzen.AuthzErrBadRequestCode, err.Error(), authzen.AuthzErrBadRequestMessage)
+		}
+	}
+	result.Decision = evalResponse.Decision
+	result.Context = evalResponse.Context
+	result.Diverged = result.Decision != result.LoggedDecision
+	return result
+}
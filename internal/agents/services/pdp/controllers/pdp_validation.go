@@ -40,6 +40,8 @@ type evaluationInput struct {
 	ResourceProperties map[string]any
 	ActionName         string
 	ActionProperties   map[string]any
+	// UnknownResource marks the partial evaluations, whose resource is unknown and whose type is optional.
+	UnknownResource bool
 }
 
 // validationRule defines a single field validation check.
@@ -61,8 +63,8 @@ func (e *evaluationInput) rules() []validationRule {
 		{ok: len(strings.TrimSpace(e.SubjectID)) > 0, message: "invalid subject id"},
 		{ok: pdp.IsValidIdentityType(e.SubjectType), message: "invalid subject type"},
 		{ok: pdp.IsValidProperties(e.SubjectProperties), message: "invalid subject properties"},
-		{ok: len(strings.TrimSpace(e.ResourceID)) > 0, message: "invalid resource id"},
-		{ok: len(strings.TrimSpace(e.ResourceType)) > 0, message: "invalid resource type"},
+		{ok: e.UnknownResource || len(strings.TrimSpace(e.ResourceID)) > 0, message: "invalid resource id"},
+		{ok: e.UnknownResource || len(strings.TrimSpace(e.ResourceType)) > 0, message: "invalid resource type"},
 		{ok: pdp.IsValidProperties(e.ResourceProperties), message: "invalid resource properties"},
 		{ok: len(strings.TrimSpace(e.ActionName)) > 0, message: "invalid action name"},
 		{ok: pdp.IsValidProperties(e.ActionProperties), message: "invalid action properties"},
@@ -550,6 +550,51 @@ func (x *EvaluationRequest) GetContext() *structpb.Struct {
 	return nil
 }
 
+// EvaluationsOptions represents the options of the evaluations of the authorization check.
+type EvaluationsOptions struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	EvaluationsSemantic *string                `protobuf:"bytes,1,opt,name=EvaluationsSemantic,proto3,oneof" json:"EvaluationsSemantic,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *EvaluationsOptions) Reset() {
+	*x = EvaluationsOptions{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluationsOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationsOptions) ProtoMessage() {}
+
+func (x *EvaluationsOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationsOptions.ProtoReflect.Descriptor instead.
+func (*EvaluationsOptions) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EvaluationsOptions) GetEvaluationsSemantic() string {
+	if x != nil && x.EvaluationsSemantic != nil {
+		return *x.EvaluationsSemantic
+	}
+	return ""
+}
+
 // AuthorizationCheckRequest represents the request to perform an authorization decision.
 type AuthorizationCheckRequest struct {
 	state              protoimpl.MessageState     `protogen:"open.v1"`
@@ -560,13 +605,14 @@ type AuthorizationCheckRequest struct {
 	Action             *Action                    `protobuf:"bytes,5,opt,name=Action,proto3,oneof" json:"Action,omitempty"`
 	Context            *structpb.Struct           `protobuf:"bytes,6,opt,name=Context,proto3,oneof" json:"Context,omitempty"`
 	Evaluations        []*EvaluationRequest       `protobuf:"bytes,7,rep,name=Evaluations,proto3" json:"Evaluations,omitempty"`
+	Options            *EvaluationsOptions        `protobuf:"bytes,8,opt,name=Options,proto3,oneof" json:"Options,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
 
 func (x *AuthorizationCheckRequest) Reset() {
 	*x = AuthorizationCheckRequest{}
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[8]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +624,7 @@ func (x *AuthorizationCheckRequest) String() string {
 func (*AuthorizationCheckRequest) ProtoMessage() {}
 
 func (x *AuthorizationCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[8]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +637,7 @@ func (x *AuthorizationCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthorizationCheckRequest.ProtoReflect.Descriptor instead.
 func (*AuthorizationCheckRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{8}
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AuthorizationCheckRequest) GetAuthorizationModel() *AuthorizationModelRequest {
@@ -643,6 +689,13 @@ func (x *AuthorizationCheckRequest) GetEvaluations() []*EvaluationRequest {
 	return nil
 }
 
+func (x *AuthorizationCheckRequest) GetOptions() *EvaluationsOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 // ReasonResponse provides the rationale for the response.
 type ReasonResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -654,7 +707,7 @@ type ReasonResponse struct {
 
 func (x *ReasonResponse) Reset() {
 	*x = ReasonResponse{}
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[9]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -666,7 +719,7 @@ func (x *ReasonResponse) String() string {
 func (*ReasonResponse) ProtoMessage() {}
 
 func (x *ReasonResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[9]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,7 +732,7 @@ func (x *ReasonResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReasonResponse.ProtoReflect.Descriptor instead.
 func (*ReasonResponse) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{9}
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ReasonResponse) GetCode() string {
@@ -708,7 +761,7 @@ type ContextResponse struct {
 
 func (x *ContextResponse) Reset() {
 	*x = ContextResponse{}
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[10]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +773,7 @@ func (x *ContextResponse) String() string {
 func (*ContextResponse) ProtoMessage() {}
 
 func (x *ContextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[10]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +786,7 @@ func (x *ContextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContextResponse.ProtoReflect.Descriptor instead.
 func (*ContextResponse) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{10}
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ContextResponse) GetID() string {
@@ -769,7 +822,7 @@ type EvaluationResponse struct {
 
 func (x *EvaluationResponse) Reset() {
 	*x = EvaluationResponse{}
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[11]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -781,7 +834,7 @@ func (x *EvaluationResponse) String() string {
 func (*EvaluationResponse) ProtoMessage() {}
 
 func (x *EvaluationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[11]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +847,7 @@ func (x *EvaluationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EvaluationResponse.ProtoReflect.Descriptor instead.
 func (*EvaluationResponse) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{11}
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *EvaluationResponse) GetDecision() bool {
@@ -820,18 +873,19 @@ func (x *EvaluationResponse) GetContext() *ContextResponse {
 
 // AuthorizationCheckResponse represents the outcome of the authorization decision.
 type AuthorizationCheckResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Decision      bool                   `protobuf:"varint,1,opt,name=Decision,proto3" json:"Decision,omitempty"`
-	RequestID     *string                `protobuf:"bytes,2,opt,name=RequestID,proto3,oneof" json:"RequestID,omitempty"`
-	Context       *ContextResponse       `protobuf:"bytes,3,opt,name=Context,proto3,oneof" json:"Context,omitempty"`
-	Evaluations   []*EvaluationResponse  `protobuf:"bytes,4,rep,name=Evaluations,proto3" json:"Evaluations,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Decision           bool                   `protobuf:"varint,1,opt,name=Decision,proto3" json:"Decision,omitempty"`
+	RequestID          *string                `protobuf:"bytes,2,opt,name=RequestID,proto3,oneof" json:"RequestID,omitempty"`
+	Context            *ContextResponse       `protobuf:"bytes,3,opt,name=Context,proto3,oneof" json:"Context,omitempty"`
+	Evaluations        []*EvaluationResponse  `protobuf:"bytes,4,rep,name=Evaluations,proto3" json:"Evaluations,omitempty"`
+	PolicyStoreVersion *string                `protobuf:"bytes,5,opt,name=PolicyStoreVersion,proto3,oneof" json:"PolicyStoreVersion,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *AuthorizationCheckResponse) Reset() {
 	*x = AuthorizationCheckResponse{}
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[12]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -843,7 +897,7 @@ func (x *AuthorizationCheckResponse) String() string {
 func (*AuthorizationCheckResponse) ProtoMessage() {}
 
 func (x *AuthorizationCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[12]
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,7 +910,7 @@ func (x *AuthorizationCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthorizationCheckResponse.ProtoReflect.Descriptor instead.
 func (*AuthorizationCheckResponse) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{12}
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AuthorizationCheckResponse) GetDecision() bool {
@@ -887,114 +941,822 @@ func (x *AuthorizationCheckResponse) GetEvaluations() []*EvaluationResponse {
 	return nil
 }
 
-var File_internal_agents_services_pdp_endpoints_api_v1_pdp_proto protoreflect.FileDescriptor
+func (x *AuthorizationCheckResponse) GetPolicyStoreVersion() string {
+	if x != nil && x.PolicyStoreVersion != nil {
+		return *x.PolicyStoreVersion
+	}
+	return ""
+}
 
-const file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDesc = "" +
-	"\n" +
-	"7internal/agents/services/pdp/endpoints/api/v1/pdp.proto\x12\x13policydecisionpoint\x1a\x1cgoogle/protobuf/struct.proto\"1\n" +
-	"\vPolicyStore\x12\x12\n" +
-	"\x04Kind\x18\x01 \x01(\tR\x04Kind\x12\x0e\n" +
-	"\x02ID\x18\x02 \x01(\tR\x02ID\"\xcb\x01\n" +
-	"\tPrincipal\x12\x12\n" +
-	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
-	"\x02ID\x18\x02 \x01(\tR\x02ID\x12\x1b\n" +
-	"\x06Source\x18\x03 \x01(\tH\x00R\x06Source\x88\x01\x01\x12)\n" +
-	"\rIdentityToken\x18\x04 \x01(\tH\x01R\rIdentityToken\x88\x01\x01\x12%\n" +
-	"\vAccessToken\x18\x05 \x01(\tH\x02R\vAccessToken\x88\x01\x01B\t\n" +
-	"\a_SourceB\x10\n" +
-	"\x0e_IdentityTokenB\x0e\n" +
-	"\f_AccessToken\"Q\n" +
-	"\bEntities\x12\x16\n" +
-	"\x06Schema\x18\x01 \x01(\tR\x06Schema\x12-\n" +
-	"\x05Items\x18\x02 \x03(\v2\x17.google.protobuf.StructR\x05Items\"\xa2\x01\n" +
-	"\aSubject\x12\x12\n" +
-	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
-	"\x02ID\x18\x02 \x01(\tR\x02ID\x12\x1b\n" +
-	"\x06Source\x18\x03 \x01(\tH\x00R\x06Source\x88\x01\x01\x12<\n" +
-	"\n" +
-	"Properties\x18\x04 \x01(\v2\x17.google.protobuf.StructH\x01R\n" +
-	"Properties\x88\x01\x01B\t\n" +
-	"\a_SourceB\r\n" +
-	"\v_Properties\"{\n" +
-	"\bResource\x12\x12\n" +
-	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
-	"\x02ID\x18\x02 \x01(\tR\x02ID\x12<\n" +
-	"\n" +
-	"Properties\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x00R\n" +
-	"Properties\x88\x01\x01B\r\n" +
-	"\v_Properties\"i\n" +
-	"\x06Action\x12\x12\n" +
-	"\x04Name\x18\x01 \x01(\tR\x04Name\x12<\n" +
-	"\n" +
-	"Properties\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\n" +
-	"Properties\x88\x01\x01B\r\n" +
-	"\v_Properties\"\x95\x02\n" +
-	"\x19AuthorizationModelRequest\x12\x16\n" +
-	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12B\n" +
-	"\vPolicyStore\x18\x02 \x01(\v2 .policydecisionpoint.PolicyStoreR\vPolicyStore\x12A\n" +
-	"\tPrincipal\x18\x03 \x01(\v2\x1e.policydecisionpoint.PrincipalH\x00R\tPrincipal\x88\x01\x01\x12>\n" +
-	"\bEntities\x18\x04 \x01(\v2\x1d.policydecisionpoint.EntitiesH\x01R\bEntities\x88\x01\x01B\f\n" +
-	"\n" +
-	"_PrincipalB\v\n" +
-	"\t_Entities\"\xe3\x02\n" +
-	"\x11EvaluationRequest\x12!\n" +
-	"\tRequestID\x18\x01 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12;\n" +
-	"\aSubject\x18\x02 \x01(\v2\x1c.policydecisionpoint.SubjectH\x01R\aSubject\x88\x01\x01\x12>\n" +
-	"\bResource\x18\x03 \x01(\v2\x1d.policydecisionpoint.ResourceH\x02R\bResource\x88\x01\x01\x128\n" +
-	"\x06Action\x18\x04 \x01(\v2\x1b.policydecisionpoint.ActionH\x03R\x06Action\x88\x01\x01\x126\n" +
-	"\aContext\x18\x05 \x01(\v2\x17.google.protobuf.StructH\x04R\aContext\x88\x01\x01B\f\n" +
-	"\n" +
-	"_RequestIDB\n" +
-	"\n" +
-	"\b_SubjectB\v\n" +
-	"\t_ResourceB\t\n" +
-	"\a_ActionB\n" +
-	"\n" +
-	"\b_Context\"\x95\x04\n" +
-	"\x19AuthorizationCheckRequest\x12^\n" +
-	"\x12AuthorizationModel\x18\x01 \x01(\v2..policydecisionpoint.AuthorizationModelRequestR\x12AuthorizationModel\x12!\n" +
-	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12;\n" +
-	"\aSubject\x18\x03 \x01(\v2\x1c.policydecisionpoint.SubjectH\x01R\aSubject\x88\x01\x01\x12>\n" +
-	"\bResource\x18\x04 \x01(\v2\x1d.policydecisionpoint.ResourceH\x02R\bResource\x88\x01\x01\x128\n" +
-	"\x06Action\x18\x05 \x01(\v2\x1b.policydecisionpoint.ActionH\x03R\x06Action\x88\x01\x01\x126\n" +
-	"\aContext\x18\x06 \x01(\v2\x17.google.protobuf.StructH\x04R\aContext\x88\x01\x01\x12H\n" +
-	"\vEvaluations\x18\a \x03(\v2&.policydecisionpoint.EvaluationRequestR\vEvaluationsB\f\n" +
-	"\n" +
-	"_RequestIDB\n" +
-	"\n" +
-	"\b_SubjectB\v\n" +
-	"\t_ResourceB\t\n" +
-	"\a_ActionB\n" +
-	"\n" +
-	"\b_Context\">\n" +
-	"\x0eReasonResponse\x12\x12\n" +
-	"\x04Code\x18\x01 \x01(\tR\x04Code\x12\x18\n" +
-	"\aMessage\x18\x02 \x01(\tR\aMessage\"\xad\x01\n" +
-	"\x0fContextResponse\x12\x0e\n" +
-	"\x02ID\x18\x01 \x01(\tR\x02ID\x12E\n" +
-	"\vReasonAdmin\x18\x02 \x01(\v2#.policydecisionpoint.ReasonResponseR\vReasonAdmin\x12C\n" +
-	"\n" +
-	"ReasonUser\x18\x03 \x01(\v2#.policydecisionpoint.ReasonResponseR\n" +
-	"ReasonUser\"\xb2\x01\n" +
-	"\x12EvaluationResponse\x12\x1a\n" +
-	"\bDecision\x18\x01 \x01(\bR\bDecision\x12!\n" +
-	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12C\n" +
-	"\aContext\x18\x03 \x01(\v2$.policydecisionpoint.ContextResponseH\x01R\aContext\x88\x01\x01B\f\n" +
-	"\n" +
-	"_RequestIDB\n" +
-	"\n" +
-	"\b_Context\"\x85\x02\n" +
-	"\x1aAuthorizationCheckResponse\x12\x1a\n" +
-	"\bDecision\x18\x01 \x01(\bR\bDecision\x12!\n" +
-	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12C\n" +
-	"\aContext\x18\x03 \x01(\v2$.policydecisionpoint.ContextResponseH\x01R\aContext\x88\x01\x01\x12I\n" +
-	"\vEvaluations\x18\x04 \x03(\v2'.policydecisionpoint.EvaluationResponseR\vEvaluationsB\f\n" +
-	"\n" +
-	"_RequestIDB\n" +
+// AuthorizationCheckStreamResponse represents an evaluation emitted in order as soon as it is ready.
+type AuthorizationCheckStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Evaluation    *EvaluationResponse    `protobuf:"bytes,2,opt,name=Evaluation,proto3" json:"Evaluation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizationCheckStreamResponse) Reset() {
+	*x = AuthorizationCheckStreamResponse{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationCheckStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationCheckStreamResponse) ProtoMessage() {}
+
+func (x *AuthorizationCheckStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationCheckStreamResponse.ProtoReflect.Descriptor instead.
+func (*AuthorizationCheckStreamResponse) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AuthorizationCheckStreamResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *AuthorizationCheckStreamResponse) GetEvaluation() *EvaluationResponse {
+	if x != nil {
+		return x.Evaluation
+	}
+	return nil
+}
+
+// ReplayEntry represents a logged evaluation to be re-evaluated.
+type ReplayEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Principal     *Principal             `protobuf:"bytes,1,opt,name=Principal,proto3,oneof" json:"Principal,omitempty"`
+	Entities      *Entities              `protobuf:"bytes,2,opt,name=Entities,proto3,oneof" json:"Entities,omitempty"`
+	Evaluation    *EvaluationRequest     `protobuf:"bytes,3,opt,name=Evaluation,proto3" json:"Evaluation,omitempty"`
+	Decision      bool                   `protobuf:"varint,4,opt,name=Decision,proto3" json:"Decision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayEntry) Reset() {
+	*x = ReplayEntry{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayEntry) ProtoMessage() {}
+
+func (x *ReplayEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayEntry.ProtoReflect.Descriptor instead.
+func (*ReplayEntry) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReplayEntry) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+func (x *ReplayEntry) GetEntities() *Entities {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *ReplayEntry) GetEvaluation() *EvaluationRequest {
+	if x != nil {
+		return x.Evaluation
+	}
+	return nil
+}
+
+func (x *ReplayEntry) GetDecision() bool {
+	if x != nil {
+		return x.Decision
+	}
+	return false
+}
+
+// AuthorizationReplayRequest represents the request to re-evaluate logged decisions against a commit.
+type AuthorizationReplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
+	PolicyStore   *PolicyStore           `protobuf:"bytes,2,opt,name=PolicyStore,proto3" json:"PolicyStore,omitempty"`
+	CommitID      string                 `protobuf:"bytes,3,opt,name=CommitID,proto3" json:"CommitID,omitempty"`
+	Entries       []*ReplayEntry         `protobuf:"bytes,4,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizationReplayRequest) Reset() {
+	*x = AuthorizationReplayRequest{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationReplayRequest) ProtoMessage() {}
+
+func (x *AuthorizationReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationReplayRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizationReplayRequest) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AuthorizationReplayRequest) GetZoneID() int64 {
+	if x != nil {
+		return x.ZoneID
+	}
+	return 0
+}
+
+func (x *AuthorizationReplayRequest) GetPolicyStore() *PolicyStore {
+	if x != nil {
+		return x.PolicyStore
+	}
+	return nil
+}
+
+func (x *AuthorizationReplayRequest) GetCommitID() string {
+	if x != nil {
+		return x.CommitID
+	}
+	return ""
+}
+
+func (x *AuthorizationReplayRequest) GetEntries() []*ReplayEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// ReplayResult represents the outcome of a single re-evaluated entry.
+type ReplayResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RequestID      *string                `protobuf:"bytes,1,opt,name=RequestID,proto3,oneof" json:"RequestID,omitempty"`
+	LoggedDecision bool                   `protobuf:"varint,2,opt,name=LoggedDecision,proto3" json:"LoggedDecision,omitempty"`
+	Decision       bool                   `protobuf:"varint,3,opt,name=Decision,proto3" json:"Decision,omitempty"`
+	Diverged       bool                   `protobuf:"varint,4,opt,name=Diverged,proto3" json:"Diverged,omitempty"`
+	Context        *ContextResponse       `protobuf:"bytes,5,opt,name=Context,proto3,oneof" json:"Context,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReplayResult) Reset() {
+	*x = ReplayResult{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayResult) ProtoMessage() {}
+
+func (x *ReplayResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayResult.ProtoReflect.Descriptor instead.
+func (*ReplayResult) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReplayResult) GetRequestID() string {
+	if x != nil && x.RequestID != nil {
+		return *x.RequestID
+	}
+	return ""
+}
+
+func (x *ReplayResult) GetLoggedDecision() bool {
+	if x != nil {
+		return x.LoggedDecision
+	}
+	return false
+}
+
+func (x *ReplayResult) GetDecision() bool {
+	if x != nil {
+		return x.Decision
+	}
+	return false
+}
+
+func (x *ReplayResult) GetDiverged() bool {
+	if x != nil {
+		return x.Diverged
+	}
+	return false
+}
+
+func (x *ReplayResult) GetContext() *ContextResponse {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// AuthorizationReplayResponse represents the outcome of the replay.
+type AuthorizationReplayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommitID      string                 `protobuf:"bytes,1,opt,name=CommitID,proto3" json:"CommitID,omitempty"`
+	Divergences   int64                  `protobuf:"varint,2,opt,name=Divergences,proto3" json:"Divergences,omitempty"`
+	Results       []*ReplayResult        `protobuf:"bytes,3,rep,name=Results,proto3" json:"Results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizationReplayResponse) Reset() {
+	*x = AuthorizationReplayResponse{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationReplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationReplayResponse) ProtoMessage() {}
+
+func (x *AuthorizationReplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationReplayResponse.ProtoReflect.Descriptor instead.
+func (*AuthorizationReplayResponse) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AuthorizationReplayResponse) GetCommitID() string {
+	if x != nil {
+		return x.CommitID
+	}
+	return ""
+}
+
+func (x *AuthorizationReplayResponse) GetDivergences() int64 {
+	if x != nil {
+		return x.Divergences
+	}
+	return 0
+}
+
+func (x *AuthorizationReplayResponse) GetResults() []*ReplayResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// AuthorizationPartialEvaluationRequest represents the request to evaluate the authorization with an unknown resource.
+type AuthorizationPartialEvaluationRequest struct {
+	state              protoimpl.MessageState     `protogen:"open.v1"`
+	AuthorizationModel *AuthorizationModelRequest `protobuf:"bytes,1,opt,name=AuthorizationModel,proto3" json:"AuthorizationModel,omitempty"`
+	RequestID          *string                    `protobuf:"bytes,2,opt,name=RequestID,proto3,oneof" json:"RequestID,omitempty"`
+	Subject            *Subject                   `protobuf:"bytes,3,opt,name=Subject,proto3,oneof" json:"Subject,omitempty"`
+	Resource           *Resource                  `protobuf:"bytes,4,opt,name=Resource,proto3,oneof" json:"Resource,omitempty"`
+	Action             *Action                    `protobuf:"bytes,5,opt,name=Action,proto3,oneof" json:"Action,omitempty"`
+	Context            *structpb.Struct           `protobuf:"bytes,6,opt,name=Context,proto3,oneof" json:"Context,omitempty"`
+	UnknownContext     []string                   `protobuf:"bytes,7,rep,name=UnknownContext,proto3" json:"UnknownContext,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AuthorizationPartialEvaluationRequest) Reset() {
+	*x = AuthorizationPartialEvaluationRequest{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationPartialEvaluationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationPartialEvaluationRequest) ProtoMessage() {}
+
+func (x *AuthorizationPartialEvaluationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationPartialEvaluationRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizationPartialEvaluationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetAuthorizationModel() *AuthorizationModelRequest {
+	if x != nil {
+		return x.AuthorizationModel
+	}
+	return nil
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetRequestID() string {
+	if x != nil && x.RequestID != nil {
+		return *x.RequestID
+	}
+	return ""
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetSubject() *Subject {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetAction() *Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetContext() *structpb.Struct {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *AuthorizationPartialEvaluationRequest) GetUnknownContext() []string {
+	if x != nil {
+		return x.UnknownContext
+	}
+	return nil
+}
+
+// ResidualEntity represents an entity referenced by a residual condition.
+type ResidualEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	ID            string                 `protobuf:"bytes,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResidualEntity) Reset() {
+	*x = ResidualEntity{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResidualEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResidualEntity) ProtoMessage() {}
+
+func (x *ResidualEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResidualEntity.ProtoReflect.Descriptor instead.
+func (*ResidualEntity) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResidualEntity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResidualEntity) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+// ResidualNode represents a node of a residual condition.
+type ResidualNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=Op,proto3" json:"Op,omitempty"`
+	Args          []*ResidualNode        `protobuf:"bytes,2,rep,name=Args,proto3" json:"Args,omitempty"`
+	Path          []string               `protobuf:"bytes,3,rep,name=Path,proto3" json:"Path,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,4,opt,name=Value,proto3,oneof" json:"Value,omitempty"`
+	Entity        *ResidualEntity        `protobuf:"bytes,5,opt,name=Entity,proto3,oneof" json:"Entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResidualNode) Reset() {
+	*x = ResidualNode{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResidualNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResidualNode) ProtoMessage() {}
+
+func (x *ResidualNode) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResidualNode.ProtoReflect.Descriptor instead.
+func (*ResidualNode) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResidualNode) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ResidualNode) GetArgs() []*ResidualNode {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ResidualNode) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *ResidualNode) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ResidualNode) GetEntity() *ResidualEntity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+// AuthorizationPartialEvaluationResponse represents the residual condition of the partial evaluation.
+type AuthorizationPartialEvaluationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestID     *string                `protobuf:"bytes,1,opt,name=RequestID,proto3,oneof" json:"RequestID,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=Decision,proto3" json:"Decision,omitempty"`
+	Residual      *ResidualNode          `protobuf:"bytes,3,opt,name=Residual,proto3,oneof" json:"Residual,omitempty"`
+	Policies      []string               `protobuf:"bytes,4,rep,name=Policies,proto3" json:"Policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizationPartialEvaluationResponse) Reset() {
+	*x = AuthorizationPartialEvaluationResponse{}
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationPartialEvaluationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationPartialEvaluationResponse) ProtoMessage() {}
+
+func (x *AuthorizationPartialEvaluationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationPartialEvaluationResponse.ProtoReflect.Descriptor instead.
+func (*AuthorizationPartialEvaluationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AuthorizationPartialEvaluationResponse) GetRequestID() string {
+	if x != nil && x.RequestID != nil {
+		return *x.RequestID
+	}
+	return ""
+}
+
+func (x *AuthorizationPartialEvaluationResponse) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *AuthorizationPartialEvaluationResponse) GetResidual() *ResidualNode {
+	if x != nil {
+		return x.Residual
+	}
+	return nil
+}
+
+func (x *AuthorizationPartialEvaluationResponse) GetPolicies() []string {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+var File_internal_agents_services_pdp_endpoints_api_v1_pdp_proto protoreflect.FileDescriptor
+
+const file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDesc = "" +
+	"\n" +
+	"7internal/agents/services/pdp/endpoints/api/v1/pdp.proto\x12\x13policydecisionpoint\x1a\x1cgoogle/protobuf/struct.proto\"1\n" +
+	"\vPolicyStore\x12\x12\n" +
+	"\x04Kind\x18\x01 \x01(\tR\x04Kind\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\tR\x02ID\"\xcb\x01\n" +
+	"\tPrincipal\x12\x12\n" +
+	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\tR\x02ID\x12\x1b\n" +
+	"\x06Source\x18\x03 \x01(\tH\x00R\x06Source\x88\x01\x01\x12)\n" +
+	"\rIdentityToken\x18\x04 \x01(\tH\x01R\rIdentityToken\x88\x01\x01\x12%\n" +
+	"\vAccessToken\x18\x05 \x01(\tH\x02R\vAccessToken\x88\x01\x01B\t\n" +
+	"\a_SourceB\x10\n" +
+	"\x0e_IdentityTokenB\x0e\n" +
+	"\f_AccessToken\"Q\n" +
+	"\bEntities\x12\x16\n" +
+	"\x06Schema\x18\x01 \x01(\tR\x06Schema\x12-\n" +
+	"\x05Items\x18\x02 \x03(\v2\x17.google.protobuf.StructR\x05Items\"\xa2\x01\n" +
+	"\aSubject\x12\x12\n" +
+	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\tR\x02ID\x12\x1b\n" +
+	"\x06Source\x18\x03 \x01(\tH\x00R\x06Source\x88\x01\x01\x12<\n" +
+	"\n" +
+	"Properties\x18\x04 \x01(\v2\x17.google.protobuf.StructH\x01R\n" +
+	"Properties\x88\x01\x01B\t\n" +
+	"\a_SourceB\r\n" +
+	"\v_Properties\"{\n" +
+	"\bResource\x12\x12\n" +
+	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\tR\x02ID\x12<\n" +
+	"\n" +
+	"Properties\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x00R\n" +
+	"Properties\x88\x01\x01B\r\n" +
+	"\v_Properties\"i\n" +
+	"\x06Action\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12<\n" +
+	"\n" +
+	"Properties\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\n" +
+	"Properties\x88\x01\x01B\r\n" +
+	"\v_Properties\"\x95\x02\n" +
+	"\x19AuthorizationModelRequest\x12\x16\n" +
+	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12B\n" +
+	"\vPolicyStore\x18\x02 \x01(\v2 .policydecisionpoint.PolicyStoreR\vPolicyStore\x12A\n" +
+	"\tPrincipal\x18\x03 \x01(\v2\x1e.policydecisionpoint.PrincipalH\x00R\tPrincipal\x88\x01\x01\x12>\n" +
+	"\bEntities\x18\x04 \x01(\v2\x1d.policydecisionpoint.EntitiesH\x01R\bEntities\x88\x01\x01B\f\n" +
+	"\n" +
+	"_PrincipalB\v\n" +
+	"\t_Entities\"\xe3\x02\n" +
+	"\x11EvaluationRequest\x12!\n" +
+	"\tRequestID\x18\x01 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12;\n" +
+	"\aSubject\x18\x02 \x01(\v2\x1c.policydecisionpoint.SubjectH\x01R\aSubject\x88\x01\x01\x12>\n" +
+	"\bResource\x18\x03 \x01(\v2\x1d.policydecisionpoint.ResourceH\x02R\bResource\x88\x01\x01\x128\n" +
+	"\x06Action\x18\x04 \x01(\v2\x1b.policydecisionpoint.ActionH\x03R\x06Action\x88\x01\x01\x126\n" +
+	"\aContext\x18\x05 \x01(\v2\x17.google.protobuf.StructH\x04R\aContext\x88\x01\x01B\f\n" +
+	"\n" +
+	"_RequestIDB\n" +
+	"\n" +
+	"\b_SubjectB\v\n" +
+	"\t_ResourceB\t\n" +
+	"\a_ActionB\n" +
+	"\n" +
+	"\b_Context\"c\n" +
+	"\x12EvaluationsOptions\x125\n" +
+	"\x13EvaluationsSemantic\x18\x01 \x01(\tH\x00R\x13EvaluationsSemantic\x88\x01\x01B\x16\n" +
+	"\x14_EvaluationsSemantic\"\xe9\x04\n" +
+	"\x19AuthorizationCheckRequest\x12^\n" +
+	"\x12AuthorizationModel\x18\x01 \x01(\v2..policydecisionpoint.AuthorizationModelRequestR\x12AuthorizationModel\x12!\n" +
+	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12;\n" +
+	"\aSubject\x18\x03 \x01(\v2\x1c.policydecisionpoint.SubjectH\x01R\aSubject\x88\x01\x01\x12>\n" +
+	"\bResource\x18\x04 \x01(\v2\x1d.policydecisionpoint.ResourceH\x02R\bResource\x88\x01\x01\x128\n" +
+	"\x06Action\x18\x05 \x01(\v2\x1b.policydecisionpoint.ActionH\x03R\x06Action\x88\x01\x01\x126\n" +
+	"\aContext\x18\x06 \x01(\v2\x17.google.protobuf.StructH\x04R\aContext\x88\x01\x01\x12H\n" +
+	"\vEvaluations\x18\a \x03(\v2&.policydecisionpoint.EvaluationRequestR\vEvaluations\x12F\n" +
+	"\aOptions\x18\b \x01(\v2'.policydecisionpoint.EvaluationsOptionsH\x05R\aOptions\x88\x01\x01B\f\n" +
+	"\n" +
+	"_RequestIDB\n" +
+	"\n" +
+	"\b_SubjectB\v\n" +
+	"\t_ResourceB\t\n" +
+	"\a_ActionB\n" +
+	"\n" +
+	"\b_ContextB\n" +
+	"\n" +
+	"\b_Options\">\n" +
+	"\x0eReasonResponse\x12\x12\n" +
+	"\x04Code\x18\x01 \x01(\tR\x04Code\x12\x18\n" +
+	"\aMessage\x18\x02 \x01(\tR\aMessage\"\xad\x01\n" +
+	"\x0fContextResponse\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12E\n" +
+	"\vReasonAdmin\x18\x02 \x01(\v2#.policydecisionpoint.ReasonResponseR\vReasonAdmin\x12C\n" +
+	"\n" +
+	"ReasonUser\x18\x03 \x01(\v2#.policydecisionpoint.ReasonResponseR\n" +
+	"ReasonUser\"\xb2\x01\n" +
+	"\x12EvaluationResponse\x12\x1a\n" +
+	"\bDecision\x18\x01 \x01(\bR\bDecision\x12!\n" +
+	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12C\n" +
+	"\aContext\x18\x03 \x01(\v2$.policydecisionpoint.ContextResponseH\x01R\aContext\x88\x01\x01B\f\n" +
+	"\n" +
+	"_RequestIDB\n" +
+	"\n" +
+	"\b_Context\"\xd1\x02\n" +
+	"\x1aAuthorizationCheckResponse\x12\x1a\n" +
+	"\bDecision\x18\x01 \x01(\bR\bDecision\x12!\n" +
+	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12C\n" +
+	"\aContext\x18\x03 \x01(\v2$.policydecisionpoint.ContextResponseH\x01R\aContext\x88\x01\x01\x12I\n" +
+	"\vEvaluations\x18\x04 \x03(\v2'.policydecisionpoint.EvaluationResponseR\vEvaluations\x123\n" +
+	"\x12PolicyStoreVersion\x18\x05 \x01(\tH\x02R\x12PolicyStoreVersion\x88\x01\x01B\f\n" +
+	"\n" +
+	"_RequestIDB\n" +
+	"\n" +
+	"\b_ContextB\x15\n" +
+	"\x13_PolicyStoreVersion\"\x81\x01\n" +
+	" AuthorizationCheckStreamResponse\x12\x14\n" +
+	"\x05Index\x18\x01 \x01(\x05R\x05Index\x12G\n" +
+	"\n" +
+	"Evaluation\x18\x02 \x01(\v2'.policydecisionpoint.EvaluationResponseR\n" +
+	"Evaluation\"\x8f\x02\n" +
+	"\vReplayEntry\x12A\n" +
+	"\tPrincipal\x18\x01 \x01(\v2\x1e.policydecisionpoint.PrincipalH\x00R\tPrincipal\x88\x01\x01\x12>\n" +
+	"\bEntities\x18\x02 \x01(\v2\x1d.policydecisionpoint.EntitiesH\x01R\bEntities\x88\x01\x01\x12F\n" +
+	"\n" +
+	"Evaluation\x18\x03 \x01(\v2&.policydecisionpoint.EvaluationRequestR\n" +
+	"Evaluation\x12\x1a\n" +
+	"\bDecision\x18\x04 \x01(\bR\bDecisionB\f\n" +
+	"\n" +
+	"_PrincipalB\v\n" +
+	"\t_Entities\"\xd0\x01\n" +
+	"\x1aAuthorizationReplayRequest\x12\x16\n" +
+	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12B\n" +
+	"\vPolicyStore\x18\x02 \x01(\v2 .policydecisionpoint.PolicyStoreR\vPolicyStore\x12\x1a\n" +
+	"\bCommitID\x18\x03 \x01(\tR\bCommitID\x12:\n" +
+	"\aEntries\x18\x04 \x03(\v2 .policydecisionpoint.ReplayEntryR\aEntries\"\xf0\x01\n" +
+	"\fReplayResult\x12!\n" +
+	"\tRequestID\x18\x01 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12&\n" +
+	"\x0eLoggedDecision\x18\x02 \x01(\bR\x0eLoggedDecision\x12\x1a\n" +
+	"\bDecision\x18\x03 \x01(\bR\bDecision\x12\x1a\n" +
+	"\bDiverged\x18\x04 \x01(\bR\bDiverged\x12C\n" +
+	"\aContext\x18\x05 \x01(\v2$.policydecisionpoint.ContextResponseH\x01R\aContext\x88\x01\x01B\f\n" +
+	"\n" +
+	"_RequestIDB\n" +
+	"\n" +
+	"\b_Context\"\x98\x01\n" +
+	"\x1bAuthorizationReplayResponse\x12\x1a\n" +
+	"\bCommitID\x18\x01 \x01(\tR\bCommitID\x12 \n" +
+	"\vDivergences\x18\x02 \x01(\x03R\vDivergences\x12;\n" +
+	"\aResults\x18\x03 \x03(\v2!.policydecisionpoint.ReplayResultR\aResults\"\xff\x03\n" +
+	"%AuthorizationPartialEvaluationRequest\x12^\n" +
+	"\x12AuthorizationModel\x18\x01 \x01(\v2..policydecisionpoint.AuthorizationModelRequestR\x12AuthorizationModel\x12!\n" +
+	"\tRequestID\x18\x02 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12;\n" +
+	"\aSubject\x18\x03 \x01(\v2\x1c.policydecisionpoint.SubjectH\x01R\aSubject\x88\x01\x01\x12>\n" +
+	"\bResource\x18\x04 \x01(\v2\x1d.policydecisionpoint.ResourceH\x02R\bResource\x88\x01\x01\x128\n" +
+	"\x06Action\x18\x05 \x01(\v2\x1b.policydecisionpoint.ActionH\x03R\x06Action\x88\x01\x01\x126\n" +
+	"\aContext\x18\x06 \x01(\v2\x17.google.protobuf.StructH\x04R\aContext\x88\x01\x01\x12&\n" +
+	"\x0eUnknownContext\x18\a \x03(\tR\x0eUnknownContextB\f\n" +
+	"\n" +
+	"_RequestIDB\n" +
+	"\n" +
+	"\b_SubjectB\v\n" +
+	"\t_ResourceB\t\n" +
+	"\a_ActionB\n" +
+	"\n" +
+	"\b_Context\"4\n" +
+	"\x0eResidualEntity\x12\x12\n" +
+	"\x04Type\x18\x01 \x01(\tR\x04Type\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\tR\x02ID\"\xf3\x01\n" +
+	"\fResidualNode\x12\x0e\n" +
+	"\x02Op\x18\x01 \x01(\tR\x02Op\x125\n" +
+	"\x04Args\x18\x02 \x03(\v2!.policydecisionpoint.ResidualNodeR\x04Args\x12\x12\n" +
+	"\x04Path\x18\x03 \x03(\tR\x04Path\x121\n" +
+	"\x05Value\x18\x04 \x01(\v2\x16.google.protobuf.ValueH\x00R\x05Value\x88\x01\x01\x12@\n" +
+	"\x06Entity\x18\x05 \x01(\v2#.policydecisionpoint.ResidualEntityH\x01R\x06Entity\x88\x01\x01B\b\n" +
+	"\x06_ValueB\t\n" +
+	"\a_Entity\"\xe2\x01\n" +
+	"&AuthorizationPartialEvaluationResponse\x12!\n" +
+	"\tRequestID\x18\x01 \x01(\tH\x00R\tRequestID\x88\x01\x01\x12\x1a\n" +
+	"\bDecision\x18\x02 \x01(\tR\bDecision\x12B\n" +
+	"\bResidual\x18\x03 \x01(\v2!.policydecisionpoint.ResidualNodeH\x01R\bResidual\x88\x01\x01\x12\x1a\n" +
+	"\bPolicies\x18\x04 \x03(\tR\bPoliciesB\f\n" +
 	"\n" +
-	"\b_Context2\x87\x01\n" +
+	"_RequestIDB\v\n" +
+	"\t_Residual2\xa9\x04\n" +
 	"\fV1PDPService\x12w\n" +
-	"\x12AuthorizationCheck\x12..policydecisionpoint.AuthorizationCheckRequest\x1a/.policydecisionpoint.AuthorizationCheckResponse\"\x00B:Z8github.com/permguard/permguard/internal/hosts/api/pdp/v1b\x06proto3"
+	"\x12AuthorizationCheck\x12..policydecisionpoint.AuthorizationCheckRequest\x1a/.policydecisionpoint.AuthorizationCheckResponse\"\x00\x12\x85\x01\n" +
+	"\x18AuthorizationCheckStream\x12..policydecisionpoint.AuthorizationCheckRequest\x1a5.policydecisionpoint.AuthorizationCheckStreamResponse\"\x000\x01\x12z\n" +
+	"\x13AuthorizationReplay\x12/.policydecisionpoint.AuthorizationReplayRequest\x1a0.policydecisionpoint.AuthorizationReplayResponse\"\x00\x12\x9b\x01\n" +
+	"\x1eAuthorizationPartialEvaluation\x12:.policydecisionpoint.AuthorizationPartialEvaluationRequest\x1a;.policydecisionpoint.AuthorizationPartialEvaluationResponse\"\x00B:Z8github.com/permguard/permguard/internal/hosts/api/pdp/v1b\x06proto3"
 
 var (
 	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescOnce sync.Once
@@ -1008,53 +1770,88 @@ func file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescGZIP()
 	return file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDescData
 }
 
-var file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_goTypes = []any{
-	(*PolicyStore)(nil),                // 0: policydecisionpoint.PolicyStore
-	(*Principal)(nil),                  // 1: policydecisionpoint.Principal
-	(*Entities)(nil),                   // 2: policydecisionpoint.Entities
-	(*Subject)(nil),                    // 3: policydecisionpoint.Subject
-	(*Resource)(nil),                   // 4: policydecisionpoint.Resource
-	(*Action)(nil),                     // 5: policydecisionpoint.Action
-	(*AuthorizationModelRequest)(nil),  // 6: policydecisionpoint.AuthorizationModelRequest
-	(*EvaluationRequest)(nil),          // 7: policydecisionpoint.EvaluationRequest
-	(*AuthorizationCheckRequest)(nil),  // 8: policydecisionpoint.AuthorizationCheckRequest
-	(*ReasonResponse)(nil),             // 9: policydecisionpoint.ReasonResponse
-	(*ContextResponse)(nil),            // 10: policydecisionpoint.ContextResponse
-	(*EvaluationResponse)(nil),         // 11: policydecisionpoint.EvaluationResponse
-	(*AuthorizationCheckResponse)(nil), // 12: policydecisionpoint.AuthorizationCheckResponse
-	(*structpb.Struct)(nil),            // 13: google.protobuf.Struct
+	(*PolicyStore)(nil),                            // 0: policydecisionpoint.PolicyStore
+	(*Principal)(nil),                              // 1: policydecisionpoint.Principal
+	(*Entities)(nil),                               // 2: policydecisionpoint.Entities
+	(*Subject)(nil),                                // 3: policydecisionpoint.Subject
+	(*Resource)(nil),                               // 4: policydecisionpoint.Resource
+	(*Action)(nil),                                 // 5: policydecisionpoint.Action
+	(*AuthorizationModelRequest)(nil),              // 6: policydecisionpoint.AuthorizationModelRequest
+	(*EvaluationRequest)(nil),                      // 7: policydecisionpoint.EvaluationRequest
+	(*EvaluationsOptions)(nil),                     // 8: policydecisionpoint.EvaluationsOptions
+	(*AuthorizationCheckRequest)(nil),              // 9: policydecisionpoint.AuthorizationCheckRequest
+	(*ReasonResponse)(nil),                         // 10: policydecisionpoint.ReasonResponse
+	(*ContextResponse)(nil),                        // 11: policydecisionpoint.ContextResponse
+	(*EvaluationResponse)(nil),                     // 12: policydecisionpoint.EvaluationResponse
+	(*AuthorizationCheckResponse)(nil),             // 13: policydecisionpoint.AuthorizationCheckResponse
+	(*AuthorizationCheckStreamResponse)(nil),       // 14: policydecisionpoint.AuthorizationCheckStreamResponse
+	(*ReplayEntry)(nil),                            // 15: policydecisionpoint.ReplayEntry
+	(*AuthorizationReplayRequest)(nil),             // 16: policydecisionpoint.AuthorizationReplayRequest
+	(*ReplayResult)(nil),                           // 17: policydecisionpoint.ReplayResult
+	(*AuthorizationReplayResponse)(nil),            // 18: policydecisionpoint.AuthorizationReplayResponse
+	(*AuthorizationPartialEvaluationRequest)(nil),  // 19: policydecisionpoint.AuthorizationPartialEvaluationRequest
+	(*ResidualEntity)(nil),                         // 20: policydecisionpoint.ResidualEntity
+	(*ResidualNode)(nil),                           // 21: policydecisionpoint.ResidualNode
+	(*AuthorizationPartialEvaluationResponse)(nil), // 22: policydecisionpoint.AuthorizationPartialEvaluationResponse
+	(*structpb.Struct)(nil),                        // 23: google.protobuf.Struct
+	(*structpb.Value)(nil),                         // 24: google.protobuf.Value
 }
 var file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_depIdxs = []int32{
-	13, // 0: policydecisionpoint.Entities.Items:type_name -> google.protobuf.Struct
-	13, // 1: policydecisionpoint.Subject.Properties:type_name -> google.protobuf.Struct
-	13, // 2: policydecisionpoint.Resource.Properties:type_name -> google.protobuf.Struct
-	13, // 3: policydecisionpoint.Action.Properties:type_name -> google.protobuf.Struct
+	23, // 0: policydecisionpoint.Entities.Items:type_name -> google.protobuf.Struct
+	23, // 1: policydecisionpoint.Subject.Properties:type_name -> google.protobuf.Struct
+	23, // 2: policydecisionpoint.Resource.Properties:type_name -> google.protobuf.Struct
+	23, // 3: policydecisionpoint.Action.Properties:type_name -> google.protobuf.Struct
 	0,  // 4: policydecisionpoint.AuthorizationModelRequest.PolicyStore:type_name -> policydecisionpoint.PolicyStore
 	1,  // 5: policydecisionpoint.AuthorizationModelRequest.Principal:type_name -> policydecisionpoint.Principal
 	2,  // 6: policydecisionpoint.AuthorizationModelRequest.Entities:type_name -> policydecisionpoint.Entities
 	3,  // 7: policydecisionpoint.EvaluationRequest.Subject:type_name -> policydecisionpoint.Subject
 	4,  // 8: policydecisionpoint.EvaluationRequest.Resource:type_name -> policydecisionpoint.Resource
 	5,  // 9: policydecisionpoint.EvaluationRequest.Action:type_name -> policydecisionpoint.Action
-	13, // 10: policydecisionpoint.EvaluationRequest.Context:type_name -> google.protobuf.Struct
+	23, // 10: policydecisionpoint.EvaluationRequest.Context:type_name -> google.protobuf.Struct
 	6,  // 11: policydecisionpoint.AuthorizationCheckRequest.AuthorizationModel:type_name -> policydecisionpoint.AuthorizationModelRequest
 	3,  // 12: policydecisionpoint.AuthorizationCheckRequest.Subject:type_name -> policydecisionpoint.Subject
 	4,  // 13: policydecisionpoint.AuthorizationCheckRequest.Resource:type_name -> policydecisionpoint.Resource
 	5,  // 14: policydecisionpoint.AuthorizationCheckRequest.Action:type_name -> policydecisionpoint.Action
-	13, // 15: policydecisionpoint.AuthorizationCheckRequest.Context:type_name -> google.protobuf.Struct
+	23, // 15: policydecisionpoint.AuthorizationCheckRequest.Context:type_name -> google.protobuf.Struct
 	7,  // 16: policydecisionpoint.AuthorizationCheckRequest.Evaluations:type_name -> policydecisionpoint.EvaluationRequest
-	9,  // 17: policydecisionpoint.ContextResponse.ReasonAdmin:type_name -> policydecisionpoint.ReasonResponse
-	9,  // 18: policydecisionpoint.ContextResponse.ReasonUser:type_name -> policydecisionpoint.ReasonResponse
-	10, // 19: policydecisionpoint.EvaluationResponse.Context:type_name -> policydecisionpoint.ContextResponse
-	10, // 20: policydecisionpoint.AuthorizationCheckResponse.Context:type_name -> policydecisionpoint.ContextResponse
-	11, // 21: policydecisionpoint.AuthorizationCheckResponse.Evaluations:type_name -> policydecisionpoint.EvaluationResponse
-	8,  // 22: policydecisionpoint.V1PDPService.AuthorizationCheck:input_type -> policydecisionpoint.AuthorizationCheckRequest
-	12, // 23: policydecisionpoint.V1PDPService.AuthorizationCheck:output_type -> policydecisionpoint.AuthorizationCheckResponse
-	23, // [23:24] is the sub-list for method output_type
-	22, // [22:23] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	8,  // 17: policydecisionpoint.AuthorizationCheckRequest.Options:type_name -> policydecisionpoint.EvaluationsOptions
+	10, // 18: policydecisionpoint.ContextResponse.ReasonAdmin:type_name -> policydecisionpoint.ReasonResponse
+	10, // 19: policydecisionpoint.ContextResponse.ReasonUser:type_name -> policydecisionpoint.ReasonResponse
+	11, // 20: policydecisionpoint.EvaluationResponse.Context:type_name -> policydecisionpoint.ContextResponse
+	11, // 21: policydecisionpoint.AuthorizationCheckResponse.Context:type_name -> policydecisionpoint.ContextResponse
+	12, // 22: policydecisionpoint.AuthorizationCheckResponse.Evaluations:type_name -> policydecisionpoint.EvaluationResponse
+	12, // 23: policydecisionpoint.AuthorizationCheckStreamResponse.Evaluation:type_name -> policydecisionpoint.EvaluationResponse
+	1,  // 24: policydecisionpoint.ReplayEntry.Principal:type_name -> policydecisionpoint.Principal
+	2,  // 25: policydecisionpoint.ReplayEntry.Entities:type_name -> policydecisionpoint.Entities
+	7,  // 26: policydecisionpoint.ReplayEntry.Evaluation:type_name -> policydecisionpoint.EvaluationRequest
+	0,  // 27: policydecisionpoint.AuthorizationReplayRequest.PolicyStore:type_name -> policydecisionpoint.PolicyStore
+	15, // 28: policydecisionpoint.AuthorizationReplayRequest.Entries:type_name -> policydecisionpoint.ReplayEntry
+	11, // 29: policydecisionpoint.ReplayResult.Context:type_name -> policydecisionpoint.ContextResponse
+	17, // 30: policydecisionpoint.AuthorizationReplayResponse.Results:type_name -> policydecisionpoint.ReplayResult
+	6,  // 31: policydecisionpoint.AuthorizationPartialEvaluationRequest.AuthorizationModel:type_name -> policydecisionpoint.AuthorizationModelRequest
+	3,  // 32: policydecisionpoint.AuthorizationPartialEvaluationRequest.Subject:type_name -> policydecisionpoint.Subject
+	4,  // 33: policydecisionpoint.AuthorizationPartialEvaluationRequest.Resource:type_name -> policydecisionpoint.Resource
+	5,  // 34: policydecisionpoint.AuthorizationPartialEvaluationRequest.Action:type_name -> policydecisionpoint.Action
+	23, // 35: policydecisionpoint.AuthorizationPartialEvaluationRequest.Context:type_name -> google.protobuf.Struct
+	21, // 36: policydecisionpoint.ResidualNode.Args:type_name -> policydecisionpoint.ResidualNode
+	24, // 37: policydecisionpoint.ResidualNode.Value:type_name -> google.protobuf.Value
+	20, // 38: policydecisionpoint.ResidualNode.Entity:type_name -> policydecisionpoint.ResidualEntity
+	21, // 39: policydecisionpoint.AuthorizationPartialEvaluationResponse.Residual:type_name -> policydecisionpoint.ResidualNode
+	9,  // 40: policydecisionpoint.V1PDPService.AuthorizationCheck:input_type -> policydecisionpoint.AuthorizationCheckRequest
+	9,  // 41: policydecisionpoint.V1PDPService.AuthorizationCheckStream:input_type -> policydecisionpoint.AuthorizationCheckRequest
+	16, // 42: policydecisionpoint.V1PDPService.AuthorizationReplay:input_type -> policydecisionpoint.AuthorizationReplayRequest
+	19, // 43: policydecisionpoint.V1PDPService.AuthorizationPartialEvaluation:input_type -> policydecisionpoint.AuthorizationPartialEvaluationRequest
+	13, // 44: policydecisionpoint.V1PDPService.AuthorizationCheck:output_type -> policydecisionpoint.AuthorizationCheckResponse
+	14, // 45: policydecisionpoint.V1PDPService.AuthorizationCheckStream:output_type -> policydecisionpoint.AuthorizationCheckStreamResponse
+	18, // 46: policydecisionpoint.V1PDPService.AuthorizationReplay:output_type -> policydecisionpoint.AuthorizationReplayResponse
+	22, // 47: policydecisionpoint.V1PDPService.AuthorizationPartialEvaluation:output_type -> policydecisionpoint.AuthorizationPartialEvaluationResponse
+	44, // [44:48] is the sub-list for method output_type
+	40, // [40:44] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_init() }
@@ -1069,15 +1866,21 @@ func file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_init() {
 	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[6].OneofWrappers = []any{}
 	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[7].OneofWrappers = []any{}
 	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[8].OneofWrappers = []any{}
-	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[11].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[9].OneofWrappers = []any{}
 	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[12].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[13].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[15].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[17].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[19].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[21].OneofWrappers = []any{}
+	file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDesc), len(file_internal_agents_services_pdp_endpoints_api_v1_pdp_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
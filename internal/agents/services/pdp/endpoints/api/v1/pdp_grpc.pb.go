@@ -35,7 +35,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	V1PDPService_AuthorizationCheck_FullMethodName = "/policydecisionpoint.V1PDPService/AuthorizationCheck"
+	V1PDPService_AuthorizationCheck_FullMethodName             = "/policydecisionpoint.V1PDPService/AuthorizationCheck"
+	V1PDPService_AuthorizationCheckStream_FullMethodName       = "/policydecisionpoint.V1PDPService/AuthorizationCheckStream"
+	V1PDPService_AuthorizationReplay_FullMethodName            = "/policydecisionpoint.V1PDPService/AuthorizationReplay"
+	V1PDPService_AuthorizationPartialEvaluation_FullMethodName = "/policydecisionpoint.V1PDPService/AuthorizationPartialEvaluation"
 )
 
 // V1PDPServiceClient is the client API for V1PDPService service.
@@ -45,6 +48,12 @@ const (
 // V1PDPService	is the service for the Policy Decision Point.
 type V1PDPServiceClient interface {
 	AuthorizationCheck(ctx context.Context, in *AuthorizationCheckRequest, opts ...grpc.CallOption) (*AuthorizationCheckResponse, error)
+	// Check the authorization emitting the evaluations in order as soon as they are ready.
+	AuthorizationCheckStream(ctx context.Context, in *AuthorizationCheckRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuthorizationCheckStreamResponse], error)
+	// Replay logged decisions against a historical commit.
+	AuthorizationReplay(ctx context.Context, in *AuthorizationReplayRequest, opts ...grpc.CallOption) (*AuthorizationReplayResponse, error)
+	// Evaluate the authorization with an unknown resource returning the residual condition.
+	AuthorizationPartialEvaluation(ctx context.Context, in *AuthorizationPartialEvaluationRequest, opts ...grpc.CallOption) (*AuthorizationPartialEvaluationResponse, error)
 }
 
 type v1PDPServiceClient struct {
@@ -65,6 +74,45 @@ func (c *v1PDPServiceClient) AuthorizationCheck(ctx context.Context, in *Authori
 	return out, nil
 }
 
+func (c *v1PDPServiceClient) AuthorizationCheckStream(ctx context.Context, in *AuthorizationCheckRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuthorizationCheckStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &V1PDPService_ServiceDesc.Streams[0], V1PDPService_AuthorizationCheckStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AuthorizationCheckRequest, AuthorizationCheckStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type V1PDPService_AuthorizationCheckStreamClient = grpc.ServerStreamingClient[AuthorizationCheckStreamResponse]
+
+func (c *v1PDPServiceClient) AuthorizationReplay(ctx context.Context, in *AuthorizationReplayRequest, opts ...grpc.CallOption) (*AuthorizationReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorizationReplayResponse)
+	err := c.cc.Invoke(ctx, V1PDPService_AuthorizationReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *v1PDPServiceClient) AuthorizationPartialEvaluation(ctx context.Context, in *AuthorizationPartialEvaluationRequest, opts ...grpc.CallOption) (*AuthorizationPartialEvaluationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorizationPartialEvaluationResponse)
+	err := c.cc.Invoke(ctx, V1PDPService_AuthorizationPartialEvaluation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // V1PDPServiceServer is the server API for V1PDPService service.
 // All implementations must embed UnimplementedV1PDPServiceServer
 // for forward compatibility.
@@ -72,6 +120,12 @@ func (c *v1PDPServiceClient) AuthorizationCheck(ctx context.Context, in *Authori
 // V1PDPService	is the service for the Policy Decision Point.
 type V1PDPServiceServer interface {
 	AuthorizationCheck(context.Context, *AuthorizationCheckRequest) (*AuthorizationCheckResponse, error)
+	// Check the authorization emitting the evaluations in order as soon as they are ready.
+	AuthorizationCheckStream(*AuthorizationCheckRequest, grpc.ServerStreamingServer[AuthorizationCheckStreamResponse]) error
+	// Replay logged decisions against a historical commit.
+	AuthorizationReplay(context.Context, *AuthorizationReplayRequest) (*AuthorizationReplayResponse, error)
+	// Evaluate the authorization with an unknown resource returning the residual condition.
+	AuthorizationPartialEvaluation(context.Context, *AuthorizationPartialEvaluationRequest) (*AuthorizationPartialEvaluationResponse, error)
 	mustEmbedUnimplementedV1PDPServiceServer()
 }
 
@@ -85,6 +139,15 @@ type UnimplementedV1PDPServiceServer struct{}
 func (UnimplementedV1PDPServiceServer) AuthorizationCheck(context.Context, *AuthorizationCheckRequest) (*AuthorizationCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AuthorizationCheck not implemented")
 }
+func (UnimplementedV1PDPServiceServer) AuthorizationCheckStream(*AuthorizationCheckRequest, grpc.ServerStreamingServer[AuthorizationCheckStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method AuthorizationCheckStream not implemented")
+}
+func (UnimplementedV1PDPServiceServer) AuthorizationReplay(context.Context, *AuthorizationReplayRequest) (*AuthorizationReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizationReplay not implemented")
+}
+func (UnimplementedV1PDPServiceServer) AuthorizationPartialEvaluation(context.Context, *AuthorizationPartialEvaluationRequest) (*AuthorizationPartialEvaluationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizationPartialEvaluation not implemented")
+}
 func (UnimplementedV1PDPServiceServer) mustEmbedUnimplementedV1PDPServiceServer() {}
 func (UnimplementedV1PDPServiceServer) testEmbeddedByValue()                      {}
 
@@ -124,6 +187,53 @@ func _V1PDPService_AuthorizationCheck_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _V1PDPService_AuthorizationCheckStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuthorizationCheckRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(V1PDPServiceServer).AuthorizationCheckStream(m, &grpc.GenericServerStream[AuthorizationCheckRequest, AuthorizationCheckStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type V1PDPService_AuthorizationCheckStreamServer = grpc.ServerStreamingServer[AuthorizationCheckStreamResponse]
+
+func _V1PDPService_AuthorizationReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(V1PDPServiceServer).AuthorizationReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: V1PDPService_AuthorizationReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(V1PDPServiceServer).AuthorizationReplay(ctx, req.(*AuthorizationReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _V1PDPService_AuthorizationPartialEvaluation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationPartialEvaluationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(V1PDPServiceServer).AuthorizationPartialEvaluation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: V1PDPService_AuthorizationPartialEvaluation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(V1PDPServiceServer).AuthorizationPartialEvaluation(ctx, req.(*AuthorizationPartialEvaluationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // V1PDPService_ServiceDesc is the grpc.ServiceDesc for V1PDPService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,7 +245,21 @@ var V1PDPService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AuthorizationCheck",
 			Handler:    _V1PDPService_AuthorizationCheck_Handler,
 		},
+		{
+			MethodName: "AuthorizationReplay",
+			Handler:    _V1PDPService_AuthorizationReplay_Handler,
+		},
+		{
+			MethodName: "AuthorizationPartialEvaluation",
+			Handler:    _V1PDPService_AuthorizationPartialEvaluation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AuthorizationCheckStream",
+			Handler:       _V1PDPService_AuthorizationCheckStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/agents/services/pdp/endpoints/api/v1/pdp.proto",
 }
@@ -17,8 +17,11 @@
 package v1
 
 import (
+	"math"
+
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/permguard/permguard/pkg/authz/residuals"
 	"github.com/permguard/permguard/pkg/transport/models/pdp"
 )
 
@@ -418,6 +421,12 @@ func MapGrpcAuthorizationCheckRequestToAgentAuthorizationCheckRequest(request *A
 	} else {
 		req.Evaluations = []pdp.EvaluationRequest{}
 	}
+	if request.Options != nil {
+		req.Options = &pdp.EvaluationsOptions{}
+		if request.Options.EvaluationsSemantic != nil {
+			req.Options.EvaluationsSemantic = *request.Options.EvaluationsSemantic
+		}
+	}
 	return req, nil
 }
 
@@ -482,6 +491,12 @@ func MapAgentAuthorizationCheckRequestToGrpcAuthorizationCheckRequest(request *p
 		}
 		req.Evaluations = evaluations
 	}
+	if request.Options != nil {
+		req.Options = &EvaluationsOptions{}
+		if len(request.Options.EvaluationsSemantic) > 0 {
+			req.Options.EvaluationsSemantic = &request.Options.EvaluationsSemantic
+		}
+	}
 	return req, nil
 }
 
@@ -603,6 +618,9 @@ func MapAgentAuthorizationCheckResponseToGrpcAuthorizationCheckResponse(response
 	target := &AuthorizationCheckResponse{}
 	target.RequestID = &response.RequestID
 	target.Decision = response.Decision
+	if len(response.PolicyStoreVersion) > 0 {
+		target.PolicyStoreVersion = &response.PolicyStoreVersion
+	}
 	if response.Context != nil {
 		context, err := MapAgentContextResponseToGrpcContextResponse(response.Context)
 		if err != nil {
@@ -636,6 +654,9 @@ func MapGrpcAuthorizationCheckResponseToAgentAuthorizationCheckResponse(response
 	} else {
 		target.RequestID = ""
 	}
+	if response.PolicyStoreVersion != nil {
+		target.PolicyStoreVersion = *response.PolicyStoreVersion
+	}
 	if response.Context != nil {
 		context, err := MapGrpcContextResponseToAgentContextResponse(response.Context)
 		if err != nil {
@@ -656,3 +677,430 @@ func MapGrpcAuthorizationCheckResponseToAgentAuthorizationCheckResponse(response
 	}
 	return target, nil
 }
+
+// MapAgentAuthorizationCheckStreamResponseToGrpcAuthorizationCheckStreamResponse maps the agent authorization check stream response to the gRPC authorization check stream response.
+func MapAgentAuthorizationCheckStreamResponseToGrpcAuthorizationCheckStreamResponse(response *pdp.AuthorizationCheckStreamResponse) (*AuthorizationCheckStreamResponse, error) {
+	if response == nil {
+		return nil, nil
+	}
+	evaluation, err := MapAgentEvaluationResponseToGrpcEvaluationResponse(&response.Evaluation)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorizationCheckStreamResponse{
+		Index:      int32(response.Index),
+		Evaluation: evaluation,
+	}, nil
+}
+
+// MapGrpcAuthorizationCheckStreamResponseToAgentAuthorizationCheckStreamResponse maps the gRPC authorization check stream response to the agent authorization check stream response.
+func MapGrpcAuthorizationCheckStreamResponseToAgentAuthorizationCheckStreamResponse(response *AuthorizationCheckStreamResponse) (*pdp.AuthorizationCheckStreamResponse, error) {
+	if response == nil {
+		return nil, nil
+	}
+	target := &pdp.AuthorizationCheckStreamResponse{}
+	target.Index = int(response.Index)
+	if response.Evaluation != nil {
+		evaluation, err := MapGrpcEvaluationResponseToAgentEvaluationResponse(response.Evaluation)
+		if err != nil {
+			return nil, err
+		}
+		target.Evaluation = *evaluation
+	}
+	return target, nil
+}
+
+// MapGrpcReplayEntryToAgentReplayEntry maps the gRPC replay entry to the agent replay entry.
+func MapGrpcReplayEntryToAgentReplayEntry(entry *ReplayEntry) (*pdp.ReplayEntry, error) {
+	if entry == nil {
+		return nil, nil
+	}
+	target := &pdp.ReplayEntry{}
+	target.Decision = entry.Decision
+	if entry.Principal != nil {
+		principal, err := MapGrpcPrincipalToAgentPrincipal(entry.Principal)
+		if err != nil {
+			return nil, err
+		}
+		target.Principal = principal
+	}
+	if entry.Entities != nil {
+		entities, err := MapGrpcEntitiesToAgentEntities(entry.Entities)
+		if err != nil {
+			return nil, err
+		}
+		target.Entities = entities
+	}
+	if entry.Evaluation != nil {
+		evaluation, err := MapGrpcEvaluationRequestToAgentEvaluationRequest(entry.Evaluation)
+		if err != nil {
+			return nil, err
+		}
+		target.Evaluation = evaluation
+	}
+	return target, nil
+}
+
+// MapAgentReplayEntryToGrpcReplayEntry maps the agent replay entry to the gRPC replay entry.
+func MapAgentReplayEntryToGrpcReplayEntry(entry *pdp.ReplayEntry) (*ReplayEntry, error) {
+	if entry == nil {
+		return nil, nil
+	}
+	target := &ReplayEntry{}
+	target.Decision = entry.Decision
+	if entry.Principal != nil {
+		principal, err := MapAgentPrincipalToGrpcPrincipal(entry.Principal)
+		if err != nil {
+			return nil, err
+		}
+		target.Principal = principal
+	}
+	if entry.Entities != nil {
+		entities, err := MapAgentEntitiesToGrpcEntities(entry.Entities)
+		if err != nil {
+			return nil, err
+		}
+		target.Entities = entities
+	}
+	if entry.Evaluation != nil {
+		evaluation, err := MapAgentEvaluationRequestToGrpcEvaluationRequest(entry.Evaluation)
+		if err != nil {
+			return nil, err
+		}
+		target.Evaluation = evaluation
+	}
+	return target, nil
+}
+
+// MapGrpcAuthorizationReplayRequestToAgentAuthorizationReplayRequest maps the gRPC authorization replay request to the agent authorization replay request.
+func MapGrpcAuthorizationReplayRequestToAgentAuthorizationReplayRequest(request *AuthorizationReplayRequest) (*pdp.AuthorizationReplayRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+	target := &pdp.AuthorizationReplayRequest{}
+	target.ZoneID = request.ZoneID
+	target.CommitID = request.CommitID
+	if request.PolicyStore != nil {
+		policyStore, err := MapGrpcPolicyStoreToAgentPolicyStore(request.PolicyStore)
+		if err != nil {
+			return nil, err
+		}
+		target.PolicyStore = policyStore
+	}
+	if request.Entries != nil {
+		entries := []pdp.ReplayEntry{}
+		for _, replayEntry := range request.Entries {
+			entry, err := MapGrpcReplayEntryToAgentReplayEntry(replayEntry)
+			if err != nil {
+				return nil, err
+			}
+			if entry == nil {
+				continue
+			}
+			entries = append(entries, *entry)
+		}
+		target.Entries = entries
+	}
+	return target, nil
+}
+
+// MapAgentAuthorizationReplayRequestToGrpcAuthorizationReplayRequest maps the agent authorization replay request to the gRPC authorization replay request.
+func MapAgentAuthorizationReplayRequestToGrpcAuthorizationReplayRequest(request *pdp.AuthorizationReplayRequest) (*AuthorizationReplayRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+	target := &AuthorizationReplayRequest{}
+	target.ZoneID = request.ZoneID
+	target.CommitID = request.CommitID
+	if request.PolicyStore != nil {
+		policyStore, err := MapAgentPolicyStoreToGrpcPolicyStore(request.PolicyStore)
+		if err != nil {
+			return nil, err
+		}
+		target.PolicyStore = policyStore
+	}
+	if request.Entries != nil {
+		entries := []*ReplayEntry{}
+		for _, replayEntry := range request.Entries {
+			entry, err := MapAgentReplayEntryToGrpcReplayEntry(&replayEntry)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+		target.Entries = entries
+	}
+	return target, nil
+}
+
+// MapAgentReplayResultToGrpcReplayResult maps the agent replay result to the gRPC replay result.
+func MapAgentReplayResultToGrpcReplayResult(result *pdp.ReplayResult) (*ReplayResult, error) {
+	if result == nil {
+		return nil, nil
+	}
+	target := &ReplayResult{}
+	target.RequestID = &result.RequestID
+	target.LoggedDecision = result.LoggedDecision
+	target.Decision = result.Decision
+	target.Diverged = result.Diverged
+	if result.Context != nil {
+		context, err := MapAgentContextResponseToGrpcContextResponse(result.Context)
+		if err != nil {
+			return nil, err
+		}
+		target.Context = context
+	}
+	return target, nil
+}
+
+// MapGrpcReplayResultToAgentReplayResult maps the gRPC replay result to the agent replay result.
+func MapGrpcReplayResultToAgentReplayResult(result *ReplayResult) (*pdp.ReplayResult, error) {
+	if result == nil {
+		return nil, nil
+	}
+	target := &pdp.ReplayResult{}
+	if result.RequestID != nil {
+		target.RequestID = *result.RequestID
+	}
+	target.LoggedDecision = result.LoggedDecision
+	target.Decision = result.Decision
+	target.Diverged = result.Diverged
+	if result.Context != nil {
+		context, err := MapGrpcContextResponseToAgentContextResponse(result.Context)
+		if err != nil {
+			return nil, err
+		}
+		target.Context = context
+	}
+	return target, nil
+}
+
+// MapAgentAuthorizationReplayResponseToGrpcAuthorizationReplayResponse maps the agent authorization replay response to the gRPC authorization replay response.
+func MapAgentAuthorizationReplayResponseToGrpcAuthorizationReplayResponse(response *pdp.AuthorizationReplayResponse) (*AuthorizationReplayResponse, error) {
+	if response == nil {
+		return nil, nil
+	}
+	target := &AuthorizationReplayResponse{}
+	target.CommitID = response.CommitID
+	target.Divergences = response.Divergences
+	if response.Results != nil {
+		results := []*ReplayResult{}
+		for _, replayResult := range response.Results {
+			result, err := MapAgentReplayResultToGrpcReplayResult(&replayResult)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		target.Results = results
+	}
+	return target, nil
+}
+
+// MapGrpcAuthorizationReplayResponseToAgentAuthorizationReplayResponse maps the gRPC authorization replay response to the agent authorization replay response.
+func MapGrpcAuthorizationReplayResponseToAgentAuthorizationReplayResponse(response *AuthorizationReplayResponse) (*pdp.AuthorizationReplayResponse, error) {
+	if response == nil {
+		return nil, nil
+	}
+	target := &pdp.AuthorizationReplayResponse{}
+	target.CommitID = response.CommitID
+	target.Divergences = response.Divergences
+	if response.Results != nil {
+		results := []pdp.ReplayResult{}
+		for _, replayResult := range response.Results {
+			result, err := MapGrpcReplayResultToAgentReplayResult(replayResult)
+			if err != nil {
+				return nil, err
+			}
+			if result == nil {
+				continue
+			}
+			results = append(results, *result)
+		}
+		target.Results = results
+	}
+	return target, nil
+}
+
+// MapGrpcAuthorizationPartialEvaluationRequestToAgentAuthorizationPartialEvaluationRequest maps the gRPC authorization partial evaluation request to the agent authorization partial evaluation request.
+func MapGrpcAuthorizationPartialEvaluationRequestToAgentAuthorizationPartialEvaluationRequest(request *AuthorizationPartialEvaluationRequest) (*pdp.AuthorizationPartialEvaluationRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+	target := &pdp.AuthorizationPartialEvaluationRequest{}
+	if request.RequestID != nil {
+		target.RequestID = *request.RequestID
+	}
+	if request.AuthorizationModel != nil {
+		authorizationModel, err := MapGrpcAuthorizationModelRequestToAgentAuthorizationModelRequest(request.AuthorizationModel)
+		if err != nil {
+			return nil, err
+		}
+		target.AuthorizationModel = authorizationModel
+	}
+	subject, err := MapGrpcSubjectToAgentSubject(request.Subject)
+	if err != nil {
+		return nil, err
+	}
+	target.Subject = subject
+	resource, err := MapGrpcResourceToAgentResource(request.Resource)
+	if err != nil {
+		return nil, err
+	}
+	target.Resource = resource
+	action, err := MapGrpcActionToAgentAction(request.Action)
+	if err != nil {
+		return nil, err
+	}
+	target.Action = action
+	if request.Context != nil {
+		target.Context = request.Context.AsMap()
+	} else {
+		target.Context = map[string]any{}
+	}
+	target.UnknownContext = request.UnknownContext
+	return target, nil
+}
+
+// MapAgentAuthorizationPartialEvaluationRequestToGrpcAuthorizationPartialEvaluationRequest maps the agent authorization partial evaluation request to the gRPC authorization partial evaluation request.
+func MapAgentAuthorizationPartialEvaluationRequestToGrpcAuthorizationPartialEvaluationRequest(request *pdp.AuthorizationPartialEvaluationRequest) (*AuthorizationPartialEvaluationRequest, error) {
+	if request == nil {
+		return nil, nil
+	}
+	target := &AuthorizationPartialEvaluationRequest{}
+	if len(request.RequestID) > 0 {
+		target.RequestID = &request.RequestID
+	}
+	if request.AuthorizationModel != nil {
+		authorizationModel, err := MapAgentAuthorizationModelRequestToGrpcAuthorizationModelRequest(request.AuthorizationModel)
+		if err != nil {
+			return nil, err
+		}
+		target.AuthorizationModel = authorizationModel
+	}
+	subject, err := MapAgentSubjectToGrpcSubject(request.Subject)
+	if err != nil {
+		return nil, err
+	}
+	target.Subject = subject
+	resource, err := MapAgentResourceToGrpcResource(request.Resource)
+	if err != nil {
+		return nil, err
+	}
+	target.Resource = resource
+	action, err := MapAgentActionToGrpcAction(request.Action)
+	if err != nil {
+		return nil, err
+	}
+	target.Action = action
+	if request.Context != nil {
+		data, err := structpb.NewStruct(request.Context)
+		if err != nil {
+			return nil, err
+		}
+		target.Context = data
+	}
+	target.UnknownContext = request.UnknownContext
+	return target, nil
+}
+
+// MapAgentResidualNodeToGrpcResidualNode maps the agent residual node to the gRPC residual node.
+func MapAgentResidualNodeToGrpcResidualNode(node *residuals.Node) (*ResidualNode, error) {
+	if node == nil {
+		return nil, nil
+	}
+	target := &ResidualNode{}
+	target.Op = node.Op
+	target.Path = node.Path
+	if node.Value != nil {
+		value, err := structpb.NewValue(node.Value)
+		if err != nil {
+			return nil, err
+		}
+		target.Value = value
+	}
+	if node.Entity != nil {
+		target.Entity = &ResidualEntity{
+			Type: node.Entity.Type,
+			ID:   node.Entity.ID,
+		}
+	}
+	for _, arg := range node.Args {
+		residualArg, err := MapAgentResidualNodeToGrpcResidualNode(arg)
+		if err != nil {
+			return nil, err
+		}
+		target.Args = append(target.Args, residualArg)
+	}
+	return target, nil
+}
+
+// MapGrpcResidualNodeToAgentResidualNode maps the gRPC residual node to the agent residual node.
+func MapGrpcResidualNodeToAgentResidualNode(node *ResidualNode) (*residuals.Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+	target := &residuals.Node{}
+	target.Op = node.Op
+	target.Path = node.Path
+	if node.Value != nil {
+		value := node.Value.AsInterface()
+		// The numbers are carried as doubles, the integral ones are restored as longs.
+		if number, ok := value.(float64); ok && number == math.Trunc(number) && math.Abs(number) <= math.MaxInt64 {
+			value = int64(number)
+		}
+		target.Value = value
+	}
+	if node.Entity != nil {
+		target.Entity = &residuals.Entity{
+			Type: node.Entity.Type,
+			ID:   node.Entity.ID,
+		}
+	}
+	for _, arg := range node.Args {
+		residualArg, err := MapGrpcResidualNodeToAgentResidualNode(arg)
+		if err != nil {
+			return nil, err
+		}
+		target.Args = append(target.Args, residualArg)
+	}
+	return target, nil
+}
+
+// MapAgentAuthorizationPartialEvaluationResponseToGrpcAuthorizationPartialEvaluationResponse maps the agent authorization partial evaluation response to the gRPC authorization partial evaluation response.
+func MapAgentAuthorizationPartialEvaluationResponseToGrpcAuthorizationPartialEvaluationResponse(response *pdp.AuthorizationPartialEvaluationResponse) (*AuthorizationPartialEvaluationResponse, error) {
+	if response == nil {
+		return nil, nil
+	}
+	target := &AuthorizationPartialEvaluationResponse{}
+	if len(response.RequestID) > 0 {
+		target.RequestID = &response.RequestID
+	}
+	target.Decision = response.Decision
+	target.Policies = response.Policies
+	residual, err := MapAgentResidualNodeToGrpcResidualNode(response.Residual)
+	if err != nil {
+		return nil, err
+	}
+	target.Residual = residual
+	return target, nil
+}
+
+// MapGrpcAuthorizationPartialEvaluationResponseToAgentAuthorizationPartialEvaluationResponse maps the gRPC authorization partial evaluation response to the agent authorization partial evaluation response.
+func MapGrpcAuthorizationPartialEvaluationResponseToAgentAuthorizationPartialEvaluationResponse(response *AuthorizationPartialEvaluationResponse) (*pdp.AuthorizationPartialEvaluationResponse, error) {
+	if response == nil {
+		return nil, nil
+	}
+	target := &pdp.AuthorizationPartialEvaluationResponse{}
+	if response.RequestID != nil {
+		target.RequestID = *response.RequestID
+	}
+	target.Decision = response.Decision
+	target.Policies = response.Policies
+	residual, err := MapGrpcResidualNodeToAgentResidualNode(response.Residual)
+	if err != nil {
+		return nil, err
+	}
+	target.Residual = residual
+	return target, nil
+}
@@ -19,22 +19,46 @@ package v1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/permguard/permguard/pkg/agents/services"
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/agents/telemetry"
 	"github.com/permguard/permguard/pkg/transport/models/pdp"
 	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
 	"go.uber.org/zap"
 )
 
+// mapStorageError maps storage sentinel errors to gRPC status codes.
+func mapStorageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, azstorage.ErrNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, azstorage.ErrInvalidInput):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "internal error")
+	}
+}
+
 // PDPService is the service for the PDP.
 type PDPService interface {
 	// AuthorizationCheck checks the authorization.
 	AuthorizationCheck(ctx context.Context, request *pdp.AuthorizationCheckWithDefaultsRequest) (*pdp.AuthorizationCheckResponse, error)
+	// AuthorizationCheckStream checks the authorization emitting the evaluations in order as soon as they are ready.
+	AuthorizationCheckStream(ctx context.Context, request *pdp.AuthorizationCheckWithDefaultsRequest, emit func(index int, evaluation *pdp.EvaluationResponse) error) (*pdp.AuthorizationCheckResponse, error)
+	// AuthorizationReplay re-evaluates logged decisions against a commit.
+	AuthorizationReplay(ctx context.Context, request *pdp.AuthorizationReplayRequest) (*pdp.AuthorizationReplayResponse, error)
+	// AuthorizationPartialEvaluation evaluates the authorization with an unknown resource returning the residual condition.
+	AuthorizationPartialEvaluation(ctx context.Context, request *pdp.AuthorizationPartialEvaluationRequest) (*pdp.AuthorizationPartialEvaluationResponse, error)
 }
 
 // NewPDPServer creates a new PDP server.
@@ -99,3 +123,90 @@ func (s *PDPServer) AuthorizationCheck(ctx context.Context, request *Authorizati
 	}
 	return resp, nil
 }
+
+// AuthorizationCheckStream checks the authorization emitting the evaluations in order as soon as they are ready.
+// Request errors are returned as the status of the stream.
+func (s *PDPServer) AuthorizationCheckStream(request *AuthorizationCheckRequest, stream grpc.ServerStreamingServer[AuthorizationCheckStreamResponse]) (retErr error) {
+	ctx := stream.Context()
+	ctx, span := telemetry.Tracer().Start(ctx, "grpc.pdp.AuthorizationCheckStream")
+	defer span.End()
+	defer func() {
+		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pdp.AuthorizationCheckStream"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
+	}()
+	req, err := MapGrpcAuthorizationCheckRequestToAgentAuthorizationCheckRequest(request)
+	if req == nil {
+		span.SetStatus(otelcodes.Error, "nil request")
+		return status.Errorf(codes.InvalidArgument, "pdp-endpoint: request cannot be nil: %v", err)
+	}
+	authzResponse, err := s.service.AuthorizationCheckStream(ctx, req, func(index int, evaluation *pdp.EvaluationResponse) error {
+		resp, err := MapAgentAuthorizationCheckStreamResponseToGrpcAuthorizationCheckStreamResponse(&pdp.AuthorizationCheckStreamResponse{
+			Index:      index,
+			Evaluation: *evaluation,
+		})
+		if err != nil {
+			return err
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return status.Errorf(codes.Internal, "pdp-endpoint: failed to stream the authorization check: %v", err)
+	}
+	if len(authzResponse.Evaluations) == 0 && authzResponse.Context != nil && authzResponse.Context.ReasonAdmin != nil {
+		reason := authzResponse.Context.ReasonAdmin
+		span.SetStatus(otelcodes.Error, reason.Message)
+		if reason.Code == authzen.AuthzErrBadRequestCode {
+			return status.Errorf(codes.InvalidArgument, "pdp-endpoint: %s", reason.Message)
+		}
+		return status.Errorf(codes.Internal, "pdp-endpoint: %s", reason.Message)
+	}
+	return nil
+}
+
+// AuthorizationReplay re-evaluates logged decisions against a commit.
+func (s *PDPServer) AuthorizationReplay(ctx context.Context, request *AuthorizationReplayRequest) (_ *AuthorizationReplayResponse, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "grpc.pdp.AuthorizationReplay")
+	defer span.End()
+	defer func() {
+		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pdp.AuthorizationReplay"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
+	}()
+	req, err := MapGrpcAuthorizationReplayRequestToAgentAuthorizationReplayRequest(request)
+	if err != nil || req == nil {
+		span.SetStatus(otelcodes.Error, "invalid request")
+		return nil, status.Errorf(codes.InvalidArgument, "pdp-endpoint: invalid replay request: %v", err)
+	}
+	replayResponse, err := s.service.AuthorizationReplay(ctx, req)
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return nil, mapStorageError(err)
+	}
+	resp, err := MapAgentAuthorizationReplayResponseToGrpcAuthorizationReplayResponse(replayResponse)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "pdp-endpoint: failed to map authorization replay response: %v", err)
+	}
+	return resp, nil
+}
+
+// AuthorizationPartialEvaluation evaluates the authorization with an unknown resource returning the residual condition.
+func (s *PDPServer) AuthorizationPartialEvaluation(ctx context.Context, request *AuthorizationPartialEvaluationRequest) (_ *AuthorizationPartialEvaluationResponse, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "grpc.pdp.AuthorizationPartialEvaluation")
+	defer span.End()
+	defer func() {
+		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("pdp.AuthorizationPartialEvaluation"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
+	}()
+	req, err := MapGrpcAuthorizationPartialEvaluationRequestToAgentAuthorizationPartialEvaluationRequest(request)
+	if err != nil || req == nil {
+		span.SetStatus(otelcodes.Error, "invalid request")
+		return nil, status.Errorf(codes.InvalidArgument, "pdp-endpoint: invalid partial evaluation request: %v", err)
+	}
+	partialResponse, err := s.service.AuthorizationPartialEvaluation(ctx, req)
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		return nil, mapStorageError(err)
+	}
+	resp, err := MapAgentAuthorizationPartialEvaluationResponseToGrpcAuthorizationPartialEvaluationResponse(partialResponse)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "pdp-endpoint: failed to map authorization partial evaluation response: %v", err)
+	}
+	return resp, nil
+}
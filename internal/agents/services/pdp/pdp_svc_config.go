@@ -37,6 +37,9 @@ const (
 	flagCentralEngine        = "engine-central"
 	flagDataFetchMaxPageSize = "data-fetch-maxpagesize"
 	flagSuffixDecisionLog    = "decision-log"
+	flagRequireSignedCommits = "require-signed-commits"
+	flagEvaluationWorkers    = "evaluation-workers"
+	flagMaxEvaluations       = "max-evaluations"
 )
 
 // ServiceConfig holds the configuration for the server.
@@ -47,6 +50,9 @@ type ServiceConfig struct {
 	storageCentralEngine storage.Kind
 	dataFetchMaxPageSize int
 	decisionLog          decisions.DecisionLogKind
+	requireSignedCommits bool
+	evaluationWorkers    int
+	maxEvaluations       int
 }
 
 // NewServiceConfig creates a new server factory configuration.
@@ -63,6 +69,9 @@ func (c *ServiceConfig) AddFlags(flagSet *flag.FlagSet) error {
 	flagSet.String(options.FlagName(flagStoragePDPPrefix, flagCentralEngine), "", "data storage engine to be used for central data; this overrides the --storage-engine-central option")
 	flagSet.Int(options.FlagName(flagServerPDPPrefix, flagDataFetchMaxPageSize), 10000, "maximum number of items to fetch per request")
 	flagSet.String(options.FlagName(flagServerPDPPrefix, flagSuffixDecisionLog), decisions.DecisionLogNone.String(), "specifies where to send decision logs output type")
+	flagSet.Bool(options.FlagName(flagServerPDPPrefix, flagRequireSignedCommits), false, "refuse to load policy stores whose head commit is not signed by a key trusted by the ledger")
+	flagSet.Int(options.FlagName(flagServerPDPPrefix, flagEvaluationWorkers), 8, "maximum number of evaluations of a request to be run concurrently")
+	flagSet.Int(options.FlagName(flagServerPDPPrefix, flagMaxEvaluations), 1000, "maximum number of evaluations per request")
 	return nil
 }
 
@@ -102,6 +111,27 @@ func (c *ServiceConfig) InitFromViper(v *viper.Viper) error {
 	}
 	c.config[flagSuffixDecisionLog] = decisionLogType
 	c.decisionLog = decisionLogType
+	// retrieve the signed commits strict mode
+	flagName = options.FlagName(flagServerPDPPrefix, flagRequireSignedCommits)
+	requireSignedCommits := v.GetBool(flagName)
+	c.config[flagRequireSignedCommits] = requireSignedCommits
+	c.requireSignedCommits = requireSignedCommits
+	// retrieve the evaluation workers
+	flagName = options.FlagName(flagServerPDPPrefix, flagEvaluationWorkers)
+	evaluationWorkers := v.GetInt(flagName)
+	if evaluationWorkers <= 0 {
+		return errors.New("pdp-service: invalid evaluation workers")
+	}
+	c.config[flagEvaluationWorkers] = evaluationWorkers
+	c.evaluationWorkers = evaluationWorkers
+	// retrieve the max evaluations
+	flagName = options.FlagName(flagServerPDPPrefix, flagMaxEvaluations)
+	maxEvaluations := v.GetInt(flagName)
+	if maxEvaluations <= 0 {
+		return errors.New("pdp-service: invalid max evaluations")
+	}
+	c.config[flagMaxEvaluations] = maxEvaluations
+	c.maxEvaluations = maxEvaluations
 	return nil
 }
 
@@ -130,6 +160,21 @@ func (c *ServiceConfig) DecisionLog() string {
 	return string(c.decisionLog)
 }
 
+// RequireSignedCommits returns true if policy stores must be loaded from commits signed by a trusted key.
+func (c *ServiceConfig) RequireSignedCommits() bool {
+	return c.requireSignedCommits
+}
+
+// EvaluationWorkers returns the maximum number of evaluations of a request to be run concurrently.
+func (c *ServiceConfig) EvaluationWorkers() int {
+	return c.evaluationWorkers
+}
+
+// MaxEvaluations returns the maximum number of evaluations per request.
+func (c *ServiceConfig) MaxEvaluations() int {
+	return c.maxEvaluations
+}
+
 // Service returns the service kind.
 func (c *ServiceConfig) Service() services.ServiceKind {
 	return c.service
@@ -21,6 +21,7 @@ import (
 
 	"github.com/permguard/permguard/pkg/agents/services"
 	"github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -54,12 +55,17 @@ func (s ZAPController) UpdateZone(ctx context.Context, zone *zap.Zone) (*zap.Zon
 	return s.storage.UpdateZone(ctx, zone)
 }
 
-// DeleteZone delete a zone.
-func (s ZAPController) DeleteZone(ctx context.Context, zoneID int64) (*zap.Zone, error) {
-	return s.storage.DeleteZone(ctx, zoneID)
+// DeleteZone soft deletes a zone.
+func (s ZAPController) DeleteZone(ctx context.Context, zoneID int64, options *models.DeleteOptions) (*zap.Zone, error) {
+	return s.storage.DeleteZone(ctx, zoneID, options)
 }
 
-// FetchZones returns all zones filtering by search criteria.
-func (s ZAPController) FetchZones(ctx context.Context, page int32, pageSize int32, fields map[string]any) ([]zap.Zone, error) {
-	return s.storage.FetchZones(ctx, page, pageSize, fields)
+// UndeleteZone restores a soft deleted zone.
+func (s ZAPController) UndeleteZone(ctx context.Context, zoneID int64) (*zap.Zone, error) {
+	return s.storage.UndeleteZone(ctx, zoneID)
+}
+
+// FetchZonesPage returns a page of zones filtering by search criteria, sorted and resumed as per the options.
+func (s ZAPController) FetchZonesPage(ctx context.Context, page int32, pageSize int32, fields map[string]any, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error) {
+	return s.storage.FetchZonesPage(ctx, page, pageSize, fields, options)
 }
@@ -45,6 +45,12 @@ type ZoneFetchRequest struct {
 	PageSize      *int32                 `protobuf:"varint,2,opt,name=PageSize,proto3,oneof" json:"PageSize,omitempty"`
 	ZoneID        *int64                 `protobuf:"varint,3,opt,name=ZoneID,proto3,oneof" json:"ZoneID,omitempty"`
 	Name          *string                `protobuf:"bytes,4,opt,name=Name,proto3,oneof" json:"Name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NamePrefix    *string                `protobuf:"bytes,6,opt,name=NamePrefix,proto3,oneof" json:"NamePrefix,omitempty"`
+	SortBy        *string                `protobuf:"bytes,7,opt,name=SortBy,proto3,oneof" json:"SortBy,omitempty"`
+	SortDesc      *bool                  `protobuf:"varint,8,opt,name=SortDesc,proto3,oneof" json:"SortDesc,omitempty"`
+	Cursor        *string                `protobuf:"bytes,9,opt,name=Cursor,proto3,oneof" json:"Cursor,omitempty"`
+	Deleted       *bool                  `protobuf:"varint,10,opt,name=Deleted,proto3,oneof" json:"Deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -107,17 +113,106 @@ func (x *ZoneFetchRequest) GetName() string {
 	return ""
 }
 
+func (x *ZoneFetchRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ZoneFetchRequest) GetNamePrefix() string {
+	if x != nil && x.NamePrefix != nil {
+		return *x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ZoneFetchRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *ZoneFetchRequest) GetSortDesc() bool {
+	if x != nil && x.SortDesc != nil {
+		return *x.SortDesc
+	}
+	return false
+}
+
+func (x *ZoneFetchRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ZoneFetchRequest) GetDeleted() bool {
+	if x != nil && x.Deleted != nil {
+		return *x.Deleted
+	}
+	return false
+}
+
+// Zone labels.
+type ZoneLabels struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       map[string]string      `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZoneLabels) Reset() {
+	*x = ZoneLabels{}
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZoneLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneLabels) ProtoMessage() {}
+
+func (x *ZoneLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneLabels.ProtoReflect.Descriptor instead.
+func (*ZoneLabels) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ZoneLabels) GetEntries() map[string]string {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 // Zone create request.
 type ZoneCreateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=Description,proto3" json:"Description,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ZoneCreateRequest) Reset() {
 	*x = ZoneCreateRequest{}
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[1]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -129,7 +224,7 @@ func (x *ZoneCreateRequest) String() string {
 func (*ZoneCreateRequest) ProtoMessage() {}
 
 func (x *ZoneCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[1]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,7 +237,7 @@ func (x *ZoneCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZoneCreateRequest.ProtoReflect.Descriptor instead.
 func (*ZoneCreateRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{1}
+	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ZoneCreateRequest) GetName() string {
@@ -152,18 +247,34 @@ func (x *ZoneCreateRequest) GetName() string {
 	return ""
 }
 
+func (x *ZoneCreateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ZoneCreateRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // Zone update request.
 type ZoneUpdateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=Description,proto3,oneof" json:"Description,omitempty"`
+	Labels        *ZoneLabels            `protobuf:"bytes,4,opt,name=Labels,proto3" json:"Labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ZoneUpdateRequest) Reset() {
 	*x = ZoneUpdateRequest{}
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[2]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -175,7 +286,7 @@ func (x *ZoneUpdateRequest) String() string {
 func (*ZoneUpdateRequest) ProtoMessage() {}
 
 func (x *ZoneUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[2]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -188,7 +299,7 @@ func (x *ZoneUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZoneUpdateRequest.ProtoReflect.Descriptor instead.
 func (*ZoneUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{2}
+	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ZoneUpdateRequest) GetZoneID() int64 {
@@ -205,17 +316,33 @@ func (x *ZoneUpdateRequest) GetName() string {
 	return ""
 }
 
+func (x *ZoneUpdateRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ZoneUpdateRequest) GetLabels() *ZoneLabels {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // Zone delete request.
 type ZoneDeleteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=Force,proto3" json:"Force,omitempty"`
+	Confirmation  string                 `protobuf:"bytes,3,opt,name=Confirmation,proto3" json:"Confirmation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ZoneDeleteRequest) Reset() {
 	*x = ZoneDeleteRequest{}
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[3]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,7 +354,7 @@ func (x *ZoneDeleteRequest) String() string {
 func (*ZoneDeleteRequest) ProtoMessage() {}
 
 func (x *ZoneDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[3]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +367,7 @@ func (x *ZoneDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZoneDeleteRequest.ProtoReflect.Descriptor instead.
 func (*ZoneDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{3}
+	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ZoneDeleteRequest) GetZoneID() int64 {
@@ -250,6 +377,65 @@ func (x *ZoneDeleteRequest) GetZoneID() int64 {
 	return 0
 }
 
+func (x *ZoneDeleteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ZoneDeleteRequest) GetConfirmation() string {
+	if x != nil {
+		return x.Confirmation
+	}
+	return ""
+}
+
+// Zone undelete request.
+type ZoneUndeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneID        int64                  `protobuf:"varint,1,opt,name=ZoneID,proto3" json:"ZoneID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZoneUndeleteRequest) Reset() {
+	*x = ZoneUndeleteRequest{}
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZoneUndeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZoneUndeleteRequest) ProtoMessage() {}
+
+func (x *ZoneUndeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZoneUndeleteRequest.ProtoReflect.Descriptor instead.
+func (*ZoneUndeleteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ZoneUndeleteRequest) GetZoneID() int64 {
+	if x != nil {
+		return x.ZoneID
+	}
+	return 0
+}
+
 // Zone response.
 type ZoneResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -257,13 +443,16 @@ type ZoneResponse struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
 	Name          string                 `protobuf:"bytes,4,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=DeletedAt,proto3,oneof" json:"DeletedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ZoneResponse) Reset() {
 	*x = ZoneResponse{}
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[4]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -275,7 +464,7 @@ func (x *ZoneResponse) String() string {
 func (*ZoneResponse) ProtoMessage() {}
 
 func (x *ZoneResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[4]
+	mi := &file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,7 +477,7 @@ func (x *ZoneResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ZoneResponse.ProtoReflect.Descriptor instead.
 func (*ZoneResponse) Descriptor() ([]byte, []int) {
-	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{4}
+	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ZoneResponse) GetZoneID() int64 {
@@ -319,39 +508,105 @@ func (x *ZoneResponse) GetName() string {
 	return ""
 }
 
+func (x *ZoneResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ZoneResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ZoneResponse) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 var File_internal_agents_services_zap_endpoints_api_v1_zap_proto protoreflect.FileDescriptor
 
 const file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDesc = "" +
 	"\n" +
-	"7internal/agents/services/zap/endpoints/api/v1/zap.proto\x12\x17zoneadministrationpoint\x1a\x1fgoogle/protobuf/timestamp.proto\"\xac\x01\n" +
+	"7internal/agents/services/zap/endpoints/api/v1/zap.proto\x12\x17zoneadministrationpoint\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x04\n" +
 	"\x10ZoneFetchRequest\x12\x17\n" +
 	"\x04Page\x18\x01 \x01(\x05H\x00R\x04Page\x88\x01\x01\x12\x1f\n" +
 	"\bPageSize\x18\x02 \x01(\x05H\x01R\bPageSize\x88\x01\x01\x12\x1b\n" +
 	"\x06ZoneID\x18\x03 \x01(\x03H\x02R\x06ZoneID\x88\x01\x01\x12\x17\n" +
-	"\x04Name\x18\x04 \x01(\tH\x03R\x04Name\x88\x01\x01B\a\n" +
+	"\x04Name\x18\x04 \x01(\tH\x03R\x04Name\x88\x01\x01\x12M\n" +
+	"\x06Labels\x18\x05 \x03(\v25.zoneadministrationpoint.ZoneFetchRequest.LabelsEntryR\x06Labels\x12#\n" +
+	"\n" +
+	"NamePrefix\x18\x06 \x01(\tH\x04R\n" +
+	"NamePrefix\x88\x01\x01\x12\x1b\n" +
+	"\x06SortBy\x18\a \x01(\tH\x05R\x06SortBy\x88\x01\x01\x12\x1f\n" +
+	"\bSortDesc\x18\b \x01(\bH\x06R\bSortDesc\x88\x01\x01\x12\x1b\n" +
+	"\x06Cursor\x18\t \x01(\tH\aR\x06Cursor\x88\x01\x01\x12\x1d\n" +
+	"\aDeleted\x18\n" +
+	" \x01(\bH\bR\aDeleted\x88\x01\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
 	"\x05_PageB\v\n" +
 	"\t_PageSizeB\t\n" +
 	"\a_ZoneIDB\a\n" +
-	"\x05_Name\"'\n" +
+	"\x05_NameB\r\n" +
+	"\v_NamePrefixB\t\n" +
+	"\a_SortByB\v\n" +
+	"\t_SortDescB\t\n" +
+	"\a_CursorB\n" +
+	"\n" +
+	"\b_Deleted\"\x94\x01\n" +
+	"\n" +
+	"ZoneLabels\x12J\n" +
+	"\aEntries\x18\x01 \x03(\v20.zoneadministrationpoint.ZoneLabels.EntriesEntryR\aEntries\x1a:\n" +
+	"\fEntriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd4\x01\n" +
 	"\x11ZoneCreateRequest\x12\x12\n" +
-	"\x04Name\x18\x01 \x01(\tR\x04Name\"?\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12 \n" +
+	"\vDescription\x18\x02 \x01(\tR\vDescription\x12N\n" +
+	"\x06Labels\x18\x03 \x03(\v26.zoneadministrationpoint.ZoneCreateRequest.LabelsEntryR\x06Labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x01\n" +
 	"\x11ZoneUpdateRequest\x12\x16\n" +
 	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12\x12\n" +
-	"\x04Name\x18\x02 \x01(\tR\x04Name\"+\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12%\n" +
+	"\vDescription\x18\x03 \x01(\tH\x00R\vDescription\x88\x01\x01\x12;\n" +
+	"\x06Labels\x18\x04 \x01(\v2#.zoneadministrationpoint.ZoneLabelsR\x06LabelsB\x0e\n" +
+	"\f_Description\"e\n" +
 	"\x11ZoneDeleteRequest\x12\x16\n" +
-	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\"\xae\x01\n" +
+	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x12\x14\n" +
+	"\x05Force\x18\x02 \x01(\bR\x05Force\x12\"\n" +
+	"\fConfirmation\x18\x03 \x01(\tR\fConfirmation\"-\n" +
+	"\x13ZoneUndeleteRequest\x12\x16\n" +
+	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\"\xa3\x03\n" +
 	"\fZoneResponse\x12\x16\n" +
 	"\x06ZoneID\x18\x01 \x01(\x03R\x06ZoneID\x128\n" +
 	"\tCreatedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
 	"\tUpdatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x12\n" +
-	"\x04Name\x18\x04 \x01(\tR\x04Name2\x9b\x03\n" +
+	"\x04Name\x18\x04 \x01(\tR\x04Name\x12 \n" +
+	"\vDescription\x18\x05 \x01(\tR\vDescription\x12I\n" +
+	"\x06Labels\x18\x06 \x03(\v21.zoneadministrationpoint.ZoneResponse.LabelsEntryR\x06Labels\x12=\n" +
+	"\tDeletedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tDeletedAt\x88\x01\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_DeletedAt2\x82\x04\n" +
 	"\fV1ZAPService\x12a\n" +
 	"\n" +
 	"CreateZone\x12*.zoneadministrationpoint.ZoneCreateRequest\x1a%.zoneadministrationpoint.ZoneResponse\"\x00\x12a\n" +
 	"\n" +
 	"UpdateZone\x12*.zoneadministrationpoint.ZoneUpdateRequest\x1a%.zoneadministrationpoint.ZoneResponse\"\x00\x12a\n" +
 	"\n" +
-	"DeleteZone\x12*.zoneadministrationpoint.ZoneDeleteRequest\x1a%.zoneadministrationpoint.ZoneResponse\"\x00\x12b\n" +
+	"DeleteZone\x12*.zoneadministrationpoint.ZoneDeleteRequest\x1a%.zoneadministrationpoint.ZoneResponse\"\x00\x12e\n" +
+	"\fUndeleteZone\x12,.zoneadministrationpoint.ZoneUndeleteRequest\x1a%.zoneadministrationpoint.ZoneResponse\"\x00\x12b\n" +
 	"\n" +
 	"FetchZones\x12).zoneadministrationpoint.ZoneFetchRequest\x1a%.zoneadministrationpoint.ZoneResponse\"\x000\x01B:Z8github.com/permguard/permguard/internal/hosts/api/zap/v1b\x06proto3"
 
@@ -367,31 +622,45 @@ func file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescGZIP()
 	return file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDescData
 }
 
-var file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_internal_agents_services_zap_endpoints_api_v1_zap_proto_goTypes = []any{
 	(*ZoneFetchRequest)(nil),      // 0: zoneadministrationpoint.ZoneFetchRequest
-	(*ZoneCreateRequest)(nil),     // 1: zoneadministrationpoint.ZoneCreateRequest
-	(*ZoneUpdateRequest)(nil),     // 2: zoneadministrationpoint.ZoneUpdateRequest
-	(*ZoneDeleteRequest)(nil),     // 3: zoneadministrationpoint.ZoneDeleteRequest
-	(*ZoneResponse)(nil),          // 4: zoneadministrationpoint.ZoneResponse
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*ZoneLabels)(nil),            // 1: zoneadministrationpoint.ZoneLabels
+	(*ZoneCreateRequest)(nil),     // 2: zoneadministrationpoint.ZoneCreateRequest
+	(*ZoneUpdateRequest)(nil),     // 3: zoneadministrationpoint.ZoneUpdateRequest
+	(*ZoneDeleteRequest)(nil),     // 4: zoneadministrationpoint.ZoneDeleteRequest
+	(*ZoneUndeleteRequest)(nil),   // 5: zoneadministrationpoint.ZoneUndeleteRequest
+	(*ZoneResponse)(nil),          // 6: zoneadministrationpoint.ZoneResponse
+	nil,                           // 7: zoneadministrationpoint.ZoneFetchRequest.LabelsEntry
+	nil,                           // 8: zoneadministrationpoint.ZoneLabels.EntriesEntry
+	nil,                           // 9: zoneadministrationpoint.ZoneCreateRequest.LabelsEntry
+	nil,                           // 10: zoneadministrationpoint.ZoneResponse.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
 }
 var file_internal_agents_services_zap_endpoints_api_v1_zap_proto_depIdxs = []int32{
-	5, // 0: zoneadministrationpoint.ZoneResponse.CreatedAt:type_name -> google.protobuf.Timestamp
-	5, // 1: zoneadministrationpoint.ZoneResponse.UpdatedAt:type_name -> google.protobuf.Timestamp
-	1, // 2: zoneadministrationpoint.V1ZAPService.CreateZone:input_type -> zoneadministrationpoint.ZoneCreateRequest
-	2, // 3: zoneadministrationpoint.V1ZAPService.UpdateZone:input_type -> zoneadministrationpoint.ZoneUpdateRequest
-	3, // 4: zoneadministrationpoint.V1ZAPService.DeleteZone:input_type -> zoneadministrationpoint.ZoneDeleteRequest
-	0, // 5: zoneadministrationpoint.V1ZAPService.FetchZones:input_type -> zoneadministrationpoint.ZoneFetchRequest
-	4, // 6: zoneadministrationpoint.V1ZAPService.CreateZone:output_type -> zoneadministrationpoint.ZoneResponse
-	4, // 7: zoneadministrationpoint.V1ZAPService.UpdateZone:output_type -> zoneadministrationpoint.ZoneResponse
-	4, // 8: zoneadministrationpoint.V1ZAPService.DeleteZone:output_type -> zoneadministrationpoint.ZoneResponse
-	4, // 9: zoneadministrationpoint.V1ZAPService.FetchZones:output_type -> zoneadministrationpoint.ZoneResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	7,  // 0: zoneadministrationpoint.ZoneFetchRequest.Labels:type_name -> zoneadministrationpoint.ZoneFetchRequest.LabelsEntry
+	8,  // 1: zoneadministrationpoint.ZoneLabels.Entries:type_name -> zoneadministrationpoint.ZoneLabels.EntriesEntry
+	9,  // 2: zoneadministrationpoint.ZoneCreateRequest.Labels:type_name -> zoneadministrationpoint.ZoneCreateRequest.LabelsEntry
+	1,  // 3: zoneadministrationpoint.ZoneUpdateRequest.Labels:type_name -> zoneadministrationpoint.ZoneLabels
+	11, // 4: zoneadministrationpoint.ZoneResponse.CreatedAt:type_name -> google.protobuf.Timestamp
+	11, // 5: zoneadministrationpoint.ZoneResponse.UpdatedAt:type_name -> google.protobuf.Timestamp
+	10, // 6: zoneadministrationpoint.ZoneResponse.Labels:type_name -> zoneadministrationpoint.ZoneResponse.LabelsEntry
+	11, // 7: zoneadministrationpoint.ZoneResponse.DeletedAt:type_name -> google.protobuf.Timestamp
+	2,  // 8: zoneadministrationpoint.V1ZAPService.CreateZone:input_type -> zoneadministrationpoint.ZoneCreateRequest
+	3,  // 9: zoneadministrationpoint.V1ZAPService.UpdateZone:input_type -> zoneadministrationpoint.ZoneUpdateRequest
+	4,  // 10: zoneadministrationpoint.V1ZAPService.DeleteZone:input_type -> zoneadministrationpoint.ZoneDeleteRequest
+	5,  // 11: zoneadministrationpoint.V1ZAPService.UndeleteZone:input_type -> zoneadministrationpoint.ZoneUndeleteRequest
+	0,  // 12: zoneadministrationpoint.V1ZAPService.FetchZones:input_type -> zoneadministrationpoint.ZoneFetchRequest
+	6,  // 13: zoneadministrationpoint.V1ZAPService.CreateZone:output_type -> zoneadministrationpoint.ZoneResponse
+	6,  // 14: zoneadministrationpoint.V1ZAPService.UpdateZone:output_type -> zoneadministrationpoint.ZoneResponse
+	6,  // 15: zoneadministrationpoint.V1ZAPService.DeleteZone:output_type -> zoneadministrationpoint.ZoneResponse
+	6,  // 16: zoneadministrationpoint.V1ZAPService.UndeleteZone:output_type -> zoneadministrationpoint.ZoneResponse
+	6,  // 17: zoneadministrationpoint.V1ZAPService.FetchZones:output_type -> zoneadministrationpoint.ZoneResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_internal_agents_services_zap_endpoints_api_v1_zap_proto_init() }
@@ -400,13 +669,15 @@ func file_internal_agents_services_zap_endpoints_api_v1_zap_proto_init() {
 		return
 	}
 	file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[0].OneofWrappers = []any{}
+	file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[3].OneofWrappers = []any{}
+	file_internal_agents_services_zap_endpoints_api_v1_zap_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDesc), len(file_internal_agents_services_zap_endpoints_api_v1_zap_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
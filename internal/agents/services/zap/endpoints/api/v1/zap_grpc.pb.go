@@ -35,10 +35,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	V1ZAPService_CreateZone_FullMethodName = "/zoneadministrationpoint.V1ZAPService/CreateZone"
-	V1ZAPService_UpdateZone_FullMethodName = "/zoneadministrationpoint.V1ZAPService/UpdateZone"
-	V1ZAPService_DeleteZone_FullMethodName = "/zoneadministrationpoint.V1ZAPService/DeleteZone"
-	V1ZAPService_FetchZones_FullMethodName = "/zoneadministrationpoint.V1ZAPService/FetchZones"
+	V1ZAPService_CreateZone_FullMethodName   = "/zoneadministrationpoint.V1ZAPService/CreateZone"
+	V1ZAPService_UpdateZone_FullMethodName   = "/zoneadministrationpoint.V1ZAPService/UpdateZone"
+	V1ZAPService_DeleteZone_FullMethodName   = "/zoneadministrationpoint.V1ZAPService/DeleteZone"
+	V1ZAPService_UndeleteZone_FullMethodName = "/zoneadministrationpoint.V1ZAPService/UndeleteZone"
+	V1ZAPService_FetchZones_FullMethodName   = "/zoneadministrationpoint.V1ZAPService/FetchZones"
 )
 
 // V1ZAPServiceClient is the client API for V1ZAPService service.
@@ -53,6 +54,8 @@ type V1ZAPServiceClient interface {
 	UpdateZone(ctx context.Context, in *ZoneUpdateRequest, opts ...grpc.CallOption) (*ZoneResponse, error)
 	// Delete a zone.
 	DeleteZone(ctx context.Context, in *ZoneDeleteRequest, opts ...grpc.CallOption) (*ZoneResponse, error)
+	// Undelete a zone.
+	UndeleteZone(ctx context.Context, in *ZoneUndeleteRequest, opts ...grpc.CallOption) (*ZoneResponse, error)
 	// Fetch Zones.
 	FetchZones(ctx context.Context, in *ZoneFetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ZoneResponse], error)
 }
@@ -95,6 +98,16 @@ func (c *v1ZAPServiceClient) DeleteZone(ctx context.Context, in *ZoneDeleteReque
 	return out, nil
 }
 
+func (c *v1ZAPServiceClient) UndeleteZone(ctx context.Context, in *ZoneUndeleteRequest, opts ...grpc.CallOption) (*ZoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ZoneResponse)
+	err := c.cc.Invoke(ctx, V1ZAPService_UndeleteZone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *v1ZAPServiceClient) FetchZones(ctx context.Context, in *ZoneFetchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ZoneResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &V1ZAPService_ServiceDesc.Streams[0], V1ZAPService_FetchZones_FullMethodName, cOpts...)
@@ -126,6 +139,8 @@ type V1ZAPServiceServer interface {
 	UpdateZone(context.Context, *ZoneUpdateRequest) (*ZoneResponse, error)
 	// Delete a zone.
 	DeleteZone(context.Context, *ZoneDeleteRequest) (*ZoneResponse, error)
+	// Undelete a zone.
+	UndeleteZone(context.Context, *ZoneUndeleteRequest) (*ZoneResponse, error)
 	// Fetch Zones.
 	FetchZones(*ZoneFetchRequest, grpc.ServerStreamingServer[ZoneResponse]) error
 	mustEmbedUnimplementedV1ZAPServiceServer()
@@ -147,6 +162,9 @@ func (UnimplementedV1ZAPServiceServer) UpdateZone(context.Context, *ZoneUpdateRe
 func (UnimplementedV1ZAPServiceServer) DeleteZone(context.Context, *ZoneDeleteRequest) (*ZoneResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteZone not implemented")
 }
+func (UnimplementedV1ZAPServiceServer) UndeleteZone(context.Context, *ZoneUndeleteRequest) (*ZoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteZone not implemented")
+}
 func (UnimplementedV1ZAPServiceServer) FetchZones(*ZoneFetchRequest, grpc.ServerStreamingServer[ZoneResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method FetchZones not implemented")
 }
@@ -225,6 +243,24 @@ func _V1ZAPService_DeleteZone_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _V1ZAPService_UndeleteZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZoneUndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(V1ZAPServiceServer).UndeleteZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: V1ZAPService_UndeleteZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(V1ZAPServiceServer).UndeleteZone(ctx, req.(*ZoneUndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _V1ZAPService_FetchZones_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ZoneFetchRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -255,6 +291,10 @@ var V1ZAPService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteZone",
 			Handler:    _V1ZAPService_DeleteZone_Handler,
 		},
+		{
+			MethodName: "UndeleteZone",
+			Handler:    _V1ZAPService_UndeleteZone_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -17,8 +17,15 @@
 package v1
 
 import (
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -31,22 +38,109 @@ func MapPointerStringToString(str *string) string {
 	return response
 }
 
+// MapGrpcFetchOptions maps the gRPC sort and cursor fields to the fetch options.
+func MapGrpcFetchOptions(sortBy *string, sortDesc *bool, cursor *string) *models.FetchOptions {
+	options := &models.FetchOptions{
+		SortBy: MapPointerStringToString(sortBy),
+		Cursor: MapPointerStringToString(cursor),
+	}
+	if sortDesc != nil {
+		options.SortDesc = *sortDesc
+	}
+	return options
+}
+
+// MapFetchPageInfoToGrpcTrailer maps the page info to the trailer of a fetch stream.
+func MapFetchPageInfoToGrpcTrailer(pageInfo *models.FetchPageInfo) metadata.MD {
+	md := metadata.MD{}
+	if pageInfo == nil {
+		return md
+	}
+	md.Set(models.FetchTotalCountKey, strconv.FormatInt(pageInfo.TotalCount, 10))
+	if pageInfo.NextCursor != "" {
+		md.Set(models.FetchNextCursorKey, pageInfo.NextCursor)
+	}
+	return md
+}
+
+// MapGrpcTrailerToFetchPageInfo maps the trailer of a fetch stream to the page info.
+func MapGrpcTrailerToFetchPageInfo(md metadata.MD) (*models.FetchPageInfo, error) {
+	pageInfo := &models.FetchPageInfo{}
+	if values := md.Get(models.FetchTotalCountKey); len(values) > 0 {
+		totalCount, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total count %q in the fetch trailer", values[0])
+		}
+		pageInfo.TotalCount = totalCount
+	}
+	if values := md.Get(models.FetchNextCursorKey); len(values) > 0 {
+		pageInfo.NextCursor = values[0]
+	}
+	return pageInfo, nil
+}
+
+// MapPointerTimeToGrpcTimestamp maps a pointer time to a gRPC timestamp, nil times are mapped to nil.
+func MapPointerTimeToGrpcTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// MapGrpcTimestampToPointerTime maps a gRPC timestamp to a pointer time, nil timestamps are mapped to nil.
+func MapGrpcTimestampToPointerTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// MapStringToPointerString maps a string to a pointer string, empty strings are mapped to nil.
+func MapStringToPointerString(str string) *string {
+	if str == "" {
+		return nil
+	}
+	return &str
+}
+
 // MapGrpcZoneResponseToAgentZone maps the gRPC zone to the agent zone.
 func MapGrpcZoneResponseToAgentZone(zone *ZoneResponse) (*zap.Zone, error) {
 	return &zap.Zone{
-		ZoneID:    zone.ZoneID,
-		CreatedAt: zone.CreatedAt.AsTime(),
-		UpdatedAt: zone.UpdatedAt.AsTime(),
-		Name:      zone.Name,
+		ZoneID:      zone.ZoneID,
+		CreatedAt:   zone.CreatedAt.AsTime(),
+		UpdatedAt:   zone.UpdatedAt.AsTime(),
+		Name:        zone.Name,
+		Description: MapStringToPointerString(zone.Description),
+		Labels:      zone.Labels,
+		DeletedAt:   MapGrpcTimestampToPointerTime(zone.DeletedAt),
 	}, nil
 }
 
+// MapGrpcZoneUpdateRequestToAgentZone maps the gRPC zone update request to the agent zone.
+// Description and labels are left unchanged when the request does not carry them.
+func MapGrpcZoneUpdateRequestToAgentZone(zoneRequest *ZoneUpdateRequest) *zap.Zone {
+	zone := &zap.Zone{
+		ZoneID:      zoneRequest.ZoneID,
+		Name:        zoneRequest.Name,
+		Description: zoneRequest.Description,
+	}
+	if zoneRequest.Labels != nil {
+		zone.Labels = map[string]string{}
+		maps.Copy(zone.Labels, zoneRequest.Labels.Entries)
+	}
+	return zone
+}
+
 // MapAgentZoneToGrpcZoneResponse maps the agent zone to the gRPC zone.
 func MapAgentZoneToGrpcZoneResponse(zone *zap.Zone) (*ZoneResponse, error) {
 	return &ZoneResponse{
-		ZoneID:    zone.ZoneID,
-		CreatedAt: timestamppb.New(zone.CreatedAt),
-		UpdatedAt: timestamppb.New(zone.UpdatedAt),
-		Name:      zone.Name,
+		ZoneID:      zone.ZoneID,
+		CreatedAt:   timestamppb.New(zone.CreatedAt),
+		UpdatedAt:   timestamppb.New(zone.UpdatedAt),
+		Name:        zone.Name,
+		Description: MapPointerStringToString(zone.Description),
+		Labels:      zone.Labels,
+		DeletedAt:   MapPointerTimeToGrpcTimestamp(zone.DeletedAt),
 	}, nil
 }
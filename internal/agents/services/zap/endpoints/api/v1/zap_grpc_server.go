@@ -29,6 +29,7 @@ import (
 	"github.com/permguard/permguard/pkg/agents/services"
 	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -46,6 +47,8 @@ func mapStorageError(err error) error {
 		return status.Errorf(grpccodes.Aborted, "%v", err)
 	case errors.Is(err, azstorage.ErrInvalidInput):
 		return status.Errorf(grpccodes.InvalidArgument, "%v", err)
+	case errors.Is(err, azstorage.ErrConfirmationRequired):
+		return status.Errorf(grpccodes.FailedPrecondition, "%v", err)
 	default:
 		return status.Errorf(grpccodes.Internal, "internal error")
 	}
@@ -59,10 +62,12 @@ type ZAPService interface {
 	CreateZone(ctx context.Context, zone *zap.Zone) (*zap.Zone, error)
 	// UpdateZone updates a zone.
 	UpdateZone(ctx context.Context, zone *zap.Zone) (*zap.Zone, error)
-	// DeleteZone deletes a zone.
-	DeleteZone(ctx context.Context, zoneID int64) (*zap.Zone, error)
-	// FetchZones returns all zones.
-	FetchZones(ctx context.Context, page int32, pageSize int32, filter map[string]any) ([]zap.Zone, error)
+	// DeleteZone soft deletes a zone.
+	DeleteZone(ctx context.Context, zoneID int64, options *models.DeleteOptions) (*zap.Zone, error)
+	// UndeleteZone restores a soft deleted zone.
+	UndeleteZone(ctx context.Context, zoneID int64) (*zap.Zone, error)
+	// FetchZonesPage returns a page of zones, sorted and resumed as per the options.
+	FetchZonesPage(ctx context.Context, page int32, pageSize int32, filter map[string]any, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error)
 }
 
 // NewZAPServer creates a new ZAP server.
@@ -87,7 +92,7 @@ func (s *ZAPServer) CreateZone(ctx context.Context, zoneRequest *ZoneCreateReque
 	defer func() {
 		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("zap.CreateZone"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
 	}()
-	zone, err := s.service.CreateZone(ctx, &zap.Zone{Name: zoneRequest.Name})
+	zone, err := s.service.CreateZone(ctx, &zap.Zone{Name: zoneRequest.Name, Description: MapStringToPointerString(zoneRequest.Description), Labels: zoneRequest.Labels})
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return nil, mapStorageError(err)
@@ -103,7 +108,7 @@ func (s *ZAPServer) UpdateZone(ctx context.Context, zoneRequest *ZoneUpdateReque
 		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("zap.UpdateZone"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", zoneRequest.ZoneID))
-	zone, err := s.service.UpdateZone(ctx, (&zap.Zone{ZoneID: zoneRequest.ZoneID, Name: zoneRequest.Name}))
+	zone, err := s.service.UpdateZone(ctx, MapGrpcZoneUpdateRequestToAgentZone(zoneRequest))
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return nil, mapStorageError(err)
@@ -119,7 +124,23 @@ func (s *ZAPServer) DeleteZone(ctx context.Context, zoneRequest *ZoneDeleteReque
 		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("zap.DeleteZone"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", zoneRequest.ZoneID))
-	zone, err := s.service.DeleteZone(ctx, zoneRequest.ZoneID)
+	zone, err := s.service.DeleteZone(ctx, zoneRequest.ZoneID, &models.DeleteOptions{Force: zoneRequest.Force, Confirmation: zoneRequest.Confirmation})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, mapStorageError(err)
+	}
+	return MapAgentZoneToGrpcZoneResponse(zone)
+}
+
+// UndeleteZone restores a soft deleted zone.
+func (s *ZAPServer) UndeleteZone(ctx context.Context, zoneRequest *ZoneUndeleteRequest) (_ *ZoneResponse, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "grpc.zap.UndeleteZone")
+	defer span.End()
+	defer func() {
+		telemetry.GRPCRequestTotal.Add(ctx, 1, telemetry.MethodAttr("zap.UndeleteZone"), telemetry.StatusAttr(telemetry.StatusFromErr(retErr)))
+	}()
+	span.SetAttributes(attribute.Int64("zone_id", zoneRequest.ZoneID))
+	zone, err := s.service.UndeleteZone(ctx, zoneRequest.ZoneID)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return nil, mapStorageError(err)
@@ -142,6 +163,15 @@ func (s *ZAPServer) FetchZones(zoneRequest *ZoneFetchRequest, stream grpc.Server
 	if zoneRequest.Name != nil {
 		fields[zap.FieldZoneName] = *zoneRequest.Name
 	}
+	if zoneRequest.NamePrefix != nil {
+		fields[zap.FieldZoneNamePrefix] = *zoneRequest.NamePrefix
+	}
+	if zoneRequest.Deleted != nil {
+		fields[zap.FieldZoneDeleted] = *zoneRequest.Deleted
+	}
+	if len(zoneRequest.Labels) > 0 {
+		fields[zap.FieldZoneLabels] = zoneRequest.Labels
+	}
 	page := int32(0)
 	if zoneRequest.Page != nil {
 		page = *zoneRequest.Page
@@ -150,11 +180,13 @@ func (s *ZAPServer) FetchZones(zoneRequest *ZoneFetchRequest, stream grpc.Server
 	if zoneRequest.PageSize != nil {
 		pageSize = *zoneRequest.PageSize
 	}
-	zones, err := s.service.FetchZones(ctx, page, pageSize, fields)
+	options := MapGrpcFetchOptions(zoneRequest.SortBy, zoneRequest.SortDesc, zoneRequest.Cursor)
+	zones, pageInfo, err := s.service.FetchZonesPage(ctx, page, pageSize, fields, options)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return mapStorageError(err)
 	}
+	stream.SetTrailer(MapFetchPageInfoToGrpcTrailer(pageInfo))
 	span.SetAttributes(attribute.Int("result_count", len(zones)))
 	for _, zone := range zones {
 		cvtedZone, err := MapAgentZoneToGrpcZoneResponse(&zone)
@@ -17,6 +17,10 @@
 package zap
 
 import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	azzapctrl "github.com/permguard/permguard/internal/agents/services/zap/controllers"
@@ -85,7 +89,59 @@ func (f *Service) Endpoints() ([]services.EndpointInitializer, error) {
 
 // Jobs returns the service background jobs.
 func (f *Service) Jobs() ([]services.JobInitializer, error) {
-	return nil, nil
+	jobs := []services.JobInitializer{}
+	if f.config.PurgeEnabled() {
+		job, err := f.purgeJob()
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// purgeJob returns the job permanently deleting the zones deleted for longer than the retention.
+func (f *Service) purgeJob() (services.JobInitializer, error) {
+	interval := f.config.PurgeInterval()
+	retention := f.config.DeletedRetention()
+	return services.NewJobInitializer(
+		f.config.Service(),
+		"deleted-zones-purge",
+		func(ctx context.Context, srvCtx *services.ServiceContext, storageConnector *storage.Connector) error {
+			logger := srvCtx.Logger()
+			storageKind := f.config.StorageCentralEngine()
+			centralStorage, err := storageConnector.CentralStorage(storageKind, srvCtx)
+			if err != nil {
+				return err
+			}
+			zapStorage, err := centralStorage.ZAPCentralStorage()
+			if err != nil {
+				return err
+			}
+			runPurge := func() {
+				purged, err := zapStorage.PurgeDeletedZones(ctx, retention)
+				if err != nil {
+					logger.Error("Deleted zones purge failed", zap.Error(err))
+					return
+				}
+				if purged > 0 {
+					logger.Info("Deleted zones purge completed", zap.Int64("purged", purged))
+				}
+			}
+			// Run immediately on startup.
+			runPurge()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					runPurge()
+				}
+			}
+		},
+	)
 }
 
 // ServiceConfigReader returns the service configuration reader.
@@ -19,6 +19,7 @@ package zap
 import (
 	"errors"
 	"flag"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -36,6 +37,9 @@ const (
 	flagCentralEngine         = "engine-central"
 	flagDataFetchMaxPageSize  = "data-fetch-maxpagesize"
 	flagEnableDefaultCreation = "data-enable-default-creation"
+	flagPurgeEnabled          = "purge-enabled"
+	flagPurgeInterval         = "purge-interval"
+	flagDeletedRetention      = "deleted-retention"
 )
 
 // ServiceConfig holds the configuration for the server.
@@ -46,6 +50,9 @@ type ServiceConfig struct {
 	storageCentralEngine  storage.Kind
 	dataFetchMaxPageSize  int
 	enableDefaultCreation bool
+	purgeEnabled          bool
+	purgeInterval         time.Duration
+	deletedRetention      time.Duration
 }
 
 // NewServiceConfig creates a new server factory configuration.
@@ -62,6 +69,9 @@ func (c *ServiceConfig) AddFlags(flagSet *flag.FlagSet) error {
 	flagSet.String(options.FlagName(flagStorageZAPPrefix, flagCentralEngine), "", "data storage engine to be used for central data; this overrides the --storage-engine-central option")
 	flagSet.Int(options.FlagName(flagServerZAPPrefix, flagDataFetchMaxPageSize), 10000, "maximum number of items to fetch per request")
 	flagSet.Bool(options.FlagName(flagServerZAPPrefix, flagEnableDefaultCreation), false, "the creation of default entities during data creation")
+	flagSet.Bool(options.FlagName(flagServerZAPPrefix, flagPurgeEnabled), true, "enable background purge of the deleted zones past the retention")
+	flagSet.Duration(options.FlagName(flagServerZAPPrefix, flagPurgeInterval), time.Hour, "how often the deleted zones purge job runs")
+	flagSet.Duration(options.FlagName(flagServerZAPPrefix, flagDeletedRetention), 30*24*time.Hour, "how long a deleted zone can be restored before it is purged")
 	return nil
 }
 
@@ -97,6 +107,19 @@ func (c *ServiceConfig) InitFromViper(v *viper.Viper) error {
 	enableDefaultCreation := v.GetBool(flagName)
 	c.config[flagEnableDefaultCreation] = enableDefaultCreation
 	c.enableDefaultCreation = enableDefaultCreation
+	// retrieve the deleted zones purge settings
+	c.purgeEnabled = v.GetBool(options.FlagName(flagServerZAPPrefix, flagPurgeEnabled))
+	c.purgeInterval = v.GetDuration(options.FlagName(flagServerZAPPrefix, flagPurgeInterval))
+	if c.purgeEnabled && c.purgeInterval <= 0 {
+		return errors.New("zap-service: invalid purge interval")
+	}
+	c.deletedRetention = v.GetDuration(options.FlagName(flagServerZAPPrefix, flagDeletedRetention))
+	if c.purgeEnabled && c.deletedRetention <= 0 {
+		return errors.New("zap-service: invalid deleted retention")
+	}
+	c.config[flagPurgeEnabled] = c.purgeEnabled
+	c.config[flagPurgeInterval] = c.purgeInterval
+	c.config[flagDeletedRetention] = c.deletedRetention
 	return nil
 }
 
@@ -125,6 +148,21 @@ func (c *ServiceConfig) EnabledDefaultCreation() bool {
 	return c.enableDefaultCreation
 }
 
+// PurgeEnabled returns whether the purge of the deleted zones is enabled.
+func (c *ServiceConfig) PurgeEnabled() bool {
+	return c.purgeEnabled
+}
+
+// PurgeInterval returns how often the deleted zones purge job runs.
+func (c *ServiceConfig) PurgeInterval() time.Duration {
+	return c.purgeInterval
+}
+
+// DeletedRetention returns how long a deleted zone can be restored before it is purged.
+func (c *ServiceConfig) DeletedRetention() time.Duration {
+	return c.deletedRetention
+}
+
 // Service returns the service kind.
 func (c *ServiceConfig) Service() services.ServiceKind {
 	return c.serviceKind
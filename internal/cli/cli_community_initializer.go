@@ -23,6 +23,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/authz"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/configs"
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/notp"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/workspace"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/zones"
 	"github.com/permguard/permguard/pkg/authz/languages"
@@ -52,11 +53,13 @@ func (s *CommunityCliInitializer) CliCommands(deps cli.DependenciesProvider, v *
 	zonesCmd := zones.CreateCommandForZones(deps, v)
 	authzCmd := authz.CreateCommandForAuthZ(deps, v)
 	configCmd := configs.CreateCommandForConfig(deps, v)
+	notpCmd := notp.CreateCommandForNOTP(deps, v)
 	wksCmds := workspace.CreateCommandsForWorkspace(deps, v)
 	return append([]*cobra.Command{
 		zonesCmd,
 		authzCmd,
 		configCmd,
+		notpCmd,
 	}, wksCmds...), nil
 }
 
@@ -91,6 +91,7 @@ func Run(cliInitializer cli.Initializer) {
 	command.PersistentFlags().Bool(options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSSkipVerify), false, "skip server certificate verification (insecure, dev only)")
 	command.PersistentFlags().Bool(options.FlagName(common.FlagPrefixSpiffe, common.FlagSuffixSpiffeEnabled), false, "enable native SPIFFE mTLS via Workload API")
 	command.PersistentFlags().String(options.FlagName(common.FlagPrefixSpiffe, common.FlagSuffixSpiffeEndpoint), "", "SPIFFE Workload API socket path (defaults to SPIFFE_ENDPOINT_SOCKET env)")
+	command.PersistentFlags().String(common.FlagContext, "", "named context to run the command with, instead of the current context")
 	_ = v.BindPFlags(command.PersistentFlags())
 
 	command.AddCommand(azcmds.CreateCommandForVersion(depsProvider, v))
@@ -58,6 +58,7 @@ type CliCommandContext struct {
 	workDir        string
 	verbose        bool
 	output         string
+	contextName    string
 	verboseDetails []map[string]any
 }
 
@@ -154,6 +155,12 @@ func newCliContext(cmd *cobra.Command, v *viper.Viper) (*CliCommandContext, erro
 		return nil, err
 	}
 	ctx.verbose = verbose
+	if cmd.Annotations[AnnotationSkipNamedContext] == "" {
+		ctx.contextName, err = applyNamedContext(cmd, v)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return ctx, nil
 }
 
@@ -221,6 +228,29 @@ func (c *CliCommandContext) WorkDir() string {
 	return c.workDir
 }
 
+// ContextName returns the name of the named context applied to the command, empty when none is.
+func (c *CliCommandContext) ContextName() string {
+	return c.contextName
+}
+
+// DefaultZoneID returns the zone id used when a command requires one and none is passed.
+func (c *CliCommandContext) DefaultZoneID() int64 {
+	return c.v.GetInt64(FlagDefaultZoneID)
+}
+
+// LedgerURIWithDefaultZone completes a ledger uri without the zone id, such as origin/ledger, with the default zone id.
+func (c *CliCommandContext) LedgerURIWithDefaultZone(ledgerURI string) (string, error) {
+	items := strings.Split(ledgerURI, "/")
+	if len(items) != 2 {
+		return ledgerURI, nil
+	}
+	zoneID := c.DefaultZoneID()
+	if zoneID <= 0 {
+		return "", fmt.Errorf("cli: ledger %s has no zone id and no default zone id is set — use 'permguard config set-context <name> --default-zone-id <zone-id>' to set one", ledgerURI)
+	}
+	return fmt.Sprintf("%s/%d/%s", items[0], zoneID, items[1]), nil
+}
+
 // ZAPEndpoint returns the zap endpoint.
 func (c *CliCommandContext) ZAPEndpoint() (string, error) {
 	endpoint := c.v.Get(options.FlagName(FlagPrefixZAP, FlagSuffixZAPEndpoint))
@@ -286,6 +316,39 @@ func (c *CliCommandContext) NOTPMaxPacketSize() (int, error) {
 	}
 }
 
+// NOTPTraceFile returns the file the notp packets are recorded in, empty if tracing is disabled.
+func (c *CliCommandContext) NOTPTraceFile() string {
+	return c.v.GetString(options.FlagName(FlagPrefixNOTP, FlagSuffixNOTPTrace))
+}
+
+// CommitSigningKey returns the key file used to sign the commits, empty if commit signing is disabled.
+func (c *CliCommandContext) CommitSigningKey() string {
+	return c.v.GetString(options.FlagName(FlagPrefixCommit, FlagSuffixCommitSigningKey))
+}
+
+// CommitAuthorName returns the name recorded as author and committer of the commits.
+func (c *CliCommandContext) CommitAuthorName() string {
+	return strings.TrimSpace(c.v.GetString(options.FlagName(FlagPrefixCommit, FlagSuffixCommitAuthorName)))
+}
+
+// CommitAuthorEmail returns the email recorded as author and committer of the commits.
+func (c *CliCommandContext) CommitAuthorEmail() string {
+	return strings.TrimSpace(c.v.GetString(options.FlagName(FlagPrefixCommit, FlagSuffixCommitAuthorEmail)))
+}
+
+// CommitIdentity returns the identity of the commits in the "name <email>" form, empty if it is not configured.
+func (c *CliCommandContext) CommitIdentity() string {
+	name, email := c.CommitAuthorName(), c.CommitAuthorEmail()
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case email != "":
+		return fmt.Sprintf("<%s>", email)
+	default:
+		return name
+	}
+}
+
 // resolveSpiffeSocketPath returns the SPIFFE socket path from the flag or SPIFFE_ENDPOINT_SOCKET env var.
 func (c *CliCommandContext) resolveSpiffeSocketPath() string {
 	if path := c.v.GetString(options.FlagName(FlagPrefixSpiffe, FlagSuffixSpiffeEndpoint)); path != "" {
@@ -0,0 +1,114 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/pkg/cli/options"
+	azvalidators "github.com/permguard/permguard/pkg/core/validators"
+)
+
+// AnnotationSkipNamedContext marks the commands which run without applying the selected named context,
+// so that a context which no longer exists can still be replaced or removed.
+const AnnotationSkipNamedContext = "permguard-skip-named-context"
+
+// contextSettingKeys are the settings a named context bundles, they take precedence over the global settings.
+var contextSettingKeys = []string{
+	options.FlagName(FlagPrefixZAP, FlagSuffixZAPEndpoint),
+	options.FlagName(FlagPrefixPAP, FlagSuffixPAPEndpoint),
+	options.FlagName(FlagPrefixPDP, FlagSuffixPDPEndpoint),
+	options.FlagName(FlagPrefixTLS, FlagSuffixTLSCAFile),
+	options.FlagName(FlagPrefixTLS, FlagSuffixTLSCertFile),
+	options.FlagName(FlagPrefixTLS, FlagSuffixTLSKeyFile),
+	options.FlagName(FlagPrefixTLS, FlagSuffixTLSSkipVerify),
+	options.FlagName(FlagPrefixSpiffe, FlagSuffixSpiffeEnabled),
+	options.FlagName(FlagPrefixSpiffe, FlagSuffixSpiffeEndpoint),
+	FlagDefaultZoneID,
+}
+
+// ContextSettingKeys returns the settings a named context bundles.
+func ContextSettingKeys() []string {
+	return append([]string{}, contextSettingKeys...)
+}
+
+// ValidateContextName validates the name of a named context.
+func ValidateContextName(name string) error {
+	return azvalidators.ValidateName("context", name)
+}
+
+// NamedContexts returns the named contexts of the config, keyed by name.
+func NamedContexts(v *viper.Viper) map[string]map[string]any {
+	contexts := map[string]map[string]any{}
+	for name, value := range v.GetStringMap(FlagContexts) {
+		if settings, ok := value.(map[string]any); ok {
+			contexts[name] = settings
+		}
+	}
+	return contexts
+}
+
+// NamedContextNames returns the names of the named contexts of the config, sorted.
+func NamedContextNames(v *viper.Viper) []string {
+	names := []string{}
+	for name := range NamedContexts(v) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectedContextName returns the context selected with the context flag, or else the current context of the config.
+func selectedContextName(v *viper.Viper) string {
+	if name := strings.TrimSpace(v.GetString(FlagContext)); name != "" {
+		return name
+	}
+	return strings.TrimSpace(v.GetString(FlagCurrentContext))
+}
+
+// applyNamedContext applies the settings of the selected context over the global settings.
+// Settings passed as flags or environment variables still take precedence over the context.
+func applyNamedContext(cmd *cobra.Command, v *viper.Viper) (string, error) {
+	name := selectedContextName(v)
+	if name == "" {
+		return "", nil
+	}
+	settings, ok := NamedContexts(v)[name]
+	if !ok {
+		return "", fmt.Errorf("cli: context %s does not exist — use 'permguard config get-contexts' to list the contexts and 'permguard config use-context' to select one", name)
+	}
+	for _, key := range contextSettingKeys {
+		value, ok := settings[key]
+		if !ok {
+			continue
+		}
+		if flag := cmd.Flags().Lookup(key); flag != nil && flag.Changed {
+			continue
+		}
+		if _, ok := os.LookupEnv(options.EnvVarName(key)); ok {
+			continue
+		}
+		v.Set(key, value)
+	}
+	return name, nil
+}
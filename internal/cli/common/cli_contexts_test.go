@@ -0,0 +1,138 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/cli/options"
+)
+
+// newNamedContextsViper creates a viper holding a dev and a prod context.
+func newNamedContextsViper() *viper.Viper {
+	v := viper.New()
+	v.SetDefault("zap-endpoint", "grpc://localhost:9091")
+	v.SetDefault("pap-endpoint", "grpc://localhost:9092")
+	v.Set(FlagContexts, map[string]any{
+		"dev":  map[string]any{"zap-endpoint": "grpc://dev:9091", FlagDefaultZoneID: int64(273165098782)},
+		"prod": map[string]any{"zap-endpoint": "grpcs://prod:9091", "tls-skip-verify": false},
+	})
+	return v
+}
+
+// TestApplyNamedContext tests the applyNamedContext function.
+func TestApplyNamedContext(t *testing.T) {
+	assert := assert.New(t)
+	cmd := &cobra.Command{}
+	cmd.Flags().String("pap-endpoint", "", "")
+
+	v := newNamedContextsViper()
+	name, err := applyNamedContext(cmd, v)
+	require.NoError(t, err)
+	assert.Empty(name, "no context should be applied when none is selected")
+	assert.Equal("grpc://localhost:9091", v.GetString("zap-endpoint"))
+
+	v = newNamedContextsViper()
+	v.Set(FlagCurrentContext, "dev")
+	name, err = applyNamedContext(cmd, v)
+	require.NoError(t, err)
+	assert.Equal("dev", name)
+	assert.Equal("grpc://dev:9091", v.GetString("zap-endpoint"))
+	assert.Equal("grpc://localhost:9092", v.GetString("pap-endpoint"), "settings missing from the context should fall back to the global ones")
+	assert.Equal(int64(273165098782), v.GetInt64(FlagDefaultZoneID))
+
+	v = newNamedContextsViper()
+	v.Set(FlagCurrentContext, "dev")
+	v.Set(FlagContext, "prod")
+	name, err = applyNamedContext(cmd, v)
+	require.NoError(t, err)
+	assert.Equal("prod", name, "the context flag should take precedence over the current context")
+	assert.Equal("grpcs://prod:9091", v.GetString("zap-endpoint"))
+
+	v = newNamedContextsViper()
+	v.Set(FlagContext, "staging")
+	_, err = applyNamedContext(cmd, v)
+	assert.Error(err, "a context which does not exist should be rejected")
+}
+
+// TestApplyNamedContextWithFlags tests that the flags take precedence over the context settings.
+func TestApplyNamedContextWithFlags(t *testing.T) {
+	assert := assert.New(t)
+	cmd := &cobra.Command{}
+	cmd.Flags().String("zap-endpoint", "", "")
+	require.NoError(t, cmd.Flags().Set("zap-endpoint", "grpc://flag:9091"))
+
+	v := newNamedContextsViper()
+	require.NoError(t, v.BindPFlag("zap-endpoint", cmd.Flags().Lookup("zap-endpoint")))
+	v.Set(FlagCurrentContext, "dev")
+	_, err := applyNamedContext(cmd, v)
+	require.NoError(t, err)
+	assert.Equal("grpc://flag:9091", v.GetString("zap-endpoint"))
+}
+
+// TestApplyNamedContextWithEnv tests that the environment variables take precedence over the context settings.
+func TestApplyNamedContextWithEnv(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv(options.EnvVarName(FlagDefaultZoneID), "895741663247")
+
+	v, err := options.NewViper()
+	require.NoError(t, err)
+	v.Set(FlagContexts, map[string]any{
+		"dev": map[string]any{"zap-endpoint": "grpc://dev:9091", FlagDefaultZoneID: int64(273165098782)},
+	})
+	v.Set(FlagCurrentContext, "dev")
+	_, err = applyNamedContext(&cobra.Command{}, v)
+	require.NoError(t, err)
+	assert.Equal("grpc://dev:9091", v.GetString("zap-endpoint"))
+	assert.Equal(int64(895741663247), v.GetInt64(FlagDefaultZoneID), "the environment variable should take precedence over the context")
+}
+
+// TestLedgerURIWithDefaultZone tests the completion of the ledger uris with the default zone id.
+func TestLedgerURIWithDefaultZone(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultZoneID int64
+		ledgerURI     string
+		expected      string
+		hasError      bool
+	}{
+		{name: "zone id", defaultZoneID: 273165098782, ledgerURI: "origin/895741663247/orders", expected: "origin/895741663247/orders"},
+		{name: "default zone id", defaultZoneID: 273165098782, ledgerURI: "origin/orders", expected: "origin/273165098782/orders"},
+		{name: "server", defaultZoneID: 273165098782, ledgerURI: "localhost/orders", expected: "localhost/273165098782/orders"},
+		{name: "no default zone id", ledgerURI: "origin/orders", hasError: true},
+		{name: "no default zone id with zone id", ledgerURI: "origin/895741663247/orders", expected: "origin/895741663247/orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set(FlagDefaultZoneID, tt.defaultZoneID)
+			ctx := &CliCommandContext{v: v}
+			ledgerURI, err := ctx.LedgerURIWithDefaultZone(tt.ledgerURI)
+			if tt.hasError {
+				assert.Error(t, err, "error should not be nil")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			assert.Equal(t, tt.expected, ledgerURI, "ledger uri should be completed")
+		})
+	}
+}
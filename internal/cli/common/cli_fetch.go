@@ -0,0 +1,66 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	azvalidators "github.com/permguard/permguard/pkg/core/validators"
+	"github.com/permguard/permguard/pkg/transport/models"
+)
+
+// fetchSortFields are the fields a list can be sorted by.
+var fetchSortFields = []string{models.FetchSortByID, models.FetchSortByName, models.FetchSortByCreatedAt, models.FetchSortByUpdatedAt}
+
+// ValidateNameSearch validates the name and name prefix search terms of a list, empty terms are skipped.
+func ValidateNameSearch(entity string, name string, namePrefix string) error {
+	if name != "" {
+		if err := azvalidators.ValidateNameSearch(entity, name); err != nil {
+			return fmt.Errorf("cli: --name %s is not valid, it must contain only lowercase letters, digits and hyphens", name)
+		}
+	}
+	if namePrefix != "" {
+		if err := azvalidators.ValidateNameSearch(entity, namePrefix); err != nil {
+			return fmt.Errorf("cli: --name-prefix %s is not valid, it must contain only lowercase letters, digits and hyphens", namePrefix)
+		}
+	}
+	return nil
+}
+
+// ParseFetchOptions parses the sort and cursor flags of a list.
+func ParseFetchOptions(sortBy string, sortDesc bool, cursor string) (*models.FetchOptions, error) {
+	if !models.IsValidFetchSortBy(sortBy) {
+		return nil, fmt.Errorf("cli: --sort must be one of %s", strings.Join(fetchSortFields, ", "))
+	}
+	return &models.FetchOptions{
+		SortBy:   sortBy,
+		SortDesc: sortDesc,
+		Cursor:   cursor,
+	}, nil
+}
+
+// AddFetchPageInfo adds the total count and the cursor of the next page to the json output of a list.
+func AddFetchPageInfo(output map[string]any, pageInfo *models.FetchPageInfo) {
+	if pageInfo == nil {
+		return
+	}
+	output["total_count"] = pageInfo.TotalCount
+	if pageInfo.NextCursor != "" {
+		output["next_cursor"] = pageInfo.NextCursor
+	}
+}
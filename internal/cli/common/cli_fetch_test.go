@@ -0,0 +1,45 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/transport/models"
+)
+
+// TestParseFetchOptions tests the ParseFetchOptions function.
+func TestParseFetchOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	options, err := ParseFetchOptions("updated_at", true, "cursor")
+	require.NoError(t, err, "error should be nil")
+	assert.Equal(&models.FetchOptions{SortBy: models.FetchSortByUpdatedAt, SortDesc: true, Cursor: "cursor"}, options, "options should be equal")
+
+	_, err = ParseFetchOptions("kind", false, "")
+	require.Error(t, err, "error should not be nil")
+
+	require.NoError(t, ValidateNameSearch("zone", "rent", ""), "error should be nil")
+	require.Error(t, ValidateNameSearch("zone", "", "rent%"), "error should not be nil")
+
+	output := map[string]any{}
+	AddFetchPageInfo(output, &models.FetchPageInfo{TotalCount: 3, NextCursor: "next"})
+	assert.Equal(map[string]any{"total_count": int64(3), "next_cursor": "next"}, output, "output should be equal")
+}
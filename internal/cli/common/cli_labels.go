@@ -0,0 +1,56 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	azvalidators "github.com/permguard/permguard/pkg/core/validators"
+)
+
+// ParseLabels parses the labels passed as key=value pairs, empty values are skipped so that
+// a single empty value yields an empty, non nil, label set.
+func ParseLabels(entity string, values []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("cli: label %s is not valid, it must be in the key=value format", value)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	if err := azvalidators.ValidateLabels(entity, labels); err != nil {
+		return nil, errors.Join(errors.New("cli: invalid labels"), err)
+	}
+	return labels, nil
+}
+
+// FormatLabels formats the labels as comma separated key=value pairs sorted by key.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseLabels tests the ParseLabels function.
+func TestParseLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	labels, err := ParseLabels("zone", []string{"owner=team-a", " env = prod ", "cost-centre="})
+	require.NoError(t, err, "error should be nil")
+	assert.Equal(map[string]string{"owner": "team-a", "env": "prod", "cost-centre": ""}, labels, "labels should be equal")
+	assert.Equal("cost-centre=,env=prod,owner=team-a", FormatLabels(labels), "formatted labels should be equal")
+
+	labels, err = ParseLabels("zone", []string{""})
+	require.NoError(t, err, "error should be nil")
+	assert.NotNil(labels, "labels should not be nil")
+	assert.Empty(labels, "labels should be empty")
+
+	_, err = ParseLabels("zone", []string{"owner"})
+	require.Error(t, err, "error should not be nil")
+	_, err = ParseLabels("zone", []string{"Owner=team-a"})
+	require.Error(t, err, "error should not be nil")
+}
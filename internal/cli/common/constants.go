@@ -40,6 +40,14 @@ const (
 	FlagCommonName                  = "name"
 	FlagCommonEmail                 = "email"
 	FlagCommonDescription           = "description"
+	FlagCommonLabel                 = "label"
+	FlagCommonNamePrefix            = "name-prefix"
+	FlagCommonSort                  = "sort"
+	FlagCommonSortDesc              = "desc"
+	FlagCommonCursor                = "cursor"
+	FlagCommonDeleted               = "deleted"
+	FlagCommonForce                 = "force"
+	FlagCommonConfirm               = "confirm"
 	FlagCommonFile                  = "file"
 	FlagCommonFileShort             = "f"
 	FlagPrefixZAP                   = "zap"
@@ -57,6 +65,11 @@ const (
 	FlagSuffixAuthstarMaxObjectSize = "max-object-size"
 	FlagPrefixNOTP                  = "notp"
 	FlagSuffixNOTPMaxPacketSize     = "max-packet-size"
+	FlagSuffixNOTPTrace             = "trace"
+	FlagPrefixCommit                = "commit"
+	FlagSuffixCommitSigningKey      = "signing-key"
+	FlagSuffixCommitAuthorName      = "author-name"
+	FlagSuffixCommitAuthorEmail     = "author-email"
 	FlagPrefixTLS                   = "tls"
 	FlagSuffixTLSCAFile             = "ca-file"
 	FlagSuffixTLSCertFile           = "cert-file"
@@ -65,6 +78,10 @@ const (
 	FlagPrefixSpiffe                = "spiffe"
 	FlagSuffixSpiffeEnabled         = "enabled"
 	FlagSuffixSpiffeEndpoint        = "endpoint"
+	FlagContext                     = "context"
+	FlagCurrentContext              = "current-context"
+	FlagContexts                    = "contexts"
+	FlagDefaultZoneID               = "default-zone-id"
 )
 
 //go:embed "art.txt"
@@ -42,5 +42,7 @@ func CreateCommandForAuthZ(deps cli.DependenciesProvider, v *viper.Viper) *cobra
 	}
 	command.AddCommand(createCommandForLedgers(deps, v))
 	command.AddCommand(createCommandForCheck(deps, v))
+	command.AddCommand(createCommandForReplay(deps, v))
+	command.AddCommand(createCommandForPartial(deps, v))
 	return command
 }
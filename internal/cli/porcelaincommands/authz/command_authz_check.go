@@ -121,6 +121,13 @@ func runECommandForCheck(deps cli.DependenciesProvider, cmd *cobra.Command, v *v
 			authzReq.AuthorizationModel = &pdp.AuthorizationModelRequest{}
 		}
 		authzReq.AuthorizationModel.ZoneID = flagZoneID
+	} else if authzReq.AuthorizationModel == nil || authzReq.AuthorizationModel.ZoneID == 0 {
+		if defaultZoneID := ctx.DefaultZoneID(); defaultZoneID > 0 {
+			if authzReq.AuthorizationModel == nil {
+				authzReq.AuthorizationModel = &pdp.AuthorizationModelRequest{}
+			}
+			authzReq.AuthorizationModel.ZoneID = defaultZoneID
+		}
 	}
 	flagPolicyStoreID := v.GetString(options.FlagName(commandNameForCheck, common.FlagCommonPolicyStoreID))
 	if flagPolicyStoreID != "" {
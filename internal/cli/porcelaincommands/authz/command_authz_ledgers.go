@@ -19,6 +19,7 @@ package authz
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/fatih/color"
@@ -39,8 +40,41 @@ const (
 	flagLedgerID = "ledger-id"
 	// flagLedgerKind is the flag for ledger kind.
 	flagLedgerKind = "kind"
+	// flagLedgerTrustedKey is the flag for a public key trusted to sign the ledger commits.
+	flagLedgerTrustedKey = "trusted-key"
 )
 
+// readTrustedKeys reads the trusted keys passed either inline or as paths to public key files.
+// A file can hold a single pem encoded key or many keys in authorized keys format.
+func readTrustedKeys(values []string) ([]string, error) {
+	keys := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		data, err := os.ReadFile(value)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("cli: trusted key file %s cannot be read: %w", value, err)
+			}
+			keys = append(keys, value)
+			continue
+		}
+		content := strings.TrimSpace(string(data))
+		if strings.HasPrefix(content, "-----BEGIN") {
+			keys = append(keys, content)
+			continue
+		}
+		for line := range strings.SplitSeq(content, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				keys = append(keys, line)
+			}
+		}
+	}
+	return keys, nil
+}
+
 // failWithDetails drains any buffered verbose details and prints the error with details.
 func failWithDetails(ctx *common.CliCommandContext, printer cli.Printer, err error) error {
 	output := map[string]any{}
@@ -80,6 +114,9 @@ func runECommandForUpsertLedger(deps cli.DependenciesProvider, cmd *cobra.Comman
 	}
 	defer func() { _ = client.Close() }()
 	zoneID := v.GetInt64(options.FlagName(commandNameForLedger, common.FlagCommonZoneID))
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
 	if zoneID == 0 {
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
 	}
@@ -87,13 +124,32 @@ func runECommandForUpsertLedger(deps cli.DependenciesProvider, cmd *cobra.Comman
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
 	}
 	ledger := &pap.Ledger{ZoneID: zoneID}
+	var trustedKeys []string
+	if cmd.Flags().Changed(flagLedgerTrustedKey) {
+		trustedKeys, err = readTrustedKeys(v.GetStringSlice(options.FlagName(flagPrefix, flagLedgerTrustedKey)))
+		if err != nil {
+			return failWithDetails(ctx, printer, err)
+		}
+	}
+	var description *string
+	if cmd.Flags().Changed(common.FlagCommonDescription) {
+		value := v.GetString(options.FlagName(flagPrefix, common.FlagCommonDescription))
+		description = &value
+	}
+	var labels map[string]string
+	if cmd.Flags().Changed(common.FlagCommonLabel) {
+		labels, err = common.ParseLabels("ledger", v.GetStringSlice(options.FlagName(flagPrefix, common.FlagCommonLabel)))
+		if err != nil {
+			return failWithDetails(ctx, printer, err)
+		}
+	}
 	if isCreate {
 		name := v.GetString(options.FlagName(flagPrefix, common.FlagCommonName))
 		if err := validators.ValidateName("ledger", name); err != nil {
 			return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid ledger name"), err))
 		}
 		ledger.Name = name
-		ledger, err = client.CreateLedger(zoneID, "policy", name)
+		ledger, err = client.CreateLedger(zoneID, "policy", name, trustedKeys, v.GetString(options.FlagName(flagPrefix, common.FlagCommonDescription)), labels)
 	} else {
 		ledgerID := v.GetString(options.FlagName(flagPrefix, flagLedgerID))
 		if ledgerID == "" {
@@ -105,6 +161,9 @@ func runECommandForUpsertLedger(deps cli.DependenciesProvider, cmd *cobra.Comman
 		}
 		ledger.LedgerID = ledgerID
 		ledger.Name = name
+		ledger.TrustedKeys = trustedKeys
+		ledger.Description = description
+		ledger.Labels = labels
 		ledger, err = client.UpdateLedger(ledger)
 	}
 	if err != nil {
@@ -156,6 +215,7 @@ func createCommandForLedgers(deps cli.DependenciesProvider, v *viper.Viper) *cob
 	command.AddCommand(createCommandForLedgerCreate(deps, v))
 	command.AddCommand(createCommandForLedgerUpdate(deps, v))
 	command.AddCommand(createCommandForLedgerDelete(deps, v))
+	command.AddCommand(createCommandForLedgerUndelete(deps, v))
 	command.AddCommand(createCommandForLedgerList(deps, v))
 	return command
 }
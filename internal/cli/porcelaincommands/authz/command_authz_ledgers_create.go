@@ -47,6 +47,10 @@ Examples:
   permguard authz ledgers create --zone-id 273165098782 pharmaauthzflow
   # create a ledger and output the result in json format
   permguard authz ledgers create --zone-id 273165098782 pharmaauthzflow --output json
+  # create a ledger accepting only commits signed by a trusted key
+  permguard authz ledgers create --zone-id 273165098782 pharmaauthzflow --trusted-key ~/.ssh/id_ed25519.pub
+  # create a ledger with a description and labels
+  permguard authz ledgers create --zone-id 273165098782 pharmaauthzflow --description "pharma policies" --label owner=team-a
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && !cmd.Flags().Changed(common.FlagCommonName) {
@@ -57,5 +61,11 @@ Examples:
 	}
 	command.Flags().String(common.FlagCommonName, "", "specify the name of the ledger to create")
 	_ = v.BindPFlag(options.FlagName(commandNameForLedgersCreate, common.FlagCommonName), command.Flags().Lookup(common.FlagCommonName))
+	command.Flags().StringArray(flagLedgerTrustedKey, nil, "specify a public key, or a public key file, trusted to sign the ledger commits")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersCreate, flagLedgerTrustedKey), command.Flags().Lookup(flagLedgerTrustedKey))
+	command.Flags().String(common.FlagCommonDescription, "", "specify the description of the ledger")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersCreate, common.FlagCommonDescription), command.Flags().Lookup(common.FlagCommonDescription))
+	command.Flags().StringArray(common.FlagCommonLabel, nil, "specify a label of the ledger as key=value")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersCreate, common.FlagCommonLabel), command.Flags().Lookup(common.FlagCommonLabel))
 	return command
 }
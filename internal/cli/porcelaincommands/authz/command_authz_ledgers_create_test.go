@@ -67,7 +67,7 @@ func TestCliLedgersCreateWithError(t *testing.T) {
 		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
 
 		papClient := mocks.NewGrpcPAPClientMock()
-		papClient.On("CreateLedger", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
+		papClient.On("CreateLedger", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
 
 		printerMock := mocks.NewPrinterMock()
 		printerMock.On("Println", mock.Anything).Return()
@@ -115,7 +115,7 @@ func TestCliLedgersCreateWithSuccess(t *testing.T) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
-		papClient.On("CreateLedger", mock.Anything, mock.Anything, mock.Anything).Return(ledger, nil)
+		papClient.On("CreateLedger", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(ledger, nil)
 
 		printerMock := mocks.NewPrinterMock()
 		outputPrinter := map[string]any{}
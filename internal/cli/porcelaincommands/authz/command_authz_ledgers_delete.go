@@ -27,6 +27,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/pkg/cli"
 	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
@@ -53,6 +54,9 @@ func runECommandForDeleteLedger(deps cli.DependenciesProvider, cmd *cobra.Comman
 	}
 	defer func() { _ = client.Close() }()
 	zoneID := v.GetInt64(options.FlagName(commandNameForLedger, common.FlagCommonZoneID))
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
 	if zoneID == 0 {
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
 	}
@@ -63,7 +67,11 @@ func runECommandForDeleteLedger(deps cli.DependenciesProvider, cmd *cobra.Comman
 	if ledgerID == "" {
 		return failWithDetails(ctx, printer, errors.New("cli: --ledger-id is required"))
 	}
-	ledger, err := client.DeleteLedger(zoneID, ledgerID)
+	deleteOptions := &models.DeleteOptions{
+		Force:        v.GetBool(options.FlagName(commandNameForLedgersDelete, common.FlagCommonForce)),
+		Confirmation: v.GetString(options.FlagName(commandNameForLedgersDelete, common.FlagCommonConfirm)),
+	}
+	ledger, err := client.DeleteLedger(zoneID, ledgerID, deleteOptions)
 	if err != nil {
 		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to delete the ledger"), err))
 	}
@@ -93,9 +101,14 @@ func createCommandForLedgerDelete(deps cli.DependenciesProvider, v *viper.Viper)
 		Short: "Delete a remote ledger",
 		Long: common.BuildCliLongTemplate(`This command deletes a remote ledger.
 
+The ledger is soft deleted and can be restored with the undelete command until the retention window of the server expires.
+A ledger holding policy history is deleted only when confirmed with its name or forced.
+
 Examples:
   # delete a ledger and output the result in json format
   permguard authz ledgers delete --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f --output json
+  # delete a ledger holding policy history confirming it with its name
+  permguard authz ledgers delete --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f --confirm orders
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -104,5 +117,9 @@ Examples:
 	}
 	command.Flags().String(flagLedgerID, "", "specify the ID of the ledger to delete")
 	_ = v.BindPFlag(options.FlagName(commandNameForLedgersDelete, flagLedgerID), command.Flags().Lookup(flagLedgerID))
+	command.Flags().Bool(common.FlagCommonForce, false, "delete the ledger even when it holds policy history")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersDelete, common.FlagCommonForce), command.Flags().Lookup(common.FlagCommonForce))
+	command.Flags().String(common.FlagCommonConfirm, "", "confirm the delete of a ledger holding policy history with its name")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersDelete, common.FlagCommonConfirm), command.Flags().Lookup(common.FlagCommonConfirm))
 	return command
 }
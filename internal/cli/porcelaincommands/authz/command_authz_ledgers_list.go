@@ -60,6 +60,9 @@ func runECommandForListLedgers(deps cli.DependenciesProvider, cmd *cobra.Command
 		return failWithDetails(ctx, printer, errors.New("cli: --size must be a positive integer"))
 	}
 	zoneID := v.GetInt64(options.FlagName(commandNameForLedger, common.FlagCommonZoneID))
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
 	if zoneID == 0 {
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
 	}
@@ -75,7 +78,7 @@ func runECommandForListLedgers(deps cli.DependenciesProvider, cmd *cobra.Command
 		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to list ledgers"), err))
 	}
 	defer func() { _ = zapClient.Close() }()
-	existingZones, err := zapClient.FetchZonesBy(1, 1, zoneID, "")
+	existingZones, err := zapClient.FetchZonesBy(1, 1, zoneID, "", nil)
 	if err != nil {
 		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to list ledgers"), err))
 	}
@@ -84,7 +87,24 @@ func runECommandForListLedgers(deps cli.DependenciesProvider, cmd *cobra.Command
 	}
 	ledgerID := v.GetString(options.FlagName(commandNameForLedgersList, flagLedgerID))
 	kind := v.GetString(options.FlagName(commandNameForLedgersList, flagLedgerKind))
-	ledgers, err := client.FetchLedgersBy(page, pageSize, zoneID, ledgerID, kind, "")
+	labels, err := common.ParseLabels("ledger", v.GetStringSlice(options.FlagName(commandNameForLedgersList, common.FlagCommonLabel)))
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	name := v.GetString(options.FlagName(commandNameForLedgersList, common.FlagCommonName))
+	namePrefix := v.GetString(options.FlagName(commandNameForLedgersList, common.FlagCommonNamePrefix))
+	if err := common.ValidateNameSearch("ledger", name, namePrefix); err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	fetchOptions, err := common.ParseFetchOptions(
+		v.GetString(options.FlagName(commandNameForLedgersList, common.FlagCommonSort)),
+		v.GetBool(options.FlagName(commandNameForLedgersList, common.FlagCommonSortDesc)),
+		v.GetString(options.FlagName(commandNameForLedgersList, common.FlagCommonCursor)))
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	fetchOptions.Deleted = v.GetBool(options.FlagName(commandNameForLedgersList, common.FlagCommonDeleted))
+	ledgers, pageInfo, err := client.FetchLedgersPage(page, pageSize, zoneID, ledgerID, kind, name, namePrefix, labels, fetchOptions)
 	if err != nil {
 		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to list ledgers"), err))
 	}
@@ -93,10 +113,14 @@ func runECommandForListLedgers(deps cli.DependenciesProvider, cmd *cobra.Command
 		for _, ledger := range ledgers {
 			ledgerID := ledger.LedgerID
 			ledgerName := ledger.Name
+			if len(ledger.Labels) > 0 {
+				ledgerName = fmt.Sprintf("%s (%s)", ledgerName, common.FormatLabels(ledger.Labels))
+			}
 			output[ledgerID] = ledgerName
 		}
 	} else if ctx.IsJSONOutput() {
 		output["ledgers"] = ledgers
+		common.AddFetchPageInfo(output, pageInfo)
 	}
 	if ctx.IsVerboseJSONOutput() {
 		details := ctx.DrainVerboseDetails()
@@ -106,6 +130,9 @@ func runECommandForListLedgers(deps cli.DependenciesProvider, cmd *cobra.Command
 		output["details"] = details
 	}
 	printer.PrintlnMap(output)
+	if ctx.IsTerminalOutput() && pageInfo != nil && pageInfo.NextCursor != "" {
+		printer.Println(fmt.Sprintf("Showing %s of %s ledgers, use --cursor %s for the next page.", common.NumberText(len(ledgers)), common.NumberText(int(pageInfo.TotalCount)), pageInfo.NextCursor))
+	}
 	return nil
 }
 
@@ -121,6 +148,14 @@ Examples:
 		permguard authz ledgers list --zone-id 273165098782 --output json
 		# list all ledgers filtered by ledger id
 		permguard authz ledgers list --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f
+		# list all ledgers filtered by labels
+		permguard authz ledgers list --zone-id 273165098782 --label owner=team-a
+		# list the ledgers whose name contains orders sorted by name
+		permguard authz ledgers list --zone-id 273165098782 --name orders --sort name
+		# list the next page of ledgers using the cursor returned by the previous page
+		permguard authz ledgers list --zone-id 273165098782 --size 100 --cursor eyJzIjoibmFtZSIsInYiOiJvcmRlcnMiLCJpIjoiNjY4ZjM3NzFlYWNmNDA5NGJhOGE4MDk0MmVhNWZkM2YifQ
+		# list the soft deleted ledgers that can still be restored
+		permguard authz ledgers list --zone-id 273165098782 --deleted
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -136,5 +171,25 @@ Examples:
 
 	command.Flags().String(flagLedgerID, "", "filter results by ledger id")
 	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, flagLedgerID), command.Flags().Lookup(flagLedgerID))
+
+	command.Flags().StringArray(common.FlagCommonLabel, nil, "filter results by label as key=value, all the labels must match")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonLabel), command.Flags().Lookup(common.FlagCommonLabel))
+
+	command.Flags().String(common.FlagCommonName, "", "filter results by ledgers whose name contains the value")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonName), command.Flags().Lookup(common.FlagCommonName))
+
+	command.Flags().String(common.FlagCommonNamePrefix, "", "filter results by ledgers whose name starts with the value")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonNamePrefix), command.Flags().Lookup(common.FlagCommonNamePrefix))
+
+	command.Flags().String(common.FlagCommonSort, "", "sort results by id, name, created_at or updated_at")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonSort), command.Flags().Lookup(common.FlagCommonSort))
+
+	command.Flags().Bool(common.FlagCommonSortDesc, false, "sort results in descending order")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonSortDesc), command.Flags().Lookup(common.FlagCommonSortDesc))
+
+	command.Flags().String(common.FlagCommonCursor, "", "resume the listing after the last result of a previous page, the page number is ignored")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonCursor), command.Flags().Lookup(common.FlagCommonCursor))
+	command.Flags().Bool(common.FlagCommonDeleted, false, "list the soft deleted ledgers instead of the live ones")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersList, common.FlagCommonDeleted), command.Flags().Lookup(common.FlagCommonDeleted))
 	return command
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils/mocks"
 	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
@@ -68,7 +69,7 @@ func TestCliLedgersListWithError(t *testing.T) {
 		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
 
 		papClient := mocks.NewGrpcPAPClientMock()
-		papClient.On("FetchLedgersBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
+		papClient.On("FetchLedgersPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, errors.New("operation error"))
 
 		printerMock := mocks.NewPrinterMock()
 		printerMock.On("Println", mock.Anything).Return()
@@ -111,7 +112,7 @@ func TestCliLedgersListWithSuccess(t *testing.T) {
 
 		zapClient := mocks.NewGrpcZAPClientMock()
 		zones := []zap.Zone{{ZoneID: 581616507495, Name: "test-zone"}}
-		zapClient.On("FetchZonesBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(zones, nil)
+		zapClient.On("FetchZonesBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(zones, nil)
 
 		papClient := mocks.NewGrpcPAPClientMock()
 		ledgers := []pap.Ledger{
@@ -130,7 +131,7 @@ func TestCliLedgersListWithSuccess(t *testing.T) {
 				UpdatedAt: time.Now(),
 			},
 		}
-		papClient.On("FetchLedgersBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(ledgers, nil)
+		papClient.On("FetchLedgersPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(ledgers, &models.FetchPageInfo{TotalCount: int64(len(ledgers))}, nil)
 
 		printerMock := mocks.NewPrinterMock()
 		outputPrinter := map[string]any{}
@@ -142,6 +143,7 @@ func TestCliLedgersListWithSuccess(t *testing.T) {
 			}
 		} else {
 			outputPrinter["ledgers"] = ledgers
+			outputPrinter["total_count"] = int64(len(ledgers))
 			outputPrinter["details"] = []map[string]any{}
 		}
 		printerMock.On("PrintMap", outputPrinter).Return()
@@ -17,8 +17,12 @@
 package authz
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
 )
 
@@ -28,3 +32,25 @@ func TestCreateCommandForLedgers(t *testing.T) {
 	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command manages ledgers on the remote server."}
 	testutils.BaseCommandTest(t, createCommandForLedgers, args, false, outputs)
 }
+
+// TestReadTrustedKeys tests that trusted keys are read inline or from public key files.
+func TestReadTrustedKeys(t *testing.T) {
+	assert := assert.New(t)
+	inlineKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHiqRThVKcxPSONSZqHkMCYTFfTkDBkzc3Hp0JkH1F2B ci"
+	pemKey := "-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAeKpFOFUpzE9I41JmoeQwJhMV9OQMGTNzcenQmQfUXYE=\n-----END PUBLIC KEY-----"
+
+	dir := t.TempDir()
+	authorizedKeysFile := filepath.Join(dir, "authorized_keys")
+	assert.NoError(os.WriteFile(authorizedKeysFile, []byte("# ci keys\n"+inlineKey+"\n\n"+inlineKey+"\n"), 0o600))
+	pemFile := filepath.Join(dir, "key.pem")
+	assert.NoError(os.WriteFile(pemFile, []byte(pemKey+"\n"), 0o600))
+
+	keys, err := readTrustedKeys([]string{inlineKey, authorizedKeysFile, pemFile, " "})
+	assert.NoError(err)
+	assert.Equal([]string{inlineKey, inlineKey, inlineKey, pemKey}, keys)
+
+	keys, err = readTrustedKeys([]string{""})
+	assert.NoError(err)
+	assert.NotNil(keys)
+	assert.Empty(keys)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+)
+
+const (
+	// commandNameForLedgersUndelete is the command name for ledgers undelete.
+	commandNameForLedgersUndelete = "ledgers-undelete"
+)
+
+// runECommandForUndeleteLedger runs the command for restoring a ledger.
+func runECommandForUndeleteLedger(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	papEndpoint, err := ctx.PAPEndpoint()
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to undelete the ledger"), err))
+	}
+	tlsCfg := ctx.TLSClientConfig()
+	client, err := deps.CreateGrpcPAPClient(papEndpoint, tlsCfg, ctx.VerboseCollector())
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to undelete the ledger"), err))
+	}
+	defer func() { _ = client.Close() }()
+	zoneID := v.GetInt64(options.FlagName(commandNameForLedger, common.FlagCommonZoneID))
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
+	if zoneID == 0 {
+		return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
+	}
+	if zoneID < 0 {
+		return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
+	}
+	ledgerID := v.GetString(options.FlagName(commandNameForLedgersUndelete, flagLedgerID))
+	if ledgerID == "" {
+		return failWithDetails(ctx, printer, errors.New("cli: --ledger-id is required"))
+	}
+	ledger, err := client.UndeleteLedger(zoneID, ledgerID)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to undelete the ledger"), err))
+	}
+	output := map[string]any{}
+	if ctx.IsTerminalOutput() {
+		ledgerID := ledger.LedgerID
+		ledgerName := ledger.Name
+		output[ledgerID] = ledgerName
+	} else if ctx.IsJSONOutput() {
+		output["ledgers"] = []*pap.Ledger{ledger}
+	}
+	if ctx.IsVerboseJSONOutput() {
+		details := ctx.DrainVerboseDetails()
+		if details == nil {
+			details = []map[string]any{}
+		}
+		output["details"] = details
+	}
+	printer.PrintlnMap(output)
+	return nil
+}
+
+// createCommandForLedgerUndelete creates a command for restoring a deleted ledger.
+func createCommandForLedgerUndelete(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "undelete",
+		Short: "Restore a deleted remote ledger",
+		Long: common.BuildCliLongTemplate(`This command restores a soft deleted remote ledger.
+
+Examples:
+  # restore a deleted ledger and output the result in json format
+  permguard authz ledgers undelete --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f --output json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForUndeleteLedger(deps, cmd, v)
+		},
+	}
+	command.Flags().String(flagLedgerID, "", "specify the ID of the ledger to restore")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersUndelete, flagLedgerID), command.Flags().Lookup(flagLedgerID))
+	return command
+}
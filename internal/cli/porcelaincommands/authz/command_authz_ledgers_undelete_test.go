@@ -0,0 +1,139 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils/mocks"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+)
+
+// TestUndeleteCommandForLedgersUndelete tests the createCommandForLedgerUndelete function.
+func TestUndeleteCommandForLedgersUndelete(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command restores a soft deleted remote ledger."}
+	testutils.BaseCommandTest(t, createCommandForLedgerUndelete, args, false, outputs)
+}
+
+// TestCliLedgersUndeleteWithError tests the command for restoring a ledger with an error.
+func TestCliLedgersUndeleteWithError(t *testing.T) {
+	tests := []struct {
+		OutputType string
+		HasError   bool
+	}{
+		{
+			OutputType: "terminal",
+			HasError:   true,
+		},
+		{
+			OutputType: "json",
+			HasError:   true,
+		},
+	}
+	for _, test := range tests {
+		args := []string{"--ledger-id", "c3160a533ab24fbcb1eab7a09fd85f36", "--output", test.OutputType}
+		outputs := []string{""}
+
+		v := viper.New()
+		v.Set(options.FlagName(common.FlagPrefixPAP, common.FlagSuffixPAPEndpoint), "localhost:9092")
+
+		depsMocks := mocks.NewCliDependenciesMock()
+		cmd := createCommandForLedgerUndelete(depsMocks, v)
+		cmd.PersistentFlags().StringP(common.FlagWorkingDirectory, common.FlagWorkingDirectoryShort, ".", "work directory")
+		cmd.PersistentFlags().StringP(common.FlagOutput, common.FlagOutputShort, test.OutputType, "output format")
+		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
+
+		papClient := mocks.NewGrpcPAPClientMock()
+		papClient.On("UndeleteLedger", mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
+
+		printerMock := mocks.NewPrinterMock()
+		printerMock.On("Println", mock.Anything).Return()
+		printerMock.On("PrintlnMap", mock.Anything).Return()
+		printerMock.On("ErrorWithOutput", mock.Anything, mock.Anything).Return()
+
+		depsMocks.On("CreatePrinter", mock.Anything, mock.Anything).Return(printerMock, nil)
+		depsMocks.On("CreateGrpcPAPClient", mock.Anything, mock.Anything, mock.Anything).Return(papClient, nil)
+
+		testutils.BaseCommandWithParamsTest(t, v, cmd, args, true, outputs)
+		if test.HasError {
+			printerMock.AssertCalled(t, "ErrorWithOutput", mock.Anything, mock.Anything)
+		} else {
+			printerMock.AssertNotCalled(t, "ErrorWithOutput", mock.Anything, mock.Anything)
+		}
+	}
+}
+
+// TestCliLedgersUndeleteWithSuccess tests the command for restoring a ledger.
+func TestCliLedgersUndeleteWithSuccess(t *testing.T) {
+	tests := []string{
+		"terminal",
+		"json",
+	}
+	for _, outputType := range tests {
+		args := []string{"--ledger-id", "c3160a533ab24fbcb1eab7a09fd85f36", "--output", outputType}
+		outputs := []string{""}
+
+		v := viper.New()
+		v.Set("output", outputType)
+		v.Set(options.FlagName(common.FlagPrefixPAP, common.FlagSuffixPAPEndpoint), "localhost:9092")
+		v.Set(options.FlagName(commandNameForLedger, common.FlagCommonZoneID), int64(581616507495))
+
+		depsMocks := mocks.NewCliDependenciesMock()
+		cmd := createCommandForLedgerUndelete(depsMocks, v)
+		cmd.PersistentFlags().StringP(common.FlagWorkingDirectory, common.FlagWorkingDirectoryShort, ".", "work directory")
+		cmd.PersistentFlags().StringP(common.FlagOutput, common.FlagOutputShort, outputType, "output format")
+		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
+
+		papClient := mocks.NewGrpcPAPClientMock()
+		ledger := &pap.Ledger{
+			LedgerID:  "c3160a533ab24fbcb1eab7a09fd85f36",
+			ZoneID:    581616507495,
+			Name:      "materabranch",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		papClient.On("UndeleteLedger", mock.Anything, mock.Anything).Return(ledger, nil)
+
+		printerMock := mocks.NewPrinterMock()
+		outputPrinter := map[string]any{}
+
+		if outputType == "terminal" {
+			ledgerID := ledger.LedgerID
+			outputPrinter[ledgerID] = ledger.Name
+		} else {
+			outputPrinter["ledgers"] = []*pap.Ledger{ledger}
+			outputPrinter["details"] = []map[string]any{}
+		}
+		printerMock.On("PrintMap", outputPrinter).Return()
+		printerMock.On("PrintlnMap", outputPrinter).Return()
+
+		depsMocks.On("CreatePrinter", mock.Anything, mock.Anything).Return(printerMock, nil)
+		depsMocks.On("CreateGrpcPAPClient", mock.Anything, mock.Anything, mock.Anything).Return(papClient, nil)
+
+		testutils.BaseCommandWithParamsTest(t, v, cmd, args, false, outputs)
+		printerMock.AssertCalled(t, "PrintlnMap", outputPrinter)
+	}
+}
@@ -47,6 +47,12 @@ Examples:
   permguard authz ledgers update --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f v1.1
   # update a ledger and output the result in json format
   permguard authz ledgers update --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f v1.1 --output json
+  # replace the keys trusted to sign the ledger commits
+  permguard authz ledgers update --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f v1.1 --trusted-key ~/.ssh/id_ed25519.pub
+  # remove all the keys trusted to sign the ledger commits
+  permguard authz ledgers update --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f v1.1 --trusted-key ""
+  # replace the labels of a ledger
+  permguard authz ledgers update --zone-id 273165098782 --ledger-id 668f3771eacf4094ba8a80942ea5fd3f v1.1 --label owner=team-b
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && !cmd.Flags().Changed(common.FlagCommonName) {
@@ -59,5 +65,11 @@ Examples:
 	_ = v.BindPFlag(options.FlagName(commandNameForLedgersUpdate, flagLedgerID), command.Flags().Lookup(flagLedgerID))
 	command.Flags().String(common.FlagCommonName, "", "specify the new name for the ledger")
 	_ = v.BindPFlag(options.FlagName(commandNameForLedgersUpdate, common.FlagCommonName), command.Flags().Lookup(common.FlagCommonName))
+	command.Flags().StringArray(flagLedgerTrustedKey, nil, "specify a public key, or a public key file, trusted to sign the ledger commits; replaces the current keys")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersUpdate, flagLedgerTrustedKey), command.Flags().Lookup(flagLedgerTrustedKey))
+	command.Flags().String(common.FlagCommonDescription, "", "specify the new description for the ledger")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersUpdate, common.FlagCommonDescription), command.Flags().Lookup(common.FlagCommonDescription))
+	command.Flags().StringArray(common.FlagCommonLabel, nil, "specify a label of the ledger as key=value; replaces the current labels")
+	_ = v.BindPFlag(options.FlagName(commandNameForLedgersUpdate, common.FlagCommonLabel), command.Flags().Lookup(common.FlagCommonLabel))
 	return command
 }
@@ -0,0 +1,288 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace"
+	"github.com/permguard/permguard/pkg/authz/residuals"
+	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+const (
+	// commandNameForPartial is the command name for partial.
+	commandNameForPartial = "partial"
+	// flagUnknownContext is the flag for the unknown context keys.
+	flagUnknownContext = "unknown-context"
+	// flagSQL is the flag to translate the residual into a SQL WHERE fragment.
+	flagSQL = "sql"
+	// flagSQLColumn is the flag for the columns of the attributes of the resource.
+	flagSQLColumn = "column"
+	// flagSQLParentColumn is the flag for the columns of the parents of the resource.
+	flagSQLParentColumn = "parent-column"
+	// flagSQLPlaceholder is the flag for the placeholder style of the SQL arguments.
+	flagSQLPlaceholder = "placeholder"
+)
+
+// parseColumnMappings parses the column mappings in the name=column format.
+func parseColumnMappings(flag string, values []string) (map[string]string, error) {
+	mappings := map[string]string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		name, column, ok := strings.Cut(value, "=")
+		name, column = strings.TrimSpace(name), strings.TrimSpace(column)
+		if !ok || name == "" || column == "" {
+			return nil, fmt.Errorf("cli: --%s %s is not valid, it must be in the name=column format", flag, value)
+		}
+		mappings[name] = column
+	}
+	return mappings, nil
+}
+
+// buildPartialSQL translates the residual of the partial evaluation into a SQL WHERE fragment.
+func buildPartialSQL(response *pdp.AuthorizationPartialEvaluationResponse, resourceType string, columns, parentColumns map[string]string, placeholder string) (string, []any, error) {
+	residual := response.Residual
+	switch response.Decision {
+	case residuals.DecisionAllow:
+		residual = residuals.True()
+	case residuals.DecisionDeny:
+		residual = residuals.False()
+	}
+	opts := &residuals.SQLOptions{
+		Columns:       columns,
+		ParentColumns: parentColumns,
+		ResourceType:  resourceType,
+	}
+	switch placeholder {
+	case "", "?":
+	case "$":
+		opts.Placeholder = func(n int) string { return fmt.Sprintf("$%d", n) }
+	default:
+		return "", nil, fmt.Errorf("cli: --%s %s is not valid, it must be either ? or $", flagSQLPlaceholder, placeholder)
+	}
+	return residuals.ToSQL(residual, opts)
+}
+
+// runECommandForPartial runs the command for executing partial.
+func runECommandForPartial(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	var input io.Reader
+	if len(args) > 0 {
+		jsonPath := args[0]
+		if !filepath.IsAbs(jsonPath) {
+			jsonPath = filepath.Join(ctx.WorkDir(), jsonPath)
+		}
+		ctx.AppendVerboseAction("reading partial evaluation request input file")
+		ctx.AppendVerboseFile(jsonPath)
+		file, err := os.Open(jsonPath)
+		if err != nil {
+			return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid input for the authz partial"), fmt.Errorf("failed to open file %s", jsonPath)))
+		}
+		defer func() { _ = file.Close() }()
+		input = file
+	} else {
+		ctx.AppendVerboseAction("reading partial evaluation request from stdin")
+		input = os.Stdin
+	}
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid input for the authz partial"), err))
+	}
+	var partialReq pdp.AuthorizationPartialEvaluationRequest
+	ctx.AppendVerboseAction("unmarshaling partial evaluation request json")
+	if err = json.Unmarshal(data, &partialReq); err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid input for the authz partial"), buildUnmarshalError(err)))
+	}
+	partialReq.UnknownContext = append(partialReq.UnknownContext, v.GetStringSlice(options.FlagName(commandNameForPartial, flagUnknownContext))...)
+
+	sqlOutput := v.GetBool(options.FlagName(commandNameForPartial, flagSQL))
+	columns, err := parseColumnMappings(flagSQLColumn, v.GetStringSlice(options.FlagName(commandNameForPartial, flagSQLColumn)))
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	parentColumns, err := parseColumnMappings(flagSQLParentColumn, v.GetStringSlice(options.FlagName(commandNameForPartial, flagSQLParentColumn)))
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	placeholder := strings.TrimSpace(v.GetString(options.FlagName(commandNameForPartial, flagSQLPlaceholder)))
+
+	// Resolve the target policy store: request (low), --current-workspace (medium), flags (high).
+	if partialReq.AuthorizationModel == nil {
+		partialReq.AuthorizationModel = &pdp.AuthorizationModelRequest{}
+	}
+	authzModel := partialReq.AuthorizationModel
+	if authzModel.PolicyStore == nil {
+		authzModel.PolicyStore = &pdp.PolicyStore{}
+	}
+	if v.GetBool(options.FlagName(commandNameForPartial, common.FlagCommonCurrentWorkspace)) {
+		langFct, langErr := deps.LanguageFactory()
+		if langErr == nil {
+			wksMgr, wksErr := workspace.NewInternalManager(ctx, langFct)
+			if wksErr == nil {
+				if wksZoneID, wksLedgerID, headErr := wksMgr.CurrentHeadZoneIDAndLedgerID(); headErr == nil {
+					authzModel.ZoneID = wksZoneID
+					authzModel.PolicyStore.ID = wksLedgerID
+				}
+			}
+		}
+	}
+	if cmd.Flags().Changed(common.FlagCommonZoneID) {
+		flagZoneID := v.GetInt64(options.FlagName(commandNameForPartial, common.FlagCommonZoneID))
+		if flagZoneID <= 0 {
+			return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
+		}
+		authzModel.ZoneID = flagZoneID
+	}
+	if authzModel.ZoneID == 0 {
+		authzModel.ZoneID = ctx.DefaultZoneID()
+	}
+	if flagPolicyStoreID := v.GetString(options.FlagName(commandNameForPartial, common.FlagCommonPolicyStoreID)); flagPolicyStoreID != "" {
+		authzModel.PolicyStore.ID = flagPolicyStoreID
+	}
+
+	pdpEndpoint, err := ctx.PDPEndpoint()
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: storage: failed to partially evaluate the authorization request"), err))
+	}
+	tlsCfg := ctx.TLSClientConfig()
+	client, err := deps.CreateGrpcPDPClient(pdpEndpoint, tlsCfg, ctx.VerboseCollector())
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: storage: failed to partially evaluate the authorization request"), err))
+	}
+	defer func() { _ = client.Close() }()
+	partialResp, err := client.AuthorizationPartialEvaluation(&partialReq)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: storage: failed to partially evaluate the authorization request"), err))
+	}
+	var where string
+	var whereArgs []any
+	if sqlOutput {
+		resourceType := ""
+		if partialReq.Resource != nil {
+			resourceType = partialReq.Resource.Type
+		}
+		where, whereArgs, err = buildPartialSQL(partialResp, resourceType, columns, parentColumns, placeholder)
+		if err != nil {
+			return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to translate the residual into sql"), err))
+		}
+	}
+	if ctx.IsTerminalOutput() {
+		printer.Println(fmt.Sprintf("Partial evaluation response: %s", common.KeywordText(partialResp.Decision)))
+		if partialResp.RequestID != "" {
+			printer.Println(fmt.Sprintf("%s: %s", common.KeywordText("Request ID"), common.CreateText(partialResp.RequestID)))
+		}
+		if len(partialResp.Policies) > 0 {
+			printer.Println(fmt.Sprintf("%s: %s", common.KeywordText("Policies"), strings.Join(partialResp.Policies, ", ")))
+		}
+		if sqlOutput {
+			printer.Println(fmt.Sprintf("%s: %s", common.KeywordText("Where"), where))
+			for i, arg := range whereArgs {
+				printer.Println(fmt.Sprintf("  - %s %s: %v", common.KeywordText("Arg"), common.NumberText(i+1), arg))
+			}
+		} else if partialResp.Residual != nil {
+			residual, err := json.MarshalIndent(partialResp.Residual, "", "  ")
+			if err != nil {
+				return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to print the residual"), err))
+			}
+			printer.Println(fmt.Sprintf("%s: %s", common.KeywordText("Residual"), string(residual)))
+		}
+	} else if ctx.IsJSONOutput() {
+		output := map[string]any{}
+		output["authorization_partial_evaluation"] = partialResp
+		if sqlOutput {
+			output["sql"] = map[string]any{
+				"where": where,
+				"args":  whereArgs,
+			}
+		}
+		if ctx.IsVerboseJSONOutput() {
+			details := ctx.DrainVerboseDetails()
+			if details == nil {
+				details = []map[string]any{}
+			}
+			output["details"] = details
+		}
+		printer.PrintlnMap(output)
+	}
+	return nil
+}
+
+// createCommandForPartial creates a command for executing partial.
+func createCommandForPartial(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "partial",
+		Short: "Partially evaluate an authorization request with an unknown resource",
+		Long: common.BuildCliLongTemplate(`This command partially evaluates an authorization request with an unknown resource and returns the residual condition the resources must satisfy.
+
+Examples:
+  # partially evaluate an authorization request
+  permguard authz partial --zone-id 273165098782 /path/to/partial_request.json
+  # translate the residual condition into a sql where fragment
+  permguard authz partial --zone-id 273165098782 --sql --column resource=id --column resource.owner=owner_id --parent-column MagicFarmacia::Platform::Branch=branch_id /path/to/partial_request.json
+		`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForPartial(deps, cmd, v, args)
+		},
+	}
+
+	command.Flags().StringArray(flagUnknownContext, nil, "specify a context key left unknown in the residual")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, flagUnknownContext), command.Flags().Lookup(flagUnknownContext))
+
+	command.Flags().Bool(flagSQL, false, "translate the residual into a sql where fragment")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, flagSQL), command.Flags().Lookup(flagSQL))
+
+	command.Flags().StringArray(flagSQLColumn, nil, "map an attribute path of the resource to a column as path=column, the resource path maps the id column")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, flagSQLColumn), command.Flags().Lookup(flagSQLColumn))
+
+	command.Flags().StringArray(flagSQLParentColumn, nil, "map a parent entity type of the resource to a column as type=column")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, flagSQLParentColumn), command.Flags().Lookup(flagSQLParentColumn))
+
+	command.Flags().String(flagSQLPlaceholder, "?", "placeholder style of the sql arguments, either ? or $")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, flagSQLPlaceholder), command.Flags().Lookup(flagSQLPlaceholder))
+
+	command.PersistentFlags().Int64(common.FlagCommonZoneID, 0, "override authorization_model.zone_id")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, common.FlagCommonZoneID), command.PersistentFlags().Lookup(common.FlagCommonZoneID))
+
+	command.PersistentFlags().String(common.FlagCommonPolicyStoreID, "", "override authorization_model.policy_store.id")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, common.FlagCommonPolicyStoreID), command.PersistentFlags().Lookup(common.FlagCommonPolicyStoreID))
+
+	command.PersistentFlags().BoolP(common.FlagCommonCurrentWorkspace, common.FlagCommonCurrentWorkspaceShort, false, "resolve zone-id and policy-store-id from the current workspace")
+	_ = v.BindPFlag(options.FlagName(commandNameForPartial, common.FlagCommonCurrentWorkspace), command.PersistentFlags().Lookup(common.FlagCommonCurrentWorkspace))
+
+	return command
+}
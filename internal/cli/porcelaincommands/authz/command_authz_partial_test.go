@@ -0,0 +1,74 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
+	"github.com/permguard/permguard/pkg/authz/residuals"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// TestCreateCommandForPartial tests the createCommandForPartial function.
+func TestCreateCommandForPartial(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command partially evaluates an authorization request with an unknown resource"}
+	testutils.BaseCommandTest(t, createCommandForPartial, args, false, outputs)
+}
+
+// TestParseColumnMappings tests the parsing of the column mappings.
+func TestParseColumnMappings(t *testing.T) {
+	assert := assert.New(t)
+	mappings, err := parseColumnMappings(flagSQLColumn, []string{"resource=id", " resource.owner = owner_id ", ""})
+	require.NoError(t, err)
+	assert.Equal(map[string]string{"resource": "id", "resource.owner": "owner_id"}, mappings)
+
+	_, err = parseColumnMappings(flagSQLColumn, []string{"resource.owner"})
+	assert.Error(err)
+	_, err = parseColumnMappings(flagSQLParentColumn, []string{"MagicFarmacia::Platform::Branch="})
+	assert.Error(err)
+}
+
+// TestBuildPartialSQL tests the translation of the partial evaluation response into sql.
+func TestBuildPartialSQL(t *testing.T) {
+	assert := assert.New(t)
+	columns := map[string]string{"resource.owner": "owner_id"}
+	parentColumns := map[string]string{"MagicFarmacia::Platform::Branch": "branch_id"}
+	response := &pdp.AuthorizationPartialEvaluationResponse{
+		Decision: residuals.DecisionConditional,
+		Residual: residuals.Or(
+			residuals.Binary(residuals.OpEq, residuals.Attr("resource", "owner"), residuals.Value("amy")),
+			residuals.Binary(residuals.OpIn, residuals.Attr("resource"), residuals.NewEntity("MagicFarmacia::Platform::Branch", "milan")),
+		),
+	}
+	where, args, err := buildPartialSQL(response, "MagicFarmacia::Platform::Order", columns, parentColumns, "$")
+	require.NoError(t, err)
+	assert.Equal("(owner_id = $1 OR branch_id = $2)", where)
+	assert.Equal([]any{"amy", "milan"}, args)
+
+	where, args, err = buildPartialSQL(&pdp.AuthorizationPartialEvaluationResponse{Decision: residuals.DecisionDeny}, "", nil, nil, "?")
+	require.NoError(t, err)
+	assert.Equal("1 = 0", where)
+	assert.Empty(args)
+
+	_, _, err = buildPartialSQL(response, "", columns, parentColumns, ":")
+	assert.Error(err)
+}
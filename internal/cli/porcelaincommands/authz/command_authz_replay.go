@@ -0,0 +1,218 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/agents/decisions"
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace"
+	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+const (
+	// commandNameForReplay is the command name for replay.
+	commandNameForReplay = "replay"
+	// flagCommit is the flag for the commit to replay against.
+	flagCommit = "commit"
+)
+
+// buildReplayRequest builds the replay request keeping only the entries logged for the target policy store.
+func buildReplayRequest(entries []decisions.DecisionLogEntry, zoneID int64, policyStoreID string, commitID string) (*pdp.AuthorizationReplayRequest, int) {
+	request := &pdp.AuthorizationReplayRequest{
+		ZoneID:      zoneID,
+		PolicyStore: &pdp.PolicyStore{ID: policyStoreID},
+		CommitID:    commitID,
+		Entries:     []pdp.ReplayEntry{},
+	}
+	skipped := 0
+	for _, entry := range entries {
+		authzModel := entry.Request.AuthorizationModel
+		if authzModel != nil {
+			if authzModel.ZoneID != 0 && authzModel.ZoneID != zoneID {
+				skipped++
+				continue
+			}
+			if authzModel.PolicyStore != nil && authzModel.PolicyStore.ID != "" && authzModel.PolicyStore.ID != policyStoreID {
+				skipped++
+				continue
+			}
+		}
+		request.Entries = append(request.Entries, *entry.ReplayEntry())
+	}
+	return request, skipped
+}
+
+// runECommandForReplay runs the command for executing replay.
+func runECommandForReplay(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	commitID := strings.TrimSpace(v.GetString(options.FlagName(commandNameForReplay, flagCommit)))
+	if commitID == "" {
+		return failWithDetails(ctx, printer, errors.New("cli: --commit is required for the authz replay"))
+	}
+	logPath := args[0]
+	if !filepath.IsAbs(logPath) {
+		logPath = filepath.Join(ctx.WorkDir(), logPath)
+	}
+	ctx.AppendVerboseAction("reading decision log file")
+	ctx.AppendVerboseFile(logPath)
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid input for the authz replay"), fmt.Errorf("failed to read file %s", logPath)))
+	}
+	entries, err := decisions.ParseDecisionLogs(data)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid input for the authz replay"), err))
+	}
+
+	// Resolve the target policy store: logged entries (low), --current-workspace (medium), flags (high).
+	var zoneID int64
+	var policyStoreID string
+	for _, entry := range entries {
+		if authzModel := entry.Request.AuthorizationModel; authzModel != nil && authzModel.PolicyStore != nil {
+			zoneID = authzModel.ZoneID
+			policyStoreID = authzModel.PolicyStore.ID
+			break
+		}
+	}
+	if v.GetBool(options.FlagName(commandNameForReplay, common.FlagCommonCurrentWorkspace)) {
+		langFct, langErr := deps.LanguageFactory()
+		if langErr == nil {
+			wksMgr, wksErr := workspace.NewInternalManager(ctx, langFct)
+			if wksErr == nil {
+				if wksZoneID, wksLedgerID, headErr := wksMgr.CurrentHeadZoneIDAndLedgerID(); headErr == nil {
+					zoneID = wksZoneID
+					policyStoreID = wksLedgerID
+				}
+			}
+		}
+	}
+	if flagZoneID := v.GetInt64(options.FlagName(commandNameForReplay, common.FlagCommonZoneID)); flagZoneID != 0 {
+		zoneID = flagZoneID
+	}
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
+	if flagPolicyStoreID := v.GetString(options.FlagName(commandNameForReplay, common.FlagCommonPolicyStoreID)); flagPolicyStoreID != "" {
+		policyStoreID = flagPolicyStoreID
+	}
+	if zoneID <= 0 {
+		return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
+	}
+	if policyStoreID == "" {
+		return failWithDetails(ctx, printer, errors.New("cli: --policy-store-id is required for the authz replay"))
+	}
+	replayReq, skipped := buildReplayRequest(entries, zoneID, policyStoreID, commitID)
+
+	pdpEndpoint, err := ctx.PDPEndpoint()
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: storage: failed to replay the decision logs"), err))
+	}
+	tlsCfg := ctx.TLSClientConfig()
+	client, err := deps.CreateGrpcPDPClient(pdpEndpoint, tlsCfg, ctx.VerboseCollector())
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: storage: failed to replay the decision logs"), err))
+	}
+	defer func() { _ = client.Close() }()
+	replayResp, err := client.AuthorizationReplay(replayReq)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: storage: failed to replay the decision logs"), err))
+	}
+	if ctx.IsTerminalOutput() {
+		printer.Println(fmt.Sprintf("Replaying %s decisions against commit %s.", common.NumberText(len(replayReq.Entries)), common.IDText(replayResp.CommitID)))
+		for _, result := range replayResp.Results {
+			if !result.Diverged && !ctx.IsVerboseTerminalOutput() {
+				continue
+			}
+			requestID := result.RequestID
+			if len(requestID) == 0 {
+				requestID = "none"
+			}
+			marker := " "
+			if result.Diverged {
+				marker = "~"
+			}
+			printer.Println(fmt.Sprintf("  %s %s: %s: logged %v, replayed %v", marker, common.KeywordText("Request ID"), common.CreateText(requestID), common.BoolText(result.LoggedDecision), common.BoolText(result.Decision)))
+			if result.Diverged && result.Context != nil && result.Context.ReasonAdmin != nil {
+				printer.Println(fmt.Sprintf("    - %s: Error: %s - %s ", common.KeywordText("Reason Admin"), common.IDText(result.Context.ReasonAdmin.Code), result.Context.ReasonAdmin.Message))
+			}
+		}
+		divergences := int(replayResp.Divergences)
+		unchanged := len(replayResp.Results) - divergences
+		printer.Println(fmt.Sprintf("Divergences %s, unchanged %s, skipped %s.", common.NumberText(divergences), common.NumberText(unchanged), common.NumberText(skipped)))
+	} else if ctx.IsJSONOutput() {
+		output := map[string]any{}
+		output["authorization_replay"] = replayResp
+		output["skipped"] = skipped
+		if ctx.IsVerboseJSONOutput() {
+			details := ctx.DrainVerboseDetails()
+			if details == nil {
+				details = []map[string]any{}
+			}
+			output["details"] = details
+		}
+		printer.PrintlnMap(output)
+	}
+	return nil
+}
+
+// createCommandForReplay creates a command for executing replay.
+func createCommandForReplay(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay logged decisions against a historical commit",
+		Long: common.BuildCliLongTemplate(`This command re-evaluates the decisions of a decision log against the policies of a historical commit and reports any divergence.
+
+Examples:
+  # replay the decision log against a commit
+  permguard authz replay --zone-id 273165098782 --policy-store-id fd1ac44e4afa4fc4beec622494d3175a --commit 48cf0ba0ba99e1dc2cbf28f1f1e4b0a3b6abdf8d6a71d1e5e1d6c4d8a3ec1a3b /path/to/decisions.log
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForReplay(deps, cmd, v, args)
+		},
+	}
+
+	command.Flags().String(flagCommit, "", "commit to replay the decisions against")
+	_ = v.BindPFlag(options.FlagName(commandNameForReplay, flagCommit), command.Flags().Lookup(flagCommit))
+
+	command.PersistentFlags().Int64(common.FlagCommonZoneID, 0, "override the zone id of the logged decisions")
+	_ = v.BindPFlag(options.FlagName(commandNameForReplay, common.FlagCommonZoneID), command.PersistentFlags().Lookup(common.FlagCommonZoneID))
+
+	command.PersistentFlags().String(common.FlagCommonPolicyStoreID, "", "override the policy store id of the logged decisions")
+	_ = v.BindPFlag(options.FlagName(commandNameForReplay, common.FlagCommonPolicyStoreID), command.PersistentFlags().Lookup(common.FlagCommonPolicyStoreID))
+
+	command.PersistentFlags().BoolP(common.FlagCommonCurrentWorkspace, common.FlagCommonCurrentWorkspaceShort, false, "resolve zone-id and policy-store-id from the current workspace")
+	_ = v.BindPFlag(options.FlagName(commandNameForReplay, common.FlagCommonCurrentWorkspace), command.PersistentFlags().Lookup(common.FlagCommonCurrentWorkspace))
+
+	return command
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/permguard/permguard/internal/agents/decisions"
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// TestCreateCommandForReplay tests the createCommandForReplay function.
+func TestCreateCommandForReplay(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command re-evaluates the decisions of a decision log against the policies of a historical commit"}
+	testutils.BaseCommandTest(t, createCommandForReplay, args, false, outputs)
+}
+
+// TestBuildReplayRequest tests the filtering of the logged entries for the target policy store.
+func TestBuildReplayRequest(t *testing.T) {
+	assert := assert.New(t)
+	evaluation := &pdp.EvaluationRequest{RequestID: "1234"}
+	entries := []decisions.DecisionLogEntry{
+		*decisions.NewDecisionLogEntry(&pdp.AuthorizationModelRequest{ZoneID: 1, PolicyStore: &pdp.PolicyStore{ID: "a"}}, evaluation, &pdp.EvaluationResponse{Decision: true}),
+		*decisions.NewDecisionLogEntry(&pdp.AuthorizationModelRequest{ZoneID: 2, PolicyStore: &pdp.PolicyStore{ID: "a"}}, evaluation, nil),
+		*decisions.NewDecisionLogEntry(&pdp.AuthorizationModelRequest{ZoneID: 1, PolicyStore: &pdp.PolicyStore{ID: "b"}}, evaluation, nil),
+		*decisions.NewDecisionLogEntry(nil, evaluation, nil),
+	}
+	request, skipped := buildReplayRequest(entries, 1, "a", "cid")
+	assert.Equal(2, skipped)
+	assert.Len(request.Entries, 2)
+	assert.True(request.Entries[0].Decision)
+	assert.Equal("cid", request.CommitID)
+	assert.Equal("a", request.PolicyStore.ID)
+}
@@ -42,5 +42,9 @@ func CreateCommandForConfig(deps cli.DependenciesProvider, v *viper.Viper) *cobr
 	command.AddCommand(createCommandForConfigGet(deps, v))
 	command.AddCommand(createCommandForConfigSet(deps, v))
 	command.AddCommand(createCommandForConfigShow(deps, v))
+	command.AddCommand(createCommandForConfigContextSet(deps, v))
+	command.AddCommand(createCommandForConfigContextUse(deps, v))
+	command.AddCommand(createCommandForConfigContextDelete(deps, v))
+	command.AddCommand(createCommandForConfigContextsGet(deps, v))
 	return command
 }
@@ -0,0 +1,371 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/common/pkg/extensions/validators"
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+)
+
+// contextFileSettingKeys are the context settings holding a file path, which are stored as absolute paths.
+var contextFileSettingKeys = map[string]bool{
+	options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSCAFile):   true,
+	options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSCertFile): true,
+	options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSKeyFile):  true,
+}
+
+// contextEndpointSettingKeys are the context settings holding an endpoint.
+var contextEndpointSettingKeys = map[string]bool{
+	options.FlagName(common.FlagPrefixZAP, common.FlagSuffixZAPEndpoint): true,
+	options.FlagName(common.FlagPrefixPAP, common.FlagSuffixPAPEndpoint): true,
+	options.FlagName(common.FlagPrefixPDP, common.FlagSuffixPDPEndpoint): true,
+}
+
+// readContextSettings reads the context settings passed as flags, an empty value removes the setting from the context.
+func readContextSettings(cmd *cobra.Command) (map[string]any, error) {
+	settings := map[string]any{}
+	for _, key := range common.ContextSettingKeys() {
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		switch flag.Value.Type() {
+		case "bool":
+			value, err := cmd.Flags().GetBool(key)
+			if err != nil {
+				return nil, err
+			}
+			settings[key] = value
+		case "int64":
+			value, err := cmd.Flags().GetInt64(key)
+			if err != nil {
+				return nil, err
+			}
+			if value < 0 {
+				return nil, fmt.Errorf("cli: %s must be a positive integer", key)
+			}
+			if value == 0 {
+				settings[key] = nil
+			} else {
+				settings[key] = value
+			}
+		default:
+			value := strings.TrimSpace(flag.Value.String())
+			switch {
+			case value == "":
+				settings[key] = nil
+				continue
+			case contextEndpointSettingKeys[key] && !validators.IsValidEndpoint(value):
+				return nil, fmt.Errorf("cli: %s must be in the format scheme://hostname:port where scheme is grpc or grpcs", key)
+			case contextFileSettingKeys[key]:
+				absValue, err := filepath.Abs(value)
+				if err != nil {
+					return nil, err
+				}
+				value = absValue
+			}
+			settings[key] = value
+		}
+	}
+	return settings, nil
+}
+
+// printContextsOutput prints the output of the context commands.
+func printContextsOutput(ctx *common.CliCommandContext, printer cli.Printer, message string, output map[string]any) {
+	if ctx.IsTerminalOutput() {
+		printer.Println(message)
+	} else if ctx.IsJSONOutput() {
+		if ctx.IsVerboseJSONOutput() {
+			details := ctx.DrainVerboseDetails()
+			if details == nil {
+				details = []map[string]any{}
+			}
+			output["details"] = details
+		}
+		printer.PrintlnMap(output)
+	}
+}
+
+// runECommandForContextSet runs the command for creating or updating a named context.
+func runECommandForContextSet(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	name := args[0]
+	if err := common.ValidateContextName(name); err != nil {
+		printer.Error(errors.Join(errors.New("cli: invalid context name"), err))
+		return common.ErrCommandSilent
+	}
+	settings, err := readContextSettings(cmd)
+	if err != nil {
+		printer.Error(errors.Join(fmt.Errorf("cli: failed to set the context %s", name), err))
+		return common.ErrCommandSilent
+	}
+	_, exists := common.NamedContexts(v)[name]
+	ctx.AppendVerboseAction(fmt.Sprintf("updating context %s in cli configuration", name))
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	err = options.UpdateViperConfig(v, func(config map[string]any) {
+		contexts, _ := config[common.FlagContexts].(map[string]any)
+		if contexts == nil {
+			contexts = map[string]any{}
+		}
+		context, _ := contexts[name].(map[string]any)
+		if context == nil {
+			context = map[string]any{}
+		}
+		for key, value := range settings {
+			if value == nil {
+				delete(context, key)
+			} else {
+				context[key] = value
+			}
+		}
+		contexts[name] = context
+		config[common.FlagContexts] = contexts
+	})
+	if err != nil {
+		ctx.FlushVerboseDetails()
+		printer.Error(errors.Join(fmt.Errorf("cli: failed to set the context %s", name), err))
+		return common.ErrCommandSilent
+	}
+	ctx.FlushVerboseDetails()
+	message := fmt.Sprintf("Context %s has been updated.", name)
+	if !exists {
+		message = fmt.Sprintf("Context %s has been created, use 'permguard config use-context %s' to switch to it.", name, name)
+	}
+	printContextsOutput(ctx, printer, message, map[string]any{"context": map[string]any{"name": name, "created": !exists}})
+	return nil
+}
+
+// runECommandForContextUse runs the command for switching the current context.
+func runECommandForContextUse(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	name := strings.TrimSpace(args[0])
+	if _, ok := common.NamedContexts(v)[name]; name != "" && !ok {
+		printer.Error(fmt.Errorf("cli: context %s does not exist — use 'permguard config set-context %s' to create it", name, name))
+		return common.ErrCommandSilent
+	}
+	ctx.AppendVerboseAction("updating current context in cli configuration")
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	err = options.UpdateViperConfig(v, func(config map[string]any) {
+		if name == "" {
+			delete(config, common.FlagCurrentContext)
+		} else {
+			config[common.FlagCurrentContext] = name
+		}
+	})
+	if err != nil {
+		ctx.FlushVerboseDetails()
+		printer.Error(errors.Join(errors.New("cli: failed to switch the current context"), err))
+		return common.ErrCommandSilent
+	}
+	ctx.FlushVerboseDetails()
+	message := fmt.Sprintf("Switched to context %s.", name)
+	if name == "" {
+		message = "The current context has been unset, the global settings are in use."
+	}
+	printContextsOutput(ctx, printer, message, map[string]any{"current_context": name})
+	return nil
+}
+
+// runECommandForContextDelete runs the command for deleting a named context.
+func runECommandForContextDelete(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	name := args[0]
+	if _, ok := common.NamedContexts(v)[name]; !ok {
+		printer.Error(fmt.Errorf("cli: context %s does not exist", name))
+		return common.ErrCommandSilent
+	}
+	ctx.AppendVerboseAction(fmt.Sprintf("deleting context %s from cli configuration", name))
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	err = options.UpdateViperConfig(v, func(config map[string]any) {
+		if contexts, ok := config[common.FlagContexts].(map[string]any); ok {
+			delete(contexts, name)
+		}
+		if config[common.FlagCurrentContext] == name {
+			delete(config, common.FlagCurrentContext)
+		}
+	})
+	if err != nil {
+		ctx.FlushVerboseDetails()
+		printer.Error(errors.Join(fmt.Errorf("cli: failed to delete the context %s", name), err))
+		return common.ErrCommandSilent
+	}
+	ctx.FlushVerboseDetails()
+	printContextsOutput(ctx, printer, fmt.Sprintf("Context %s has been deleted.", name), map[string]any{"context": map[string]any{"name": name, "deleted": true}})
+	return nil
+}
+
+// runECommandForContextsGet runs the command for listing the named contexts.
+func runECommandForContextsGet(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	ctx.AppendVerboseAction("reading contexts from cli configuration")
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	ctx.FlushVerboseDetails()
+	current := strings.TrimSpace(v.GetString(common.FlagCurrentContext))
+	contexts := common.NamedContexts(v)
+	names := common.NamedContextNames(v)
+	if ctx.IsTerminalOutput() {
+		if len(names) == 0 {
+			printer.Println("No contexts are configured, use 'permguard config set-context' to create one.")
+			return nil
+		}
+		for _, name := range names {
+			marker := " "
+			if name == current {
+				marker = "*"
+			}
+			printer.Println(fmt.Sprintf("%s %s", marker, name))
+			for _, key := range common.ContextSettingKeys() {
+				if value, ok := contexts[name][key]; ok {
+					printer.Println(fmt.Sprintf("    %s: %v", key, value))
+				}
+			}
+		}
+		return nil
+	}
+	items := []any{}
+	for _, name := range names {
+		item := map[string]any{
+			"name":    name,
+			"current": name == current,
+		}
+		for _, key := range common.ContextSettingKeys() {
+			if value, ok := contexts[name][key]; ok {
+				item[strings.ReplaceAll(key, "-", "_")] = value
+			}
+		}
+		items = append(items, item)
+	}
+	printContextsOutput(ctx, printer, "", map[string]any{"current_context": current, "contexts": items})
+	return nil
+}
+
+// createCommandForConfigContextSet creates the command for creating or updating a named context.
+func createCommandForConfigContextSet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "set-context <name>",
+		Short: "Create or update a named context",
+		Long: common.BuildCliLongTemplate(`This command creates or updates a named context bundling the endpoints, the tls settings, the credentials and the default zone of an environment.
+Only the settings passed as flags are changed, passing an empty value removes the setting from the context.
+
+Examples:
+# create a context for the production environment
+permguard config set-context prod --zap-endpoint grpcs://zap.example.com:9091 --pap-endpoint grpcs://pap.example.com:9092 --pdp-endpoint grpcs://pdp.example.com:9094 --tls-ca-file ./ca.pem --default-zone-id 273165098782
+# authenticate with a client certificate in the staging context
+permguard config set-context staging --tls-cert-file ./client.pem --tls-key-file ./client-key.pem
+		`),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{common.AnnotationSkipNamedContext: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForContextSet(deps, cmd, v, args)
+		},
+	}
+	command.Flags().String(options.FlagName(common.FlagPrefixZAP, common.FlagSuffixZAPEndpoint), "", "zap endpoint of the context")
+	command.Flags().String(options.FlagName(common.FlagPrefixPAP, common.FlagSuffixPAPEndpoint), "", "pap endpoint of the context")
+	command.Flags().String(options.FlagName(common.FlagPrefixPDP, common.FlagSuffixPDPEndpoint), "", "pdp endpoint of the context")
+	command.Flags().Int64(common.FlagDefaultZoneID, 0, "zone id used by the commands requiring one when none is passed")
+	return command
+}
+
+// createCommandForConfigContextUse creates the command for switching the current context.
+func createCommandForConfigContextUse(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "use-context <name>",
+		Short: "Switch the current context",
+		Long: common.BuildCliLongTemplate(`This command switches the current context, whose settings take precedence over the global settings.
+A single command can run with another context using the --context flag.
+
+Examples:
+# switch to the development context
+permguard config use-context dev
+# go back to the global settings
+permguard config use-context ""
+		`),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{common.AnnotationSkipNamedContext: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForContextUse(deps, cmd, v, args)
+		},
+	}
+	return command
+}
+
+// createCommandForConfigContextDelete creates the command for deleting a named context.
+func createCommandForConfigContextDelete(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "delete-context <name>",
+		Short: "Delete a named context",
+		Long: common.BuildCliLongTemplate(`This command deletes a named context, the current context is unset when it is the deleted one.
+
+Examples:
+# delete the staging context
+permguard config delete-context staging
+		`),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{common.AnnotationSkipNamedContext: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForContextDelete(deps, cmd, v, args)
+		},
+	}
+	return command
+}
+
+// createCommandForConfigContextsGet creates the command for listing the named contexts.
+func createCommandForConfigContextsGet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the named contexts",
+		Long: common.BuildCliLongTemplate(`This command lists the named contexts, the current context is marked with an asterisk.
+
+Examples:
+# list the named contexts
+permguard config get-contexts
+		`),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{common.AnnotationSkipNamedContext: "true"},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForContextsGet(deps, cmd, v)
+		},
+	}
+	return command
+}
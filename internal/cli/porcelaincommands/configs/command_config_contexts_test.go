@@ -0,0 +1,51 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
+)
+
+// TestCreateCommandForConfigContextSet tests the createCommandForConfigContextSet function.
+func TestCreateCommandForConfigContextSet(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command creates or updates a named context"}
+	testutils.BaseCommandTest(t, createCommandForConfigContextSet, args, false, outputs)
+}
+
+// TestCreateCommandForConfigContextUse tests the createCommandForConfigContextUse function.
+func TestCreateCommandForConfigContextUse(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command switches the current context"}
+	testutils.BaseCommandTest(t, createCommandForConfigContextUse, args, false, outputs)
+}
+
+// TestCreateCommandForConfigContextDelete tests the createCommandForConfigContextDelete function.
+func TestCreateCommandForConfigContextDelete(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command deletes a named context"}
+	testutils.BaseCommandTest(t, createCommandForConfigContextDelete, args, false, outputs)
+}
+
+// TestCreateCommandForConfigContextsGet tests the createCommandForConfigContextsGet function.
+func TestCreateCommandForConfigContextsGet(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command lists the named contexts"}
+	testutils.BaseCommandTest(t, createCommandForConfigContextsGet, args, false, outputs)
+}
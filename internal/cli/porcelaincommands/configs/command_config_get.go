@@ -19,6 +19,7 @@ package configs
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
 )
 
 // runECommandForZAPGet runs the command for getting the zap endpoint.
@@ -193,6 +195,81 @@ func createCommandForConfigNOTPMaxPacketSizeGet(deps cli.DependenciesProvider, v
 	return command
 }
 
+// runECommandForCommitSigningKeyGet runs the command for getting the commit signing key.
+func runECommandForCommitSigningKeyGet(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	ctx.AppendVerboseAction("reading commit signing key from cli configuration")
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	ctx.FlushVerboseDetails()
+	printer.PrintlnMap(map[string]any{"commit_signing_key": ctx.CommitSigningKey()})
+	return nil
+}
+
+// createCommandForConfigCommitSigningKeyGet creates the command for getting the commit signing key.
+func createCommandForConfigCommitSigningKeyGet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "commit-signing-key",
+		Short: "Get the commit signing key",
+		Long:  common.BuildCliLongTemplate(`This command gets the key file used to sign the commits.`),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForCommitSigningKeyGet(deps, cmd, v)
+		},
+	}
+	return command
+}
+
+// runECommandForCommitAuthorGet runs the command for getting the commit author name or email.
+func runECommandForCommitAuthorGet(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, suffix string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	ctx.AppendVerboseAction(fmt.Sprintf("reading commit %s from cli configuration", strings.ReplaceAll(suffix, "-", " ")))
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	ctx.FlushVerboseDetails()
+	value := ctx.CommitAuthorName()
+	if suffix == common.FlagSuffixCommitAuthorEmail {
+		value = ctx.CommitAuthorEmail()
+	}
+	key := strings.ReplaceAll(options.FlagName(common.FlagPrefixCommit, suffix), "-", "_")
+	printer.PrintlnMap(map[string]any{key: value})
+	return nil
+}
+
+// createCommandForConfigCommitAuthorNameGet creates the command for getting the commit author name.
+func createCommandForConfigCommitAuthorNameGet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "commit-author-name",
+		Short: "Get the commit author name",
+		Long:  common.BuildCliLongTemplate(`This command gets the name recorded as author and committer of the commits.`),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForCommitAuthorGet(deps, cmd, v, common.FlagSuffixCommitAuthorName)
+		},
+	}
+	return command
+}
+
+// createCommandForConfigCommitAuthorEmailGet creates the command for getting the commit author email.
+func createCommandForConfigCommitAuthorEmailGet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "commit-author-email",
+		Short: "Get the commit author email",
+		Long:  common.BuildCliLongTemplate(`This command gets the email recorded as author and committer of the commits.`),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForCommitAuthorGet(deps, cmd, v, common.FlagSuffixCommitAuthorEmail)
+		},
+	}
+	return command
+}
+
 func createCommandForConfigGet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
 	command := &cobra.Command{
 		Use:   "get",
@@ -200,7 +277,7 @@ func createCommandForConfigGet(deps cli.DependenciesProvider, v *viper.Viper) *c
 		Long:  common.BuildCliLongTemplate(`This command gets configuration items.`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				color.Red(fmt.Sprintf("unknown config key %q; available keys: zap-endpoint, pap-endpoint, pdp-endpoint, authstar-max-object-size, notp-max-packet-size", args[0]))
+				color.Red(fmt.Sprintf("unknown config key %q; available keys: zap-endpoint, pap-endpoint, pdp-endpoint, authstar-max-object-size, notp-max-packet-size, commit-signing-key, commit-author-name, commit-author-email", args[0]))
 				return common.ErrCommandSilent
 			}
 			return cmd.Help()
@@ -211,5 +288,8 @@ func createCommandForConfigGet(deps cli.DependenciesProvider, v *viper.Viper) *c
 	command.AddCommand(createCommandForConfigPDPGet(deps, v))
 	command.AddCommand(createCommandForConfigAuthstarMaxObjectSizeGet(deps, v))
 	command.AddCommand(createCommandForConfigNOTPMaxPacketSizeGet(deps, v))
+	command.AddCommand(createCommandForConfigCommitSigningKeyGet(deps, v))
+	command.AddCommand(createCommandForConfigCommitAuthorNameGet(deps, v))
+	command.AddCommand(createCommandForConfigCommitAuthorEmailGet(deps, v))
 	return command
 }
@@ -19,7 +19,10 @@ package configs
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -286,6 +289,159 @@ permguard config set notp-max-packet-size 16777216
 	return command
 }
 
+// runECommandForCommitSigningKeySet runs the command for setting the commit signing key.
+func runECommandForCommitSigningKeySet(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	if len(args) == 0 {
+		printer.Error(errors.Join(errors.New("cli: failed to set the commit signing key"), err))
+		return common.ErrCommandSilent
+	}
+	keyFile := args[0]
+	if keyFile != "" {
+		keyFile, err = filepath.Abs(keyFile)
+		if err == nil {
+			_, err = os.Stat(keyFile)
+		}
+		if err != nil {
+			printer.Error(errors.Join(errors.New("cli: commit-signing-key must be an existing key file"), err))
+			return common.ErrCommandSilent
+		}
+	}
+	ctx.AppendVerboseAction("updating commit signing key in cli configuration")
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	key := options.FlagName(common.FlagPrefixCommit, common.FlagSuffixCommitSigningKey)
+	valueMap := map[string]interface{}{
+		key: keyFile,
+	}
+	err = options.OverrideViperFromConfig(v, valueMap)
+	if err != nil {
+		ctx.FlushVerboseDetails()
+		printer.Error(errors.Join(errors.New("cli: failed to set the commit signing key"), err))
+		return common.ErrCommandSilent
+	}
+	ctx.FlushVerboseDetails()
+	if ctx.IsTerminalOutput() {
+		if keyFile == "" {
+			printer.Println("commit_signing_key has been unset, commits will not be signed.")
+		} else {
+			printer.Println(fmt.Sprintf("commit_signing_key has been set to %s.", keyFile))
+		}
+	}
+	return nil
+}
+
+// createCommandForConfigCommitSigningKeySet creates the command for setting the commit signing key.
+func createCommandForConfigCommitSigningKeySet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "commit-signing-key",
+		Short: "Set the commit signing key",
+		Long: common.BuildCliLongTemplate(`This command sets the key file used to sign the commits.
+The key file is either a private key (ed25519 pem or ssh key) or a public ssh key whose private key is held by the ssh agent.
+
+Examples:
+# sign the commits with an ssh private key
+permguard config set commit-signing-key ~/.ssh/id_ed25519
+# sign the commits with the ssh agent key matching a public key
+permguard config set commit-signing-key ~/.ssh/id_ed25519.pub
+# stop signing the commits
+permguard config set commit-signing-key ""
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForCommitSigningKeySet(deps, cmd, v, args)
+		},
+	}
+	return command
+}
+
+// runECommandForCommitAuthorSet runs the command for setting the commit author name or email.
+func runECommandForCommitAuthorSet(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string, suffix string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	label := strings.ReplaceAll(suffix, "-", " ")
+	if len(args) == 0 {
+		printer.Error(errors.Join(fmt.Errorf("cli: failed to set the commit %s", label), err))
+		return common.ErrCommandSilent
+	}
+	value := strings.TrimSpace(args[0])
+	if strings.ContainsAny(value, "<>\n") {
+		printer.Error(fmt.Errorf("cli: commit-%s must not contain angle brackets or new lines", suffix))
+		return common.ErrCommandSilent
+	}
+	if suffix == common.FlagSuffixCommitAuthorEmail && value != "" && !strings.Contains(value, "@") {
+		printer.Error(errors.New("cli: commit-author-email must be a valid email address"))
+		return common.ErrCommandSilent
+	}
+	ctx.AppendVerboseAction(fmt.Sprintf("updating commit %s in cli configuration", label))
+	ctx.AppendVerboseFile(v.ConfigFileUsed())
+	key := options.FlagName(common.FlagPrefixCommit, suffix)
+	valueMap := map[string]interface{}{
+		key: value,
+	}
+	err = options.OverrideViperFromConfig(v, valueMap)
+	if err != nil {
+		ctx.FlushVerboseDetails()
+		printer.Error(errors.Join(fmt.Errorf("cli: failed to set the commit %s", label), err))
+		return common.ErrCommandSilent
+	}
+	ctx.FlushVerboseDetails()
+	if ctx.IsTerminalOutput() {
+		outKey := strings.ReplaceAll(key, "-", "_")
+		if value == "" {
+			printer.Println(fmt.Sprintf("%s has been unset.", outKey))
+		} else {
+			printer.Println(fmt.Sprintf("%s has been set to %s.", outKey, value))
+		}
+	}
+	return nil
+}
+
+// createCommandForConfigCommitAuthorNameSet creates the command for setting the commit author name.
+func createCommandForConfigCommitAuthorNameSet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "commit-author-name",
+		Short: "Set the commit author name",
+		Long: common.BuildCliLongTemplate(`This command sets the name recorded as author and committer of the commits.
+
+Examples:
+# set the commit author name
+permguard config set commit-author-name "Nicola Gallo"
+# unset the commit author name
+permguard config set commit-author-name ""
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForCommitAuthorSet(deps, cmd, v, args, common.FlagSuffixCommitAuthorName)
+		},
+	}
+	return command
+}
+
+// createCommandForConfigCommitAuthorEmailSet creates the command for setting the commit author email.
+func createCommandForConfigCommitAuthorEmailSet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "commit-author-email",
+		Short: "Set the commit author email",
+		Long: common.BuildCliLongTemplate(`This command sets the email recorded as author and committer of the commits.
+
+Examples:
+# set the commit author email
+permguard config set commit-author-email nicola.gallo@example.com
+# unset the commit author email
+permguard config set commit-author-email ""
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForCommitAuthorSet(deps, cmd, v, args, common.FlagSuffixCommitAuthorEmail)
+		},
+	}
+	return command
+}
+
 func createCommandForConfigSet(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
 	command := &cobra.Command{
 		Use:   "set",
@@ -293,7 +449,7 @@ func createCommandForConfigSet(deps cli.DependenciesProvider, v *viper.Viper) *c
 		Long:  common.BuildCliLongTemplate(`This command sets configuration items.`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				color.Red(fmt.Sprintf("unknown config key %q; available keys: zap-endpoint, pap-endpoint, pdp-endpoint, authstar-max-object-size, notp-max-packet-size", args[0]))
+				color.Red(fmt.Sprintf("unknown config key %q; available keys: zap-endpoint, pap-endpoint, pdp-endpoint, authstar-max-object-size, notp-max-packet-size, commit-signing-key, commit-author-name, commit-author-email", args[0]))
 			}
 			return cmd.Help()
 		},
@@ -303,5 +459,8 @@ func createCommandForConfigSet(deps cli.DependenciesProvider, v *viper.Viper) *c
 	command.AddCommand(createCommandForConfigPDPSet(deps, v))
 	command.AddCommand(createCommandForConfigAuthstarMaxObjectSizeSet(deps, v))
 	command.AddCommand(createCommandForConfigNOTPMaxPacketSizeSet(deps, v))
+	command.AddCommand(createCommandForConfigCommitSigningKeySet(deps, v))
+	command.AddCommand(createCommandForConfigCommitAuthorNameSet(deps, v))
+	command.AddCommand(createCommandForConfigCommitAuthorEmailSet(deps, v))
 	return command
 }
@@ -34,6 +34,20 @@ func TestCreateCommandForConfigZAPSet(t *testing.T) {
 	testutils.BaseCommandTest(t, createCommandForConfigZAPSet, args, false, outputs)
 }
 
+// TestCreateCommandForConfigCommitSigningKeySet tests the createCommandForConfigCommitSigningKeySet function.
+func TestCreateCommandForConfigCommitSigningKeySet(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command sets the key file used to sign the commits."}
+	testutils.BaseCommandTest(t, createCommandForConfigCommitSigningKeySet, args, false, outputs)
+}
+
+// TestCreateCommandForConfigCommitAuthorNameSet tests the createCommandForConfigCommitAuthorNameSet function.
+func TestCreateCommandForConfigCommitAuthorNameSet(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command sets the name recorded as author and committer of the commits."}
+	testutils.BaseCommandTest(t, createCommandForConfigCommitAuthorNameSet, args, false, outputs)
+}
+
 // TestCliConfigSetZAPEndpoint tests the command for setting the zap endpoint.
 func TestCliConfigSetZAPEndpointWithError(t *testing.T) {
 	tests := []string{
@@ -60,19 +60,47 @@ func runECommandForConfigShow(deps cli.DependenciesProvider, cmd *cobra.Command,
 	if err == nil {
 		notpMaxPacketSizeStr = strconv.Itoa(notpMaxPacketSize)
 	}
+	commitSigningKey := ctx.CommitSigningKey()
+	commitSigningKeyStr := commitSigningKey
+	if commitSigningKeyStr == "" {
+		commitSigningKeyStr = "not set"
+	}
+	commitAuthorName := ctx.CommitAuthorName()
+	commitAuthorNameStr := commitAuthorName
+	if commitAuthorNameStr == "" {
+		commitAuthorNameStr = "not set"
+	}
+	commitAuthorEmail := ctx.CommitAuthorEmail()
+	commitAuthorEmailStr := commitAuthorEmail
+	if commitAuthorEmailStr == "" {
+		commitAuthorEmailStr = "not set"
+	}
+	contextName := ctx.ContextName()
+	contextNameStr := contextName
+	if contextNameStr == "" {
+		contextNameStr = "not set"
+	}
 	if ctx.IsTerminalOutput() {
+		printer.Println(fmt.Sprintf("context: %s", contextNameStr))
 		printer.Println(fmt.Sprintf("zap-endpoint: %s", zapEndpoint))
 		printer.Println(fmt.Sprintf("pap-endpoint: %s", papEndpoint))
 		printer.Println(fmt.Sprintf("pdp-endpoint: %s", pdpEndpoint))
 		printer.Println(fmt.Sprintf("authstar-max-object-size: %s", authstarMaxObjectSizeStr))
 		printer.Println(fmt.Sprintf("notp-max-packet-size: %s", notpMaxPacketSizeStr))
+		printer.Println(fmt.Sprintf("commit-signing-key: %s", commitSigningKeyStr))
+		printer.Println(fmt.Sprintf("commit-author-name: %s", commitAuthorNameStr))
+		printer.Println(fmt.Sprintf("commit-author-email: %s", commitAuthorEmailStr))
 	} else if ctx.IsJSONOutput() {
 		output := map[string]any{
+			"context":                  contextName,
 			"zap_endpoint":             zapEndpoint,
 			"pap_endpoint":             papEndpoint,
 			"pdp_endpoint":             pdpEndpoint,
 			"authstar_max_object_size": authstarMaxObjectSize,
 			"notp_max_packet_size":     notpMaxPacketSize,
+			"commit_signing_key":       commitSigningKey,
+			"commit_author_name":       commitAuthorName,
+			"commit_author_email":      commitAuthorEmail,
 		}
 		if ctx.IsVerboseJSONOutput() {
 			details := ctx.DrainVerboseDetails()
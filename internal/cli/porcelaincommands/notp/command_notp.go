@@ -0,0 +1,58 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notp
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/pkg/cli"
+)
+
+// failWithDetails prints the error with the verbose details and returns a silent error.
+func failWithDetails(ctx *common.CliCommandContext, printer cli.Printer, err error) error {
+	output := map[string]any{}
+	ctx.FlushVerboseDetails()
+	if ctx.IsVerboseJSONOutput() {
+		details := ctx.DrainVerboseDetails()
+		if details == nil {
+			details = []map[string]any{}
+		}
+		output["details"] = details
+	}
+	printer.ErrorWithOutput(output, err)
+	return common.ErrCommandSilent
+}
+
+// runECommandForNOTP runs the command for inspecting the notp protocol.
+func runECommandForNOTP(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
+
+// CreateCommandForNOTP for inspecting the notp protocol.
+func CreateCommandForNOTP(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "notp",
+		Short: "Inspect the NOTP protocol",
+		Long:  common.BuildCliLongTemplate(`This command inspects the NOTP protocol used to push and pull the ledgers.`),
+		Args:  cobra.NoArgs,
+		RunE:  runECommandForNOTP,
+	}
+	command.AddCommand(createCommandForNOTPDump(deps, v))
+	return command
+}
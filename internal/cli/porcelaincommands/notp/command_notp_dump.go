@@ -0,0 +1,158 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	aznotpcapture "github.com/permguard/permguard/notp-protocol/pkg/notp/capture"
+	"github.com/permguard/permguard/pkg/cli"
+)
+
+const (
+	// maxPayloadLength is the maximum length of a payload printed without verbosity.
+	maxPayloadLength = 120
+)
+
+// dumpRecord converts a record into its printable form.
+func dumpRecord(record *aznotpcapture.Record) map[string]any {
+	dump := map[string]any{
+		"seq":       record.Seq,
+		"time":      record.Time,
+		"session":   record.Session,
+		"role":      record.Role,
+		"direction": record.Direction,
+		"flow":      aznotpcapture.FlowName(record.FlowType),
+		"state":     aznotpcapture.StateName(record.StateID),
+		"size":      len(record.Data),
+		"packets":   record.Packets,
+	}
+	if statePacket, ok := record.StatePacket(); ok {
+		dump["message"] = aznotpcapture.MessageName(statePacket.MessageCode)
+		dump["message_value"] = statePacket.MessageValue
+		if statePacket.ErrorCode != 0 {
+			dump["error_code"] = statePacket.ErrorCode
+		}
+	}
+	return dump
+}
+
+// formatRecord formats a record as the lines printed on the terminal.
+func formatRecord(record *aznotpcapture.Record, verbose bool) []string {
+	message := "none"
+	if statePacket, ok := record.StatePacket(); ok {
+		message = aznotpcapture.MessageName(statePacket.MessageCode)
+	}
+	lines := []string{
+		fmt.Sprintf("#%s %s %s %s %s %s: %s, %s: %s, %s: %s, %s bytes",
+			common.NumberText(int(record.Seq)), common.TimeStampText(record.Time.Format(time.RFC3339Nano)), common.IDText(record.Session), record.Role, record.Direction,
+			common.KeywordText("flow"), aznotpcapture.FlowName(record.FlowType),
+			common.KeywordText("state"), aznotpcapture.StateName(record.StateID),
+			common.KeywordText("message"), message, common.NumberText(len(record.Data))),
+	}
+	for _, packet := range record.Packets {
+		payload, err := json.Marshal(packet.Payload)
+		if err != nil {
+			payload = []byte("invalid payload")
+		}
+		text := string(payload)
+		if !verbose && len(text) > maxPayloadLength {
+			text = text[:maxPayloadLength] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("    - %s %#x: %s", common.KeywordText("type"), packet.Type, text))
+	}
+	return lines
+}
+
+// runECommandForNOTPDump runs the command for dumping a notp capture.
+func runECommandForNOTPDump(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	capturePath := args[0]
+	if !filepath.IsAbs(capturePath) {
+		capturePath = filepath.Join(ctx.WorkDir(), capturePath)
+	}
+	ctx.AppendVerboseAction("reading notp capture file")
+	ctx.AppendVerboseFile(capturePath)
+	file, err := os.Open(capturePath)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to dump the notp capture"), fmt.Errorf("failed to read file %s", capturePath)))
+	}
+	defer func() { _ = file.Close() }()
+	records, err := aznotpcapture.ReadRecords(file)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to dump the notp capture"), err))
+	}
+	ctx.FlushVerboseDetails()
+	if ctx.IsTerminalOutput() {
+		for i := range records {
+			for _, line := range formatRecord(&records[i], ctx.IsVerbose()) {
+				printer.Println(line)
+			}
+		}
+		printer.Println(fmt.Sprintf("Dumped %s packets.", common.NumberText(len(records))))
+	} else if ctx.IsJSONOutput() {
+		dumps := make([]map[string]any, 0, len(records))
+		for i := range records {
+			dumps = append(dumps, dumpRecord(&records[i]))
+		}
+		output := map[string]any{
+			"records": dumps,
+		}
+		if ctx.IsVerboseJSONOutput() {
+			details := ctx.DrainVerboseDetails()
+			if details == nil {
+				details = []map[string]any{}
+			}
+			output["details"] = details
+		}
+		printer.PrintlnMap(output)
+	}
+	return nil
+}
+
+// createCommandForNOTPDump creates the command for dumping a notp capture.
+func createCommandForNOTPDump(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the packets of a NOTP capture",
+		Long: common.BuildCliLongTemplate(`This command prints the packets recorded in a NOTP capture, with their direction, state, flow type and decoded payload.
+
+Examples:
+  # print the packets recorded by a pull
+  permguard pull --notp-trace ./notp.trace
+  permguard notp dump ./notp.trace`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForNOTPDump(deps, cmd, v, args)
+		},
+	}
+	return command
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
+	aznotpcapture "github.com/permguard/permguard/notp-protocol/pkg/notp/capture"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
+)
+
+// TestCreateCommandForNOTPDump tests the createCommandForNOTPDump function.
+func TestCreateCommandForNOTPDump(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command prints the packets recorded in a NOTP capture"}
+	testutils.BaseCommandTest(t, createCommandForNOTPDump, args, false, outputs)
+}
+
+// TestDumpRecord tests the printable form of a recorded packet.
+func TestDumpRecord(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := aznotpcapture.NewWriter(buffer)
+	require.NoError(t, err)
+	recorder := writer.NewRecorder(aznotpcapture.RoleFollower, "session-1")
+	inspector, err := recorder.Inspector()
+	require.NoError(t, err)
+
+	statePacket := &aznotpsmpackets.StatePacket{MessageCode: aznotpsmpackets.CommitMessage, MessageValue: 7}
+	packet := &aznotppackets.Packet{}
+	packetWriter, err := aznotppackets.NewPacketWriter(packet)
+	require.NoError(t, err)
+	require.NoError(t, packetWriter.WriteProtocol(&aznotppackets.ProtocolPacket{Version: 1}))
+	require.NoError(t, packetWriter.AppendDataPacket(statePacket))
+	inspector.InspectSent(packet)
+
+	records, err := aznotpcapture.ReadRecords(buffer)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	dump := dumpRecord(&records[0])
+	assert.Equal(t, "commit", dump["message"])
+	assert.Equal(t, uint64(7), dump["message_value"])
+	assert.Equal(t, aznotpcapture.DirectionSent, dump["direction"])
+	assert.Equal(t, aznotpcapture.FlowName(uint64(aznotpstatemachines.UnknownFlowType)), dump["flow"])
+
+	lines := formatRecord(&records[0], false)
+	require.Len(t, lines, 2)
+	assert.True(t, strings.Contains(lines[0], "commit"))
+}
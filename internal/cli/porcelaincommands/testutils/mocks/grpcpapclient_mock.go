@@ -19,6 +19,7 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
@@ -28,8 +29,8 @@ type GrpcPAPClientMock struct {
 }
 
 // CreateLedger creates a ledger.
-func (m *GrpcPAPClientMock) CreateLedger(zoneID int64, kind string, name string) (*pap.Ledger, error) {
-	args := m.Called(zoneID, kind, name)
+func (m *GrpcPAPClientMock) CreateLedger(zoneID int64, kind string, name string, trustedKeys []string, description string, labels map[string]string) (*pap.Ledger, error) {
+	args := m.Called(zoneID, kind, name, trustedKeys, description, labels)
 	var r0 *pap.Ledger
 	if val, ok := args.Get(0).(*pap.Ledger); ok {
 		r0 = val
@@ -48,7 +49,17 @@ func (m *GrpcPAPClientMock) UpdateLedger(ledger *pap.Ledger) (*pap.Ledger, error
 }
 
 // DeleteLedger deletes a ledger.
-func (m *GrpcPAPClientMock) DeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger, error) {
+func (m *GrpcPAPClientMock) DeleteLedger(zoneID int64, ledgerID string, options *models.DeleteOptions) (*pap.Ledger, error) {
+	args := m.Called(zoneID, ledgerID, options)
+	var r0 *pap.Ledger
+	if val, ok := args.Get(0).(*pap.Ledger); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// UndeleteLedger restores a ledger.
+func (m *GrpcPAPClientMock) UndeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger, error) {
 	args := m.Called(zoneID, ledgerID)
 	var r0 *pap.Ledger
 	if val, ok := args.Get(0).(*pap.Ledger); ok {
@@ -88,8 +99,8 @@ func (m *GrpcPAPClientMock) FetchLedgersByName(page int32, pageSize int32, zoneI
 }
 
 // FetchLedgersBy returns all ledgers filtering by ledger id and name.
-func (m *GrpcPAPClientMock) FetchLedgersBy(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string) ([]pap.Ledger, error) {
-	args := m.Called(page, pageSize, zoneID, ledgerID, kind, name)
+func (m *GrpcPAPClientMock) FetchLedgersBy(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string, labels map[string]string) ([]pap.Ledger, error) {
+	args := m.Called(page, pageSize, zoneID, ledgerID, kind, name, labels)
 	var r0 []pap.Ledger
 	if val, ok := args.Get(0).([]pap.Ledger); ok {
 		r0 = val
@@ -97,6 +108,20 @@ func (m *GrpcPAPClientMock) FetchLedgersBy(page int32, pageSize int32, zoneID in
 	return r0, args.Error(1)
 }
 
+// FetchLedgersPage returns a page of ledgers.
+func (m *GrpcPAPClientMock) FetchLedgersPage(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string, namePrefix string, labels map[string]string, options *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error) {
+	args := m.Called(page, pageSize, zoneID, ledgerID, kind, name, namePrefix, labels, options)
+	var r0 []pap.Ledger
+	if val, ok := args.Get(0).([]pap.Ledger); ok {
+		r0 = val
+	}
+	var r1 *models.FetchPageInfo
+	if val, ok := args.Get(1).(*models.FetchPageInfo); ok {
+		r1 = val
+	}
+	return r0, r1, args.Error(2)
+}
+
 // Close closes the client connection.
 func (m *GrpcPAPClientMock) Close() error {
 	return nil
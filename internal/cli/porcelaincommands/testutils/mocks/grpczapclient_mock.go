@@ -19,6 +19,7 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -28,8 +29,8 @@ type GrpcZAPClientMock struct {
 }
 
 // CreateZone creates a new zone.
-func (m *GrpcZAPClientMock) CreateZone(name string) (*zap.Zone, error) {
-	args := m.Called(name)
+func (m *GrpcZAPClientMock) CreateZone(name string, description string, labels map[string]string) (*zap.Zone, error) {
+	args := m.Called(name, description, labels)
 	var r0 *zap.Zone
 	if val, ok := args.Get(0).(*zap.Zone); ok {
 		r0 = val
@@ -48,7 +49,17 @@ func (m *GrpcZAPClientMock) UpdateZone(zone *zap.Zone) (*zap.Zone, error) {
 }
 
 // DeleteZone deletes a zone.
-func (m *GrpcZAPClientMock) DeleteZone(zoneID int64) (*zap.Zone, error) {
+func (m *GrpcZAPClientMock) DeleteZone(zoneID int64, options *models.DeleteOptions) (*zap.Zone, error) {
+	args := m.Called(zoneID, options)
+	var r0 *zap.Zone
+	if val, ok := args.Get(0).(*zap.Zone); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// UndeleteZone restores a zone.
+func (m *GrpcZAPClientMock) UndeleteZone(zoneID int64) (*zap.Zone, error) {
 	args := m.Called(zoneID)
 	var r0 *zap.Zone
 	if val, ok := args.Get(0).(*zap.Zone); ok {
@@ -88,8 +99,8 @@ func (m *GrpcZAPClientMock) FetchZonesByName(page int32, pageSize int32, name st
 }
 
 // FetchZonesBy fetches zones by.
-func (m *GrpcZAPClientMock) FetchZonesBy(page int32, pageSize int32, zoneID int64, name string) ([]zap.Zone, error) {
-	args := m.Called(page, pageSize, zoneID, name)
+func (m *GrpcZAPClientMock) FetchZonesBy(page int32, pageSize int32, zoneID int64, name string, labels map[string]string) ([]zap.Zone, error) {
+	args := m.Called(page, pageSize, zoneID, name, labels)
 	var r0 []zap.Zone
 	if val, ok := args.Get(0).([]zap.Zone); ok {
 		r0 = val
@@ -97,6 +108,20 @@ func (m *GrpcZAPClientMock) FetchZonesBy(page int32, pageSize int32, zoneID int6
 	return r0, args.Error(1)
 }
 
+// FetchZonesPage fetches a page of zones.
+func (m *GrpcZAPClientMock) FetchZonesPage(page int32, pageSize int32, zoneID int64, name string, namePrefix string, labels map[string]string, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error) {
+	args := m.Called(page, pageSize, zoneID, name, namePrefix, labels, options)
+	var r0 []zap.Zone
+	if val, ok := args.Get(0).([]zap.Zone); ok {
+		r0 = val
+	}
+	var r1 *models.FetchPageInfo
+	if val, ok := args.Get(1).(*models.FetchPageInfo); ok {
+		r1 = val
+	}
+	return r0, r1, args.Error(2)
+}
+
 // Close closes the client connection.
 func (m *GrpcZAPClientMock) Close() error {
 	return nil
@@ -18,6 +18,7 @@ package workspace
 
 import (
 	"errors"
+	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -25,7 +26,9 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/permguard/permguard/internal/cli/common"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
 	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
 )
 
 // validateArg is the function to validate the arguments.
@@ -36,6 +39,77 @@ var validateArg = func(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// addNOTPTraceFlag adds the flag to record the notp packets of the command in a capture file.
+// The flag is bound when the command runs, as the commands sharing it are bound to the same key.
+func addNOTPTraceFlag(command *cobra.Command, v *viper.Viper) {
+	flagName := options.FlagName(common.FlagPrefixNOTP, common.FlagSuffixNOTPTrace)
+	command.Flags().String(flagName, "", "record the notp packets in a capture file, which can be printed with 'permguard notp dump'")
+	command.PreRun = func(cmd *cobra.Command, _ []string) {
+		_ = v.BindPFlag(flagName, cmd.Flags().Lookup(flagName))
+	}
+}
+
+// addCommitSigningKeyFlag adds the flag to sign the commits created by the command.
+func addCommitSigningKeyFlag(command *cobra.Command, v *viper.Viper) {
+	flagName := options.FlagName(common.FlagPrefixCommit, common.FlagSuffixCommitSigningKey)
+	command.Flags().String(flagName, "", "sign the commit with a private key file, or with the ssh agent key matching a public key file")
+	_ = v.BindPFlag(flagName, command.Flags().Lookup(flagName))
+}
+
+const (
+	// flagTLSMode is the flag name for the tls mode persisted for a remote.
+	flagTLSMode = "tls-mode"
+	// flagTokenSource is the flag name for the token source persisted for a remote.
+	flagTokenSource = "token-source"
+)
+
+// addRemoteTLSFlags adds the flags to persist the tls settings of a remote.
+// The certificate files and the spiffe socket are read from the global tls flags passed with the command.
+func addRemoteTLSFlags(command *cobra.Command, v *viper.Viper, commandName string) {
+	command.Flags().String(flagTLSMode, "", "tls mode persisted for the remote: none, tls, mtls or spiffe, inferred from the tls flags when not set")
+	_ = v.BindPFlag(options.FlagName(commandName, flagTLSMode), command.Flags().Lookup(flagTLSMode))
+	command.Flags().String(flagTokenSource, "", "source of the bearer token sent to the remote: env:<VARIABLE> or file:<PATH>")
+	_ = v.BindPFlag(options.FlagName(commandName, flagTokenSource), command.Flags().Lookup(flagTokenSource))
+}
+
+// readRemoteTLSInfo reads the tls settings of a remote from the flags passed with the command, nil when none is passed.
+func readRemoteTLSInfo(cmd *cobra.Command, v *viper.Viper, commandName string) (*azwkscommon.RemoteTLSInfo, error) {
+	changedFlag := func(name string) string {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || !flag.Changed {
+			return ""
+		}
+		return strings.TrimSpace(flag.Value.String())
+	}
+	changedFile := func(name string) (string, error) {
+		path := changedFlag(name)
+		if path == "" {
+			return "", nil
+		}
+		return filepath.Abs(path)
+	}
+	mode := v.GetString(options.FlagName(commandName, flagTLSMode))
+	if mode == "" && changedFlag(options.FlagName(common.FlagPrefixSpiffe, common.FlagSuffixSpiffeEnabled)) == "true" {
+		mode = azwkscommon.RemoteTLSModeSpiffe
+	}
+	caFile, err := changedFile(options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSCAFile))
+	if err != nil {
+		return nil, err
+	}
+	certFile, err := changedFile(options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSCertFile))
+	if err != nil {
+		return nil, err
+	}
+	keyFile, err := changedFile(options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSKeyFile))
+	if err != nil {
+		return nil, err
+	}
+	skipVerify := changedFlag(options.FlagName(common.FlagPrefixTLS, common.FlagSuffixTLSSkipVerify)) == "true"
+	spiffeSocket := changedFlag(options.FlagName(common.FlagPrefixSpiffe, common.FlagSuffixSpiffeEndpoint))
+	tokenSource := strings.TrimSpace(v.GetString(options.FlagName(commandName, flagTokenSource)))
+	return azwkscommon.NewRemoteTLSInfo(mode, caFile, certFile, keyFile, skipVerify, spiffeSocket, tokenSource)
+}
+
 // finalizeOutput injects verbose details into the output map for JSON verbose mode before printing.
 // In verbose JSON mode, "details" is always present (at minimum an empty array).
 func finalizeOutput(ctx *common.CliCommandContext, output map[string]any) map[string]any {
@@ -120,6 +194,7 @@ var outFunc = func(ctx *common.CliCommandContext, printer cli.Printer) common.Pr
 func CreateCommandsForWorkspace(deps cli.DependenciesProvider, v *viper.Viper) []*cobra.Command {
 	commands := []*cobra.Command{
 		CreateCommandForWorkspaceInit(deps, v),
+		CreateCommandForWorkspaceStatus(deps, v),
 		CreateCommandForWorkspaceRemote(deps, v),
 		CreateCommandForWorkspaceCheckout(deps, v),
 		CreateCommandForWorkspaceLedger(deps, v),
@@ -127,7 +202,9 @@ func CreateCommandsForWorkspace(deps cli.DependenciesProvider, v *viper.Viper) [
 		CreateCommandForWorkspacePull(deps, v),
 		CreateCommandForWorkspaceRefresh(deps, v),
 		CreateCommandForWorkspaceValidate(deps, v),
+		CreateCommandForWorkspaceTest(deps, v),
 		CreateCommandForWorkspaceHistory(deps, v),
+		CreateCommandForWorkspaceDiff(deps, v),
 		CreateCommandForWorkspaceObjects(deps, v),
 		CreateCommandForWorkspacePlan(deps, v),
 		CreateCommandForWorkspaceApply(deps, v),
@@ -27,6 +27,16 @@ import (
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/internal/cli/workspace"
 	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+)
+
+const (
+	// commandNameForWorkspacesApply is the command name for workspaces apply.
+	commandNameForWorkspacesApply = "workspaces-apply"
+	// flagMessage is the flag name for the commit message.
+	flagMessage = "message"
+	// flagMessageShort is the short flag name for the commit message.
+	flagMessageShort = "m"
 )
 
 // runECommandForApplyWorkspace runs the command for applying workspace changes.
@@ -44,7 +54,8 @@ func runECommandForApplyWorkspace(deps cli.DependenciesProvider, cmd *cobra.Comm
 	if err != nil {
 		return failWithDetails(ctx, printer, err)
 	}
-	output, err := wksMgr.ExecApply(outFunc(ctx, printer))
+	message := v.GetString(options.FlagName(commandNameForWorkspacesApply, flagMessage))
+	output, err := wksMgr.ExecApply(message, outFunc(ctx, printer))
 	if err != nil {
 		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to apply workspace changes"), err))
 		return common.ErrCommandSilent
@@ -64,11 +75,24 @@ func CreateCommandForWorkspaceApply(deps cli.DependenciesProvider, v *viper.Vipe
 
 Examples:
   # apply the plan to the remote ledger
-  permguard apply`),
+  permguard apply
+
+  # apply the plan to the remote ledger recording why the policies have changed
+  permguard apply -m "grant the auditors read access to the orders"
+
+  # apply the plan to the remote ledger and record the notp packets
+  permguard apply --notp-trace ./notp.trace
+
+  # apply the plan to the remote ledger signing the commit with an ssh key
+  permguard apply --commit-signing-key ~/.ssh/id_ed25519`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runECommandForApplyWorkspace(deps, cmd, v)
 		},
 	}
+	command.Flags().StringP(flagMessage, flagMessageShort, "", "the message recorded in the commit")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesApply, flagMessage), command.Flags().Lookup(flagMessage))
+	addNOTPTraceFlag(command, v)
+	addCommitSigningKeyFlag(command, v)
 	return command
 }
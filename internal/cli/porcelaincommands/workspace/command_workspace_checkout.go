@@ -48,7 +48,10 @@ func runECommandForCheckoutWorkspace(args []string, deps cli.DependenciesProvide
 	if len(args) < 1 {
 		return failWithDetails(ctx, printer, errors.New("cli: failed to checkout the workspace\na ledger URI is required (e.g., origin/<zone-id>/<ledger-name>)"))
 	}
-	ledger := args[0]
+	ledger, err := ctx.LedgerURIWithDefaultZone(args[0])
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to checkout the workspace"), err))
+	}
 	output, err := wksMgr.ExecCheckoutLedger(ledger, outFunc(ctx, printer))
 	if err != nil {
 		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to checkout the workspace"), err))
@@ -69,7 +72,10 @@ func CreateCommandForWorkspaceCheckout(deps cli.DependenciesProvider, v *viper.V
 
 Examples:
   # check out the contents of a remote ledger to the local permguard workspace
-  permguard checkout origin/273165098782/pharmaauthzflow`),
+  permguard checkout origin/273165098782/pharmaauthzflow
+
+  # check out a ledger of the default zone of the current context
+  permguard checkout origin/pharmaauthzflow`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runECommandForCheckoutWorkspace(args, deps, cmd, v)
 		},
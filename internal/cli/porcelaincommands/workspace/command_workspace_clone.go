@@ -52,10 +52,10 @@ func runECommandForCloneWorkspace(args []string, deps cli.DependenciesProvider,
 		} else {
 			ledger := strings.TrimPrefix(ledgerURI, "permguard@")
 			elements := strings.Split(ledger, "/")
-			if len(elements) < 3 {
+			if len(elements) < 2 {
 				validationErr = errors.New("cli: invalid arguments")
 			} else {
-				folder = elements[2]
+				folder = elements[len(elements)-1]
 			}
 		}
 	}
@@ -90,6 +90,11 @@ func runECommandForCloneWorkspace(args []string, deps cli.DependenciesProvider,
 	if validationErr != nil {
 		return fail(validationErr)
 	}
+	ledger, err := ctx.LedgerURIWithDefaultZone(strings.TrimPrefix(ledgerURI, "permguard@"))
+	if err != nil {
+		return fail(err)
+	}
+	ledgerURI = "permguard@" + ledger
 
 	langFct, err := deps.LanguageFactory()
 	if err != nil {
@@ -102,7 +107,11 @@ func runECommandForCloneWorkspace(args []string, deps cli.DependenciesProvider,
 	zapPort := v.GetInt(options.FlagName(commandNameForWorkspacesClone, flagZAP))
 	papPort := v.GetInt(options.FlagName(commandNameForWorkspacesClone, flagPAP))
 	flagScheme := v.GetString(options.FlagName(commandNameForWorkspacesClone, flagScheme))
-	output, err := wksMgr.ExecCloneLedger(ledgerURI, zapPort, papPort, flagScheme, outFunc(ctx, printer))
+	tlsInfo, err := readRemoteTLSInfo(cmd, v, commandNameForWorkspacesClone)
+	if err != nil {
+		return fail(err)
+	}
+	output, err := wksMgr.ExecCloneLedger(ledgerURI, zapPort, papPort, flagScheme, tlsInfo, outFunc(ctx, printer))
 	if err != nil {
 		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to clone the workspace"), err))
 		return common.ErrCommandSilent
@@ -122,7 +131,13 @@ func CreateCommandForWorkspaceClone(deps cli.DependenciesProvider, v *viper.Vipe
 
 Examples:
   # clone a remote ledger to the local permguard workspace
-  permguard clone permguard@localhost/273165098782/pharmaauthzflow`),
+  permguard clone permguard@localhost/273165098782/pharmaauthzflow
+
+  # clone a ledger of the default zone of the current context
+  permguard clone permguard@localhost/pharmaauthzflow
+
+  # clone a remote ledger persisting the mtls settings of the origin remote
+  permguard clone permguard@permguard.example.com/273165098782/pharmaauthzflow --tls-mode mtls --tls-ca-file ./ca.pem --tls-cert-file ./client.pem --tls-key-file ./client-key.pem`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runECommandForCloneWorkspace(args, deps, cmd, v)
 		},
@@ -134,5 +149,6 @@ Examples:
 	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesClone, flagPAP), command.Flags().Lookup(flagPAP))
 	command.Flags().String(flagScheme, "", "specify the gRPC scheme: 'grpc' (plaintext) or 'grpcs' (TLS)")
 	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesClone, flagScheme), command.Flags().Lookup(flagScheme))
+	addRemoteTLSFlags(command, v, commandNameForWorkspacesClone)
 	return command
 }
@@ -0,0 +1,89 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace"
+	"github.com/permguard/permguard/pkg/cli"
+)
+
+// runECommandForDiffWorkspace runs the command for showing the differences of the policies.
+func runECommandForDiffWorkspace(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper, args []string) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	absLangFact, err := deps.LanguageFactory()
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	wksMgr, err := workspace.NewInternalManager(ctx, absLangFact)
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	fromRef, toRef := "", ""
+	if len(args) > 0 {
+		fromRef = args[0]
+	}
+	if len(args) > 1 {
+		toRef = args[1]
+	}
+	output, err := wksMgr.ExecDiff(fromRef, toRef, outFunc(ctx, printer))
+	if err != nil {
+		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to show the diff"), err))
+		return common.ErrCommandSilent
+	}
+	if ctx.IsJSONOutput() {
+		printer.PrintlnMap(finalizeOutput(ctx, output))
+	}
+	return nil
+}
+
+// CreateCommandForWorkspaceDiff creates a command for showing the differences of the policies.
+func CreateCommandForWorkspaceDiff(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "diff [<commit>] [<commit>]",
+		Short: "Show the differences of the policies between commits or between a commit and the workspace",
+		Long: common.BuildCliLongTemplate(`This command shows the differences of the policies as unified diffs.
+Without commits the remote head is compared with the workspace, with one commit the commit is compared with the workspace.
+A commit is either its full id, a unique prefix of a commit in the history or HEAD.
+
+Examples:
+  # show the changes of the workspace which are not applied yet
+  permguard diff
+
+  # show the changes of the workspace since a commit
+  permguard diff bafyreihpc3vupfos5yqnlakgbrpjx3ztbkwwlir5zetbwo3y6uhzpwtxuy
+
+  # show the changes between two commits
+  permguard diff bafyreibaytcoyens bafyreihteemeyv3y`),
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runECommandForDiffWorkspace(deps, cmd, v, args)
+		},
+	}
+	return command
+}
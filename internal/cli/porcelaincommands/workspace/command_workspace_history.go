@@ -19,6 +19,9 @@ package workspace
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -27,8 +30,45 @@ import (
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/internal/cli/workspace"
 	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
 )
 
+const (
+	// commandNameForWorkspacesHistory is the command name for workspaces history.
+	commandNameForWorkspacesHistory = "workspaces-history"
+	// flagSince is the flag name for the oldest committer date of the history.
+	flagSince = "since"
+	// flagAuthor is the flag name for the author of the history.
+	flagAuthor = "author"
+	// flagPath is the flag name for the changed path of the history.
+	flagPath = "path"
+)
+
+// parseHistorySince parses a date (2006-01-02), a timestamp (RFC 3339) or an age (e.g. 12h, 7d, 2w) relative to now.
+func parseHistorySince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			if count, err := strconv.Atoi(n); err == nil && count >= 0 {
+				return now.Add(-time.Duration(count) * unit), nil
+			}
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("cli: invalid since value %q, expected a date (2006-01-02), a timestamp (RFC 3339) or an age (e.g. 12h, 7d, 2w)", value)
+}
+
 // runECommandForHistoryWorkspace run the command for listing history in the workspace.
 func runECommandForHistoryWorkspace(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
 	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
@@ -44,7 +84,13 @@ func runECommandForHistoryWorkspace(deps cli.DependenciesProvider, cmd *cobra.Co
 	if err != nil {
 		return failWithDetails(ctx, printer, err)
 	}
-	output, err := wksMgr.ExecHistory(outFunc(ctx, printer))
+	since, err := parseHistorySince(v.GetString(options.FlagName(commandNameForWorkspacesHistory, flagSince)), time.Now())
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	author := v.GetString(options.FlagName(commandNameForWorkspacesHistory, flagAuthor))
+	changedPath := v.GetString(options.FlagName(commandNameForWorkspacesHistory, flagPath))
+	output, err := wksMgr.ExecHistory(since, author, changedPath, outFunc(ctx, printer))
 	if err != nil {
 		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to show history"), err))
 		return common.ErrCommandSilent
@@ -60,15 +106,27 @@ func CreateCommandForWorkspaceHistory(deps cli.DependenciesProvider, v *viper.Vi
 	command := &cobra.Command{
 		Use:   "history",
 		Short: "Show the history",
-		Long: common.BuildCliLongTemplate(`This command shows the history.
+		Long: common.BuildCliLongTemplate(`This command shows the history with the files changed by each commit.
 
 Examples:
   # show the history
-  permguard history`),
+  permguard history
+
+  # show the commits of the last two weeks
+  permguard history --since 2w
+
+  # show the commits of an author changing the policies of a directory
+  permguard history --author nicola --path platform/`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runECommandForHistoryWorkspace(deps, cmd, v)
 		},
 	}
+	command.Flags().String(flagSince, "", "show the commits committed after a date (2006-01-02), a timestamp (RFC 3339) or an age (e.g. 12h, 7d, 2w)")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesHistory, flagSince), command.Flags().Lookup(flagSince))
+	command.Flags().String(flagAuthor, "", "show the commits whose author contains the text, ignoring case")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesHistory, flagAuthor), command.Flags().Lookup(flagAuthor))
+	command.Flags().String(flagPath, "", "show the commits changing a file, a directory or a glob pattern")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesHistory, flagPath), command.Flags().Lookup(flagPath))
 	return command
 }
@@ -0,0 +1,63 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseHistorySince tests the parsing of the since value of the history.
+func TestParseHistorySince(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+		hasError bool
+	}{
+		{value: "", expected: time.Time{}},
+		{value: "  ", expected: time.Time{}},
+		{value: "2026-10-01T08:30:00Z", expected: time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "2026-10-01T08:30:00+02:00", expected: time.Date(2026, 10, 1, 6, 30, 0, 0, time.UTC)},
+		{value: "2026-10-01", expected: time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)},
+		{value: "12h", expected: now.Add(-12 * time.Hour)},
+		{value: "90m", expected: now.Add(-90 * time.Minute)},
+		{value: "7d", expected: now.AddDate(0, 0, -7)},
+		{value: "0d", expected: now},
+		{value: "2w", expected: now.AddDate(0, 0, -14)},
+		{value: " 3d ", expected: now.AddDate(0, 0, -3)},
+		{value: "-3d", hasError: true},
+		{value: "-1h", hasError: true},
+		{value: "3x", hasError: true},
+		{value: "d", hasError: true},
+		{value: "yesterday", hasError: true},
+		{value: "2026-13-01", hasError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			since, err := parseHistorySince(tt.value, now)
+			if tt.hasError {
+				assert.Error(t, err, "error should not be nil")
+				return
+			}
+			assert.NoError(t, err, "error should be nil")
+			assert.True(t, tt.expected.Equal(since), "since should be %s, got %s", tt.expected, since)
+		})
+	}
+}
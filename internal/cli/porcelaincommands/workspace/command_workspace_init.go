@@ -39,6 +39,10 @@ const (
 	commandNameForWorkspacesInitLanguage = "language"
 	// commandNameForWorkspacesInitTemplate is the template of the workspace to initialize.
 	commandNameForWorkspacesInitTemplate = "template"
+	// commandNameForWorkspacesInitObjectStore is the object store of the workspace to initialize.
+	commandNameForWorkspacesInitObjectStore = "object-store"
+	// commandNameForWorkspacesInitObjectsCacheDir is the objects cache directory of the workspace to initialize.
+	commandNameForWorkspacesInitObjectsCacheDir = "objects-cache-dir"
 )
 
 // runECommandForInitWorkspace runs the command for initializing a workspace.
@@ -60,10 +64,14 @@ func runECommandForInitWorkspace(deps cli.DependenciesProvider, cmd *cobra.Comma
 	name := v.GetString(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitName))
 	language := v.GetString(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitLanguage))
 	template := v.GetString(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitTemplate))
+	objectStore := v.GetString(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitObjectStore))
+	objectsCacheDir := v.GetString(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitObjectsCacheDir))
 	initParams := &workspace.InitParms{
-		Name:     name,
-		Language: language,
-		Template: template,
+		Name:            name,
+		Language:        language,
+		Template:        template,
+		ObjectStore:     objectStore,
+		ObjectsCacheDir: objectsCacheDir,
 	}
 	output, err := wksMgr.ExecInitWorkspace(initParams, outFunc(ctx, printer))
 	if err != nil {
@@ -90,7 +98,11 @@ Examples:
   # initialize a new working directory
   permguard init
   # initialize a new working directory with a specific name
-  permguard init myworkspace`),
+  permguard init myworkspace
+  # initialize a new working directory keeping the objects in a pack file
+  permguard init --object-store packfile
+  # initialize a new working directory keeping the objects in a cache shared across workspaces
+  permguard init --object-store shared --objects-cache-dir ~/.permguard/objects`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && !cmd.Flags().Changed(commandNameForWorkspacesInitName) {
 				_ = cmd.Flags().Set(commandNameForWorkspacesInitName, args[0])
@@ -108,5 +120,11 @@ Examples:
 	command.Flags().String(commandNameForWorkspacesInitTemplate, "", "specify the template of the workspace to initialize")
 	_ = v.BindPFlag(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitTemplate), command.Flags().Lookup(commandNameForWorkspacesInitTemplate))
 
+	command.Flags().String(commandNameForWorkspacesInitObjectStore, "", "specify the object store of the workspace to initialize: filesystem, packfile or shared")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitObjectStore), command.Flags().Lookup(commandNameForWorkspacesInitObjectStore))
+
+	command.Flags().String(commandNameForWorkspacesInitObjectsCacheDir, "", "specify the objects cache directory shared across workspaces, required by the shared object store")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspaceInit, commandNameForWorkspacesInitObjectsCacheDir), command.Flags().Lookup(commandNameForWorkspacesInitObjectsCacheDir))
+
 	return command
 }
@@ -27,6 +27,14 @@ import (
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/internal/cli/workspace"
 	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+)
+
+const (
+	// commandNameForWorkspacesPlan is the command name for workspaces plan.
+	commandNameForWorkspacesPlan = "workspaces-plan"
+	// flagReplay is the flag name for the decision logs to replay.
+	flagReplay = "replay"
 )
 
 // runECommandForPlanWorkspace runs the command for generating a workspace plan.
@@ -44,7 +52,8 @@ func runECommandForPlanWorkspace(deps cli.DependenciesProvider, cmd *cobra.Comma
 	if err != nil {
 		return failWithDetails(ctx, printer, err)
 	}
-	output, err := wksMgr.ExecPlan(outFunc(ctx, printer))
+	replayFile := v.GetString(options.FlagName(commandNameForWorkspacesPlan, flagReplay))
+	output, err := wksMgr.ExecPlan(replayFile, outFunc(ctx, printer))
 	if err != nil {
 		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to execute the plan"), err))
 		return common.ErrCommandSilent
@@ -64,11 +73,16 @@ func CreateCommandForWorkspacePlan(deps cli.DependenciesProvider, v *viper.Viper
 
 Examples:
   # generate a plan of changes to apply to the remote ledger based on the differences between the local and remote states
-  permguard plan`),
+  permguard plan
+
+  # generate a plan and report the decision flips of the recorded requests in the decision logs
+  permguard plan --replay ./decisions.log`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runECommandForPlanWorkspace(deps, cmd, v)
 		},
 	}
+	command.Flags().String(flagReplay, "", "replay the recorded requests of a decision logs file against the remote and the planned policies")
+	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesPlan, flagReplay), command.Flags().Lookup(flagReplay))
 	return command
 }
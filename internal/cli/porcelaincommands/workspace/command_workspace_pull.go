@@ -64,11 +64,15 @@ func CreateCommandForWorkspacePull(deps cli.DependenciesProvider, v *viper.Viper
 
 Examples:
   # fetches the latest changes from the remote ledger and constructs the remote state
-  permguard pull`),
+  permguard pull
+
+  # fetch the latest changes and record the notp packets
+  permguard pull --notp-trace ./notp.trace`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runECommandForPullWorkspace(deps, cmd, v)
 		},
 	}
+	addNOTPTraceFlag(command, v)
 	return command
 }
@@ -68,8 +68,15 @@ func runECommandForRemoteAddWorkspace(args []string, deps cli.DependenciesProvid
 	zapPort := v.GetInt(options.FlagName(commandNameForWorkspacesRemoteAdd, flagZAP))
 	papPort := v.GetInt(options.FlagName(commandNameForWorkspacesRemoteAdd, flagPAP))
 	flagScheme := v.GetString(options.FlagName(commandNameForWorkspacesRemoteAdd, flagScheme))
+	tlsInfo, err := readRemoteTLSInfo(cmd, v, commandNameForWorkspacesRemoteAdd)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to add the remote"), err))
+	}
+	if serverScheme == "" && flagScheme == "" && tlsInfo != nil {
+		flagScheme = tlsInfo.Scheme()
+	}
 	scheme := azwkscommon.ResolveScheme(serverScheme, flagScheme)
-	output, err := wksMgr.ExecAddRemote(remote, server, zapPort, papPort, scheme, outFunc(ctx, printer))
+	output, err := wksMgr.ExecAddRemote(remote, server, zapPort, papPort, scheme, tlsInfo, outFunc(ctx, printer))
 	if err != nil {
 		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to add the remote"), err))
 		return common.ErrCommandSilent
@@ -89,7 +96,13 @@ func CreateCommandForWorkspaceRemoteAdd(deps cli.DependenciesProvider, v *viper.
 
 Examples:
   # add a new remote ledger to track and interact with
-  permguard remote add origin localhost`),
+  permguard remote add origin localhost
+
+  # add a remote authenticating with a client certificate, which pull, apply and clone use automatically
+  permguard remote add prod permguard.example.com --tls-mode mtls --tls-ca-file ./ca.pem --tls-cert-file ./client.pem --tls-key-file ./client-key.pem
+
+  # add a remote sending a bearer token read from an environment variable
+  permguard remote add staging grpcs:staging.example.com --token-source env:PERMGUARD_STAGING_TOKEN`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runECommandForRemoteAddWorkspace(args, deps, cmd, v)
 		},
@@ -101,5 +114,6 @@ Examples:
 	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesRemoteAdd, flagPAP), command.Flags().Lookup(flagPAP))
 	command.Flags().String(flagScheme, "", "specify the gRPC scheme: 'grpc' (plaintext) or 'grpcs' (TLS), overrides scheme prefix in server")
 	_ = v.BindPFlag(options.FlagName(commandNameForWorkspacesRemoteAdd, flagScheme), command.Flags().Lookup(flagScheme))
+	addRemoteTLSFlags(command, v, commandNameForWorkspacesRemoteAdd)
 	return command
 }
@@ -0,0 +1,78 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace"
+	"github.com/permguard/permguard/pkg/cli"
+)
+
+// runECommandForStatusWorkspace runs the command for showing the status of the workspace.
+func runECommandForStatusWorkspace(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	absLangFact, err := deps.LanguageFactory()
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	wksMgr, err := workspace.NewInternalManager(ctx, absLangFact)
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	output, err := wksMgr.ExecStatus(outFunc(ctx, printer))
+	if err != nil {
+		printer.ErrorWithOutput(finalizeErrorOutput(ctx, output), errors.Join(errors.New("cli: failed to show the workspace status"), err))
+		return common.ErrCommandSilent
+	}
+	if ctx.IsJSONOutput() {
+		printer.PrintlnMap(finalizeOutput(ctx, output))
+	}
+	return nil
+}
+
+// CreateCommandForWorkspaceStatus creates a command for showing the status of the workspace.
+func CreateCommandForWorkspaceStatus(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the workspace",
+		Long: common.BuildCliLongTemplate(`This command shows the status of the workspace: the checked out ledger, the local and remote commits,
+the changes to the source files since the last refresh, the staged plan and the configured remotes.
+
+Examples:
+  # show the status of the workspace
+  permguard status
+
+  # show the status of the workspace in json
+  permguard status --output json`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForStatusWorkspace(deps, cmd, v)
+		},
+	}
+	return command
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace"
+	"github.com/permguard/permguard/pkg/cli"
+)
+
+// runECommandForTestWorkspace runs the command for testing the workspace policies.
+func runECommandForTestWorkspace(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	absLangFact, err := deps.LanguageFactory()
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	wksMgr, err := workspace.NewInternalManager(ctx, absLangFact)
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	output, err := wksMgr.ExecTest(outFunc(ctx, printer))
+	if err != nil {
+		errOutput := finalizeErrorOutput(ctx, output)
+		if tests, ok := output["tests"]; ok && ctx.IsJSONOutput() {
+			errOutput["tests"] = tests
+		}
+		printer.ErrorWithOutput(errOutput, errors.Join(errors.New("cli: failed to run the policy tests"), err))
+		return common.ErrCommandSilent
+	}
+	if ctx.IsJSONOutput() {
+		printer.PrintlnMap(finalizeOutput(ctx, output))
+	}
+	return nil
+}
+
+// CreateCommandForWorkspaceTest creates a command for testing the workspace policies.
+func CreateCommandForWorkspaceTest(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "test",
+		Short: "Run the policy tests against the local policies",
+		Long: common.BuildCliLongTemplate(`This command runs the policy tests declared in the *.test.yaml, *.test.yml and *.test.json files
+against the local policies and reports the policies never fired and the actions never exercised.
+
+Examples:
+  # run the policy tests against the local policies
+  permguard test`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForTestWorkspace(deps, cmd, v)
+		},
+	}
+	return command
+}
@@ -61,6 +61,9 @@ func CreateCommandForWorkspaceValidate(deps cli.DependenciesProvider, v *viper.V
 		Use:   "validate",
 		Short: "Validate the local state for consistency and correctness",
 		Long: common.BuildCliLongTemplate(`This command validates the local state for consistency and correctness.
+It also runs a lint pass reporting type mismatches against the schema, shadowed permits,
+permits made dead by forbid policies, unused schema declarations and duplicated policy ids.
+Findings with the error severity make the validation fail.
 
 Examples:
   # validate the local state for consistency and correctness",
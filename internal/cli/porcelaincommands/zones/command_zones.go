@@ -74,15 +74,30 @@ func runECommandForUpsertZone(deps cli.DependenciesProvider, cmd *cobra.Command,
 		return failWithDetails(ctx, printer, errors.Join(fmt.Errorf("cli: cli: %s", strings.ToLower(opGetErroMessage(isCreate))), err))
 	}
 	defer func() { _ = client.Close() }()
+	var description *string
+	if cmd.Flags().Changed(common.FlagCommonDescription) {
+		value := v.GetString(options.FlagName(flagPrefix, common.FlagCommonDescription))
+		description = &value
+	}
+	var labels map[string]string
+	if cmd.Flags().Changed(common.FlagCommonLabel) {
+		labels, err = common.ParseLabels("zone", v.GetStringSlice(options.FlagName(flagPrefix, common.FlagCommonLabel)))
+		if err != nil {
+			return failWithDetails(ctx, printer, err)
+		}
+	}
 	var zone *zap.Zone
 	if isCreate {
 		name := v.GetString(options.FlagName(flagPrefix, common.FlagCommonName))
 		if err := validators.ValidateName("zone", name); err != nil {
 			return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid zone name"), err))
 		}
-		zone, err = client.CreateZone(name)
+		zone, err = client.CreateZone(name, v.GetString(options.FlagName(flagPrefix, common.FlagCommonDescription)), labels)
 	} else {
 		zoneID := v.GetInt64(options.FlagName(flagPrefix, common.FlagCommonZoneID))
+		if zoneID == 0 {
+			zoneID = ctx.DefaultZoneID()
+		}
 		if zoneID == 0 {
 			return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
 		}
@@ -94,8 +109,10 @@ func runECommandForUpsertZone(deps cli.DependenciesProvider, cmd *cobra.Command,
 			return failWithDetails(ctx, printer, errors.Join(errors.New("cli: invalid zone name"), err))
 		}
 		inputZone := &zap.Zone{
-			ZoneID: zoneID,
-			Name:   name,
+			ZoneID:      zoneID,
+			Name:        name,
+			Description: description,
+			Labels:      labels,
 		}
 		zone, err = client.UpdateZone(inputZone)
 	}
@@ -147,6 +164,7 @@ func CreateCommandForZones(deps cli.DependenciesProvider, v *viper.Viper) *cobra
 	command.AddCommand(createCommandForZoneCreate(deps, v))
 	command.AddCommand(createCommandForZoneUpdate(deps, v))
 	command.AddCommand(createCommandForZoneDelete(deps, v))
+	command.AddCommand(createCommandForZoneUndelete(deps, v))
 	command.AddCommand(createCommandForZoneList(deps, v))
 	return command
 }
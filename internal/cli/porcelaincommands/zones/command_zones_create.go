@@ -46,7 +46,9 @@ Examples:
   # create a zone
   permguard zones create pharmaauthzflow-dev
   # create a zone and output the result in json format
-  permguard zones create pharmaauthzflow-dev --output json`),
+  permguard zones create pharmaauthzflow-dev --output json
+  # create a zone with a description and labels
+  permguard zones create pharmaauthzflow-dev --description "pharma tenant" --label owner=team-a --label env=dev`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && !cmd.Flags().Changed(common.FlagCommonName) {
 				_ = cmd.Flags().Set(common.FlagCommonName, args[0])
@@ -56,5 +58,9 @@ Examples:
 	}
 	command.Flags().String(common.FlagCommonName, "", "specify the name of the zone to create")
 	_ = v.BindPFlag(options.FlagName(commandNameForZonesCreate, common.FlagCommonName), command.Flags().Lookup(common.FlagCommonName))
+	command.Flags().String(common.FlagCommonDescription, "", "specify the description of the zone")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesCreate, common.FlagCommonDescription), command.Flags().Lookup(common.FlagCommonDescription))
+	command.Flags().StringArray(common.FlagCommonLabel, nil, "specify a label of the zone as key=value")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesCreate, common.FlagCommonLabel), command.Flags().Lookup(common.FlagCommonLabel))
 	return command
 }
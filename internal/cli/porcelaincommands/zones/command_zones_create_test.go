@@ -68,7 +68,7 @@ func TestCliZonesCreateWithError(t *testing.T) {
 		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
 
 		zapClient := mocks.NewGrpcZAPClientMock()
-		zapClient.On("CreateZone", mock.Anything).Return(nil, errors.New("operation error"))
+		zapClient.On("CreateZone", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
 
 		printerMock := mocks.NewPrinterMock()
 		printerMock.On("Println", mock.Anything).Return()
@@ -114,7 +114,7 @@ func TestCliZonesCreateWithSuccess(t *testing.T) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
-		zapClient.On("CreateZone", mock.Anything).Return(zone, nil)
+		zapClient.On("CreateZone", mock.Anything, mock.Anything, mock.Anything).Return(zone, nil)
 
 		printerMock := mocks.NewPrinterMock()
 		outputPrinter := map[string]any{}
@@ -136,3 +136,46 @@ func TestCliZonesCreateWithSuccess(t *testing.T) {
 		printerMock.AssertCalled(t, "PrintlnMap", outputPrinter)
 	}
 }
+
+// TestCliZonesCreateWithLabels tests that the description and labels are passed to the client.
+func TestCliZonesCreateWithLabels(t *testing.T) {
+	args := []string{"zones", "create", "mycorporate", "--description", "corporate tenant", "--label", "owner=team-a", "--label", "env=prod", "--output", "json"}
+	outputs := []string{""}
+
+	v := viper.New()
+	v.Set("output", "json")
+	v.Set(options.FlagName(common.FlagPrefixZAP, common.FlagSuffixZAPEndpoint), "localhost:9092")
+
+	depsMocks := mocks.NewCliDependenciesMock()
+	cmd := createCommandForZoneCreate(depsMocks, v)
+	cmd.PersistentFlags().StringP(common.FlagWorkingDirectory, common.FlagWorkingDirectoryShort, ".", "work directory")
+	cmd.PersistentFlags().StringP(common.FlagOutput, common.FlagOutputShort, "json", "output format")
+	cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
+
+	labels := map[string]string{"owner": "team-a", "env": "prod"}
+	description := "corporate tenant"
+	zapClient := mocks.NewGrpcZAPClientMock()
+	zone := &zap.Zone{
+		ZoneID:      581616507495,
+		Name:        "mycorporate",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		Description: &description,
+		Labels:      labels,
+	}
+	zapClient.On("CreateZone", mock.Anything, description, labels).Return(zone, nil)
+
+	printerMock := mocks.NewPrinterMock()
+	outputPrinter := map[string]any{
+		"zones":   []*zap.Zone{zone},
+		"details": []map[string]any{},
+	}
+	printerMock.On("PrintlnMap", outputPrinter).Return()
+
+	depsMocks.On("CreatePrinter", mock.Anything, mock.Anything).Return(printerMock, nil)
+	depsMocks.On("CreateGrpcZAPClient", mock.Anything, mock.Anything, mock.Anything).Return(zapClient, nil)
+
+	testutils.BaseCommandWithParamsTest(t, v, cmd, args, false, outputs)
+	zapClient.AssertCalled(t, "CreateZone", mock.Anything, description, labels)
+	printerMock.AssertCalled(t, "PrintlnMap", outputPrinter)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/common"
 	"github.com/permguard/permguard/pkg/cli"
 	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -54,13 +55,20 @@ func runECommandForDeleteZone(deps cli.DependenciesProvider, cmd *cobra.Command,
 	}
 	defer func() { _ = client.Close() }()
 	zoneID := v.GetInt64(options.FlagName(commandNameForZonesDelete, common.FlagCommonZoneID))
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
 	if zoneID == 0 {
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
 	}
 	if zoneID < 0 {
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
 	}
-	zone, err := client.DeleteZone(zoneID)
+	deleteOptions := &models.DeleteOptions{
+		Force:        v.GetBool(options.FlagName(commandNameForZonesDelete, common.FlagCommonForce)),
+		Confirmation: v.GetString(options.FlagName(commandNameForZonesDelete, common.FlagCommonConfirm)),
+	}
+	zone, err := client.DeleteZone(zoneID, deleteOptions)
 	if err != nil {
 		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to delete the zone"), err))
 	}
@@ -89,9 +97,14 @@ func createCommandForZoneDelete(deps cli.DependenciesProvider, v *viper.Viper) *
 		Short: "Delete a remote zone",
 		Long: common.BuildCliLongTemplate(`This command deletes a remote zone.
 
+The zone is soft deleted and can be restored with the undelete command until the retention window of the server expires.
+A zone holding ledgers with policy history is deleted only when confirmed with its name or forced.
+
 Examples:
   # delete a zone and output the result in json format
   permguard zones delete --zone-id 273165098782 --output json
+  # delete a zone holding policy history confirming it with its name
+  permguard zones delete --zone-id 273165098782 --confirm production
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -100,5 +113,9 @@ Examples:
 	}
 	command.Flags().Int64(common.FlagCommonZoneID, 0, "specify the ID of the zone to delete")
 	_ = v.BindPFlag(options.FlagName(commandNameForZonesDelete, common.FlagCommonZoneID), command.Flags().Lookup(common.FlagCommonZoneID))
+	command.Flags().Bool(common.FlagCommonForce, false, "delete the zone even when its ledgers hold policy history")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesDelete, common.FlagCommonForce), command.Flags().Lookup(common.FlagCommonForce))
+	command.Flags().String(common.FlagCommonConfirm, "", "confirm the delete of a zone holding policy history with its name")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesDelete, common.FlagCommonConfirm), command.Flags().Lookup(common.FlagCommonConfirm))
 	return command
 }
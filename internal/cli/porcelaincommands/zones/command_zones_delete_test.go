@@ -29,6 +29,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils/mocks"
 	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -68,7 +69,7 @@ func TestCliZonesDeleteWithError(t *testing.T) {
 		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
 
 		zapClient := mocks.NewGrpcZAPClientMock()
-		zapClient.On("DeleteZone", mock.Anything).Return(nil, errors.New("operation error"))
+		zapClient.On("DeleteZone", mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
 
 		printerMock := mocks.NewPrinterMock()
 		printerMock.On("Println", mock.Anything).Return()
@@ -94,7 +95,7 @@ func TestCliZonesDeleteWithSuccess(t *testing.T) {
 		"json",
 	}
 	for _, outputType := range tests {
-		args := []string{"--zone-id", "581616507495", "--output", outputType}
+		args := []string{"--zone-id", "581616507495", "--confirm", "mycorporate", "--output", outputType}
 		outputs := []string{""}
 
 		v := viper.New()
@@ -114,7 +115,7 @@ func TestCliZonesDeleteWithSuccess(t *testing.T) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
-		zapClient.On("DeleteZone", mock.Anything).Return(zone, nil)
+		zapClient.On("DeleteZone", int64(581616507495), &models.DeleteOptions{Confirmation: "mycorporate"}).Return(zone, nil)
 
 		printerMock := mocks.NewPrinterMock()
 		outputPrinter := map[string]any{}
@@ -66,7 +66,26 @@ func runECommandForListZones(deps cli.DependenciesProvider, cmd *cobra.Command,
 		return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
 	}
 
-	zones, err := client.FetchZonesBy(page, pageSize, zoneID, "")
+	labels, err := common.ParseLabels("zone", v.GetStringSlice(options.FlagName(commandNameForZonesList, common.FlagCommonLabel)))
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+
+	name := v.GetString(options.FlagName(commandNameForZonesList, common.FlagCommonName))
+	namePrefix := v.GetString(options.FlagName(commandNameForZonesList, common.FlagCommonNamePrefix))
+	if err := common.ValidateNameSearch("zone", name, namePrefix); err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	fetchOptions, err := common.ParseFetchOptions(
+		v.GetString(options.FlagName(commandNameForZonesList, common.FlagCommonSort)),
+		v.GetBool(options.FlagName(commandNameForZonesList, common.FlagCommonSortDesc)),
+		v.GetString(options.FlagName(commandNameForZonesList, common.FlagCommonCursor)))
+	if err != nil {
+		return failWithDetails(ctx, printer, err)
+	}
+	fetchOptions.Deleted = v.GetBool(options.FlagName(commandNameForZonesList, common.FlagCommonDeleted))
+
+	zones, pageInfo, err := client.FetchZonesPage(page, pageSize, zoneID, name, namePrefix, labels, fetchOptions)
 	if err != nil {
 		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to list zones"), err))
 	}
@@ -78,9 +97,13 @@ func runECommandForListZones(deps cli.DependenciesProvider, cmd *cobra.Command,
 		for _, zone := range zones {
 			zoneID := strconv.FormatInt(zone.ZoneID, 10)
 			output[zoneID] = zone.Name
+			if len(zone.Labels) > 0 {
+				output[zoneID] = fmt.Sprintf("%s (%s)", zone.Name, common.FormatLabels(zone.Labels))
+			}
 		}
 	} else if ctx.IsJSONOutput() {
 		output["zones"] = zones
+		common.AddFetchPageInfo(output, pageInfo)
 	}
 	if ctx.IsVerboseJSONOutput() {
 		details := ctx.DrainVerboseDetails()
@@ -90,6 +113,9 @@ func runECommandForListZones(deps cli.DependenciesProvider, cmd *cobra.Command,
 		output["details"] = details
 	}
 	printer.PrintlnMap(output)
+	if ctx.IsTerminalOutput() && pageInfo != nil && pageInfo.NextCursor != "" {
+		printer.Println(fmt.Sprintf("Showing %s of %s zones, use --cursor %s for the next page.", common.NumberText(len(zones)), common.NumberText(int(pageInfo.TotalCount)), pageInfo.NextCursor))
+	}
 	return nil
 }
 
@@ -107,6 +133,14 @@ Examples:
 		permguard zones list --page 1 --size 100
 		# list zones and filter by zone
 		permguard zones list --zone-id 268786704340
+		# list zones and filter by labels
+		permguard zones list --label owner=team-a --label env=prod
+		# list zones whose name starts with prod, most recently updated first
+		permguard zones list --name-prefix prod --sort updated_at --desc
+		# list the next page of zones using the cursor returned by the previous page
+		permguard zones list --size 100 --cursor eyJpIjoiMjY4Nzg2NzA0MzQwIn0
+		# list the soft deleted zones that can still be restored
+		permguard zones list --deleted
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -119,5 +153,19 @@ Examples:
 	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonPageSize), command.Flags().Lookup(common.FlagCommonPageSize))
 	command.Flags().Int64(common.FlagCommonZoneID, 0, "filter results by zone ID")
 	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonZoneID), command.Flags().Lookup(common.FlagCommonZoneID))
+	command.Flags().StringArray(common.FlagCommonLabel, nil, "filter results by label as key=value, all the labels must match")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonLabel), command.Flags().Lookup(common.FlagCommonLabel))
+	command.Flags().String(common.FlagCommonName, "", "filter results by zones whose name contains the value")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonName), command.Flags().Lookup(common.FlagCommonName))
+	command.Flags().String(common.FlagCommonNamePrefix, "", "filter results by zones whose name starts with the value")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonNamePrefix), command.Flags().Lookup(common.FlagCommonNamePrefix))
+	command.Flags().String(common.FlagCommonSort, "", "sort results by id, name, created_at or updated_at")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonSort), command.Flags().Lookup(common.FlagCommonSort))
+	command.Flags().Bool(common.FlagCommonSortDesc, false, "sort results in descending order")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonSortDesc), command.Flags().Lookup(common.FlagCommonSortDesc))
+	command.Flags().String(common.FlagCommonCursor, "", "resume the listing after the last result of a previous page, the page number is ignored")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonCursor), command.Flags().Lookup(common.FlagCommonCursor))
+	command.Flags().Bool(common.FlagCommonDeleted, false, "list the soft deleted zones instead of the live ones")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesList, common.FlagCommonDeleted), command.Flags().Lookup(common.FlagCommonDeleted))
 	return command
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
 	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils/mocks"
 	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -68,7 +69,7 @@ func TestCliZonesListWithError(t *testing.T) {
 		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
 
 		zapClient := mocks.NewGrpcZAPClientMock()
-		zapClient.On("FetchZonesBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("operation error"))
+		zapClient.On("FetchZonesPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, errors.New("operation error"))
 
 		printerMock := mocks.NewPrinterMock()
 		printerMock.On("Println", mock.Anything).Return()
@@ -122,7 +123,7 @@ func TestCliZonesListWithSuccess(t *testing.T) {
 				UpdatedAt: time.Now(),
 			},
 		}
-		zapClient.On("FetchZonesBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(zones, nil)
+		zapClient.On("FetchZonesPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(zones, &models.FetchPageInfo{TotalCount: int64(len(zones))}, nil)
 
 		printerMock := mocks.NewPrinterMock()
 		outputPrinter := map[string]any{}
@@ -134,6 +135,7 @@ func TestCliZonesListWithSuccess(t *testing.T) {
 			}
 		} else {
 			outputPrinter["zones"] = zones
+			outputPrinter["total_count"] = int64(len(zones))
 			outputPrinter["details"] = []map[string]any{}
 		}
 		printerMock.On("PrintMap", outputPrinter).Return()
@@ -0,0 +1,107 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package zones
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models/zap"
+)
+
+const (
+	// commandNameForZonesUndelete is the command name for zones undelete.
+	commandNameForZonesUndelete = "zones-undelete"
+)
+
+// runECommandForUndeleteZone runs the command for restoring a zone.
+func runECommandForUndeleteZone(deps cli.DependenciesProvider, cmd *cobra.Command, v *viper.Viper) error {
+	ctx, printer, err := common.CreateContextAndPrinter(deps, cmd, v)
+	if err != nil {
+		color.Red(fmt.Sprintf("%s", err))
+		return common.ErrCommandSilent
+	}
+	zapEndpoint, err := ctx.ZAPEndpoint()
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to undelete the zone"), err))
+	}
+	tlsCfg := ctx.TLSClientConfig()
+	client, err := deps.CreateGrpcZAPClient(zapEndpoint, tlsCfg, ctx.VerboseCollector())
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to undelete the zone"), err))
+	}
+	defer func() { _ = client.Close() }()
+	zoneID := v.GetInt64(options.FlagName(commandNameForZonesUndelete, common.FlagCommonZoneID))
+	if zoneID == 0 {
+		zoneID = ctx.DefaultZoneID()
+	}
+	if zoneID == 0 {
+		return failWithDetails(ctx, printer, errors.New("cli: --zone-id is required"))
+	}
+	if zoneID < 0 {
+		return failWithDetails(ctx, printer, errors.New("cli: --zone-id must be a positive integer"))
+	}
+	zone, err := client.UndeleteZone(zoneID)
+	if err != nil {
+		return failWithDetails(ctx, printer, errors.Join(errors.New("cli: failed to undelete the zone"), err))
+	}
+	output := map[string]any{}
+	if ctx.IsTerminalOutput() {
+		zoneID := strconv.FormatInt(zone.ZoneID, 10)
+		output[zoneID] = zone.Name
+	} else if ctx.IsJSONOutput() {
+		output["zones"] = []*zap.Zone{zone}
+	}
+	if ctx.IsVerboseJSONOutput() {
+		details := ctx.DrainVerboseDetails()
+		if details == nil {
+			details = []map[string]any{}
+		}
+		output["details"] = details
+	}
+	printer.PrintlnMap(output)
+	return nil
+}
+
+// createCommandForZoneUndelete creates a command for restoring a deleted zone.
+func createCommandForZoneUndelete(deps cli.DependenciesProvider, v *viper.Viper) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "undelete",
+		Short: "Restore a deleted remote zone",
+		Long: common.BuildCliLongTemplate(`This command restores a soft deleted remote zone.
+
+Examples:
+  # restore a deleted zone and output the result in json format
+  permguard zones undelete --zone-id 273165098782 --output json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runECommandForUndeleteZone(deps, cmd, v)
+		},
+	}
+	command.Flags().Int64(common.FlagCommonZoneID, 0, "specify the ID of the zone to restore")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesUndelete, common.FlagCommonZoneID), command.Flags().Lookup(common.FlagCommonZoneID))
+	return command
+}
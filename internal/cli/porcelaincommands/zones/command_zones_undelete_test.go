@@ -0,0 +1,138 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package zones
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils"
+	"github.com/permguard/permguard/internal/cli/porcelaincommands/testutils/mocks"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/transport/models/zap"
+)
+
+// TestUndeleteCommandForZonesUndelete tests the createCommandForZoneUndelete function.
+func TestUndeleteCommandForZonesUndelete(t *testing.T) {
+	args := []string{"-h"}
+	outputs := []string{"The official Permguard Command Line Interface", "Copyright © 2022 Nitro Agility S.r.l.", "This command restores a soft deleted remote zone."}
+	testutils.BaseCommandTest(t, createCommandForZoneUndelete, args, false, outputs)
+}
+
+// TestCliZonesUndeleteWithError tests the command for restoring a zone with an error.
+func TestCliZonesUndeleteWithError(t *testing.T) {
+	tests := []struct {
+		OutputType string
+		HasError   bool
+	}{
+		{
+			OutputType: "terminal",
+			HasError:   true,
+		},
+		{
+			OutputType: "json",
+			HasError:   true,
+		},
+	}
+	for _, test := range tests {
+		args := []string{"--zone-id", "581616507495", "--output", test.OutputType}
+		outputs := []string{""}
+
+		v := viper.New()
+		v.Set(options.FlagName(common.FlagPrefixZAP, common.FlagSuffixZAPEndpoint), "localhost:9092")
+
+		depsMocks := mocks.NewCliDependenciesMock()
+		cmd := createCommandForZoneUndelete(depsMocks, v)
+		cmd.PersistentFlags().StringP(common.FlagWorkingDirectory, common.FlagWorkingDirectoryShort, ".", "work directory")
+		cmd.PersistentFlags().StringP(common.FlagOutput, common.FlagOutputShort, test.OutputType, "output format")
+		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
+
+		zapClient := mocks.NewGrpcZAPClientMock()
+		zapClient.On("UndeleteZone", mock.Anything).Return(nil, errors.New("operation error"))
+
+		printerMock := mocks.NewPrinterMock()
+		printerMock.On("Println", mock.Anything).Return()
+		printerMock.On("PrintlnMap", mock.Anything).Return()
+		printerMock.On("ErrorWithOutput", mock.Anything, mock.Anything).Return()
+
+		depsMocks.On("CreatePrinter", mock.Anything, mock.Anything).Return(printerMock, nil)
+		depsMocks.On("CreateGrpcZAPClient", mock.Anything, mock.Anything, mock.Anything).Return(zapClient, nil)
+
+		testutils.BaseCommandWithParamsTest(t, v, cmd, args, true, outputs)
+		if test.HasError {
+			printerMock.AssertCalled(t, "ErrorWithOutput", mock.Anything, mock.Anything)
+		} else {
+			printerMock.AssertNotCalled(t, "ErrorWithOutput", mock.Anything, mock.Anything)
+		}
+	}
+}
+
+// TestCliZonesUndeleteWithSuccess tests the command for restoring a zone.
+func TestCliZonesUndeleteWithSuccess(t *testing.T) {
+	tests := []string{
+		"terminal",
+		"json",
+	}
+	for _, outputType := range tests {
+		args := []string{"--zone-id", "581616507495", "--output", outputType}
+		outputs := []string{""}
+
+		v := viper.New()
+		v.Set("output", outputType)
+		v.Set(options.FlagName(common.FlagPrefixZAP, common.FlagSuffixZAPEndpoint), "localhost:9092")
+
+		depsMocks := mocks.NewCliDependenciesMock()
+		cmd := createCommandForZoneUndelete(depsMocks, v)
+		cmd.PersistentFlags().StringP(common.FlagWorkingDirectory, common.FlagWorkingDirectoryShort, ".", "work directory")
+		cmd.PersistentFlags().StringP(common.FlagOutput, common.FlagOutputShort, outputType, "output format")
+		cmd.PersistentFlags().BoolP(common.FlagVerbose, common.FlagVerboseShort, true, "true for verbose output")
+
+		zapClient := mocks.NewGrpcZAPClientMock()
+		zone := &zap.Zone{
+			ZoneID:    581616507495,
+			Name:      "mycorporate",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		zapClient.On("UndeleteZone", mock.Anything).Return(zone, nil)
+
+		printerMock := mocks.NewPrinterMock()
+		outputPrinter := map[string]any{}
+
+		if outputType == "terminal" {
+			zoneID := fmt.Sprintf("%d", zone.ZoneID)
+			outputPrinter[zoneID] = zone.Name
+		} else {
+			outputPrinter["zones"] = []*zap.Zone{zone}
+			outputPrinter["details"] = []map[string]any{}
+		}
+		printerMock.On("PrintMap", outputPrinter).Return()
+		printerMock.On("PrintlnMap", outputPrinter).Return()
+
+		depsMocks.On("CreatePrinter", mock.Anything, mock.Anything).Return(printerMock, nil)
+		depsMocks.On("CreateGrpcZAPClient", mock.Anything, mock.Anything, mock.Anything).Return(zapClient, nil)
+
+		testutils.BaseCommandWithParamsTest(t, v, cmd, args, false, outputs)
+		printerMock.AssertCalled(t, "PrintlnMap", outputPrinter)
+	}
+}
@@ -47,6 +47,10 @@ Examples:
   permguard zones update --zone-id 273165098782 pharmaauthzflow-dev
   # update a zone and output the result in json format
   permguard zones update --zone-id 273165098782 pharmaauthzflow-dev --output json
+  # replace the labels of a zone
+  permguard zones update --zone-id 273165098782 pharmaauthzflow-dev --label owner=team-b --label env=prod
+  # remove all the labels and the description of a zone
+  permguard zones update --zone-id 273165098782 pharmaauthzflow-dev --label "" --description ""
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && !cmd.Flags().Changed(common.FlagCommonName) {
@@ -59,5 +63,9 @@ Examples:
 	_ = v.BindPFlag(options.FlagName(commandNameForZonesUpdate, common.FlagCommonZoneID), command.Flags().Lookup(common.FlagCommonZoneID))
 	command.Flags().String(common.FlagCommonName, "", "specify the new name for the zone")
 	_ = v.BindPFlag(options.FlagName(commandNameForZonesUpdate, common.FlagCommonName), command.Flags().Lookup(common.FlagCommonName))
+	command.Flags().String(common.FlagCommonDescription, "", "specify the new description for the zone")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesUpdate, common.FlagCommonDescription), command.Flags().Lookup(common.FlagCommonDescription))
+	command.Flags().StringArray(common.FlagCommonLabel, nil, "specify a label of the zone as key=value; replaces the current labels")
+	_ = v.BindPFlag(options.FlagName(commandNameForZonesUpdate, common.FlagCommonLabel), command.Flags().Lookup(common.FlagCommonLabel))
 	return command
 }
@@ -22,6 +22,19 @@ import (
 	"strings"
 
 	"github.com/permguard/permguard/common/pkg/extensions/validators"
+	"github.com/permguard/permguard/pkg/transport/grpctls"
+)
+
+// Remote tls modes.
+const (
+	// RemoteTLSModeNone connects to the remote in plaintext.
+	RemoteTLSModeNone = "none"
+	// RemoteTLSModeTLS verifies the certificate of the remote.
+	RemoteTLSModeTLS = "tls"
+	// RemoteTLSModeMTLS verifies the certificate of the remote and authenticates with a client certificate.
+	RemoteTLSModeMTLS = "mtls"
+	// RemoteTLSModeSpiffe authenticates with the SPIFFE identity of the workload.
+	RemoteTLSModeSpiffe = "spiffe"
 )
 
 // RemoteInfo represents the remote information.
@@ -30,6 +43,139 @@ type RemoteInfo struct {
 	zapPort int
 	papPort int
 	scheme  string
+	tlsInfo *RemoteTLSInfo
+}
+
+// RemoteTLSInfo represents the tls and credential settings persisted for a remote.
+type RemoteTLSInfo struct {
+	mode         string
+	caFile       string
+	certFile     string
+	keyFile      string
+	skipVerify   bool
+	spiffeSocket string
+	tokenSource  string
+}
+
+// NewRemoteTLSInfo creates the tls settings of a remote, when the mode is empty it is inferred from the settings.
+// It returns nil when no setting is passed, in which case the tls flags of each invocation apply.
+func NewRemoteTLSInfo(mode, caFile, certFile, keyFile string, skipVerify bool, spiffeSocket, tokenSource string) (*RemoteTLSInfo, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		switch {
+		case spiffeSocket != "":
+			mode = RemoteTLSModeSpiffe
+		case certFile != "" || keyFile != "":
+			mode = RemoteTLSModeMTLS
+		case caFile != "" || skipVerify || tokenSource != "":
+			mode = RemoteTLSModeTLS
+		default:
+			return nil, nil
+		}
+	}
+	hasCertificates := caFile != "" || certFile != "" || keyFile != "" || skipVerify
+	switch mode {
+	case RemoteTLSModeNone:
+		if hasCertificates || spiffeSocket != "" || tokenSource != "" {
+			return nil, errors.New("cli: tls mode none does not accept tls settings or a token source, which would be sent in plaintext")
+		}
+	case RemoteTLSModeTLS:
+		if certFile != "" || keyFile != "" {
+			return nil, errors.New("cli: tls mode tls does not accept a client certificate, use the mtls mode instead")
+		}
+	case RemoteTLSModeMTLS:
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("cli: tls mode mtls requires both the client certificate and key files")
+		}
+	case RemoteTLSModeSpiffe:
+		if hasCertificates {
+			return nil, errors.New("cli: tls mode spiffe does not accept certificate files, the certificates are provided by the Workload API")
+		}
+	default:
+		return nil, fmt.Errorf("cli: invalid tls mode %q: must be one of none, tls, mtls, spiffe", mode)
+	}
+	if spiffeSocket != "" && mode != RemoteTLSModeSpiffe {
+		return nil, fmt.Errorf("cli: tls mode %s does not accept a spiffe socket", mode)
+	}
+	if tokenSource != "" {
+		if err := grpctls.ValidateTokenSource(tokenSource); err != nil {
+			return nil, err
+		}
+	}
+	return &RemoteTLSInfo{
+		mode:         mode,
+		caFile:       caFile,
+		certFile:     certFile,
+		keyFile:      keyFile,
+		skipVerify:   skipVerify,
+		spiffeSocket: spiffeSocket,
+		tokenSource:  tokenSource,
+	}, nil
+}
+
+// Mode returns the tls mode.
+func (i *RemoteTLSInfo) Mode() string {
+	return i.mode
+}
+
+// CAFile returns the CA certificate file used to verify the remote.
+func (i *RemoteTLSInfo) CAFile() string {
+	return i.caFile
+}
+
+// CertFile returns the client certificate file.
+func (i *RemoteTLSInfo) CertFile() string {
+	return i.certFile
+}
+
+// KeyFile returns the client key file.
+func (i *RemoteTLSInfo) KeyFile() string {
+	return i.keyFile
+}
+
+// SkipVerify returns true if the certificate of the remote is not verified.
+func (i *RemoteTLSInfo) SkipVerify() bool {
+	return i.skipVerify
+}
+
+// SpiffeSocket returns the SPIFFE Workload API socket path.
+func (i *RemoteTLSInfo) SpiffeSocket() string {
+	return i.spiffeSocket
+}
+
+// TokenSource returns the source of the bearer token sent to the remote.
+func (i *RemoteTLSInfo) TokenSource() string {
+	return i.tokenSource
+}
+
+// Scheme returns the gRPC scheme required by the tls mode.
+func (i *RemoteTLSInfo) Scheme() string {
+	if i.mode == RemoteTLSModeNone {
+		return "grpc"
+	}
+	return "grpcs"
+}
+
+// ClientConfig returns the client tls configuration, the default spiffe socket is used when the remote has none.
+func (i *RemoteTLSInfo) ClientConfig(defaultSpiffeSocket string) *grpctls.ClientConfig {
+	switch i.mode {
+	case RemoteTLSModeNone:
+		return nil
+	case RemoteTLSModeSpiffe:
+		socket := i.spiffeSocket
+		if socket == "" {
+			socket = defaultSpiffeSocket
+		}
+		return &grpctls.ClientConfig{Spiffe: true, SpiffeSocketPath: socket, TokenSource: i.tokenSource}
+	default:
+		return &grpctls.ClientConfig{
+			CAFile:      i.caFile,
+			CertFile:    i.certFile,
+			KeyFile:     i.keyFile,
+			SkipVerify:  i.skipVerify,
+			TokenSource: i.tokenSource,
+		}
+	}
 }
 
 // ParseServerScheme extracts an optional scheme prefix from the server string.
@@ -79,6 +225,19 @@ func NewRemoteInfo(server string, zapPort, papPort int, scheme string) (*RemoteI
 	}, nil
 }
 
+// NewRemoteInfoWithTLS creates a new remote info with the tls settings persisted for the remote.
+func NewRemoteInfoWithTLS(server string, zapPort, papPort int, scheme string, tlsInfo *RemoteTLSInfo) (*RemoteInfo, error) {
+	info, err := NewRemoteInfo(server, zapPort, papPort, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if tlsInfo != nil && scheme != "" && scheme != tlsInfo.Scheme() {
+		return nil, fmt.Errorf("cli: scheme %s does not match the tls mode %s, which requires the %s scheme", scheme, tlsInfo.Mode(), tlsInfo.Scheme())
+	}
+	info.tlsInfo = tlsInfo
+	return info, nil
+}
+
 // Server returns the server.
 func (i *RemoteInfo) Server() string {
 	return i.server
@@ -99,6 +258,11 @@ func (i *RemoteInfo) Scheme() string {
 	return i.scheme
 }
 
+// TLSInfo returns the tls settings persisted for the remote, nil when it has none.
+func (i *RemoteInfo) TLSInfo() *RemoteTLSInfo {
+	return i.tlsInfo
+}
+
 // SanitizeRemote sanitizes the remote name.
 func SanitizeRemote(remote string) (string, error) {
 	if len(remote) == 0 {
@@ -0,0 +1,155 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewRemoteTLSInfo tests the inference and the validation of the tls settings of a remote.
+func TestNewRemoteTLSInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		caFile       string
+		certFile     string
+		keyFile      string
+		skipVerify   bool
+		spiffeSocket string
+		tokenSource  string
+		expectedMode string
+		expectNil    bool
+		expectError  bool
+	}{
+		{name: "no-settings", expectNil: true},
+		{name: "infer-tls-from-ca", caFile: "ca.pem", expectedMode: RemoteTLSModeTLS},
+		{name: "infer-tls-from-skip-verify", skipVerify: true, expectedMode: RemoteTLSModeTLS},
+		{name: "infer-tls-from-token", tokenSource: "env:PERMGUARD_TOKEN", expectedMode: RemoteTLSModeTLS},
+		{name: "infer-mtls-from-cert", certFile: "client.pem", keyFile: "client-key.pem", expectedMode: RemoteTLSModeMTLS},
+		{name: "infer-mtls-cert-without-key", certFile: "client.pem", expectError: true},
+		{name: "infer-spiffe-from-socket", spiffeSocket: "unix:///tmp/agent.sock", expectedMode: RemoteTLSModeSpiffe},
+		{name: "explicit-mode-is-normalized", mode: " TLS ", caFile: "ca.pem", expectedMode: RemoteTLSModeTLS},
+		{name: "none", mode: RemoteTLSModeNone, expectedMode: RemoteTLSModeNone},
+		{name: "none-with-ca", mode: RemoteTLSModeNone, caFile: "ca.pem", expectError: true},
+		{name: "none-with-skip-verify", mode: RemoteTLSModeNone, skipVerify: true, expectError: true},
+		{name: "none-with-token", mode: RemoteTLSModeNone, tokenSource: "env:PERMGUARD_TOKEN", expectError: true},
+		{name: "none-with-spiffe-socket", mode: RemoteTLSModeNone, spiffeSocket: "unix:///tmp/agent.sock", expectError: true},
+		{name: "tls-with-client-cert", mode: RemoteTLSModeTLS, certFile: "client.pem", keyFile: "client-key.pem", expectError: true},
+		{name: "tls-with-spiffe-socket", mode: RemoteTLSModeTLS, spiffeSocket: "unix:///tmp/agent.sock", expectError: true},
+		{name: "mtls-without-key", mode: RemoteTLSModeMTLS, certFile: "client.pem", expectError: true},
+		{name: "mtls-without-cert", mode: RemoteTLSModeMTLS, keyFile: "client-key.pem", expectError: true},
+		{name: "mtls-with-ca-and-token", mode: RemoteTLSModeMTLS, caFile: "ca.pem", certFile: "client.pem", keyFile: "client-key.pem", tokenSource: "file:/tmp/token", expectedMode: RemoteTLSModeMTLS},
+		{name: "spiffe-without-socket", mode: RemoteTLSModeSpiffe, expectedMode: RemoteTLSModeSpiffe},
+		{name: "spiffe-with-ca", mode: RemoteTLSModeSpiffe, caFile: "ca.pem", expectError: true},
+		{name: "invalid-mode", mode: "ssl", expectError: true},
+		{name: "invalid-token-source", caFile: "ca.pem", tokenSource: "PERMGUARD_TOKEN", expectError: true},
+		{name: "empty-env-token-source", caFile: "ca.pem", tokenSource: "env:", expectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			info, err := NewRemoteTLSInfo(test.mode, test.caFile, test.certFile, test.keyFile, test.skipVerify, test.spiffeSocket, test.tokenSource)
+			if test.expectError {
+				require.Error(t, err, "error should not be nil")
+				assert.Nil(info, "tls info should be nil")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			if test.expectNil {
+				assert.Nil(info, "tls info should be nil")
+				return
+			}
+			require.NotNil(t, info, "tls info should not be nil")
+			assert.Equal(test.expectedMode, info.Mode(), "mode should be equal")
+			assert.Equal(test.caFile, info.CAFile(), "ca file should be equal")
+			assert.Equal(test.certFile, info.CertFile(), "cert file should be equal")
+			assert.Equal(test.keyFile, info.KeyFile(), "key file should be equal")
+			assert.Equal(test.skipVerify, info.SkipVerify(), "skip verify should be equal")
+			assert.Equal(test.tokenSource, info.TokenSource(), "token source should be equal")
+			if test.expectedMode == RemoteTLSModeNone {
+				assert.Equal("grpc", info.Scheme(), "scheme should be plaintext")
+				assert.Nil(info.ClientConfig(""), "client config should be nil")
+			} else {
+				assert.Equal("grpcs", info.Scheme(), "scheme should be tls")
+				require.NotNil(t, info.ClientConfig(""), "client config should not be nil")
+				assert.Equal(test.tokenSource, info.ClientConfig("").TokenSource, "client config token source should be equal")
+			}
+		})
+	}
+}
+
+// TestRemoteTLSInfoSpiffeSocket tests the default spiffe socket of the client config.
+func TestRemoteTLSInfoSpiffeSocket(t *testing.T) {
+	assert := assert.New(t)
+
+	info, err := NewRemoteTLSInfo(RemoteTLSModeSpiffe, "", "", "", false, "", "")
+	require.NoError(t, err, "error should be nil")
+	cfg := info.ClientConfig("unix:///default.sock")
+	assert.True(cfg.Spiffe, "spiffe should be enabled")
+	assert.Equal("unix:///default.sock", cfg.SpiffeSocketPath, "the default socket should be used")
+
+	info, err = NewRemoteTLSInfo("", "", "", "", false, "unix:///remote.sock", "")
+	require.NoError(t, err, "error should be nil")
+	assert.Equal("unix:///remote.sock", info.ClientConfig("unix:///default.sock").SpiffeSocketPath, "the socket of the remote should be used")
+}
+
+// TestNewRemoteInfoWithTLS tests the consistency of the scheme of a remote with its tls settings.
+func TestNewRemoteInfoWithTLS(t *testing.T) {
+	tlsInfo, err := NewRemoteTLSInfo(RemoteTLSModeTLS, "ca.pem", "", "", false, "", "")
+	require.NoError(t, err, "error should be nil")
+	noneInfo, err := NewRemoteTLSInfo(RemoteTLSModeNone, "", "", "", false, "", "")
+	require.NoError(t, err, "error should be nil")
+
+	tests := []struct {
+		name        string
+		server      string
+		zapPort     int
+		papPort     int
+		scheme      string
+		tlsInfo     *RemoteTLSInfo
+		expectError bool
+	}{
+		{name: "without-tls", server: "localhost", zapPort: 9091, papPort: 9092, scheme: "grpc"},
+		{name: "tls-auto-scheme", server: "localhost", zapPort: 9091, papPort: 9092, tlsInfo: tlsInfo},
+		{name: "tls-with-grpcs", server: "localhost", zapPort: 9091, papPort: 9092, scheme: "grpcs", tlsInfo: tlsInfo},
+		{name: "tls-with-grpc", server: "localhost", zapPort: 9091, papPort: 9092, scheme: "grpc", tlsInfo: tlsInfo, expectError: true},
+		{name: "none-with-grpc", server: "localhost", zapPort: 9091, papPort: 9092, scheme: "grpc", tlsInfo: noneInfo},
+		{name: "none-with-grpcs", server: "localhost", zapPort: 9091, papPort: 9092, scheme: "grpcs", tlsInfo: noneInfo, expectError: true},
+		{name: "invalid-scheme", server: "localhost", zapPort: 9091, papPort: 9092, scheme: "https", tlsInfo: tlsInfo, expectError: true},
+		{name: "empty-server", zapPort: 9091, papPort: 9092, tlsInfo: tlsInfo, expectError: true},
+		{name: "invalid-zap-port", server: "localhost", papPort: 9092, expectError: true},
+		{name: "invalid-pap-port", server: "localhost", zapPort: 9091, expectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			info, err := NewRemoteInfoWithTLS(test.server, test.zapPort, test.papPort, test.scheme, test.tlsInfo)
+			if test.expectError {
+				require.Error(t, err, "error should not be nil")
+				assert.Nil(info, "remote info should be nil")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			assert.Equal(test.server, info.Server(), "server should be equal")
+			assert.Equal(test.scheme, info.Scheme(), "scheme should be equal")
+			assert.Same(test.tlsInfo, info.TLSInfo(), "tls info should be equal")
+		})
+	}
+}
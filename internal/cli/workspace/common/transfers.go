@@ -0,0 +1,69 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+)
+
+const (
+	// TransferFlowPush is the transfer flow of a push.
+	TransferFlowPush = "push"
+	// TransferFlowPull is the transfer flow of a pull.
+	TransferFlowPull = "pull"
+)
+
+// TransferResumeInfo represents the state needed to resume an interrupted object transfer.
+type TransferResumeInfo struct {
+	key              string
+	token            string
+	commitID         string
+	confirmedObjects uint64
+}
+
+// NewTransferResumeInfo creates a new TransferResumeInfo.
+func NewTransferResumeInfo(key, token, commitID string, confirmedObjects uint64) (*TransferResumeInfo, error) {
+	if len(key) == 0 {
+		return nil, errors.New("cli: invalid transfer key")
+	}
+	return &TransferResumeInfo{
+		key:              key,
+		token:            token,
+		commitID:         commitID,
+		confirmedObjects: confirmedObjects,
+	}, nil
+}
+
+// Key returns the key identifying the objects being transferred.
+func (i *TransferResumeInfo) Key() string {
+	return i.key
+}
+
+// Token returns the resume token issued by the server.
+func (i *TransferResumeInfo) Token() string {
+	return i.token
+}
+
+// CommitID returns the commit being transferred, if any, so that an interrupted push resumes the same commit.
+func (i *TransferResumeInfo) CommitID() string {
+	return i.commitID
+}
+
+// ConfirmedObjects returns the number of objects confirmed by the receiver.
+func (i *TransferResumeInfo) ConfirmedObjects() uint64 {
+	return i.confirmedObjects
+}
@@ -27,6 +27,8 @@ type config struct {
 type coreConfig struct {
 	ClientVersion         string `toml:"client-version"`
 	AuthstarMaxObjectSize int    `toml:"authstar-max-object-size,omitempty"`
+	ObjectStore           string `toml:"object-store,omitempty"`
+	ObjectsCacheDir       string `toml:"objects-cache-dir,omitempty"`
 }
 
 // remoteConfig represents the configuration for the remote.
@@ -35,6 +37,14 @@ type remoteConfig struct {
 	ZAPPort int    `toml:"zapport"`
 	PAPPort int    `toml:"papport"`
 	Scheme  string `toml:"scheme,omitempty"`
+
+	TLSMode      string `toml:"tls-mode,omitempty"`
+	CAFile       string `toml:"ca-file,omitempty"`
+	CertFile     string `toml:"cert-file,omitempty"`
+	KeyFile      string `toml:"key-file,omitempty"`
+	SkipVerify   bool   `toml:"skip-verify,omitempty"`
+	SpiffeSocket string `toml:"spiffe-socket,omitempty"`
+	TokenSource  string `toml:"token-source,omitempty"`
 }
 
 // ledgerConfig represents the configuration for the ledger.
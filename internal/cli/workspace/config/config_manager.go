@@ -19,6 +19,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/pelletier/go-toml"
 
@@ -90,7 +91,29 @@ func (m *Manager) RemoteInfo(remote string) (*azwkscommon.RemoteInfo, error) {
 		return nil, fmt.Errorf("cli: remote %s does not exist", remote)
 	}
 	cfgRemote := cfg.Remotes[remote]
-	return azwkscommon.NewRemoteInfo(cfgRemote.Server, cfgRemote.ZAPPort, cfgRemote.PAPPort, cfgRemote.Scheme)
+	var tlsInfo *azwkscommon.RemoteTLSInfo
+	if cfgRemote.TLSMode != "" {
+		tlsInfo, err = azwkscommon.NewRemoteTLSInfo(cfgRemote.TLSMode, cfgRemote.CAFile, cfgRemote.CertFile, cfgRemote.KeyFile,
+			cfgRemote.SkipVerify, cfgRemote.SpiffeSocket, cfgRemote.TokenSource)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: invalid tls settings for remote %s", remote), err)
+		}
+	}
+	return azwkscommon.NewRemoteInfoWithTLS(cfgRemote.Server, cfgRemote.ZAPPort, cfgRemote.PAPPort, cfgRemote.Scheme, tlsInfo)
+}
+
+// RemoteNames gets the names of the configured remotes, sorted.
+func (m *Manager) RemoteNames() ([]string, error) {
+	cfg, err := m.readConfig()
+	if err != nil {
+		return nil, err
+	}
+	remotes := make([]string, 0, len(cfg.Remotes))
+	for remote := range cfg.Remotes {
+		remotes = append(remotes, remote)
+	}
+	sort.Strings(remotes)
+	return remotes, nil
 }
 
 // LedgerInfo gets the ref info.
@@ -132,6 +155,26 @@ func (m *Manager) SetAuthstarMaxObjectSize(size int) error {
 	return m.saveConfig(true, cfg)
 }
 
+// ObjectStore returns the configured object store kind and objects cache directory, empty if not set.
+func (m *Manager) ObjectStore() (string, string, error) {
+	cfg, err := m.readConfig()
+	if err != nil {
+		return "", "", errors.Join(errors.New("cli: failed to read the object store settings"), err)
+	}
+	return cfg.Core.ObjectStore, cfg.Core.ObjectsCacheDir, nil
+}
+
+// SetObjectStore sets the object store kind and objects cache directory.
+func (m *Manager) SetObjectStore(kind string, cacheDir string) error {
+	cfg, err := m.readConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Core.ObjectStore = kind
+	cfg.Core.ObjectsCacheDir = cacheDir
+	return m.saveConfig(true, cfg)
+}
+
 // CheckLedgerIfExists checks if a ledger exists.
 func (m *Manager) CheckLedgerIfExists(ledgerURI string) bool {
 	ledgerURI, _ = azwkscommon.SanitizeLedger(ledgerURI)
@@ -38,7 +38,7 @@ func (m *Manager) ExecInitialize() error {
 }
 
 // ExecAddRemote adds a remote.
-func (m *Manager) ExecAddRemote(remote string, server string, zap int, pap int, scheme string, output map[string]any, out common.PrinterOutFunc) (map[string]any, error) {
+func (m *Manager) ExecAddRemote(remote string, server string, zap int, pap int, scheme string, tlsInfo *azwkscommon.RemoteTLSInfo, output map[string]any, out common.PrinterOutFunc) (map[string]any, error) {
 	if output == nil {
 		output = map[string]any{}
 	}
@@ -65,6 +65,15 @@ func (m *Manager) ExecAddRemote(remote string, server string, zap int, pap int,
 		PAPPort: pap,
 		Scheme:  scheme,
 	}
+	if tlsInfo != nil {
+		cfgRemote.TLSMode = tlsInfo.Mode()
+		cfgRemote.CAFile = tlsInfo.CAFile()
+		cfgRemote.CertFile = tlsInfo.CertFile()
+		cfgRemote.KeyFile = tlsInfo.KeyFile()
+		cfgRemote.SkipVerify = tlsInfo.SkipVerify()
+		cfgRemote.SpiffeSocket = tlsInfo.SpiffeSocket()
+		cfgRemote.TokenSource = tlsInfo.TokenSource()
+	}
 	cfg.Remotes[remote] = cfgRemote
 	if err := m.saveConfig(true, cfg); err != nil {
 		return output, err
@@ -81,12 +90,28 @@ func (m *Manager) ExecAddRemote(remote string, server string, zap int, pap int,
 			"pap_port":   cfgRemote.PAPPort,
 			"scheme":     cfgRemote.Scheme,
 		}
+		if cfgRemote.TLSMode != "" {
+			remoteObj["tls"] = remoteTLSMap(cfgRemote)
+		}
 		remotes = append(remotes, remoteObj)
 		output = out(output, "remotes", remotes, nil, true)
 	}
 	return output, nil
 }
 
+// remoteTLSMap returns the tls settings of a remote for the json output.
+func remoteTLSMap(cfgRemote remoteConfig) map[string]any {
+	return map[string]any{
+		"mode":          cfgRemote.TLSMode,
+		"ca_file":       cfgRemote.CAFile,
+		"cert_file":     cfgRemote.CertFile,
+		"key_file":      cfgRemote.KeyFile,
+		"skip_verify":   cfgRemote.SkipVerify,
+		"spiffe_socket": cfgRemote.SpiffeSocket,
+		"token_source":  cfgRemote.TokenSource,
+	}
+}
+
 // ExecRemoveRemote removes a remote.
 func (m *Manager) ExecRemoveRemote(remote string, output map[string]any, out common.PrinterOutFunc) (map[string]any, error) {
 	if output == nil {
@@ -149,6 +174,10 @@ func (m *Manager) ExecListRemotes(output map[string]any, out common.PrinterOutFu
 		} else {
 			out(nil, "", "Your workspace configured remotes:\n", nil, true)
 			for _, remote := range remotes {
+				if tlsMode := cfg.Remotes[remote].TLSMode; tlsMode != "" {
+					out(nil, "", fmt.Sprintf("	- %s (tls mode %s)", common.KeywordText(remote), tlsMode), nil, true)
+					continue
+				}
 				out(nil, "", fmt.Sprintf("	- %s", common.KeywordText(remote)), nil, true)
 			}
 			out(nil, "", "\n", nil, false)
@@ -163,6 +192,9 @@ func (m *Manager) ExecListRemotes(output map[string]any, out common.PrinterOutFu
 				"pap_server": cfg.Remotes[cfgRemote].Server,
 				"pap_port":   cfg.Remotes[cfgRemote].PAPPort,
 			}
+			if cfg.Remotes[cfgRemote].TLSMode != "" {
+				remoteObj["tls"] = remoteTLSMap(cfg.Remotes[cfgRemote])
+			}
 			remotes = append(remotes, remoteObj)
 		}
 		output = out(output, "remotes", remotes, nil, true)
@@ -23,11 +23,14 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml"
 
 	"github.com/permguard/permguard/internal/cli/common"
 	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/cli/workspace/config"
+	"github.com/permguard/permguard/internal/cli/workspace/objstore"
 	"github.com/permguard/permguard/internal/cli/workspace/persistence"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
@@ -51,13 +54,15 @@ const (
 
 // Manager implements the internal manager for code, objs, states and plans.
 type Manager struct {
-	ctx     *common.CliCommandContext
-	persMgr *persistence.Manager
-	objMgr  *objects.ObjectManager
+	ctx      *common.CliCommandContext
+	persMgr  *persistence.Manager
+	cfgMgr   *config.Manager
+	objMgr   *objects.ObjectManager
+	objStore objstore.ObjectStore
 }
 
 // NewPlansManager creates a new plansuration manager.
-func NewPlansManager(ctx *common.CliCommandContext, persMgr *persistence.Manager) (*Manager, error) {
+func NewPlansManager(ctx *common.CliCommandContext, persMgr *persistence.Manager, cfgMgr *config.Manager) (*Manager, error) {
 	objMgr, err := objects.NewObjectManager()
 	if err != nil {
 		return nil, err
@@ -65,10 +70,28 @@ func NewPlansManager(ctx *common.CliCommandContext, persMgr *persistence.Manager
 	return &Manager{
 		ctx:     ctx,
 		persMgr: persMgr,
+		cfgMgr:  cfgMgr,
 		objMgr:  objMgr,
 	}, nil
 }
 
+// objectStore returns the object store configured for the workspace, created on first use as the workspace may not be initialized yet.
+func (m *Manager) objectStore() (objstore.ObjectStore, error) {
+	if m.objStore != nil {
+		return m.objStore, nil
+	}
+	kind, cacheDir, err := m.cfgMgr.ObjectStore()
+	if err != nil {
+		return nil, err
+	}
+	store, err := objstore.NewObjectStore(kind, m.persMgr, m.objectsDir(), cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	m.objStore = store
+	return store, nil
+}
+
 // codeDir returns the code directory.
 func (m *Manager) codeDir() string {
 	return hiddenCodeDir
@@ -309,6 +332,19 @@ func (m *Manager) ReadCodeSourceCodeMap() ([]CodeFile, error) {
 	return codeFiles, nil
 }
 
+// CodeSourceRefreshTime returns the time the code map was written by the last refresh, false if the code source is clean.
+func (m *Manager) CodeSourceRefreshTime() (time.Time, bool, error) {
+	path := m.persMgr.Path(persistence.PermguardDir, filepath.Join(m.codeSourceDir(), hiddenCodeMapFile))
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, errors.Join(errors.New("cli: failed to read the code map"), err)
+	}
+	return info.ModTime(), true, nil
+}
+
 // SaveCodeSourceCodeState saves the code object state in the code source.
 func (m *Manager) SaveCodeSourceCodeState(codeObjects []CodeObjectState) error {
 	path := filepath.Join(m.codeSourceDir(), hiddenCodeStateFile)
@@ -424,6 +460,7 @@ func (m *Manager) saveCodeObjectStates(path string, codeObjects []CodeObjectStat
 			strconv.FormatUint(uint64(codeObject.LanguageID), 10),
 			strconv.FormatUint(uint64(codeObject.LanguageVersionID), 10),
 			strconv.FormatUint(uint64(codeObject.LanguageTypeID), 10),
+			strconv.FormatUint(uint64(codeObject.DataType), 10),
 		}
 	}
 	err := m.persMgr.WriteCSVStream(persistence.PermguardDir, path, nil, codeObjects, rowFunc, true)
@@ -437,13 +474,20 @@ func (m *Manager) saveCodeObjectStates(path string, codeObjects []CodeObjectStat
 func (m *Manager) readCodeObjectStates(path string) ([]CodeObjectState, error) {
 	var codeObjects []CodeObjectState
 	recordFunc := func(record []string) error {
-		if len(record) < 2 {
+		if len(record) < 10 {
 			return errors.New("invalid record format")
 		}
 		codeTypeID64, _ := strconv.ParseUint(record[6], 10, 32)
 		langID64, _ := strconv.ParseUint(record[7], 10, 32)
 		langVersionID64, _ := strconv.ParseUint(record[8], 10, 32)
 		langTypeID64, _ := strconv.ParseUint(record[9], 10, 32)
+		var dataType64 uint64
+		if len(record) > 10 {
+			dataType64, _ = strconv.ParseUint(record[10], 10, 32)
+		} else if record[1] == "/" && record[2] == "manifest" {
+			// Rows saved before the data type column only carried the manifest entry without a type.
+			dataType64 = uint64(objects.TreeDataTypeManifest)
+		}
 		codeObject := CodeObjectState{
 			State: record[0],
 			CodeObject: CodeObject{
@@ -451,6 +495,7 @@ func (m *Manager) readCodeObjectStates(path string) ([]CodeObjectState, error) {
 				OName:             record[2],
 				OType:             record[3],
 				OID:               record[4],
+				DataType:          uint32(dataType64),
 				CodeID:            record[5],
 				CodeTypeID:        uint32(codeTypeID64),
 				LanguageID:        uint32(langID64),
@@ -524,20 +569,20 @@ func (m *Manager) CalculateCodeObjectsState(currentObjs []CodeObjectState, remot
 
 // SaveObject saves the object in the object store.
 func (m *Manager) SaveObject(oid string, content []byte) (bool, error) {
-	folder, name := m.codeSourceObjectDir(oid, "")
-	path := filepath.Join(folder, name)
-	_, err := m.persMgr.CreateDirIfNotExists(persistence.PermguardDir, folder)
+	store, err := m.objectStore()
 	if err != nil {
-		return false, errors.Join(fmt.Errorf("cli: failed to save object %s", oid), err)
+		return false, err
 	}
-	return m.persMgr.WriteFile(persistence.PermguardDir, path, content, 0o644, false)
+	return store.SaveObject(oid, content)
 }
 
 // ReadObject reads the object from the objs store and verifies OID integrity.
 func (m *Manager) ReadObject(oid string) (*objects.Object, error) {
-	folder, name := m.codeSourceObjectDir(oid, "")
-	path := filepath.Join(folder, name)
-	data, _, err := m.persMgr.ReadFile(persistence.PermguardDir, path, false)
+	store, err := m.objectStore()
+	if err != nil {
+		return nil, err
+	}
+	data, err := store.ReadObject(oid)
 	if err != nil {
 		return nil, err
 	}
@@ -620,26 +665,27 @@ func (m *Manager) CollectGarbage(commitID string) (int, error) {
 		}
 		currentID = commit.Predecessor().String
 	}
-	allObjs, err := m.objects(m.objectsDir(), true)
+	store, err := m.objectStore()
 	if err != nil {
 		return 0, err
 	}
-	removed := 0
-	for _, obj := range allObjs {
-		if !reachable[obj.OID()] {
-			folder, name := m.codeSourceObjectDir(obj.OID(), "")
-			path := filepath.Join(folder, name)
-			if _, err := m.persMgr.DeletePath(persistence.PermguardDir, path); err == nil {
-				removed++
-			}
+	oids, err := store.ObjectIDs()
+	if err != nil {
+		return 0, err
+	}
+	orphans := []string{}
+	for _, oid := range oids {
+		if !reachable[oid] {
+			orphans = append(orphans, oid)
 		}
 	}
-	return removed, nil
+	return store.DeleteObjects(orphans)
 }
 
-// GetObjects returns the objs.
-func (m *Manager) objects(path string, isStore bool) ([]objects.Object, error) {
+// codeSourceObjects returns the objs of the code source.
+func (m *Manager) codeSourceObjects() ([]objects.Object, error) {
 	objs := []objects.Object{}
+	path := m.codeSourceObjectsDir()
 	dirs, err := m.persMgr.ListDirectories(persistence.PermguardDir, path)
 	if err != nil {
 		return nil, err
@@ -650,18 +696,9 @@ func (m *Manager) objects(path string, isStore bool) ([]objects.Object, error) {
 			return nil, err
 		}
 		for _, file := range files {
-			oid := fmt.Sprintf("%s%s", file, dir)
-			var obj *objects.Object
-			if isStore {
-				obj, err = m.ReadObject(oid)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				obj, err = m.ReadCodeSourceObject(oid)
-				if err != nil {
-					return nil, err
-				}
+			obj, err := m.ReadCodeSourceObject(fmt.Sprintf("%s%s", file, dir))
+			if err != nil {
+				return nil, err
 			}
 			objs = append(objs, *obj)
 		}
@@ -669,12 +706,33 @@ func (m *Manager) objects(path string, isStore bool) ([]objects.Object, error) {
 	return objs, nil
 }
 
+// storeObjects returns the objs of the object store.
+func (m *Manager) storeObjects() ([]objects.Object, error) {
+	store, err := m.objectStore()
+	if err != nil {
+		return nil, err
+	}
+	oids, err := store.ObjectIDs()
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]objects.Object, 0, len(oids))
+	for _, oid := range oids {
+		obj, err := m.ReadObject(oid)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, *obj)
+	}
+	return objs, nil
+}
+
 // Objects returns the objs.
 func (m *Manager) Objects(includeStorage, includeCode bool) ([]objects.Object, error) {
 	objs := []objects.Object{}
 	if includeCode {
 		if ok, _ := m.persMgr.CheckPathIfExists(persistence.PermguardDir, m.codeSourceObjectsDir()); ok {
-			codeObjs, err := m.objects(m.codeSourceObjectsDir(), false)
+			codeObjs, err := m.codeSourceObjects()
 			if err != nil {
 				return nil, err
 			}
@@ -682,13 +740,11 @@ func (m *Manager) Objects(includeStorage, includeCode bool) ([]objects.Object, e
 		}
 	}
 	if includeStorage {
-		if ok, _ := m.persMgr.CheckPathIfExists(persistence.PermguardDir, m.objectsDir()); ok {
-			storageObjs, err := m.objects(m.objectsDir(), true)
-			if err != nil {
-				return nil, err
-			}
-			objs = append(objs, storageObjs...)
+		storageObjs, err := m.storeObjects()
+		if err != nil {
+			return nil, err
 		}
+		objs = append(objs, storageObjs...)
 	}
 	return objs, nil
 }
@@ -743,3 +799,27 @@ func (m *Manager) History(commitID string) ([]azwkscommon.CommitInfo, error) {
 	}
 	return commits, nil
 }
+
+// HeldCommits returns the commits held in the object store walking back from the given head, up to the given limit.
+func (m *Manager) HeldCommits(headCommitID string, limit int) ([]string, error) {
+	commitIDs := []string{}
+	visited := map[string]bool{}
+	commitID := headCommitID
+	for len(commitIDs) < limit && commitID != "" && commitID != objects.ZeroOID && !visited[commitID] {
+		visited[commitID] = true
+		commit, err := m.Commit(commitID)
+		if err != nil {
+			// The history is not held beyond this commit.
+			break
+		}
+		// A commit missing any object, e.g. after an interrupted pull, must not be advertised as held.
+		if err := m.objMgr.VerifyCommitGraphIntegrity(commitID, m.ReadObject); err == nil {
+			commitIDs = append(commitIDs, commitID)
+		}
+		if !commit.Predecessor().Valid {
+			break
+		}
+		commitID = commit.Predecessor().String
+	}
+	return commitIDs, nil
+}
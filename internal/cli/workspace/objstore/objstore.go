@@ -0,0 +1,112 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objstore
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+)
+
+const (
+	// KindFileSystem is the object store keeping each object in its own file.
+	KindFileSystem = "filesystem"
+	// KindPackfile is the object store keeping the objects in a pack file with an index.
+	KindPackfile = "packfile"
+	// KindShared is the object store keeping the objects in a content-addressed cache shared across workspaces.
+	KindShared = "shared"
+)
+
+// ObjectStore stores the objects of the workspace, addressed by their OID.
+type ObjectStore interface {
+	// Kind returns the kind of the object store.
+	Kind() string
+	// SaveObject saves the content of an object.
+	SaveObject(oid string, content []byte) (bool, error)
+	// ReadObject reads the content of an object, failing if the object is not stored.
+	ReadObject(oid string) ([]byte, error)
+	// HasObject checks if an object is stored.
+	HasObject(oid string) (bool, error)
+	// DeleteObjects deletes the objects, returning the number of deleted objects.
+	DeleteObjects(oids []string) (int, error)
+	// ObjectIDs returns the OIDs of the stored objects.
+	ObjectIDs() ([]string, error)
+}
+
+// Kinds returns the supported kinds of object store.
+func Kinds() []string {
+	return []string{KindFileSystem, KindPackfile, KindShared}
+}
+
+// ValidateKind validates an object store kind along with its objects cache directory, returning the absolute cache directory.
+func ValidateKind(kind string, cacheDir string) (string, error) {
+	switch kind {
+	case "", KindFileSystem, KindPackfile:
+		if cacheDir != "" {
+			return "", fmt.Errorf("cli: an objects cache directory can be set for the %s object store only", KindShared)
+		}
+		return "", nil
+	case KindShared:
+		if cacheDir == "" {
+			return "", fmt.Errorf("cli: the %s object store requires an objects cache directory", KindShared)
+		}
+		absCacheDir, err := filepath.Abs(cacheDir)
+		if err != nil {
+			return "", fmt.Errorf("cli: invalid objects cache directory %s: %w", cacheDir, err)
+		}
+		return absCacheDir, nil
+	default:
+		return "", fmt.Errorf("cli: unsupported object store %q, supported object stores are: %s", kind, strings.Join(Kinds(), ", "))
+	}
+}
+
+// NewObjectStore creates the object store of the given kind, rooted at a directory relative to the permguard directory.
+// The cache directory is required by the shared object store only.
+func NewObjectStore(kind string, persMgr *persistence.Manager, dir string, cacheDir string) (ObjectStore, error) {
+	switch kind {
+	case "", KindFileSystem:
+		return newFileSystemStore(persMgr, dir), nil
+	case KindPackfile:
+		return newPackfileStore(persMgr, dir), nil
+	case KindShared:
+		absCacheDir, err := ValidateKind(kind, cacheDir)
+		if err != nil {
+			return nil, err
+		}
+		return newSharedStore(persMgr, dir, absCacheDir), nil
+	default:
+		_, err := ValidateKind(kind, cacheDir)
+		return nil, err
+	}
+}
+
+// shardPath returns the folder and the file name of an object, sharding the objects into subdirectories using the last two
+// characters of the OID (CID format) to ensure even distribution (base32 suffix provides uniform hashing across 1024 buckets).
+func shardPath(basePath string, oid string) (string, string) {
+	folder := filepath.Join(basePath, oid[len(oid)-2:])
+	return folder, oid[:len(oid)-2]
+}
+
+// validateOID validates that an OID can be sharded.
+func validateOID(oid string) error {
+	if len(oid) < 3 {
+		return fmt.Errorf("cli: invalid object id %q", oid)
+	}
+	return nil
+}
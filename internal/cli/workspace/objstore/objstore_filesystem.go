@@ -0,0 +1,117 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objstore
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+)
+
+// fileSystemStore keeps each object in its own file, sharded into subdirectories.
+type fileSystemStore struct {
+	persMgr *persistence.Manager
+	dir     string
+}
+
+// newFileSystemStore creates a new file system object store.
+func newFileSystemStore(persMgr *persistence.Manager, dir string) *fileSystemStore {
+	return &fileSystemStore{
+		persMgr: persMgr,
+		dir:     dir,
+	}
+}
+
+// Kind returns the kind of the object store.
+func (s *fileSystemStore) Kind() string {
+	return KindFileSystem
+}
+
+// SaveObject saves the content of an object.
+func (s *fileSystemStore) SaveObject(oid string, content []byte) (bool, error) {
+	if err := validateOID(oid); err != nil {
+		return false, err
+	}
+	folder, name := shardPath(s.dir, oid)
+	if _, err := s.persMgr.CreateDirIfNotExists(persistence.PermguardDir, folder); err != nil {
+		return false, errors.Join(fmt.Errorf("cli: failed to save object %s", oid), err)
+	}
+	return s.persMgr.WriteFile(persistence.PermguardDir, filepath.Join(folder, name), content, 0o644, false)
+}
+
+// ReadObject reads the content of an object.
+func (s *fileSystemStore) ReadObject(oid string) ([]byte, error) {
+	if err := validateOID(oid); err != nil {
+		return nil, err
+	}
+	folder, name := shardPath(s.dir, oid)
+	data, _, err := s.persMgr.ReadFile(persistence.PermguardDir, filepath.Join(folder, name), false)
+	return data, err
+}
+
+// HasObject checks if an object is stored.
+func (s *fileSystemStore) HasObject(oid string) (bool, error) {
+	if err := validateOID(oid); err != nil {
+		return false, err
+	}
+	folder, name := shardPath(s.dir, oid)
+	return s.persMgr.CheckPathIfExists(persistence.PermguardDir, filepath.Join(folder, name))
+}
+
+// DeleteObjects deletes the objects.
+func (s *fileSystemStore) DeleteObjects(oids []string) (int, error) {
+	deleted := 0
+	for _, oid := range oids {
+		if err := validateOID(oid); err != nil {
+			return deleted, err
+		}
+		folder, name := shardPath(s.dir, oid)
+		if ok, err := s.persMgr.DeletePath(persistence.PermguardDir, filepath.Join(folder, name)); err == nil && ok {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// ObjectIDs returns the OIDs of the stored objects.
+func (s *fileSystemStore) ObjectIDs() ([]string, error) {
+	oids := []string{}
+	if ok, _ := s.persMgr.CheckPathIfExists(persistence.PermguardDir, s.dir); !ok {
+		return oids, nil
+	}
+	dirs, err := s.persMgr.ListDirectories(persistence.PermguardDir, s.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		files, err := s.persMgr.ListFiles(persistence.PermguardDir, filepath.Join(s.dir, dir))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			// Temporary files left by an interrupted write are not objects.
+			if strings.HasPrefix(file, ".") {
+				continue
+			}
+			oids = append(oids, file+dir)
+		}
+	}
+	return oids, nil
+}
@@ -0,0 +1,308 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objstore
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+)
+
+const (
+	// packIndexFile is the index of the pack file.
+	packIndexFile = "objects.idx"
+	// packIndexHeader heads the index line naming the pack file.
+	packIndexHeader = "pack"
+)
+
+// packEntry locates an object in the pack file.
+type packEntry struct {
+	offset int64
+	length int64
+}
+
+// packfileStore keeps the objects appended to a single pack file, located through an index.
+// The index names the pack file it refers to, so that a compaction can write a new pack file and switch to it atomically.
+type packfileStore struct {
+	persMgr  *persistence.Manager
+	dir      string
+	packName string
+	index    map[string]packEntry
+}
+
+// newPackfileStore creates a new packfile object store.
+func newPackfileStore(persMgr *persistence.Manager, dir string) *packfileStore {
+	return &packfileStore{
+		persMgr: persMgr,
+		dir:     dir,
+	}
+}
+
+// Kind returns the kind of the object store.
+func (s *packfileStore) Kind() string {
+	return KindPackfile
+}
+
+// path returns the path of a file of the store.
+func (s *packfileStore) path(name string) string {
+	return s.persMgr.Path(persistence.PermguardDir, filepath.Join(s.dir, name))
+}
+
+// packFileName returns the name of the pack file of a generation.
+func packFileName(generation int) string {
+	return fmt.Sprintf("objects-%d.pack", generation)
+}
+
+// packGeneration returns the generation of a pack file.
+func packGeneration(packName string) (int, error) {
+	generation := strings.TrimSuffix(strings.TrimPrefix(packName, "objects-"), ".pack")
+	return strconv.Atoi(generation)
+}
+
+// loadIndex loads the index, once.
+func (s *packfileStore) loadIndex() error {
+	if s.index != nil {
+		return nil
+	}
+	index := map[string]packEntry{}
+	data, err := os.ReadFile(s.path(packIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		s.packName = packFileName(1)
+		s.index = index
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cli: failed to read the pack index: %w", err)
+	}
+	// An interrupted save may leave a partial trailing line, its object is dropped and the index truncated after the last complete line
+	if end := bytes.LastIndexByte(data, '\n') + 1; end < len(data) {
+		if end == 0 {
+			err = os.Remove(s.path(packIndexFile))
+		} else {
+			err = os.Truncate(s.path(packIndexFile), int64(end))
+		}
+		if err != nil {
+			return fmt.Errorf("cli: failed to recover the pack index: %w", err)
+		}
+		data = data[:end]
+	}
+	if len(data) == 0 {
+		s.packName = packFileName(1)
+		s.index = index
+		return nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if line == 1 {
+			if len(fields) != 2 || fields[0] != packIndexHeader {
+				return errors.New("cli: invalid pack index header")
+			}
+			if _, err := packGeneration(fields[1]); err != nil {
+				return fmt.Errorf("cli: invalid pack file %q in the pack index", fields[1])
+			}
+			s.packName = fields[1]
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return fmt.Errorf("cli: invalid pack index entry at line %d", line)
+		}
+		offset, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("cli: invalid pack index offset at line %d", line)
+		}
+		length, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("cli: invalid pack index length at line %d", line)
+		}
+		index[fields[0]] = packEntry{offset: offset, length: length}
+	}
+	if s.packName == "" {
+		return errors.New("cli: invalid pack index header")
+	}
+	s.index = index
+	return nil
+}
+
+// appendFile appends data to a file and syncs it, returning the offset the data has been written at.
+func appendFile(path string, data []byte) (int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return 0, err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return 0, err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return 0, err
+	}
+	return info.Size(), file.Close()
+}
+
+// indexEntryLine returns the index line of an object.
+func indexEntryLine(oid string, entry packEntry) string {
+	return fmt.Sprintf("%s %d %d\n", oid, entry.offset, entry.length)
+}
+
+// SaveObject appends the content of an object to the pack file and its location to the index.
+// The content is synced before the index entry is written, so that an interrupted write leaves at most unreferenced bytes
+// in the pack and a partial index line, which is dropped when the index is loaded.
+func (s *packfileStore) SaveObject(oid string, content []byte) (bool, error) {
+	if err := validateOID(oid); err != nil {
+		return false, err
+	}
+	if err := s.loadIndex(); err != nil {
+		return false, err
+	}
+	if _, ok := s.index[oid]; ok {
+		return true, nil
+	}
+	if _, err := s.persMgr.CreateDirIfNotExists(persistence.PermguardDir, s.dir); err != nil {
+		return false, errors.Join(fmt.Errorf("cli: failed to save object %s", oid), err)
+	}
+	offset, err := appendFile(s.path(s.packName), content)
+	if err != nil {
+		return false, errors.Join(fmt.Errorf("cli: failed to save object %s", oid), err)
+	}
+	entry := packEntry{offset: offset, length: int64(len(content))}
+	line := indexEntryLine(oid, entry)
+	if ok, _ := s.persMgr.CheckPathIfExists(persistence.PermguardDir, filepath.Join(s.dir, packIndexFile)); !ok {
+		line = fmt.Sprintf("%s %s\n", packIndexHeader, s.packName) + line
+	}
+	if _, err := appendFile(s.path(packIndexFile), []byte(line)); err != nil {
+		return false, errors.Join(fmt.Errorf("cli: failed to index object %s", oid), err)
+	}
+	s.index[oid] = entry
+	return true, nil
+}
+
+// ReadObject reads the content of an object from the pack file.
+func (s *packfileStore) ReadObject(oid string) ([]byte, error) {
+	if err := s.loadIndex(); err != nil {
+		return nil, err
+	}
+	entry, ok := s.index[oid]
+	if !ok {
+		return nil, fmt.Errorf("cli: object %s: %w", oid, os.ErrNotExist)
+	}
+	file, err := os.Open(s.path(s.packName))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	data := make([]byte, entry.length)
+	if n, err := file.ReadAt(data, entry.offset); n != len(data) {
+		return nil, fmt.Errorf("cli: failed to read object %s from the pack file: %w", oid, err)
+	}
+	return data, nil
+}
+
+// HasObject checks if an object is indexed.
+func (s *packfileStore) HasObject(oid string) (bool, error) {
+	if err := s.loadIndex(); err != nil {
+		return false, err
+	}
+	_, ok := s.index[oid]
+	return ok, nil
+}
+
+// DeleteObjects deletes the objects by compacting the live objects into a pack file of the next generation.
+func (s *packfileStore) DeleteObjects(oids []string) (int, error) {
+	if err := s.loadIndex(); err != nil {
+		return 0, err
+	}
+	deleted := map[string]bool{}
+	for _, oid := range oids {
+		if _, ok := s.index[oid]; ok {
+			deleted[oid] = true
+		}
+	}
+	if len(deleted) == 0 {
+		return 0, nil
+	}
+	generation, err := packGeneration(s.packName)
+	if err != nil {
+		return 0, err
+	}
+	newPackName := packFileName(generation + 1)
+	newIndex := map[string]packEntry{}
+	var pack bytes.Buffer
+	var index strings.Builder
+	index.WriteString(fmt.Sprintf("%s %s\n", packIndexHeader, newPackName))
+	for _, oid := range s.sortedOIDs() {
+		if deleted[oid] {
+			continue
+		}
+		data, err := s.ReadObject(oid)
+		if err != nil {
+			return 0, err
+		}
+		entry := packEntry{offset: int64(pack.Len()), length: int64(len(data))}
+		pack.Write(data)
+		index.WriteString(indexEntryLine(oid, entry))
+		newIndex[oid] = entry
+	}
+	if _, err := s.persMgr.WriteFile(persistence.PermguardDir, filepath.Join(s.dir, newPackName), pack.Bytes(), 0o644, false); err != nil {
+		return 0, errors.Join(errors.New("cli: failed to compact the pack file"), err)
+	}
+	if _, err := s.persMgr.WriteFile(persistence.PermguardDir, filepath.Join(s.dir, packIndexFile), []byte(index.String()), 0o644, false); err != nil {
+		return 0, errors.Join(errors.New("cli: failed to compact the pack index"), err)
+	}
+	_, _ = s.persMgr.DeletePath(persistence.PermguardDir, filepath.Join(s.dir, s.packName))
+	s.packName = newPackName
+	s.index = newIndex
+	return len(deleted), nil
+}
+
+// sortedOIDs returns the indexed OIDs in pack order.
+func (s *packfileStore) sortedOIDs() []string {
+	oids := make([]string, 0, len(s.index))
+	for oid := range s.index {
+		oids = append(oids, oid)
+	}
+	slices.SortFunc(oids, func(a, b string) int {
+		return cmp.Compare(s.index[a].offset, s.index[b].offset)
+	})
+	return oids
+}
+
+// ObjectIDs returns the OIDs of the indexed objects.
+func (s *packfileStore) ObjectIDs() ([]string, error) {
+	if err := s.loadIndex(); err != nil {
+		return nil, err
+	}
+	return s.sortedOIDs(), nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objstore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+	"github.com/permguard/permguard/pkg/core/files"
+)
+
+const (
+	// sharedObjectsFile lists the objects of the workspace held in the shared cache.
+	sharedObjectsFile = "objects.shared"
+)
+
+// sharedStore keeps the objects in a content-addressed cache directory shared across workspaces, while the workspace
+// lists the objects it holds. As the cache is shared, deleting objects only removes them from the list of the workspace.
+type sharedStore struct {
+	persMgr  *persistence.Manager
+	dir      string
+	cacheDir string
+	oids     map[string]bool
+}
+
+// newSharedStore creates a new shared object store.
+func newSharedStore(persMgr *persistence.Manager, dir string, cacheDir string) *sharedStore {
+	return &sharedStore{
+		persMgr:  persMgr,
+		dir:      dir,
+		cacheDir: cacheDir,
+	}
+}
+
+// Kind returns the kind of the object store.
+func (s *sharedStore) Kind() string {
+	return KindShared
+}
+
+// listFile returns the relative path of the list of the objects of the workspace.
+func (s *sharedStore) listFile() string {
+	return filepath.Join(s.dir, sharedObjectsFile)
+}
+
+// loadList loads the list of the objects of the workspace, once.
+func (s *sharedStore) loadList() error {
+	if s.oids != nil {
+		return nil
+	}
+	oids := map[string]bool{}
+	data, _, err := s.persMgr.ReadFile(persistence.PermguardDir, s.listFile(), false)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cli: failed to read the shared objects list: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if oid := strings.TrimSpace(scanner.Text()); oid != "" {
+			oids[oid] = true
+		}
+	}
+	s.oids = oids
+	return nil
+}
+
+// SaveObject saves the content of an object in the cache, unless already cached, and adds it to the list of the workspace.
+// Objects are written to a temporary file and renamed, so that concurrent workspaces never read a partial object.
+func (s *sharedStore) SaveObject(oid string, content []byte) (bool, error) {
+	if err := validateOID(oid); err != nil {
+		return false, err
+	}
+	if err := s.loadList(); err != nil {
+		return false, err
+	}
+	folder, name := shardPath(s.cacheDir, oid)
+	path := filepath.Join(folder, name)
+	if ok, _ := files.CheckPathIfExists(path); !ok {
+		if _, err := files.CreateDirIfNotExists(folder); err != nil {
+			return false, errors.Join(fmt.Errorf("cli: failed to save object %s in the objects cache", oid), err)
+		}
+		if _, err := files.WriteFile(path, content, 0o644, false); err != nil {
+			return false, errors.Join(fmt.Errorf("cli: failed to save object %s in the objects cache", oid), err)
+		}
+	}
+	if s.oids[oid] {
+		return true, nil
+	}
+	if _, err := s.persMgr.CreateDirIfNotExists(persistence.PermguardDir, s.dir); err != nil {
+		return false, errors.Join(fmt.Errorf("cli: failed to save object %s", oid), err)
+	}
+	if _, err := appendFile(s.persMgr.Path(persistence.PermguardDir, s.listFile()), []byte(oid+"\n")); err != nil {
+		return false, errors.Join(fmt.Errorf("cli: failed to save object %s", oid), err)
+	}
+	s.oids[oid] = true
+	return true, nil
+}
+
+// ReadObject reads the content of an object of the workspace from the cache.
+func (s *sharedStore) ReadObject(oid string) ([]byte, error) {
+	if err := validateOID(oid); err != nil {
+		return nil, err
+	}
+	if err := s.loadList(); err != nil {
+		return nil, err
+	}
+	if !s.oids[oid] {
+		return nil, fmt.Errorf("cli: object %s: %w", oid, os.ErrNotExist)
+	}
+	folder, name := shardPath(s.cacheDir, oid)
+	data, _, err := files.ReadFile(filepath.Join(folder, name), false)
+	return data, err
+}
+
+// HasObject checks if an object is held by the workspace.
+func (s *sharedStore) HasObject(oid string) (bool, error) {
+	if err := s.loadList(); err != nil {
+		return false, err
+	}
+	return s.oids[oid], nil
+}
+
+// DeleteObjects removes the objects from the list of the workspace, leaving the cache untouched.
+func (s *sharedStore) DeleteObjects(oids []string) (int, error) {
+	if err := s.loadList(); err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, oid := range oids {
+		if s.oids[oid] {
+			delete(s.oids, oid)
+			deleted++
+		}
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+	var list strings.Builder
+	for _, oid := range s.sortedOIDs() {
+		list.WriteString(oid + "\n")
+	}
+	if _, err := s.persMgr.WriteFile(persistence.PermguardDir, s.listFile(), []byte(list.String()), 0o644, false); err != nil {
+		return 0, errors.Join(errors.New("cli: failed to write the shared objects list"), err)
+	}
+	return deleted, nil
+}
+
+// sortedOIDs returns the OIDs of the workspace sorted.
+func (s *sharedStore) sortedOIDs() []string {
+	oids := make([]string, 0, len(s.oids))
+	for oid := range s.oids {
+		oids = append(oids, oid)
+	}
+	slices.Sort(oids)
+	return oids
+}
+
+// ObjectIDs returns the OIDs of the objects held by the workspace.
+func (s *sharedStore) ObjectIDs() ([]string, error) {
+	if err := s.loadList(); err != nil {
+		return nil, err
+	}
+	return s.sortedOIDs(), nil
+}
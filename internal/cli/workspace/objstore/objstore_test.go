@@ -0,0 +1,200 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objstore
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+)
+
+// newTestStore creates an object store of the given kind in a new workspace.
+func newTestStore(t *testing.T, kind string, cacheDir string) (ObjectStore, *persistence.Manager) {
+	t.Helper()
+	persMgr, err := persistence.NewManager(t.TempDir(), ".permguard", nil)
+	require.NoError(t, err)
+	store, err := NewObjectStore(kind, persMgr, "objs", cacheDir)
+	require.NoError(t, err)
+	return store, persMgr
+}
+
+// testOID returns a test object id.
+func testOID(i int) string {
+	return fmt.Sprintf("bafyreitestobject%04d", i)
+}
+
+// TestObjectStores tests the object stores save, read, list and delete the objects.
+func TestObjectStores(t *testing.T) {
+	for _, kind := range Kinds() {
+		t.Run(kind, func(t *testing.T) {
+			store, _ := newTestStore(t, kind, t.TempDir())
+			assert.Equal(t, kind, store.Kind())
+			for i := range 10 {
+				ok, err := store.SaveObject(testOID(i), []byte(fmt.Sprintf("content-%d", i)))
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+			_, err := store.SaveObject(testOID(3), []byte("content-3"))
+			require.NoError(t, err)
+
+			data, err := store.ReadObject(testOID(7))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("content-7"), data)
+			_, err = store.ReadObject(testOID(42))
+			assert.Error(t, err)
+			has, err := store.HasObject(testOID(42))
+			require.NoError(t, err)
+			assert.False(t, has)
+
+			oids, err := store.ObjectIDs()
+			require.NoError(t, err)
+			assert.Len(t, oids, 10)
+
+			deleted, err := store.DeleteObjects([]string{testOID(1), testOID(2), testOID(42)})
+			require.NoError(t, err)
+			assert.Equal(t, 2, deleted)
+			oids, err = store.ObjectIDs()
+			require.NoError(t, err)
+			assert.Len(t, oids, 8)
+			assert.NotContains(t, oids, testOID(1))
+			data, err = store.ReadObject(testOID(9))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("content-9"), data)
+		})
+	}
+}
+
+// TestPackfileStoreReopen tests the pack file is read back through its index after a compaction.
+func TestPackfileStoreReopen(t *testing.T) {
+	store, persMgr := newTestStore(t, KindPackfile, "")
+	for i := range 5 {
+		_, err := store.SaveObject(testOID(i), []byte(fmt.Sprintf("content-%d", i)))
+		require.NoError(t, err)
+	}
+	_, err := store.DeleteObjects([]string{testOID(0)})
+	require.NoError(t, err)
+	_, err = store.SaveObject(testOID(5), []byte("content-5"))
+	require.NoError(t, err)
+
+	reopened, err := NewObjectStore(KindPackfile, persMgr, "objs", "")
+	require.NoError(t, err)
+	oids, err := reopened.ObjectIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{testOID(1), testOID(2), testOID(3), testOID(4), testOID(5)}, oids)
+	data, err := reopened.ReadObject(testOID(5))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content-5"), data)
+
+	files, err := persMgr.ListFiles(persistence.PermguardDir, "objs")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{packIndexFile, packFileName(2)}, files)
+}
+
+// TestSharedStoreAcrossWorkspaces tests the objects cache is shared while each workspace lists its own objects.
+func TestSharedStoreAcrossWorkspaces(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	first, _ := newTestStore(t, KindShared, cacheDir)
+	second, _ := newTestStore(t, KindShared, cacheDir)
+
+	_, err := first.SaveObject(testOID(1), []byte("content-1"))
+	require.NoError(t, err)
+	has, err := second.HasObject(testOID(1))
+	require.NoError(t, err)
+	assert.False(t, has)
+	_, err = second.SaveObject(testOID(1), []byte("content-1"))
+	require.NoError(t, err)
+
+	deleted, err := first.DeleteObjects([]string{testOID(1)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	data, err := second.ReadObject(testOID(1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content-1"), data)
+}
+
+// TestValidateKind tests the validation of the object store kinds.
+func TestValidateKind(t *testing.T) {
+	_, err := ValidateKind("", "")
+	assert.NoError(t, err)
+	_, err = ValidateKind(KindPackfile, "")
+	assert.NoError(t, err)
+	_, err = ValidateKind(KindPackfile, "/tmp/cache")
+	assert.Error(t, err)
+	_, err = ValidateKind(KindShared, "")
+	assert.Error(t, err)
+	cacheDir, err := ValidateKind(KindShared, "cache")
+	require.NoError(t, err)
+	assert.True(t, filepath.IsAbs(cacheDir))
+	_, err = ValidateKind("unknown", "")
+	assert.Error(t, err)
+}
+
+// TestPackfileStoreRecoverPartialIndex tests the pack index is recovered after a save interrupted while writing the index line.
+func TestPackfileStoreRecoverPartialIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		saved    int
+		expected []string
+	}{
+		{name: "partial entry", saved: 3, expected: []string{testOID(0), testOID(1), testOID(2)}},
+		{name: "partial header", saved: 0, expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, persMgr := newTestStore(t, KindPackfile, "")
+			for i := range tt.saved {
+				_, err := store.SaveObject(testOID(i), []byte(fmt.Sprintf("content-%d", i)))
+				require.NoError(t, err)
+			}
+			// Simulate a save interrupted after the content was appended to the pack and while the index line was written
+			_, err := persMgr.CreateDirIfNotExists(persistence.PermguardDir, "objs")
+			require.NoError(t, err)
+			packPath := persMgr.Path(persistence.PermguardDir, filepath.Join("objs", packFileName(1)))
+			_, err = appendFile(packPath, []byte("content-x"))
+			require.NoError(t, err)
+			indexPath := persMgr.Path(persistence.PermguardDir, filepath.Join("objs", packIndexFile))
+			partial := testOID(99)
+			if tt.saved == 0 {
+				partial = packIndexHeader + " objects-"
+			}
+			_, err = appendFile(indexPath, []byte(partial))
+			require.NoError(t, err)
+
+			reopened, err := NewObjectStore(KindPackfile, persMgr, "objs", "")
+			require.NoError(t, err)
+			oids, err := reopened.ObjectIDs()
+			require.NoError(t, err, "partial index line should be dropped")
+			assert.Equal(t, tt.expected, oids)
+
+			_, err = reopened.SaveObject(testOID(5), []byte("content-5"))
+			require.NoError(t, err)
+			reopened, err = NewObjectStore(KindPackfile, persMgr, "objs", "")
+			require.NoError(t, err)
+			oids, err = reopened.ObjectIDs()
+			require.NoError(t, err, "index should be valid after the recovery")
+			assert.Equal(t, append(tt.expected, testOID(5)), oids)
+			data, err := reopened.ReadObject(testOID(5))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("content-5"), data)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policytests
+
+import (
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+const (
+	// DecisionAllow represents the expected allow decision.
+	DecisionAllow = "allow"
+	// DecisionDeny represents the expected deny decision.
+	DecisionDeny = "deny"
+)
+
+// TestFileExtensions represents the extensions of the policy test files.
+var TestFileExtensions = []string{".test.yaml", ".test.yml", ".test.json"}
+
+// TestSuite represents a policy test file.
+type TestSuite struct {
+	Name  string     `json:"name,omitempty" yaml:"name,omitempty"`
+	Cases []TestCase `json:"cases" yaml:"cases"`
+}
+
+// TestCase represents a policy test case made of one or more AuthZEN requests.
+type TestCase struct {
+	Name     string        `json:"name" yaml:"name"`
+	Entities *pdp.Entities `json:"entities,omitempty" yaml:"entities,omitempty"`
+	Expect   string        `json:"expect,omitempty" yaml:"expect,omitempty"`
+	Requests []TestRequest `json:"requests" yaml:"requests"`
+}
+
+// TestRequest represents an AuthZEN request evaluated by a test case.
+type TestRequest struct {
+	Subject  *pdp.Subject   `json:"subject" yaml:"subject"`
+	Resource *pdp.Resource  `json:"resource" yaml:"resource"`
+	Action   *pdp.Action    `json:"action" yaml:"action"`
+	Context  map[string]any `json:"context,omitempty" yaml:"context,omitempty"`
+	Expect   string         `json:"expect,omitempty" yaml:"expect,omitempty"`
+}
+
+// TestResult represents the result of a single test request.
+type TestResult struct {
+	File                string   `json:"file"`
+	Suite               string   `json:"suite,omitempty"`
+	Case                string   `json:"case"`
+	Request             int      `json:"request"`
+	Action              string   `json:"action"`
+	Expected            string   `json:"expected"`
+	Actual              string   `json:"actual,omitempty"`
+	Passed              bool     `json:"passed"`
+	Error               string   `json:"error,omitempty"`
+	DeterminingPolicies []string `json:"determining_policies,omitempty"`
+}
+
+// TestCoverage represents the coverage of the policy tests.
+type TestCoverage struct {
+	UnfiredPolicies    []string `json:"unfired_policies"`
+	UnexercisedActions []string `json:"unexercised_actions"`
+}
+
+// Evaluator evaluates a test request and returns the decision and the policies which determined it.
+type Evaluator func(request *TestRequest, entities *pdp.Entities) (bool, []string, error)
@@ -0,0 +1,192 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policytests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+)
+
+// Manager implements the internal manager for the policy tests.
+type Manager struct {
+	ctx     *common.CliCommandContext
+	persMgr *persistence.Manager
+}
+
+// NewManager creates a new policy tests manager.
+func NewManager(ctx *common.CliCommandContext, persMgr *persistence.Manager) (*Manager, error) {
+	return &Manager{
+		ctx:     ctx,
+		persMgr: persMgr,
+	}, nil
+}
+
+// ScanTestFiles scans the workspace for policy test files and returns their relative paths.
+func (m *Manager) ScanTestFiles(ignorePatterns []string, ignoreFile string) ([]string, error) {
+	includedPaths, _, err := m.persMgr.ScanAndFilterFiles(persistence.WorkspaceDir, ".", TestFileExtensions, ignorePatterns, ignoreFile)
+	if err != nil {
+		return nil, errors.Join(errors.New("cli: failed to scan the policy test files"), err)
+	}
+	workDir := m.ctx.WorkDir()
+	paths := make([]string, 0, len(includedPaths))
+	for _, absPath := range includedPaths {
+		relPath, err := filepath.Rel(workDir, absPath)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: failed to compute relative path for test file %q", absPath), err)
+		}
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ReadTestSuite reads and validates a policy test file.
+func (m *Manager) ReadTestSuite(path string) (*TestSuite, error) {
+	data, _, err := m.persMgr.ReadFile(persistence.WorkspaceDir, path, false)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("cli: failed to read the test file %s", path), err)
+	}
+	suite := &TestSuite{}
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, suite)
+	} else {
+		err = yaml.Unmarshal(data, suite)
+	}
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("cli: invalid test file %s", path), err)
+	}
+	for i, testCase := range suite.Cases {
+		if len(testCase.Requests) == 0 {
+			return nil, fmt.Errorf("cli: test case %d in %s has no requests", i+1, path)
+		}
+		for j, request := range testCase.Requests {
+			if request.Subject == nil || request.Resource == nil || request.Action == nil {
+				return nil, fmt.Errorf("cli: request %d of test case %d in %s requires subject, resource and action", j+1, i+1, path)
+			}
+			if _, err := expectedDecision(testCase.Expect, request.Expect); err != nil {
+				return nil, errors.Join(fmt.Errorf("cli: invalid expectation for request %d of test case %d in %s", j+1, i+1, path), err)
+			}
+		}
+	}
+	return suite, nil
+}
+
+// expectedDecision returns the expected decision of a request, falling back to the one of the case.
+func expectedDecision(caseExpect, requestExpect string) (string, error) {
+	expect := strings.ToLower(strings.TrimSpace(requestExpect))
+	if expect == "" {
+		expect = strings.ToLower(strings.TrimSpace(caseExpect))
+	}
+	switch expect {
+	case DecisionAllow, DecisionDeny:
+		return expect, nil
+	case "":
+		return "", errors.New("cli: the expected decision is required")
+	default:
+		return "", fmt.Errorf("cli: the expected decision must be %s or %s", DecisionAllow, DecisionDeny)
+	}
+}
+
+// RunTestSuite runs the test cases of a test suite using the input evaluator.
+func (m *Manager) RunTestSuite(path string, suite *TestSuite, evaluator Evaluator) []TestResult {
+	results := []TestResult{}
+	for i, testCase := range suite.Cases {
+		caseName := testCase.Name
+		if caseName == "" {
+			caseName = fmt.Sprintf("case %d", i+1)
+		}
+		for j := range testCase.Requests {
+			request := &testCase.Requests[j]
+			expected, _ := expectedDecision(testCase.Expect, request.Expect)
+			result := TestResult{
+				File:     path,
+				Suite:    suite.Name,
+				Case:     caseName,
+				Request:  j + 1,
+				Action:   request.Action.Name,
+				Expected: expected,
+			}
+			decision, policies, err := evaluator(request, testCase.Entities)
+			if err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			result.Actual = DecisionDeny
+			if decision {
+				result.Actual = DecisionAllow
+			}
+			result.DeterminingPolicies = policies
+			result.Passed = result.Actual == result.Expected
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// BuildCoverage builds the coverage of the test results against the input policies and actions.
+func BuildCoverage(results []TestResult, policyIDs []string, actions []string) *TestCoverage {
+	firedPolicies := map[string]struct{}{}
+	exercisedActions := map[string]struct{}{}
+	for _, result := range results {
+		for _, policyID := range result.DeterminingPolicies {
+			firedPolicies[policyID] = struct{}{}
+		}
+		if result.Error == "" {
+			exercisedActions[result.Action] = struct{}{}
+		}
+	}
+	coverage := &TestCoverage{
+		UnfiredPolicies:    []string{},
+		UnexercisedActions: []string{},
+	}
+	for _, policyID := range uniqueSorted(policyIDs) {
+		if _, ok := firedPolicies[policyID]; !ok {
+			coverage.UnfiredPolicies = append(coverage.UnfiredPolicies, policyID)
+		}
+	}
+	for _, action := range uniqueSorted(actions) {
+		if _, ok := exercisedActions[action]; !ok {
+			coverage.UnexercisedActions = append(coverage.UnexercisedActions, action)
+		}
+	}
+	return coverage
+}
+
+// uniqueSorted returns the sorted unique items.
+func uniqueSorted(items []string) []string {
+	seen := map[string]struct{}{}
+	unique := []string{}
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		unique = append(unique, item)
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,248 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package policytests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// newTestManager creates a policy tests manager for a workspace with the given files.
+func newTestManager(t *testing.T, files map[string]string) *Manager {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600), "error should be nil")
+	}
+	persMgr, err := persistence.NewManager(dir, ".permguard", nil)
+	require.NoError(t, err, "error should be nil")
+	m, err := NewManager(nil, persMgr)
+	require.NoError(t, err, "error should be nil")
+	return m
+}
+
+// TestReadTestSuite tests the reading and the validation of the policy test files.
+func TestReadTestSuite(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		cases       int
+		errContains string
+	}{
+		{
+			name: "yaml",
+			file: "orders.test.yaml",
+			content: `name: orders
+cases:
+  - name: view
+    expect: allow
+    requests:
+      - subject: {type: user, id: amy}
+        resource: {type: Order, id: "1"}
+        action: {name: view}
+      - subject: {type: user, id: amy}
+        resource: {type: Order, id: "1"}
+        action: {name: delete}
+        expect: DENY
+`,
+			cases: 1,
+		},
+		{
+			name:    "json",
+			file:    "orders.test.json",
+			content: `{"cases": [{"name": "view", "requests": [{"subject": {"id": "amy"}, "resource": {"id": "1"}, "action": {"name": "view"}, "expect": "allow"}]}]}`,
+			cases:   1,
+		},
+		{name: "missing file", file: "missing.test.yaml", errContains: "failed to read the test file"},
+		{name: "invalid syntax", file: "orders.test.json", content: `{"cases": [`, errContains: "invalid test file"},
+		{
+			name:        "no requests",
+			file:        "orders.test.yaml",
+			content:     "cases:\n  - name: empty\n    expect: allow\n",
+			errContains: "test case 1 in orders.test.yaml has no requests",
+		},
+		{
+			name: "missing action",
+			file: "orders.test.yaml",
+			content: `cases:
+  - expect: allow
+    requests:
+      - subject: {id: amy}
+        resource: {id: "1"}
+`,
+			errContains: "requires subject, resource and action",
+		},
+		{
+			name: "missing expectation",
+			file: "orders.test.yaml",
+			content: `cases:
+  - requests:
+      - subject: {id: amy}
+        resource: {id: "1"}
+        action: {name: view}
+`,
+			errContains: "the expected decision is required",
+		},
+		{
+			name: "invalid expectation",
+			file: "orders.test.yaml",
+			content: `cases:
+  - expect: maybe
+    requests:
+      - subject: {id: amy}
+        resource: {id: "1"}
+        action: {name: view}
+`,
+			errContains: "the expected decision must be allow or deny",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{}
+			if tt.content != "" {
+				files[tt.file] = tt.content
+			}
+			m := newTestManager(t, files)
+			suite, err := m.ReadTestSuite(tt.file)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains, "error should be as expected")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			assert.Len(t, suite.Cases, tt.cases, "cases should be read")
+		})
+	}
+}
+
+// TestExpectedDecision tests the fallback of the expected decision to the one of the case.
+func TestExpectedDecision(t *testing.T) {
+	tests := []struct {
+		caseExpect    string
+		requestExpect string
+		expected      string
+		hasError      bool
+	}{
+		{caseExpect: "allow", expected: DecisionAllow},
+		{caseExpect: "allow", requestExpect: " Deny ", expected: DecisionDeny},
+		{requestExpect: "ALLOW", expected: DecisionAllow},
+		{hasError: true},
+		{caseExpect: "permit", hasError: true},
+		{caseExpect: "allow", requestExpect: "forbid", hasError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caseExpect+"/"+tt.requestExpect, func(t *testing.T) {
+			expected, err := expectedDecision(tt.caseExpect, tt.requestExpect)
+			if tt.hasError {
+				assert.Error(t, err, "error should not be nil")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			assert.Equal(t, tt.expected, expected, "expected decision should match")
+		})
+	}
+}
+
+// TestRunTestSuite tests the results of the test requests for the evaluator decisions.
+func TestRunTestSuite(t *testing.T) {
+	request := func(action, expect string) TestRequest {
+		return TestRequest{
+			Subject:  &pdp.Subject{Type: "user", ID: "amy"},
+			Resource: &pdp.Resource{Type: "Order", ID: "1"},
+			Action:   &pdp.Action{Name: action},
+			Expect:   expect,
+		}
+	}
+	entities := &pdp.Entities{Schema: "cedar"}
+	suite := &TestSuite{
+		Name: "orders",
+		Cases: []TestCase{
+			{
+				Name:     "view",
+				Entities: entities,
+				Expect:   DecisionAllow,
+				Requests: []TestRequest{request("view", ""), request("delete", DecisionAllow)},
+			},
+			{
+				Expect:   DecisionDeny,
+				Requests: []TestRequest{request("update", "")},
+			},
+		},
+	}
+	var receivedEntities []*pdp.Entities
+	evaluator := func(request *TestRequest, entities *pdp.Entities) (bool, []string, error) {
+		receivedEntities = append(receivedEntities, entities)
+		switch request.Action.Name {
+		case "view":
+			return true, []string{"view-orders"}, nil
+		case "delete":
+			return false, []string{"no-delete"}, nil
+		default:
+			return false, nil, errors.New("evaluation failed")
+		}
+	}
+
+	results := newTestManager(t, nil).RunTestSuite("orders.test.yaml", suite, evaluator)
+	require.Len(t, results, 3, "each request should have a result")
+	assert.Equal(t, []*pdp.Entities{entities, entities, nil}, receivedEntities, "entities of the case should be evaluated")
+
+	assert.Equal(t, TestResult{
+		File:                "orders.test.yaml",
+		Suite:               "orders",
+		Case:                "view",
+		Request:             1,
+		Action:              "view",
+		Expected:            DecisionAllow,
+		Actual:              DecisionAllow,
+		Passed:              true,
+		DeterminingPolicies: []string{"view-orders"},
+	}, results[0], "matching decision should pass")
+
+	assert.False(t, results[1].Passed, "mismatching decision should fail")
+	assert.Equal(t, 2, results[1].Request, "request index should be reported")
+	assert.Equal(t, DecisionAllow, results[1].Expected, "request expectation should override the case one")
+	assert.Equal(t, DecisionDeny, results[1].Actual, "actual decision should be deny")
+
+	assert.False(t, results[2].Passed, "failed evaluation should not pass")
+	assert.Equal(t, "case 2", results[2].Case, "unnamed case should be named by index")
+	assert.Equal(t, "evaluation failed", results[2].Error, "evaluation error should be reported")
+	assert.Empty(t, results[2].Actual, "failed evaluation should have no decision")
+}
+
+// TestBuildCoverage tests the unfired policies and the unexercised actions of the test results.
+func TestBuildCoverage(t *testing.T) {
+	results := []TestResult{
+		{Action: "view", DeterminingPolicies: []string{"view-orders"}},
+		{Action: "delete", DeterminingPolicies: []string{"no-delete", "view-orders"}},
+		{Action: "update", Error: "evaluation failed"},
+	}
+	coverage := BuildCoverage(results, []string{"view-orders", "no-delete", "admin", "audit", "admin"}, []string{"view", "update", "delete", "list"})
+	assert.Equal(t, []string{"admin", "audit"}, coverage.UnfiredPolicies, "unfired policies should be sorted and unique")
+	assert.Equal(t, []string{"list", "update"}, coverage.UnexercisedActions, "actions of failed evaluations should not be exercised")
+
+	coverage = BuildCoverage(nil, nil, nil)
+	assert.NotNil(t, coverage.UnfiredPolicies, "unfired policies should not be nil")
+	assert.NotNil(t, coverage.UnexercisedActions, "unexercised actions should not be nil")
+}
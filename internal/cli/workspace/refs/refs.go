@@ -37,3 +37,16 @@ type refObjectsConfig struct {
 type refConfig struct {
 	Objects refObjectsConfig `toml:"objects"`
 }
+
+// transferObjectsConfig represents the configuration for the resume state of a transfer.
+type transferObjectsConfig struct {
+	Key              string `toml:"key"`
+	Token            string `toml:"token"`
+	CommitID         string `toml:"commitid,omitempty"`
+	ConfirmedObjects uint64 `toml:"confirmedobjects"`
+}
+
+// transferConfig represents the configuration for a transfer.
+type transferConfig struct {
+	Objects transferObjectsConfig `toml:"objects"`
+}
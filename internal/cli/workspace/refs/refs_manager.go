@@ -34,6 +34,8 @@ const (
 	hiddenRefsDir = "refs"
 	// hiddenHeadFile represents the hidden head file.
 	hiddenHeadFile = "HEAD"
+	// hiddenTransfersDir represents the hidden transfers directory.
+	hiddenTransfersDir = "transfers"
 )
 
 // Manager implements the internal manager for the ref files.
@@ -262,3 +264,69 @@ func (m *Manager) CurrentHeadRefInfo() (*azwkscommon.RefInfo, error) {
 	}
 	return m.RefInfo(headInfo.Ref())
 }
+
+// transferFile returns the transfer file of the ref for the given flow.
+func (m *Manager) transferFile(ref string, flow string) (string, error) {
+	refInfo, err := azwkscommon.ConvertStringWithLedgerIDToRefInfo(ref)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(hiddenTransfersDir, refInfo.SourceType(), refInfo.Remote(), strconv.FormatInt(refInfo.ZoneID(), 10), refInfo.LedgerID(), flow), nil
+}
+
+// SaveTransferResume saves the resume state of a transfer.
+func (m *Manager) SaveTransferResume(ref string, flow string, info *azwkscommon.TransferResumeInfo) error {
+	if info == nil {
+		return errors.New("cli: invalid transfer resume info")
+	}
+	transferPath, err := m.transferFile(ref, flow)
+	if err != nil {
+		return err
+	}
+	if _, err = m.persMgr.CreateFileIfNotExists(persistence.PermguardDir, transferPath); err != nil {
+		return err
+	}
+	transferCfg := transferConfig{
+		Objects: transferObjectsConfig{
+			Key:              info.Key(),
+			Token:            info.Token(),
+			CommitID:         info.CommitID(),
+			ConfirmedObjects: info.ConfirmedObjects(),
+		},
+	}
+	return m.saveConfig(transferPath, true, &transferCfg)
+}
+
+// TransferResume reads the resume state of a transfer, it returns nil if there is no interrupted transfer.
+func (m *Manager) TransferResume(ref string, flow string) (*azwkscommon.TransferResumeInfo, error) {
+	transferPath, err := m.transferFile(ref, flow)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := m.persMgr.CheckPathIfExists(persistence.PermguardDir, transferPath)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var config transferConfig
+	if err := m.persMgr.ReadTOMLFile(persistence.PermguardDir, transferPath, &config); err != nil {
+		return nil, err
+	}
+	if config.Objects.Key == "" {
+		return nil, nil
+	}
+	return azwkscommon.NewTransferResumeInfo(config.Objects.Key, config.Objects.Token, config.Objects.CommitID, config.Objects.ConfirmedObjects)
+}
+
+// DeleteTransferResume deletes the resume state of a transfer.
+func (m *Manager) DeleteTransferResume(ref string, flow string) error {
+	transferPath, err := m.transferFile(ref, flow)
+	if err != nil {
+		return err
+	}
+	exists, err := m.persMgr.CheckPathIfExists(persistence.PermguardDir, transferPath)
+	if err != nil || !exists {
+		return err
+	}
+	_, err = m.persMgr.DeletePath(persistence.PermguardDir, transferPath)
+	return err
+}
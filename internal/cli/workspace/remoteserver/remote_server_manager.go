@@ -52,6 +52,17 @@ func grpcEndpoint(tlsCfg *grpctls.ClientConfig, remoteScheme string, host string
 	return fmt.Sprintf("%s://%s:%d", scheme, host, port), nil
 }
 
+// remoteClientConfig returns the tls configuration and the scheme used to connect to the remote.
+// The tls settings persisted for the remote take precedence, the tls flags apply to the remotes without them.
+func remoteClientConfig(ctx *common.CliCommandContext, remoteInfo *azwkscommon.RemoteInfo) (*grpctls.ClientConfig, string) {
+	flagsCfg := ctx.TLSClientConfig()
+	tlsInfo := remoteInfo.TLSInfo()
+	if tlsInfo == nil {
+		return flagsCfg, remoteInfo.Scheme()
+	}
+	return tlsInfo.ClientConfig(flagsCfg.SpiffeSocketPath), tlsInfo.Scheme()
+}
+
 // Manager implements the internal manager for the remote file.
 type Manager struct {
 	ctx *common.CliCommandContext
@@ -72,8 +83,8 @@ func (m *Manager) ServerRemoteLedger(remoteInfo *azwkscommon.RemoteInfo, ledgerI
 	if ledgerInfo == nil {
 		return nil, errors.New("cli: ledger info is nil — ensure a ledger is checked out with 'permguard checkout'")
 	}
-	tlsCfg := m.ctx.TLSClientConfig()
-	zapEndpoint, err := grpcEndpoint(tlsCfg, remoteInfo.Scheme(), remoteInfo.Server(), remoteInfo.ZAPPort())
+	tlsCfg, scheme := remoteClientConfig(m.ctx, remoteInfo)
+	zapEndpoint, err := grpcEndpoint(tlsCfg, scheme, remoteInfo.Server(), remoteInfo.ZAPPort())
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +93,7 @@ func (m *Manager) ServerRemoteLedger(remoteInfo *azwkscommon.RemoteInfo, ledgerI
 		return nil, err
 	}
 	defer func() { _ = zapClient.Close() }()
-	papEndpoint, err := grpcEndpoint(tlsCfg, remoteInfo.Scheme(), remoteInfo.Server(), remoteInfo.PAPPort())
+	papEndpoint, err := grpcEndpoint(tlsCfg, scheme, remoteInfo.Server(), remoteInfo.PAPPort())
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +131,8 @@ func (m *Manager) ServerRemoteLedgerByID(remoteInfo *azwkscommon.RemoteInfo, zon
 	if remoteInfo == nil {
 		return nil, errors.New("cli: remote info is nil")
 	}
-	tlsCfg := m.ctx.TLSClientConfig()
-	papEndpoint, err := grpcEndpoint(tlsCfg, remoteInfo.Scheme(), remoteInfo.Server(), remoteInfo.PAPPort())
+	tlsCfg, scheme := remoteClientConfig(m.ctx, remoteInfo)
+	papEndpoint, err := grpcEndpoint(tlsCfg, scheme, remoteInfo.Server(), remoteInfo.PAPPort())
 	if err != nil {
 		return nil, err
 	}
@@ -138,9 +149,12 @@ func (m *Manager) ServerRemoteLedgerByID(remoteInfo *azwkscommon.RemoteInfo, zon
 }
 
 // NewPAPClientSession creates a new gRPC PAP client session with a reusable connection.
-func (m *Manager) NewPAPClientSession(server string, papPort int, scheme string) (*clients.GrpcPAPClientSession, error) {
-	tlsCfg := m.ctx.TLSClientConfig()
-	papEndpoint, err := grpcEndpoint(tlsCfg, scheme, server, papPort)
+func (m *Manager) NewPAPClientSession(remoteInfo *azwkscommon.RemoteInfo) (*clients.GrpcPAPClientSession, error) {
+	if remoteInfo == nil {
+		return nil, errors.New("cli: remote info is nil")
+	}
+	tlsCfg, scheme := remoteClientConfig(m.ctx, remoteInfo)
+	papEndpoint, err := grpcEndpoint(tlsCfg, scheme, remoteInfo.Server(), remoteInfo.PAPPort())
 	if err != nil {
 		return nil, err
 	}
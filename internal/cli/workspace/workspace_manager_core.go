@@ -29,6 +29,7 @@ import (
 	"github.com/permguard/permguard/internal/cli/workspace/cosp"
 	"github.com/permguard/permguard/internal/cli/workspace/logs"
 	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+	"github.com/permguard/permguard/internal/cli/workspace/policytests"
 	azrefs "github.com/permguard/permguard/internal/cli/workspace/refs"
 	"github.com/permguard/permguard/internal/cli/workspace/remoteserver"
 	"github.com/permguard/permguard/pkg/authz/languages"
@@ -63,6 +64,7 @@ type Manager struct {
 	logsMgr        *logs.Manager
 	rfsMgr         *azrefs.Manager
 	cospMgr        *cosp.Manager
+	ptestsMgr      *policytests.Manager
 	contextPrinted bool
 }
 
@@ -93,7 +95,11 @@ func NewInternalManager(ctx *common.CliCommandContext, langFct languages.Languag
 	if err != nil {
 		return nil, err
 	}
-	cospMgr, err := cosp.NewPlansManager(ctx, persMgr)
+	cospMgr, err := cosp.NewPlansManager(ctx, persMgr, cfgMgr)
+	if err != nil {
+		return nil, err
+	}
+	ptestsMgr, err := policytests.NewManager(ctx, persMgr)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +115,7 @@ func NewInternalManager(ctx *common.CliCommandContext, langFct languages.Languag
 		logsMgr:   logsMgr,
 		rfsMgr:    rfsMgr,
 		cospMgr:   cospMgr,
+		ptestsMgr: ptestsMgr,
 	}, nil
 }
 
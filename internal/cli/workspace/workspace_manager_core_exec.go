@@ -25,6 +25,8 @@ import (
 	"github.com/permguard/permguard/common/pkg/extensions/ids"
 	"github.com/permguard/permguard/common/pkg/extensions/validators"
 	"github.com/permguard/permguard/internal/cli/common"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/cli/workspace/objstore"
 	"github.com/permguard/permguard/internal/cli/workspace/persistence"
 	"github.com/permguard/permguard/pkg/authz/languages"
 	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
@@ -54,6 +56,10 @@ type InitParms struct {
 	Language string
 	// Template the template.
 	Template string
+	// ObjectStore the kind of object store, the file system one if empty.
+	ObjectStore string
+	// ObjectsCacheDir the objects cache directory shared across workspaces, used by the shared object store.
+	ObjectsCacheDir string
 }
 
 // ExecInitWorkspace initializes the workspace.
@@ -65,7 +71,12 @@ func (m *Manager) ExecInitWorkspace(initParams *InitParms, out common.PrinterOut
 
 	homeHiddenDir := m.homeHiddenDir()
 
-	var err error
+	objStoreKind := strings.ToLower(strings.TrimSpace(initParams.ObjectStore))
+	objectsCacheDir, err := objstore.ValidateKind(objStoreKind, strings.TrimSpace(initParams.ObjectsCacheDir))
+	if err != nil {
+		return fail(nil, err)
+	}
+
 	var created bool
 	created, err = m.persMgr.CreateDirIfNotExists(persistence.WorkDir, homeHiddenDir)
 	if err != nil {
@@ -138,6 +149,11 @@ func (m *Manager) ExecInitWorkspace(initParams *InitParms, out common.PrinterOut
 		m.rfsMgr.ExecInitalize,
 		m.cospMgr.ExecInitalize,
 	}
+	if objStoreKind != "" {
+		initializers = append(initializers, func() error {
+			return m.cfgMgr.SetObjectStore(objStoreKind, objectsCacheDir)
+		})
+	}
 	for _, initializer := range initializers {
 		err := initializer()
 		if err != nil {
@@ -167,7 +183,7 @@ func (m *Manager) ExecInitWorkspace(initParams *InitParms, out common.PrinterOut
 }
 
 // execInternalAddRemote adds a remote.
-func (m *Manager) execInternalAddRemote(_ bool, remote string, server string, zapPort int, papPort int, scheme string, out common.PrinterOutFunc) (map[string]any, error) {
+func (m *Manager) execInternalAddRemote(_ bool, remote string, server string, zapPort int, papPort int, scheme string, tlsInfo *azwkscommon.RemoteTLSInfo, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -175,6 +191,9 @@ func (m *Manager) execInternalAddRemote(_ bool, remote string, server string, za
 	if scheme != "" && scheme != "grpc" && scheme != "grpcs" {
 		return fail(nil, fmt.Errorf("cli: invalid scheme %q: must be 'grpc' (plaintext) or 'grpcs' (TLS)", scheme))
 	}
+	if tlsInfo != nil && scheme != tlsInfo.Scheme() {
+		return fail(nil, fmt.Errorf("cli: scheme %s does not match the tls mode %s, which requires the %s scheme", scheme, tlsInfo.Mode(), tlsInfo.Scheme()))
+	}
 	if !validators.IsValidHostname(server) {
 		return fail(nil, fmt.Errorf("cli: invalid server %s: must be a valid hostname or IP address", server))
 	}
@@ -185,7 +204,7 @@ func (m *Manager) execInternalAddRemote(_ bool, remote string, server string, za
 		return fail(nil, fmt.Errorf("cli: invalid pap port %d: must be between 1 and 65535", papPort))
 	}
 
-	output, err := m.cfgMgr.ExecAddRemote(remote, server, zapPort, papPort, scheme, nil, out)
+	output, err := m.cfgMgr.ExecAddRemote(remote, server, zapPort, papPort, scheme, tlsInfo, nil, out)
 	if err != nil {
 		return fail(output, err)
 	}
@@ -193,7 +212,7 @@ func (m *Manager) execInternalAddRemote(_ bool, remote string, server string, za
 }
 
 // ExecAddRemote adds a remote.
-func (m *Manager) ExecAddRemote(remote string, server string, zapPort int, papPort int, scheme string, out common.PrinterOutFunc) (map[string]any, error) {
+func (m *Manager) ExecAddRemote(remote string, server string, zapPort int, papPort int, scheme string, tlsInfo *azwkscommon.RemoteTLSInfo, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -208,7 +227,7 @@ func (m *Manager) ExecAddRemote(remote string, server string, zapPort int, papPo
 	}
 	defer func() { _ = fileLock.Unlock() }()
 
-	return m.execInternalAddRemote(false, remote, server, zapPort, papPort, scheme, out)
+	return m.execInternalAddRemote(false, remote, server, zapPort, papPort, scheme, tlsInfo, out)
 }
 
 // ExecRemoveRemote removes a remote.
@@ -0,0 +1,314 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+const (
+	// diffWorkspaceLabel is the label of the workspace side of a diff.
+	diffWorkspaceLabel = "workspace"
+	// diffManifestKey is the key of the manifest entry of a diff side.
+	diffManifestKey = "\x00manifest"
+	// diffContextLines is the number of context lines of the unified diffs.
+	diffContextLines = 3
+)
+
+// diffSide is one side of a diff: the entries of a commit or of the workspace, and the reader of their objects.
+type diffSide struct {
+	label   string
+	entries map[string]commitChange
+	read    func(oid string) (*objects.Object, error)
+}
+
+// diffChange is a code object which differs between the two sides of a diff.
+type diffChange struct {
+	state string
+	from  *commitChange
+	to    *commitChange
+	patch string
+}
+
+// entry returns the entry of the change, preferring the destination side.
+func (c diffChange) entry() commitChange {
+	if c.to != nil {
+		return *c.to
+	}
+	return *c.from
+}
+
+// resolveDiffCommit resolves a commit by its id, by a unique prefix of a commit in the history of the head or by HEAD.
+func (m *Manager) resolveDiffCommit(headCtx *currentHeadContext, ref string) (string, *objects.Commit, error) {
+	headCommitID := headCtx.RemoteCommitID()
+	if strings.EqualFold(ref, "HEAD") {
+		if headCommitID == objects.ZeroOID {
+			return "", nil, fmt.Errorf("cli: ledger %s has no commits", headCtx.LedgerURI())
+		}
+		ref = headCommitID
+	}
+	if commit, err := m.cospMgr.Commit(ref); err == nil {
+		return ref, commit, nil
+	}
+	var matches []string
+	var matched *objects.Commit
+	if headCommitID != objects.ZeroOID {
+		commitInfos, err := m.history(headCommitID)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, info := range commitInfos {
+			if strings.HasPrefix(info.CommitOID(), ref) {
+				matches = append(matches, info.CommitOID())
+				matched = info.Commit()
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", nil, fmt.Errorf("cli: commit %s is not held by the workspace", ref)
+	case 1:
+		return matches[0], matched, nil
+	default:
+		return "", nil, fmt.Errorf("cli: commit prefix %s is ambiguous, it matches %d commits", ref, len(matches))
+	}
+}
+
+// commitDiffSide builds the diff side of a commit.
+func (m *Manager) commitDiffSide(commitID string, commit *objects.Commit) (*diffSide, error) {
+	sourcePaths, err := m.codeSourcePaths()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := m.commitTreeEntries(commit, sourcePaths)
+	if err != nil {
+		return nil, err
+	}
+	entries[diffManifestKey] = commitChange{oname: "manifest", oid: commit.Manifest().String(), codeID: "manifest"}
+	return &diffSide{label: commitID, entries: entries, read: m.cospMgr.ReadObject}, nil
+}
+
+// remoteDiffSide builds the diff side of the remote head, empty if the ledger has no commits.
+func (m *Manager) remoteDiffSide(headCtx *currentHeadContext) (*diffSide, error) {
+	side := &diffSide{label: headCtx.RemoteCommitID(), entries: map[string]commitChange{}, read: m.cospMgr.ReadObject}
+	commit, err := m.CurrentHeadCommit(headCtx.Ref())
+	if err != nil || commit == nil {
+		return side, err
+	}
+	trees, err := m.CurrentHeadTrees(headCtx.Ref())
+	if err != nil {
+		return nil, err
+	}
+	treeList := make([]*objects.Tree, 0, len(trees))
+	for _, tree := range trees {
+		treeList = append(treeList, tree)
+	}
+	sourcePaths, err := m.codeSourcePaths()
+	if err != nil {
+		return nil, err
+	}
+	side.entries = treesEntries(treeList, sourcePaths)
+	side.entries[diffManifestKey] = commitChange{oname: "manifest", oid: commit.Manifest().String(), codeID: "manifest"}
+	return side, nil
+}
+
+// workspaceDiffSide builds the diff side of the workspace, the local area must have been refreshed.
+func (m *Manager) workspaceDiffSide() (*diffSide, error) {
+	codeStates, err := m.cospMgr.ReadCodeSourceCodeState()
+	if err != nil {
+		return nil, err
+	}
+	sourcePaths, err := m.codeSourcePaths()
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]commitChange{}
+	for _, codeState := range codeStates {
+		key := codeState.Partition + "\x00" + codeState.OName
+		entries[key] = commitChange{
+			partition: codeState.Partition,
+			oname:     codeState.OName,
+			oid:       codeState.OID,
+			codeID:    codeState.CodeID,
+			path:      sourcePaths[key],
+		}
+	}
+	_, manifestID, err := m.cospMgr.ReadCodeSourceConfig()
+	if err != nil {
+		return nil, err
+	}
+	if manifestID != "" && manifestID != objects.ZeroOID {
+		entries[diffManifestKey] = commitChange{oname: "manifest", oid: manifestID, codeID: "manifest"}
+	}
+	read := func(oid string) (*objects.Object, error) {
+		obj, err := m.cospMgr.ReadCodeSourceObject(oid)
+		if err != nil {
+			return m.cospMgr.ReadObject(oid)
+		}
+		return obj, nil
+	}
+	return &diffSide{label: diffWorkspaceLabel, entries: entries, read: read}, nil
+}
+
+// diffObjectText returns the human-readable content of an object of a diff side.
+func (m *Manager) diffObjectText(langPvd *ManifestLanguageProvider, side *diffSide, entry *commitChange) (string, error) {
+	if entry == nil {
+		return "", nil
+	}
+	obj, err := side.read(entry.oid)
+	if err != nil {
+		return "", err
+	}
+	objInfo, err := m.objMar.ObjectInfo(obj)
+	if err != nil {
+		return "", err
+	}
+	content, ok := objInfo.Instance().([]byte)
+	if !ok {
+		return "", fmt.Errorf("cli: oid %s is not a blob", entry.oid)
+	}
+	humanContent, err := m.blobHumanContent(langPvd, objInfo.Header(), content)
+	if err != nil {
+		return "", err
+	}
+	return string(humanContent), nil
+}
+
+// diffSides diffs the entries of the two sides, matching them by partition and object name.
+func (m *Manager) diffSides(langPvd *ManifestLanguageProvider, from, to *diffSide) ([]diffChange, error) {
+	keys := map[string]bool{}
+	for key := range from.entries {
+		keys[key] = true
+	}
+	for key := range to.entries {
+		keys[key] = true
+	}
+	changes := []diffChange{}
+	for key := range keys {
+		change := diffChange{}
+		if entry, ok := from.entries[key]; ok {
+			change.from = &entry
+		}
+		if entry, ok := to.entries[key]; ok {
+			change.to = &entry
+		}
+		switch {
+		case change.from == nil:
+			change.state = cosp.CodeObjectStateCreate
+		case change.to == nil:
+			change.state = cosp.CodeObjectStateDelete
+		case change.from.oid != change.to.oid:
+			change.state = cosp.CodeObjectStateModify
+		default:
+			continue
+		}
+		fromText, err := m.diffObjectText(langPvd, from, change.from)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: object %s cannot be read", change.from.oid), err)
+		}
+		toText, err := m.diffObjectText(langPvd, to, change.to)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: object %s cannot be read", change.to.oid), err)
+		}
+		fromFile, toFile := "/dev/null", "/dev/null"
+		if change.from != nil {
+			fromFile = "a/" + change.from.displayPath()
+		}
+		if change.to != nil {
+			toFile = "b/" + change.to.displayPath()
+		}
+		change.patch, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        diffLines(fromText),
+			B:        diffLines(toText),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  diffContextLines,
+		})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		ei, ej := changes[i].entry(), changes[j].entry()
+		if ei.displayPath() != ej.displayPath() {
+			return ei.displayPath() < ej.displayPath()
+		}
+		return ei.oname < ej.oname
+	})
+	return changes, nil
+}
+
+// diffLines splits the text in lines keeping the new lines, terminating the last line so that it is complete in the unified diffs.
+func diffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	lines := strings.SplitAfter(text, "\n")
+	return lines[:len(lines)-1]
+}
+
+// diffPatchString colors the unified diff of a change.
+func diffPatchString(patch string) string {
+	lines := strings.Split(strings.TrimSuffix(patch, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			lines[i] = common.FileText(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = common.KeywordText(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = common.CreateText(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = common.DeleteText(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffChangeMap gets the diff change map.
+func diffChangeMap(change diffChange) map[string]any {
+	entry := change.entry()
+	changeMap := map[string]any{
+		"state":     change.state,
+		"partition": entry.partition,
+		"oname":     entry.oname,
+		"code_id":   entry.codeID,
+		"patch":     change.patch,
+	}
+	if change.from != nil {
+		changeMap["from_oid"] = change.from.oid
+		changeMap["from_path"] = change.from.displayPath()
+	}
+	if change.to != nil {
+		changeMap["to_oid"] = change.to.oid
+		changeMap["to_path"] = change.to.displayPath()
+	}
+	return changeMap
+}
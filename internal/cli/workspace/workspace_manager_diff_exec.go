@@ -0,0 +1,124 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/permguard/permguard/internal/cli/common"
+)
+
+// ExecDiff shows the textual differences of the policies between two commits, or between a commit and the workspace.
+// An empty fromRef is the remote head and an empty toRef is the workspace.
+func (m *Manager) ExecDiff(fromRef, toRef string, out common.PrinterOutFunc) (map[string]any, error) {
+	fail := func(output map[string]any, err error) (map[string]any, error) {
+		return output, err
+	}
+	output := m.ExecPrintContext(nil, out)
+	if !m.isWorkspaceDir() {
+		return fail(output, m.raiseWrongWorkspaceDirError(out))
+	}
+
+	fileLock, err := m.tryLock()
+	if err != nil {
+		return fail(output, err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	headCtx, err := m.currentHeadContext()
+	if err != nil {
+		return fail(output, err)
+	}
+	langPvd, err := m.buildManifestLanguageProvider()
+	if err != nil {
+		return fail(output, err)
+	}
+
+	// Refresh the local area only when the workspace is compared, otherwise the workspace files are resolved from the existing code map
+	if toRef == "" {
+		if m.ctx.IsVerboseTerminalOutput() {
+			out(nil, "diff", "Refreshing the workspace to read the local policies.", nil, true)
+		}
+		refreshOutput, err := m.execInternalRefresh(true, out)
+		if err != nil {
+			return fail(refreshOutput, err)
+		}
+	}
+
+	// Resolve the source side
+	var from *diffSide
+	if fromRef == "" {
+		from, err = m.remoteDiffSide(headCtx)
+	} else {
+		commitID, commit, resolveErr := m.resolveDiffCommit(headCtx, fromRef)
+		if resolveErr != nil {
+			return fail(output, resolveErr)
+		}
+		from, err = m.commitDiffSide(commitID, commit)
+	}
+	if err != nil {
+		return fail(output, err)
+	}
+
+	// Resolve the destination side
+	var to *diffSide
+	if toRef == "" {
+		to, err = m.workspaceDiffSide()
+	} else {
+		commitID, commit, resolveErr := m.resolveDiffCommit(headCtx, toRef)
+		if resolveErr != nil {
+			return fail(output, resolveErr)
+		}
+		to, err = m.commitDiffSide(commitID, commit)
+	}
+	if err != nil {
+		return fail(output, err)
+	}
+
+	changes, err := m.diffSides(langPvd, from, to)
+	if err != nil {
+		return fail(output, err)
+	}
+
+	if m.ctx.IsTerminalOutput() {
+		if len(changes) == 0 {
+			out(nil, "", fmt.Sprintf("No differences found between %s and %s.", common.IDText(from.label), common.IDText(to.label)), nil, true)
+			return output, nil
+		}
+		out(nil, "", fmt.Sprintf("Your diff from %s to %s:\n", common.IDText(from.label), common.IDText(to.label)), nil, true)
+		for _, change := range changes {
+			entry := change.entry()
+			out(nil, "", fmt.Sprintf("%s %s (%s)", common.KeywordText(change.state), common.NameText(entry.oname), common.FileText(entry.displayPath())), nil, true)
+			if change.patch != "" {
+				out(nil, "", diffPatchString(change.patch), nil, true)
+			}
+			out(nil, "", "\n", nil, false)
+		}
+		out(nil, "", "total "+common.NumberText(len(changes)), nil, true)
+	} else if m.ctx.IsJSONOutput() {
+		changeMaps := make([]map[string]any, 0, len(changes))
+		for _, change := range changes {
+			changeMaps = append(changeMaps, diffChangeMap(change))
+		}
+		output = out(output, "diff", map[string]any{
+			"from":    from.label,
+			"to":      to.label,
+			"changes": changeMaps,
+		}, nil, true)
+	}
+	return output, nil
+}
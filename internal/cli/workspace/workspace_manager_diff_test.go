@@ -0,0 +1,199 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diffChanges runs the diff with the JSON output and returns the changes keyed by object name.
+func diffChanges(t *testing.T, w *testWorkspace, fromRef, toRef string) (map[string]any, map[string]map[string]any) {
+	t.Helper()
+	output, err := w.jsonManager(t).ExecDiff(fromRef, toRef, w.out)
+	require.NoError(t, err, "error should be nil")
+	diff := output["diff"].(map[string]any)
+	changes := map[string]map[string]any{}
+	for _, change := range diff["changes"].([]map[string]any) {
+		changes[change["oname"].(string)] = change
+	}
+	return diff, changes
+}
+
+// TestExecDiffResolveRefs tests the resolution of the refs of the diff.
+func TestExecDiffResolveRefs(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	first := w.commit(t, "add orders")
+	w.writeFile(t, "admin.cedar", testAdminPolicies)
+	second := w.commit(t, "add admin")
+
+	tests := []struct {
+		name     string
+		fromRef  string
+		toRef    string
+		from     string
+		to       string
+		hasError bool
+	}{
+		{name: "remote head and workspace", from: second, to: diffWorkspaceLabel},
+		{name: "head", fromRef: "HEAD", from: second, to: diffWorkspaceLabel},
+		{name: "head lower case", fromRef: "head", toRef: "HEAD", from: second, to: second},
+		{name: "full commit id", fromRef: first, toRef: second, from: first, to: second},
+		{name: "short commit id", fromRef: first[:len(first)-8], toRef: second[:len(second)-8], from: first, to: second},
+		{name: "ambiguous prefix", fromRef: "bafyrei", hasError: true},
+		{name: "unknown commit", fromRef: "0123456789abcdef", hasError: true},
+		{name: "unknown destination", fromRef: first, toRef: "zzzz", hasError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := w.jsonManager(t).ExecDiff(tt.fromRef, tt.toRef, w.out)
+			if tt.hasError {
+				assert.Error(t, err, "error should not be nil")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			diff := output["diff"].(map[string]any)
+			assert.Equal(t, tt.from, diff["from"], "from should be resolved")
+			assert.Equal(t, tt.to, diff["to"], "to should be resolved")
+		})
+	}
+}
+
+// TestExecDiffHeadWithoutCommits tests that HEAD cannot be resolved for a ledger without commits.
+func TestExecDiffHeadWithoutCommits(t *testing.T) {
+	w := newTestWorkspace(t)
+	_, err := w.jsonManager(t).ExecDiff("HEAD", "", w.out)
+	assert.ErrorContains(t, err, "has no commits", "error should report the missing commits")
+}
+
+// TestExecDiffChanges tests the added, removed and modified objects between two commits and the workspace.
+func TestExecDiffChanges(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	w.writeFile(t, "admin.cedar", testAdminPolicies)
+	first := w.commit(t, "add policies")
+	w.writeFile(t, "orders.cedar", `@id("view-orders")
+permit(principal, action == Action::"view", resource)
+when { context.authenticated };
+`)
+	w.removeFile(t, "admin.cedar")
+	w.writeFile(t, "audit.cedar", `@id("audit")
+forbid(principal, action == Action::"delete", resource);
+`)
+
+	_, changes := diffChanges(t, w, first, "")
+	require.Len(t, changes, 3, "diff should contain three changes")
+
+	modified := changes["view-orders"]
+	assert.Equal(t, "modify", modified["state"], "view-orders should be modified")
+	assert.Equal(t, "orders.cedar", modified["from_path"], "from path should be the workspace file")
+	assert.Equal(t, "orders.cedar", modified["to_path"], "to path should be the workspace file")
+	assert.NotEqual(t, modified["from_oid"], modified["to_oid"], "oids should differ")
+
+	added := changes["audit"]
+	assert.Equal(t, "create", added["state"], "audit should be created")
+	assert.NotContains(t, added, "from_oid", "created object should have no source")
+
+	removed := changes["admin"]
+	assert.Equal(t, "delete", removed["state"], "admin should be deleted")
+	assert.NotContains(t, removed, "to_oid", "deleted object should have no destination")
+
+	second := w.commit(t, "update policies")
+	_, changes = diffChanges(t, w, first, second)
+	assert.Len(t, changes, 3, "diff between the commits should contain the same changes")
+	_, changes = diffChanges(t, w, second, "")
+	assert.Empty(t, changes, "workspace should not differ from the last commit")
+	_, changes = diffChanges(t, w, second, first)
+	assert.Equal(t, "delete", changes["audit"]["state"], "reversed diff should delete the created object")
+	assert.Equal(t, "create", changes["admin"]["state"], "reversed diff should create the deleted object")
+}
+
+// TestExecDiffCommitsWithoutRefresh tests that the diff between two commits does not refresh the workspace.
+func TestExecDiffCommitsWithoutRefresh(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	first := w.commit(t, "add orders")
+	w.writeFile(t, "admin.cedar", testAdminPolicies)
+	second := w.commit(t, "add admin")
+	w.writeFile(t, "audit.cedar", `@id("audit")
+forbid(principal, action == Action::"delete", resource);
+`)
+
+	_, changes := diffChanges(t, w, first, second)
+	require.Len(t, changes, 1, "diff should contain one change")
+	assert.Equal(t, "admin", changes["admin"]["to_path"], "change should be reported by partition and name")
+
+	codeMap, err := w.m.cospMgr.ReadCodeSourceCodeMap()
+	require.NoError(t, err, "error should be nil")
+	assert.Empty(t, codeMap, "diff between commits should not refresh the code map")
+
+	_, changes = diffChanges(t, w, second, "")
+	assert.Equal(t, "create", changes["audit"]["state"], "diff with the workspace should read the local policies")
+	codeMap, err = w.m.cospMgr.ReadCodeSourceCodeMap()
+	require.NoError(t, err, "error should be nil")
+	assert.NotEmpty(t, codeMap, "diff with the workspace should refresh the code map")
+}
+
+// TestExecDiffPatch tests the unified patch of the changes.
+func TestExecDiffPatch(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	w.writeFile(t, "admin.cedar", testAdminPolicies)
+	first := w.commit(t, "add policies")
+	w.writeFile(t, "orders.cedar", `@id("view-orders")
+permit(principal, action == Action::"list", resource);
+`)
+	w.removeFile(t, "admin.cedar")
+
+	_, changes := diffChanges(t, w, first, "")
+	assert.Equal(t, `--- a/orders.cedar
++++ b/orders.cedar
+@@ -1,6 +1,6 @@
+ @id("view-orders")
+ permit (
+     principal,
+-    action == Action::"view",
++    action == Action::"list",
+     resource
+ );
+`, changes["view-orders"]["patch"], "patch should be the unified diff of the policy")
+	assert.Equal(t, `--- a/admin
++++ /dev/null
+@@ -1,6 +0,0 @@
+-@id("admin")
+-permit (
+-    principal == User::"root",
+-    action,
+-    resource
+-);
+`, changes["admin"]["patch"], "patch of a removed file should be reported by partition and name")
+
+	_, err := w.m.ExecDiff(first, "", w.out)
+	require.NoError(t, err, "error should be nil")
+	text := w.outputText()
+	assert.Contains(t, text, "modify", "terminal output should contain the state")
+	assert.Contains(t, text, "orders.cedar", "terminal output should contain the file")
+	assert.Contains(t, text, `+    action == Action::"list",`, "terminal output should contain the patch")
+	assert.Contains(t, text, "total", "terminal output should contain the total")
+
+	_, err = w.m.ExecDiff(first, first, w.out)
+	require.NoError(t, err, "error should be nil")
+	assert.Contains(t, w.outputText(), "No differences found", "terminal output should report no differences")
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// testOrdersPolicies are the policies of the orders file.
+	testOrdersPolicies = `@id("view-orders")
+permit(principal, action == Action::"view", resource);
+`
+	// testAdminPolicies are the policies of the admin file.
+	testAdminPolicies = `@id("admin")
+permit(principal == User::"root", action, resource);
+`
+)
+
+// historyCommits runs the history with the JSON output and returns the selected commits.
+func historyCommits(t *testing.T, w *testWorkspace, since time.Time, author, changedPath string) []map[string]any {
+	t.Helper()
+	output, err := w.jsonManager(t).ExecHistory(since, author, changedPath, w.out)
+	require.NoError(t, err, "error should be nil")
+	commits, _ := output["commits"].([]map[string]any)
+	return commits
+}
+
+// refreshCodeMap rebuilds the code map of the workspace as the refresh command does.
+func refreshCodeMap(t *testing.T, w *testWorkspace) {
+	t.Helper()
+	_, err := w.m.execInternalRefresh(true, w.out)
+	require.NoError(t, err, w.outputText())
+	w.outputText()
+}
+
+// commitMessages returns the messages of the commits.
+func commitMessages(commits []map[string]any) []string {
+	messages := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		messages = append(messages, commit["message"].(string))
+	}
+	return messages
+}
+
+// TestExecHistoryFilters tests the author, path and since filters of the history.
+func TestExecHistoryFilters(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	w.commit(t, "add orders")
+	w.setAuthor("Bob", "bob@example.com")
+	w.writeFile(t, "admin/admin.cedar", testAdminPolicies)
+	w.commit(t, "add admin")
+	refreshCodeMap(t, w)
+
+	tests := []struct {
+		name        string
+		since       time.Time
+		author      string
+		changedPath string
+		expected    []string
+	}{
+		{name: "no filters", expected: []string{"add admin", "add orders"}},
+		{name: "author name", author: "amy", expected: []string{"add orders"}},
+		{name: "author email case insensitive", author: "BOB@EXAMPLE", expected: []string{"add admin"}},
+		{name: "unknown author", author: "carl", expected: []string{}},
+		{name: "exact path", changedPath: "orders.cedar", expected: []string{"add orders"}},
+		{name: "relative path", changedPath: "./orders.cedar", expected: []string{"add orders"}},
+		{name: "directory", changedPath: "admin", expected: []string{"add admin"}},
+		{name: "glob", changedPath: "admin/*.cedar", expected: []string{"add admin"}},
+		{name: "unknown path", changedPath: "missing.cedar", expected: []string{}},
+		{name: "author and path", author: "amy", changedPath: "admin", expected: []string{}},
+		{name: "since past", since: time.Now().Add(-time.Hour), expected: []string{"add admin", "add orders"}},
+		{name: "since future", since: time.Now().Add(time.Hour), expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits := historyCommits(t, w, tt.since, tt.author, tt.changedPath)
+			assert.Equal(t, tt.expected, commitMessages(commits), "selected commits should match")
+		})
+	}
+}
+
+// TestExecHistoryChanges tests the changes reported for each commit of the history.
+func TestExecHistoryChanges(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	w.writeFile(t, "admin/admin.cedar", testAdminPolicies)
+	w.commit(t, "add policies")
+	w.removeFile(t, "admin/admin.cedar")
+	w.commit(t, "remove admin")
+	refreshCodeMap(t, w)
+
+	commits := historyCommits(t, w, time.Time{}, "", "")
+	require.Len(t, commits, 2, "history should contain two commits")
+
+	changes := func(commit map[string]any) map[string]string {
+		states := map[string]string{}
+		for _, change := range commit["changes"].([]map[string]any) {
+			states[change["path"].(string)] = change["state"].(string)
+		}
+		return states
+	}
+	// The removed file is no longer in the code map, its policy is reported by partition and name in every commit
+	assert.Equal(t, map[string]string{"admin": "delete"}, changes(commits[0]), "removed policy should be reported by partition and name")
+	assert.Equal(t, map[string]string{"orders.cedar": "create", "admin": "create"}, changes(commits[1]), "created policies should be reported by file")
+}
+
+// TestExecHistoryWithoutCodeMap tests that the history does not refresh the workspace and reports the changes by partition and name when the code map is missing.
+func TestExecHistoryWithoutCodeMap(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	w.commit(t, "add orders")
+	w.writeFile(t, "admin/admin.cedar", testAdminPolicies)
+
+	commits := historyCommits(t, w, time.Time{}, "", "")
+	require.Len(t, commits, 1, "history should contain one commit")
+	changes := commits[0]["changes"].([]map[string]any)
+	require.Len(t, changes, 1, "commit should contain one change")
+	assert.Equal(t, "view-orders", changes[0]["path"], "change should be reported by partition and name")
+	assert.Empty(t, historyCommits(t, w, time.Time{}, "", "orders.cedar"), "file path should not match without the code map")
+
+	codeMap, err := w.m.cospMgr.ReadCodeSourceCodeMap()
+	require.NoError(t, err, "error should be nil")
+	assert.Empty(t, codeMap, "history should not refresh the code map")
+}
+
+// TestMatchCommitChangesPath tests the matching of the changed files.
+func TestMatchCommitChangesPath(t *testing.T) {
+	changes := []commitChange{
+		{partition: "/", oname: "view-orders", path: "policies/orders.cedar"},
+		{partition: "/", oname: "root-admin"},
+	}
+	tests := []struct {
+		pattern  string
+		expected bool
+	}{
+		{pattern: "policies/orders.cedar", expected: true},
+		{pattern: "./policies/orders.cedar", expected: true},
+		{pattern: "policies", expected: true},
+		{pattern: "policies/", expected: true},
+		{pattern: "policies/*.cedar", expected: true},
+		{pattern: "*.cedar", expected: false},
+		{pattern: "pol", expected: false},
+		{pattern: "root-admin", expected: true},
+		{pattern: "other.cedar", expected: false},
+		{pattern: "[", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchCommitChangesPath(changes, tt.pattern), "path match should be as expected")
+		})
+	}
+}
+
+// TestBuildPlanCommitMessage tests the message and the identity of the commit built by apply.
+func TestBuildPlanCommitMessage(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	w.setAuthor("Amy", "")
+	w.commit(t, "  add orders\n")
+
+	commits := historyCommits(t, w, time.Time{}, "", "")
+	require.Len(t, commits, 1, "history should contain one commit")
+	assert.Equal(t, "add orders", commits[0]["message"], "message should be trimmed")
+	assert.Equal(t, "Amy", commits[0]["author"], "author should be the commit identity")
+	assert.Equal(t, "Amy", commits[0]["committer"], "committer should be the commit identity")
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+	"github.com/permguard/permguard/pkg/authz/languages"
+)
+
+// lintLocal runs the lint pass of the languages over the source files of the workspace.
+func (m *Manager) lintLocal() ([]languages.LintFinding, error) {
+	langPvd, err := m.buildManifestLanguageProvider()
+	if err != nil {
+		return nil, err
+	}
+	codeFiles, _, err := m.scanSourceCodeFiles(langPvd)
+	if err != nil {
+		return nil, err
+	}
+	// Sources are grouped by language so that collisions across partitions are detected.
+	langSources := map[string][]languages.LintSource{}
+	langProfiles := map[string]string{}
+	wkdir := m.ctx.WorkDir()
+	for _, codeFile := range codeFiles {
+		profileKey, err := langPvd.ProfileKeyByPartition(codeFile.Partition)
+		if err != nil {
+			return nil, err
+		}
+		lang, err := langPvd.Language(profileKey)
+		if err != nil {
+			return nil, err
+		}
+		data, _, err := m.persMgr.ReadFile(persistence.WorkspaceDir, codeFile.Path, false)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := langProfiles[lang.Name]; !exists {
+			langProfiles[lang.Name] = profileKey
+		}
+		langSources[lang.Name] = append(langSources[lang.Name], languages.LintSource{
+			Partition: codeFile.Partition,
+			Path:      strings.TrimPrefix(codeFile.Path, wkdir),
+			Schema:    codeFile.Kind == cosp.CodeFileOfSchemaType,
+			Content:   data,
+		})
+	}
+	langNames := make([]string, 0, len(langSources))
+	for langName := range langSources {
+		langNames = append(langNames, langName)
+	}
+	sort.Strings(langNames)
+	findings := []languages.LintFinding{}
+	for _, langName := range langNames {
+		absLang, err := langPvd.AbstractLanguage(langProfiles[langName])
+		if err != nil {
+			return nil, err
+		}
+		lang, err := langPvd.Language(langProfiles[langName])
+		if err != nil {
+			return nil, err
+		}
+		langFindings, err := absLang.LintSources(lang, langSources[langName])
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: failed to lint the %s sources", langName), err)
+		}
+		findings = append(findings, langFindings...)
+	}
+	return findings, nil
+}
+
+// countLintErrors counts the lint findings with the error severity.
+func countLintErrors(findings []languages.LintFinding) int {
+	count := 0
+	for _, finding := range findings {
+		if finding.Severity == languages.LintSeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// lintFindingPosition formats the file position of a lint finding.
+func lintFindingPosition(finding *languages.LintFinding) string {
+	switch {
+	case finding.Line > 0 && finding.Column > 0:
+		return fmt.Sprintf("%s:%d:%d", finding.Path, finding.Line, finding.Column)
+	case finding.Line > 0:
+		return fmt.Sprintf("%s:%d", finding.Path, finding.Line)
+	}
+	return finding.Path
+}
+
+// buildOutputForLintFindings builds the output for the lint findings.
+func buildOutputForLintFindings(findings []languages.LintFinding, m *Manager, out common.PrinterOutFunc, output map[string]any) map[string]any {
+	if output == nil {
+		output = map[string]any{}
+	}
+	if m.ctx.IsJSONOutput() {
+		lintOut := make([]map[string]any, 0, len(findings))
+		for _, finding := range findings {
+			lintOut = append(lintOut, map[string]any{
+				"rule":      finding.Rule,
+				"severity":  finding.Severity,
+				"partition": finding.Partition,
+				"path":      finding.Path,
+				"line":      finding.Line,
+				"column":    finding.Column,
+				"policy_id": finding.PolicyID,
+				"message":   finding.Message,
+			})
+		}
+		output["lint"] = lintOut
+		return output
+	}
+	if len(findings) == 0 {
+		return output
+	}
+	if len(findings) == 1 {
+		out(nil, "", "The lint pass reported the following finding:\n", nil, true)
+	} else {
+		out(nil, "", fmt.Sprintf("The lint pass reported the following %s findings:\n", common.NumberText(len(findings))), nil, true)
+	}
+	for _, finding := range findings {
+		severity := common.KeywordText(finding.Severity)
+		if finding.Severity == languages.LintSeverityError {
+			severity = common.LogErrorText(finding.Severity)
+		}
+		out(nil, "", fmt.Sprintf("  - %s: %s [%s] %s", common.FileText(lintFindingPosition(&finding)), severity, common.NameText(finding.Rule), finding.Message), nil, true)
+	}
+	out(nil, "", "", nil, true)
+	return output
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+)
+
+func TestExecValidateLintErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies string
+		valid    bool
+	}{
+		{
+			name:     "without errors",
+			policies: testOrdersPolicies,
+			valid:    true,
+		},
+		{
+			name: "dead permit",
+			policies: testOrdersPolicies + `@id("deny-all")
+forbid(principal, action, resource);
+`,
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := newTestWorkspace(t)
+			w.writeFile(t, "orders.cedar", test.policies)
+			_, err := w.m.ExecValidate(w.out)
+			text := w.outputText()
+			if test.valid {
+				require.NoError(t, err, "error should be nil")
+				assert.Contains(t, text, "validated successfully", "workspace should be valid")
+				return
+			}
+			assert.ErrorContains(t, err, "1 lint errors found", "lint errors should fail the validation")
+			assert.NotContains(t, text, "validated successfully", "workspace should not be valid")
+			assert.Contains(t, text, "Please fix the lint errors to proceed.", "lint errors should be reported")
+
+			output, err := w.jsonManager(t).ExecValidate(w.out)
+			assert.Error(t, err, "error should not be nil")
+			findings, _ := output["lint"].([]map[string]any)
+			severities := []any{}
+			for _, finding := range findings {
+				severities = append(severities, finding["severity"])
+			}
+			assert.Contains(t, severities, languages.LintSeverityError, "error finding should be in the output")
+		})
+	}
+}
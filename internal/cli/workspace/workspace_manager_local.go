@@ -24,6 +24,7 @@ import (
 
 	"github.com/permguard/permguard/internal/cli/workspace/cosp"
 	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+	"github.com/permguard/permguard/pkg/authz/languages"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/authz/languages/types"
 	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
@@ -199,7 +200,11 @@ func (m *Manager) blobifyPermSchemaFile(langPvd *ManifestLanguageProvider, parti
 	if err != nil {
 		return nil, err
 	}
-	multiSecObj, err := absLang.CreateSchemaBlobObjects(lang, partition, path, data)
+	profileKey, err := langPvd.ProfileKeyByPartition(file.Partition)
+	if err != nil {
+		return nil, err
+	}
+	multiSecObj, err := absLang.CreateSchemaBlobObjects(lang, partition, path, data, langPvd.SchemaStrict(profileKey))
 	if err != nil {
 		codeFile := cosp.CodeFile{
 			Partition: partition,
@@ -285,6 +290,104 @@ func (m *Manager) buildCodeFileFromSection(secObj *objects.SectionObject, inputF
 	return codeFile
 }
 
+// validateStrictPolicies marks the policies that do not conform to the schema of the partitions in strict mode.
+func (m *Manager) validateStrictPolicies(langPvd *ManifestLanguageProvider, codeFiles []cosp.CodeFile, partitionSchemaData, policyFilesData map[string][]byte, blobifiedCodeFiles []cosp.CodeFile) error {
+	wkdir := m.ctx.WorkDir()
+	for _, file := range codeFiles {
+		data, isPolicy := policyFilesData[file.Path]
+		schemaData, hasSchema := partitionSchemaData[file.Partition]
+		if !isPolicy || !hasSchema {
+			continue
+		}
+		profileKey, err := langPvd.ProfileKeyByPartition(file.Partition)
+		if err != nil {
+			return err
+		}
+		if !langPvd.SchemaStrict(profileKey) {
+			continue
+		}
+		absLang, err := langPvd.AbstractLanguage(profileKey)
+		if err != nil {
+			return err
+		}
+		lang, err := langPvd.Language(profileKey)
+		if err != nil {
+			return err
+		}
+		policyErrors, err := absLang.ValidatePolicies(lang, schemaData, file.Path, data)
+		if err != nil {
+			// Syntax errors are already reported by the blobification of the file.
+			continue
+		}
+		path := strings.TrimPrefix(file.Path, wkdir)
+		for i := range blobifiedCodeFiles {
+			codeFile := &blobifiedCodeFiles[i]
+			errs, exists := policyErrors[codeFile.OName]
+			if codeFile.HasErrors || codeFile.Path != path || codeFile.Partition != file.Partition || !exists {
+				continue
+			}
+			codeFile.HasErrors = true
+			codeFile.Error = fmt.Sprintf("language: the policy does not conform to the schema of partition '%s': %s", file.Partition, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateSourceRelations marks the objects whose relations with the other source files of the workspace are invalid, such as the template links to missing templates.
+func (m *Manager) validateSourceRelations(langPvd *ManifestLanguageProvider, codeFiles []cosp.CodeFile, policyFilesData map[string][]byte, blobifiedCodeFiles []cosp.CodeFile) error {
+	// Sources are grouped by language so that the relations across partitions are validated.
+	langSources := map[string][]languages.LintSource{}
+	langProfiles := map[string]string{}
+	wkdir := m.ctx.WorkDir()
+	for _, file := range codeFiles {
+		data, isPolicy := policyFilesData[file.Path]
+		if !isPolicy {
+			continue
+		}
+		profileKey, err := langPvd.ProfileKeyByPartition(file.Partition)
+		if err != nil {
+			return err
+		}
+		lang, err := langPvd.Language(profileKey)
+		if err != nil {
+			return err
+		}
+		if _, exists := langProfiles[lang.Name]; !exists {
+			langProfiles[lang.Name] = profileKey
+		}
+		langSources[lang.Name] = append(langSources[lang.Name], languages.LintSource{
+			Partition: file.Partition,
+			Path:      strings.TrimPrefix(file.Path, wkdir),
+			Content:   data,
+		})
+	}
+	for langName, sources := range langSources {
+		absLang, err := langPvd.AbstractLanguage(langProfiles[langName])
+		if err != nil {
+			return err
+		}
+		lang, err := langPvd.Language(langProfiles[langName])
+		if err != nil {
+			return err
+		}
+		relationErrors, err := absLang.ValidateSourceRelations(lang, sources)
+		if err != nil {
+			return errors.Join(fmt.Errorf("cli: failed to validate the %s sources", langName), err)
+		}
+		for _, relationError := range relationErrors {
+			for i := range blobifiedCodeFiles {
+				codeFile := &blobifiedCodeFiles[i]
+				if codeFile.HasErrors || codeFile.Partition != relationError.Partition || codeFile.Path != relationError.Path || codeFile.OName != relationError.ObjectName {
+					continue
+				}
+				codeFile.HasErrors = true
+				codeFile.Error = relationError.Message
+			}
+		}
+	}
+	return nil
+}
+
 // blobifyLocal processes source files and converts them into blobs, handling both code and schema types.
 // It ensures that only one schema file exists per partition and constructs a tree object to represent the structure.
 // Pre-condition: the code source area must be clean before calling this function.
@@ -292,6 +395,8 @@ func (m *Manager) buildCodeFileFromSection(secObj *objects.SectionObject, inputF
 func (m *Manager) blobifyLocal(codeFiles []cosp.CodeFile, langPvd *ManifestLanguageProvider) ([]objects.CommitProfile, string, []cosp.CodeFile, error) {
 	blobifiedCodeFiles := []cosp.CodeFile{}
 	partitionSchemas := map[string]int{}
+	partitionSchemaData := map[string][]byte{}
+	policyFilesData := map[string][]byte{}
 
 	for _, file := range codeFiles {
 		wkdir := m.ctx.WorkDir()
@@ -311,6 +416,7 @@ func (m *Manager) blobifyLocal(codeFiles []cosp.CodeFile, langPvd *ManifestLangu
 		// Process code files using the language provider
 		switch file.Kind {
 		case cosp.CodeFileTypeOfCodeType:
+			policyFilesData[path] = data
 			blobifiedCodeFiles, err = m.blobifyLanguageFile(langPvd, partition, path, data, file, wkdir, mode, blobifiedCodeFiles)
 			if err != nil {
 				return nil, "", nil, err
@@ -329,6 +435,7 @@ func (m *Manager) blobifyLocal(codeFiles []cosp.CodeFile, langPvd *ManifestLangu
 				}
 				blobifiedCodeFiles = append(blobifiedCodeFiles, codeFile)
 			} else {
+				partitionSchemaData[partition] = data
 				blobifiedCodeFiles, err = m.blobifyPermSchemaFile(langPvd, partition, path, wkdir, mode, blobifiedCodeFiles, data, file)
 				if err != nil {
 					return nil, "", nil, err
@@ -369,6 +476,16 @@ func (m *Manager) blobifyLocal(codeFiles []cosp.CodeFile, langPvd *ManifestLangu
 		}
 	}
 
+	// Validate the policies against the schema of the partitions in strict mode
+	if err := m.validateStrictPolicies(langPvd, codeFiles, partitionSchemaData, policyFilesData, blobifiedCodeFiles); err != nil {
+		return nil, "", nil, err
+	}
+
+	// Validate the relations between the source files of all the partitions
+	if err := m.validateSourceRelations(langPvd, codeFiles, policyFilesData, blobifiedCodeFiles); err != nil {
+		return nil, "", nil, err
+	}
+
 	// Save code source map
 	var err error
 	if err = m.cospMgr.SaveCodeSourceCodeMap(blobifiedCodeFiles); err != nil {
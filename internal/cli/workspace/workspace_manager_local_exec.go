@@ -247,6 +247,24 @@ func (m *Manager) execInternalValidate(internal bool, out common.PrinterOutFunc)
 			out(nil, "validate", "Validation completed successfully.", nil, true)
 		}
 		if !internal {
+			if m.ctx.IsVerboseTerminalOutput() {
+				out(nil, "validate", "Lint pass initiated.", nil, true)
+			}
+			findings, err := m.lintLocal()
+			if err != nil {
+				if m.ctx.IsVerboseTerminalOutput() {
+					out(nil, "validate", "Lint pass could not be completed.", nil, true)
+				}
+				return fail(output, err)
+			}
+			output = buildOutputForLintFindings(findings, m, out, output)
+			if lintErrors := countLintErrors(findings); lintErrors > 0 {
+				if m.ctx.IsVerboseTerminalOutput() {
+					out(nil, "validate", "Lint pass failed. Error findings detected.", nil, true)
+				}
+				out(nil, "", "Please fix the lint errors to proceed.", nil, true)
+				return fail(output, fmt.Errorf("cli: %d lint errors found in the workspace. please check the logs for more details", lintErrors))
+			}
 			out(nil, "", "Your workspace has been validated successfully.", nil, true)
 		}
 		return output, nil
@@ -0,0 +1,56 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlobifyLocalTemplateLinks tests that the template links are validated against the templates of the workspace.
+func TestBlobifyLocalTemplateLinks(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, "share.cedar", `@id("share-order")
+permit(principal == ?principal, action, resource);
+`)
+	w.writeFile(t, "template-links.json", `[
+  {"template_id": "share-order", "link_id": "share-order-amy", "args": {"?principal": {"type": "User", "id": "amy"}}},
+  {"template_id": "share-report", "link_id": "share-report-amy", "args": {"?principal": {"type": "User", "id": "amy"}}}
+]`)
+
+	_, err := w.m.execInternalRefresh(true, w.out)
+	assert.Error(t, err, "refresh should fail for a link to a missing template")
+	codeMap, err := w.m.cospMgr.ReadCodeSourceCodeMap()
+	require.NoError(t, err, "error should be nil")
+	linkErrors := map[string]string{}
+	for _, codeFile := range codeMap {
+		if codeFile.HasErrors {
+			linkErrors[codeFile.OName] = codeFile.Error
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"share-report-amy": "cedar: template share-report of template link share-report-amy does not exist",
+	}, linkErrors, "only the link to the missing template should have errors")
+
+	w.writeFile(t, "template-links.json", `[
+  {"template_id": "share-order", "link_id": "share-order-amy", "args": {"?principal": {"type": "User", "id": "amy"}}}
+]`)
+	_, err = w.m.execInternalRefresh(true, w.out)
+	require.NoError(t, err, w.outputText())
+}
@@ -0,0 +1,403 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	azpapv1 "github.com/permguard/permguard/internal/agents/services/pap/endpoints/api/v1"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+// notpTestService is an in-memory PAP service storing the objects and the ref of the test ledger.
+type notpTestService struct {
+	azpapv1.PAPService
+	mu      sync.Mutex
+	ref     string
+	objects map[string][]byte
+	staged  map[string][]pap.ObjectState
+	txCount int
+}
+
+// readObject reads an object of the service, the caller must hold the lock.
+func (s *notpTestService) readObject(oid string) (*objects.Object, error) {
+	content, ok := s.objects[oid]
+	if !ok {
+		return nil, nil
+	}
+	return objects.NewObject(content)
+}
+
+// commitIDs returns the ids of the commits from the to commit back to the from commit.
+func (s *notpTestService) commitIDs(to, from string) ([]string, error) {
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return nil, err
+	}
+	_, history, err := objMng.BuildCommitHistory(to, from, true, s.readObject)
+	if err != nil {
+		return nil, err
+	}
+	commitIDs := []string{}
+	for _, commit := range history {
+		obj, err := objects.CreateCommitObject(&commit)
+		if err != nil {
+			return nil, err
+		}
+		commitIDs = append(commitIDs, obj.OID())
+	}
+	return commitIDs, nil
+}
+
+// FetchLedgersPage returns no ledgers, so that the ledger name is not synced.
+func (s *notpTestService) FetchLedgersPage(context.Context, int32, int32, int64, map[string]any, *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error) {
+	return nil, nil, azstorage.ErrNotFound
+}
+
+// PushAdvertise starts a push transaction unless the ledger has diverged or is up to date.
+func (s *notpTestService) PushAdvertise(_ context.Context, req *pap.PushAdvertiseRequest) (*pap.PushAdvertiseResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &pap.PushAdvertiseResponse{
+		ServerCommit: s.ref,
+		IsUpToDate:   s.ref == req.RefCommit,
+		HasConflicts: s.ref != req.RefCommit && s.ref != req.RefPrevCommit,
+	}
+	if !resp.IsUpToDate && !resp.HasConflicts {
+		s.txCount++
+		resp.TxID = fmt.Sprintf("tx-%d", s.txCount)
+		s.staged[resp.TxID] = []pap.ObjectState{}
+	}
+	return resp, nil
+}
+
+// PushTransfer stages the objects of a push transaction, committing it on the last transfer.
+func (s *notpTestService) PushTransfer(_ context.Context, req *pap.PushTransferRequest) (*pap.PushTransferResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	staged, ok := s.staged[req.TxID]
+	if !ok {
+		return nil, azstorage.ErrInvalidInput
+	}
+	staged = append(staged, req.Objects...)
+	s.staged[req.TxID] = staged
+	if req.IsLast {
+		if err := s.commit(req.TxID, req.RemoteCommitID, req.ExpectedServerCommit); err != nil {
+			return nil, err
+		}
+		return &pap.PushTransferResponse{Committed: true, ConfirmedObjects: int64(len(staged))}, nil
+	}
+	return &pap.PushTransferResponse{ConfirmedObjects: int64(len(staged))}, nil
+}
+
+// PushCommit publishes the staged objects and moves the ref to the pushed commit.
+func (s *notpTestService) PushCommit(_ context.Context, req *pap.PushCommitRequest) (*pap.PushCommitResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.commit(req.TxID, req.RemoteCommitID, req.ExpectedServerCommit); err != nil {
+		return nil, err
+	}
+	return &pap.PushCommitResponse{Committed: true}, nil
+}
+
+// commit publishes the staged objects of a transaction and moves the ref, the caller must hold the lock.
+func (s *notpTestService) commit(txID, remoteCommitID, expectedServerCommit string) error {
+	staged, ok := s.staged[txID]
+	if !ok || expectedServerCommit != s.ref {
+		return azstorage.ErrConflict
+	}
+	for _, obj := range staged {
+		if err := objects.VerifyOID(obj.OID, obj.Content); err != nil {
+			return err
+		}
+		s.objects[obj.OID] = obj.Content
+	}
+	delete(s.staged, txID)
+	s.ref = remoteCommitID
+	return nil
+}
+
+// PullState returns the ref of the ledger and the number of commits to pull.
+func (s *notpTestService) PullState(_ context.Context, req *pap.PullStateRequest) (*pap.PullStateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	commitIDs, err := s.commitIDs(s.ref, req.RefCommit)
+	if err != nil {
+		return nil, err
+	}
+	return &pap.PullStateResponse{
+		ServerCommit:    s.ref,
+		NumberOfCommits: uint32(len(commitIDs)),
+		IsUpToDate:      s.ref == req.RefCommit,
+	}, nil
+}
+
+// PullNegotiate returns the commits to pull.
+func (s *notpTestService) PullNegotiate(_ context.Context, req *pap.PullNegotiateRequest) (*pap.PullNegotiateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	commitIDs, err := s.commitIDs(req.RemoteCommitID, req.LocalCommitID)
+	if err != nil {
+		return nil, err
+	}
+	return &pap.PullNegotiateResponse{CommitIDs: commitIDs, HaveCommitIDs: []string{}}, nil
+}
+
+// PullObjects returns the objects of a commit.
+func (s *notpTestService) PullObjects(_ context.Context, req *pap.PullObjectsRequest, _ map[string]struct{}) (*pap.PullObjectsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return nil, err
+	}
+	commitObj, err := s.readObject(req.CommitID)
+	if err != nil || commitObj == nil {
+		return nil, azstorage.ErrNotFound
+	}
+	commit, err := objects.ConvertObjectToCommit(commitObj)
+	if err != nil {
+		return nil, err
+	}
+	oids := []string{commit.Manifest().String()}
+	for _, profile := range commit.Profiles() {
+		treeObj, err := s.readObject(profile.Tree().String())
+		if err != nil || treeObj == nil {
+			return nil, azstorage.ErrNotFound
+		}
+		tree, err := objects.ConvertObjectToTree(treeObj)
+		if err != nil {
+			return nil, err
+		}
+		oids = append(oids, treeObj.OID())
+		for _, entry := range tree.Entries() {
+			oids = append(oids, entry.OID())
+		}
+	}
+	oids = append(oids, req.CommitID)
+	objs := []pap.ObjectState{}
+	for _, oid := range oids {
+		obj, err := s.readObject(oid)
+		if err != nil || obj == nil {
+			return nil, azstorage.ErrNotFound
+		}
+		info, err := objMng.ObjectInfo(obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, pap.ObjectState{OID: oid, OType: info.Type(), Content: obj.Content()})
+	}
+	return &pap.PullObjectsResponse{Objects: objs}, nil
+}
+
+// unaryPAPServer serves the PAP without the notp stream, as the servers released before it.
+type unaryPAPServer struct {
+	*azpapv1.PAPServer
+}
+
+// NOTPStream rejects the notp stream as not implemented.
+func (unaryPAPServer) NOTPStream(grpc.BidiStreamingServer[azpapv1.PackMessage, azpapv1.PackMessage]) error {
+	return status.Error(codes.Unimplemented, "method NOTPStream not implemented")
+}
+
+// startNOTPTestServer serves the PAP of the in-memory service on a local port and returns the port.
+func startNOTPTestServer(t *testing.T, service *notpTestService) int {
+	t.Helper()
+	papServer, err := azpapv1.NewPAPServer(nil, service)
+	require.NoError(t, err, "error should be nil")
+	return serveNOTPTestServer(t, papServer)
+}
+
+// serveNOTPTestServer serves a PAP server on a local port and returns the port.
+func serveNOTPTestServer(t *testing.T, papServer azpapv1.V1PAPServiceServer) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "error should be nil")
+	grpcServer := grpc.NewServer()
+	azpapv1.RegisterV1PAPServiceServer(grpcServer, papServer)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestNOTPStreamPushPull(t *testing.T) {
+	service := &notpTestService{
+		ref:     objects.ZeroOID,
+		objects: map[string][]byte{},
+		staged:  map[string][]pap.ObjectState{},
+	}
+	papPort := startNOTPTestServer(t, service)
+
+	amy := newTestWorkspaceWithPAPPort(t, papPort)
+	amy.writeFile(t, "orders.cedar", testOrdersPolicies)
+	_, err := amy.m.ExecApply("add orders", amy.out)
+	require.NoError(t, err, amy.outputText())
+	amyHead, err := amy.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	assert.NotEqual(t, objects.ZeroOID, service.ref, "push should move the ledger ref")
+	assert.Equal(t, service.ref, amyHead.remoteCommitID, "apply should pull the pushed commit")
+	assert.Empty(t, service.staged, "push transaction should be committed")
+
+	bob := newTestWorkspaceWithPAPPort(t, papPort)
+	_, err = bob.m.ExecPull(bob.out)
+	require.NoError(t, err, bob.outputText())
+	bobHead, err := bob.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	assert.Equal(t, service.ref, bobHead.remoteCommitID, "pull should fetch the ledger ref")
+	for oid := range service.objects {
+		_, err := bob.m.cospMgr.ReadObject(oid)
+		assert.NoError(t, err, "pulled object %s should be stored", oid)
+	}
+
+	bob.setAuthor("Bob", "bob@example.com")
+	bob.writeFile(t, "admin.cedar", testAdminPolicies)
+	_, err = bob.m.ExecApply("add admin", bob.out)
+	require.NoError(t, err, bob.outputText())
+	secondCommitID := service.ref
+	assert.NotEqual(t, bobHead.remoteCommitID, secondCommitID, "second push should move the ledger ref")
+
+	amy.writeFile(t, "ship.cedar", "@id(\"ship-orders\")\npermit(principal, action == Action::\"ship\", resource);\n")
+	_, err = amy.m.ExecApply("diverge", amy.out)
+	assert.ErrorContains(t, err, "remote ledger has diverged", "push on a diverged ledger should fail")
+	assert.Equal(t, secondCommitID, service.ref, "rejected push should not move the ledger ref")
+	amy.removeFile(t, "ship.cedar")
+
+	_, err = amy.m.ExecPull(amy.out)
+	require.NoError(t, err, amy.outputText())
+	amyHead, err = amy.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	assert.Equal(t, secondCommitID, amyHead.remoteCommitID, "pull should fetch the commit pushed by another workspace")
+	commits := historyCommits(t, amy, time.Time{}, "", "")
+	assert.Equal(t, []string{"add admin", "add orders"}, commitMessages(commits), "history should hold both commits")
+}
+
+func TestNOTPStreamUnimplementedFallsBackToUnary(t *testing.T) {
+	service := &notpTestService{
+		ref:     objects.ZeroOID,
+		objects: map[string][]byte{},
+		staged:  map[string][]pap.ObjectState{},
+	}
+	papServer, err := azpapv1.NewPAPServer(nil, service)
+	require.NoError(t, err, "error should be nil")
+	papPort := serveNOTPTestServer(t, unaryPAPServer{PAPServer: papServer})
+
+	amy := newTestWorkspaceWithPAPPort(t, papPort)
+	amy.writeFile(t, "orders.cedar", testOrdersPolicies)
+	_, err = amy.m.ExecApply("add orders", amy.out)
+	require.NoError(t, err, amy.outputText())
+	amyHead, err := amy.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	assert.NotEqual(t, objects.ZeroOID, service.ref, "unary push should move the ledger ref")
+	assert.Equal(t, service.ref, amyHead.remoteCommitID, "apply should pull the pushed commit")
+	assert.Empty(t, service.staged, "unary push transaction should be committed")
+
+	bob := newTestWorkspaceWithPAPPort(t, papPort)
+	_, err = bob.m.ExecPull(bob.out)
+	require.NoError(t, err, bob.outputText())
+	bobHead, err := bob.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	assert.Equal(t, service.ref, bobHead.remoteCommitID, "unary pull should fetch the ledger ref")
+	for oid := range service.objects {
+		_, err := bob.m.cospMgr.ReadObject(oid)
+		assert.NoError(t, err, "pulled object %s should be stored", oid)
+	}
+
+	amy.writeFile(t, "ship.cedar", "@id(\"ship-orders\")\npermit(principal, action == Action::\"ship\", resource);\n")
+	_, err = amy.m.ExecApply("add ship", amy.out)
+	require.NoError(t, err, amy.outputText())
+	_, err = bob.m.ExecPull(bob.out)
+	require.NoError(t, err, bob.outputText())
+	bobHead, err = bob.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	assert.Equal(t, service.ref, bobHead.remoteCommitID, "unary pull should fetch the commit on top of the held one")
+	commits := historyCommits(t, bob, time.Time{}, "", "")
+	assert.Equal(t, []string{"add ship", "add orders"}, commitMessages(commits), "history should hold both commits")
+}
+
+// testPushCommit creates a root commit object with a single profile.
+func testPushCommit(t *testing.T, tree, manifest string, at time.Time, message string) *objects.Object {
+	t.Helper()
+	profile, err := objects.NewCommitProfile("default/default", objects.CID(tree))
+	require.NoError(t, err, "error should be nil")
+	commit, err := objects.NewCommit([]objects.CommitProfile{*profile}, objects.CID(manifest), objects.NewNullableString(nil), "Amy <amy@example.com>", at, "Amy <amy@example.com>", at, message)
+	require.NoError(t, err, "error should be nil")
+	commitObj, err := objects.CreateCommitObject(commit)
+	require.NoError(t, err, "error should be nil")
+	return commitObj
+}
+
+func TestPushResumeCommit(t *testing.T) {
+	w := newTestWorkspace(t)
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err, "error should be nil")
+	header, err := objects.NewObjectHeader(objects.TreeDataTypePolicy, nil)
+	require.NoError(t, err, "error should be nil")
+	blob, err := objMng.CreateBlobObject(header, []byte("manifest"))
+	require.NoError(t, err, "error should be nil")
+	at := time.Unix(1700000000, 0)
+	interrupted := testPushCommit(t, blob.OID(), blob.OID(), at, "add orders")
+	_, err = w.m.cospMgr.SaveObject(interrupted.OID(), interrupted.Content())
+	require.NoError(t, err, "error should be nil")
+	unsaved := testPushCommit(t, blob.OID(), blob.OID(), at.Add(time.Hour), "add orders")
+
+	tests := []struct {
+		name     string
+		message  string
+		key      string
+		commitID string
+		resumed  bool
+	}{
+		{name: "same change", message: "add orders", key: "key", commitID: interrupted.OID(), resumed: true},
+		{name: "different message", message: "add shipping", key: "key", commitID: interrupted.OID()},
+		{name: "different objects", message: "add orders", key: "other", commitID: interrupted.OID()},
+		{name: "commit not saved", message: "add orders", key: "key", commitID: unsaved.OID()},
+	}
+	for _, test := range tests {
+		commitObj := testPushCommit(t, blob.OID(), blob.OID(), at.Add(time.Minute), test.message)
+		resume, err := azwkscommon.NewTransferResumeInfo(test.key, "tx-1", test.commitID, 3)
+		require.NoError(t, err, test.name)
+		f := &pushFollower{
+			m:           w.m,
+			commitObj:   commitObj,
+			resume:      resume,
+			transferKey: "key",
+			objects:     []pap.ObjectState{{OID: commitObj.OID(), OType: objects.ObjectTypeCommit, Content: commitObj.Content()}},
+		}
+		require.NoError(t, f.resumeCommit(), test.name)
+		expected := commitObj
+		if test.resumed {
+			expected = interrupted
+		}
+		assert.Equal(t, expected.OID(), f.commitObj.OID(), test.name)
+		assert.Equal(t, expected.OID(), f.objects[0].OID, test.name)
+	}
+}
@@ -22,13 +22,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 
 	"github.com/permguard/permguard/internal/cli/common"
 	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
 )
 
 // objectsInfos retrieves and filters object metadata based on object type.
@@ -104,11 +107,179 @@ func (m *Manager) commitString(oid string, commit *objects.Commit) (string, erro
 		fmt.Fprintf(&sb, "    - %s: %s\n", common.NameText(profile.Key()), common.IDText(profile.Tree().String()))
 	}
 	fmt.Fprintf(&sb, "  - %s: %s\n", common.KeywordText("manifest"), common.IDText(commit.Manifest().String()))
+	if author := metadata.Author(); author != "" {
+		fmt.Fprintf(&sb, "  - Author: %s\n", common.NameText(author))
+	}
+	if committer := metadata.Committer(); committer != "" {
+		fmt.Fprintf(&sb, "  - Committer: %s\n", common.NameText(committer))
+	}
 	fmt.Fprintf(&sb, "  - Committer date: %s\n", common.DateText(committerTimestamp))
 	fmt.Fprintf(&sb, "  - Author date: %s", common.DateText(authorTimestamp))
+	if message := commit.Message(); message != "" {
+		fmt.Fprintf(&sb, "\n  - Message: %s", message)
+	}
 	return sb.String(), nil
 }
 
+// commitChange is a code object created, modified or deleted by a commit.
+type commitChange struct {
+	state     string
+	partition string
+	oname     string
+	oid       string
+	codeID    string
+	path      string
+}
+
+// displayPath returns the workspace file of the change, or the partition and the object name when the file was not recorded.
+func (c commitChange) displayPath() string {
+	if c.path != "" {
+		return c.path
+	}
+	return path.Join(strings.TrimPrefix(c.partition, "/"), c.oname)
+}
+
+// codeSourcePaths returns the workspace files of the code map keyed by partition and object name.
+// Trees do not record the workspace files, so that their oids only depend on the code.
+func (m *Manager) codeSourcePaths() (map[string]string, error) {
+	codeMap, err := m.cospMgr.ReadCodeSourceCodeMap()
+	if err != nil {
+		return nil, err
+	}
+	sourcePaths := make(map[string]string, len(codeMap))
+	for _, codeFile := range codeMap {
+		sourcePaths[codeFile.Partition+"\x00"+codeFile.OName] = filepath.ToSlash(codeFile.Path)
+	}
+	return sourcePaths, nil
+}
+
+// commitTreeEntries returns the tree entries of the commit keyed by partition and object name.
+func (m *Manager) commitTreeEntries(commit *objects.Commit, sourcePaths map[string]string) (map[string]commitChange, error) {
+	trees := make([]*objects.Tree, 0, len(commit.Profiles()))
+	for _, profile := range commit.Profiles() {
+		treeObj, err := m.cospMgr.ReadObject(profile.Tree().String())
+		if err != nil {
+			return nil, err
+		}
+		tree, err := objects.ConvertObjectToTree(treeObj)
+		if err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+	}
+	return treesEntries(trees, sourcePaths), nil
+}
+
+// treesEntries returns the entries of the trees keyed by partition and object name, with their workspace files if known.
+func treesEntries(trees []*objects.Tree, sourcePaths map[string]string) map[string]commitChange {
+	entries := map[string]commitChange{}
+	for _, tree := range trees {
+		for _, entry := range tree.Entries() {
+			key := tree.Partition() + "\x00" + entry.OName()
+			entries[key] = commitChange{
+				partition: tree.Partition(),
+				oname:     entry.OName(),
+				oid:       entry.OID(),
+				codeID:    entry.MetadataString(objects.MetaKeyCodeID),
+				path:      sourcePaths[key],
+			}
+		}
+	}
+	return entries
+}
+
+// commitChanges diffs the trees of the commit against the trees of its predecessor, nil for a root commit.
+func (m *Manager) commitChanges(commit, predecessor *objects.Commit, sourcePaths map[string]string) ([]commitChange, error) {
+	current, err := m.commitTreeEntries(commit, sourcePaths)
+	if err != nil {
+		return nil, err
+	}
+	previous := map[string]commitChange{}
+	if predecessor != nil {
+		previous, err = m.commitTreeEntries(predecessor, sourcePaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+	changes := []commitChange{}
+	for key, entry := range current {
+		prev, ok := previous[key]
+		switch {
+		case !ok:
+			entry.state = cosp.CodeObjectStateCreate
+		case prev.oid != entry.oid:
+			entry.state = cosp.CodeObjectStateModify
+		default:
+			continue
+		}
+		changes = append(changes, entry)
+	}
+	for key, entry := range previous {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		entry.state = cosp.CodeObjectStateDelete
+		changes = append(changes, entry)
+	}
+	if predecessor != nil && predecessor.Manifest() != commit.Manifest() {
+		changes = append(changes, commitChange{state: cosp.CodeObjectStateModify, oname: "manifest", oid: commit.Manifest().String(), codeID: "manifest"})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].displayPath() != changes[j].displayPath() {
+			return changes[i].displayPath() < changes[j].displayPath()
+		}
+		return changes[i].oname < changes[j].oname
+	})
+	return changes, nil
+}
+
+// matchCommitChangesPath reports whether any change touches the path, which is a file, a directory or a glob pattern.
+func matchCommitChangesPath(changes []commitChange, pattern string) bool {
+	pattern = path.Clean(strings.TrimPrefix(filepath.ToSlash(pattern), "./"))
+	for _, change := range changes {
+		changePath := change.displayPath()
+		if changePath == pattern || strings.HasPrefix(changePath, pattern+"/") {
+			return true
+		}
+		if matched, err := path.Match(pattern, changePath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// commitChangesString gets the commit changes string.
+func commitChangesString(changes []commitChange) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "  - %s:", common.KeywordText("changes"))
+	if changes == nil {
+		sb.WriteString(" not available, the trees of the commits are not held by the workspace")
+	}
+	for _, change := range changes {
+		fmt.Fprintf(&sb, "\n    - %s %s (%s)", common.KeywordText(change.state), common.FileText(change.displayPath()), common.NameText(change.oname))
+	}
+	return sb.String()
+}
+
+// commitChangesMaps gets the commit changes maps.
+func commitChangesMaps(changes []commitChange) []map[string]any {
+	if changes == nil {
+		return nil
+	}
+	maps := make([]map[string]any, 0, len(changes))
+	for _, change := range changes {
+		maps = append(maps, map[string]any{
+			"state":     change.state,
+			"partition": change.partition,
+			"oname":     change.oname,
+			"oid":       change.oid,
+			"code_id":   change.codeID,
+			"path":      change.displayPath(),
+		})
+	}
+	return maps
+}
+
 // derefPredecessor returns the dereferenced predecessor OID or an empty string for a root commit.
 func derefPredecessor(p objects.NullableString) string {
 	if !p.Valid {
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -140,6 +141,31 @@ func (m *Manager) resolveAbstractLanguage(langPvd *ManifestLanguageProvider, hea
 	return nil, errors.New("cli: no matching partition found for blob")
 }
 
+// blobHumanContent converts the content of a blob to its human-readable form: policies to the language and manifests to YAML.
+func (m *Manager) blobHumanContent(langPvd *ManifestLanguageProvider, header *objects.ObjectHeader, content []byte) ([]byte, error) {
+	if header == nil {
+		return nil, errors.New("cli: object header is nil")
+	}
+	switch header.DataType() {
+	case objects.DataTypeAbstractTree:
+		absLang, err := m.resolveAbstractLanguage(langPvd, header)
+		if err != nil {
+			return nil, err
+		}
+		return absLang.ConvertBytesToHumanLanguage(
+			nil,
+			header.MetadataUint32(objects.MetaKeyLanguageID),
+			header.MetadataUint32(objects.MetaKeyLanguageVersionID),
+			header.MetadataUint32(objects.MetaKeyLanguageTypeID),
+			content,
+		)
+	case objects.DataTypeManifest:
+		return convertManifestToYAML(content, header.MetadataString(objects.MetaKeyFormat))
+	default:
+		return content, nil
+	}
+}
+
 // convertManifestToYAML converts manifest bytes to YAML for human-readable output.
 // If the content is already YAML, it is returned as-is.
 func convertManifestToYAML(data []byte, format string) ([]byte, error) {
@@ -179,33 +205,10 @@ func (m *Manager) execPrintObjectContent(langPvd *ManifestLanguageProvider, oid
 		instanceBytes := instance
 
 		if showHuman {
-			header := objInfo.Header()
-			if header == nil {
-				return errors.New("cli: object header is nil")
-			}
-
-			switch header.DataType() {
-			case objects.DataTypeAbstractTree:
-				absLang, err := m.resolveAbstractLanguage(langPvd, header)
-				if err != nil {
-					return err
-				}
-				instanceBytes, err = absLang.ConvertBytesToHumanLanguage(
-					nil,
-					header.MetadataUint32(objects.MetaKeyLanguageID),
-					header.MetadataUint32(objects.MetaKeyLanguageVersionID),
-					header.MetadataUint32(objects.MetaKeyLanguageTypeID),
-					instance,
-				)
-				if err != nil {
-					return err
-				}
-			case objects.DataTypeManifest:
-				yamlBytes, err := convertManifestToYAML(instance, header.MetadataString(objects.MetaKeyFormat))
-				if err != nil {
-					return err
-				}
-				instanceBytes = yamlBytes
+			var err error
+			instanceBytes, err = m.blobHumanContent(langPvd, objInfo.Header(), instance)
+			if err != nil {
+				return err
 			}
 		}
 
@@ -274,33 +277,9 @@ func (m *Manager) execMapObjectContent(langPvd *ManifestLanguageProvider, oid st
 		instanceBytes := instance
 
 		if showHuman {
-			header := objInfo.Header()
-			if header == nil {
-				return errors.New("cli: object header is nil")
-			}
-
-			switch header.DataType() {
-			case objects.DataTypeAbstractTree:
-				var absLang languages.LanguageAbstraction
-				absLang, err = m.resolveAbstractLanguage(langPvd, header)
-				if err != nil {
-					return err
-				}
-				instanceBytes, err = absLang.ConvertBytesToHumanLanguage(
-					nil,
-					header.MetadataUint32(objects.MetaKeyLanguageID),
-					header.MetadataUint32(objects.MetaKeyLanguageVersionID),
-					header.MetadataUint32(objects.MetaKeyLanguageTypeID),
-					instance,
-				)
-				if err != nil {
-					return err
-				}
-			case objects.DataTypeManifest:
-				instanceBytes, err = convertManifestToYAML(instance, header.MetadataString(objects.MetaKeyFormat))
-				if err != nil {
-					return err
-				}
+			instanceBytes, err = m.blobHumanContent(langPvd, objInfo.Header(), instance)
+			if err != nil {
+				return err
 			}
 		}
 
@@ -445,7 +424,8 @@ func (m *Manager) ExecObjectsCat(includeStorage, includeCode, showHuman, showRaw
 }
 
 // ExecHistory shows the commit history of the current workspace.
-func (m *Manager) ExecHistory(out common.PrinterOutFunc) (map[string]any, error) {
+// The commits are filtered by committer date, by author and by changed path, zero values disable the filters.
+func (m *Manager) ExecHistory(since time.Time, author, changedPath string, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -480,36 +460,74 @@ func (m *Manager) ExecHistory(out common.PrinterOutFunc) (map[string]any, error)
 		}
 	}
 
+	// Diff each commit against its predecessor and apply the filters, the changed files are resolved from the existing code map
+	sourcePaths, err := m.codeSourcePaths()
+	if err != nil {
+		return fail(nil, err)
+	}
+	author = strings.ToLower(strings.TrimSpace(author))
+	var selectedInfos []azwkscommon.CommitInfo
+	var selectedChanges [][]commitChange
+	for i, info := range commitInfos {
+		commit := info.Commit()
+		metadata := commit.MetaData()
+		if !since.IsZero() && metadata.CommitterTimestamp().Before(since) {
+			continue
+		}
+		if author != "" && !strings.Contains(strings.ToLower(metadata.Author()), author) {
+			continue
+		}
+		var predecessor *objects.Commit
+		if i+1 < len(commitInfos) {
+			predecessor = commitInfos[i+1].Commit()
+		}
+		// Older commits may not be held with their trees, their changes are reported as not available
+		changes, err := m.commitChanges(commit, predecessor, sourcePaths)
+		if err != nil {
+			changes = nil
+		}
+		if changedPath != "" && !matchCommitChangesPath(changes, changedPath) {
+			continue
+		}
+		selectedInfos = append(selectedInfos, info)
+		selectedChanges = append(selectedChanges, changes)
+	}
+
 	// Terminal output
 	if m.ctx.IsTerminalOutput() {
 		if len(commitInfos) == 0 {
 			out(nil, "", "No history data is available in the current workspace.", nil, true)
 			return output, nil
 		}
+		if len(selectedInfos) == 0 {
+			out(nil, "", "No commits in the history match the filters.", nil, true)
+			return output, nil
+		}
 
 		out(nil, "", fmt.Sprintf("Your workspace history %s:\n", common.KeywordText(headCtx.LedgerURI())), nil, true)
 
-		for _, info := range commitInfos {
+		for i, info := range selectedInfos {
 			commit := info.Commit()
 			commitStr, err := m.commitString(info.CommitOID(), commit)
 			if err != nil {
 				return fail(nil, err)
 			}
-			out(nil, "", commitStr, nil, true)
+			out(nil, "", commitStr+"\n"+commitChangesString(selectedChanges[i]), nil, true)
 		}
 
 		out(nil, "", "\n", nil, false)
-		out(nil, "", "total "+common.NumberText(len(commitInfos)), nil, true)
+		out(nil, "", "total "+common.NumberText(len(selectedInfos)), nil, true)
 
 		// JSON output
 	} else if m.ctx.IsJSONOutput() {
 		var objMaps []map[string]any
-		for _, info := range commitInfos {
+		for i, info := range selectedInfos {
 			commit := info.Commit()
 			objMap, err := m.commitMap(info.CommitOID(), commit)
 			if err != nil {
 				return fail(nil, err)
 			}
+			objMap["changes"] = commitChangesMaps(selectedChanges[i])
 			objMaps = append(objMaps, objMap)
 		}
 		output = out(output, "commits", objMaps, nil, true)
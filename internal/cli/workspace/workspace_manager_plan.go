@@ -18,8 +18,12 @@ package workspace
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"golang.org/x/crypto/ssh"
+
 	"github.com/permguard/permguard/internal/cli/workspace/cosp"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
@@ -86,15 +90,35 @@ func (m *Manager) buildPlanTrees(plan []cosp.CodeObjectState, langPvd *ManifestL
 
 // buildPlanCommit builds the plan commit.
 // predecessorCommitID is the string from the ref system; ZeroOID and empty string both mean root commit.
-func (m *Manager) buildPlanCommit(profiles []objects.CommitProfile, manifest string, predecessorCommitID string) (*objects.Commit, *objects.Object, error) {
+// The author and the committer are the identity configured in the cli.
+func (m *Manager) buildPlanCommit(profiles []objects.CommitProfile, manifest string, predecessorCommitID string, message string) (*objects.Commit, *objects.Object, error) {
 	predecessor := objects.NewNullableString(nil)
 	if predecessorCommitID != "" && predecessorCommitID != objects.ZeroOID {
 		predecessor = objects.NewNullableString(&predecessorCommitID)
 	}
-	commit, err := objects.NewCommit(profiles, objects.CID(manifest), predecessor, "", time.Now(), "", time.Now(), "")
+	identity := m.ctx.CommitIdentity()
+	now := time.Now()
+	commit, err := objects.NewCommit(profiles, objects.CID(manifest), predecessor, identity, now, identity, now, strings.TrimSpace(message))
 	if err != nil {
 		return nil, nil, errors.Join(errors.New("cli: commit cannot be created"), err)
 	}
+	signer, closeSigner, err := m.commitSigner()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeSigner()
+	if signer != nil {
+		if m.ctx.IsVerbose() {
+			m.ctx.AppendVerboseAction(fmt.Sprintf("signing the commit with key %s", ssh.FingerprintSHA256(signer.PublicKey())))
+		}
+		objMng, err := objects.NewObjectManager()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := objMng.SignCommit(commit, signer); err != nil {
+			return nil, nil, errors.Join(errors.New("cli: commit cannot be signed"), err)
+		}
+	}
 	commitObj, err := objects.CreateCommitObject(commit)
 	if err != nil {
 		return nil, nil, errors.Join(errors.New("cli: commit object cannot be created"), err)
@@ -29,6 +29,7 @@ type currentHeadContext struct {
 	server         string
 	serverPAPPort  int
 	scheme         string
+	remoteInfo     *common.RemoteInfo
 }
 
 // Remote returns the remote.
@@ -86,6 +87,11 @@ func (h *currentHeadContext) ServerPAPPort() int {
 	return h.serverPAPPort
 }
 
+// RemoteInfo returns the info of the remote, including its tls settings.
+func (h *currentHeadContext) RemoteInfo() *common.RemoteInfo {
+	return h.remoteInfo
+}
+
 // Scheme returns the remote gRPC scheme.
 func (h *currentHeadContext) Scheme() string {
 	return h.scheme
@@ -29,7 +29,8 @@ import (
 )
 
 // ExecPlan generates a plan of changes to apply to the remote ledger based on the differences between the local and remote states.
-func (m *Manager) ExecPlan(out common.PrinterOutFunc) (map[string]any, error) {
+// If a replay file is provided, the recorded requests are replayed against the remote and the planned policies.
+func (m *Manager) ExecPlan(replayFile string, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -44,11 +45,11 @@ func (m *Manager) ExecPlan(out common.PrinterOutFunc) (map[string]any, error) {
 	}
 	defer func() { _ = fileLock.Unlock() }()
 
-	return m.execInternalPlan(false, out)
+	return m.execInternalPlan(false, replayFile, out)
 }
 
 // execInternalPlan generates a plan of changes to apply to the remote ledger based on the differences between the local and remote states.
-func (m *Manager) execInternalPlan(internal bool, out common.PrinterOutFunc) (map[string]any, error) {
+func (m *Manager) execInternalPlan(internal bool, replayFile string, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -69,6 +70,13 @@ func (m *Manager) execInternalPlan(internal bool, out common.PrinterOutFunc) (ma
 		return out(output, "", fmt.Sprintf("Please execute '%s' to perform a comprehensive validation check for any potential errors.", common.CliCommandText("permguard validate")), nil, true), err
 	}
 
+	// Executes the policy tests as a gate for the planning
+	output, err = m.execInternalTest(true, output, out)
+	if err != nil {
+		output, err = fail(output, err)
+		return out(output, "", fmt.Sprintf("Please execute '%s' to review the failing policy tests.", common.CliCommandText("permguard test")), nil, true), err
+	}
+
 	if m.ctx.IsVerboseTerminalOutput() {
 		out(nil, "plan", fmt.Sprintf("Head successfully set to %s.", common.KeywordText(headCtx.Ref())), nil, true)
 		out(nil, "plan", fmt.Sprintf("Ledger set to %s.", common.KeywordText(headCtx.LedgerURI())), nil, true)
@@ -239,6 +247,13 @@ func (m *Manager) execInternalPlan(internal bool, out common.PrinterOutFunc) (ma
 			out(nil, "", "Run the 'apply' command to apply the changes.", nil, true)
 		}
 	}
+	if replayFile != "" {
+		output, err = m.execInternalReplay(replayFile, remoteCodeState, output, out)
+		if err != nil {
+			out(nil, "", "Unable to replay the recorded requests.", nil, true)
+			return fail(output, err)
+		}
+	}
 	if m.ctx.IsJSONOutput() {
 		if output == nil {
 			output = map[string]any{}
@@ -253,8 +268,8 @@ func (m *Manager) execInternalPlan(internal bool, out common.PrinterOutFunc) (ma
 	return output, nil
 }
 
-// ExecApply applies the plan to the remote ledger
-func (m *Manager) ExecApply(out common.PrinterOutFunc) (map[string]any, error) {
+// ExecApply applies the plan to the remote ledger, recording the message in the commit.
+func (m *Manager) ExecApply(message string, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -269,11 +284,11 @@ func (m *Manager) ExecApply(out common.PrinterOutFunc) (map[string]any, error) {
 	}
 	defer func() { _ = fileLock.Unlock() }()
 
-	return m.execInternalApply(false, out)
+	return m.execInternalApply(false, message, out)
 }
 
 // execInternalApply applies the plan to the remote ledger
-func (m *Manager) execInternalApply(internal bool, out common.PrinterOutFunc) (map[string]any, error) {
+func (m *Manager) execInternalApply(internal bool, message string, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		return output, err
 	}
@@ -285,7 +300,7 @@ func (m *Manager) execInternalApply(internal bool, out common.PrinterOutFunc) (m
 	}
 
 	// Executes the plan for the current head
-	output, err := m.execInternalPlan(true, out)
+	output, err := m.execInternalPlan(true, "", out)
 	if err != nil {
 		return fail(nil, err)
 	}
@@ -357,7 +372,7 @@ func (m *Manager) execInternalApply(internal bool, out common.PrinterOutFunc) (m
 		out(nil, "", errPlanningProcessFailed, nil, true)
 		return fail(output, errors.New("cli: no profiles found in plan"))
 	}
-	_, commitObj, err := m.buildPlanCommit(commitProfiles, manifestID, headCtx.remoteCommitID)
+	commit, commitObj, err := m.buildPlanCommit(commitProfiles, manifestID, headCtx.remoteCommitID, message)
 	if err != nil {
 		if m.ctx.IsVerboseTerminalOutput() {
 			out(nil, "apply", "Failed to build the commit.", nil, true)
@@ -367,6 +382,10 @@ func (m *Manager) execInternalApply(internal bool, out common.PrinterOutFunc) (m
 	}
 	if m.ctx.IsVerboseTerminalOutput() {
 		out(nil, "apply", fmt.Sprintf("The commit has been created with id: %s.", common.IDText(commitObj.OID())), nil, true)
+		metaData := commit.MetaData()
+		if signature := metaData.Signature(); signature != nil {
+			out(nil, "apply", fmt.Sprintf("The commit has been signed with a %s key.", common.KeywordText(signature.Format())), nil, true)
+		}
 	}
 
 	// Execute the synchronous push
@@ -379,7 +398,11 @@ func (m *Manager) execInternalApply(internal bool, out common.PrinterOutFunc) (m
 		return fail(nil, err)
 	}
 
-	_, err = m.logsMgr.Log(headCtx.headRefInfo, headCtx.remoteCommitID, commitObj.OID(), logs.LogActionPush, pushResult.Committed, headCtx.LedgerURI())
+	pushedCommitID := commitObj.OID()
+	if pushResult.Committed {
+		pushedCommitID = pushResult.RemoteCommitID
+	}
+	_, err = m.logsMgr.Log(headCtx.headRefInfo, headCtx.remoteCommitID, pushedCommitID, logs.LogActionPush, pushResult.Committed, headCtx.LedgerURI())
 	if err != nil {
 		return fail(nil, err)
 	}
@@ -62,6 +62,7 @@ func (m *Manager) currentHeadContext() (*currentHeadContext, error) {
 		server:         remoteInfo.Server(),
 		serverPAPPort:  remoteInfo.PAPPort(),
 		scheme:         remoteInfo.Scheme(),
+		remoteInfo:     remoteInfo,
 	}
 	ledgerID, err := m.rfsMgr.RefLedgerID(headRef)
 	if err != nil {
@@ -331,6 +331,8 @@ func (m *Manager) execInternalPull(internal bool, out common.PrinterOutFunc) (ma
 		codeEntries := []map[string]any{}
 		schemaBlocks := map[string][]byte{}
 		codeBlocks := map[string][][]byte{}
+		linkBlocks := map[string][][]byte{}
+		roleBlocks := map[string][][]byte{}
 		for _, profile := range commit.Profiles() {
 			treeObj, err := m.cospMgr.ReadObject(profile.Tree().String())
 			if err != nil {
@@ -382,7 +384,13 @@ func (m *Manager) execInternalPull(internal bool, out common.PrinterOutFunc) (ma
 						}
 						schemaBlocks[partition] = codeBlock
 						continue
-					case types.ClassTypePolicyID:
+					case types.ClassTypeTemplateLinkID:
+						partition := tree.Partition()
+						linkBlocks[partition] = append(linkBlocks[partition], codeBlock)
+					case types.ClassTypeRoleID:
+						partition := tree.Partition()
+						roleBlocks[partition] = append(roleBlocks[partition], codeBlock)
+					case types.ClassTypePolicyID, types.ClassTypeTemplateID:
 						partition := tree.Partition()
 						langID := header.MetadataUint32(objects.MetaKeyLanguageID)
 						langVersionID := header.MetadataUint32(objects.MetaKeyLanguageVersionID)
@@ -422,6 +430,36 @@ func (m *Manager) execInternalPull(internal bool, out common.PrinterOutFunc) (ma
 				return fail(err)
 			}
 		}
+		for partition, linkBlockItem := range linkBlocks {
+			absLang, err := langPvd.AbstractLanguageByPartition(partition)
+			if err != nil {
+				return fail(err)
+			}
+			linkBlock, linkFileName, err := absLang.CreateTemplateLinksContentBytes(nil, linkBlockItem)
+			if err != nil {
+				return fail(err)
+			}
+			fileBase := strings.TrimPrefix(partition, "/")
+			linkFileName = path.Join(fileBase, linkFileName)
+			if _, err := m.persMgr.WriteFile(persistence.WorkspaceDir, linkFileName, linkBlock, 0o644, false); err != nil {
+				return fail(err)
+			}
+		}
+		for partition, roleBlockItem := range roleBlocks {
+			absLang, err := langPvd.AbstractLanguageByPartition(partition)
+			if err != nil {
+				return fail(err)
+			}
+			roleBlock, roleFileName, err := absLang.CreateRolesContentBytes(nil, roleBlockItem)
+			if err != nil {
+				return fail(err)
+			}
+			fileBase := strings.TrimPrefix(partition, "/")
+			roleFileName = path.Join(fileBase, roleFileName)
+			if _, err := m.persMgr.WriteFile(persistence.WorkspaceDir, roleFileName, roleBlock, 0o644, false); err != nil {
+				return fail(err)
+			}
+		}
 		for partition, schemaBlockItem := range schemaBlocks {
 			absLang, err := langPvd.AbstractLanguageByPartition(partition)
 			if err != nil {
@@ -486,7 +524,7 @@ func (m *Manager) ExecPull(out common.PrinterOutFunc) (map[string]any, error) {
 }
 
 // ExecCloneLedger clones a ledger.
-func (m *Manager) ExecCloneLedger(ledgerURI string, zapPort, papPort int, scheme string, out common.PrinterOutFunc) (map[string]any, error) {
+func (m *Manager) ExecCloneLedger(ledgerURI string, zapPort, papPort int, scheme string, tlsInfo *azwkscommon.RemoteTLSInfo, out common.PrinterOutFunc) (map[string]any, error) {
 	fail := func(output map[string]any, err error) (map[string]any, error) {
 		out(nil, "", fmt.Sprintf("Failed to clone the ledger %s.", common.KeywordText(ledgerURI)), nil, true)
 		return output, err
@@ -509,6 +547,9 @@ func (m *Manager) ExecCloneLedger(ledgerURI string, zapPort, papPort int, scheme
 	uriLedger := elements[2]
 
 	serverScheme, uriServer := azwkscommon.ParseServerScheme(rawServer)
+	if serverScheme == "" && scheme == "" && tlsInfo != nil {
+		scheme = tlsInfo.Scheme()
+	}
 	resolvedScheme := azwkscommon.ResolveScheme(serverScheme, scheme)
 
 	output, err := m.ExecInitWorkspace(nil, out)
@@ -520,7 +561,7 @@ func (m *Manager) ExecCloneLedger(ledgerURI string, zapPort, papPort int, scheme
 		return fail(nil, err)
 	}
 	defer func() { _ = fileLock.Unlock() }()
-	output, err = m.execInternalAddRemote(true, OriginRemoteName, uriServer, zapPort, papPort, resolvedScheme, out)
+	output, err = m.execInternalAddRemote(true, OriginRemoteName, uriServer, zapPort, papPort, resolvedScheme, tlsInfo, out)
 	if err != nil {
 		return fail(output, err)
 	}
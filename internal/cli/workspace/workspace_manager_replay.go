@@ -0,0 +1,191 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/permguard/permguard/internal/agents/decisions"
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+	"github.com/permguard/permguard/internal/cli/workspace/policytests"
+	"github.com/permguard/permguard/pkg/core/files"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// decisionReplay represents the replay of a recorded request against the remote and the planned policies.
+type decisionReplay struct {
+	Entry     int    `json:"entry"`
+	RequestID string `json:"request_id,omitempty"`
+	Subject   string `json:"subject"`
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+	Remote    string `json:"remote,omitempty"`
+	Planned   string `json:"planned,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// decisionReplayReport represents the report of the replay of the recorded requests.
+type decisionReplayReport struct {
+	Total     int              `json:"total"`
+	Unchanged int              `json:"unchanged"`
+	Flips     []decisionReplay `json:"flips"`
+	Errors    []decisionReplay `json:"errors"`
+}
+
+// readDecisionLogs reads the recorded requests from a decision logs file.
+func readDecisionLogs(path string) ([]decisions.DecisionLogEntry, error) {
+	data, _, err := files.ReadFile(path, false)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("cli: failed to read the decision logs %s", path), err)
+	}
+	entries, err := decisions.ParseDecisionLogs(data)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("cli: invalid decision logs %s", path), err)
+	}
+	return entries, nil
+}
+
+// convertDecisionLogEntry converts a decision log entry to a request which can be evaluated locally.
+func convertDecisionLogEntry(entry *decisions.DecisionLogEntry) (*policytests.TestRequest, *pdp.Entities, error) {
+	evaluation := entry.Request.Evaluation
+	if evaluation.Subject == nil || evaluation.Resource == nil || evaluation.Action == nil {
+		return nil, nil, errors.New("cli: the recorded request requires subject, resource and action")
+	}
+	var entities *pdp.Entities
+	if entry.Request.AuthorizationModel != nil {
+		entities = entry.Request.AuthorizationModel.Entities
+	}
+	return &policytests.TestRequest{
+		Subject:  evaluation.Subject,
+		Resource: evaluation.Resource,
+		Action:   evaluation.Action,
+		Context:  evaluation.Context,
+	}, entities, nil
+}
+
+// decisionText returns the text of a decision.
+func decisionText(decision bool) string {
+	if decision {
+		return policytests.DecisionAllow
+	}
+	return policytests.DecisionDeny
+}
+
+// replayDecisionLogs replays the recorded requests against the remote and the planned policies and reports the decision flips.
+func replayDecisionLogs(entries []decisions.DecisionLogEntry, remote, planned policytests.Evaluator) *decisionReplayReport {
+	report := &decisionReplayReport{
+		Total:  len(entries),
+		Flips:  []decisionReplay{},
+		Errors: []decisionReplay{},
+	}
+	for i := range entries {
+		entry := &entries[i]
+		replay := decisionReplay{
+			Entry:     i + 1,
+			RequestID: entry.Request.Evaluation.RequestID,
+		}
+		request, entities, err := convertDecisionLogEntry(entry)
+		if err != nil {
+			replay.Error = err.Error()
+			report.Errors = append(report.Errors, replay)
+			continue
+		}
+		replay.Subject = fmt.Sprintf("%s:%s", request.Subject.Type, request.Subject.ID)
+		replay.Resource = fmt.Sprintf("%s:%s", request.Resource.Type, request.Resource.ID)
+		replay.Action = request.Action.Name
+		remoteDecision, _, err := remote(request, entities)
+		if err != nil {
+			replay.Error = err.Error()
+			report.Errors = append(report.Errors, replay)
+			continue
+		}
+		plannedDecision, _, err := planned(request, entities)
+		if err != nil {
+			replay.Error = err.Error()
+			report.Errors = append(report.Errors, replay)
+			continue
+		}
+		if remoteDecision == plannedDecision {
+			report.Unchanged++
+			continue
+		}
+		replay.Remote = decisionText(remoteDecision)
+		replay.Planned = decisionText(plannedDecision)
+		report.Flips = append(report.Flips, replay)
+	}
+	return report
+}
+
+// execInternalReplay replays the recorded requests of the decision logs against the remote and the planned policies.
+func (m *Manager) execInternalReplay(replayFile string, remoteCodeState []cosp.CodeObjectState, output map[string]any, out common.PrinterOutFunc) (map[string]any, error) {
+	fail := func(output map[string]any, err error) (map[string]any, error) {
+		return output, err
+	}
+	if m.ctx.IsVerboseTerminalOutput() {
+		out(nil, "plan", fmt.Sprintf("Reading the recorded requests from %s.", common.FileText(replayFile)), nil, true)
+	}
+	entries, err := readDecisionLogs(replayFile)
+	if err != nil {
+		return fail(output, err)
+	}
+	langPvd, err := m.buildManifestLanguageProvider()
+	if err != nil {
+		return fail(output, err)
+	}
+	plannedStore, err := m.buildLocalPolicyStore(langPvd)
+	if err != nil {
+		return fail(output, err)
+	}
+	remoteStore, err := m.buildPolicyStore(langPvd, remoteCodeState, m.cospMgr.ReadObject, "")
+	if err != nil {
+		return fail(output, err)
+	}
+	plannedEvaluator, err := m.buildPolicyEvaluator(langPvd, plannedStore)
+	if err != nil {
+		return fail(output, err)
+	}
+	remoteEvaluator, err := m.buildPolicyEvaluator(langPvd, remoteStore)
+	if err != nil {
+		return fail(output, err)
+	}
+	report := replayDecisionLogs(entries, remoteEvaluator, plannedEvaluator)
+
+	if m.ctx.IsTerminalOutput() {
+		out(nil, "", fmt.Sprintf("Replayed %s recorded requests against the remote and the planned policies.", common.NumberText(report.Total)), nil, true)
+		for _, flip := range report.Flips {
+			symbol := common.CreateText("+")
+			if flip.Planned == policytests.DecisionDeny {
+				symbol = common.DeleteText("-")
+			}
+			out(nil, "", fmt.Sprintf("  %s %s %s on %s: %s -> %s", symbol, common.NameText(flip.Subject), common.KeywordText(flip.Action),
+				common.NameText(flip.Resource), flip.Remote, flip.Planned), nil, true)
+		}
+		for _, replayErr := range report.Errors {
+			out(nil, "", fmt.Sprintf("  ! entry %s: %s", common.NumberText(replayErr.Entry), common.LogErrorText(replayErr.Error)), nil, true)
+		}
+		out(nil, "", fmt.Sprintf("decision flips %s, unchanged %s, errors %s",
+			common.NumberText(len(report.Flips)), common.NumberText(report.Unchanged), common.NumberText(len(report.Errors))), nil, true)
+	} else if m.ctx.IsJSONOutput() {
+		if output == nil {
+			output = map[string]any{}
+		}
+		output["replay"] = report
+	}
+	return output, nil
+}
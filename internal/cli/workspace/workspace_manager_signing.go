@@ -0,0 +1,79 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+// commitSigner returns the signer of the commits if a signing key is configured, along with the function releasing it.
+// The signing key is either a private key file or a public key file whose private key is held by the ssh agent.
+func (m *Manager) commitSigner() (ssh.Signer, func(), error) {
+	keyFile := m.ctx.CommitSigningKey()
+	if keyFile == "" {
+		return nil, func() {}, nil
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("cli: failed to read the commit signing key"), err)
+	}
+	if m.ctx.IsVerbose() {
+		m.ctx.AppendVerboseFile(keyFile)
+	}
+	if pubKey, _, _, _, err := ssh.ParseAuthorizedKey(data); err == nil {
+		return agentSigner(pubKey)
+	}
+	signer, err := objects.ParseSigningKey(data)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("cli: invalid commit signing key"), err)
+	}
+	return signer, func() {}, nil
+}
+
+// agentSigner returns the signer of the ssh agent holding the private key of the public key.
+func agentSigner(pubKey ssh.PublicKey) (ssh.Signer, func(), error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, errors.New("cli: the commit signing key is a public key but no ssh agent is available (SSH_AUTH_SOCK is not set)")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("cli: failed to connect to the ssh agent"), err)
+	}
+	closeConn := func() { _ = conn.Close() }
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		closeConn()
+		return nil, nil, errors.Join(errors.New("cli: failed to list the ssh agent keys"), err)
+	}
+	for _, signer := range signers {
+		if bytes.Equal(signer.PublicKey().Marshal(), pubKey.Marshal()) {
+			return signer, closeConn, nil
+		}
+	}
+	closeConn()
+	return nil, nil, fmt.Errorf("cli: the commit signing key %s is not loaded in the ssh agent", ssh.FingerprintSHA256(pubKey))
+}
@@ -15,3 +15,58 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package workspace
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	notppackets "github.com/permguard/permguard/internal/transport/notp/statemachines/packets"
+	aznotpcapture "github.com/permguard/permguard/notp-protocol/pkg/notp/capture"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotptransport "github.com/permguard/permguard/notp-protocol/pkg/notp/transport"
+)
+
+// notpMaxPacketSize returns the configured notp max packet size, falling back to the protocol default.
+func (m *Manager) notpMaxPacketSize() int {
+	maxPacketSize, err := m.ctx.NOTPMaxPacketSize()
+	if err != nil || maxPacketSize <= 0 {
+		return aznotptransport.DefaultMaxPacketSize
+	}
+	return maxPacketSize
+}
+
+// notpTraceRecorder returns the recorder of the notp trace file if one is configured, along with the function closing it.
+func (m *Manager) notpTraceRecorder() (*aznotpcapture.Recorder, func(), error) {
+	traceFile := m.ctx.NOTPTraceFile()
+	if traceFile == "" {
+		return nil, func() {}, nil
+	}
+	writer, err := aznotpcapture.OpenFile(traceFile)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("cli: failed to open the notp trace file"), err)
+	}
+	if m.ctx.IsVerbose() {
+		m.ctx.AppendVerboseFile(traceFile)
+	}
+	return writer.NewRecorder(aznotpcapture.RoleFollower, uuid.NewString()), func() { _ = writer.Close() }, nil
+}
+
+// isNOTPStreamUnimplemented reports whether the server does not serve the notp stream, so that the flow falls back to the unary rpcs.
+func isNOTPStreamUnimplemented(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
+
+// readLocalRefPacket reads the local ref state packet carried by a state packet.
+func readLocalRefPacket(packetables []aznotppackets.Packetable) (*notppackets.LocalRefStatePacket, error) {
+	if len(packetables) == 0 {
+		return nil, errors.New("cli: missing local ref state packet")
+	}
+	localRef := &notppackets.LocalRefStatePacket{}
+	if err := aznotppackets.ConvertPacketable(packetables[0], localRef); err != nil {
+		return nil, err
+	}
+	return localRef, nil
+}
@@ -17,10 +17,19 @@
 package workspace
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
 	"github.com/permguard/permguard/internal/cli/common"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/transport/clients"
+	notpstatemachines "github.com/permguard/permguard/internal/transport/notp/statemachines"
+	notppackets "github.com/permguard/permguard/internal/transport/notp/statemachines/packets"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
@@ -34,42 +43,302 @@ type PullResult struct {
 	Committed         bool
 }
 
-// execRemotePull performs a synchronous pull from the remote server.
-func (m *Manager) execRemotePull(headCtx *currentHeadContext, out common.PrinterOutFunc) (*PullResult, error) {
-	if m.ctx.IsVerboseTerminalOutput() {
-		out(nil, "pull", "Advertising - Initiating request for ledger state.", nil, true)
+// pullFollower holds the state of a pull flow run by the CLI as follower.
+type pullFollower struct {
+	m                 *Manager
+	ref               string
+	out               common.PrinterOutFunc
+	maxPacketSize     int
+	resume            *azwkscommon.TransferResumeInfo
+	assembler         *aznotppackets.ChunkAssembler
+	localCommitID     string
+	remoteCommitID    string
+	remoteCommitCount uint32
+	haveCommitIDs     []string
+	hasConflicts      bool
+	isUpToDate        bool
+	pulledCommitCount uint32
+	confirmedObjects  uint64
+	localCommitCount  uint32
+}
+
+// saveObjects verifies and saves a chunk of pulled objects.
+func (f *pullFollower) saveObjects(objs []pap.ObjectState) error {
+	m := f.m
+	for _, obj := range objs {
+		if err := objects.VerifyOID(obj.OID, obj.Content); err != nil {
+			return fmt.Errorf("cli: received corrupted object %s: %w", obj.OID, err)
+		}
+		if err := objects.ValidateObjectSize(obj.Content, objects.DefaultMaxObjectSize); err != nil {
+			return fmt.Errorf("cli: received oversized object %s: %w", obj.OID, err)
+		}
+		if _, err := m.cospMgr.SaveObject(obj.OID, obj.Content); err != nil {
+			return fmt.Errorf("cli: failed to save object %s: %w", obj.OID, err)
+		}
 	}
+	f.confirmedObjects += uint64(len(objs))
+	return nil
+}
+
+// transferKey returns the key of the pulled objects, which depend on the local and the remote commit and on the held commits.
+func (f *pullFollower) transferKey() string {
+	hash := sha256.New()
+	for _, haveCommitID := range f.haveCommitIDs {
+		hash.Write([]byte(haveCommitID))
+	}
+	return fmt.Sprintf("%s..%s@%s", f.localCommitID, f.remoteCommitID, hex.EncodeToString(hash.Sum(nil)))
+}
 
-	papClient, err := m.rmSrvtMgr.NewPAPClientSession(headCtx.Server(), headCtx.ServerPAPPort(), headCtx.Scheme())
+// saveResume saves the resume state of the pull, so that an interrupted transfer restarts from the last confirmed object.
+func (f *pullFollower) saveResume() error {
+	resume, err := azwkscommon.NewTransferResumeInfo(f.transferKey(), f.remoteCommitID, "", f.confirmedObjects)
+	if err != nil {
+		return err
+	}
+	return f.m.rfsMgr.SaveTransferResume(f.ref, azwkscommon.TransferFlowPull, resume)
+}
+
+// verifyPulledCommits verifies the graph integrity of the pulled commits, as chunks may span commits and sessions.
+func (f *pullFollower) verifyPulledCommits() error {
+	m := f.m
+	_, history, err := m.objMar.BuildCommitHistory(f.remoteCommitID, f.localCommitID, false, func(oid string) (*objects.Object, error) {
+		obj, _ := m.cospMgr.ReadObject(oid)
+		return obj, nil
+	})
+	if err != nil {
+		return err
+	}
+	if uint32(len(history)) < f.pulledCommitCount {
+		return fmt.Errorf("cli: pulled %d of %d commit(s)", len(history), f.pulledCommitCount)
+	}
+	for _, commit := range history {
+		commitObj, err := objects.CreateCommitObject(&commit)
+		if err != nil {
+			return err
+		}
+		// Verify commit graph integrity: commit → tree → all blobs must exist.
+		if err := m.objMar.VerifyCommitGraphIntegrity(commitObj.OID(), func(oid string) (*objects.Object, error) {
+			return m.cospMgr.ReadObject(oid)
+		}); err != nil {
+			return fmt.Errorf("cli: graph integrity check failed for commit %s: %w", commitObj.OID(), err)
+		}
+	}
+	f.localCommitCount = f.pulledCommitCount
+	return nil
+}
+
+// handle handles the state packets of the pull flow.
+func (f *pullFollower) handle(handlerCtx *aznotpstatemachines.HandlerContext, statePacket *aznotpsmpackets.StatePacket, packetables []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+	verbose := f.m.ctx.IsVerboseTerminalOutput()
+	handlerReturn := &aznotpstatemachines.HostHandlerReturn{
+		MessageValue: statePacket.MessageValue,
+	}
+	switch statePacket.MessageCode {
+	case aznotpsmpackets.RequestCurrentObjectsStateMessage:
+		if verbose {
+			f.out(nil, "pull", "Advertising - Initiating request for ledger state.", nil, true)
+		}
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = []aznotppackets.Packetable{&notppackets.RemoteRefStatePacket{
+			RefPrevCommit: f.localCommitID,
+			RefCommit:     f.localCommitID,
+			MaxPacketSize: uint32(f.maxPacketSize),
+		}}
+	case aznotpsmpackets.RespondCurrentStateMessage:
+		localRef, err := readLocalRefPacket(packetables)
+		if err != nil {
+			return nil, err
+		}
+		f.remoteCommitID = localRef.RefCommit
+		f.remoteCommitCount = localRef.NumberOfCommits
+		f.hasConflicts = localRef.HasConflicts
+		f.isUpToDate = localRef.IsUpToDate
+		if f.hasConflicts || f.isUpToDate {
+			handlerReturn.Terminate = true
+		}
+	case aznotpsmpackets.NegotiationRequestMessage:
+		if verbose {
+			f.out(nil, "pull", "Negotiation - Requesting commit list.", nil, true)
+		}
+		haveCommitIDs, err := f.m.cospMgr.HeldCommits(f.localCommitID, pap.MaxHaveCommits)
+		if err != nil {
+			return nil, err
+		}
+		f.haveCommitIDs = haveCommitIDs
+		if verbose {
+			f.out(nil, "pull", fmt.Sprintf("Negotiation - Advertising %d held commit(s).", len(haveCommitIDs)), nil, true)
+		}
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = []aznotppackets.Packetable{&notppackets.RemoteRefStatePacket{
+			RefPrevCommit: f.localCommitID,
+			RefCommit:     f.remoteCommitID,
+			HaveCommits:   haveCommitIDs,
+		}}
+		if f.resume != nil && f.resume.Key() == f.transferKey() {
+			handlerReturn.Packetables = append(handlerReturn.Packetables, &notppackets.TransferResumePacket{
+				Token:            f.resume.Token(),
+				ConfirmedObjects: f.resume.ConfirmedObjects(),
+			})
+		}
+	case aznotpsmpackets.RespondNegotiationRequestMessage:
+		localRef, err := readLocalRefPacket(packetables)
+		if err != nil {
+			return nil, err
+		}
+		f.pulledCommitCount = localRef.NumberOfCommits
+		if len(packetables) > 1 {
+			resume, err := notppackets.ReadTransferResumePacket(packetables[1:])
+			if err != nil {
+				return nil, err
+			}
+			f.confirmedObjects = resume.ConfirmedObjects
+		}
+		if verbose {
+			if f.confirmedObjects > 0 {
+				f.out(nil, "pull", fmt.Sprintf("Data Exchange - Resuming transfer after %d confirmed object(s).", f.confirmedObjects), nil, true)
+			}
+			f.out(nil, "pull", fmt.Sprintf("Data Exchange - Pulling %d commit(s).", localRef.NumberOfCommits), nil, true)
+		}
+	case aznotpsmpackets.ExchangeDataStreamMessage:
+		assembled, err := f.assembler.Assemble(packetables)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := notppackets.ReadObjectStatePackets(assembled)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.saveObjects(objs); err != nil {
+			return nil, err
+		}
+	case aznotpsmpackets.AcknowledgeDataStreamMessage:
+		if err := f.saveResume(); err != nil {
+			return nil, err
+		}
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = []aznotppackets.Packetable{&notppackets.TransferResumePacket{
+			Token:            f.remoteCommitID,
+			ConfirmedObjects: f.confirmedObjects,
+		}}
+	case aznotpsmpackets.CommitMessage:
+		if f.assembler.HasPending() {
+			return nil, errors.New("cli: data stream completed with an incomplete object")
+		}
+		if err := f.verifyPulledCommits(); err != nil {
+			return nil, err
+		}
+		if err := f.m.rfsMgr.DeleteTransferResume(f.ref, azwkscommon.TransferFlowPull); err != nil {
+			return nil, err
+		}
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+	}
+	return handlerReturn, nil
+}
+
+// execRemotePull performs a pull from the remote server over a single NOTP stream.
+func (m *Manager) execRemotePull(headCtx *currentHeadContext, out common.PrinterOutFunc) (*PullResult, error) {
+	papClient, err := m.rmSrvtMgr.NewPAPClientSession(headCtx.RemoteInfo())
 	if err != nil {
 		return nil, fmt.Errorf("cli: failed to create PAP client: %w", err)
 	}
 	defer func() { _ = papClient.Close() }()
 
-	localCommitID := headCtx.remoteCommitID
+	resume, err := m.rfsMgr.TransferResume(headCtx.Ref(), azwkscommon.TransferFlowPull)
+	if err != nil {
+		return nil, fmt.Errorf("cli: failed to read the pull resume state: %w", err)
+	}
+	maxPacketSize := m.notpMaxPacketSize()
+	assembler, err := notppackets.NewObjectChunkAssembler(maxPacketSize)
+	if err != nil {
+		return nil, err
+	}
+	follower := &pullFollower{
+		m:             m,
+		ref:           headCtx.Ref(),
+		out:           out,
+		resume:        resume,
+		maxPacketSize: maxPacketSize,
+		assembler:     assembler,
+		localCommitID: headCtx.remoteCommitID,
+	}
+	recorder, closeTrace, err := m.notpTraceRecorder()
+	if err != nil {
+		return nil, err
+	}
+	defer closeTrace()
+	err = papClient.NOTPStream(follower.handle, headCtx.ZoneID(), headCtx.LedgerID(), aznotpstatemachines.PullFlowType, follower.maxPacketSize, recorder)
+	if err != nil {
+		if isNOTPStreamUnimplemented(err) && follower.confirmedObjects == 0 {
+			return m.execRemotePullUnary(papClient, headCtx, out)
+		}
+		if follower.confirmedObjects > 0 {
+			return nil, fmt.Errorf("cli: pull failed after %d confirmed object(s), run the command again to resume: %w", follower.confirmedObjects, err)
+		}
+		return nil, fmt.Errorf("cli: pull failed: %w", err)
+	}
+
+	if follower.isUpToDate {
+		if m.ctx.IsVerboseTerminalOutput() {
+			out(nil, "pull", "Already up to date.", nil, true)
+		}
+		return &PullResult{
+			LocalCommitID:     follower.localCommitID,
+			RemoteCommitID:    follower.remoteCommitID,
+			LocalCommitCount:  0,
+			RemoteCommitCount: follower.remoteCommitCount,
+			Committed:         false,
+		}, nil
+	}
+	if follower.hasConflicts {
+		return nil, errors.New("cli: conflicts detected in the remote ledger")
+	}
+
+	if m.ctx.IsVerboseTerminalOutput() {
+		out(nil, "pull", "Commit - Pull completed successfully.", nil, true)
+	}
+
+	return &PullResult{
+		LocalCommitID:     follower.localCommitID,
+		RemoteCommitID:    follower.remoteCommitID,
+		LocalCommitCount:  follower.localCommitCount,
+		RemoteCommitCount: follower.remoteCommitCount,
+		Committed:         true,
+	}, nil
+}
+
+// execRemotePullUnary performs a pull through the unary rpcs, for the servers not serving the notp stream.
+func (m *Manager) execRemotePullUnary(papClient *clients.GrpcPAPClientSession, headCtx *currentHeadContext, out common.PrinterOutFunc) (*PullResult, error) {
+	verbose := m.ctx.IsVerboseTerminalOutput()
+	if verbose {
+		out(nil, "pull", "Advertising - Initiating request for ledger state.", nil, true)
+	}
+	follower := &pullFollower{
+		m:             m,
+		ref:           headCtx.Ref(),
+		out:           out,
+		localCommitID: headCtx.remoteCommitID,
+	}
 
-	// Step 1: PullState
 	stateResp, err := papClient.PullState(&pap.PullStateRequest{
 		ZoneID:        headCtx.ZoneID(),
 		LedgerID:      headCtx.LedgerID(),
-		RefCommit:     localCommitID,
-		RefPrevCommit: localCommitID,
+		RefCommit:     follower.localCommitID,
+		RefPrevCommit: follower.localCommitID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cli: pull state failed: %w", err)
 	}
-
-	remoteCommitID := stateResp.ServerCommit
-
+	follower.remoteCommitID = stateResp.ServerCommit
+	follower.remoteCommitCount = stateResp.NumberOfCommits
 	if stateResp.IsUpToDate {
-		if m.ctx.IsVerboseTerminalOutput() {
+		if verbose {
 			out(nil, "pull", "Already up to date.", nil, true)
 		}
 		return &PullResult{
-			LocalCommitID:     localCommitID,
-			RemoteCommitID:    remoteCommitID,
+			LocalCommitID:     follower.localCommitID,
+			RemoteCommitID:    follower.remoteCommitID,
 			LocalCommitCount:  0,
-			RemoteCommitCount: stateResp.NumberOfCommits,
+			RemoteCommitCount: follower.remoteCommitCount,
 			Committed:         false,
 		}, nil
 	}
@@ -77,66 +346,55 @@ func (m *Manager) execRemotePull(headCtx *currentHeadContext, out common.Printer
 		return nil, errors.New("cli: conflicts detected in the remote ledger")
 	}
 
-	if m.ctx.IsVerboseTerminalOutput() {
+	if verbose {
 		out(nil, "pull", "Negotiation - Requesting commit list.", nil, true)
 	}
-
-	// Step 2: PullNegotiate
+	haveCommitIDs, err := m.cospMgr.HeldCommits(follower.localCommitID, pap.MaxHaveCommits)
+	if err != nil {
+		return nil, err
+	}
 	negResp, err := papClient.PullNegotiate(&pap.PullNegotiateRequest{
 		ZoneID:         headCtx.ZoneID(),
 		LedgerID:       headCtx.LedgerID(),
-		LocalCommitID:  localCommitID,
-		RemoteCommitID: remoteCommitID,
+		LocalCommitID:  follower.localCommitID,
+		RemoteCommitID: follower.remoteCommitID,
+		HaveCommitIDs:  haveCommitIDs,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cli: pull negotiate failed: %w", err)
 	}
+	follower.pulledCommitCount = uint32(len(negResp.CommitIDs))
 
-	if m.ctx.IsVerboseTerminalOutput() {
+	if verbose {
 		out(nil, "pull", fmt.Sprintf("Data Exchange - Pulling %d commit(s).", len(negResp.CommitIDs)), nil, true)
 	}
-
-	// Step 3: PullObjects for each commit
-	localCommitCount := uint32(0)
 	for _, commitID := range negResp.CommitIDs {
 		objResp, err := papClient.PullObjects(&pap.PullObjectsRequest{
-			ZoneID:   headCtx.ZoneID(),
-			LedgerID: headCtx.LedgerID(),
-			CommitID: commitID,
+			ZoneID:        headCtx.ZoneID(),
+			LedgerID:      headCtx.LedgerID(),
+			CommitID:      commitID,
+			HaveCommitIDs: negResp.HaveCommitIDs,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("cli: pull objects failed for commit %s: %w", commitID, err)
 		}
-		for _, obj := range objResp.Objects {
-			if err := objects.VerifyOID(obj.OID, obj.Content); err != nil {
-				return nil, fmt.Errorf("cli: received corrupted object %s: %w", obj.OID, err)
-			}
-			if err := objects.ValidateObjectSize(obj.Content, objects.DefaultMaxObjectSize); err != nil {
-				return nil, fmt.Errorf("cli: received oversized object %s: %w", obj.OID, err)
-			}
-			_, err = m.cospMgr.SaveObject(obj.OID, obj.Content)
-			if err != nil {
-				return nil, fmt.Errorf("cli: failed to save object %s: %w", obj.OID, err)
-			}
-		}
-		// Verify commit graph integrity: commit → tree → all blobs must exist.
-		if err := m.objMar.VerifyCommitGraphIntegrity(commitID, func(oid string) (*objects.Object, error) {
-			return m.cospMgr.ReadObject(oid)
-		}); err != nil {
-			return nil, fmt.Errorf("cli: graph integrity check failed for commit %s: %w", commitID, err)
+		if err := follower.saveObjects(objResp.Objects); err != nil {
+			return nil, err
 		}
-		localCommitCount++
+	}
+	if err := follower.verifyPulledCommits(); err != nil {
+		return nil, err
 	}
 
-	if m.ctx.IsVerboseTerminalOutput() {
+	if verbose {
 		out(nil, "pull", "Commit - Pull completed successfully.", nil, true)
 	}
 
 	return &PullResult{
-		LocalCommitID:     localCommitID,
-		RemoteCommitID:    remoteCommitID,
-		LocalCommitCount:  localCommitCount,
-		RemoteCommitCount: stateResp.NumberOfCommits,
+		LocalCommitID:     follower.localCommitID,
+		RemoteCommitID:    follower.remoteCommitID,
+		LocalCommitCount:  follower.localCommitCount,
+		RemoteCommitCount: follower.remoteCommitCount,
 		Committed:         true,
 	}, nil
 }
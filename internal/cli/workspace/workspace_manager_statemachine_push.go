@@ -17,10 +17,19 @@
 package workspace
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
 	"github.com/permguard/permguard/internal/cli/common"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/transport/clients"
+	notpstatemachines "github.com/permguard/permguard/internal/transport/notp/statemachines"
+	notppackets "github.com/permguard/permguard/internal/transport/notp/statemachines/packets"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
@@ -103,46 +112,8 @@ func (m *Manager) collectObjectsForCommit(isCode bool, commitObj *objects.Object
 	return result, nil
 }
 
-// execPush performs a synchronous push to the remote server.
-func (m *Manager) execPush(headCtx *currentHeadContext, commitObj *objects.Object, out common.PrinterOutFunc) (*PushResult, error) {
-	if m.ctx.IsVerboseTerminalOutput() {
-		out(nil, "push", "Advertising - Initiating ledger state notification.", nil, true)
-	}
-
-	papClient, err := m.rmSrvtMgr.NewPAPClientSession(headCtx.Server(), headCtx.ServerPAPPort(), headCtx.Scheme())
-	if err != nil {
-		return nil, fmt.Errorf("cli: failed to create PAP client: %w", err)
-	}
-	defer func() { _ = papClient.Close() }()
-
-	// Step 1: PushAdvertise
-	advResp, err := papClient.PushAdvertise(&pap.PushAdvertiseRequest{
-		ZoneID:        headCtx.ZoneID(),
-		LedgerID:      headCtx.LedgerID(),
-		RefCommit:     commitObj.OID(),
-		RefPrevCommit: headCtx.remoteCommitID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("cli: push advertise failed: %w", err)
-	}
-
-	if advResp.IsUpToDate {
-		if m.ctx.IsVerboseTerminalOutput() {
-			out(nil, "push", "Remote is already up to date.", nil, true)
-		}
-		return &PushResult{Committed: false, RemoteCommitID: advResp.ServerCommit}, nil
-	}
-	if advResp.HasConflicts {
-		return nil, errors.New("cli: remote ledger has diverged, run 'pull' to sync your workspace then retry")
-	}
-	txid := advResp.TxID
-	remoteCommitID := advResp.ServerCommit
-
-	if m.ctx.IsVerboseTerminalOutput() {
-		out(nil, "push", "Negotiation - Computing diff commits.", nil, true)
-	}
-
-	// Step 2: Build diff commit list locally
+// collectPushBatches collects the objects to push, one batch per commit from the remote commit with the current (code) commit as last.
+func (m *Manager) collectPushBatches(commitObj *objects.Object, remoteCommitID string) ([][]pap.ObjectState, error) {
 	localCommitID := commitObj.OID()
 	commitIDs := []string{}
 	if localCommitID != remoteCommitID {
@@ -168,12 +139,7 @@ func (m *Manager) execPush(headCtx *currentHeadContext, commitObj *objects.Objec
 			commitIDs = append(commitIDs, obj.OID())
 		}
 	}
-
-	if m.ctx.IsVerboseTerminalOutput() {
-		out(nil, "push", fmt.Sprintf("Data Exchange - Transferring %d commit(s).", len(commitIDs)+1), nil, true)
-	}
-
-	// Step 3: Transfer diff commits (non-code objects from history)
+	batches := [][]pap.ObjectState{}
 	for _, cid := range commitIDs {
 		cidObj, err := m.cospMgr.ReadObject(cid)
 		if err != nil {
@@ -183,46 +149,270 @@ func (m *Manager) execPush(headCtx *currentHeadContext, commitObj *objects.Objec
 		if err != nil {
 			return nil, err
 		}
-		_, err = papClient.PushTransfer(&pap.PushTransferRequest{
-			TxID:     txid,
-			ZoneID:   headCtx.ZoneID(),
-			LedgerID: headCtx.LedgerID(),
-			Objects:  objs,
-			IsLast:   false,
-		})
+		batches = append(batches, objs)
+	}
+	codeObjs, err := m.collectObjectsForCommit(true, commitObj)
+	if err != nil {
+		return nil, err
+	}
+	return append(batches, codeObjs), nil
+}
+
+// pushFollower holds the state of a push flow run by the CLI as follower.
+type pushFollower struct {
+	m                *Manager
+	headCtx          *currentHeadContext
+	commitObj        *objects.Object
+	out              common.PrinterOutFunc
+	maxPacketSize    int
+	resume           *azwkscommon.TransferResumeInfo
+	serverCommit     string
+	hasConflicts     bool
+	isUpToDate       bool
+	txID             string
+	confirmedObjects uint64
+	commitCount      int
+	objects          []pap.ObjectState
+	transferKey      string
+	chunks           [][]aznotppackets.Packetable
+	commitSaved      bool
+	committed        bool
+}
+
+// buildObjects builds the objects to transfer, commit by commit with the current (code) commit as last.
+// Each commit object follows the objects it references, so that the objects of the code commit, which is
+// created again by every apply, stay in the same order and an interrupted transfer can be resumed.
+func (f *pushFollower) buildObjects() error {
+	remoteCommitID := f.headCtx.remoteCommitID
+	batches, err := f.m.collectPushBatches(f.commitObj, remoteCommitID)
+	if err != nil {
+		return err
+	}
+	f.commitCount = len(batches)
+	f.objects = []pap.ObjectState{}
+	for _, batch := range batches {
+		f.objects = append(f.objects, batch[1:]...)
+		f.objects = append(f.objects, batch[0])
+	}
+	// The key covers every object but the trailing code commit.
+	hash := sha256.New()
+	hash.Write([]byte(remoteCommitID))
+	for _, obj := range f.objects[:len(f.objects)-1] {
+		hash.Write([]byte(obj.OID))
+	}
+	f.transferKey = hex.EncodeToString(hash.Sum(nil))
+	return f.resumeCommit()
+}
+
+// resumeCommit replaces the code commit with the one of the interrupted push, when it carries the same change,
+// so that the server resumes the transaction which is bound to the pushed commit.
+func (f *pushFollower) resumeCommit() error {
+	if f.resume == nil || f.resume.Key() != f.transferKey || f.resume.CommitID() == "" || f.resume.CommitID() == f.commitObj.OID() {
+		return nil
+	}
+	resumeObj, err := f.m.cospMgr.ReadObject(f.resume.CommitID())
+	if err != nil || resumeObj == nil {
+		return nil
+	}
+	resumeCommit, err := objects.ConvertObjectToCommit(resumeObj)
+	if err != nil {
+		return nil
+	}
+	commit, err := objects.ConvertObjectToCommit(f.commitObj)
+	if err != nil {
+		return err
+	}
+	resumeMetaData, metaData := resumeCommit.MetaData(), commit.MetaData()
+	if resumeCommit.Message() != commit.Message() || resumeMetaData.Author() != metaData.Author() || resumeMetaData.Committer() != metaData.Committer() {
+		return nil
+	}
+	f.commitObj = resumeObj
+	f.objects[len(f.objects)-1] = pap.ObjectState{
+		OID:     resumeObj.OID(),
+		OType:   objects.ObjectTypeCommit,
+		Content: resumeObj.Content(),
+	}
+	return nil
+}
+
+// buildChunks builds the chunks of objects to transfer, skipping the objects already confirmed by the server.
+func (f *pushFollower) buildChunks() error {
+	skip := min(f.confirmedObjects, uint64(len(f.objects)))
+	chunks, err := aznotppackets.ChunkPacketables(notppackets.NewObjectStatePackets(f.objects[skip:]), f.maxPacketSize)
+	if err != nil {
+		return err
+	}
+	f.chunks = chunks
+	return nil
+}
+
+// saveResume saves the resume state of the push, so that an interrupted transfer restarts from the last confirmed object.
+// The pushed commit is saved as well, as the server resumes a transaction only for the same commit.
+func (f *pushFollower) saveResume() error {
+	if !f.commitSaved {
+		if _, err := f.m.cospMgr.SaveObject(f.commitObj.OID(), f.commitObj.Content()); err != nil {
+			return err
+		}
+		f.commitSaved = true
+	}
+	resume, err := azwkscommon.NewTransferResumeInfo(f.transferKey, f.txID, f.commitObj.OID(), f.confirmedObjects)
+	if err != nil {
+		return err
+	}
+	return f.m.rfsMgr.SaveTransferResume(f.headCtx.Ref(), azwkscommon.TransferFlowPush, resume)
+}
+
+// handle handles the state packets of the push flow.
+func (f *pushFollower) handle(handlerCtx *aznotpstatemachines.HandlerContext, statePacket *aznotpsmpackets.StatePacket, packetables []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+	verbose := f.m.ctx.IsVerboseTerminalOutput()
+	handlerReturn := &aznotpstatemachines.HostHandlerReturn{
+		MessageValue: statePacket.MessageValue,
+	}
+	switch statePacket.MessageCode {
+	case aznotpsmpackets.NotifyCurrentObjectStatesMessage:
+		if verbose {
+			f.out(nil, "push", "Advertising - Initiating ledger state notification.", nil, true)
+		}
+		if err := f.buildObjects(); err != nil {
+			return nil, err
+		}
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+		handlerReturn.Packetables = []aznotppackets.Packetable{&notppackets.RemoteRefStatePacket{
+			RefPrevCommit: f.headCtx.remoteCommitID,
+			RefCommit:     f.commitObj.OID(),
+			MaxPacketSize: uint32(f.maxPacketSize),
+		}}
+		if f.resume != nil && f.resume.Key() == f.transferKey && f.resume.Token() != "" {
+			handlerReturn.Packetables = append(handlerReturn.Packetables, &notppackets.TransferResumePacket{
+				Token: f.resume.Token(),
+			})
+		}
+	case aznotpsmpackets.RespondCurrentStateMessage:
+		localRef, err := readLocalRefPacket(packetables)
 		if err != nil {
-			return nil, fmt.Errorf("cli: push transfer failed: %w", err)
+			return nil, err
+		}
+		f.maxPacketSize = notppackets.NegotiateMaxPacketSize(f.maxPacketSize, localRef.MaxPacketSize)
+		f.serverCommit = localRef.RefCommit
+		f.hasConflicts = localRef.HasConflicts
+		f.isUpToDate = localRef.IsUpToDate
+		if f.hasConflicts || f.isUpToDate {
+			handlerReturn.Terminate = true
+			break
 		}
+		if len(packetables) > 1 {
+			resume, err := notppackets.ReadTransferResumePacket(packetables[1:])
+			if err != nil {
+				return nil, err
+			}
+			f.txID = resume.Token
+			f.confirmedObjects = resume.ConfirmedObjects
+			if err := f.saveResume(); err != nil {
+				return nil, err
+			}
+		}
+	case aznotpsmpackets.NegotiationRequestMessage:
+		if verbose {
+			f.out(nil, "push", "Negotiation - Computing diff commits.", nil, true)
+		}
+		if err := f.buildChunks(); err != nil {
+			return nil, err
+		}
+	case aznotpsmpackets.RespondNegotiationRequestMessage:
+		if verbose {
+			if f.confirmedObjects > 0 {
+				f.out(nil, "push", fmt.Sprintf("Data Exchange - Resuming transfer after %d confirmed object(s).", f.confirmedObjects), nil, true)
+			}
+			f.out(nil, "push", fmt.Sprintf("Data Exchange - Transferring %d commit(s) in %d chunk(s).", f.commitCount, len(f.chunks)), nil, true)
+		}
+		handlerReturn.MessageValue = notpstatemachines.AcknowledgedMessageValue()
+	case aznotpsmpackets.ExchangeDataStreamMessage:
+		if len(f.chunks) > 0 {
+			handlerReturn.Packetables = f.chunks[0]
+			f.chunks = f.chunks[1:]
+		}
+		handlerReturn.HasMore = len(f.chunks) > 0
+		handlerReturn.MessageValue = notpstatemachines.DataStreamMessageValue(handlerReturn.HasMore)
+	case aznotpsmpackets.AcknowledgeDataStreamMessage:
+		resume, err := notppackets.ReadTransferResumePacket(packetables)
+		if err != nil {
+			return nil, err
+		}
+		f.confirmedObjects = resume.ConfirmedObjects
+		if err := f.saveResume(); err != nil {
+			return nil, err
+		}
+	case aznotpsmpackets.CommitMessage:
+		f.committed = statePacket.HasAck()
+	}
+	return handlerReturn, nil
+}
+
+// execPush performs a push to the remote server over a single NOTP stream.
+func (m *Manager) execPush(headCtx *currentHeadContext, commitObj *objects.Object, out common.PrinterOutFunc) (*PushResult, error) {
+	papClient, err := m.rmSrvtMgr.NewPAPClientSession(headCtx.RemoteInfo())
+	if err != nil {
+		return nil, fmt.Errorf("cli: failed to create PAP client: %w", err)
 	}
+	defer func() { _ = papClient.Close() }()
 
-	// Step 4: Transfer the current (code) commit as last
-	codeObjs, err := m.collectObjectsForCommit(true, commitObj)
+	resume, err := m.rfsMgr.TransferResume(headCtx.Ref(), azwkscommon.TransferFlowPush)
+	if err != nil {
+		return nil, fmt.Errorf("cli: failed to read the push resume state: %w", err)
+	}
+	follower := &pushFollower{
+		m:             m,
+		headCtx:       headCtx,
+		commitObj:     commitObj,
+		out:           out,
+		maxPacketSize: m.notpMaxPacketSize(),
+		resume:        resume,
+	}
+	recorder, closeTrace, err := m.notpTraceRecorder()
 	if err != nil {
 		return nil, err
 	}
-	transferResp, err := papClient.PushTransfer(&pap.PushTransferRequest{
-		TxID:                 txid,
-		ZoneID:               headCtx.ZoneID(),
-		LedgerID:             headCtx.LedgerID(),
-		Objects:              codeObjs,
-		IsLast:               true,
-		RemoteCommitID:       commitObj.OID(),
-		ExpectedServerCommit: remoteCommitID,
-	})
+	defer closeTrace()
+	err = papClient.NOTPStream(follower.handle, headCtx.ZoneID(), headCtx.LedgerID(), aznotpstatemachines.PushFlowType, follower.maxPacketSize, recorder)
 	if err != nil {
-		return nil, fmt.Errorf("cli: push transfer (final) failed: %w", err)
+		if isNOTPStreamUnimplemented(err) && follower.confirmedObjects == 0 {
+			return m.execPushUnary(papClient, headCtx, commitObj, out)
+		}
+		if follower.confirmedObjects > 0 {
+			return nil, fmt.Errorf("cli: push failed after %d confirmed object(s), run the command again to resume: %w", follower.confirmedObjects, err)
+		}
+		return nil, fmt.Errorf("cli: push failed: %w", err)
+	}
+	if follower.committed || follower.isUpToDate || follower.hasConflicts {
+		if err := m.rfsMgr.DeleteTransferResume(headCtx.Ref(), azwkscommon.TransferFlowPush); err != nil {
+			return nil, fmt.Errorf("cli: failed to delete the push resume state: %w", err)
+		}
 	}
 
-	if !transferResp.Committed {
+	if follower.isUpToDate {
+		if m.ctx.IsVerboseTerminalOutput() {
+			out(nil, "push", "Remote is already up to date.", nil, true)
+		}
+		return &PushResult{Committed: false, RemoteCommitID: follower.serverCommit}, nil
+	}
+	if follower.hasConflicts {
+		return nil, errors.New("cli: remote ledger has diverged, run 'pull' to sync your workspace then retry")
+	}
+	if !follower.committed {
 		return nil, errors.New("cli: server did not commit the push")
 	}
 
+	return m.completePush(headCtx, follower.commitObj, out)
+}
+
+// completePush cleans up the local code source once the push has been committed.
+func (m *Manager) completePush(headCtx *currentHeadContext, commitObj *objects.Object, out common.PrinterOutFunc) (*PushResult, error) {
 	if m.ctx.IsVerboseTerminalOutput() {
 		out(nil, "push", "Commit - Push committed successfully.", nil, true)
 	}
 
 	// Clean up local code source
-	_, err = m.cospMgr.CleanCodeSource()
+	_, err := m.cospMgr.CleanCodeSource()
 	if err != nil {
 		return nil, err
 	}
@@ -233,3 +423,64 @@ func (m *Manager) execPush(headCtx *currentHeadContext, commitObj *objects.Objec
 
 	return &PushResult{Committed: true, RemoteCommitID: commitObj.OID()}, nil
 }
+
+// execPushUnary performs a push through the unary rpcs, for the servers not serving the notp stream.
+func (m *Manager) execPushUnary(papClient *clients.GrpcPAPClientSession, headCtx *currentHeadContext, commitObj *objects.Object, out common.PrinterOutFunc) (*PushResult, error) {
+	verbose := m.ctx.IsVerboseTerminalOutput()
+	if verbose {
+		out(nil, "push", "Advertising - Initiating ledger state notification.", nil, true)
+	}
+	advResp, err := papClient.PushAdvertise(&pap.PushAdvertiseRequest{
+		ZoneID:        headCtx.ZoneID(),
+		LedgerID:      headCtx.LedgerID(),
+		RefCommit:     commitObj.OID(),
+		RefPrevCommit: headCtx.remoteCommitID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cli: push advertise failed: %w", err)
+	}
+	if advResp.IsUpToDate {
+		if verbose {
+			out(nil, "push", "Remote is already up to date.", nil, true)
+		}
+		return &PushResult{Committed: false, RemoteCommitID: advResp.ServerCommit}, nil
+	}
+	if advResp.HasConflicts {
+		return nil, errors.New("cli: remote ledger has diverged, run 'pull' to sync your workspace then retry")
+	}
+
+	if verbose {
+		out(nil, "push", "Negotiation - Computing diff commits.", nil, true)
+	}
+	batches, err := m.collectPushBatches(commitObj, advResp.ServerCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	if verbose {
+		out(nil, "push", fmt.Sprintf("Data Exchange - Transferring %d commit(s).", len(batches)), nil, true)
+	}
+	committed := false
+	for i, batch := range batches {
+		req := &pap.PushTransferRequest{
+			TxID:     advResp.TxID,
+			ZoneID:   headCtx.ZoneID(),
+			LedgerID: headCtx.LedgerID(),
+			Objects:  batch,
+		}
+		if i == len(batches)-1 {
+			req.IsLast = true
+			req.RemoteCommitID = commitObj.OID()
+			req.ExpectedServerCommit = advResp.ServerCommit
+		}
+		transferResp, err := papClient.PushTransfer(req)
+		if err != nil {
+			return nil, fmt.Errorf("cli: push transfer failed: %w", err)
+		}
+		committed = transferResp.Committed
+	}
+	if !committed {
+		return nil, errors.New("cli: server did not commit the push")
+	}
+	return m.completePush(headCtx, commitObj, out)
+}
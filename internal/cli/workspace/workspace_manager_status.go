@@ -0,0 +1,117 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+	"github.com/permguard/permguard/internal/cli/workspace/persistence"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+)
+
+// localCodeStatus is the status of the source files relative to the last refresh of the local area.
+type localCodeStatus struct {
+	refreshed   bool
+	refreshedAt time.Time
+	modified    []string
+	added       []string
+	removed     []string
+}
+
+// isDirty reports whether the source files have changed since the last refresh.
+func (s *localCodeStatus) isDirty() bool {
+	return len(s.modified) > 0 || len(s.added) > 0 || len(s.removed) > 0
+}
+
+// localCodeStatus compares the source files of the workspace with the code map written by the last refresh.
+func (m *Manager) localCodeStatus(langPvd *ManifestLanguageProvider) (*localCodeStatus, error) {
+	status := &localCodeStatus{}
+	refreshedAt, refreshed, err := m.cospMgr.CodeSourceRefreshTime()
+	if err != nil || !refreshed {
+		return status, err
+	}
+	status.refreshed = true
+	status.refreshedAt = refreshedAt
+
+	codeMap, err := m.cospMgr.ReadCodeSourceCodeMap()
+	if err != nil {
+		return nil, err
+	}
+	refreshedPaths := map[string]bool{}
+	for _, codeFile := range codeMap {
+		refreshedPaths[codeFile.Path] = true
+	}
+	selectedFiles, _, err := m.scanSourceCodeFiles(langPvd)
+	if err != nil {
+		return nil, err
+	}
+	currentPaths := map[string]bool{}
+	for _, codeFile := range selectedFiles {
+		currentPaths[codeFile.Path] = true
+	}
+	for _, manifestFile := range azmanifests.ManifestFileNames {
+		if exists, _ := m.persMgr.CheckPathIfExists(persistence.WorkspaceDir, manifestFile); exists {
+			currentPaths[manifestFile] = true
+			refreshedPaths[manifestFile] = true
+		}
+	}
+
+	for path := range currentPaths {
+		if !refreshedPaths[path] {
+			status.added = append(status.added, path)
+			continue
+		}
+		info, err := os.Stat(m.persMgr.Path(persistence.WorkspaceDir, path))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil && info.ModTime().After(refreshedAt) {
+			status.modified = append(status.modified, path)
+		}
+	}
+	for path := range refreshedPaths {
+		if !currentPaths[path] {
+			status.removed = append(status.removed, path)
+		}
+	}
+	sort.Strings(status.modified)
+	sort.Strings(status.added)
+	sort.Strings(status.removed)
+	return status, nil
+}
+
+// stagedPlanChanges returns the changes of the plan staged for the ref, excluding the unchanged objects.
+func (m *Manager) stagedPlanChanges(ref string) ([]cosp.CodeObjectState, bool, error) {
+	plan, err := m.cospMgr.ReadRemoteCodePlan(ref)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(plan) == 0 {
+		return nil, false, nil
+	}
+	changes := []cosp.CodeObjectState{}
+	for _, planItem := range plan {
+		if planItem.State != cosp.CodeObjectStateUnchanged {
+			changes = append(changes, planItem)
+		}
+	}
+	return changes, true, nil
+}
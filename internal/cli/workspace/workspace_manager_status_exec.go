@@ -0,0 +1,241 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+)
+
+// ExecStatus prints the status of the workspace: the checked out ledger, the local and remote heads,
+// the changes to the source files since the last refresh, the staged plan and the configured remotes.
+func (m *Manager) ExecStatus(out common.PrinterOutFunc) (map[string]any, error) {
+	fail := func(output map[string]any, err error) (map[string]any, error) {
+		return output, err
+	}
+
+	output := m.ExecPrintContext(nil, out)
+
+	// Ensure we're in a valid workspace
+	if !m.isWorkspaceDir() {
+		return fail(nil, m.raiseWrongWorkspaceDirError(out))
+	}
+
+	// Acquire workspace lock
+	fileLock, err := m.tryLock()
+	if err != nil {
+		return fail(nil, err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	status := map[string]any{}
+
+	// Read the checked out ledger, a freshly initialized workspace has none
+	var headCtx *currentHeadContext
+	if headRef, err := m.rfsMgr.CurrentHeadRef(); err == nil && headRef != "" {
+		headCtx, err = m.currentHeadContext()
+		if err != nil {
+			return fail(nil, err)
+		}
+	}
+	if headCtx == nil {
+		status["ledger_uri"] = ""
+		if m.ctx.IsTerminalOutput() {
+			out(nil, "", "Your workspace doesn't have any ledger checked out.\n", nil, true)
+		}
+	} else {
+		inSync := headCtx.HeadCommitID() == headCtx.RemoteCommitID()
+		status["ledger_uri"] = headCtx.LedgerURI()
+		status["ref"] = headCtx.Ref()
+		status["local_commit"] = headCtx.HeadCommitID()
+		status["remote_commit"] = headCtx.RemoteCommitID()
+		status["in_sync"] = inSync
+		if m.ctx.IsTerminalOutput() {
+			out(nil, "", fmt.Sprintf("Your workspace status %s:\n", common.KeywordText(headCtx.LedgerURI())), nil, true)
+			out(nil, "", fmt.Sprintf("	Ref: %s", common.KeywordText(headCtx.Ref())), nil, true)
+			out(nil, "", fmt.Sprintf("	Local commit: %s", common.IDText(headCtx.HeadCommitID())), nil, true)
+			out(nil, "", fmt.Sprintf("	Remote commit: %s", common.IDText(headCtx.RemoteCommitID())), nil, true)
+			if inSync {
+				out(nil, "", "	The local head is in sync with the remote ledger.", nil, true)
+			} else {
+				out(nil, "", "	The local head differs from the remote ledger, run the 'pull' command to synchronize it.", nil, true)
+			}
+			out(nil, "", "\n", nil, false)
+		}
+	}
+	// Compare the source files with the last refresh
+	if headCtx != nil {
+		langPvd, err := m.buildManifestLanguageProvider()
+		if err != nil {
+			return fail(nil, err)
+		}
+		codeStatus, err := m.localCodeStatus(langPvd)
+		if err != nil {
+			return fail(nil, err)
+		}
+		local := map[string]any{
+			"refreshed": codeStatus.refreshed,
+			"dirty":     codeStatus.isDirty(),
+			"modified":  append([]string{}, codeStatus.modified...),
+			"added":     append([]string{}, codeStatus.added...),
+			"removed":   append([]string{}, codeStatus.removed...),
+		}
+		if codeStatus.refreshed {
+			local["refreshed_at"] = codeStatus.refreshedAt.UTC().Format(time.RFC3339)
+		}
+		status["local"] = local
+		if m.ctx.IsTerminalOutput() {
+			switch {
+			case !codeStatus.refreshed:
+				out(nil, "", "The local area has not been refreshed, run the 'refresh' command to track the source files.", nil, true)
+			case !codeStatus.isDirty():
+				out(nil, "", fmt.Sprintf("No changes to the source files since the last refresh at %s.", common.TimeStampText(codeStatus.refreshedAt.Format(time.DateTime))), nil, true)
+			default:
+				out(nil, "", fmt.Sprintf("Changes to the source files since the last refresh at %s:\n", common.TimeStampText(codeStatus.refreshedAt.Format(time.DateTime))), nil, true)
+				for _, path := range codeStatus.added {
+					out(nil, "", fmt.Sprintf("	%s %s", common.CreateText("+"), common.CreateText(path)), nil, true)
+				}
+				for _, path := range codeStatus.modified {
+					out(nil, "", fmt.Sprintf("	%s %s", common.ModifyText("~"), common.ModifyText(path)), nil, true)
+				}
+				for _, path := range codeStatus.removed {
+					out(nil, "", fmt.Sprintf("	%s %s", common.DeleteText("-"), common.DeleteText(path)), nil, true)
+				}
+				out(nil, "", "\nRun the 'refresh' command to include them in the next plan.", nil, true)
+			}
+			out(nil, "", "\n", nil, false)
+		}
+
+		// Read the staged plan
+		planChanges, staged, err := m.stagedPlanChanges(headCtx.Ref())
+		if err != nil {
+			return fail(nil, err)
+		}
+		planItems := []any{}
+		created, modified, deleted := 0, 0, 0
+		for _, planItem := range planChanges {
+			switch planItem.State {
+			case cosp.CodeObjectStateCreate:
+				created++
+			case cosp.CodeObjectStateModify:
+				modified++
+			case cosp.CodeObjectStateDelete:
+				deleted++
+			}
+			planItems = append(planItems, map[string]any{
+				"state":     planItem.State,
+				"oid":       planItem.OID,
+				"partition": planItem.Partition,
+				"oname":     planItem.OName,
+			})
+		}
+		status["plan"] = map[string]any{
+			"staged":   staged,
+			"created":  created,
+			"modified": modified,
+			"deleted":  deleted,
+			"changes":  planItems,
+		}
+		if m.ctx.IsTerminalOutput() {
+			switch {
+			case !staged:
+				out(nil, "", "No plan is staged, run the 'plan' command to prepare one.", nil, true)
+			case len(planChanges) == 0:
+				out(nil, "", "The staged plan has no changes to apply.", nil, true)
+			default:
+				out(nil, "", "The staged plan:\n", nil, true)
+				for _, planItem := range planChanges {
+					symbol, colorFn := common.CreateText("+"), common.CreateText
+					switch planItem.State {
+					case cosp.CodeObjectStateModify:
+						symbol, colorFn = common.ModifyText("~"), common.ModifyText
+					case cosp.CodeObjectStateDelete:
+						symbol, colorFn = common.DeleteText("-"), common.DeleteText
+					}
+					partName := planItem.Partition
+					if !strings.HasSuffix(partName, "/") {
+						partName += "/"
+					}
+					partName += planItem.OName
+					out(nil, "", fmt.Sprintf("	%s %s %s", symbol, common.IDText(planItem.OID), colorFn(partName)), nil, true)
+				}
+				out(nil, "", fmt.Sprintf("\ncreated %d, modified %d, deleted %d", created, modified, deleted), nil, true)
+				out(nil, "", "Run the 'apply' command to apply the changes.", nil, true)
+			}
+			out(nil, "", "\n", nil, false)
+		}
+	}
+
+	// Read the configured remotes
+	remoteNames, err := m.cfgMgr.RemoteNames()
+	if err != nil {
+		return fail(nil, err)
+	}
+	remotes := []any{}
+	for _, remoteName := range remoteNames {
+		remoteInfo, err := m.cfgMgr.RemoteInfo(remoteName)
+		if err != nil {
+			return fail(nil, err)
+		}
+		// Remotes added before the scheme was persisted connect with the scheme of their tls settings, or plaintext
+		tlsMode, scheme := "", remoteInfo.Scheme()
+		if tlsInfo := remoteInfo.TLSInfo(); tlsInfo != nil {
+			tlsMode = tlsInfo.Mode()
+			if scheme == "" {
+				scheme = tlsInfo.Scheme()
+			}
+		}
+		scheme = azwkscommon.ResolveScheme(scheme, "")
+		remotes = append(remotes, map[string]any{
+			"remote":   remoteName,
+			"server":   remoteInfo.Server(),
+			"zap_port": remoteInfo.ZAPPort(),
+			"pap_port": remoteInfo.PAPPort(),
+			"scheme":   scheme,
+			"tls_mode": tlsMode,
+		})
+		if m.ctx.IsTerminalOutput() && len(remotes) == 1 {
+			out(nil, "", "Your workspace configured remotes:\n", nil, true)
+		}
+		if m.ctx.IsTerminalOutput() {
+			remoteStr := fmt.Sprintf("	- %s %s://%s (zap %d, pap %d)", common.KeywordText(remoteName), scheme,
+				remoteInfo.Server(), remoteInfo.ZAPPort(), remoteInfo.PAPPort())
+			if tlsMode != "" {
+				remoteStr += fmt.Sprintf(" tls mode %s", tlsMode)
+			}
+			out(nil, "", remoteStr, nil, true)
+		}
+	}
+	status["remotes"] = remotes
+	if m.ctx.IsTerminalOutput() {
+		if len(remotes) == 0 {
+			out(nil, "", "Your workspace doesn't have any remote configured.", nil, true)
+		} else {
+			out(nil, "", "\n", nil, false)
+		}
+	}
+
+	if m.ctx.IsJSONOutput() {
+		output = out(output, "status", status, nil, true)
+	}
+	return output, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+)
+
+// workspaceStatus runs the status with the JSON output and returns it.
+func workspaceStatus(t *testing.T, w *testWorkspace) map[string]any {
+	t.Helper()
+	output, err := w.jsonManager(t).ExecStatus(w.out)
+	require.NoError(t, err, "error should be nil")
+	return output["status"].(map[string]any)
+}
+
+// TestExecStatusHead tests the status of the checked out ledger, of the source files and of the staged plan.
+func TestExecStatusHead(t *testing.T) {
+	w := newTestWorkspace(t)
+	headCtx, err := w.m.currentHeadContext()
+	require.NoError(t, err, "error should be nil")
+	status := workspaceStatus(t, w)
+	assert.Equal(t, headCtx.LedgerURI(), status["ledger_uri"], "ledger should be checked out")
+	assert.Equal(t, headCtx.Ref(), status["ref"], "ref should be the head ref")
+	assert.Equal(t, true, status["in_sync"], "head should be in sync")
+	assert.Equal(t, false, status["local"].(map[string]any)["refreshed"], "local area should not be refreshed")
+	assert.Equal(t, false, status["plan"].(map[string]any)["staged"], "no plan should be staged")
+
+	w.writeFile(t, "orders.cedar", testOrdersPolicies)
+	_, err = w.m.execInternalPlan(true, "", w.out)
+	require.NoError(t, err, "error should be nil")
+	w.writeFile(t, "admin.cedar", testAdminPolicies)
+	status = workspaceStatus(t, w)
+	local := status["local"].(map[string]any)
+	assert.Equal(t, true, local["refreshed"], "local area should be refreshed by the plan")
+	assert.Equal(t, true, local["dirty"], "local area should be dirty")
+	assert.Equal(t, []string{"admin.cedar"}, local["added"], "new file should be reported")
+	plan := status["plan"].(map[string]any)
+	assert.Equal(t, true, plan["staged"], "plan should be staged")
+	assert.Equal(t, 2, plan["created"], "plan should create the policy and the manifest")
+
+	commitID := w.commit(t, "add policies")
+	status = workspaceStatus(t, w)
+	assert.Equal(t, commitID, status["remote_commit"], "remote commit should be the last commit")
+	assert.Equal(t, commitID, status["local_commit"], "local commit should be the last commit")
+
+	_, err = w.m.ExecStatus(w.out)
+	require.NoError(t, err, "error should be nil")
+	text := w.outputText()
+	assert.Contains(t, text, "Your workspace status", "terminal output should contain the ledger")
+	assert.Contains(t, text, "The local head is in sync with the remote ledger.", "terminal output should contain the sync state")
+	assert.Contains(t, text, "No plan is staged", "terminal output should contain the plan state")
+}
+
+// TestExecStatusRemotes tests the schemes of the remotes, also for the remotes saved without one.
+func TestExecStatusRemotes(t *testing.T) {
+	w := newTestWorkspace(t)
+	tlsInfo, err := azwkscommon.NewRemoteTLSInfo(azwkscommon.RemoteTLSModeTLS, "ca.pem", "", "", false, "", "")
+	require.NoError(t, err, "error should be nil")
+	_, err = w.m.cfgMgr.ExecAddRemote("legacy", "legacy.example.com", 9091, 9092, "", nil, nil, w.out)
+	require.NoError(t, err, "error should be nil")
+	_, err = w.m.cfgMgr.ExecAddRemote("secure", "secure.example.com", 9091, 9092, "", tlsInfo, nil, w.out)
+	require.NoError(t, err, "error should be nil")
+
+	schemes := map[string]string{}
+	for _, remote := range workspaceStatus(t, w)["remotes"].([]any) {
+		remoteMap := remote.(map[string]any)
+		schemes[remoteMap["remote"].(string)] = remoteMap["scheme"].(string)
+	}
+	assert.Equal(t, map[string]string{"origin": "grpc", "legacy": "grpc", "secure": "grpcs"}, schemes, "schemes should be resolved")
+
+	w.outputText()
+	_, err = w.m.ExecStatus(w.out)
+	require.NoError(t, err, "error should be nil")
+	text := w.outputText()
+	assert.Contains(t, text, "grpc://legacy.example.com (zap 9091, pap 9092)", "legacy remote should fall back to the default scheme")
+	assert.Contains(t, text, "grpcs://secure.example.com (zap 9091, pap 9092) tls mode tls", "tls remote should use the tls scheme")
+	assert.NotContains(t, text, " ://", "scheme should never be empty")
+}
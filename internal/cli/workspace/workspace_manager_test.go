@@ -0,0 +1,248 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	azwkscommon "github.com/permguard/permguard/internal/cli/workspace/common"
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+	"github.com/permguard/permguard/pkg/authz/languages"
+	langregistry "github.com/permguard/permguard/pkg/authz/languages/registry"
+	"github.com/permguard/permguard/pkg/cli"
+	"github.com/permguard/permguard/pkg/cli/options"
+	"github.com/permguard/permguard/pkg/core/files"
+	"github.com/permguard/permguard/plugin/languages/cedar"
+	"github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+const (
+	// testZoneID is the zone of the ledger checked out by the test workspaces.
+	testZoneID = 273165098782
+	// testLedgerURI is the ledger checked out by the test workspaces.
+	testLedgerURI = "origin/273165098782/orders"
+	// testLedgerID is the id of the ledger checked out by the test workspaces.
+	testLedgerID = "f6b1c5a3e7e04e7f9d2a6c9f0b1d2e3f"
+)
+
+// testLanguageFactory is the cedar language factory of the test workspaces.
+type testLanguageFactory struct {
+	langAbs languages.LanguageAbstraction
+	langReg *langregistry.LanguageRegistry
+}
+
+// LanguageAbstraction gets the cedar language abstraction.
+func (f *testLanguageFactory) LanguageAbstraction(language, version string) (languages.LanguageAbstraction, error) {
+	if language != cedarlang.LanguageName {
+		return nil, fmt.Errorf("cli: invalid language %s with version %s", language, version)
+	}
+	return f.langAbs, nil
+}
+
+// LanguageRegistry returns the registry of language descriptors.
+func (f *testLanguageFactory) LanguageRegistry() *langregistry.LanguageRegistry {
+	return f.langReg
+}
+
+// testWorkspace is a workspace with a ledger checked out locally, whose commits are stored without a remote server.
+type testWorkspace struct {
+	m      *Manager
+	dir    string
+	v      *viper.Viper
+	output []string
+}
+
+// out records the terminal messages of the workspace.
+func (w *testWorkspace) out(output map[string]any, key string, value any, _ error, _ bool) map[string]any {
+	if output == nil {
+		output = map[string]any{}
+	}
+	if key != "" {
+		output[key] = value
+	} else if msg, ok := value.(string); ok {
+		w.output = append(w.output, msg)
+	}
+	return output
+}
+
+// outputText returns the terminal messages recorded since the last call.
+func (w *testWorkspace) outputText() string {
+	text := strings.Join(w.output, "\n")
+	w.output = nil
+	return text
+}
+
+// newTestManager creates a manager for the workspace directory with the given output.
+func newTestManager(t *testing.T, dir, output string, v *viper.Viper) *Manager {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test", Annotations: map[string]string{common.AnnotationSkipNamedContext: "true"}}
+	cmd.Flags().String(common.FlagWorkingDirectory, dir, "")
+	cmd.Flags().String(common.FlagOutput, output, "")
+	cmd.Flags().Bool(common.FlagVerbose, false, "")
+	langReg := langregistry.NewLanguageRegistry()
+	require.NoError(t, langReg.Register(cedar.NewCedarLanguageDescriptor()))
+	langAbs, err := cedar.NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	langFct := &testLanguageFactory{langAbs: langAbs, langReg: langReg}
+	deps, err := common.NewCliDependenciesProvider(langFct)
+	require.NoError(t, err)
+	ctx, _, err := common.CreateContextAndPrinter(deps, cmd, v)
+	require.NoError(t, err)
+	m, err := NewInternalManager(ctx, langFct)
+	require.NoError(t, err)
+	return m
+}
+
+// newTestWorkspace initializes a cedar workspace and checks out a ledger with no commits.
+func newTestWorkspace(t *testing.T) *testWorkspace {
+	t.Helper()
+	return newTestWorkspaceWithPAPPort(t, 9092)
+}
+
+// newTestWorkspaceWithPAPPort initializes a cedar workspace whose remote serves the PAP on the given local port.
+func newTestWorkspaceWithPAPPort(t *testing.T, papPort int) *testWorkspace {
+	t.Helper()
+	dir := t.TempDir()
+	w := &testWorkspace{dir: dir, v: viper.New()}
+	w.setAuthor("Amy", "amy@example.com")
+	w.m = newTestManager(t, dir, cli.OutputTerminal, w.v)
+	_, err := w.m.ExecInitWorkspace(&InitParms{Name: "orders", Language: cedarlang.LanguageName}, w.out)
+	require.NoError(t, err)
+	_, err = w.m.ExecAddRemote(OriginRemoteName, "localhost", 9091, papPort, "grpc", nil, w.out)
+	require.NoError(t, err)
+	ledgerInfo, err := azwkscommon.GetLedgerInfoFromURI(testLedgerURI)
+	require.NoError(t, err)
+	ref := w.m.rfsMgr.GenerateRef(ledgerInfo.Remote(), ledgerInfo.ZoneID(), testLedgerID)
+	_, err = w.m.cfgMgr.ExecAddLedger(testLedgerURI, ref, ledgerInfo.Remote(), ledgerInfo.Ledger(), testLedgerID, ledgerInfo.ZoneID(), nil, w.out)
+	require.NoError(t, err)
+	_, _, _, err = w.m.rfsMgr.ExecCheckoutRefFilesForRemote(ledgerInfo.Remote(), ledgerInfo.ZoneID(), ledgerInfo.Ledger(), testLedgerID, objects.ZeroOID, nil, w.out)
+	require.NoError(t, err)
+	refInfo, err := w.m.cfgMgr.LedgerInfo(testLedgerURI)
+	require.NoError(t, err)
+	_, _, err = w.m.rfsMgr.ExecCheckoutHead(azwkscommon.GenerateHeadRef(refInfo.ZoneID(), refInfo.Ledger()), nil, w.out)
+	require.NoError(t, err)
+	w.outputText()
+	return w
+}
+
+// jsonManager returns a manager of the workspace with the JSON output.
+func (w *testWorkspace) jsonManager(t *testing.T) *Manager {
+	t.Helper()
+	return newTestManager(t, w.dir, cli.OutputJSON, w.v)
+}
+
+// setAuthor sets the commit author of the workspace.
+func (w *testWorkspace) setAuthor(name, email string) {
+	w.v.Set(options.FlagName(common.FlagPrefixCommit, common.FlagSuffixCommitAuthorName), name)
+	w.v.Set(options.FlagName(common.FlagPrefixCommit, common.FlagSuffixCommitAuthorEmail), email)
+}
+
+// writeFile writes a file of the workspace.
+func (w *testWorkspace) writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	path := filepath.Join(w.dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// removeFile removes a file of the workspace.
+func (w *testWorkspace) removeFile(t *testing.T, name string) {
+	t.Helper()
+	require.NoError(t, os.Remove(filepath.Join(w.dir, name)))
+}
+
+// commit plans the workspace and stores the commit as if it was applied to the remote ledger and pulled back.
+func (w *testWorkspace) commit(t *testing.T, message string) string {
+	t.Helper()
+	_, err := w.m.execInternalPlan(true, "", w.out)
+	require.NoError(t, err, w.outputText())
+	headCtx, err := w.m.currentHeadContext()
+	require.NoError(t, err)
+	plan, err := w.m.cospMgr.ReadRemoteCodePlan(headCtx.Ref())
+	require.NoError(t, err)
+	langPvd, err := w.m.buildManifestLanguageProvider()
+	require.NoError(t, err)
+	profiles, err := w.m.buildPlanTrees(plan, langPvd)
+	require.NoError(t, err)
+	_, manifestID, err := w.m.cospMgr.ReadCodeSourceConfig()
+	require.NoError(t, err)
+	_, commitObj, err := w.m.buildPlanCommit(profiles, manifestID, headCtx.remoteCommitID, message)
+	require.NoError(t, err)
+	codeObjs, err := w.m.cospMgr.Objects(false, true)
+	require.NoError(t, err)
+	for _, obj := range append(codeObjs, *commitObj) {
+		_, err := w.m.cospMgr.SaveObject(obj.OID(), obj.Content())
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.m.rfsMgr.SaveRefConfig(headCtx.remoteRefInfo.LedgerID(), headCtx.remoteRefInfo.Ref(), commitObj.OID()))
+	require.NoError(t, w.m.rfsMgr.SaveRefWithRemoteConfig(headCtx.headRefInfo.LedgerID(), headCtx.headRefInfo.Ref(), headCtx.remoteRefInfo.Ref(), commitObj.OID()))
+	_, err = w.m.cospMgr.CleanCode(headCtx.Ref())
+	require.NoError(t, err)
+	_, err = w.m.cospMgr.CleanCodeSource()
+	require.NoError(t, err)
+	w.outputText()
+	return commitObj.OID()
+}
+
+// TestObjectStoreConfigError tests that the objects are not read or saved with a fallback store when the config cannot be read.
+func TestObjectStoreConfigError(t *testing.T) {
+	w := newTestWorkspace(t)
+	w.writeFile(t, filepath.Join(".permguard", "config"), "[core\nobjectstore = ")
+	m := newTestManager(t, w.dir, cli.OutputTerminal, w.v)
+	_, err := m.cospMgr.SaveObject("bafyreitestobject0001", []byte("content"))
+	assert.ErrorContains(t, err, "failed to read the object store settings", "config error should be propagated")
+	_, err = m.cospMgr.ReadObject("bafyreitestobject0001")
+	assert.ErrorContains(t, err, "failed to read the object store settings", "config error should be propagated")
+}
+
+func TestReadRemoteCodePlanLegacyRows(t *testing.T) {
+	w := newTestWorkspace(t)
+	ref := "legacy"
+	require.NoError(t, w.m.cospMgr.SaveRemoteCodePlan(ref, nil), "error should be nil")
+	planPath := filepath.Join(w.dir, ".permguard", "code", ref, "plan")
+	require.FileExists(t, planPath, "plan file should exist")
+
+	writeRows := func(rows [][]string) {
+		rowFunc := func(record any) []string { return record.([]string) }
+		require.NoError(t, files.WriteCSVStream(planPath, nil, rows, rowFunc, true), "error should be nil")
+	}
+	writeRows([][]string{
+		{cosp.CodeObjectStateModify, "/", "view-orders", objects.ObjectTypeBlob, "bafyreipolicy", "view-orders", "1", "0", "0", "0"},
+		{cosp.CodeObjectStateModify, "/", "manifest", objects.ObjectTypeBlob, "bafyreimanifest", "", "0", "0", "0", "0"},
+	})
+	legacy, err := w.m.cospMgr.ReadRemoteCodePlan(ref)
+	require.NoError(t, err, "error should be nil")
+	require.Len(t, legacy, 2, "both rows should be read")
+	assert.Equal(t, "view-orders", legacy[0].CodeID, "policy row should be read")
+	assert.Equal(t, uint32(0), legacy[0].DataType, "policy row should have no data type")
+	assert.Equal(t, objects.TreeDataTypeManifest, legacy[1].DataType, "manifest row should keep its data type")
+
+	writeRows([][]string{{cosp.CodeObjectStateModify, "/", "view-orders"}})
+	_, err = w.m.cospMgr.ReadRemoteCodePlan(ref)
+	assert.Error(t, err, "truncated rows should be rejected")
+}
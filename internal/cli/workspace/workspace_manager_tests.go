@@ -0,0 +1,164 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/permguard/permguard/internal/cli/workspace/cosp"
+	"github.com/permguard/permguard/internal/cli/workspace/policytests"
+	"github.com/permguard/permguard/pkg/authz/languages"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/authz/languages/types"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+// evaluationPolicyStore represents a policy store built from code objects for local evaluations.
+type evaluationPolicyStore struct {
+	store     *authzen.PolicyStore
+	policyIDs []string
+	actions   []string
+}
+
+// defaultLanguage returns the language of the first profile, used to evaluate the policies merged across partitions.
+func (m *Manager) defaultLanguage(langPvd *ManifestLanguageProvider) (languages.LanguageAbstraction, *azmanifests.Language, error) {
+	profileKeys := langPvd.ProfileKeys()
+	sort.Strings(profileKeys)
+	if len(profileKeys) == 0 {
+		return nil, nil, errors.New("cli: no profile/partitions are supported")
+	}
+	absLang, err := langPvd.AbstractLanguage(profileKeys[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	lang, err := langPvd.Language(profileKeys[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return absLang, lang, nil
+}
+
+// buildLocalPolicyStore builds the policy store from the blobified code source objects of all partitions.
+func (m *Manager) buildLocalPolicyStore(langPvd *ManifestLanguageProvider) (*evaluationPolicyStore, error) {
+	codeStates, err := m.cospMgr.ReadCodeSourceCodeState()
+	if err != nil {
+		return nil, errors.Join(errors.New("cli: failed to read the code state"), err)
+	}
+	_, manifestID, err := m.cospMgr.ReadCodeSourceConfig()
+	if err != nil {
+		return nil, errors.Join(errors.New("cli: failed to read the code source config"), err)
+	}
+	return m.buildPolicyStore(langPvd, codeStates, m.cospMgr.ReadCodeSourceObject, manifestID)
+}
+
+// buildPolicyStore builds the policy store from the input code object states merging all partitions.
+func (m *Manager) buildPolicyStore(langPvd *ManifestLanguageProvider, codeStates []cosp.CodeObjectState, readObject func(oid string) (*objects.Object, error), version string) (*evaluationPolicyStore, error) {
+	defAbsLang, defLang, err := m.defaultLanguage(langPvd)
+	if err != nil {
+		return nil, err
+	}
+	localStore := &evaluationPolicyStore{
+		store:     &authzen.PolicyStore{},
+		policyIDs: []string{},
+		actions:   []string{},
+	}
+	localStore.store.SetVersion(version)
+	for _, codeState := range codeStates {
+		switch codeState.CodeTypeID {
+		case types.ClassTypeSchemaID, types.ClassTypePolicyID, types.ClassTypeTemplateID, types.ClassTypeTemplateLinkID, types.ClassTypeRoleID:
+		default:
+			continue
+		}
+		obj, err := readObject(codeState.OID)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: failed to read the object %s", codeState.OID), err)
+		}
+		objInfo, err := m.objMar.ObjectInfo(obj)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: failed to read the object info of %s", codeState.OID), err)
+		}
+		content, ok := objInfo.Instance().([]byte)
+		if !ok {
+			return nil, fmt.Errorf("cli: object %s is not a blob object", codeState.OID)
+		}
+		absLang, lang := defAbsLang, defLang
+		if partAbsLang, err := langPvd.AbstractLanguageByPartition(codeState.Partition); err == nil {
+			absLang = partAbsLang
+			lang, _ = langPvd.LanguageByPartition(codeState.Partition)
+		}
+		actions, err := absLang.ReferencedActions(lang, codeState.LanguageID, codeState.LanguageVersionID, codeState.LanguageTypeID, content)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cli: failed to read the actions of %s", codeState.OName), err)
+		}
+		localStore.actions = append(localStore.actions, actions...)
+		switch codeState.CodeTypeID {
+		case types.ClassTypeSchemaID:
+			localStore.store.AddSchema(codeState.OID, objInfo)
+		case types.ClassTypePolicyID:
+			localStore.store.AddPolicy(codeState.OID, objInfo)
+			localStore.policyIDs = append(localStore.policyIDs, codeState.CodeID)
+		case types.ClassTypeTemplateID:
+			localStore.store.AddTemplate(codeState.OID, objInfo)
+		case types.ClassTypeTemplateLinkID:
+			localStore.store.AddTemplateLink(codeState.OID, objInfo)
+			localStore.policyIDs = append(localStore.policyIDs, codeState.CodeID)
+		case types.ClassTypeRoleID:
+			localStore.store.AddRole(codeState.OID, objInfo)
+		}
+	}
+	return localStore, nil
+}
+
+// buildPolicyEvaluator builds an evaluator of the policy tests against the input policy store.
+func (m *Manager) buildPolicyEvaluator(langPvd *ManifestLanguageProvider, localStore *evaluationPolicyStore) (policytests.Evaluator, error) {
+	absLang, lang, err := m.defaultLanguage(langPvd)
+	if err != nil {
+		return nil, err
+	}
+	return func(request *policytests.TestRequest, entities *pdp.Entities) (bool, []string, error) {
+		authzCtx := authzen.AuthorizationModel{}
+		if err := authzCtx.SetSubject(request.Subject.Type, request.Subject.ID, request.Subject.Source, request.Subject.Properties); err != nil {
+			return false, nil, err
+		}
+		if err := authzCtx.SetResource(request.Resource.Type, request.Resource.ID, request.Resource.Properties); err != nil {
+			return false, nil, err
+		}
+		if err := authzCtx.SetAction(request.Action.Name, request.Action.Properties); err != nil {
+			return false, nil, err
+		}
+		if err := authzCtx.SetContext(request.Context); err != nil {
+			return false, nil, err
+		}
+		if entities != nil {
+			if err := authzCtx.SetEntities(entities.Schema, entities.Items); err != nil {
+				return false, nil, err
+			}
+		}
+		decision, err := absLang.AuthorizationCheck(lang, "", localStore.store, &authzCtx)
+		if err != nil {
+			return false, nil, err
+		}
+		if decision == nil {
+			return false, nil, errors.New("cli: nil authorization decision")
+		}
+		return decision.Decision(), decision.DeterminingPolicies(), nil
+	}, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/permguard/permguard/internal/cli/common"
+	"github.com/permguard/permguard/internal/cli/workspace/policytests"
+)
+
+// ExecTest runs the policy tests of the workspace against the local policies.
+func (m *Manager) ExecTest(out common.PrinterOutFunc) (map[string]any, error) {
+	fail := func(output map[string]any, err error) (map[string]any, error) {
+		return output, err
+	}
+	m.ExecPrintContext(nil, out)
+	if !m.isWorkspaceDir() {
+		return fail(nil, m.raiseWrongWorkspaceDirError(out))
+	}
+
+	fileLock, err := m.tryLock()
+	if err != nil {
+		return fail(nil, err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	output, err := m.execInternalValidate(true, out)
+	if err != nil {
+		output, err = fail(output, err)
+		return out(output, "", fmt.Sprintf("Please execute '%s' to perform a comprehensive validation check for any potential errors.", common.CliCommandText("permguard validate")), nil, true), err
+	}
+	return m.execInternalTest(false, output, out)
+}
+
+// execInternalTest runs the policy tests of the workspace against the blobified local policies.
+// Pre-condition: the workspace must have been validated, so that the code source area is up to date.
+func (m *Manager) execInternalTest(internal bool, output map[string]any, out common.PrinterOutFunc) (map[string]any, error) {
+	fail := func(output map[string]any, err error) (map[string]any, error) {
+		return output, err
+	}
+	if output == nil {
+		output = map[string]any{}
+	}
+
+	if m.ctx.IsVerboseTerminalOutput() {
+		out(nil, "test", "Scanning policy test files.", nil, true)
+	}
+	testFiles, err := m.ptestsMgr.ScanTestFiles([]string{hiddenIgnoreFile, hiddenDir, gitDir, gitIgnoreFile}, hiddenIgnoreFile)
+	if err != nil {
+		return fail(output, err)
+	}
+	if len(testFiles) == 0 {
+		if m.ctx.IsVerboseTerminalOutput() {
+			out(nil, "test", "No policy test files found.", nil, true)
+		}
+		if !internal {
+			out(nil, "", "No policy tests found in the workspace.", nil, true)
+		}
+		return output, nil
+	}
+
+	langPvd, err := m.buildManifestLanguageProvider()
+	if err != nil {
+		return fail(output, err)
+	}
+	localStore, err := m.buildLocalPolicyStore(langPvd)
+	if err != nil {
+		return fail(output, err)
+	}
+	evaluator, err := m.buildPolicyEvaluator(langPvd, localStore)
+	if err != nil {
+		return fail(output, err)
+	}
+
+	results := []policytests.TestResult{}
+	for _, testFile := range testFiles {
+		if m.ctx.IsVerboseTerminalOutput() {
+			out(nil, "test", fmt.Sprintf("Running policy tests in %s.", common.FileText(testFile)), nil, true)
+		}
+		suite, err := m.ptestsMgr.ReadTestSuite(testFile)
+		if err != nil {
+			if !internal {
+				out(nil, "", fmt.Sprintf("The policy test file %s is invalid: %s", common.FileText(testFile), common.LogErrorText(err.Error())), nil, true)
+			}
+			return fail(output, err)
+		}
+		results = append(results, m.ptestsMgr.RunTestSuite(testFile, suite, evaluator)...)
+	}
+	coverage := policytests.BuildCoverage(results, localStore.policyIDs, localStore.actions)
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	passed := len(results) - failed
+
+	if m.ctx.IsTerminalOutput() {
+		for _, result := range results {
+			label := fmt.Sprintf("%s > %s #%d", result.File, result.Case, result.Request)
+			switch {
+			case result.Passed:
+				if !internal || m.ctx.IsVerboseTerminalOutput() {
+					out(nil, "", fmt.Sprintf("	%s %s", common.CreateText("PASS"), label), nil, true)
+				}
+			case result.Error != "":
+				out(nil, "", fmt.Sprintf("	%s %s: %s", common.DeleteText("FAIL"), label, common.LogErrorText(result.Error)), nil, true)
+			default:
+				out(nil, "", fmt.Sprintf("	%s %s: expected %s but got %s", common.DeleteText("FAIL"), label,
+					common.KeywordText(result.Expected), common.KeywordText(result.Actual)), nil, true)
+			}
+		}
+		if !internal || m.ctx.IsVerboseTerminalOutput() {
+			if len(coverage.UnfiredPolicies) > 0 {
+				out(nil, "", fmt.Sprintf("\nPolicies never fired: %s", strings.Join(coverage.UnfiredPolicies, ", ")), nil, true)
+			}
+			if len(coverage.UnexercisedActions) > 0 {
+				out(nil, "", fmt.Sprintf("Actions never exercised: %s", strings.Join(coverage.UnexercisedActions, ", ")), nil, true)
+			}
+		}
+		out(nil, "", fmt.Sprintf("\nPolicy tests: %s passed, %s failed.", common.NumberText(passed), common.NumberText(failed)), nil, true)
+	} else if m.ctx.IsJSONOutput() {
+		output["tests"] = map[string]any{
+			"passed":   passed,
+			"failed":   failed,
+			"results":  results,
+			"coverage": coverage,
+		}
+	}
+
+	if failed > 0 {
+		if !internal {
+			out(nil, "", "Please fix the failing policy tests to proceed.", nil, true)
+		}
+		return fail(output, errors.New("cli: policy tests failed. please check the logs for more details"))
+	}
+	return output, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/internal/cli/workspace/policytests"
+)
+
+const (
+	// testOrdersSuite is a policy test file whose expectations match the orders policies.
+	testOrdersSuite = `name: orders
+cases:
+  - name: view orders
+    expect: allow
+    requests:
+      - subject: {type: user, id: amy}
+        resource: {type: Order, id: "1"}
+        action: {name: "Action::view"}
+      - subject: {type: user, id: amy}
+        resource: {type: Order, id: "1"}
+        action: {name: "Action::delete"}
+        expect: deny
+`
+	// testOrdersMismatchSuite is a policy test file whose expectation does not match the orders policies.
+	testOrdersMismatchSuite = `{
+  "cases": [
+    {
+      "name": "deny view",
+      "expect": "deny",
+      "requests": [
+        {"subject": {"type": "user", "id": "amy"}, "resource": {"type": "Order", "id": "1"}, "action": {"name": "Action::view"}}
+      ]
+    }
+  ]
+}`
+)
+
+func TestExecTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		passed  int
+		failed  int
+		errText string
+		texts   []string
+	}{
+		{
+			name:  "without test files",
+			texts: []string{"No policy tests found in the workspace."},
+		},
+		{
+			name:   "passing tests",
+			files:  map[string]string{"tests/orders.test.yaml": testOrdersSuite},
+			passed: 2,
+			texts:  []string{"PASS", "tests/orders.test.yaml > view orders #2", "Policy tests: 2 passed, 0 failed."},
+		},
+		{
+			name:    "expected decision mismatch",
+			files:   map[string]string{"tests/orders.test.yaml": testOrdersSuite, "tests/mismatch.test.json": testOrdersMismatchSuite},
+			passed:  2,
+			failed:  1,
+			errText: "policy tests failed",
+			texts:   []string{"FAIL", "tests/mismatch.test.json > deny view #1: expected deny but got allow", "Please fix the failing policy tests to proceed."},
+		},
+		{
+			name:    "invalid test file",
+			files:   map[string]string{"tests/invalid.test.yaml": "cases:\n  - name: no expectation\n    requests:\n      - subject: {id: amy}\n        resource: {id: \"1\"}\n        action: {name: \"Action::view\"}\n"},
+			errText: "invalid expectation for request 1 of test case 1",
+			texts:   []string{"The policy test file", "is invalid"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := newTestWorkspace(t)
+			w.writeFile(t, "orders.cedar", testOrdersPolicies)
+			for name, content := range test.files {
+				w.writeFile(t, name, content)
+			}
+			_, err := w.m.ExecTest(w.out)
+			text := w.outputText()
+			if test.errText == "" {
+				require.NoError(t, err, text)
+			} else {
+				assert.ErrorContains(t, err, test.errText, "policy tests should fail")
+			}
+			for _, expected := range test.texts {
+				assert.Contains(t, text, expected, "output should report the tests")
+			}
+			if test.passed+test.failed == 0 {
+				return
+			}
+
+			output, err := w.jsonManager(t).ExecTest(w.out)
+			assert.Equal(t, test.failed > 0, err != nil, "error should match the failed tests")
+			summary, ok := output["tests"].(map[string]any)
+			require.True(t, ok, "tests should be in the output")
+			assert.Equal(t, test.passed, summary["passed"], "passed tests should match")
+			assert.Equal(t, test.failed, summary["failed"], "failed tests should match")
+			results, _ := summary["results"].([]policytests.TestResult)
+			require.Len(t, results, test.passed+test.failed, "results should match")
+			for _, result := range results {
+				if !result.Passed {
+					assert.Equal(t, policytests.DecisionDeny, result.Expected, "expected decision should be reported")
+					assert.Equal(t, policytests.DecisionAllow, result.Actual, "actual decision should be reported")
+				}
+			}
+			coverage, _ := summary["coverage"].(*policytests.TestCoverage)
+			require.NotNil(t, coverage, "coverage should be in the output")
+			assert.Empty(t, coverage.UnfiredPolicies, "view-orders should be fired")
+		})
+	}
+}
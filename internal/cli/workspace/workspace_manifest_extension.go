@@ -30,6 +30,7 @@ type languageInfo struct {
 	lang          *azmanifests.Language
 	langAbs       languages.LanguageAbstraction
 	schemaEnabled bool
+	schemaStrict  bool
 }
 
 // ManifestLanguageProvider manifest language provider.
@@ -84,6 +85,18 @@ func (p *ManifestLanguageProvider) SchemaEnabled(profileKey string) bool {
 	return info.schemaEnabled
 }
 
+// SchemaStrict returns whether the schema-strict validation is enabled for the given profile key.
+func (p *ManifestLanguageProvider) SchemaStrict(profileKey string) bool {
+	if p.langInfos == nil {
+		return false
+	}
+	info, ok := p.langInfos[profileKey]
+	if !ok {
+		return false
+	}
+	return info.schemaStrict
+}
+
 // AbstractLanguage gets the abstract language for the input profile key.
 func (p *ManifestLanguageProvider) AbstractLanguage(profileKey string) (languages.LanguageAbstraction, error) {
 	if p.langInfos == nil {
@@ -158,6 +171,7 @@ func (m *Manager) buildManifestLanguageProvider() (*ManifestLanguageProvider, er
 					lang:          &runtime.Language,
 					langAbs:       absLang,
 					schemaEnabled: partition.Schema,
+					schemaStrict:  partition.Schema && partition.Strict,
 				}
 			}
 		}
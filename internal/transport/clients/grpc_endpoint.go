@@ -20,6 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/permguard/permguard/pkg/transport/grpctls"
 )
 
 // parseGrpcEndpoint validates the endpoint has a grpc:// or grpcs:// scheme and returns the host:port and whether TLS is indicated.
@@ -45,3 +49,14 @@ func parseGrpcEndpoint(endpoint string) (string, bool, error) {
 	}
 	return "", false, fmt.Errorf("client: endpoint scheme must be grpc:// or grpcs://, got %q (use grpc:// for plaintext or grpcs:// for TLS)", endpoint)
 }
+
+// newTokenCredentials builds the per-call credentials of the token source of the tls config, nil when it has none.
+func newTokenCredentials(tlsCfg *grpctls.ClientConfig, useTLS bool) (credentials.PerRPCCredentials, error) {
+	if tlsCfg == nil || tlsCfg.TokenSource == "" {
+		return nil, nil
+	}
+	if !useTLS {
+		return nil, errors.New("client: a token source requires the grpcs:// scheme, the token is never sent in plaintext")
+	}
+	return grpctls.NewTokenCredentials(tlsCfg.TokenSource)
+}
@@ -21,18 +21,19 @@ import (
 	"io"
 
 	azpapv1 "github.com/permguard/permguard/internal/agents/services/pap/endpoints/api/v1"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
 // CreateLedger creates a new ledger.
-func (c *GrpcPAPClient) CreateLedger(zoneID int64, kind string, name string) (*pap.Ledger, error) {
+func (c *GrpcPAPClient) CreateLedger(zoneID int64, kind string, name string, trustedKeys []string, description string, labels map[string]string) (*pap.Ledger, error) {
 	client, err := c.getClient()
 	if err != nil {
 		return nil, err
 	}
 	ctx, cancel := grpcContext()
 	defer cancel()
-	ledger, err := client.CreateLedger(ctx, &azpapv1.LedgerCreateRequest{ZoneID: zoneID, Name: name, Kind: kind})
+	ledger, err := client.CreateLedger(ctx, &azpapv1.LedgerCreateRequest{ZoneID: zoneID, Name: name, Kind: kind, TrustedKeys: trustedKeys, Description: description, Labels: labels})
 	if err != nil {
 		return nil, err
 	}
@@ -50,27 +51,55 @@ func (c *GrpcPAPClient) UpdateLedger(ledger *pap.Ledger) (*pap.Ledger, error) {
 	}
 	ctx, cancel := grpcContext()
 	defer cancel()
-	updatedLedger, err := client.UpdateLedger(ctx, &azpapv1.LedgerUpdateRequest{
-		LedgerID: ledger.LedgerID,
-		ZoneID:   ledger.ZoneID,
-		Kind:     ledger.Kind,
-		Name:     ledger.Name,
-	})
+	ledgerRequest := &azpapv1.LedgerUpdateRequest{
+		LedgerID:    ledger.LedgerID,
+		ZoneID:      ledger.ZoneID,
+		Kind:        ledger.Kind,
+		Name:        ledger.Name,
+		Description: ledger.Description,
+	}
+	if ledger.TrustedKeys != nil {
+		ledgerRequest.TrustedKeys = &azpapv1.LedgerTrustedKeys{Keys: ledger.TrustedKeys}
+	}
+	if ledger.Labels != nil {
+		ledgerRequest.Labels = &azpapv1.LedgerLabels{Entries: ledger.Labels}
+	}
+	updatedLedger, err := client.UpdateLedger(ctx, ledgerRequest)
 	if err != nil {
 		return nil, err
 	}
 	return azpapv1.MapGrpcLedgerResponseToAgentLedger(updatedLedger)
 }
 
-// DeleteLedger deletes an ledger.
-func (c *GrpcPAPClient) DeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger, error) {
+// DeleteLedger soft deletes a ledger.
+func (c *GrpcPAPClient) DeleteLedger(zoneID int64, ledgerID string, options *models.DeleteOptions) (*pap.Ledger, error) {
 	client, err := c.getClient()
 	if err != nil {
 		return nil, err
 	}
+	ledgerRequest := &azpapv1.LedgerDeleteRequest{ZoneID: zoneID, LedgerID: ledgerID}
+	if options != nil {
+		ledgerRequest.Force = options.Force
+		ledgerRequest.Confirmation = options.Confirmation
+	}
 	ctx, cancel := grpcContext()
 	defer cancel()
-	ledger, err := client.DeleteLedger(ctx, &azpapv1.LedgerDeleteRequest{ZoneID: zoneID, LedgerID: ledgerID})
+	ledger, err := client.DeleteLedger(ctx, ledgerRequest)
+	if err != nil {
+		return nil, err
+	}
+	return azpapv1.MapGrpcLedgerResponseToAgentLedger(ledger)
+}
+
+// UndeleteLedger restores a soft deleted ledger.
+func (c *GrpcPAPClient) UndeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := grpcContext()
+	defer cancel()
+	ledger, err := client.UndeleteLedger(ctx, &azpapv1.LedgerUndeleteRequest{ZoneID: zoneID, LedgerID: ledgerID})
 	if err != nil {
 		return nil, err
 	}
@@ -79,24 +108,30 @@ func (c *GrpcPAPClient) DeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger
 
 // FetchLedgers returns all ledgers.
 func (c *GrpcPAPClient) FetchLedgers(page int32, pageSize int32, zoneID int64) ([]pap.Ledger, error) {
-	return c.FetchLedgersBy(page, pageSize, zoneID, "", "", "")
+	return c.FetchLedgersBy(page, pageSize, zoneID, "", "", "", nil)
 }
 
 // FetchLedgersByID returns all ledgers filtering by ledger id.
 func (c *GrpcPAPClient) FetchLedgersByID(page int32, pageSize int32, zoneID int64, ledgerID string) ([]pap.Ledger, error) {
-	return c.FetchLedgersBy(page, pageSize, zoneID, ledgerID, "", "")
+	return c.FetchLedgersBy(page, pageSize, zoneID, ledgerID, "", "", nil)
 }
 
 // FetchLedgersByName returns all ledgers filtering by name.
 func (c *GrpcPAPClient) FetchLedgersByName(page int32, pageSize int32, zoneID int64, name string) ([]pap.Ledger, error) {
-	return c.FetchLedgersBy(page, pageSize, zoneID, "", "", name)
+	return c.FetchLedgersBy(page, pageSize, zoneID, "", "", name, nil)
 }
 
-// FetchLedgersBy returns all ledgers filtering by ledger id and name.
-func (c *GrpcPAPClient) FetchLedgersBy(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string) ([]pap.Ledger, error) {
+// FetchLedgersBy returns all ledgers filtering by ledger id, kind, name and labels.
+func (c *GrpcPAPClient) FetchLedgersBy(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string, labels map[string]string) ([]pap.Ledger, error) {
+	ledgers, _, err := c.FetchLedgersPage(page, pageSize, zoneID, ledgerID, kind, name, "", labels, nil)
+	return ledgers, err
+}
+
+// FetchLedgersPage returns a page of ledgers filtering by ledger id, kind, name, name prefix and labels, sorted and resumed as per the options.
+func (c *GrpcPAPClient) FetchLedgersPage(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string, namePrefix string, labels map[string]string, options *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error) {
 	client, err := c.getClient()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	ledgerFetchRequest := &azpapv1.LedgerFetchRequest{}
 	ledgerFetchRequest.Page = &page
@@ -113,11 +148,23 @@ func (c *GrpcPAPClient) FetchLedgersBy(page int32, pageSize int32, zoneID int64,
 	if ledgerID != "" {
 		ledgerFetchRequest.LedgerID = &ledgerID
 	}
+	if namePrefix != "" {
+		ledgerFetchRequest.NamePrefix = &namePrefix
+	}
+	ledgerFetchRequest.Labels = labels
+	if options != nil {
+		ledgerFetchRequest.SortBy = azpapv1.MapStringToPointerString(options.SortBy)
+		ledgerFetchRequest.SortDesc = &options.SortDesc
+		ledgerFetchRequest.Cursor = azpapv1.MapStringToPointerString(options.Cursor)
+		if options.Deleted {
+			ledgerFetchRequest.Deleted = &options.Deleted
+		}
+	}
 	ctx, cancel := grpcContext()
 	defer cancel()
 	stream, err := client.FetchLedgers(ctx, ledgerFetchRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	ledgers := []pap.Ledger{}
 	for {
@@ -126,13 +173,17 @@ func (c *GrpcPAPClient) FetchLedgersBy(page int32, pageSize int32, zoneID int64,
 			break
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		ledger, err := azpapv1.MapGrpcLedgerResponseToAgentLedger(response)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		ledgers = append(ledgers, *ledger)
 	}
-	return ledgers, nil
+	pageInfo, err := azpapv1.MapGrpcTrailerToFetchPageInfo(stream.Trailer())
+	if err != nil {
+		return nil, nil, err
+	}
+	return ledgers, pageInfo, nil
 }
@@ -0,0 +1,93 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+
+	azpapv1 "github.com/permguard/permguard/internal/agents/services/pap/endpoints/api/v1"
+	notpstatemachines "github.com/permguard/permguard/internal/transport/notp/statemachines"
+	aznotpcapture "github.com/permguard/permguard/notp-protocol/pkg/notp/capture"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotptransport "github.com/permguard/permguard/notp-protocol/pkg/notp/transport"
+)
+
+// NOTPStream runs a whole NOTP flow as follower over a single bidirectional stream, with packets capped at the max packet size.
+// The server status is preferred over the state machine error, as it carries the reason of a failure on the server side.
+// When a recorder is given, the packets of the flow are recorded in its capture.
+func (s *GrpcPAPClientSession) NOTPStream(hostHandler aznotpstatemachines.HostHandler, zoneID int64, ledgerID string, flowType aznotpstatemachines.FlowType, maxPacketSize int, recorder *aznotpcapture.Recorder) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx,
+		notpstatemachines.ZoneIDKey, strconv.FormatInt(zoneID, 10),
+		notpstatemachines.LedgerIDKey, ledgerID)
+	stream, err := s.client.NOTPStream(ctx)
+	if err != nil {
+		return err
+	}
+	wireStream, err := aznotptransport.NewWireStream(func(packet *aznotppackets.Packet) error {
+		return stream.Send(&azpapv1.PackMessage{Data: packet.Data})
+	}, func() (*aznotppackets.Packet, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return &aznotppackets.Packet{Data: msg.Data}, nil
+	}, grpcCallTimeout)
+	if err != nil {
+		return err
+	}
+	var inspector *aznotptransport.PacketInspector
+	if recorder != nil {
+		inspector, err = recorder.Inspector()
+		if err != nil {
+			return err
+		}
+	}
+	transportLayer, err := aznotptransport.NewTransportLayer(maxPacketSize, wireStream.TransmitPacket, wireStream.ReceivePacket, inspector)
+	if err != nil {
+		return err
+	}
+	stateMachine, err := aznotpstatemachines.NewFollowerStateMachine(hostHandler, transportLayer)
+	if err != nil {
+		return err
+	}
+	if recorder != nil {
+		recorder.Attach(stateMachine)
+	}
+	_, runErr := stateMachine.Run(nil, flowType)
+	if err := stream.CloseSend(); err != nil && runErr == nil {
+		runErr = err
+	}
+	// Drain the stream to collect the final status of the server.
+	for {
+		_, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Join(runErr, err)
+		}
+	}
+	return runErr
+}
@@ -27,6 +27,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	azpdpv1 "github.com/permguard/permguard/internal/agents/services/pdp/endpoints/api/v1"
+	"github.com/permguard/permguard/pkg/transport/clients"
 	"github.com/permguard/permguard/pkg/transport/grpctls"
 )
 
@@ -36,10 +37,12 @@ type GrpcPDPClient struct {
 	displayEndpoint string
 	collect         func(string)
 	creds           credentials.TransportCredentials
+	tokenCreds      credentials.PerRPCCredentials
 	spiffeCloser    io.Closer
 	mu              sync.Mutex
 	conn            *grpc.ClientConn
 	client          azpdpv1.V1PDPServiceClient
+	cache           *decisionCache
 }
 
 // NewGrpcPDPClient creates a new gRPC client for the PDP service.
@@ -69,11 +72,16 @@ func NewGrpcPDPClient(endpoint string, tlsCfg *grpctls.ClientConfig, collect fun
 			return nil, err
 		}
 	}
+	tokenCreds, err := newTokenCredentials(tlsCfg, useTLS)
+	if err != nil {
+		return nil, err
+	}
 	return &GrpcPDPClient{
 		endpoint:        hostPort,
 		displayEndpoint: endpoint,
 		collect:         collect,
 		creds:           creds,
+		tokenCreds:      tokenCreds,
 		spiffeCloser:    spiffeCloser,
 	}, nil
 }
@@ -91,10 +99,14 @@ func (c *GrpcPDPClient) getClient() (azpdpv1.V1PDPServiceClient, error) {
 	} else {
 		dialOpt = grpc.WithTransportCredentials(insecure.NewCredentials())
 	}
-	conn, err := grpc.NewClient(c.endpoint, dialOpt,
+	dialOpts := []grpc.DialOption{dialOpt,
 		grpc.WithChainUnaryInterceptor(verboseLoggingUnaryInterceptor(c.collect, c.displayEndpoint), tlsHintUnaryInterceptor()),
 		grpc.WithChainStreamInterceptor(verboseLoggingStreamInterceptor(c.collect, c.displayEndpoint), tlsHintStreamInterceptor()),
-	)
+	}
+	if c.tokenCreds != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(c.tokenCreds))
+	}
+	conn, err := grpc.NewClient(c.endpoint, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +115,25 @@ func (c *GrpcPDPClient) getClient() (azpdpv1.V1PDPServiceClient, error) {
 	return c.client, nil
 }
 
+// EnableDecisionCache enables the client-side cache of the authorization check decisions.
+func (c *GrpcPDPClient) EnableDecisionCache(config *clients.DecisionCacheConfig) error {
+	cache, err := newDecisionCache(config)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = cache
+	return nil
+}
+
+// getDecisionCache returns the decision cache, nil if not enabled.
+func (c *GrpcPDPClient) getDecisionCache() *decisionCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache
+}
+
 // Close closes the persistent gRPC connection.
 func (c *GrpcPDPClient) Close() error {
 	c.mu.Lock()
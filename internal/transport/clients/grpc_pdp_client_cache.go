@@ -0,0 +1,228 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/permguard/permguard/pkg/transport/clients"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// decisionCacheEntry is a cached authorization check response.
+type decisionCacheEntry struct {
+	key       string
+	store     string
+	expiresAt time.Time
+	response  *pdp.AuthorizationCheckResponse
+}
+
+// decisionCache is a size bounded cache of the authorization check responses with a time to live.
+// Keys combine the canonical hash of the request with the last known version of its policy store, which is updated
+// from the responses: when a response reports a new version all the decisions of the policy store are dropped.
+type decisionCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	entries    map[string]*list.Element
+	lru        *list.List
+	versions   map[string]string
+}
+
+// newDecisionCache creates a new decision cache.
+func newDecisionCache(config *clients.DecisionCacheConfig) (*decisionCache, error) {
+	if config == nil {
+		return nil, errors.New("client: decision cache config cannot be nil")
+	}
+	if config.TTL <= 0 {
+		return nil, errors.New("client: decision cache ttl must be positive")
+	}
+	if config.MaxEntries <= 0 {
+		return nil, errors.New("client: decision cache max entries must be positive")
+	}
+	return &decisionCache{
+		ttl:        config.TTL,
+		maxEntries: config.MaxEntries,
+		now:        time.Now,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		versions:   map[string]string{},
+	}, nil
+}
+
+// decisionCacheStore returns the key of the policy store of the request.
+func decisionCacheStore(request *pdp.AuthorizationCheckWithDefaultsRequest) string {
+	model := request.AuthorizationModel
+	if model == nil || model.PolicyStore == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%s/%s", model.ZoneID, model.PolicyStore.Kind, model.PolicyStore.ID)
+}
+
+// decisionCacheKey returns the cache key of the hash of a request for a version of its policy store.
+func decisionCacheKey(hash string, version string) string {
+	return fmt.Sprintf("%s@%s", hash, version)
+}
+
+// decisionCacheHash returns the canonical hash of the request, request identifiers excluded.
+// Maps are marshaled with sorted keys, so equal requests have the same hash.
+func decisionCacheHash(request *pdp.AuthorizationCheckWithDefaultsRequest) (string, error) {
+	evaluations := make([]pdp.EvaluationRequest, len(request.Evaluations))
+	for i, evaluation := range request.Evaluations {
+		evaluation.RequestID = ""
+		evaluations[i] = evaluation
+	}
+	canonical := struct {
+		AuthorizationModel *pdp.AuthorizationModelRequest `json:"authorization_model,omitempty"`
+		Subject            *pdp.Subject                   `json:"subject,omitempty"`
+		Resource           *pdp.Resource                  `json:"resource,omitempty"`
+		Action             *pdp.Action                    `json:"action,omitempty"`
+		Context            map[string]any                 `json:"context,omitempty"`
+		Evaluations        []pdp.EvaluationRequest        `json:"evaluations,omitempty"`
+		Options            *pdp.EvaluationsOptions        `json:"options,omitempty"`
+	}{
+		AuthorizationModel: request.AuthorizationModel,
+		Subject:            request.Subject,
+		Resource:           request.Resource,
+		Action:             request.Action,
+		Context:            request.Context,
+		Evaluations:        evaluations,
+		Options:            request.Options,
+	}
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return "", errors.Join(errors.New("client: failed to marshal the decision cache key"), err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// get returns a copy of the cached response of the request, if any and not expired.
+// The hash is looked up for the last known version of the policy store.
+func (c *decisionCache) get(hash string, store string, request *pdp.AuthorizationCheckWithDefaultsRequest) *pdp.AuthorizationCheckResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	version, ok := c.versions[store]
+	if !ok {
+		return nil
+	}
+	elem, ok := c.entries[decisionCacheKey(hash, version)]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*decisionCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	return decisionCacheResponse(entry.response, request)
+}
+
+// put caches the response of the request, dropping the decisions of the policy store if its version has changed.
+// Responses without a policy store version are not cached, as the policy store has not been evaluated.
+func (c *decisionCache) put(hash string, store string, response *pdp.AuthorizationCheckResponse) {
+	if response == nil || len(response.PolicyStoreVersion) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	version := response.PolicyStoreVersion
+	if known, ok := c.versions[store]; ok && known != version {
+		for elem := c.lru.Front(); elem != nil; {
+			next := elem.Next()
+			if elem.Value.(*decisionCacheEntry).store == store {
+				c.remove(elem)
+			}
+			elem = next
+		}
+	}
+	c.versions[store] = version
+	key := decisionCacheKey(hash, version)
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	entry := &decisionCacheEntry{
+		key:       key,
+		store:     store,
+		expiresAt: c.now().Add(c.ttl),
+		response:  decisionCacheResponse(response, nil),
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove removes the element from the cache.
+func (c *decisionCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*decisionCacheEntry).key)
+}
+
+// decisionCacheResponse returns a copy of the response, setting the request identifiers of the request if any.
+func decisionCacheResponse(response *pdp.AuthorizationCheckResponse, request *pdp.AuthorizationCheckWithDefaultsRequest) *pdp.AuthorizationCheckResponse {
+	target := *response
+	target.Context = decisionCacheContext(response.Context)
+	if response.Evaluations != nil {
+		target.Evaluations = make([]pdp.EvaluationResponse, len(response.Evaluations))
+		for i, evaluation := range response.Evaluations {
+			evaluation.Context = decisionCacheContext(evaluation.Context)
+			target.Evaluations[i] = evaluation
+		}
+	}
+	if request == nil {
+		return &target
+	}
+	target.RequestID = request.RequestID
+	for i := range target.Evaluations {
+		requestID := request.RequestID
+		if i < len(request.Evaluations) && len(request.Evaluations[i].RequestID) > 0 {
+			requestID = request.Evaluations[i].RequestID
+		}
+		target.Evaluations[i].RequestID = requestID
+	}
+	if len(target.Evaluations) == 1 {
+		target.RequestID = target.Evaluations[0].RequestID
+	}
+	return &target
+}
+
+// decisionCacheContext returns a copy of the context response.
+func decisionCacheContext(context *pdp.ContextResponse) *pdp.ContextResponse {
+	if context == nil {
+		return nil
+	}
+	target := *context
+	if context.ReasonAdmin != nil {
+		reason := *context.ReasonAdmin
+		target.ReasonAdmin = &reason
+	}
+	if context.ReasonUser != nil {
+		reason := *context.ReasonUser
+		target.ReasonUser = &reason
+	}
+	return &target
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/transport/clients"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// newTestDecisionRequest creates an authorization check request for the decision cache tests.
+func newTestDecisionRequest(requestID string, subjectID string, context map[string]any) *pdp.AuthorizationCheckWithDefaultsRequest {
+	request := &pdp.AuthorizationCheckWithDefaultsRequest{
+		RequestID: requestID,
+		Subject:   &pdp.Subject{Type: "user", ID: subjectID},
+		Resource:  &pdp.Resource{Type: "MagicFarmacia::Platform::Order", ID: "o1"},
+		Action:    &pdp.Action{Name: "MagicFarmacia::Platform::Action::view"},
+		Context:   context,
+	}
+	request.AuthorizationModel = &pdp.AuthorizationModelRequest{
+		ZoneID:      273165098782,
+		PolicyStore: &pdp.PolicyStore{Kind: "ledger", ID: "fd1ac44e4afa4fc4beec622494d3175a"},
+	}
+	return request
+}
+
+// TestDecisionCacheHash tests the canonical hash of the requests.
+func TestDecisionCacheHash(t *testing.T) {
+	assert := assert.New(t)
+	hash1, err := decisionCacheHash(newTestDecisionRequest("req-1", "amy", map[string]any{"a": 1, "b": "x"}))
+	require.NoError(t, err, "decisionCacheHash should not return an error")
+	hash2, err := decisionCacheHash(newTestDecisionRequest("req-2", "amy", map[string]any{"b": "x", "a": 1}))
+	require.NoError(t, err, "decisionCacheHash should not return an error")
+	assert.Equal(hash1, hash2, "hash should not depend on request ids and map order")
+	hash3, err := decisionCacheHash(newTestDecisionRequest("req-1", "bob", map[string]any{"a": 1, "b": "x"}))
+	require.NoError(t, err, "decisionCacheHash should not return an error")
+	assert.NotEqual(hash1, hash3, "hash should depend on the subject")
+	hash4, err := decisionCacheHash(newTestDecisionRequest("req-1", "amy", map[string]any{"a": 2, "b": "x"}))
+	require.NoError(t, err, "decisionCacheHash should not return an error")
+	assert.NotEqual(hash1, hash4, "hash should depend on the context")
+}
+
+// TestDecisionCache tests the time to live, the size bound and the version invalidation of the decision cache.
+func TestDecisionCache(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := newDecisionCache(&clients.DecisionCacheConfig{TTL: 0, MaxEntries: 10})
+	assert.Error(err, "newDecisionCache should return an error for an invalid ttl")
+	_, err = newDecisionCache(&clients.DecisionCacheConfig{TTL: time.Minute, MaxEntries: 0})
+	assert.Error(err, "newDecisionCache should return an error for invalid max entries")
+
+	cache, err := newDecisionCache(&clients.DecisionCacheConfig{TTL: time.Minute, MaxEntries: 2})
+	require.NoError(t, err, "newDecisionCache should not return an error")
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	requests := []*pdp.AuthorizationCheckWithDefaultsRequest{
+		newTestDecisionRequest("req-1", "amy", nil),
+		newTestDecisionRequest("req-2", "bob", nil),
+		newTestDecisionRequest("req-3", "carl", nil),
+	}
+	hashes := make([]string, len(requests))
+	for i, request := range requests {
+		hashes[i], err = decisionCacheHash(request)
+		require.NoError(t, err, "decisionCacheHash should not return an error")
+	}
+	store := decisionCacheStore(requests[0])
+	response := func(requestID string, version string) *pdp.AuthorizationCheckResponse {
+		return &pdp.AuthorizationCheckResponse{
+			RequestID:          requestID,
+			Decision:           true,
+			Evaluations:        []pdp.EvaluationResponse{{RequestID: requestID, Decision: true, Context: &pdp.ContextResponse{ID: "ctx"}}},
+			PolicyStoreVersion: version,
+		}
+	}
+
+	assert.Nil(cache.get(hashes[0], store, requests[0]), "empty cache should miss")
+	cache.put(hashes[0], store, response("req-1", ""))
+	assert.Nil(cache.get(hashes[0], store, requests[0]), "responses without version should not be cached")
+
+	cache.put(hashes[0], store, response("req-1", "v1"))
+	cached := cache.get(hashes[0], store, newTestDecisionRequest("req-9", "amy", nil))
+	require.NotNil(t, cached, "cache should hit")
+	assert.True(cached.Decision, "cached decision mismatch")
+	assert.Equal("req-9", cached.RequestID, "cached response should carry the request id of the request")
+	assert.Equal("req-9", cached.Evaluations[0].RequestID, "cached evaluation should carry the request id of the request")
+	assert.Equal("v1", cached.PolicyStoreVersion, "cached version mismatch")
+
+	cache.put(hashes[1], store, response("req-2", "v1"))
+	cache.put(hashes[2], store, response("req-3", "v1"))
+	assert.Nil(cache.get(hashes[0], store, requests[0]), "least recently used decision should be evicted")
+	assert.NotNil(cache.get(hashes[1], store, requests[1]), "cache should hit")
+	assert.NotNil(cache.get(hashes[2], store, requests[2]), "cache should hit")
+
+	now = now.Add(2 * time.Minute)
+	assert.Nil(cache.get(hashes[1], store, requests[1]), "expired decision should miss")
+
+	cache.put(hashes[1], store, response("req-2", "v1"))
+	cache.put(hashes[0], store, response("req-1", "v2"))
+	assert.Nil(cache.get(hashes[1], store, requests[1]), "decisions of a previous version should be dropped")
+	assert.NotNil(cache.get(hashes[0], store, requests[0]), "cache should hit for the new version")
+	assert.Equal(1, cache.lru.Len(), "cache size mismatch")
+}
@@ -21,8 +21,30 @@ import (
 	"github.com/permguard/permguard/pkg/transport/models/pdp"
 )
 
-// AuthorizationCheck checks the authorization request.
+// AuthorizationCheck checks the authorization request, serving it from the decision cache if enabled.
 func (c *GrpcPDPClient) AuthorizationCheck(request *pdp.AuthorizationCheckWithDefaultsRequest) (*pdp.AuthorizationCheckResponse, error) {
+	cache := c.getDecisionCache()
+	if cache == nil || request == nil {
+		return c.authorizationCheck(request)
+	}
+	hash, err := decisionCacheHash(request)
+	if err != nil {
+		return nil, err
+	}
+	store := decisionCacheStore(request)
+	if response := cache.get(hash, store, request); response != nil {
+		return response, nil
+	}
+	response, err := c.authorizationCheck(request)
+	if err != nil {
+		return nil, err
+	}
+	cache.put(hash, store, response)
+	return response, nil
+}
+
+// authorizationCheck checks the authorization request against the PDP.
+func (c *GrpcPDPClient) authorizationCheck(request *pdp.AuthorizationCheckWithDefaultsRequest) (*pdp.AuthorizationCheckResponse, error) {
 	client, err := c.getClient()
 	if err != nil {
 		return nil, err
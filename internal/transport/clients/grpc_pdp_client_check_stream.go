@@ -0,0 +1,58 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"io"
+
+	azpdpv1 "github.com/permguard/permguard/internal/agents/services/pdp/endpoints/api/v1"
+	"github.com/permguard/permguard/pkg/transport/models/pdp"
+)
+
+// AuthorizationCheckStream checks the authorization request passing the evaluations to the handler in order as soon as they are ready.
+func (c *GrpcPDPClient) AuthorizationCheckStream(request *pdp.AuthorizationCheckWithDefaultsRequest, handler func(response *pdp.AuthorizationCheckStreamResponse) error) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	req, err := azpdpv1.MapAgentAuthorizationCheckRequestToGrpcAuthorizationCheckRequest(request)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := grpcContext()
+	defer cancel()
+	stream, err := client.AuthorizationCheckStream(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		streamResponse, err := azpdpv1.MapGrpcAuthorizationCheckStreamResponseToAgentAuthorizationCheckStreamResponse(response)
+		if err != nil {
+			return err
+		}
+		if err := handler(streamResponse); err != nil {
+			return err
+		}
+	}
+}
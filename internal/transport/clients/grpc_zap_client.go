@@ -21,18 +21,19 @@ import (
 	"io"
 
 	azzapv1 "github.com/permguard/permguard/internal/agents/services/zap/endpoints/api/v1"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
 // CreateZone creates a new zone.
-func (c *GrpcZAPClient) CreateZone(name string) (*zap.Zone, error) {
+func (c *GrpcZAPClient) CreateZone(name string, description string, labels map[string]string) (*zap.Zone, error) {
 	client, err := c.getClient()
 	if err != nil {
 		return nil, err
 	}
 	ctx, cancel := grpcContext()
 	defer cancel()
-	zone, err := client.CreateZone(ctx, &azzapv1.ZoneCreateRequest{Name: name})
+	zone, err := client.CreateZone(ctx, &azzapv1.ZoneCreateRequest{Name: name, Description: description, Labels: labels})
 	if err != nil {
 		return nil, err
 	}
@@ -50,25 +51,50 @@ func (c *GrpcZAPClient) UpdateZone(zone *zap.Zone) (*zap.Zone, error) {
 	}
 	ctx, cancel := grpcContext()
 	defer cancel()
-	updatedZone, err := client.UpdateZone(ctx, &azzapv1.ZoneUpdateRequest{
-		ZoneID: zone.ZoneID,
-		Name:   zone.Name,
-	})
+	zoneRequest := &azzapv1.ZoneUpdateRequest{
+		ZoneID:      zone.ZoneID,
+		Name:        zone.Name,
+		Description: zone.Description,
+	}
+	if zone.Labels != nil {
+		zoneRequest.Labels = &azzapv1.ZoneLabels{Entries: zone.Labels}
+	}
+	updatedZone, err := client.UpdateZone(ctx, zoneRequest)
 	if err != nil {
 		return nil, err
 	}
 	return azzapv1.MapGrpcZoneResponseToAgentZone(updatedZone)
 }
 
-// DeleteZone deletes a zone.
-func (c *GrpcZAPClient) DeleteZone(zoneID int64) (*zap.Zone, error) {
+// DeleteZone soft deletes a zone.
+func (c *GrpcZAPClient) DeleteZone(zoneID int64, options *models.DeleteOptions) (*zap.Zone, error) {
+	client, err := c.getClient()
+	if err != nil {
+		return nil, err
+	}
+	zoneRequest := &azzapv1.ZoneDeleteRequest{ZoneID: zoneID}
+	if options != nil {
+		zoneRequest.Force = options.Force
+		zoneRequest.Confirmation = options.Confirmation
+	}
+	ctx, cancel := grpcContext()
+	defer cancel()
+	zone, err := client.DeleteZone(ctx, zoneRequest)
+	if err != nil {
+		return nil, err
+	}
+	return azzapv1.MapGrpcZoneResponseToAgentZone(zone)
+}
+
+// UndeleteZone restores a soft deleted zone.
+func (c *GrpcZAPClient) UndeleteZone(zoneID int64) (*zap.Zone, error) {
 	client, err := c.getClient()
 	if err != nil {
 		return nil, err
 	}
 	ctx, cancel := grpcContext()
 	defer cancel()
-	zone, err := client.DeleteZone(ctx, &azzapv1.ZoneDeleteRequest{ZoneID: zoneID})
+	zone, err := client.UndeleteZone(ctx, &azzapv1.ZoneUndeleteRequest{ZoneID: zoneID})
 	if err != nil {
 		return nil, err
 	}
@@ -77,24 +103,30 @@ func (c *GrpcZAPClient) DeleteZone(zoneID int64) (*zap.Zone, error) {
 
 // FetchZones returns all zones.
 func (c *GrpcZAPClient) FetchZones(page int32, pageSize int32) ([]zap.Zone, error) {
-	return c.FetchZonesBy(page, pageSize, 0, "")
+	return c.FetchZonesBy(page, pageSize, 0, "", nil)
 }
 
 // FetchZonesByID returns all zones filtering by zone id.
 func (c *GrpcZAPClient) FetchZonesByID(page int32, pageSize int32, zoneID int64) ([]zap.Zone, error) {
-	return c.FetchZonesBy(page, pageSize, zoneID, "")
+	return c.FetchZonesBy(page, pageSize, zoneID, "", nil)
 }
 
 // FetchZonesByName returns all zones filtering by name.
 func (c *GrpcZAPClient) FetchZonesByName(page int32, pageSize int32, name string) ([]zap.Zone, error) {
-	return c.FetchZonesBy(page, pageSize, 0, name)
+	return c.FetchZonesBy(page, pageSize, 0, name, nil)
 }
 
-// FetchZonesBy returns all zones filtering by zone id and name.
-func (c *GrpcZAPClient) FetchZonesBy(page int32, pageSize int32, zoneID int64, name string) ([]zap.Zone, error) {
+// FetchZonesBy returns all zones filtering by zone id, name and labels.
+func (c *GrpcZAPClient) FetchZonesBy(page int32, pageSize int32, zoneID int64, name string, labels map[string]string) ([]zap.Zone, error) {
+	zones, _, err := c.FetchZonesPage(page, pageSize, zoneID, name, "", labels, nil)
+	return zones, err
+}
+
+// FetchZonesPage returns a page of zones filtering by zone id, name, name prefix and labels, sorted and resumed as per the options.
+func (c *GrpcZAPClient) FetchZonesPage(page int32, pageSize int32, zoneID int64, name string, namePrefix string, labels map[string]string, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error) {
 	client, err := c.getClient()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	zoneFetchRequest := &azzapv1.ZoneFetchRequest{}
 	zoneFetchRequest.Page = &page
@@ -105,11 +137,23 @@ func (c *GrpcZAPClient) FetchZonesBy(page int32, pageSize int32, zoneID int64, n
 	if name != "" {
 		zoneFetchRequest.Name = &name
 	}
+	if namePrefix != "" {
+		zoneFetchRequest.NamePrefix = &namePrefix
+	}
+	zoneFetchRequest.Labels = labels
+	if options != nil {
+		zoneFetchRequest.SortBy = azzapv1.MapStringToPointerString(options.SortBy)
+		zoneFetchRequest.SortDesc = &options.SortDesc
+		zoneFetchRequest.Cursor = azzapv1.MapStringToPointerString(options.Cursor)
+		if options.Deleted {
+			zoneFetchRequest.Deleted = &options.Deleted
+		}
+	}
 	ctx, cancel := grpcContext()
 	defer cancel()
 	stream, err := client.FetchZones(ctx, zoneFetchRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	zones := []zap.Zone{}
 	for {
@@ -118,13 +162,17 @@ func (c *GrpcZAPClient) FetchZonesBy(page int32, pageSize int32, zoneID int64, n
 			break
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		zone, err := azzapv1.MapGrpcZoneResponseToAgentZone(response)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		zones = append(zones, *zone)
 	}
-	return zones, nil
+	pageInfo, err := azzapv1.MapGrpcTrailerToFetchPageInfo(stream.Trailer())
+	if err != nil {
+		return nil, nil, err
+	}
+	return zones, pageInfo, nil
 }
@@ -36,6 +36,7 @@ type GrpcZAPClient struct {
 	displayEndpoint string
 	collect         func(string)
 	creds           credentials.TransportCredentials
+	tokenCreds      credentials.PerRPCCredentials
 	spiffeCloser    io.Closer
 	mu              sync.Mutex
 	conn            *grpc.ClientConn
@@ -69,11 +70,16 @@ func NewGrpcZAPClient(endpoint string, tlsCfg *grpctls.ClientConfig, collect fun
 			return nil, err
 		}
 	}
+	tokenCreds, err := newTokenCredentials(tlsCfg, useTLS)
+	if err != nil {
+		return nil, err
+	}
 	return &GrpcZAPClient{
 		endpoint:        hostPort,
 		displayEndpoint: endpoint,
 		collect:         collect,
 		creds:           creds,
+		tokenCreds:      tokenCreds,
 		spiffeCloser:    spiffeCloser,
 	}, nil
 }
@@ -91,10 +97,14 @@ func (c *GrpcZAPClient) getClient() (azzapv1.V1ZAPServiceClient, error) {
 	} else {
 		dialOpt = grpc.WithTransportCredentials(insecure.NewCredentials())
 	}
-	conn, err := grpc.NewClient(c.endpoint, dialOpt,
+	dialOpts := []grpc.DialOption{dialOpt,
 		grpc.WithChainUnaryInterceptor(verboseLoggingUnaryInterceptor(c.collect, c.displayEndpoint), tlsHintUnaryInterceptor()),
 		grpc.WithChainStreamInterceptor(verboseLoggingStreamInterceptor(c.collect, c.displayEndpoint), tlsHintStreamInterceptor()),
-	)
+	}
+	if c.tokenCreds != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(c.tokenCreds))
+	}
+	conn, err := grpc.NewClient(c.endpoint, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,35 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachines
+
+import (
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
+)
+
+// AcknowledgedMessageValue returns the message value acknowledging a state packet.
+func AcknowledgedMessageValue() uint64 {
+	return aznotppackets.CombineUint32toUint64(aznotpsmpackets.AcknowledgedValue, aznotpsmpackets.UnknownValue)
+}
+
+// DataStreamMessageValue returns the message value of a data stream packet, either active or completed.
+func DataStreamMessageValue(hasMore bool) uint64 {
+	if hasMore {
+		return aznotppackets.CombineUint32toUint64(aznotpsmpackets.AcknowledgedValue, aznotpsmpackets.ActiveDataStreamValue)
+	}
+	return aznotppackets.CombineUint32toUint64(aznotpsmpackets.AcknowledgedValue, aznotpsmpackets.CompletedDataStreamValue)
+}
@@ -25,4 +25,6 @@ const (
 	LocalRefStatePacketType = uint32(22)
 	// ObjectStatePacketType represents the type of the object state packet.
 	ObjectStatePacketType = uint32(23)
+	// TransferResumeStatePacketType represents the type of the transfer resume state packet.
+	TransferResumeStatePacketType = uint32(24)
 )
@@ -32,6 +32,8 @@ type LocalRefStatePacket struct {
 	NumberOfCommits uint32 `cbor:"4,keyasint"`
 	// OpCode is the operation code of the packet.
 	OpCode uint16 `cbor:"5,keyasint"`
+	// MaxPacketSize is the maximum packet size accepted by the sender, zero if not advertised.
+	MaxPacketSize uint32 `cbor:"6,keyasint,omitempty"`
 }
 
 // Type returns the type of the packet.
@@ -18,8 +18,13 @@ package packets
 
 import (
 	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
+// objectStatePacketOverhead is the room in bytes kept for the id, the type and the encoding of an object state packet.
+const objectStatePacketOverhead = 1024
+
 // ObjectStatePacket is object state description packet.
 type ObjectStatePacket struct {
 	// OID is the OID.
@@ -44,3 +49,39 @@ func (p *ObjectStatePacket) Serialize() ([]byte, error) {
 func (p *ObjectStatePacket) Deserialize(data []byte) error {
 	return aznotppackets.DeserializeCBOR(data, p)
 }
+
+// NewObjectStatePackets converts the object states into object state packets.
+func NewObjectStatePackets(objs []pap.ObjectState) []aznotppackets.Packetable {
+	packetables := make([]aznotppackets.Packetable, 0, len(objs))
+	for _, obj := range objs {
+		packetables = append(packetables, &ObjectStatePacket{
+			OID:     obj.OID,
+			OType:   obj.OType,
+			Content: obj.Content,
+		})
+	}
+	return packetables
+}
+
+// ReadObjectStatePackets converts the packetables into object states.
+func ReadObjectStatePackets(packetables []aznotppackets.Packetable) ([]pap.ObjectState, error) {
+	objs := make([]pap.ObjectState, 0, len(packetables))
+	for _, packetable := range packetables {
+		packet := &ObjectStatePacket{}
+		if err := aznotppackets.ConvertPacketable(packetable, packet); err != nil {
+			return nil, err
+		}
+		objs = append(objs, pap.ObjectState{
+			OID:     packet.OID,
+			OType:   packet.OType,
+			Content: packet.Content,
+		})
+	}
+	return objs, nil
+}
+
+// NewObjectChunkAssembler creates an assembler of the object state packets fragmented across a data stream.
+// A reassembled packet is capped at the size of the largest object, or at the max packet size when larger.
+func NewObjectChunkAssembler(maxPacketSize int) (*aznotppackets.ChunkAssembler, error) {
+	return aznotppackets.NewChunkAssembler(max(maxPacketSize, objects.DefaultMaxObjectSize+objectStatePacketOverhead))
+}
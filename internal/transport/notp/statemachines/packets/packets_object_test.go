@@ -21,6 +21,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
 // TestObjectStatePacket tests the object  state packet
@@ -43,3 +46,28 @@ func TestObjectStatePacket(t *testing.T) {
 	assert.Equal(packet.OType, newPacket.OType)
 	assert.Equal(packet.Content, newPacket.Content)
 }
+
+// TestObjectStatePacketsConversion tests the conversion of object states to and from packetables.
+func TestObjectStatePacketsConversion(t *testing.T) {
+	assert := assert.New(t)
+
+	objs := []pap.ObjectState{
+		{OID: "oid-commit", OType: "commit", Content: []byte("commit-content")},
+		{OID: "oid-blob", OType: "blob", Content: []byte("blob-content")},
+	}
+	packetables := NewObjectStatePackets(objs)
+	require.Len(t, packetables, 2)
+
+	wired := []aznotppackets.Packetable{}
+	for _, packetable := range packetables {
+		data, err := packetable.Serialize()
+		require.NoError(t, err)
+		wired = append(wired, &aznotppackets.Packet{Data: data})
+	}
+	readObjs, err := ReadObjectStatePackets(wired)
+	require.NoError(t, err)
+	assert.Equal(objs, readObjs)
+
+	_, err = ReadObjectStatePackets([]aznotppackets.Packetable{&aznotppackets.Packet{Data: []byte("invalid")}})
+	assert.Error(err)
+}
@@ -28,6 +28,10 @@ type RemoteRefStatePacket struct {
 	RefCommit string `cbor:"2,keyasint"`
 	// OpCode is the operation code of the packet.
 	OpCode uint16 `cbor:"3,keyasint"`
+	// MaxPacketSize is the maximum packet size accepted by the sender, zero if not advertised.
+	MaxPacketSize uint32 `cbor:"4,keyasint,omitempty"`
+	// HaveCommits are the commits whose objects are already held by the sender.
+	HaveCommits []string `cbor:"5,keyasint,omitempty"`
 }
 
 // Type returns the type of the packet.
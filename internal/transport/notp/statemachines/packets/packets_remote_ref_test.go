@@ -31,6 +31,7 @@ func TestRemoteRefStatePacket(t *testing.T) {
 	packet.OpCode = 0x15
 	packet.RefPrevCommit = "477161cc-83c5-4004-8901-a61727ce045a"
 	packet.RefCommit = "952dd2f1-1ba2-44b5-92d0-1b6fb8d6f3c0"
+	packet.HaveCommits = []string{"0b3a1b8e-5d0f-4bb1-9d6c-3f1f9a3e2c71"}
 
 	data, err := packet.Serialize()
 	require.NoError(t, err)
@@ -42,4 +43,5 @@ func TestRemoteRefStatePacket(t *testing.T) {
 	assert.Equal(packet.RefPrevCommit, newPacket.RefPrevCommit)
 	assert.Equal(packet.RefCommit, newPacket.RefCommit)
 	assert.Equal(packet.OpCode, newPacket.OpCode)
+	assert.Equal(packet.HaveCommits, newPacket.HaveCommits)
 }
@@ -0,0 +1,66 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package packets
+
+import (
+	"errors"
+
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+)
+
+// TransferResumePacket is the packet to confirm the objects of a transfer and to resume it after an interruption.
+type TransferResumePacket struct {
+	// Token is the resume token of the transfer.
+	Token string `cbor:"1,keyasint"`
+	// ConfirmedObjects is the number of objects confirmed by the receiver.
+	ConfirmedObjects uint64 `cbor:"2,keyasint"`
+}
+
+// Type returns the type of the packet.
+func (p *TransferResumePacket) Type() uint64 {
+	return aznotppackets.CombineUint32toUint64(TransferResumeStatePacketType, 0)
+}
+
+// Serialize serializes the packet.
+func (p *TransferResumePacket) Serialize() ([]byte, error) {
+	return aznotppackets.SerializeCBOR(p)
+}
+
+// Deserialize deserializes the packet.
+func (p *TransferResumePacket) Deserialize(data []byte) error {
+	return aznotppackets.DeserializeCBOR(data, p)
+}
+
+// NegotiateMaxPacketSize returns the packet size both peers accept, given the local maximum and the one advertised by the peer.
+func NegotiateMaxPacketSize(localMaxPacketSize int, peerMaxPacketSize uint32) int {
+	if peerMaxPacketSize > 0 && uint64(peerMaxPacketSize) < uint64(localMaxPacketSize) {
+		return int(peerMaxPacketSize)
+	}
+	return localMaxPacketSize
+}
+
+// ReadTransferResumePacket reads the transfer resume packet at the head of the packetables.
+func ReadTransferResumePacket(packetables []aznotppackets.Packetable) (*TransferResumePacket, error) {
+	if len(packetables) == 0 {
+		return nil, errors.New("notp: missing transfer resume packet")
+	}
+	packet := &TransferResumePacket{}
+	if err := aznotppackets.ConvertPacketable(packetables[0], packet); err != nil {
+		return nil, err
+	}
+	return packet, nil
+}
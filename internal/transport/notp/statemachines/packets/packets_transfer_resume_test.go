@@ -0,0 +1,52 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package packets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransferResumePacket tests the transfer resume packet
+func TestTransferResumePacket(t *testing.T) {
+	assert := assert.New(t)
+
+	packet := &TransferResumePacket{}
+	packet.Token = "0e4f7c0a-6c86-4a5b-9c2e-6ad3c1f0b8e1"
+	packet.ConfirmedObjects = 42
+
+	data, err := packet.Serialize()
+	require.NoError(t, err)
+
+	newPacket := &TransferResumePacket{}
+	err = newPacket.Deserialize(data)
+
+	require.NoError(t, err)
+	assert.Equal(packet.Token, newPacket.Token)
+	assert.Equal(packet.ConfirmedObjects, newPacket.ConfirmedObjects)
+}
+
+// TestNegotiateMaxPacketSize tests the negotiation of the maximum packet size
+func TestNegotiateMaxPacketSize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(16384, NegotiateMaxPacketSize(16384, 0))
+	assert.Equal(4096, NegotiateMaxPacketSize(16384, 4096))
+	assert.Equal(4096, NegotiateMaxPacketSize(4096, 16384))
+}
@@ -0,0 +1,272 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotptransport "github.com/permguard/permguard/notp-protocol/pkg/notp/transport"
+)
+
+const (
+	// DirectionSent represents a packet sent by the recorded peer.
+	DirectionSent = "sent"
+	// DirectionReceived represents a packet received by the recorded peer.
+	DirectionReceived = "received"
+
+	// RoleFollower represents the follower peer of a flow.
+	RoleFollower = "follower"
+	// RoleLeader represents the leader peer of a flow.
+	RoleLeader = "leader"
+)
+
+// RecordPacket is a data packet of a recorded packet.
+type RecordPacket struct {
+	// Type is the type of the data packet.
+	Type uint64 `json:"type"`
+	// Payload is the decoded CBOR payload of the data packet.
+	Payload any `json:"payload,omitempty"`
+}
+
+// Record is a recorded packet, one per line of a capture.
+type Record struct {
+	// Seq is the sequence number of the packet in its session.
+	Seq uint64 `json:"seq"`
+	// Time is the time the packet was recorded at.
+	Time time.Time `json:"time"`
+	// Session identifies the flow the packet belongs to.
+	Session string `json:"session,omitempty"`
+	// Role is the role of the recorded peer.
+	Role string `json:"role"`
+	// Direction is the direction of the packet from the recorded peer.
+	Direction string `json:"direction"`
+	// FlowType is the flow type of the state machine.
+	FlowType uint64 `json:"flow_type"`
+	// StateID is the state of the state machine.
+	StateID uint16 `json:"state_id"`
+	// Data is the uncompressed packet, which can be replayed through a transport layer.
+	Data []byte `json:"data"`
+	// Packets are the decoded data packets.
+	Packets []RecordPacket `json:"packets,omitempty"`
+}
+
+// Packet returns the recorded packet.
+func (r *Record) Packet() *aznotppackets.Packet {
+	return &aznotppackets.Packet{Data: r.Data}
+}
+
+// Writer writes records to a capture, it is safe for concurrent use by multiple recorders.
+type Writer struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriter creates a new capture writer.
+func NewWriter(w io.Writer) (*Writer, error) {
+	if w == nil {
+		return nil, errors.New("notp: nil capture writer")
+	}
+	return &Writer{w: w}, nil
+}
+
+// OpenFile opens a capture file, records are appended to the existing ones.
+func OpenFile(path string) (*Writer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("notp: failed to open capture file %s: %w", path, err)
+	}
+	return &Writer{w: file, closer: file}, nil
+}
+
+// Close flushes the capture to the storage and closes it, the records written after are discarded.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closer == nil {
+		return nil
+	}
+	closer := w.closer
+	w.w, w.closer = io.Discard, nil
+	var syncErr error
+	if file, ok := closer.(*os.File); ok {
+		syncErr = file.Sync()
+	}
+	return errors.Join(syncErr, closer.Close())
+}
+
+// write writes a record as a single line.
+func (w *Writer) write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.w.Write(append(data, '\n'))
+	return err
+}
+
+// NewRecorder creates a recorder for the packets of a session.
+func (w *Writer) NewRecorder(role, session string) *Recorder {
+	return &Recorder{
+		writer:  w,
+		role:    role,
+		session: session,
+	}
+}
+
+// Recorder records the packets of a session through the inspector of its transport layer.
+type Recorder struct {
+	writer       *Writer
+	role         string
+	session      string
+	seq          uint64
+	stateMachine *aznotpstatemachines.StateMachine
+}
+
+// Inspector returns the packet inspector to be given to the transport layer.
+func (r *Recorder) Inspector() (*aznotptransport.PacketInspector, error) {
+	return aznotptransport.NewPacketInspector(func(packet *aznotppackets.Packet) {
+		r.record(DirectionSent, packet)
+	}, func(packet *aznotppackets.Packet) {
+		r.record(DirectionReceived, packet)
+	})
+}
+
+// Attach attaches the state machine, whose flow type and state are recorded with each packet.
+func (r *Recorder) Attach(stateMachine *aznotpstatemachines.StateMachine) {
+	r.stateMachine = stateMachine
+}
+
+// record records a packet, failures are ignored as the capture must not break the flow.
+func (r *Recorder) record(direction string, packet *aznotppackets.Packet) {
+	r.seq++
+	record := &Record{
+		Seq:       r.seq,
+		Time:      time.Now().UTC(),
+		Session:   r.session,
+		Role:      r.role,
+		Direction: direction,
+		Data:      append([]byte{}, packet.Data...),
+		Packets:   decodePackets(packet.Data),
+	}
+	if r.stateMachine != nil {
+		record.FlowType = uint64(r.stateMachine.FlowType())
+		record.StateID = r.stateMachine.CurrentStateID()
+	}
+	_ = r.writer.write(record)
+}
+
+// decodePackets decodes the data packets of a packet, returning nil if the packet cannot be read.
+func decodePackets(data []byte) []RecordPacket {
+	reader, err := aznotppackets.NewPacketReader(&aznotppackets.Packet{Data: data})
+	if err != nil {
+		return nil
+	}
+	if _, err := reader.ReadProtocol(); err != nil {
+		return nil
+	}
+	packets := []RecordPacket{}
+	var state *aznotppackets.DataPacketState
+	for {
+		var payload []byte
+		payload, state, err = reader.ReadNextDataPacket(state)
+		if err != nil {
+			return packets
+		}
+		recordPacket := RecordPacket{Type: state.PacketType()}
+		var value any
+		if err := cbor.Unmarshal(payload, &value); err == nil {
+			recordPacket.Payload = normalizePayload(value)
+		}
+		packets = append(packets, recordPacket)
+		if state.IsComplete() {
+			return packets
+		}
+	}
+}
+
+// normalizePayload converts the decoded CBOR maps, keyed by integers, into maps which can be encoded as JSON.
+func normalizePayload(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalizePayload(item)
+		}
+		return m
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = normalizePayload(item)
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// ReadRecords reads the records of a capture, the lines are not bounded as a record is larger than the packet it holds.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	records := []Record{}
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("notp: failed to read capture: %w", err)
+		}
+		if data = bytes.TrimRight(data, "\r\n"); len(data) > 0 {
+			// Numbers are kept as read, as the payloads carry 64-bit values which cannot be represented as floats.
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			record := Record{}
+			if err := decoder.Decode(&record); err != nil {
+				return nil, fmt.Errorf("notp: invalid capture record at line %d: %w", line, err)
+			}
+			records = append(records, record)
+		}
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+	}
+}
+
+// NewReplayReceiver returns a packet receiver replaying the packets of the records, to feed a transport layer from a capture.
+func NewReplayReceiver(records []Record) aznotptransport.PacketReceiver {
+	index := 0
+	return func() (*aznotppackets.Packet, error) {
+		if index >= len(records) {
+			return nil, io.EOF
+		}
+		record := records[index]
+		index++
+		return aznotptransport.UncompressedPacket(record.Packet())
+	}
+}
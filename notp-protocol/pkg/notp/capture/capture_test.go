@@ -0,0 +1,164 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
+	aznotptransport "github.com/permguard/permguard/notp-protocol/pkg/notp/transport"
+)
+
+// TestCaptureAndReplay tests the capture of a flow and the replay of the captured packets.
+func TestCaptureAndReplay(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer)
+	require.NoError(t, err)
+
+	followerStream, err := aznotptransport.NewInMemoryStream(5 * time.Second)
+	require.NoError(t, err)
+	leaderStream, err := aznotptransport.NewInMemoryStream(5 * time.Second)
+	require.NoError(t, err)
+
+	followerRecorder := writer.NewRecorder(RoleFollower, "session-1")
+	followerInspector, err := followerRecorder.Inspector()
+	require.NoError(t, err)
+	followerTransport, err := aznotptransport.NewTransportLayer(0, leaderStream.TransmitPacket, followerStream.ReceivePacket, followerInspector)
+	require.NoError(t, err)
+	leaderRecorder := writer.NewRecorder(RoleLeader, "session-1")
+	leaderInspector, err := leaderRecorder.Inspector()
+	require.NoError(t, err)
+	leaderTransport, err := aznotptransport.NewTransportLayer(0, followerStream.TransmitPacket, leaderStream.ReceivePacket, leaderInspector)
+	require.NoError(t, err)
+
+	followerHandler := func(handlerCtx *aznotpstatemachines.HandlerContext, statePacket *aznotpsmpackets.StatePacket, _ []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+		if statePacket.MessageCode == aznotpsmpackets.RespondCurrentStateMessage {
+			return &aznotpstatemachines.HostHandlerReturn{Terminate: true}, nil
+		}
+		return &aznotpstatemachines.HostHandlerReturn{
+			MessageValue: statePacket.MessageValue,
+			Packetables:  []aznotppackets.Packetable{&aznotppackets.Packet{Data: []byte("policy")}},
+		}, nil
+	}
+	leaderHandler := func(_ *aznotpstatemachines.HandlerContext, statePacket *aznotpsmpackets.StatePacket, packets []aznotppackets.Packetable) (*aznotpstatemachines.HostHandlerReturn, error) {
+		return &aznotpstatemachines.HostHandlerReturn{MessageValue: statePacket.MessageValue, Packetables: packets}, nil
+	}
+	follower, err := aznotpstatemachines.NewFollowerStateMachine(followerHandler, followerTransport)
+	require.NoError(t, err)
+	followerRecorder.Attach(follower)
+	leader, err := aznotpstatemachines.NewLeaderStateMachine(leaderHandler, leaderTransport)
+	require.NoError(t, err)
+	leaderRecorder.Attach(leader)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = follower.Run(nil, aznotpstatemachines.PushFlowType)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = leader.Run(nil, aznotpstatemachines.UnknownFlowType)
+	}()
+	wg.Wait()
+
+	records, err := ReadRecords(buffer)
+	require.NoError(t, err)
+	followerRecords := []Record{}
+	leaderReceived := []Record{}
+	for _, record := range records {
+		switch {
+		case record.Role == RoleFollower:
+			followerRecords = append(followerRecords, record)
+		case record.Direction == DirectionReceived:
+			leaderReceived = append(leaderReceived, record)
+		}
+	}
+	require.NotEmpty(t, followerRecords)
+	first := followerRecords[0]
+	assert.Equal(t, uint64(1), first.Seq)
+	assert.Equal(t, DirectionSent, first.Direction)
+	assert.Equal(t, "push", FlowName(first.FlowType))
+	assert.Equal(t, "start-flow", StateName(first.StateID))
+	statePacket, ok := first.StatePacket()
+	require.True(t, ok)
+	assert.Equal(t, "start-flow", MessageName(statePacket.MessageCode))
+	require.NotEmpty(t, first.Packets)
+	assert.NotNil(t, first.Packets[0].Payload)
+
+	// The packets received by the leader are replayed through a new transport layer.
+	require.NotEmpty(t, leaderReceived)
+	replayTransport, err := aznotptransport.NewTransportLayer(0, leaderStream.TransmitPacket, NewReplayReceiver(leaderReceived), nil)
+	require.NoError(t, err)
+	for _, record := range leaderReceived {
+		packetables, err := replayTransport.ReceivePacket()
+		require.NoError(t, err)
+		assert.Len(t, packetables, len(record.Packets))
+	}
+	_, err = replayTransport.ReceivePacket()
+	assert.Error(t, err)
+}
+
+// TestReadRecords tests the reading of the capture lines, whatever their size.
+func TestReadRecords(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer)
+	require.NoError(t, err)
+	large := bytes.Repeat([]byte{0xA5}, 2*1024*1024)
+	require.NoError(t, writer.write(&Record{Seq: 1, Role: RoleLeader, Direction: DirectionSent, Data: large}))
+	buffer.WriteString("\r\n\n")
+	require.NoError(t, writer.write(&Record{Seq: 2, Role: RoleLeader, Direction: DirectionReceived, Data: []byte("policy")}))
+	buffer.Truncate(buffer.Len() - 1)
+
+	records, err := ReadRecords(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, large, records[0].Data, "large record should be read")
+	assert.Equal(t, []byte("policy"), records[1].Data, "last record without a new line should be read")
+
+	_, err = ReadRecords(bytes.NewReader(append(buffer.Bytes(), []byte("\n{invalid")...)))
+	assert.ErrorContains(t, err, "line 5", "invalid record should be reported with its line")
+}
+
+// TestWriterClose tests that the records written before the capture file is closed are kept and the ones written after are discarded.
+func TestWriterClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	writer, err := OpenFile(path)
+	require.NoError(t, err)
+	require.NoError(t, writer.write(&Record{Seq: 1, Role: RoleLeader, Direction: DirectionSent, Data: []byte("policy")}))
+	require.NoError(t, writer.Close())
+	require.NoError(t, writer.Close(), "closing twice should not fail")
+	require.NoError(t, writer.write(&Record{Seq: 2, Role: RoleLeader, Direction: DirectionSent, Data: []byte("policy")}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	records, err := ReadRecords(file)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(1), records[0].Seq)
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"fmt"
+
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+	aznotpstatemachines "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines"
+	aznotpsmpackets "github.com/permguard/permguard/notp-protocol/pkg/notp/statemachines/packets"
+)
+
+var (
+	flowNames = map[uint64]string{
+		uint64(aznotpstatemachines.UnknownFlowType): "unknown",
+		uint64(aznotpstatemachines.PushFlowType):    "push",
+		uint64(aznotpstatemachines.PullFlowType):    "pull",
+	}
+	stateNames = map[uint16]string{
+		aznotpstatemachines.InitialStateID:               "initial",
+		aznotpstatemachines.FinalStateID:                 "final",
+		aznotpstatemachines.StartFlowStateID:             "start-flow",
+		aznotpstatemachines.ProcessStartFlowStateID:      "process-start-flow",
+		aznotpstatemachines.RequestObjectsStateID:        "request-objects",
+		aznotpstatemachines.ProcessRequestObjectsStateID: "process-request-objects",
+		aznotpstatemachines.NotifyObjectsStateID:         "notify-objects",
+		aznotpstatemachines.ProcessNotifyObjectsStateID:  "process-notify-objects",
+		aznotpstatemachines.SubscriberNegotiationStateID: "subscriber-negotiation",
+		aznotpstatemachines.SubscriberDataStreamStateID:  "subscriber-data-stream",
+		aznotpstatemachines.SubscriberCommitStateID:      "subscriber-commit",
+		aznotpstatemachines.PublisherNegotiationStateID:  "publisher-negotiation",
+		aznotpstatemachines.PublisherDataStreamStateID:   "publisher-data-stream",
+		aznotpstatemachines.PublisherCommitStateID:       "publisher-commit",
+	}
+	messageNames = map[uint16]string{
+		aznotpsmpackets.StartFlowMessage:                  "start-flow",
+		aznotpsmpackets.ActionResponseMessage:             "action-response",
+		aznotpsmpackets.TerminateMessage:                  "terminate",
+		aznotpsmpackets.NotifyCurrentObjectStatesMessage:  "notify-current-object-states",
+		aznotpsmpackets.RequestCurrentObjectsStateMessage: "request-current-objects-state",
+		aznotpsmpackets.RespondCurrentStateMessage:        "respond-current-state",
+		aznotpsmpackets.NegotiationRequestMessage:         "negotiation-request",
+		aznotpsmpackets.RespondNegotiationRequestMessage:  "respond-negotiation-request",
+		aznotpsmpackets.ExchangeDataStreamMessage:         "exchange-data-stream",
+		aznotpsmpackets.AcknowledgeDataStreamMessage:      "acknowledge-data-stream",
+		aznotpsmpackets.CommitMessage:                     "commit",
+	}
+)
+
+// nameOrCode returns the name of a code, or the code itself if it has no name.
+func nameOrCode[K comparable](names map[K]string, code K) string {
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return fmt.Sprint(code)
+}
+
+// FlowName returns the name of a flow type.
+func FlowName(flowType uint64) string {
+	return nameOrCode(flowNames, flowType)
+}
+
+// StateName returns the name of a state.
+func StateName(stateID uint16) string {
+	return nameOrCode(stateNames, stateID)
+}
+
+// MessageName returns the name of a state packet message.
+func MessageName(messageCode uint16) string {
+	return nameOrCode(messageNames, messageCode)
+}
+
+// StatePacket returns the state packet heading the recorded packet, if any.
+func (r *Record) StatePacket() (*aznotpsmpackets.StatePacket, bool) {
+	if len(r.Packets) == 0 {
+		return nil, false
+	}
+	head := r.Packets[0]
+	if head.Type != aznotppackets.CombineUint32toUint64(aznotpsmpackets.StatePacketType, 0) {
+		return nil, false
+	}
+	reader, err := aznotppackets.NewPacketReader(r.Packet())
+	if err != nil {
+		return nil, false
+	}
+	if _, err := reader.ReadProtocol(); err != nil {
+		return nil, false
+	}
+	payload, _, err := reader.ReadNextDataPacket(nil)
+	if err != nil {
+		return nil, false
+	}
+	statePacket := &aznotpsmpackets.StatePacket{}
+	if err := statePacket.Deserialize(payload); err != nil {
+		return nil, false
+	}
+	return statePacket, true
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package packets
+
+import "fmt"
+
+const (
+	// streamPacketHeaderSize is the size in bytes of a stream data packet header.
+	streamPacketHeaderSize = uint64Size * 3
+	// chunkReservedSize is the room kept in each chunk for the protocol and state packets.
+	chunkReservedSize = 256
+)
+
+// fragmentType returns the type of the data packet fragments.
+func fragmentType() uint64 {
+	return CombineUint32toUint64(FragmentPacketType, 0)
+}
+
+// ChunkPacketables splits the packetables into ordered chunks whose stream encoding fits into the max packet size.
+// A packetable that does not fit into a single chunk is split into consecutive data packets of the stream: every part
+// but the last has the fragment type, while the last part keeps the type of the packetable.
+func ChunkPacketables(packetables []Packetable, maxPacketSize int) ([][]Packetable, error) {
+	budget := maxPacketSize - chunkReservedSize
+	if budget <= streamPacketHeaderSize {
+		return nil, fmt.Errorf("notp: max packet size %d is too small to carry data chunks", maxPacketSize)
+	}
+	chunks := [][]Packetable{}
+	chunk := []Packetable{}
+	chunkSize := 0
+	flush := func() {
+		chunks = append(chunks, chunk)
+		chunk = []Packetable{}
+		chunkSize = 0
+	}
+	for _, packetable := range packetables {
+		if packetable == nil {
+			return nil, fmt.Errorf("notp: nil data packet")
+		}
+		data, err := packetable.Serialize()
+		if err != nil {
+			return nil, fmt.Errorf("notp: failed to serialize packet: %w", err)
+		}
+		size := len(data) + streamPacketHeaderSize
+		// Prefer starting a new chunk over fragmenting a packetable that fits into one.
+		if len(chunk) > 0 && chunkSize+size > budget && size <= budget {
+			flush()
+		}
+		if chunkSize+size <= budget {
+			chunk = append(chunk, packetable)
+			chunkSize += size
+			continue
+		}
+		for {
+			if budget-chunkSize <= streamPacketHeaderSize {
+				flush()
+			}
+			size := min(len(data), budget-chunkSize-streamPacketHeaderSize)
+			packetType := packetable.Type()
+			if size < len(data) {
+				packetType = fragmentType()
+			}
+			chunk = append(chunk, &Packet{Data: data[:size], DataType: packetType})
+			chunkSize += size + streamPacketHeaderSize
+			data = data[size:]
+			if len(data) == 0 {
+				break
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		flush()
+	}
+	return chunks, nil
+}
+
+// ChunkAssembler reassembles the packetables fragmented across the data packets of a chunked data stream.
+type ChunkAssembler struct {
+	maxSize    int
+	pending    []byte
+	hasPending bool
+}
+
+// NewChunkAssembler creates a new chunk assembler, failing the reassembly of packetables larger than the max size.
+func NewChunkAssembler(maxSize int) (*ChunkAssembler, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("notp: invalid chunk assembler max size %d", maxSize)
+	}
+	return &ChunkAssembler{maxSize: maxSize}, nil
+}
+
+// Assemble reads the data packets of a chunk and returns the packetables completed by it.
+func (a *ChunkAssembler) Assemble(packetables []Packetable) ([]Packetable, error) {
+	assembled := []Packetable{}
+	for _, packetable := range packetables {
+		if !a.hasPending && packetable.Type() != fragmentType() {
+			assembled = append(assembled, packetable)
+			continue
+		}
+		data, err := packetable.Serialize()
+		if err != nil {
+			return nil, fmt.Errorf("notp: failed to serialize packet: %w", err)
+		}
+		if len(a.pending)+len(data) > a.maxSize {
+			return nil, fmt.Errorf("notp: fragmented packet exceeds the maximum size %d", a.maxSize)
+		}
+		a.pending = append(a.pending, data...)
+		a.hasPending = true
+		if packetable.Type() == fragmentType() {
+			continue
+		}
+		assembled = append(assembled, &Packet{Data: a.pending, DataType: packetable.Type()})
+		a.pending = nil
+		a.hasPending = false
+	}
+	return assembled, nil
+}
+
+// HasPending reports whether a fragmented packetable is still incomplete.
+func (a *ChunkAssembler) HasPending() bool {
+	return a.hasPending
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package packets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assembleChunks reassembles the chunks and returns the serialized packetables.
+func assembleChunks(assert *assert.Assertions, chunks [][]Packetable) []string {
+	assembler, err := NewChunkAssembler(1024 * 1024)
+	assert.NoError(err)
+	return assembleChunksWith(assert, assembler, chunks)
+}
+
+// assembleChunksWith reassembles the chunks with the assembler and returns the serialized packetables.
+func assembleChunksWith(assert *assert.Assertions, assembler *ChunkAssembler, chunks [][]Packetable) []string {
+	assembled := []string{}
+	for _, chunk := range chunks {
+		packetables, err := assembler.Assemble(chunk)
+		assert.NoError(err)
+		for _, packetable := range packetables {
+			data, err := packetable.Serialize()
+			assert.NoError(err)
+			assembled = append(assembled, string(data))
+		}
+	}
+	assert.False(assembler.HasPending())
+	return assembled
+}
+
+// TestChunkPacketables verifies that packetables are split in order into chunks that fit the max packet size.
+func TestChunkPacketables(t *testing.T) {
+	assert := assert.New(t)
+
+	packetables := []Packetable{}
+	expected := []string{}
+	for range 10 {
+		data := strings.Repeat("x", 400)
+		packetables = append(packetables, &Packet{Data: []byte(data)})
+		expected = append(expected, data)
+	}
+
+	chunks, err := ChunkPacketables(packetables, 1280)
+	assert.NoError(err)
+	assert.Len(chunks, 5)
+	for _, chunk := range chunks {
+		assert.Len(chunk, 2)
+	}
+	assert.Equal(expected, assembleChunks(assert, chunks))
+
+	chunks, err = ChunkPacketables(nil, 1280)
+	assert.NoError(err)
+	assert.Empty(chunks)
+
+	_, err = ChunkPacketables(packetables, 256)
+	assert.Error(err)
+}
+
+// TestChunkPacketablesFragmentsLargePackets verifies that a packetable larger than a chunk is fragmented and reassembled.
+func TestChunkPacketablesFragmentsLargePackets(t *testing.T) {
+	assert := assert.New(t)
+
+	large := strings.Repeat("abcdefgh", 1024)
+	packetables := []Packetable{
+		&Packet{Data: []byte("head")},
+		&Packet{Data: []byte(large)},
+		&Packet{Data: []byte("tail")},
+	}
+
+	chunks, err := ChunkPacketables(packetables, 1280)
+	assert.NoError(err)
+	assert.Greater(len(chunks), len(large)/1280)
+	for _, chunk := range chunks {
+		size := 0
+		for _, packetable := range chunk {
+			data, err := packetable.Serialize()
+			assert.NoError(err)
+			size += len(data) + streamPacketHeaderSize
+		}
+		assert.LessOrEqual(size, 1280-chunkReservedSize)
+	}
+	assert.Equal([]string{"head", large, "tail"}, assembleChunks(assert, chunks))
+
+	assembler, err := NewChunkAssembler(1024 * 1024)
+	assert.NoError(err)
+	packets, err := assembler.Assemble(chunks[0])
+	assert.NoError(err)
+	assert.Len(packets, 1)
+	assert.True(assembler.HasPending())
+}
+
+// TestChunkPacketablesStreamIndexing verifies that the fragments are carried as data packets of the stream and keep their types.
+func TestChunkPacketablesStreamIndexing(t *testing.T) {
+	assert := assert.New(t)
+
+	large := strings.Repeat("abcdefgh", 512)
+	chunks, err := ChunkPacketables([]Packetable{&ProtocolPacket{Version: 1}, &Packet{Data: []byte(large)}}, 1280)
+	assert.NoError(err)
+
+	assembler, err := NewChunkAssembler(1024 * 1024)
+	assert.NoError(err)
+	assembled := []Packetable{}
+	for _, chunk := range chunks {
+		packet := &Packet{}
+		writer, err := NewPacketWriter(packet)
+		assert.NoError(err)
+		assert.NoError(writer.WriteProtocol(&ProtocolPacket{Version: 1}))
+		for _, packetable := range chunk {
+			assert.NoError(writer.AppendDataPacket(packetable))
+		}
+		assert.LessOrEqual(len(packet.Data), 1280)
+
+		reader, err := NewPacketReader(packet)
+		assert.NoError(err)
+		_, err = reader.ReadProtocol()
+		assert.NoError(err)
+		received := []Packetable{}
+		var state *DataPacketState
+		for {
+			var data []byte
+			data, state, err = reader.ReadNextDataPacket(state)
+			assert.NoError(err)
+			received = append(received, &Packet{Data: data, DataType: state.PacketType()})
+			if state.IsComplete() {
+				break
+			}
+		}
+		packetables, err := assembler.Assemble(received)
+		assert.NoError(err)
+		assembled = append(assembled, packetables...)
+	}
+	assert.False(assembler.HasPending())
+	assert.Len(assembled, 2)
+	assert.Equal(CombineUint32toUint64(ProtocolPacketType, 0), assembled[0].Type())
+	assert.Equal(CombineUint32toUint64(PacketType, 0), assembled[1].Type())
+	data, err := assembled[1].Serialize()
+	assert.NoError(err)
+	assert.Equal(large, string(data))
+}
+
+// TestChunkAssemblerMaxSize verifies that the reassembly fails once the fragments exceed the max size.
+func TestChunkAssemblerMaxSize(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewChunkAssembler(0)
+	assert.Error(err)
+
+	chunks, err := ChunkPacketables([]Packetable{&Packet{Data: []byte(strings.Repeat("x", 4096))}}, 1280)
+	assert.NoError(err)
+	assembler, err := NewChunkAssembler(2048)
+	assert.NoError(err)
+	var assembleErr error
+	for _, chunk := range chunks {
+		if _, assembleErr = assembler.Assemble(chunk); assembleErr != nil {
+			break
+		}
+	}
+	assert.ErrorContains(assembleErr, "exceeds the maximum size")
+
+	assembler, err = NewChunkAssembler(4096)
+	assert.NoError(err)
+	assert.Equal([]string{strings.Repeat("x", 4096)}, assembleChunksWith(assert, assembler, chunks))
+}
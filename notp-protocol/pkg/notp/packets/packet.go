@@ -23,16 +23,23 @@ const (
 	PacketType = uint32(0)
 	// ProtocolPacketType represents the type of the protocol packet.
 	ProtocolPacketType = uint32(1)
+	// FragmentPacketType represents the type of a data packet fragment, continued by the next data packet of the stream.
+	FragmentPacketType = uint32(2)
 )
 
 // Packet represents a packet.
 type Packet struct {
 	Data []byte
+	// DataType is the type of the data packet as indexed in the stream, the generic packet type if zero.
+	DataType uint64
 }
 
 // Type returns the packet type.
 func (p *Packet) Type() uint64 {
-	return CombineUint32toUint64(PacketType, 0)
+	if p.DataType == 0 {
+		return CombineUint32toUint64(PacketType, 0)
+	}
+	return p.DataType
 }
 
 // Serialize serializes the packet.
@@ -16,9 +16,20 @@
 
 package packets
 
+const (
+	// CompressionNone represents a packet sent without compression.
+	CompressionNone = uint8(1)
+	// CompressionGzip represents a packet compressed with gzip.
+	CompressionGzip = uint8(2)
+	// CompressionZstd represents a packet compressed with zstd.
+	CompressionZstd = uint8(3)
+)
+
 // ProtocolPacket represents a protocol packet.
 type ProtocolPacket struct {
 	Version uint32 `cbor:"1,keyasint"`
+	// Compressions are the compression codecs accepted by the sender, in order of preference.
+	Compressions []uint8 `cbor:"2,keyasint,omitempty"`
 }
 
 // Type returns the type of the packet.
@@ -28,6 +28,7 @@ func TestProtocolPacket(t *testing.T) {
 
 	inPacket := &ProtocolPacket{}
 	inPacket.Version = 10
+	inPacket.Compressions = []uint8{CompressionZstd, CompressionGzip, CompressionNone}
 	data, err := inPacket.Serialize()
 	assert.NoError(err)
 	assert.NotNil(data)
@@ -37,4 +38,5 @@ func TestProtocolPacket(t *testing.T) {
 	assert.NoError(err)
 
 	assert.Equal(inPacket.Version, outPacket.Version)
+	assert.Equal(inPacket.Compressions, outPacket.Compressions)
 }
@@ -59,6 +59,8 @@ const (
 
 	// ExchangeDataStreamMessage represents the exchange of data stream.
 	ExchangeDataStreamMessage = uint16(170)
+	// AcknowledgeDataStreamMessage represents the acknowledgment of a data stream chunk.
+	AcknowledgeDataStreamMessage = uint16(171)
 
 	// CommitMessage represents the commit message.
 	CommitMessage = uint16(200)
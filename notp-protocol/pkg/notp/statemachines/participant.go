@@ -368,6 +368,14 @@ func subscriberDataStreamState(runtime *StateMachineRuntimeContext) (*StateTrans
 			return nil, fmt.Errorf("notp: subscriber data stream failed to receive and handle exchange data stream packet: %w", err)
 		}
 		hasStream = statePacket.HasActiveDataStream()
+		ackValue := aznotppackets.CombineUint32toUint64(aznotpsmpackets.AcknowledgedValue, aznotpsmpackets.UnknownValue)
+		_, _, terminate, err = createAndHandleAndSendStatePacketWithValue(runtime, aznotpsmpackets.AcknowledgeDataStreamMessage, ackValue, nil)
+		if terminate {
+			return terminateWithFinal(runtime)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("notp: subscriber data stream failed to create and handle acknowledge data stream packet: %w", err)
+		}
 	}
 	return &StateTransitionInfo{
 		Runtime: runtime,
@@ -375,14 +383,29 @@ func subscriberDataStreamState(runtime *StateMachineRuntimeContext) (*StateTrans
 	}, nil
 }
 
-// publisherDataStreamState state to send data stream.
+// publisherDataStreamState state to send data stream, one acknowledged chunk at a time.
 func publisherDataStreamState(runtime *StateMachineRuntimeContext) (*StateTransitionInfo, error) {
-	_, terminate, err := createAndHandleAndStreamStatePacket(runtime, aznotpsmpackets.ExchangeDataStreamMessage, nil)
-	if terminate {
-		return terminateWithFinal(runtime)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("notp: publisher data stream failed to create and handle respond current state packet: %w", err)
+	messageValue := aznotppackets.CombineUint32toUint64(aznotpsmpackets.UnknownValue, aznotpsmpackets.UnknownValue)
+	hasMore := true
+	for hasMore {
+		_, handlerHasMore, terminate, err := createAndHandleAndSendStatePacketWithValue(runtime, aznotpsmpackets.ExchangeDataStreamMessage, messageValue, nil)
+		if terminate {
+			return terminateWithFinal(runtime)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("notp: publisher data stream failed to create and handle exchange data stream packet: %w", err)
+		}
+		hasMore = handlerHasMore
+		ackPacket, _, terminate, err := receiveAndHandleStatePacket(runtime, aznotpsmpackets.AcknowledgeDataStreamMessage)
+		if terminate {
+			return terminateWithFinal(runtime)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("notp: publisher data stream failed to receive and handle acknowledge data stream packet: %w", err)
+		}
+		if !ackPacket.HasAck() {
+			return nil, fmt.Errorf("notp: publisher data stream failed to receive ack in acknowledge data stream packet")
+		}
 	}
 	return &StateTransitionInfo{
 		Runtime: runtime,
@@ -105,10 +105,10 @@ func TestPullProtocolExecution(t *testing.T) {
 		{
 			name:             "PullFlowType",
 			flowType:         PullFlowType,
-			followerSent:     4,
+			followerSent:     8,
 			followerReceived: 7,
 			leaderSent:       7,
-			leaderReceived:   4,
+			leaderReceived:   8,
 			expectedFollowerIDs: []uint16{
 				RequestObjectsStateID,
 				RequestObjectsStateID,
@@ -118,6 +118,10 @@ func TestPullProtocolExecution(t *testing.T) {
 				SubscriberDataStreamStateID,
 				SubscriberDataStreamStateID,
 				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
 				SubscriberCommitStateID,
 				SubscriberCommitStateID,
 			},
@@ -130,6 +134,10 @@ func TestPullProtocolExecution(t *testing.T) {
 				PublisherDataStreamStateID,
 				PublisherDataStreamStateID,
 				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
 				PublisherCommitStateID,
 				PublisherCommitStateID,
 			},
@@ -138,8 +146,8 @@ func TestPullProtocolExecution(t *testing.T) {
 			name:             "PushFlowType",
 			flowType:         PushFlowType,
 			followerSent:     7,
-			followerReceived: 4,
-			leaderSent:       4,
+			followerReceived: 8,
+			leaderSent:       8,
 			leaderReceived:   7,
 			expectedFollowerIDs: []uint16{
 				NotifyObjectsStateID,
@@ -150,6 +158,10 @@ func TestPullProtocolExecution(t *testing.T) {
 				PublisherDataStreamStateID,
 				PublisherDataStreamStateID,
 				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
+				PublisherDataStreamStateID,
 				PublisherCommitStateID,
 				PublisherCommitStateID,
 			},
@@ -162,6 +174,10 @@ func TestPullProtocolExecution(t *testing.T) {
 				SubscriberDataStreamStateID,
 				SubscriberDataStreamStateID,
 				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
+				SubscriberDataStreamStateID,
 				SubscriberCommitStateID,
 				SubscriberCommitStateID,
 			},
@@ -183,7 +199,7 @@ func TestPullProtocolExecution(t *testing.T) {
 				handlerReturn := &HostHandlerReturn{
 					Packetables: []aznotppackets.Packetable{packet},
 				}
-				if handlerCtx.CurrentStateID() == PublisherDataStreamStateID && handlerCtx.FlowType() == PushFlowType {
+				if handlerCtx.CurrentStateID() == PublisherDataStreamStateID && handlerCtx.FlowType() == PushFlowType && statePacket.MessageCode == aznotpsmpackets.ExchangeDataStreamMessage {
 					if streamSize > 0 {
 						handlerReturn.MessageValue = aznotppackets.CombineUint32toUint64(aznotpsmpackets.AcknowledgedValue, aznotpsmpackets.ActiveDataStreamValue)
 						handlerReturn.HasMore = true
@@ -208,7 +224,7 @@ func TestPullProtocolExecution(t *testing.T) {
 				}
 				if handlerCtx.CurrentStateID() == SubscriberDataStreamStateID && handlerCtx.FlowType() == PushFlowType {
 					handlerReturn.MessageValue = statePacket.MessageValue
-				} else if handlerCtx.CurrentStateID() == PublisherDataStreamStateID && handlerCtx.FlowType() == PullFlowType {
+				} else if handlerCtx.CurrentStateID() == PublisherDataStreamStateID && handlerCtx.FlowType() == PullFlowType && statePacket.MessageCode == aznotpsmpackets.ExchangeDataStreamMessage {
 					if streamSize > 0 {
 						handlerReturn.MessageValue = aznotppackets.CombineUint32toUint64(aznotpsmpackets.AcknowledgedValue, aznotpsmpackets.ActiveDataStreamValue)
 						handlerReturn.HasMore = true
@@ -257,3 +273,42 @@ func TestPullProtocolExecution(t *testing.T) {
 		})
 	}
 }
+
+// TestTerminationOnReceiveIsPropagated verifies that a termination requested while handling a received packet reaches the peer.
+func TestTerminationOnReceiveIsPropagated(t *testing.T) {
+	assert := assert.New(t)
+
+	followerHandler := func(handlerCtx *HandlerContext, statePacket *aznotpsmpackets.StatePacket, packets []aznotppackets.Packetable) (*HostHandlerReturn, error) {
+		if handlerCtx.CurrentStateID() == NotifyObjectsStateID && statePacket.MessageCode == aznotpsmpackets.RespondCurrentStateMessage {
+			return &HostHandlerReturn{Terminate: true}, nil
+		}
+		return &HostHandlerReturn{MessageValue: statePacket.MessageValue}, nil
+	}
+	leaderHandler := func(handlerCtx *HandlerContext, statePacket *aznotpsmpackets.StatePacket, packets []aznotppackets.Packetable) (*HostHandlerReturn, error) {
+		return &HostHandlerReturn{MessageValue: statePacket.MessageValue, Packetables: packets}, nil
+	}
+
+	sMInfo := buildCommitStateMachines(assert, followerHandler, leaderHandler)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var followerRuntime, leaderRuntime *StateMachineRuntimeContext
+	var followerErr, leaderErr error
+	go func() {
+		defer wg.Done()
+		followerRuntime, followerErr = sMInfo.follower.Run(nil, PushFlowType)
+	}()
+	go func() {
+		defer wg.Done()
+		leaderRuntime, leaderErr = sMInfo.leader.Run(nil, UnknownFlowType)
+	}()
+	wg.Wait()
+
+	assert.Nil(followerErr, "Failed to run the follower state machine")
+	assert.Nil(leaderErr, "Failed to run the leader state machine")
+	assert.True(followerRuntime.IsFinal())
+	assert.True(leaderRuntime.IsFinal())
+	assert.Len(sMInfo.followerSent, 3, "Follower sent packets")
+	assert.Len(sMInfo.leaderReceived, 3, "Leader received packets")
+}
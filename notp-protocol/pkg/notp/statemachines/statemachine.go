@@ -254,6 +254,23 @@ func (t *StateMachineRuntimeContext) HandleStream(handlerCtx *HandlerContext, st
 // StateMachine orchestrates the execution of state transitions.
 type StateMachine struct {
 	runtime *StateMachineRuntimeContext
+	current *StateMachineRuntimeContext
+}
+
+// FlowType returns the flow type of the running state machine.
+func (m *StateMachine) FlowType() FlowType {
+	if m.current == nil {
+		return UnknownFlowType
+	}
+	return m.current.FlowType()
+}
+
+// CurrentStateID returns the ID of the state being run by the state machine.
+func (m *StateMachine) CurrentStateID() uint16 {
+	if m.current == nil {
+		return m.runtime.CurrentStateID()
+	}
+	return m.current.CurrentStateID()
 }
 
 // Run starts and runs the state machine through its states until termination.
@@ -267,6 +284,7 @@ func (m *StateMachine) Run(bag map[string]any, inputValue FlowType) (*StateMachi
 	state := m.runtime.statemap[runtime.initialStateID]
 	for state != nil {
 		runtime = runtime.withCurrentState(stateID)
+		m.current = runtime
 		nextStateInfo, err := state(runtime)
 		if err != nil {
 			return nil, err
@@ -17,6 +17,7 @@
 package statemachines
 
 import (
+	"errors"
 	"fmt"
 
 	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
@@ -62,7 +63,9 @@ func createAndHandleStatePacket(runtime *StateMachineRuntimeContext, messageCode
 			handledPacketables = handlerReturn.Packetables
 		}
 		if err != nil {
-			err := sendTermination(runtime)
+			if errTerm := sendTermination(runtime); errTerm != nil {
+				err = errors.Join(err, errTerm)
+			}
 			return nil, nil, false, false, fmt.Errorf("notp: failed to handle created packet: %w", err)
 		}
 		statePacket.MessageValue = handlerReturn.MessageValue
@@ -84,27 +87,38 @@ func createAndHandleAndStreamStatePacketWithValue(runtime *StateMachineRuntimeCo
 	var packet *aznotpsmpackets.StatePacket
 	hasMore := true
 	for hasMore {
-		statePacket, handledPacketable, handlerHasMore, terminate, err := createAndHandleStatePacket(runtime, messageCode, messageValue, packetables)
-		if terminate {
-			err2 := sendTermination(runtime)
-			return nil, true, err2
-		}
-		if err != nil {
-			err2 := sendTermination(runtime)
-			return nil, false, fmt.Errorf("notp: failed to create and handle packet: %w", err2)
+		statePacket, handlerHasMore, terminate, err := createAndHandleAndSendStatePacketWithValue(runtime, messageCode, messageValue, packetables)
+		if terminate || err != nil {
+			return nil, terminate, err
 		}
 		hasMore = handlerHasMore
 		packet = statePacket
-		streamPacketables := append([]aznotppackets.Packetable{statePacket}, handledPacketable...)
-		err = runtime.SendStream(streamPacketables)
-		if err != nil {
-			err := sendTermination(runtime)
-			return nil, false, err
-		}
 	}
 	return packet, false, nil
 }
 
+// createAndHandleAndSendStatePacketWithValue creates a state packet with value, handles it, and sends it once.
+func createAndHandleAndSendStatePacketWithValue(runtime *StateMachineRuntimeContext, messageCode uint16, messageValue uint64, packetables []aznotppackets.Packetable) (*aznotpsmpackets.StatePacket, bool, bool, error) {
+	statePacket, handledPacketable, hasMore, terminate, err := createAndHandleStatePacket(runtime, messageCode, messageValue, packetables)
+	if terminate {
+		err2 := sendTermination(runtime)
+		return nil, false, true, err2
+	}
+	if err != nil {
+		if errTerm := sendTermination(runtime); errTerm != nil {
+			err = errors.Join(err, errTerm)
+		}
+		return nil, false, false, fmt.Errorf("notp: failed to create and handle packet: %w", err)
+	}
+	streamPacketables := append([]aznotppackets.Packetable{statePacket}, handledPacketable...)
+	err = runtime.SendStream(streamPacketables)
+	if err != nil {
+		err := sendTermination(runtime)
+		return nil, false, false, err
+	}
+	return statePacket, hasMore, false, nil
+}
+
 // sendTermination sends a termination message.
 func sendTermination(runtime *StateMachineRuntimeContext) error {
 	statePacket := &aznotpsmpackets.StatePacket{
@@ -151,12 +165,16 @@ func receiveAndHandleStatePacket(runtime *StateMachineRuntimeContext, expectedMe
 		handlerReturn, err := runtime.HandleStream(handlerCtx, statePacket, packetsStream[1:])
 		if handlerReturn != nil {
 			if handlerReturn.Terminate {
-				return nil, nil, true, nil
+				err := sendTermination(runtime)
+				return nil, nil, true, err
 			}
 			handledPacketables = handlerReturn.Packetables
 		}
 		if err != nil {
-			return nil, nil, false, fmt.Errorf("notp: failed to handle created packet: %w", err)
+			if errTerm := sendTermination(runtime); errTerm != nil {
+				err = errors.Join(err, errTerm)
+			}
+			return nil, nil, false, fmt.Errorf("notp: failed to handle received packet: %w", err)
 		}
 		statePacket.MessageValue = handlerReturn.MessageValue
 		statePacket.ErrorCode = handlerReturn.ErrorCode
@@ -0,0 +1,170 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/permguard/permguard/common/pkg/extensions/data"
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+)
+
+const (
+	// compressionLegacy represents the zlib framing used before the compression negotiation, understood by every peer.
+	compressionLegacy = uint8(0)
+	// compressedFrameMagic marks a packet framed with a negotiated codec, it is never the first byte of a zlib stream.
+	compressedFrameMagic = byte(0xCE)
+	// compressedFrameHeaderSize is the size of the magic and codec bytes heading a negotiated frame.
+	compressedFrameHeaderSize = 2
+	// maxDecompressedPacketRatio bounds the decompressed size of a packet as a multiple of the maximum packet size.
+	maxDecompressedPacketRatio = 4
+)
+
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+)
+
+// SupportedCompressions returns the compression codecs supported by the transport layer, in order of preference.
+func SupportedCompressions() []uint8 {
+	return []uint8{aznotppackets.CompressionZstd, aznotppackets.CompressionGzip, aznotppackets.CompressionNone}
+}
+
+// negotiateCompression returns the first local codec accepted by the peer, or the legacy framing if the peer did not advertise any.
+func negotiateCompression(local, peer []uint8) uint8 {
+	for _, codec := range local {
+		if slices.Contains(peer, codec) {
+			return codec
+		}
+	}
+	return compressionLegacy
+}
+
+// compressFrame compresses the data with the codec and frames it, falling back to no compression if it does not shrink the data.
+func compressFrame(codec uint8, payload []byte) ([]byte, error) {
+	if codec == compressionLegacy {
+		return data.CompressData(payload)
+	}
+	compressed := payload
+	switch codec {
+	case aznotppackets.CompressionNone:
+	case aznotppackets.CompressionGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(payload); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		compressed = buf.Bytes()
+	case aznotppackets.CompressionZstd:
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, err
+		}
+		compressed = encoder.EncodeAll(payload, nil)
+	default:
+		return nil, fmt.Errorf("notp: unsupported compression codec %d", codec)
+	}
+	if len(compressed) >= len(payload) {
+		codec, compressed = aznotppackets.CompressionNone, payload
+	}
+	frame := make([]byte, 0, compressedFrameHeaderSize+len(compressed))
+	frame = append(frame, compressedFrameMagic, codec)
+	return append(frame, compressed...), nil
+}
+
+// maxDecompressedSize returns the maximum decompressed size of a packet for the maximum packet size.
+func maxDecompressedSize(maxPacketSize int) int64 {
+	return int64(maxPacketSize) * maxDecompressedPacketRatio
+}
+
+// readDecompressed reads the decompressed data, failing as soon as it exceeds the limit.
+func readDecompressed(reader io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil && !errors.Is(err, zstd.ErrDecoderSizeExceeded) {
+		return nil, err
+	}
+	if err != nil || int64(len(data)) > limit {
+		return nil, fmt.Errorf("notp: decompressed packet exceeds maximum allowed size %d", limit)
+	}
+	return data, nil
+}
+
+// decompressFrame decompresses a framed packet up to the limit, legacy packets are recognized by the missing frame magic.
+func decompressFrame(accepted []uint8, frame []byte, limit int64) ([]byte, error) {
+	if len(frame) < compressedFrameHeaderSize || frame[0] != compressedFrameMagic {
+		if len(frame) == 0 {
+			return []byte{}, nil
+		}
+		reader, err := zlib.NewReader(bytes.NewReader(frame))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return readDecompressed(reader, limit)
+	}
+	codec, payload := frame[1], frame[compressedFrameHeaderSize:]
+	if !slices.Contains(accepted, codec) {
+		return nil, fmt.Errorf("notp: unsupported compression codec %d", codec)
+	}
+	switch codec {
+	case aznotppackets.CompressionNone:
+		if int64(len(payload)) > limit {
+			return nil, fmt.Errorf("notp: decompressed packet exceeds maximum allowed size %d", limit)
+		}
+		return payload, nil
+	case aznotppackets.CompressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return readDecompressed(reader, limit)
+	case aznotppackets.CompressionZstd:
+		decoder, err := zstd.NewReader(bytes.NewReader(payload), zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(limit)))
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		return readDecompressed(decoder, limit)
+	}
+	return nil, errors.New("notp: invalid compressed frame")
+}
+
+// UncompressedPacket returns the packet framed without compression, as accepted by any transport layer with the negotiation.
+func UncompressedPacket(packet *aznotppackets.Packet) (*aznotppackets.Packet, error) {
+	if packet == nil {
+		return nil, errors.New("notp: nil packet")
+	}
+	frame, err := compressFrame(aznotppackets.CompressionNone, packet.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &aznotppackets.Packet{Data: frame}, nil
+}
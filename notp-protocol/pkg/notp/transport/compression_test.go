@@ -0,0 +1,153 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
+)
+
+// TestCompressFrame tests the compression of packets with every supported codec.
+func TestCompressFrame(t *testing.T) {
+	payload := bytes.Repeat([]byte(`permit(principal, action, resource);`), 100)
+	codecs := append(SupportedCompressions(), compressionLegacy)
+	for _, codec := range codecs {
+		frame, err := compressFrame(codec, payload)
+		require.NoError(t, err)
+		assert.Less(t, len(frame), len(payload)+compressedFrameHeaderSize+1)
+		if codec != aznotppackets.CompressionNone {
+			assert.Less(t, len(frame), len(payload)/5)
+		}
+		data, err := decompressFrame(SupportedCompressions(), frame, maxDecompressedSize(DefaultMaxPacketSize))
+		require.NoError(t, err)
+		assert.Equal(t, payload, data)
+	}
+
+	frame, err := compressFrame(aznotppackets.CompressionZstd, []byte{0x01})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{compressedFrameMagic, aznotppackets.CompressionNone, 0x01}, frame)
+
+	frame, err = compressFrame(aznotppackets.CompressionGzip, payload)
+	require.NoError(t, err)
+	_, err = decompressFrame([]uint8{aznotppackets.CompressionZstd}, frame, maxDecompressedSize(DefaultMaxPacketSize))
+	assert.Error(t, err)
+}
+
+// TestDecompressFrameOversized tests that the decompression stops at the limit with every supported codec.
+func TestDecompressFrameOversized(t *testing.T) {
+	const limit = 1024
+	payload := make([]byte, 64*limit)
+	codecs := append(SupportedCompressions(), compressionLegacy)
+	for _, codec := range codecs {
+		frame, err := compressFrame(codec, payload)
+		require.NoError(t, err)
+		_, err = decompressFrame(SupportedCompressions(), frame, limit)
+		assert.ErrorContains(t, err, "exceeds maximum allowed size", "codec %d should not decompress beyond the limit", codec)
+
+		data, err := decompressFrame(SupportedCompressions(), frame, int64(len(payload)))
+		require.NoError(t, err, "codec %d should decompress up to the limit", codec)
+		assert.Equal(t, payload, data)
+	}
+}
+
+// TestNegotiateCompression tests the negotiation of the compression codec.
+func TestNegotiateCompression(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(aznotppackets.CompressionZstd, negotiateCompression(SupportedCompressions(), SupportedCompressions()))
+	assert.Equal(aznotppackets.CompressionGzip, negotiateCompression(SupportedCompressions(), []uint8{aznotppackets.CompressionGzip}))
+	assert.Equal(compressionLegacy, negotiateCompression(SupportedCompressions(), nil))
+}
+
+// TestTransportLayerCompression tests the compression negotiated between two transport layers, also with a legacy peer.
+func TestTransportLayerCompression(t *testing.T) {
+	tests := []struct {
+		name             string
+		peerCompressions []uint8
+		expected         uint8
+	}{
+		{name: "zstd", peerCompressions: SupportedCompressions(), expected: aznotppackets.CompressionZstd},
+		{name: "gzip", peerCompressions: []uint8{aznotppackets.CompressionGzip, aznotppackets.CompressionNone}, expected: aznotppackets.CompressionGzip},
+		{name: "legacy", peerCompressions: nil, expected: compressionLegacy},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			followerStream, err := NewInMemoryStream(time.Second)
+			require.NoError(t, err)
+			leaderStream, err := NewInMemoryStream(time.Second)
+			require.NoError(t, err)
+			follower, err := NewTransportLayer(0, leaderStream.TransmitPacket, followerStream.ReceivePacket, nil)
+			require.NoError(t, err)
+			leader, err := NewTransportLayer(0, followerStream.TransmitPacket, leaderStream.ReceivePacket, nil)
+			require.NoError(t, err)
+			leader.compressions = test.peerCompressions
+
+			payload := &aznotppackets.Packet{Data: bytes.Repeat([]byte("policy"), 100)}
+			for range 3 {
+				require.NoError(t, follower.TransmitPacket([]aznotppackets.Packetable{payload}))
+				packetables, err := leader.ReceivePacket()
+				require.NoError(t, err)
+				assert.Equal(t, payload.Data, packetables[0].(*aznotppackets.Packet).Data)
+				require.NoError(t, leader.TransmitPacket([]aznotppackets.Packetable{payload}))
+				packetables, err = follower.ReceivePacket()
+				require.NoError(t, err)
+				assert.Equal(t, payload.Data, packetables[0].(*aznotppackets.Packet).Data)
+			}
+			assert.Equal(t, test.expected, follower.compression)
+			sent := leaderStream.packets[len(leaderStream.packets)-1].Data
+			if test.expected == compressionLegacy {
+				assert.NotEqual(t, compressedFrameMagic, sent[0])
+			} else {
+				assert.Equal(t, []byte{compressedFrameMagic, test.expected}, sent[:compressedFrameHeaderSize])
+			}
+		})
+	}
+}
+
+// TestTransportLayerOversizedPacket tests that the transport layers reject packets whose decompressed size exceeds the limit.
+func TestTransportLayerOversizedPacket(t *testing.T) {
+	const maxPacketSize = 1024
+	followerStream, err := NewInMemoryStream(time.Second)
+	require.NoError(t, err)
+	leaderStream, err := NewInMemoryStream(time.Second)
+	require.NoError(t, err)
+	follower, err := NewTransportLayer(0, leaderStream.TransmitPacket, followerStream.ReceivePacket, nil)
+	require.NoError(t, err)
+	leader, err := NewTransportLayer(maxPacketSize, followerStream.TransmitPacket, leaderStream.ReceivePacket, nil)
+	require.NoError(t, err)
+
+	payload := &aznotppackets.Packet{Data: make([]byte, 64*maxPacketSize)}
+	require.NoError(t, follower.TransmitPacket([]aznotppackets.Packetable{payload}))
+	require.LessOrEqual(t, len(leaderStream.packets[0].Data), maxPacketSize, "compressed packet should fit the maximum packet size")
+	_, err = leader.ReceivePacket()
+	assert.ErrorContains(t, err, "exceeds maximum allowed size", "decompressed packet should be rejected")
+
+	// The sender only bounds the compressed frame, the decompressed size is enforced by the receiver.
+	assert.NoError(t, leader.TransmitPacket([]aznotppackets.Packetable{payload}), "compressible packet should be sent")
+	random := &aznotppackets.Packet{Data: make([]byte, 2*maxPacketSize)}
+	_, err = rand.Read(random.Data)
+	require.NoError(t, err)
+	err = leader.TransmitPacket([]aznotppackets.Packetable{random})
+	assert.ErrorContains(t, err, "exceeds maximum allowed size", "incompressible packet should be rejected")
+}
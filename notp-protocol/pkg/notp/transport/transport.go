@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/permguard/permguard/common/pkg/extensions/data"
 	aznotppackets "github.com/permguard/permguard/notp-protocol/pkg/notp/packets"
 )
 
@@ -31,8 +30,12 @@ const (
 )
 
 // TransportLayer represents the transport layer responsible for packet transmission in the NOTP protocol.
+// Each peer advertises its compression codecs in the protocol packet, packets are sent with the legacy zlib framing
+// until the codecs of the peer are known, so that peers not advertising any keep working.
 type TransportLayer struct {
 	maxPacketSize  int
+	compressions   []uint8
+	compression    uint8
 	inspector      *PacketInspector
 	packetSender   PacketSender
 	packetReceiver PacketReceiver
@@ -51,25 +54,26 @@ func (t *TransportLayer) TransmitPacket(packetables []aznotppackets.Packetable)
 	if err != nil {
 		return err
 	}
-	writer.WriteProtocol(&aznotppackets.ProtocolPacket{Version: 1})
+	writer.WriteProtocol(&aznotppackets.ProtocolPacket{Version: 1, Compressions: t.compressions})
 	for _, packetable := range packetables {
 		err := writer.AppendDataPacket(packetable)
 		if err != nil {
 			return err
 		}
 	}
-	compressedData, err := data.CompressData(packet.Data)
+	compressedData, err := compressFrame(t.compression, packet.Data)
 	if err != nil {
 		return err
 	}
-	packet.Data = compressedData
-	if len(packet.Data) > t.maxPacketSize {
-		return fmt.Errorf("notp: packet size %d exceeds maximum allowed size %d", len(packet.Data), t.maxPacketSize)
+	wirePacket := aznotppackets.Packet{Data: compressedData}
+	if len(wirePacket.Data) > t.maxPacketSize {
+		return fmt.Errorf("notp: packet size %d exceeds maximum allowed size %d", len(wirePacket.Data), t.maxPacketSize)
 	}
-	err = t.packetSender(&packet)
+	err = t.packetSender(&wirePacket)
 	if err != nil {
 		return err
 	}
+	// As for received packets, the inspector is given the uncompressed packet.
 	if t.inspector != nil {
 		t.inspector.InspectSent(&packet)
 	}
@@ -91,7 +95,7 @@ func (t *TransportLayer) ReceivePacket() ([]aznotppackets.Packetable, error) {
 	if len(packet.Data) > t.maxPacketSize {
 		return nil, fmt.Errorf("notp: received packet size %d exceeds maximum allowed size %d", len(packet.Data), t.maxPacketSize)
 	}
-	decompressedData, err := data.DecompressData(packet.Data)
+	decompressedData, err := decompressFrame(t.compressions, packet.Data, maxDecompressedSize(t.maxPacketSize))
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +114,9 @@ func (t *TransportLayer) ReceivePacket() ([]aznotppackets.Packetable, error) {
 	if protocol.Version != 1 {
 		return nil, errors.New("notp: unsupported protocol version")
 	}
+	if t.compression == compressionLegacy {
+		t.compression = negotiateCompression(t.compressions, protocol.Compressions)
+	}
 	packetables := []aznotppackets.Packetable{}
 	var state *aznotppackets.DataPacketState
 	for {
@@ -119,7 +126,8 @@ func (t *TransportLayer) ReceivePacket() ([]aznotppackets.Packetable, error) {
 			return nil, err
 		}
 		packetable := &aznotppackets.Packet{
-			Data: data,
+			Data:     data,
+			DataType: state.PacketType(),
 		}
 		packetables = append(packetables, packetable)
 		if state.IsComplete() {
@@ -142,6 +150,8 @@ func NewTransportLayer(maxPacketSize int, packetSender PacketSender, packetRecei
 	}
 	return &TransportLayer{
 		maxPacketSize:  maxPacketSize,
+		compressions:   SupportedCompressions(),
+		compression:    compressionLegacy,
 		inspector:      inspector,
 		packetSender:   packetSender,
 		packetReceiver: packetReceiver,
@@ -105,6 +105,16 @@ func (h *mockHostConfig) AppData() string {
 	return h.appData
 }
 
+// NOTPMaxPacketSize returns the notp maximum packet size in bytes.
+func (h *mockHostConfig) NOTPMaxPacketSize() int {
+	return 0
+}
+
+// NOTPTraceFile returns the file the notp packets are recorded in.
+func (h *mockHostConfig) NOTPTraceFile() string {
+	return ""
+}
+
 // mockServiceConfig is a mock type for the ServiceConfigReader type.
 type mockServiceConfig struct {
 	values map[string]any
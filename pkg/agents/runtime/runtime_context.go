@@ -42,6 +42,10 @@ type Context interface {
 type HostConfigReader interface {
 	// AppData returns the zone data.
 	AppData() string
+	// NOTPMaxPacketSize returns the notp maximum packet size in bytes.
+	NOTPMaxPacketSize() int
+	// NOTPTraceFile returns the file the notp packets are recorded in, empty if tracing is disabled.
+	NOTPTraceFile() string
 }
 
 // ServiceConfigReader declares the service configuration reader.
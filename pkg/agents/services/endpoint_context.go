@@ -18,7 +18,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
 	"go.uber.org/zap"
 
@@ -38,6 +41,8 @@ type EndpointContext struct {
 	parentCtx *ServiceContext
 	logger    *zap.Logger
 	port      int
+	stopMu    sync.Mutex
+	stopFuncs []func() error
 }
 
 // NewEndpointContext creates a new endpoint context.
@@ -88,3 +93,23 @@ func (e *EndpointContext) HostConfigReader() (runtime.HostConfigReader, error) {
 func (e *EndpointContext) ServiceConfigReader() (runtime.ServiceConfigReader, error) {
 	return e.parentCtx.ServiceConfigReader()
 }
+
+// OnStop registers a function releasing the resources of a server, called once the endpoint has stopped serving.
+func (e *EndpointContext) OnStop(stopFunc func() error) {
+	e.stopMu.Lock()
+	defer e.stopMu.Unlock()
+	e.stopFuncs = append(e.stopFuncs, stopFunc)
+}
+
+// Stop calls the functions registered with OnStop in reverse order, once.
+func (e *EndpointContext) Stop() error {
+	e.stopMu.Lock()
+	stopFuncs := e.stopFuncs
+	e.stopFuncs = nil
+	e.stopMu.Unlock()
+	var errs []error
+	for _, stopFunc := range slices.Backward(stopFuncs) {
+		errs = append(errs, stopFunc())
+	}
+	return errors.Join(errs...)
+}
@@ -18,13 +18,17 @@ package services
 
 // HostConfiguration declares the host configuration.
 type HostConfiguration struct {
-	appData string
+	appData           string
+	notpMaxPacketSize int
+	notpTraceFile     string
 }
 
 // NewHostConfiguration creates a new host configuration.
-func NewHostConfiguration(appData string) *HostConfiguration {
+func NewHostConfiguration(appData string, notpMaxPacketSize int, notpTraceFile string) *HostConfiguration {
 	return &HostConfiguration{
-		appData: appData,
+		appData:           appData,
+		notpMaxPacketSize: notpMaxPacketSize,
+		notpTraceFile:     notpTraceFile,
 	}
 }
 
@@ -32,3 +36,13 @@ func NewHostConfiguration(appData string) *HostConfiguration {
 func (h *HostConfiguration) AppData() string {
 	return h.appData
 }
+
+// NOTPMaxPacketSize returns the notp maximum packet size in bytes.
+func (h *HostConfiguration) NOTPMaxPacketSize() int {
+	return h.notpMaxPacketSize
+}
+
+// NOTPTraceFile returns the file the notp packets are recorded in.
+func (h *HostConfiguration) NOTPTraceFile() string {
+	return h.notpTraceFile
+}
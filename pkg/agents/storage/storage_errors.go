@@ -30,6 +30,9 @@ var (
 	// ErrConflict indicates a conflict such as an optimistic-lock violation.
 	ErrConflict = errors.New("storage: conflict")
 
+	// ErrConfirmationRequired indicates a destructive operation that must be confirmed or forced.
+	ErrConfirmationRequired = errors.New("storage: confirmation required")
+
 	// ErrInvalidInput indicates the caller provided invalid data.
 	ErrInvalidInput = errors.New("storage: invalid input")
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/permguard/permguard/pkg/transport/models"
 	azmpap "github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
@@ -29,20 +30,30 @@ type PAPCentralStorage interface {
 	CreateLedger(ctx context.Context, ledger *azmpap.Ledger) (*azmpap.Ledger, error)
 	// UpdateLedger updates an ledger.
 	UpdateLedger(ctx context.Context, ledger *azmpap.Ledger) (*azmpap.Ledger, error)
-	// DeleteLedger deletes an ledger.
-	DeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (*azmpap.Ledger, error)
+	// DeleteLedger soft deletes a ledger.
+	DeleteLedger(ctx context.Context, zoneID int64, ledgerID string, options *models.DeleteOptions) (*azmpap.Ledger, error)
+	// UndeleteLedger restores a soft deleted ledger.
+	UndeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (*azmpap.Ledger, error)
+	// PurgeDeletedLedgers permanently deletes the ledgers soft deleted for longer than the retention.
+	PurgeDeletedLedgers(ctx context.Context, retention time.Duration) (int64, error)
 	// FetchLedgers gets all ledgers.
 	FetchLedgers(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any) ([]azmpap.Ledger, error)
+	// FetchLedgersPage gets a page of ledgers, sorted and resumed as per the options.
+	FetchLedgersPage(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any, options *models.FetchOptions) ([]azmpap.Ledger, *models.FetchPageInfo, error)
 	// PushAdvertise handles the push advertise step.
 	PushAdvertise(ctx context.Context, req *azmpap.PushAdvertiseRequest) (*azmpap.PushAdvertiseResponse, error)
 	// PushTransfer handles the push transfer step (receives objects and optionally commits).
 	PushTransfer(ctx context.Context, req *azmpap.PushTransferRequest) (*azmpap.PushTransferResponse, error)
+	// PushCommit stores the objects of a streamed push and updates the ledger ref in a single step.
+	PushCommit(ctx context.Context, req *azmpap.PushCommitRequest) (*azmpap.PushCommitResponse, error)
 	// PullState handles the pull state step.
 	PullState(ctx context.Context, req *azmpap.PullStateRequest) (*azmpap.PullStateResponse, error)
 	// PullNegotiate handles the pull negotiate step (computes diff commit IDs).
 	PullNegotiate(ctx context.Context, req *azmpap.PullNegotiateRequest) (*azmpap.PullNegotiateResponse, error)
 	// PullObjects handles the pull objects step (returns objects for a commit).
-	PullObjects(ctx context.Context, req *azmpap.PullObjectsRequest) (*azmpap.PullObjectsResponse, error)
+	// The held set collects the objects held by the client within a pull session: when empty it is filled from the have
+	// commits, and the returned objects are added to it. It is nil outside a session.
+	PullObjects(ctx context.Context, req *azmpap.PullObjectsRequest, held map[string]struct{}) (*azmpap.PullObjectsResponse, error)
 	// CleanupStaleTransactions cleans up stale pending transactions older than maxAge.
 	// Returns the number of transactions cleaned and total objects deleted.
 	CleanupStaleTransactions(ctx context.Context, maxAge time.Duration) (int, int64, error)
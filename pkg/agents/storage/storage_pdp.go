@@ -26,4 +26,6 @@ import (
 type PDPCentralStorage interface {
 	// LoadPolicyStore loads the policy store for a given zone ID and store ID.
 	LoadPolicyStore(ctx context.Context, zoneID int64, storeID string) (*authzen.PolicyStore, error)
+	// LoadPolicyStoreAtCommit loads the policy store for a given zone ID and store ID as of a historical commit.
+	LoadPolicyStoreAtCommit(ctx context.Context, zoneID int64, storeID string, commitID string) (*authzen.PolicyStore, error)
 }
@@ -18,7 +18,9 @@ package storage
 
 import (
 	"context"
+	"time"
 
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
@@ -28,8 +30,14 @@ type ZAPCentralStorage interface {
 	CreateZone(ctx context.Context, zone *zap.Zone) (*zap.Zone, error)
 	// UpdateZone updates a zone.
 	UpdateZone(ctx context.Context, zone *zap.Zone) (*zap.Zone, error)
-	// DeleteZone deletes a zone.
-	DeleteZone(ctx context.Context, zoneID int64) (*zap.Zone, error)
+	// DeleteZone soft deletes a zone.
+	DeleteZone(ctx context.Context, zoneID int64, options *models.DeleteOptions) (*zap.Zone, error)
+	// UndeleteZone restores a soft deleted zone.
+	UndeleteZone(ctx context.Context, zoneID int64) (*zap.Zone, error)
+	// PurgeDeletedZones permanently deletes the zones soft deleted for longer than the retention.
+	PurgeDeletedZones(ctx context.Context, retention time.Duration) (int64, error)
 	// FetchZones returns all zones filtering by search criteria.
 	FetchZones(ctx context.Context, page int32, pageSize int32, fields map[string]any) ([]zap.Zone, error)
+	// FetchZonesPage returns a page of zones filtering by search criteria, sorted and resumed as per the options.
+	FetchZonesPage(ctx context.Context, page int32, pageSize int32, fields map[string]any, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error)
 }
@@ -17,11 +17,29 @@
 package languages
 
 import (
+	"errors"
+
+	"github.com/permguard/permguard/pkg/authz/residuals"
 	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
 	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
+// ErrSchemaViolation is returned when an authorization request does not conform to a strict schema.
+var ErrSchemaViolation = errors.New("languages: the request does not conform to the schema")
+
+// SourceRelationError is an error of an object found validating the relations between the source files.
+type SourceRelationError struct {
+	// Partition is the partition of the source file.
+	Partition string
+	// Path is the path of the source file.
+	Path string
+	// ObjectName is the name of the object the error refers to.
+	ObjectName string
+	// Message is the message of the error.
+	Message string
+}
+
 // LanguageAbstraction is the interface for the language abstraction.
 type LanguageAbstraction interface {
 	// BuildManifest builds the manifest.
@@ -42,14 +60,28 @@ type LanguageAbstraction interface {
 	CreatePolicyBlobObjects(mfestLang *azmanifests.Language, partition string, path string, data []byte) (*objects.MultiSectionsObject, error)
 	// CreatePolicyContentBytesBody creates a multi policy content bytes.
 	CreatePolicyContentBytes(mfestLang *azmanifests.Language, blocks [][]byte) ([]byte, string, error)
+	// CreateTemplateLinksContentBytes creates the template links content bytes and returns the file name.
+	CreateTemplateLinksContentBytes(mfestLang *azmanifests.Language, blocks [][]byte) ([]byte, string, error)
+	// CreateRolesContentBytes creates the role and group definitions content bytes and returns the file name.
+	CreateRolesContentBytes(mfestLang *azmanifests.Language, blocks [][]byte) ([]byte, string, error)
 	// SchemaFileNames gets the schema file names.
 	SchemaFileNames() []string
-	// CreateSchemaBlobObjects creates multi sections schema blob objects.
-	CreateSchemaBlobObjects(mfestLang *azmanifests.Language, partition string, path string, data []byte) (*objects.MultiSectionsObject, error)
+	// CreateSchemaBlobObjects creates multi sections schema blob objects, strict schemas are enforced on the authorization requests.
+	CreateSchemaBlobObjects(mfestLang *azmanifests.Language, partition string, path string, data []byte, strict bool) (*objects.MultiSectionsObject, error)
 	// CreateSchemaContentBytes creates a schema content bytes.
 	CreateSchemaContentBytes(mfestLang *azmanifests.Language, blocks []byte) ([]byte, string, error)
 	// ConvertBytesToHumanLanguage converts bytes to the human-readable language.
 	ConvertBytesToHumanLanguage(mfestLang *azmanifests.Language, langID, langVersionID, langTypeID uint32, content []byte) ([]byte, error)
+	// ReferencedActions gets the actions referenced by the policy or schema content.
+	ReferencedActions(mfestLang *azmanifests.Language, langID, langVersionID, langTypeID uint32, content []byte) ([]string, error)
+	// ValidatePolicies validates the policies of a source file against the schema and returns the errors by policy id.
+	ValidatePolicies(mfestLang *azmanifests.Language, schema []byte, path string, data []byte) (map[string][]string, error)
+	// ValidateSourceRelations validates the relations between the source files of all the partitions, such as the templates referenced by the template links.
+	ValidateSourceRelations(mfestLang *azmanifests.Language, sources []LintSource) ([]SourceRelationError, error)
+	// LintSources runs the static analysis of the policy and schema sources.
+	LintSources(mfestLang *azmanifests.Language, sources []LintSource) ([]LintFinding, error)
 	// AuthorizationCheck checks the authorization.
 	AuthorizationCheck(mfestLang *azmanifests.Language, contextID string, policyStore *authzen.PolicyStore, authzCtx *authzen.AuthorizationModel) (*authzen.AuthorizationDecision, error)
+	// PartialEvaluation evaluates the authorization with an unknown resource and unknown context keys, returning the residual condition.
+	PartialEvaluation(mfestLang *azmanifests.Language, policyStore *authzen.PolicyStore, authzCtx *authzen.AuthorizationModel, unknownContextKeys []string) (*residuals.Result, error)
 }
@@ -0,0 +1,71 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package languages
+
+const (
+	// LintSeverityError is the severity of the findings making a policy ineffective.
+	LintSeverityError = "error"
+	// LintSeverityWarning is the severity of the findings which do not change the authorization decisions.
+	LintSeverityWarning = "warning"
+)
+
+const (
+	// LintRuleTypeMismatch reports policies that can never match because of a type mismatch against the schema.
+	LintRuleTypeMismatch = "type-mismatch"
+	// LintRuleShadowedPermit reports permits that are redundant because another permit already grants the same access.
+	LintRuleShadowedPermit = "shadowed-permit"
+	// LintRuleDeadPermit reports permits that can never grant access because a forbid always overrides them.
+	LintRuleDeadPermit = "dead-permit"
+	// LintRuleUnusedEntityType reports entity types declared in the schema and never used.
+	LintRuleUnusedEntityType = "unused-entity-type"
+	// LintRuleUnusedAction reports actions declared in the schema and never used.
+	LintRuleUnusedAction = "unused-action"
+	// LintRuleDuplicatePolicyID reports policy ids declared more than once across the partitions.
+	LintRuleDuplicatePolicyID = "duplicate-policy-id"
+)
+
+// LintSource is a source file to be linted.
+type LintSource struct {
+	// Partition is the partition of the source file.
+	Partition string
+	// Path is the path of the source file.
+	Path string
+	// Schema is true if the source file is a schema.
+	Schema bool
+	// Content is the raw content of the source file.
+	Content []byte
+}
+
+// LintFinding is a finding of the lint pass.
+type LintFinding struct {
+	// Rule is the rule that produced the finding.
+	Rule string
+	// Severity is the severity of the finding.
+	Severity string
+	// Partition is the partition of the source file.
+	Partition string
+	// Path is the path of the source file.
+	Path string
+	// Line is the line of the finding, zero if unknown.
+	Line int
+	// Column is the column of the finding, zero if unknown.
+	Column int
+	// PolicyID is the id of the policy the finding refers to, if any.
+	PolicyID string
+	// Message is the human-readable description of the finding.
+	Message string
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package residuals
+
+const (
+	// DecisionAllow is the decision of a partial evaluation granting access to every resource.
+	DecisionAllow = "allow"
+	// DecisionDeny is the decision of a partial evaluation denying access to every resource.
+	DecisionDeny = "deny"
+	// DecisionConditional is the decision of a partial evaluation granting access to the resources matching the residual.
+	DecisionConditional = "conditional"
+)
+
+const (
+	// OpValue is a literal boolean, long or string value.
+	OpValue = "value"
+	// OpEntity is a literal entity.
+	OpEntity = "entity"
+	// OpSet is a literal set of the arguments.
+	OpSet = "set"
+	// OpAttr is a reference to a variable or to one of its attributes, such as resource.owner.
+	OpAttr = "attr"
+	// OpAnd is the conjunction of the arguments.
+	OpAnd = "and"
+	// OpOr is the disjunction of the arguments.
+	OpOr = "or"
+	// OpNot is the negation of the argument.
+	OpNot = "not"
+	// OpEq is the equality of the arguments.
+	OpEq = "eq"
+	// OpNe is the inequality of the arguments.
+	OpNe = "ne"
+	// OpLt is the less than comparison of the arguments.
+	OpLt = "lt"
+	// OpLe is the less than or equal comparison of the arguments.
+	OpLe = "le"
+	// OpGt is the greater than comparison of the arguments.
+	OpGt = "gt"
+	// OpGe is the greater than or equal comparison of the arguments.
+	OpGe = "ge"
+	// OpIn is the membership of the first argument in the entity, or in one of the set of entities, of the second argument.
+	OpIn = "in"
+	// OpContains is the membership of the second argument in the set of the first argument.
+	OpContains = "contains"
+	// OpLike is the match of the argument with the wildcard pattern of the value, where \* and \\ are a literal * and \.
+	OpLike = "like"
+	// OpHas is the presence of the attribute of the path.
+	OpHas = "has"
+	// OpIs is the test of the entity type of the argument with the type of the value.
+	OpIs = "is"
+)
+
+// Entity is a literal entity of a residual.
+type Entity struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Node is a node of a residual condition expressed independently of the policy language.
+type Node struct {
+	Op     string   `json:"op"`
+	Args   []*Node  `json:"args,omitempty"`
+	Path   []string `json:"path,omitempty"`
+	Value  any      `json:"value,omitempty"`
+	Entity *Entity  `json:"entity,omitempty"`
+}
+
+// Result is the result of a partial evaluation.
+type Result struct {
+	Decision string   `json:"decision"`
+	Residual *Node    `json:"residual,omitempty"`
+	Policies []string `json:"policies,omitempty"`
+}
+
+// NewResult creates the result of a partial evaluation from its residual and the policies contributing to it.
+func NewResult(residual *Node, policies []string) *Result {
+	switch {
+	case IsTrue(residual):
+		return &Result{Decision: DecisionAllow, Policies: policies}
+	case IsFalse(residual):
+		return &Result{Decision: DecisionDeny, Policies: policies}
+	default:
+		return &Result{Decision: DecisionConditional, Residual: residual, Policies: policies}
+	}
+}
+
+// Value creates a literal value node.
+func Value(value any) *Node {
+	return &Node{Op: OpValue, Value: value}
+}
+
+// True creates a literal true node.
+func True() *Node {
+	return Value(true)
+}
+
+// False creates a literal false node.
+func False() *Node {
+	return Value(false)
+}
+
+// NewEntity creates a literal entity node.
+func NewEntity(entityType, id string) *Node {
+	return &Node{Op: OpEntity, Entity: &Entity{Type: entityType, ID: id}}
+}
+
+// Set creates a literal set node.
+func Set(elements ...*Node) *Node {
+	return &Node{Op: OpSet, Args: elements}
+}
+
+// Attr creates a reference to a variable or to one of its attributes.
+func Attr(path ...string) *Node {
+	return &Node{Op: OpAttr, Path: path}
+}
+
+// Binary creates a node with two arguments.
+func Binary(op string, left, right *Node) *Node {
+	return &Node{Op: op, Args: []*Node{left, right}}
+}
+
+// IsTrue reports whether the node is the literal true.
+func IsTrue(node *Node) bool {
+	return node != nil && node.Op == OpValue && node.Value == true
+}
+
+// IsFalse reports whether the node is the literal false.
+func IsFalse(node *Node) bool {
+	return node != nil && node.Op == OpValue && node.Value == false
+}
+
+// And creates the conjunction of the nodes, simplifying the literal values.
+func And(nodes ...*Node) *Node {
+	args := []*Node{}
+	for _, node := range nodes {
+		switch {
+		case IsTrue(node):
+			continue
+		case IsFalse(node):
+			return False()
+		case node.Op == OpAnd:
+			args = append(args, node.Args...)
+		default:
+			args = append(args, node)
+		}
+	}
+	switch len(args) {
+	case 0:
+		return True()
+	case 1:
+		return args[0]
+	default:
+		return &Node{Op: OpAnd, Args: args}
+	}
+}
+
+// Or creates the disjunction of the nodes, simplifying the literal values.
+func Or(nodes ...*Node) *Node {
+	args := []*Node{}
+	for _, node := range nodes {
+		switch {
+		case IsFalse(node):
+			continue
+		case IsTrue(node):
+			return True()
+		case node.Op == OpOr:
+			args = append(args, node.Args...)
+		default:
+			args = append(args, node)
+		}
+	}
+	switch len(args) {
+	case 0:
+		return False()
+	case 1:
+		return args[0]
+	default:
+		return &Node{Op: OpOr, Args: args}
+	}
+}
+
+// Not creates the negation of the node, simplifying the literal values.
+func Not(node *Node) *Node {
+	switch {
+	case IsTrue(node):
+		return False()
+	case IsFalse(node):
+		return True()
+	case node.Op == OpNot:
+		return node.Args[0]
+	default:
+		return &Node{Op: OpNot, Args: []*Node{node}}
+	}
+}
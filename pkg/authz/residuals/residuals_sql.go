@@ -0,0 +1,328 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package residuals
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedSQL is returned when a residual cannot be translated into SQL.
+var ErrUnsupportedSQL = errors.New("residuals: the residual cannot be translated into sql")
+
+// sqlComparisons maps the comparison operators to their SQL operators.
+var sqlComparisons = map[string]string{
+	OpEq: "=",
+	OpNe: "<>",
+	OpLt: "<",
+	OpLe: "<=",
+	OpGt: ">",
+	OpGe: ">=",
+}
+
+// SQLOptions configures the translation of a residual into a SQL WHERE fragment.
+type SQLOptions struct {
+	// Columns maps the attribute paths, such as resource.owner, to the columns, the resource path maps the column of the id of the resource.
+	Columns map[string]string
+	// ParentColumns maps the entity types of the parents of the resource to the columns holding their ids.
+	ParentColumns map[string]string
+	// ResourceType is the entity type of the rows, it resolves the entity type tests of the resource.
+	ResourceType string
+	// Placeholder returns the placeholder of the n-th argument starting from 1, the ? placeholder is used if nil.
+	Placeholder func(n int) string
+}
+
+// sqlTranslator translates a residual into a SQL WHERE fragment.
+type sqlTranslator struct {
+	opts      *SQLOptions
+	args      []any
+	negations int
+}
+
+// ToSQL translates a residual into a SQL WHERE fragment and the arguments of its placeholders.
+// The conditions on the columns inside a negation are guarded with IS NOT NULL, so that the rows with a null column satisfy
+// the negation as the forbid policies failing on a missing attribute are skipped. Outside a negation the rows with a null
+// column are excluded, as the permit policies failing on a missing attribute.
+func ToSQL(node *Node, opts *SQLOptions) (string, []any, error) {
+	if node == nil {
+		return "", nil, fmt.Errorf("%w: missing residual", ErrUnsupportedSQL)
+	}
+	if opts == nil {
+		opts = &SQLOptions{}
+	}
+	translator := &sqlTranslator{opts: opts, args: []any{}}
+	where, err := translator.condition(node)
+	if err != nil {
+		return "", nil, err
+	}
+	return where, translator.args, nil
+}
+
+// placeholder adds an argument and returns its placeholder.
+func (t *sqlTranslator) placeholder(arg any) string {
+	t.args = append(t.args, arg)
+	if t.opts.Placeholder != nil {
+		return t.opts.Placeholder(len(t.args))
+	}
+	return "?"
+}
+
+// guard guards the condition on the columns with IS NOT NULL when it is inside a negation.
+func (t *sqlTranslator) guard(where string, columns ...string) string {
+	if t.negations == 0 || len(columns) == 0 {
+		return where
+	}
+	parts := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		parts = append(parts, column+" IS NOT NULL")
+	}
+	return "(" + strings.Join(append(parts, where), " AND ") + ")"
+}
+
+// column returns the column of an attribute path.
+func (t *sqlTranslator) column(path []string) (string, error) {
+	name := strings.Join(path, ".")
+	column, exists := t.opts.Columns[name]
+	if !exists {
+		return "", fmt.Errorf("%w: no column is mapped to %s", ErrUnsupportedSQL, name)
+	}
+	return column, nil
+}
+
+// condition translates a boolean node.
+func (t *sqlTranslator) condition(node *Node) (string, error) {
+	switch node.Op {
+	case OpValue:
+		value, ok := node.Value.(bool)
+		if !ok {
+			return "", fmt.Errorf("%w: %v is not a condition", ErrUnsupportedSQL, node.Value)
+		}
+		if value {
+			return "1 = 1", nil
+		}
+		return "1 = 0", nil
+	case OpAnd, OpOr:
+		parts := make([]string, 0, len(node.Args))
+		for _, arg := range node.Args {
+			part, err := t.condition(arg)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return "(" + strings.Join(parts, " "+strings.ToUpper(node.Op)+" ") + ")", nil
+	case OpNot:
+		if len(node.Args) != 1 {
+			return "", fmt.Errorf("%w: invalid negation", ErrUnsupportedSQL)
+		}
+		t.negations++
+		part, err := t.condition(node.Args[0])
+		t.negations--
+		if err != nil {
+			return "", err
+		}
+		// The groups and the guarded conditions are already enclosed in parentheses.
+		if strings.HasPrefix(part, "(") {
+			return "NOT " + part, nil
+		}
+		return "NOT (" + part + ")", nil
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe:
+		if len(node.Args) != 2 {
+			return "", fmt.Errorf("%w: invalid comparison", ErrUnsupportedSQL)
+		}
+		if where, resolved, err := t.resourceComparison(node); err != nil || resolved {
+			return where, err
+		}
+		left, err := t.operand(node.Args[0])
+		if err != nil {
+			return "", err
+		}
+		right, err := t.operand(node.Args[1])
+		if err != nil {
+			return "", err
+		}
+		columns := []string{}
+		if node.Args[0].Op == OpAttr {
+			columns = append(columns, left)
+		}
+		if node.Args[1].Op == OpAttr {
+			columns = append(columns, right)
+		}
+		return t.guard(left+" "+sqlComparisons[node.Op]+" "+right, columns...), nil
+	case OpIn:
+		return t.in(node)
+	case OpLike:
+		if len(node.Args) != 1 {
+			return "", fmt.Errorf("%w: invalid like", ErrUnsupportedSQL)
+		}
+		pattern, ok := node.Value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: invalid like pattern", ErrUnsupportedSQL)
+		}
+		left, err := t.operand(node.Args[0])
+		if err != nil {
+			return "", err
+		}
+		where := left + " LIKE " + t.placeholder(likePattern(pattern)) + ` ESCAPE '\'`
+		if node.Args[0].Op == OpAttr {
+			return t.guard(where, left), nil
+		}
+		return where, nil
+	case OpHas:
+		column, err := t.column(node.Path)
+		if err != nil {
+			return "", err
+		}
+		return column + " IS NOT NULL", nil
+	case OpIs:
+		entityType, ok := node.Value.(string)
+		if len(node.Args) != 1 || !ok || !isResource(node.Args[0]) || t.opts.ResourceType == "" {
+			return "", fmt.Errorf("%w: entity type tests are only supported on the resource of a known type", ErrUnsupportedSQL)
+		}
+		if entityType == t.opts.ResourceType {
+			return "1 = 1", nil
+		}
+		return "1 = 0", nil
+	case OpAttr:
+		column, err := t.column(node.Path)
+		if err != nil {
+			return "", err
+		}
+		return t.guard(column+" = "+t.placeholder(true), column), nil
+	default:
+		return "", fmt.Errorf("%w: the %s operator is not supported", ErrUnsupportedSQL, node.Op)
+	}
+}
+
+// operand translates a node used as operand of a comparison.
+func (t *sqlTranslator) operand(node *Node) (string, error) {
+	switch node.Op {
+	case OpAttr:
+		return t.column(node.Path)
+	case OpValue:
+		switch node.Value.(type) {
+		case bool, int64, float64, string:
+			return t.placeholder(node.Value), nil
+		default:
+			return "", fmt.Errorf("%w: the %T value is not supported", ErrUnsupportedSQL, node.Value)
+		}
+	case OpEntity:
+		// The ids of the entities are compared without their types, only the types the columns can hold are accepted.
+		if _, isParent := t.opts.ParentColumns[node.Entity.Type]; !isParent && node.Entity.Type != t.opts.ResourceType {
+			return "", fmt.Errorf("%w: the entity type %s is neither the resource type nor a mapped parent type", ErrUnsupportedSQL, node.Entity.Type)
+		}
+		return t.placeholder(node.Entity.ID), nil
+	default:
+		return "", fmt.Errorf("%w: the %s operator is not supported as an operand", ErrUnsupportedSQL, node.Op)
+	}
+}
+
+// resourceComparison translates the equality of the resource with an entity, which never matches if the entity is of another type.
+func (t *sqlTranslator) resourceComparison(node *Node) (string, bool, error) {
+	left, right := node.Args[0], node.Args[1]
+	if isResource(right) {
+		left, right = right, left
+	}
+	if !isResource(left) || right.Op != OpEntity {
+		return "", false, nil
+	}
+	if (node.Op != OpEq && node.Op != OpNe) || t.opts.ResourceType == "" {
+		return "", false, fmt.Errorf("%w: the resource can only be compared for equality with an entity of a known type", ErrUnsupportedSQL)
+	}
+	if right.Entity.Type == t.opts.ResourceType {
+		return "", false, nil
+	}
+	if node.Op == OpEq {
+		return "1 = 0", true, nil
+	}
+	return "1 = 1", true, nil
+}
+
+// in translates the membership of the resource in an entity or in a set of entities.
+func (t *sqlTranslator) in(node *Node) (string, error) {
+	if len(node.Args) != 2 || !isResource(node.Args[0]) {
+		return "", fmt.Errorf("%w: entity membership is only supported on the resource", ErrUnsupportedSQL)
+	}
+	target := node.Args[1]
+	switch target.Op {
+	case OpEntity:
+		return t.inEntity(target.Entity)
+	case OpSet:
+		parts := make([]string, 0, len(target.Args))
+		for _, element := range target.Args {
+			if element.Op != OpEntity {
+				return "", fmt.Errorf("%w: entity membership requires a set of entities", ErrUnsupportedSQL)
+			}
+			part, err := t.inEntity(element.Entity)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		if len(parts) == 0 {
+			return "1 = 0", nil
+		}
+		return "(" + strings.Join(parts, " OR ") + ")", nil
+	default:
+		return "", fmt.Errorf("%w: entity membership requires an entity", ErrUnsupportedSQL)
+	}
+}
+
+// inEntity translates the membership of the resource in an entity, either itself or one of its parents.
+func (t *sqlTranslator) inEntity(entity *Entity) (string, error) {
+	if column, exists := t.opts.ParentColumns[entity.Type]; exists {
+		return t.guard(column+" = "+t.placeholder(entity.ID), column), nil
+	}
+	if entity.Type == t.opts.ResourceType {
+		column, err := t.column([]string{"resource"})
+		if err != nil {
+			return "", err
+		}
+		return t.guard(column+" = "+t.placeholder(entity.ID), column), nil
+	}
+	return "", fmt.Errorf("%w: no parent column is mapped to %s", ErrUnsupportedSQL, entity.Type)
+}
+
+// isResource reports whether the node references the resource.
+func isResource(node *Node) bool {
+	return node.Op == OpAttr && len(node.Path) == 1 && node.Path[0] == "resource"
+}
+
+// likePattern converts a wildcard pattern into a SQL LIKE pattern escaped with the backslash.
+func likePattern(pattern string) string {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern) && (pattern[i+1] == '*' || pattern[i+1] == '\\'):
+			i++
+			if pattern[i] == '\\' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(pattern[i])
+		case c == '*':
+			sb.WriteByte('%')
+		case c == '%' || c == '_' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package residuals
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToSQL tests the translation of the residuals into SQL WHERE fragments.
+func TestToSQL(t *testing.T) {
+	opts := &SQLOptions{
+		Columns: map[string]string{
+			"resource":          "id",
+			"resource.owner":    "owner_id",
+			"resource.archived": "archived",
+			"resource.name":     "name",
+			"resource.priority": "priority",
+		},
+		ParentColumns: map[string]string{"MagicFarmacia::Platform::Branch": "branch_id"},
+		ResourceType:  "MagicFarmacia::Platform::Order",
+	}
+	tests := []struct {
+		name  string
+		node  *Node
+		where string
+		args  []any
+	}{
+		{"literal true", True(), "1 = 1", []any{}},
+		{"literal false", False(), "1 = 0", []any{}},
+		{
+			"comparisons",
+			And(Binary(OpEq, Attr("resource", "owner"), Value("amy")), Binary(OpGe, Attr("resource", "priority"), Value(int64(3)))),
+			"(owner_id = ? AND priority >= ?)",
+			[]any{"amy", int64(3)},
+		},
+		{
+			"negated attribute",
+			Or(Binary(OpNe, Attr("resource", "owner"), Value("bob")), Not(Attr("resource", "archived"))),
+			"(owner_id <> ? OR NOT (archived IS NOT NULL AND archived = ?))",
+			[]any{"bob", true},
+		},
+		{
+			"parent membership",
+			Binary(OpIn, Attr("resource"), NewEntity("MagicFarmacia::Platform::Branch", "milan")),
+			"branch_id = ?",
+			[]any{"milan"},
+		},
+		{
+			"membership in a set",
+			Binary(OpIn, Attr("resource"), Set(NewEntity("MagicFarmacia::Platform::Order", "order-1"), NewEntity("MagicFarmacia::Platform::Branch", "rome"))),
+			"(id = ? OR branch_id = ?)",
+			[]any{"order-1", "rome"},
+		},
+		{
+			"like and has",
+			And(&Node{Op: OpHas, Path: []string{"resource", "name"}}, &Node{Op: OpLike, Args: []*Node{Attr("resource", "name")}, Value: `ord\*_*`}),
+			`(name IS NOT NULL AND name LIKE ? ESCAPE '\')`,
+			[]any{`ord*\_%`},
+		},
+		{
+			"like with literal backslashes",
+			&Node{Op: OpLike, Args: []*Node{Attr("resource", "name")}, Value: `c:\\dir\\*\*"%`},
+			`name LIKE ? ESCAPE '\'`,
+			[]any{`c:\\dir\\%*"\%`},
+		},
+		{
+			"entity type test",
+			Or(&Node{Op: OpIs, Args: []*Node{Attr("resource")}, Value: "MagicFarmacia::Platform::Branch"}, Binary(OpEq, Attr("resource"), NewEntity("MagicFarmacia::Platform::Order", "order-2"))),
+			"(1 = 0 OR id = ?)",
+			[]any{"order-2"},
+		},
+		{
+			"mismatched resource type",
+			Or(Binary(OpEq, Attr("resource"), NewEntity("MagicFarmacia::Platform::Branch", "order-1")), Binary(OpNe, NewEntity("MagicFarmacia::Platform::Branch", "order-2"), Attr("resource"))),
+			"(1 = 0 OR 1 = 1)",
+			[]any{},
+		},
+		{
+			"entity attribute",
+			Binary(OpEq, Attr("resource", "owner"), NewEntity("MagicFarmacia::Platform::Branch", "milan")),
+			"owner_id = ?",
+			[]any{"milan"},
+		},
+		{
+			"negated conditions",
+			Not(And(Binary(OpEq, Attr("resource", "owner"), Value("amy")), &Node{Op: OpLike, Args: []*Node{Attr("resource", "name")}, Value: "ord*"})),
+			`NOT ((owner_id IS NOT NULL AND owner_id = ?) AND (name IS NOT NULL AND name LIKE ? ESCAPE '\'))`,
+			[]any{"amy", "ord%"},
+		},
+		{
+			"negated membership",
+			Not(Binary(OpIn, Attr("resource"), NewEntity("MagicFarmacia::Platform::Branch", "milan"))),
+			"NOT (branch_id IS NOT NULL AND branch_id = ?)",
+			[]any{"milan"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := ToSQL(tt.node, opts)
+			require.NoError(t, err, "ToSQL should not return an error")
+			assert.Equal(t, tt.where, where, "where mismatch")
+			assert.Equal(t, tt.args, args, "args mismatch")
+		})
+	}
+}
+
+// TestToSQLPlaceholder tests the custom placeholders of the SQL translation.
+func TestToSQLPlaceholder(t *testing.T) {
+	opts := &SQLOptions{
+		Columns:     map[string]string{"resource.owner": "owner_id", "resource.priority": "priority"},
+		Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	}
+	where, args, err := ToSQL(And(Binary(OpEq, Attr("resource", "owner"), Value("amy")), Binary(OpLt, Attr("resource", "priority"), Value(int64(5)))), opts)
+	require.NoError(t, err, "ToSQL should not return an error")
+	assert.Equal(t, "(owner_id = $1 AND priority < $2)", where, "where mismatch")
+	assert.Equal(t, []any{"amy", int64(5)}, args, "args mismatch")
+}
+
+// TestToSQLUnsupported tests the residuals which cannot be translated into SQL.
+func TestToSQLUnsupported(t *testing.T) {
+	opts := &SQLOptions{
+		Columns: map[string]string{"resource.owner": "owner_id"},
+	}
+	tests := []struct {
+		name string
+		node *Node
+	}{
+		{"missing residual", nil},
+		{"unmapped column", Binary(OpEq, Attr("resource", "region"), Value("eu"))},
+		{"unmapped parent", Binary(OpIn, Attr("resource"), NewEntity("MagicFarmacia::Platform::Branch", "milan"))},
+		{"membership of an attribute", Binary(OpIn, Attr("resource", "owner"), NewEntity("MagicFarmacia::Platform::Branch", "milan"))},
+		{"unknown resource type", &Node{Op: OpIs, Args: []*Node{Attr("resource")}, Value: "MagicFarmacia::Platform::Order"}},
+		{"resource of an unknown type", Binary(OpEq, Attr("resource"), NewEntity("MagicFarmacia::Platform::Order", "order-1"))},
+		{"resource ordering", Binary(OpLt, Attr("resource"), NewEntity("MagicFarmacia::Platform::Order", "order-1"))},
+		{"unmapped entity type", Binary(OpEq, Attr("resource", "owner"), NewEntity("MagicFarmacia::Platform::User", "amy"))},
+		{"set contains", Binary(OpContains, Attr("resource", "owner"), Value("amy"))},
+		{"non boolean value", Value("amy")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ToSQL(tt.node, opts)
+			assert.ErrorIs(t, err, ErrUnsupportedSQL, "error mismatch")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package residuals
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCombinators tests the simplification of the literal values by the combinators.
+func TestCombinators(t *testing.T) {
+	assert := assert.New(t)
+
+	owner := Binary(OpEq, Attr("resource", "owner"), Value("amy"))
+	archived := Attr("resource", "archived")
+
+	assert.Equal(owner, And(True(), owner), "true should be dropped from a conjunction")
+	assert.True(IsFalse(And(owner, False())), "false should absorb a conjunction")
+	assert.True(IsTrue(And()), "an empty conjunction should be true")
+	assert.Equal(owner, Or(False(), owner), "false should be dropped from a disjunction")
+	assert.True(IsTrue(Or(owner, True())), "true should absorb a disjunction")
+	assert.True(IsFalse(Or()), "an empty disjunction should be false")
+	assert.Equal(&Node{Op: OpAnd, Args: []*Node{owner, archived, owner}}, And(And(owner, archived), owner), "nested conjunctions should be flattened")
+	assert.Equal(&Node{Op: OpOr, Args: []*Node{owner, archived, owner}}, Or(owner, Or(archived, owner)), "nested disjunctions should be flattened")
+	assert.True(IsFalse(Not(True())), "not true should be false")
+	assert.True(IsTrue(Not(False())), "not false should be true")
+	assert.Equal(owner, Not(Not(owner)), "double negations should be removed")
+}
+
+// TestNewResult tests the decision of the result of a partial evaluation.
+func TestNewResult(t *testing.T) {
+	assert := assert.New(t)
+
+	result := NewResult(True(), []string{"view-all"})
+	assert.Equal(DecisionAllow, result.Decision, "decision mismatch")
+	assert.Nil(result.Residual, "residual should be empty")
+
+	result = NewResult(False(), nil)
+	assert.Equal(DecisionDeny, result.Decision, "decision mismatch")
+	assert.Nil(result.Residual, "residual should be empty")
+
+	residual := And(Binary(OpEq, Attr("resource", "owner"), Value("amy")), Not(Attr("resource", "archived")))
+	result = NewResult(residual, []string{"view-own-orders"})
+	assert.Equal(DecisionConditional, result.Decision, "decision mismatch")
+	assert.Equal(residual, result.Residual, "residual mismatch")
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err, "Marshal should not return an error")
+	assert.JSONEq(`{
+  "decision": "conditional",
+  "residual": {"op": "and", "args": [
+    {"op": "eq", "args": [{"op": "attr", "path": ["resource", "owner"]}, {"op": "value", "value": "amy"}]},
+    {"op": "not", "args": [{"op": "attr", "path": ["resource", "archived"]}]}
+  ]},
+  "policies": ["view-own-orders"]
+}`, string(data), "json mismatch")
+}
@@ -28,11 +28,24 @@ import (
 	"github.com/permguard/permguard/pkg/core/files"
 )
 
+const (
+	// envPrefix is the prefix of the environment variables of the settings.
+	envPrefix = "PERMGUARD"
+)
+
+// envKeyReplacer replaces the separators of the setting keys in the names of the environment variables.
+var envKeyReplacer = strings.NewReplacer("-", "_", ".", "_")
+
 // configureViper configures the viper.
 func configureViper(v *viper.Viper) {
 	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
-	v.SetEnvPrefix("PERMGUARD")
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.SetEnvPrefix(envPrefix)
+}
+
+// EnvVarName returns the name of the environment variable which overrides the setting key, as resolved by the viper.
+func EnvVarName(key string) string {
+	return envKeyReplacer.Replace(strings.ToUpper(envPrefix + "_" + key))
 }
 
 // checkIfKeyExists checks if the key exists.
@@ -124,6 +137,22 @@ func OverrideViperFromConfig(v *viper.Viper, valueMap map[string]interface{}) er
 	return newViper.WriteConfigAs(v.ConfigFileUsed())
 }
 
+// UpdateViperConfig rewrites the config with the settings changed by the update function.
+// Unlike OverrideViperFromConfig, the update function can remove settings, including nested ones.
+func UpdateViperConfig(v *viper.Viper, update func(settings map[string]any)) error {
+	newViper, err := NewViperFromConfig(nil)
+	if err != nil {
+		return err
+	}
+	settings := newViper.AllSettings()
+	update(settings)
+	writeViper := viper.New()
+	for key, value := range settings {
+		writeViper.Set(key, value)
+	}
+	return writeViper.WriteConfigAs(v.ConfigFileUsed())
+}
+
 // Viperize creates a new viper and a new cobra command.
 func Viperize(funcs ...func(*flag.FlagSet) error) (*viper.Viper, *cobra.Command, error) {
 	viper := viper.New()
@@ -18,13 +18,32 @@ package validators
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
 
 	cid "github.com/ipfs/go-cid"
 
 	"github.com/permguard/permguard/common/pkg/extensions/validators"
 )
 
+const (
+	// maxLabels is the maximum number of labels an entity can have.
+	maxLabels = 64
+	// maxLabelKeyLength is the maximum length of a label key.
+	maxLabelKeyLength = 63
+	// maxLabelValueLength is the maximum length of a label value.
+	maxLabelValueLength = 255
+	// maxDescriptionLength is the maximum length of a description.
+	maxDescriptionLength = 1024
+)
+
+// nameSearchRegex matches the terms a name can be searched by.
+var nameSearchRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// labelKeyRegex matches lower case keys made of alphanumerics, '-', '_', '.' and '/' starting and ending with an alphanumeric.
+var labelKeyRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9._/-]*[a-z0-9])?$`)
+
 // ValidateCodeID validates a zone ID.
 func ValidateCodeID(entity string, zoneID int64) error {
 	vZoneID := struct {
@@ -120,3 +139,55 @@ func ValidateName(entity string, name string) error {
 	}
 	return nil
 }
+
+// ValidateNameSearch validates a term used to search names by prefix or substring.
+func ValidateNameSearch(entity string, term string) error {
+	if len(term) > 255 {
+		return fmt.Errorf("validators: %s name search is too long (max 255 characters)", entity)
+	}
+	if !nameSearchRegex.MatchString(term) {
+		return fmt.Errorf("validators: %s name search %s is not valid. it must be lower case alphanumerics or '-'", entity, term)
+	}
+	return nil
+}
+
+// ValidateLabelKey validates a label key.
+func ValidateLabelKey(entity string, key string) error {
+	if len(key) > maxLabelKeyLength {
+		return fmt.Errorf("validators: %s label key %s is too long (max %d characters)", entity, key, maxLabelKeyLength)
+	}
+	if !labelKeyRegex.MatchString(key) {
+		return fmt.Errorf("validators: %s label key %s is not valid. it must be lower case alphanumerics, '-', '_', '.' or '/' and start and end with an alphanumeric", entity, key)
+	}
+	return nil
+}
+
+// ValidateLabels validates the labels of an entity.
+func ValidateLabels(entity string, labels map[string]string) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("validators: %s has too many labels (max %d)", entity, maxLabels)
+	}
+	for key, value := range labels {
+		if err := ValidateLabelKey(entity, key); err != nil {
+			return err
+		}
+		if len(value) > maxLabelValueLength {
+			return fmt.Errorf("validators: %s label %s value is too long (max %d characters)", entity, key, maxLabelValueLength)
+		}
+		if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return fmt.Errorf("validators: %s label %s value is not valid. it cannot contain control characters", entity, key)
+		}
+	}
+	return nil
+}
+
+// ValidateDescription validates a description.
+func ValidateDescription(entity string, description string) error {
+	if len(description) > maxDescriptionLength {
+		return fmt.Errorf("validators: %s description is too long (max %d characters)", entity, maxDescriptionLength)
+	}
+	if strings.ContainsFunc(description, func(r rune) bool { return unicode.IsControl(r) && r != '\n' && r != '\t' }) {
+		return fmt.Errorf("validators: %s description is not valid. it cannot contain control characters", entity)
+	}
+	return nil
+}
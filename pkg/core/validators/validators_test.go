@@ -17,6 +17,7 @@
 package validators
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -110,3 +111,53 @@ func TestValidateName(t *testing.T) {
 		}
 	}
 }
+
+// TestValidateLabels tests the ValidateLabels function.
+func TestValidateLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		labels   map[string]string
+		hasError bool
+	}{
+		{nil, false},
+		{map[string]string{"owner": "team-a", "env": "prod", "cost-centre": "cc-42"}, false},
+		{map[string]string{"permguard.com/tier": "gold"}, false},
+		{map[string]string{"owner": ""}, false},
+		{map[string]string{"": "value"}, true},
+		{map[string]string{"Owner": "team-a"}, true},
+		{map[string]string{"-owner": "team-a"}, true},
+		{map[string]string{"owner=": "team-a"}, true},
+		{map[string]string{strings.Repeat("a", 64): "value"}, true},
+		{map[string]string{"owner": strings.Repeat("a", 256)}, true},
+		{map[string]string{"owner": "team\na"}, true},
+	}
+	for _, tc := range testCases {
+		result := ValidateLabels("zone", tc.labels)
+		if tc.hasError {
+			assert.Error(result, "error should not be nil")
+		} else {
+			assert.NoError(result, "error should be nil")
+		}
+	}
+}
+
+// TestValidateDescription tests the ValidateDescription function.
+func TestValidateDescription(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(ValidateDescription("zone", ""), "error should be nil")
+	assert.NoError(ValidateDescription("zone", "Tenant zone for the orders service.\nOwned by team a."), "error should be nil")
+	assert.Error(ValidateDescription("zone", strings.Repeat("a", 1025)), "error should not be nil")
+	assert.Error(ValidateDescription("zone", "bad\x00description"), "error should not be nil")
+}
+
+// TestValidateNameSearch tests the ValidateNameSearch function.
+func TestValidateNameSearch(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(ValidateNameSearch("zone", "orders-"), "error should be nil")
+	assert.NoError(ValidateNameSearch("zone", "-eu"), "error should be nil")
+	assert.Error(ValidateNameSearch("zone", ""), "error should not be nil")
+	assert.Error(ValidateNameSearch("zone", "orders%"), "error should not be nil")
+	assert.Error(ValidateNameSearch("zone", "orders_"), "error should not be nil")
+	assert.Error(ValidateNameSearch("zone", strings.Repeat("a", 256)), "error should not be nil")
+}
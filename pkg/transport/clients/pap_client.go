@@ -17,25 +17,30 @@
 package clients
 
 import (
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 )
 
 // GrpcPAPClient is the gRPC PAP client servicer.
 type GrpcPAPClient interface {
 	// CreateLedger creates a ledger.
-	CreateLedger(zoneID int64, kind string, name string) (*pap.Ledger, error)
+	CreateLedger(zoneID int64, kind string, name string, trustedKeys []string, description string, labels map[string]string) (*pap.Ledger, error)
 	// UpdateLedger updates a ledger.
 	UpdateLedger(ledger *pap.Ledger) (*pap.Ledger, error)
-	// DeleteLedger deletes a ledger.
-	DeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger, error)
+	// DeleteLedger soft deletes a ledger.
+	DeleteLedger(zoneID int64, ledgerID string, options *models.DeleteOptions) (*pap.Ledger, error)
+	// UndeleteLedger restores a soft deleted ledger.
+	UndeleteLedger(zoneID int64, ledgerID string) (*pap.Ledger, error)
 	// FetchLedgers returns all ledgers.
 	FetchLedgers(page int32, pageSize int32, zoneID int64) ([]pap.Ledger, error)
 	// FetchLedgersByID returns all ledgers filtering by ledger id.
 	FetchLedgersByID(page int32, pageSize int32, zoneID int64, ledgerID string) ([]pap.Ledger, error)
 	// FetchLedgersByName returns all ledgers filtering by name.
 	FetchLedgersByName(page int32, pageSize int32, zoneID int64, name string) ([]pap.Ledger, error)
-	// FetchLedgersBy returns all ledgers filtering by ledger id and name.
-	FetchLedgersBy(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string) ([]pap.Ledger, error)
+	// FetchLedgersBy returns all ledgers filtering by ledger id, kind, name and labels.
+	FetchLedgersBy(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string, labels map[string]string) ([]pap.Ledger, error)
+	// FetchLedgersPage returns a page of ledgers filtering by ledger id, kind, name, name prefix and labels, sorted and resumed as per the options.
+	FetchLedgersPage(page int32, pageSize int32, zoneID int64, ledgerID string, kind string, name string, namePrefix string, labels map[string]string, options *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error)
 	// Close closes the client connection.
 	Close() error
 }
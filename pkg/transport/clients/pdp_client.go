@@ -17,13 +17,32 @@
 package clients
 
 import (
+	"time"
+
 	"github.com/permguard/permguard/pkg/transport/models/pdp"
 )
 
+// DecisionCacheConfig is the configuration of the opt-in client-side decision cache.
+// Cached decisions are dropped when the TTL expires or when a response reports a new version of their policy store.
+type DecisionCacheConfig struct {
+	// TTL is the time to live of the cached decisions.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached decisions, the least recently used are evicted first.
+	MaxEntries int
+}
+
 // GrpcPDPClient is the gRPC PDP client servicer.
 type GrpcPDPClient interface {
 	// AuthorizationCheck checks the authorization.
 	AuthorizationCheck(request *pdp.AuthorizationCheckWithDefaultsRequest) (*pdp.AuthorizationCheckResponse, error)
+	// AuthorizationCheckStream checks the authorization passing each evaluation to the handler as soon as it is ready.
+	AuthorizationCheckStream(request *pdp.AuthorizationCheckWithDefaultsRequest, handler func(response *pdp.AuthorizationCheckStreamResponse) error) error
+	// AuthorizationReplay re-evaluates logged decisions against a historical commit.
+	AuthorizationReplay(request *pdp.AuthorizationReplayRequest) (*pdp.AuthorizationReplayResponse, error)
+	// AuthorizationPartialEvaluation evaluates the authorization with an unknown resource returning the residual condition.
+	AuthorizationPartialEvaluation(request *pdp.AuthorizationPartialEvaluationRequest) (*pdp.AuthorizationPartialEvaluationResponse, error)
+	// EnableDecisionCache enables the client-side cache of the authorization check decisions.
+	EnableDecisionCache(config *DecisionCacheConfig) error
 	// Close closes the client connection.
 	Close() error
 }
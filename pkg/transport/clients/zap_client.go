@@ -17,25 +17,30 @@
 package clients
 
 import (
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 )
 
 // GrpcZAPClient is the gRPC ZAP client servicer.
 type GrpcZAPClient interface {
 	// CreateZone creates a new zone.
-	CreateZone(name string) (*zap.Zone, error)
+	CreateZone(name string, description string, labels map[string]string) (*zap.Zone, error)
 	// UpdateZone updates a zone.
 	UpdateZone(zone *zap.Zone) (*zap.Zone, error)
-	// DeleteZone deletes a zone.
-	DeleteZone(zoneID int64) (*zap.Zone, error)
+	// DeleteZone soft deletes a zone.
+	DeleteZone(zoneID int64, options *models.DeleteOptions) (*zap.Zone, error)
+	// UndeleteZone restores a soft deleted zone.
+	UndeleteZone(zoneID int64) (*zap.Zone, error)
 	// FetchZones fetches zones.
 	FetchZones(page int32, pageSize int32) ([]zap.Zone, error)
 	// FetchZonesByID fetches zones by ID.
 	FetchZonesByID(page int32, pageSize int32, zoneID int64) ([]zap.Zone, error)
 	// FetchZonesByName fetches zones by name.
 	FetchZonesByName(page int32, pageSize int32, name string) ([]zap.Zone, error)
-	// FetchZonesBy fetches zones by.
-	FetchZonesBy(page int32, pageSize int32, zoneID int64, name string) ([]zap.Zone, error)
+	// FetchZonesBy fetches zones by id, name and labels.
+	FetchZonesBy(page int32, pageSize int32, zoneID int64, name string, labels map[string]string) ([]zap.Zone, error)
+	// FetchZonesPage fetches a page of zones by id, name, name prefix and labels, sorted and resumed as per the options.
+	FetchZonesPage(page int32, pageSize int32, zoneID int64, name string, namePrefix string, labels map[string]string, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error)
 	// Close closes the client connection.
 	Close() error
 }
@@ -165,6 +165,7 @@ type ClientConfig struct {
 	SkipVerify       bool
 	Spiffe           bool
 	SpiffeSocketPath string
+	TokenSource      string
 }
 
 // Validate checks the client TLS configuration for consistency.
@@ -178,6 +179,9 @@ func (c *ClientConfig) Validate() error {
 	if c.Spiffe && c.SpiffeSocketPath == "" {
 		return errors.New("tls: spiffe-enabled requires spiffe-socket-path")
 	}
+	if c.TokenSource != "" {
+		return ValidateTokenSource(c.TokenSource)
+	}
 	return nil
 }
 
@@ -0,0 +1,95 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpctls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// tokenSourceEnvPrefix is the prefix of the token sources reading the token from an environment variable.
+	tokenSourceEnvPrefix = "env:"
+	// tokenSourceFilePrefix is the prefix of the token sources reading the token from a file.
+	tokenSourceFilePrefix = "file:"
+)
+
+// ValidateTokenSource checks that the token source is either env:<VARIABLE> or file:<PATH>.
+func ValidateTokenSource(source string) error {
+	switch {
+	case strings.HasPrefix(source, tokenSourceEnvPrefix) && len(source) > len(tokenSourceEnvPrefix):
+		return nil
+	case strings.HasPrefix(source, tokenSourceFilePrefix) && len(source) > len(tokenSourceFilePrefix):
+		return nil
+	default:
+		return fmt.Errorf("tls: invalid token source %q, must be env:<VARIABLE> or file:<PATH>", source)
+	}
+}
+
+// readToken reads the token from the token source.
+func readToken(source string) (string, error) {
+	if err := ValidateTokenSource(source); err != nil {
+		return "", err
+	}
+	var token string
+	if name, ok := strings.CutPrefix(source, tokenSourceEnvPrefix); ok {
+		token = os.Getenv(name)
+	} else {
+		data, err := os.ReadFile(strings.TrimPrefix(source, tokenSourceFilePrefix))
+		if err != nil {
+			return "", fmt.Errorf("tls: failed to read the token: %w", err)
+		}
+		token = string(data)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("tls: token source %q is empty", source)
+	}
+	return token, nil
+}
+
+// tokenCredentials sends a bearer token with each call.
+type tokenCredentials struct {
+	token string
+}
+
+// GetRequestMetadata returns the authorization metadata of the call.
+func (c *tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// RequireTransportSecurity returns true as the token must never be sent in plaintext.
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// NewTokenCredentials builds gRPC per-call credentials sending the token read from the source as a bearer token.
+func NewTokenCredentials(source string) (credentials.PerRPCCredentials, error) {
+	if source == "" {
+		return nil, errors.New("tls: token source is required")
+	}
+	token, err := readToken(source)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenCredentials{token: token}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpctls
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TestValidateTokenSource tests the parsing of the token sources.
+func TestValidateTokenSource(t *testing.T) {
+	tests := []struct {
+		source      string
+		expectError bool
+	}{
+		{"env:PERMGUARD_TOKEN", false},
+		{"file:/var/run/secrets/token", false},
+		{"file:token", false},
+		{"", true},
+		{"env:", true},
+		{"file:", true},
+		{"PERMGUARD_TOKEN", true},
+		{"ENV:PERMGUARD_TOKEN", true},
+		{"vault:secret/token", true},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			err := ValidateTokenSource(test.source)
+			if test.expectError {
+				require.Error(t, err, "error should not be nil")
+			} else {
+				require.NoError(t, err, "error should be nil")
+			}
+		})
+	}
+}
+
+// TestNewTokenCredentials tests the reading of the token from the token sources.
+func TestNewTokenCredentials(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("  file-token\n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("\n"), 0o600))
+	t.Setenv("PERMGUARD_TEST_TOKEN", " env-token ")
+	t.Setenv("PERMGUARD_TEST_EMPTY_TOKEN", "")
+
+	tests := []struct {
+		name          string
+		source        string
+		expectedToken string
+		expectError   bool
+	}{
+		{name: "env", source: "env:PERMGUARD_TEST_TOKEN", expectedToken: "env-token"},
+		{name: "env-missing", source: "env:PERMGUARD_TEST_MISSING_TOKEN", expectError: true},
+		{name: "env-empty", source: "env:PERMGUARD_TEST_EMPTY_TOKEN", expectError: true},
+		{name: "file", source: "file:" + tokenFile, expectedToken: "file-token"},
+		{name: "file-missing", source: "file:" + filepath.Join(dir, "missing"), expectError: true},
+		{name: "file-unreadable", source: "file:" + dir, expectError: true},
+		{name: "file-empty", source: "file:" + emptyFile, expectError: true},
+		{name: "empty-source", source: "", expectError: true},
+		{name: "invalid-source", source: "PERMGUARD_TEST_TOKEN", expectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			creds, err := NewTokenCredentials(test.source)
+			if test.expectError {
+				require.Error(t, err, "error should not be nil")
+				assert.Nil(creds, "credentials should be nil")
+				return
+			}
+			require.NoError(t, err, "error should be nil")
+			metadata, err := creds.GetRequestMetadata(t.Context())
+			require.NoError(t, err, "error should be nil")
+			assert.Equal(map[string]string{"authorization": "Bearer " + test.expectedToken}, metadata, "metadata should be equal")
+		})
+	}
+}
+
+// TestTokenCredentialsRequireTransportSecurity tests that the token is never sent over a plaintext connection.
+func TestTokenCredentialsRequireTransportSecurity(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("PERMGUARD_TEST_TOKEN", "env-token")
+	creds, err := NewTokenCredentials("env:PERMGUARD_TEST_TOKEN")
+	require.NoError(t, err, "error should be nil")
+	assert.True(creds.RequireTransportSecurity(), "transport security should be required")
+
+	conn, err := grpc.NewClient("localhost:9092", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithPerRPCCredentials(creds))
+	require.Error(t, err, "a plaintext connection should be refused")
+	assert.Nil(conn, "connection should be nil")
+}
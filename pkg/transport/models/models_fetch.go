@@ -0,0 +1,64 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+const (
+	// FetchSortByID sorts the entries by their identifier, it is the default sort.
+	FetchSortByID = "id"
+	// FetchSortByName sorts the entries by name.
+	FetchSortByName = "name"
+	// FetchSortByCreatedAt sorts the entries by creation time.
+	FetchSortByCreatedAt = "created_at"
+	// FetchSortByUpdatedAt sorts the entries by last update time.
+	FetchSortByUpdatedAt = "updated_at"
+)
+
+const (
+	// FetchNextCursorKey is the stream trailer key carrying the cursor of the next page.
+	FetchNextCursorKey = "x-permguard-next-cursor"
+	// FetchTotalCountKey is the stream trailer key carrying the total number of entries matching the filters.
+	FetchTotalCountKey = "x-permguard-total-count"
+)
+
+// FetchOptions are the sorting and cursor options of a fetch.
+type FetchOptions struct {
+	// SortBy is the field the entries are sorted by, empty sorts by id.
+	SortBy string
+	// SortDesc sorts the entries in descending order.
+	SortDesc bool
+	// Cursor resumes the fetch after the last entry of a previous page, the page number is ignored when set.
+	Cursor string
+	// Deleted fetches the soft deleted entries instead of the live ones.
+	Deleted bool
+}
+
+// FetchPageInfo describes the page returned by a fetch.
+type FetchPageInfo struct {
+	// NextCursor is the cursor of the next page, empty when there are no more entries.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// TotalCount is the number of entries matching the filters across all the pages.
+	TotalCount int64 `json:"total_count"`
+}
+
+// IsValidFetchSortBy returns true when the sort field is supported.
+func IsValidFetchSortBy(sortBy string) bool {
+	switch sortBy {
+	case "", FetchSortByID, FetchSortByName, FetchSortByCreatedAt, FetchSortByUpdatedAt:
+		return true
+	}
+	return false
+}
@@ -29,6 +29,9 @@ type PushAdvertiseRequest struct {
 	LedgerID      string `json:"ledger_id"`
 	RefCommit     string `json:"ref_commit"`
 	RefPrevCommit string `json:"ref_prev_commit"`
+	// ResumeTxID is the transaction of an interrupted push to resume, if any.
+	// The push resumes only if the transaction is still pending and pushes the same commit on the same previous commit.
+	ResumeTxID string `json:"resume_txid,omitempty"`
 }
 
 // PushAdvertiseResponse is the response for the push advertise step.
@@ -37,6 +40,8 @@ type PushAdvertiseResponse struct {
 	ServerCommit string `json:"server_commit"`
 	HasConflicts bool   `json:"has_conflicts"`
 	IsUpToDate   bool   `json:"is_up_to_date"`
+	// ConfirmedObjects is the number of objects already staged by the resumed transaction.
+	ConfirmedObjects int64 `json:"confirmed_objects,omitempty"`
 }
 
 // PushTransferRequest is the request for the push transfer step.
@@ -53,6 +58,22 @@ type PushTransferRequest struct {
 // PushTransferResponse is the response for the push transfer step.
 type PushTransferResponse struct {
 	Committed bool `json:"committed"`
+	// ConfirmedObjects is the number of objects staged by the transaction so far.
+	ConfirmedObjects int64 `json:"confirmed_objects,omitempty"`
+}
+
+// PushCommitRequest is the request to publish the objects staged by a push transaction by updating the ledger ref.
+type PushCommitRequest struct {
+	TxID                 string `json:"txid"`
+	ZoneID               int64  `json:"zone_id"`
+	LedgerID             string `json:"ledger_id"`
+	RemoteCommitID       string `json:"remote_commit_id"`
+	ExpectedServerCommit string `json:"expected_server_commit"`
+}
+
+// PushCommitResponse is the response for the push commit step.
+type PushCommitResponse struct {
+	Committed bool `json:"committed"`
 }
 
 // PullStateRequest is the request for the pull state step.
@@ -71,24 +92,30 @@ type PullStateResponse struct {
 	IsUpToDate      bool   `json:"is_up_to_date"`
 }
 
+// MaxHaveCommits is the maximum number of commits a client can advertise as already held during a pull.
+const MaxHaveCommits = 256
+
 // PullNegotiateRequest is the request for the pull negotiate step.
 type PullNegotiateRequest struct {
-	ZoneID         int64  `json:"zone_id"`
-	LedgerID       string `json:"ledger_id"`
-	LocalCommitID  string `json:"local_commit_id"`
-	RemoteCommitID string `json:"remote_commit_id"`
+	ZoneID         int64    `json:"zone_id"`
+	LedgerID       string   `json:"ledger_id"`
+	LocalCommitID  string   `json:"local_commit_id"`
+	RemoteCommitID string   `json:"remote_commit_id"`
+	HaveCommitIDs  []string `json:"have_commit_ids,omitempty"`
 }
 
 // PullNegotiateResponse is the response for the pull negotiate step.
 type PullNegotiateResponse struct {
-	CommitIDs []string `json:"commit_ids"`
+	CommitIDs     []string `json:"commit_ids"`
+	HaveCommitIDs []string `json:"have_commit_ids,omitempty"`
 }
 
 // PullObjectsRequest is the request for the pull objects step.
 type PullObjectsRequest struct {
-	ZoneID   int64  `json:"zone_id"`
-	LedgerID string `json:"ledger_id"`
-	CommitID string `json:"commit_id"`
+	ZoneID        int64    `json:"zone_id"`
+	LedgerID      string   `json:"ledger_id"`
+	CommitID      string   `json:"commit_id"`
+	HaveCommitIDs []string `json:"have_commit_ids,omitempty"`
 }
 
 // PullObjectsResponse is the response for the pull objects step.
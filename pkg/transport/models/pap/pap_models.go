@@ -27,8 +27,14 @@ const (
 	FieldLedgerLedgerID = "ledger_id"
 	// FieldLedgerKind is the kind field for ledgers.
 	FieldLedgerKind = "kind"
-	// FieldLedgerName is the name field for ledgers.
+	// FieldLedgerName is the name field for ledgers, it matches the ledgers whose name contains the value.
 	FieldLedgerName = "name"
+	// FieldLedgerNamePrefix is the name prefix field for ledgers, it matches the ledgers whose name starts with the value.
+	FieldLedgerNamePrefix = "name_prefix"
+	// FieldLedgerLabels is the labels field for ledgers, all the given labels must match.
+	FieldLedgerLabels = "labels"
+	// FieldLedgerDeleted is the deleted field for ledgers, when true it matches the soft deleted ledgers instead of the live ones.
+	FieldLedgerDeleted = "deleted"
 	// FieldSchemaSchemaID is the schema ID field for schemas.
 	FieldSchemaSchemaID = "schema_id"
 	// FieldSchemaZoneID is the zone ID field for schemas.
@@ -44,6 +50,14 @@ type Ledger struct {
 	Name      string    `json:"name"`
 	Kind      string    `json:"kind" validate:"required,oneof='policy'"`
 	Ref       string    `json:"ref"`
+	// TrustedKeys are the public keys trusted to sign the ledger commits, nil leaves them unchanged on update.
+	TrustedKeys []string `json:"trusted_keys,omitempty"`
+	// Description is the free-form description, nil leaves it unchanged on update.
+	Description *string `json:"description,omitempty"`
+	// Labels are the free-form key/value labels, nil leaves them unchanged on update.
+	Labels map[string]string `json:"labels,omitempty"`
+	// DeletedAt is the time the ledger was soft deleted, nil for live ledgers.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // Schema is the schema.
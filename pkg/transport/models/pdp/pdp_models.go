@@ -16,6 +16,10 @@
 
 package pdp
 
+import (
+	"github.com/permguard/permguard/pkg/authz/residuals"
+)
+
 // PolicyStore is the location where policies are maintained.
 type PolicyStore struct {
 	Kind string `json:"kind,omitempty"`
@@ -83,10 +87,25 @@ type EvaluationRequest struct {
 	ContextID string         `json:"context_id,omitempty"`
 }
 
+const (
+	// EvaluationsSemanticExecuteAll evaluates all the requests of the batch.
+	EvaluationsSemanticExecuteAll = "execute_all"
+	// EvaluationsSemanticDenyOnFirstDeny stops the batch at the first denied evaluation.
+	EvaluationsSemanticDenyOnFirstDeny = "deny_on_first_deny"
+	// EvaluationsSemanticPermitOnFirstPermit stops the batch at the first permitted evaluation.
+	EvaluationsSemanticPermitOnFirstPermit = "permit_on_first_permit"
+)
+
+// EvaluationsOptions represents the options of the evaluations of the authorization check.
+type EvaluationsOptions struct {
+	EvaluationsSemantic string `json:"evaluations_semantic,omitempty"`
+}
+
 // AuthorizationCheckRequest represents the request to perform an authorization decision.
 type AuthorizationCheckRequest struct {
 	AuthorizationModel *AuthorizationModelRequest `json:"authorization_model,omitempty" validate:"required"`
 	Evaluations        []EvaluationRequest        `json:"evaluations,omitempty"`
+	Options            *EvaluationsOptions        `json:"options,omitempty"`
 }
 
 // AuthorizationCheckWithDefaultsRequest represents the request to perform an authorization decision with defaults.
@@ -122,9 +141,78 @@ type EvaluationResponse struct {
 }
 
 // AuthorizationCheckResponse represents the outcome of the authorization decision.
+// The policy store version identifies the ledger commit the decision has been taken against.
 type AuthorizationCheckResponse struct {
-	RequestID   string               `json:"request_id,omitempty"`
-	Decision    bool                 `json:"decision" validate:"required"`
-	Context     *ContextResponse     `json:"context,omitempty"`
-	Evaluations []EvaluationResponse `json:"evaluations,omitempty"`
+	RequestID          string               `json:"request_id,omitempty"`
+	Decision           bool                 `json:"decision" validate:"required"`
+	Context            *ContextResponse     `json:"context,omitempty"`
+	Evaluations        []EvaluationResponse `json:"evaluations,omitempty"`
+	PolicyStoreVersion string               `json:"policy_store_version,omitempty"`
+}
+
+// AuthorizationCheckStreamResponse represents an evaluation of the authorization check emitted as soon as it is ready.
+// The index refers to the position of the evaluation in the request, as evaluations are not emitted in order.
+type AuthorizationCheckStreamResponse struct {
+	Index      int                `json:"index"`
+	Evaluation EvaluationResponse `json:"evaluation"`
+}
+
+// AuthorizationReplay Request
+
+// ReplayEntry represents a logged evaluation to be re-evaluated.
+type ReplayEntry struct {
+	Principal  *Principal         `json:"principal,omitempty"`
+	Entities   *Entities          `json:"entities,omitempty"`
+	Evaluation *EvaluationRequest `json:"evaluation,omitempty" validate:"required"`
+	Decision   bool               `json:"decision"`
+}
+
+// AuthorizationReplayRequest represents the request to re-evaluate logged decisions against a commit.
+type AuthorizationReplayRequest struct {
+	ZoneID      int64         `json:"zone_id" validate:"required,gt=0"`
+	PolicyStore *PolicyStore  `json:"policy_store,omitempty" validate:"required"`
+	CommitID    string        `json:"commit_id" validate:"required"`
+	Entries     []ReplayEntry `json:"entries,omitempty"`
+}
+
+// AuthorizationReplay Response
+
+// ReplayResult represents the outcome of a single re-evaluated entry.
+type ReplayResult struct {
+	RequestID      string           `json:"request_id,omitempty"`
+	LoggedDecision bool             `json:"logged_decision"`
+	Decision       bool             `json:"decision"`
+	Diverged       bool             `json:"diverged"`
+	Context        *ContextResponse `json:"context,omitempty"`
+}
+
+// AuthorizationReplayResponse represents the outcome of the replay.
+type AuthorizationReplayResponse struct {
+	CommitID    string         `json:"commit_id"`
+	Divergences int64          `json:"divergences"`
+	Results     []ReplayResult `json:"results,omitempty"`
+}
+
+// AuthorizationPartialEvaluation Request
+
+// AuthorizationPartialEvaluationRequest represents the request to evaluate the authorization with an unknown resource.
+// The resource carries only the optional type of the filtered resources, the unknown context keys are left in the residual.
+type AuthorizationPartialEvaluationRequest struct {
+	AuthorizationModel *AuthorizationModelRequest `json:"authorization_model,omitempty" validate:"required"`
+	RequestID          string                     `json:"request_id,omitempty"`
+	Subject            *Subject                   `json:"subject,omitempty"`
+	Resource           *Resource                  `json:"resource,omitempty"`
+	Action             *Action                    `json:"action,omitempty"`
+	Context            map[string]any             `json:"context,omitempty"`
+	UnknownContext     []string                   `json:"unknown_context,omitempty"`
+}
+
+// AuthorizationPartialEvaluation Response
+
+// AuthorizationPartialEvaluationResponse represents the residual condition of the partial evaluation.
+type AuthorizationPartialEvaluationResponse struct {
+	RequestID string          `json:"request_id,omitempty"`
+	Decision  string          `json:"decision"`
+	Residual  *residuals.Node `json:"residual,omitempty"`
+	Policies  []string        `json:"policies,omitempty"`
 }
@@ -57,6 +57,15 @@ func IsValidIdentityType(identityType string) bool {
 	return false
 }
 
+// IsValidEvaluationsSemantic checks if the evaluations semantic is valid, the empty semantic defaults to execute all.
+func IsValidEvaluationsSemantic(semantic string) bool {
+	switch semantic {
+	case "", EvaluationsSemanticExecuteAll, EvaluationsSemanticDenyOnFirstDeny, EvaluationsSemanticPermitOnFirstPermit:
+		return true
+	}
+	return false
+}
+
 // NewEvaluationErrorResponse creates an evaluation error response.
 func NewEvaluationErrorResponse(requestID string, errorCode string, adminReason string, userReason string) *EvaluationResponse {
 	return &EvaluationResponse{
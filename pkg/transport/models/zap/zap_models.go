@@ -23,8 +23,14 @@ import (
 const (
 	// FieldZoneZoneID is the zone ID field for zones.
 	FieldZoneZoneID = "zone_id"
-	// FieldZoneName is the name field for zones.
+	// FieldZoneName is the name field for zones, it matches the zones whose name contains the value.
 	FieldZoneName = "name"
+	// FieldZoneNamePrefix is the name prefix field for zones, it matches the zones whose name starts with the value.
+	FieldZoneNamePrefix = "name_prefix"
+	// FieldZoneLabels is the labels field for zones, all the given labels must match.
+	FieldZoneLabels = "labels"
+	// FieldZoneDeleted is the deleted field for zones, when true it matches the soft deleted zones instead of the live ones.
+	FieldZoneDeleted = "deleted"
 )
 
 // Zone is the zone.
@@ -33,4 +39,10 @@ type Zone struct {
 	CreatedAt time.Time `json:"created_at" validate:"required"`
 	UpdatedAt time.Time `json:"updated_at" validate:"required"`
 	Name      string    `json:"name" validate:"required,name"`
+	// Description is the free-form description, nil leaves it unchanged on update.
+	Description *string `json:"description,omitempty"`
+	// Labels are the free-form key/value labels, nil leaves them unchanged on update.
+	Labels map[string]string `json:"labels,omitempty"`
+	// DeletedAt is the time the zone was soft deleted, nil for live zones.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
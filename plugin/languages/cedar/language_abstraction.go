@@ -20,11 +20,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/x/exp/ast"
+	"github.com/cedar-policy/cedar-go/x/exp/schema"
 
 	"github.com/permguard/permguard/pkg/authz/engines"
+	"github.com/permguard/permguard/pkg/authz/languages"
 	"github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
 	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/authz/languages/types"
@@ -81,7 +85,17 @@ func (abs *LanguageAbstraction) BackendLanguage() string {
 
 // PolicyFileExtensions gets the policy file extensions.
 func (abs *LanguageAbstraction) PolicyFileExtensions() []string {
-	return []string{cedarlang.LanguageFileExtension}
+	return []string{cedarlang.LanguageFileExtension, cedarlang.LanguageTemplateLinksFileName, cedarlang.LanguageRolesFileName}
+}
+
+// isTemplateLinksFile reports whether the file contains template links.
+func isTemplateLinksFile(filePath string) bool {
+	return strings.EqualFold(filepath.Base(filePath), cedarlang.LanguageTemplateLinksFileName)
+}
+
+// isRolesFile reports whether the file contains role and group definitions.
+func isRolesFile(filePath string) bool {
+	return strings.EqualFold(filepath.Base(filePath), cedarlang.LanguageRolesFileName)
 }
 
 // CreatePolicyBlobObjects creates multi sections policy blob objects.
@@ -89,8 +103,14 @@ func (abs *LanguageAbstraction) CreatePolicyBlobObjects(mfestLang *azmanifests.L
 	if mfestLang.Name != cedarlang.LanguageCedar {
 		return nil, errors.New("cedar: unsupported human-readable language")
 	}
+	if isTemplateLinksFile(filePath) {
+		return abs.createTemplateLinkBlobObjects(partition, filePath, data)
+	}
+	if isRolesFile(filePath) {
+		return abs.createRoleBlobObjects(partition, filePath, data)
+	}
 
-	policySet, err := cedar.NewPolicySetFromBytes(filePath, data)
+	policyList, err := parsePolicyList(filePath, data)
 	if err != nil {
 		multiSecObj, err2 := objects.NewMultiSectionsObject(filePath, 0, nil)
 		if err2 != nil {
@@ -100,24 +120,12 @@ func (abs *LanguageAbstraction) CreatePolicyBlobObjects(mfestLang *azmanifests.L
 		return multiSecObj, nil
 	}
 
-	policiesMap := policySet.Map()
-	multiSecObj, err := objects.NewMultiSectionsObject(filePath, len(policiesMap), nil)
+	multiSecObj, err := objects.NewMultiSectionsObject(filePath, len(policyList), nil)
 	if err != nil {
 		return nil, errors.New("cedar: failed to create the multi section object")
 	}
 
-	const (
-		codeTypeID = types.ClassTypePolicyID
-
-		langPolicyTypeID = cedarlang.LanguagePolicyTypeID
-	)
-
-	langID := cedarlang.LanguageCedarJSONID
-	langVersionID := cedarlang.LanguageSyntaxVersionID
-
-	i := -1
-	for _, policy := range policiesMap {
-		i++
+	for i, policy := range policyList {
 		var policyID string
 		annPolicyID, exists := policy.Annotations()["id"]
 		if !exists {
@@ -125,25 +133,22 @@ func (abs *LanguageAbstraction) CreatePolicyBlobObjects(mfestLang *azmanifests.L
 			continue
 		}
 		policyID = string(annPolicyID)
-		objName := policyID
-		codeID := objName
 
 		if isValid, err := validators.ValidatePolicyName(policyID); !isValid {
 			_ = multiSecObj.AddSectionObjectWithError(i, err)
 			continue
 		}
 
-		header, err := objects.NewObjectHeader(objects.DataTypeAbstractTree, map[string]any{
-			objects.MetaKeyLanguageID:        langID,
-			objects.MetaKeyLanguageVersionID: langVersionID,
-			objects.MetaKeyLanguageTypeID:    langPolicyTypeID,
-			objects.MetaKeyCodeID:            codeID,
-			objects.MetaKeyCodeTypeID:        codeTypeID,
-		})
+		// Policies having slots in their scopes are stored as templates.
+		codeTypeID, langTypeID := types.ClassTypePolicyID, cedarlang.LanguagePolicyTypeID
+		slots, err := templateSlots(policy)
 		if err != nil {
 			_ = multiSecObj.AddSectionObjectWithError(i, err)
 			continue
 		}
+		if len(slots) > 0 {
+			codeTypeID, langTypeID = types.ClassTypeTemplateID, cedarlang.LanguageTemplateTypeID
+		}
 
 		policyJSON, err := policy.MarshalJSON()
 		if err != nil {
@@ -151,29 +156,138 @@ func (abs *LanguageAbstraction) CreatePolicyBlobObjects(mfestLang *azmanifests.L
 			continue
 		}
 
-		obj, err := abs.objMng.CreateBlobObject(header, policyJSON)
+		if err := abs.addBlobSectionObject(multiSecObj, partition, i, policyID, codeTypeID, langTypeID, policyJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	return multiSecObj, nil
+}
+
+// createTemplateLinkBlobObjects creates multi sections template link blob objects.
+func (abs *LanguageAbstraction) createTemplateLinkBlobObjects(partition string, filePath string, data []byte) (*objects.MultiSectionsObject, error) {
+	links, err := parseTemplateLinks(data)
+	if err != nil {
+		multiSecObj, err2 := objects.NewMultiSectionsObject(filePath, 0, nil)
+		if err2 != nil {
+			return nil, errors.New("cedar: failed to create the multi section object")
+		}
+		_ = multiSecObj.AddSectionObjectWithError(0, err)
+		return multiSecObj, nil
+	}
+
+	multiSecObj, err := objects.NewMultiSectionsObject(filePath, len(links), nil)
+	if err != nil {
+		return nil, errors.New("cedar: failed to create the multi section object")
+	}
+
+	for i, link := range links {
+		if isValid, err := validators.ValidatePolicyName(link.LinkID); !isValid {
+			_ = multiSecObj.AddSectionObjectWithError(i, err)
+			continue
+		}
+		if len(strings.TrimSpace(link.TemplateID)) == 0 {
+			_ = multiSecObj.AddSectionObjectWithError(i, fmt.Errorf("cedar: missing the template id of template link %s", link.LinkID))
+			continue
+		}
+		if len(link.Args) == 0 {
+			_ = multiSecObj.AddSectionObjectWithError(i, fmt.Errorf("cedar: missing the slot bindings of template link %s", link.LinkID))
+			continue
+		}
+
+		linkJSON, err := json.Marshal(link)
 		if err != nil {
 			_ = multiSecObj.AddSectionObjectWithError(i, err)
 			continue
 		}
 
-		objInfo, err := abs.objMng.ObjectInfo(obj)
+		if err := abs.addBlobSectionObject(multiSecObj, partition, i, link.LinkID, types.ClassTypeTemplateLinkID, cedarlang.LanguageTemplateLinkTypeID, linkJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	return multiSecObj, nil
+}
+
+// createRoleBlobObjects creates multi sections role and group blob objects.
+func (abs *LanguageAbstraction) createRoleBlobObjects(partition string, filePath string, data []byte) (*objects.MultiSectionsObject, error) {
+	roles, err := parseRoleDefinitions(data)
+	if err == nil {
+		if i, exists := roleHierarchyCycle(roles); exists {
+			err = fmt.Errorf("cedar: %s %s is its own ancestor in the role hierarchy", roles[i].Kind, roles[i].ID)
+		}
+	}
+	if err != nil {
+		multiSecObj, err2 := objects.NewMultiSectionsObject(filePath, 0, nil)
+		if err2 != nil {
+			return nil, errors.New("cedar: failed to create the multi section object")
+		}
+		_ = multiSecObj.AddSectionObjectWithError(0, err)
+		return multiSecObj, nil
+	}
+
+	multiSecObj, err := objects.NewMultiSectionsObject(filePath, len(roles), nil)
+	if err != nil {
+		return nil, errors.New("cedar: failed to create the multi section object")
+	}
+
+	for i, role := range roles {
+		if isValid, err := validators.ValidatePolicyName(role.ID); !isValid {
+			_ = multiSecObj.AddSectionObjectWithError(i, err)
+			continue
+		}
+		if err := validateRoleDefinition(&role); err != nil {
+			_ = multiSecObj.AddSectionObjectWithError(i, err)
+			continue
+		}
+
+		roleJSON, err := json.Marshal(role)
 		if err != nil {
-			return nil, errors.Join(errors.New("cedar: failed to get the object info"), err)
+			_ = multiSecObj.AddSectionObjectWithError(i, err)
+			continue
 		}
 
-		_ = multiSecObj.AddSectionObjectWithParams(obj, partition, objInfo.Type(), objName, map[string]any{
-			objects.MetaKeyCodeID:            codeID,
-			objects.MetaKeyCodeTypeID:        codeTypeID,
-			objects.MetaKeyLanguageID:        langID,
-			objects.MetaKeyLanguageVersionID: langVersionID,
-			objects.MetaKeyLanguageTypeID:    langPolicyTypeID,
-		}, i)
+		if err := abs.addBlobSectionObject(multiSecObj, partition, i, role.ID, types.ClassTypeRoleID, cedarlang.LanguageRoleTypeID, roleJSON); err != nil {
+			return nil, err
+		}
 	}
 
 	return multiSecObj, nil
 }
 
+// addBlobSectionObject creates the blob object of a section and adds it to the multi sections object.
+func (abs *LanguageAbstraction) addBlobSectionObject(multiSecObj *objects.MultiSectionsObject, partition string, section int, codeID string, codeTypeID, langTypeID uint32, content []byte) error {
+	langID := cedarlang.LanguageCedarJSONID
+	langVersionID := cedarlang.LanguageSyntaxVersionID
+
+	metadata := map[string]any{
+		objects.MetaKeyLanguageID:        langID,
+		objects.MetaKeyLanguageVersionID: langVersionID,
+		objects.MetaKeyLanguageTypeID:    langTypeID,
+		objects.MetaKeyCodeID:            codeID,
+		objects.MetaKeyCodeTypeID:        codeTypeID,
+	}
+	header, err := objects.NewObjectHeader(objects.DataTypeAbstractTree, metadata)
+	if err != nil {
+		_ = multiSecObj.AddSectionObjectWithError(section, err)
+		return nil
+	}
+
+	obj, err := abs.objMng.CreateBlobObject(header, content)
+	if err != nil {
+		_ = multiSecObj.AddSectionObjectWithError(section, err)
+		return nil
+	}
+
+	objInfo, err := abs.objMng.ObjectInfo(obj)
+	if err != nil {
+		return errors.Join(errors.New("cedar: failed to get the object info"), err)
+	}
+
+	_ = multiSecObj.AddSectionObjectWithParams(obj, partition, objInfo.Type(), codeID, metadata, section)
+	return nil
+}
+
 // CreatePolicyContentBytes creates a multi policy content bytes.
 func (abs *LanguageAbstraction) CreatePolicyContentBytes(_ *azmanifests.Language, blocks [][]byte) ([]byte, string, error) {
 	var sb strings.Builder
@@ -186,13 +300,47 @@ func (abs *LanguageAbstraction) CreatePolicyContentBytes(_ *azmanifests.Language
 	return []byte(sb.String()), cedarlang.LanguageFileExtension, nil
 }
 
+// CreateTemplateLinksContentBytes creates the template links content bytes.
+func (abs *LanguageAbstraction) CreateTemplateLinksContentBytes(_ *azmanifests.Language, blocks [][]byte) ([]byte, string, error) {
+	links := make([]templateLink, 0, len(blocks))
+	for _, block := range blocks {
+		var link templateLink
+		if err := json.Unmarshal(block, &link); err != nil {
+			return nil, "", errors.Join(errors.New("cedar: invalid template link syntax"), err)
+		}
+		links = append(links, link)
+	}
+	data, err := json.MarshalIndent(links, "", "  ")
+	if err != nil {
+		return nil, "", errors.Join(errors.New("cedar: failed to marshal the template links"), err)
+	}
+	return data, cedarlang.LanguageTemplateLinksFileName, nil
+}
+
+// CreateRolesContentBytes creates the role and group definitions content bytes.
+func (abs *LanguageAbstraction) CreateRolesContentBytes(_ *azmanifests.Language, blocks [][]byte) ([]byte, string, error) {
+	roles := make([]roleDefinition, 0, len(blocks))
+	for _, block := range blocks {
+		var role roleDefinition
+		if err := json.Unmarshal(block, &role); err != nil {
+			return nil, "", errors.Join(errors.New("cedar: invalid role syntax"), err)
+		}
+		roles = append(roles, role)
+	}
+	data, err := json.MarshalIndent(roles, "", "  ")
+	if err != nil {
+		return nil, "", errors.Join(errors.New("cedar: failed to marshal the roles"), err)
+	}
+	return data, cedarlang.LanguageRolesFileName, nil
+}
+
 // SchemaFileNames gets schema file names.
 func (abs *LanguageAbstraction) SchemaFileNames() []string {
 	return []string{cedarlang.LanguageSchemaFileName}
 }
 
 // CreateSchemaBlobObjects creates multi sections schema blob objects.
-func (abs *LanguageAbstraction) CreateSchemaBlobObjects(mfestLang *azmanifests.Language, partition string, path string, data []byte) (*objects.MultiSectionsObject, error) {
+func (abs *LanguageAbstraction) CreateSchemaBlobObjects(mfestLang *azmanifests.Language, partition string, path string, data []byte, strict bool) (*objects.MultiSectionsObject, error) {
 	if mfestLang.Name != cedarlang.LanguageCedar {
 		return nil, errors.New("cedar: unsupported human-readable language")
 	}
@@ -209,19 +357,31 @@ func (abs *LanguageAbstraction) CreateSchemaBlobObjects(mfestLang *azmanifests.L
 	langID := cedarlang.LanguageCedarJSONID
 	langVersionID := cedarlang.LanguageSyntaxVersionID
 
-	// TODO: Implement schema validation
-
 	multiSecObj, err := objects.NewMultiSectionsObject(path, 1, nil)
 	if err != nil {
 		return nil, errors.Join(errors.New("cedar: failed to create the multi section object"), err)
 	}
-	header, err := objects.NewObjectHeader(objects.DataTypeAbstractTree, map[string]any{
+	metadata := map[string]any{
 		objects.MetaKeyLanguageID:        langID,
 		objects.MetaKeyLanguageVersionID: langVersionID,
 		objects.MetaKeyLanguageTypeID:    langSchemaTypeID,
 		objects.MetaKeyCodeID:            codeID,
 		objects.MetaKeyCodeTypeID:        codeTypeID,
-	})
+	}
+	if strict {
+		// Strict schemas must be well formed as they are enforced on the authorization requests.
+		var cedarSchema schema.Schema
+		if err := cedarSchema.UnmarshalJSON(data); err != nil {
+			_ = multiSecObj.AddSectionObjectWithError(0, errors.Join(errors.New("cedar: invalid schema syntax"), err))
+			return multiSecObj, nil
+		}
+		if _, err := parsePolicySchema(data); err != nil {
+			_ = multiSecObj.AddSectionObjectWithError(0, errors.Join(errors.New("cedar: invalid schema syntax"), err))
+			return multiSecObj, nil
+		}
+		metadata[objects.MetaKeySchemaStrict] = true
+	}
+	header, err := objects.NewObjectHeader(objects.DataTypeAbstractTree, metadata)
 	if err != nil {
 		_ = multiSecObj.AddSectionObjectWithError(0, err)
 		return multiSecObj, nil
@@ -273,7 +433,14 @@ func (abs *LanguageAbstraction) ConvertBytesToHumanLanguage(_ *azmanifests.Langu
 			return nil, errors.Join(errors.New("cedar: invalid policy syntax"), err)
 		}
 		humanContent = cedarPolicy.MarshalCedar()
-	case cedarlang.LanguageSchemaTypeID:
+	case cedarlang.LanguageTemplateTypeID:
+		var cedarPolicy cedar.Policy
+		err := cedarPolicy.UnmarshalJSON(content)
+		if err != nil {
+			return nil, errors.Join(errors.New("cedar: invalid template syntax"), err)
+		}
+		humanContent = restoreTemplateSlots(cedarPolicy.MarshalCedar())
+	case cedarlang.LanguageSchemaTypeID, cedarlang.LanguageTemplateLinkTypeID, cedarlang.LanguageRoleTypeID:
 		humanContent = content
 	default:
 		return nil, errors.New("cedar: invalid syntax")
@@ -281,9 +448,74 @@ func (abs *LanguageAbstraction) ConvertBytesToHumanLanguage(_ *azmanifests.Langu
 	return humanContent, nil
 }
 
-// AuthorizationCheck checks the authorization.
-func (abs *LanguageAbstraction) AuthorizationCheck(_ *azmanifests.Language, contextID string, policyStore *authzen.PolicyStore, authzCtx *authzen.AuthorizationModel) (*authzen.AuthorizationDecision, error) {
-	// Creates a new policy set.
+// ReferencedActions gets the actions referenced by the policy or schema content.
+func (abs *LanguageAbstraction) ReferencedActions(_ *azmanifests.Language, langID, langVersionID, langTypeID uint32, content []byte) ([]string, error) {
+	if cedarlang.LanguageCedarJSONID != langID {
+		return nil, errors.New("cedar: invalid backend language")
+	}
+	if cedarlang.LanguageSyntaxVersionID != langVersionID {
+		return nil, errors.New("cedar: invalid backend language version")
+	}
+	switch langTypeID {
+	case cedarlang.LanguagePolicyTypeID, cedarlang.LanguageTemplateTypeID:
+		var cedarPolicy cedar.Policy
+		if err := cedarPolicy.UnmarshalJSON(content); err != nil {
+			return nil, errors.Join(errors.New("cedar: invalid policy syntax"), err)
+		}
+		return policyScopeActions(&cedarPolicy), nil
+	case cedarlang.LanguageTemplateLinkTypeID, cedarlang.LanguageRoleTypeID:
+		return nil, nil
+	case cedarlang.LanguageSchemaTypeID:
+		actions, err := schemaActions(content)
+		if err != nil {
+			return nil, errors.Join(errors.New("cedar: invalid schema syntax"), err)
+		}
+		return actions, nil
+	default:
+		return nil, errors.New("cedar: invalid syntax")
+	}
+}
+
+// ValidatePolicies validates the policies of a source file against the schema and returns the errors by policy id.
+func (abs *LanguageAbstraction) ValidatePolicies(mfestLang *azmanifests.Language, schema []byte, path string, data []byte) (map[string][]string, error) {
+	if mfestLang.Name != cedarlang.LanguageCedar {
+		return nil, errors.New("cedar: unsupported human-readable language")
+	}
+	policySchema, err := parsePolicySchema(schema)
+	if err != nil {
+		return nil, errors.Join(errors.New("cedar: invalid schema syntax"), err)
+	}
+	policyErrors := map[string][]string{}
+	if isTemplateLinksFile(path) || isRolesFile(path) {
+		return policyErrors, nil
+	}
+	policyList, err := parsePolicyList(path, data)
+	if err != nil {
+		return nil, errors.Join(errors.New("cedar: invalid policy syntax"), err)
+	}
+	for _, policy := range policyList {
+		policyID, exists := policy.Annotations()["id"]
+		if !exists {
+			continue
+		}
+		position := policy.Position()
+		for _, typeError := range policyTypeErrors((*ast.Policy)(policy.AST()), policySchema) {
+			policyErrors[string(policyID)] = append(policyErrors[string(policyID)], fmt.Sprintf("line %d, column %d: %s", position.Line, position.Column, typeError))
+		}
+	}
+	return policyErrors, nil
+}
+
+// ValidateSourceRelations validates the template links against the templates and the role hierarchy across the source files of all the partitions.
+func (abs *LanguageAbstraction) ValidateSourceRelations(mfestLang *azmanifests.Language, sources []languages.LintSource) ([]languages.SourceRelationError, error) {
+	if mfestLang != nil && mfestLang.Name != cedarlang.LanguageCedar {
+		return nil, errors.New("cedar: unsupported human-readable language")
+	}
+	return append(templateLinkErrors(sources), roleRelationErrors(sources)...), nil
+}
+
+// buildPolicySet builds the policy set of the policy store, linking the templates.
+func buildPolicySet(policyStore *authzen.PolicyStore) (*cedar.PolicySet, error) {
 	ps := cedar.NewPolicySet()
 	for _, policy := range policyStore.Policies() {
 		objInfo := policy.ObjectInfo()
@@ -299,114 +531,224 @@ func (abs *LanguageAbstraction) AuthorizationCheck(_ *azmanifests.Language, cont
 		ps.Add(cedar.PolicyID(codeID), &policy)
 	}
 
-	// Extract the subject from the authorization context.
+	// Links the templates and adds the linked policies to the policy set.
+	templates := map[string]*cedar.Policy{}
+	for _, template := range policyStore.Templates() {
+		objInfo := template.ObjectInfo()
+		templateBytes, ok := objInfo.Instance().([]byte)
+		if !ok {
+			return nil, errors.New("cedar: template object instance is not a byte slice")
+		}
+		var template cedar.Policy
+		if err := template.UnmarshalJSON(templateBytes); err != nil {
+			return nil, errors.Join(errors.New("cedar: template could not be unmarshalled"), err)
+		}
+		codeID := objInfo.Header().MetadataString(objects.MetaKeyCodeID)
+		templates[codeID] = &template
+	}
+	for _, link := range policyStore.TemplateLinks() {
+		objInfo := link.ObjectInfo()
+		linkBytes, ok := objInfo.Instance().([]byte)
+		if !ok {
+			return nil, errors.New("cedar: template link object instance is not a byte slice")
+		}
+		var link templateLink
+		if err := json.Unmarshal(linkBytes, &link); err != nil {
+			return nil, errors.Join(errors.New("cedar: template link could not be unmarshalled"), err)
+		}
+		template, exists := templates[link.TemplateID]
+		if !exists {
+			return nil, fmt.Errorf("cedar: template %s of template link %s does not exist", link.TemplateID, link.LinkID)
+		}
+		linkedPolicy, err := linkTemplate(template, &link)
+		if err != nil {
+			return nil, errors.Join(errors.New("cedar: template could not be linked"), err)
+		}
+		ps.Add(cedar.PolicyID(link.LinkID), linkedPolicy)
+	}
+	return ps, nil
+}
+
+// requestSubject extracts the kind, the id and the entity of the subject from the authorization context.
+func requestSubject(authzCtx *authzen.AuthorizationModel) (string, string, map[string]any, error) {
 	subject := authzCtx.Subject()
 	subjectID := subject.ID()
 	if len(strings.TrimSpace(subjectID)) == 0 {
-		return nil, errors.New("cedar: bad request for the subject id")
+		return "", "", nil, errors.New("cedar: bad request for the subject id")
 	}
-	subjectKind := subject.Type()
-	var err error
-	var pmgSubjectKind string
-	pmgSubjectKind, err = createPermguardSubjectKind(subjectKind)
+	pmgSubjectKind, err := createPermguardSubjectKind(subject.Type())
 	if err != nil {
-		return nil, errors.Join(errors.New("cedar: bad request for the subject type"), err)
+		return "", "", nil, errors.Join(errors.New("cedar: bad request for the subject type"), err)
 	}
 	subjectProperties, err := createEntityAttribJSON(pmgSubjectKind, subjectID, subject.Properties())
 	if err != nil {
-		return nil, errors.Join(errors.New("cedar: bad request for the subject properties"), err)
-	}
-
-	// Extract the resource from the authorization context.
-	resource := authzCtx.Resource()
-	resourceType := resource.Type()
-	if len(strings.TrimSpace(resourceType)) == 0 {
-		return nil, errors.New("cedar: bad request for the resource type")
-	}
-	resourceID := resource.ID()
-	if len(strings.TrimSpace(resourceID)) == 0 {
-		return nil, errors.New("cedar: bad request for the resource id")
-	}
-	resourceProperties, err := createEntityAttribJSON(resourceType, resourceID, resource.Properties())
-	if err != nil {
-		return nil, errors.New("cedar: bad request for the resource properties")
+		return "", "", nil, errors.Join(errors.New("cedar: bad request for the subject properties"), err)
 	}
+	return pmgSubjectKind, subjectID, subjectProperties, nil
+}
 
-	// Extract the action from the authorization context.
+// requestAction extracts the type, the id and the entity of the action from the authorization context.
+func requestAction(authzCtx *authzen.AuthorizationModel) (string, string, map[string]any, error) {
 	action := authzCtx.Action()
 	actionID := action.ID()
 	actiondIndex := strings.LastIndex(actionID, "::")
 	if actiondIndex == -1 {
-		return nil, errors.New("cedar: bad request for an invalid action format")
+		return "", "", nil, errors.New("cedar: bad request for an invalid action format")
 	}
 	actionType := actionID[:actiondIndex]
 	if len(strings.TrimSpace(actionType)) == 0 {
-		return nil, errors.New("cedar: bad request for the action type")
+		return "", "", nil, errors.New("cedar: bad request for the action type")
 	}
 	actionID = actionID[actiondIndex+len("::"):]
 	if len(strings.TrimSpace(actionID)) == 0 {
-		return nil, errors.New("cedar: bad request for the action id")
+		return "", "", nil, errors.New("cedar: bad request for the action id")
 	}
 	actionProperties, err := createEntityAttribJSON(actionType, actionID, action.Properties())
 	if err != nil {
-		return nil, errors.Join(errors.New("cedar: bad request for the action properties"), err)
+		return "", "", nil, errors.Join(errors.New("cedar: bad request for the action properties"), err)
 	}
+	return actionType, actionID, actionProperties, nil
+}
 
-	// Extract the context from the authorization context.
+// requestContext extracts the context record from the authorization context.
+func requestContext(authzCtx *authzen.AuthorizationModel) (cedar.Record, error) {
 	context := cedar.RecordMap{}
 	contextRecord := cedar.NewRecord(context)
 	jsonContext, err := json.Marshal(authzCtx.Context())
 	if err != nil {
-		return nil, errors.Join(errors.New("cedar: bad request for the context"), err)
+		return contextRecord, errors.Join(errors.New("cedar: bad request for the context"), err)
 	}
 	if err = contextRecord.UnmarshalJSON(jsonContext); err != nil {
-		return nil, errors.Join(errors.New("cedar: bad request for the context"), err)
+		return contextRecord, errors.Join(errors.New("cedar: bad request for the context"), err)
 	}
-	hasIllegalKey := false
+	var illegalKey string
 	contextRecord.Iterate(func(key cedar.String, _ cedar.Value) bool {
 		keyStr := key.String()
 		isValid, _ := verifyKey(keyStr)
 		if !isValid {
-			hasIllegalKey = true
+			illegalKey = keyStr
 			return false
 		}
 		return true
 	})
-	if hasIllegalKey {
-		return nil, fmt.Errorf("cedar: bad request for an invalid context key, key %s is reserved by permguard and cannot be used", actionID)
+	if illegalKey != "" {
+		return contextRecord, fmt.Errorf("cedar: bad request for an invalid context key, key %s is reserved by permguard and cannot be used", illegalKey)
 	}
+	return contextRecord, nil
+}
 
-	// Build the entities.
-	// Always include subject/action/resource properties so that Cedar `when`
-	// conditions can access attributes (e.g. resource.status) even when no
-	// explicit entity set is provided in the request.
+// requestEntities builds the entities of the request from the request entities and the input entities.
+func requestEntities(authzCtx *authzen.AuthorizationModel, items ...map[string]any) (cedar.EntityMap, error) {
 	var entities cedar.EntityMap
-	authzEntitiesItems := []map[string]any{subjectProperties, actionProperties, resourceProperties}
+	authzEntitiesItems := items
 	authzEntities := authzCtx.Entities()
 	if authzEntities != nil {
 		extraItems := authzEntities.Items()
-		if _, err = verifyUIDTypeFromEntityMap(extraItems); err != nil {
+		if _, err := verifyUIDTypeFromEntityMap(extraItems); err != nil {
 			return nil, errors.Join(errors.New("cedar: bad request for the entities"), err)
 		}
 		authzEntitiesItems = append(extraItems, authzEntitiesItems...)
 	}
-	jsonEntities, err2 := json.Marshal(authzEntitiesItems)
-	if err2 != nil {
-		return nil, errors.Join(errors.New("cedar: bad request for the entities"), err2)
+	jsonEntities, err := json.Marshal(authzEntitiesItems)
+	if err != nil {
+		return nil, errors.Join(errors.New("cedar: bad request for the entities"), err)
 	}
 	if err = json.Unmarshal(jsonEntities, &entities); err != nil {
 		return nil, errors.Join(errors.New("cedar: bad request for the entities"), err)
 	}
+	return entities, nil
+}
+
+// AuthorizationCheck checks the authorization.
+func (abs *LanguageAbstraction) AuthorizationCheck(_ *azmanifests.Language, contextID string, policyStore *authzen.PolicyStore, authzCtx *authzen.AuthorizationModel) (*authzen.AuthorizationDecision, error) {
+	// Creates a new policy set.
+	ps, err := buildPolicySet(policyStore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract the subject from the authorization context.
+	pmgSubjectKind, subjectID, subjectProperties, err := requestSubject(authzCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract the resource from the authorization context.
+	resource := authzCtx.Resource()
+	resourceType := resource.Type()
+	if len(strings.TrimSpace(resourceType)) == 0 {
+		return nil, errors.New("cedar: bad request for the resource type")
+	}
+	resourceID := resource.ID()
+	if len(strings.TrimSpace(resourceID)) == 0 {
+		return nil, errors.New("cedar: bad request for the resource id")
+	}
+	resourceProperties, err := createEntityAttribJSON(resourceType, resourceID, resource.Properties())
+	if err != nil {
+		return nil, errors.New("cedar: bad request for the resource properties")
+	}
+
+	// Extract the action from the authorization context.
+	actionType, actionID, actionProperties, err := requestAction(authzCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract the context from the authorization context.
+	contextRecord, err := requestContext(authzCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the request against the strict schemas.
+	strictSchema, err := strictPolicySchema(policyStore)
+	if err != nil {
+		return nil, errors.Join(errors.New("cedar: invalid strict schema"), err)
+	}
+	if strictSchema != nil {
+		var extraItems []map[string]any
+		if authzEntities := authzCtx.Entities(); authzEntities != nil {
+			extraItems = authzEntities.Items()
+		}
+		violations, err := requestSchemaErrors(strictSchema, &schemaRequest{
+			principalType:  pmgSubjectKind,
+			principalAttrs: authzCtx.Subject().Properties(),
+			resourceType:   resourceType,
+			resourceAttrs:  resource.Properties(),
+			actionUID:      formatActionUID(cedar.NewEntityUID(cedar.EntityType(actionType), cedar.String(actionID))),
+			context:        authzCtx.Context(),
+			entities:       extraItems,
+		})
+		if err != nil {
+			return nil, errors.Join(errors.New("cedar: bad request for the schema"), err)
+		}
+		if len(violations) > 0 {
+			return nil, fmt.Errorf("%w: %s", languages.ErrSchemaViolation, strings.Join(violations, "; "))
+		}
+	}
+
+	// Build the entities.
+	// Always include subject/action/resource properties so that Cedar `when`
+	// conditions can access attributes (e.g. resource.status) even when no
+	// explicit entity set is provided in the request.
+	entities, err := requestEntities(authzCtx, subjectProperties, actionProperties, resourceProperties)
+	if err != nil {
+		return nil, err
+	}
+	principal := cedar.NewEntityUID(cedar.EntityType(pmgSubjectKind), cedar.String(subjectID))
+	if err := expandRoles(policyStore, entities, principal); err != nil {
+		return nil, err
+	}
 
 	// Create the request.
 	req := cedar.Request{
-		Principal: cedar.NewEntityUID(cedar.EntityType(pmgSubjectKind), cedar.String(subjectID)),
+		Principal: principal,
 		Action:    cedar.NewEntityUID(cedar.EntityType(actionType), cedar.String(actionID)),
 		Resource:  cedar.NewEntityUID(cedar.EntityType(resourceType), cedar.String(resourceID)),
 		Context:   contextRecord,
 	}
 
-	ok, _ := ps.IsAuthorized(entities, req)
+	ok, diag := ps.IsAuthorized(entities, req)
 	var adminError, userError *authzen.AuthorizationError
 	if !ok {
 		adminError, userError = createAuthorizationErrors(authzen.AuthzErrForbiddenCode, authzen.AuthzErrForbiddenMessage, authzen.AuthzErrForbiddenMessage)
@@ -416,5 +758,10 @@ func (abs *LanguageAbstraction) AuthorizationCheck(_ *azmanifests.Language, cont
 	if err != nil {
 		return nil, errors.Join(errors.New("cedar: failed to create the authorization decision"), err)
 	}
+	determiningPolicies := make([]string, 0, len(diag.Reasons))
+	for _, reason := range diag.Reasons {
+		determiningPolicies = append(determiningPolicies, string(reason.PolicyID))
+	}
+	authzDecision.SetDeterminingPolicies(determiningPolicies)
 	return authzDecision, nil
 }
@@ -18,11 +18,18 @@ package cedar
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/types"
+	"github.com/cedar-policy/cedar-go/x/exp/ast"
+
 	"github.com/permguard/permguard/pkg/transport/models/pdp"
 	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
 // verifyKey verifies the key.
@@ -118,3 +125,72 @@ func createEntityAttribJSON(uidType, uid string, attrs map[string]any) (map[stri
 
 	return jsonMap, nil
 }
+
+// formatActionUID formats an action uid the same way actions are referenced in the authorization requests.
+func formatActionUID(uid types.EntityUID) string {
+	return fmt.Sprintf("%s::%s", uid.Type, uid.ID)
+}
+
+// policyScopeActions returns the actions referenced by the action scope of the policy.
+func policyScopeActions(policy *cedar.Policy) []string {
+	var uids []types.EntityUID
+	switch scope := policy.AST().Action.(type) {
+	case ast.ScopeTypeEq:
+		uids = append(uids, scope.Entity)
+	case ast.ScopeTypeIn:
+		uids = append(uids, scope.Entity)
+	case ast.ScopeTypeInSet:
+		uids = append(uids, scope.Entities...)
+	}
+	actions := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		actions = append(actions, formatActionUID(uid))
+	}
+	return actions
+}
+
+// cedarSchemaNamespace represents the subset of a cedar JSON schema namespace used by the language abstraction.
+type cedarSchemaNamespace struct {
+	EntityTypes map[string]json.RawMessage `json:"entityTypes"`
+	Actions     map[string]json.RawMessage `json:"actions"`
+}
+
+// schemaActions returns the actions declared in the cedar JSON schema.
+func schemaActions(content []byte) ([]string, error) {
+	var namespaces map[string]cedarSchemaNamespace
+	if err := json.Unmarshal(content, &namespaces); err != nil {
+		return nil, err
+	}
+	actions := []string{}
+	for namespace, nsSchema := range namespaces {
+		actionType := "Action"
+		if namespace != "" {
+			actionType = namespace + "::Action"
+		}
+		for actionName := range nsSchema.Actions {
+			actions = append(actions, formatActionUID(types.NewEntityUID(types.EntityType(actionType), types.String(actionName))))
+		}
+	}
+	sort.Strings(actions)
+	return actions, nil
+}
+
+// strictPolicySchema returns the schema merging the strict schemas of the policy store, nil if no schema is strict.
+func strictPolicySchema(policyStore *authzen.PolicyStore) (*policySchema, error) {
+	var contents [][]byte
+	for _, item := range policyStore.Schemas() {
+		objInfo := item.ObjectInfo()
+		if !objInfo.Header().MetadataBool(objects.MetaKeySchemaStrict) {
+			continue
+		}
+		content, ok := objInfo.Instance().([]byte)
+		if !ok {
+			return nil, errors.New("cedar: schema object instance is not a byte slice")
+		}
+		contents = append(contents, content)
+	}
+	if len(contents) == 0 {
+		return nil, nil
+	}
+	return parsePolicySchema(contents...)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cedarlang "github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
 	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
@@ -117,3 +119,182 @@ func TestTreeCreation(t *testing.T) {
 		assert.Equal(entry.MetadataUint32(objects.MetaKeyLanguageVersionID), convertedEntry.MetadataUint32(objects.MetaKeyLanguageVersionID), "LanguageVersionID mismatch")
 	}
 }
+
+// TestAuthorizationCheckDeterminingPolicies tests that the authorization decision reports the determining policies.
+func TestAuthorizationCheckDeterminingPolicies(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+
+	policies := `@id("view-orders")
+permit(
+  principal == Permguard::Identity::User::"amy",
+  action == MagicFarmacia::Platform::Action::"view",
+  resource
+);
+
+@id("delete-orders")
+permit(
+  principal,
+  action in [MagicFarmacia::Platform::Action::"delete", MagicFarmacia::Platform::Action::"update"],
+  resource
+) when { false };`
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", "orders.cedar", []byte(policies))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err, "NewObjectManager should not return an error")
+	policyStore := &authzen.PolicyStore{}
+	actions := []string{}
+	for _, secObj := range multiSecObj.SectionObjects() {
+		require.NoError(t, secObj.Error(), "section object should not have errors")
+		objInfo, err := objMng.ObjectInfo(secObj.Object())
+		require.NoError(t, err, "ObjectInfo should not return an error")
+		policyStore.AddPolicy(secObj.Object().OID(), objInfo)
+		policyActions, err := langAbs.ReferencedActions(mfestLang, cedarlang.LanguageCedarJSONID, cedarlang.LanguageSyntaxVersionID,
+			cedarlang.LanguagePolicyTypeID, objInfo.Instance().([]byte))
+		require.NoError(t, err, "ReferencedActions should not return an error")
+		actions = append(actions, policyActions...)
+	}
+	assert.ElementsMatch([]string{
+		"MagicFarmacia::Platform::Action::view",
+		"MagicFarmacia::Platform::Action::delete",
+		"MagicFarmacia::Platform::Action::update",
+	}, actions, "referenced actions mismatch")
+
+	authzCtx := &authzen.AuthorizationModel{}
+	require.NoError(t, authzCtx.SetSubject("user", "amy", "", nil))
+	require.NoError(t, authzCtx.SetResource("MagicFarmacia::Platform::Order", "order-1", nil))
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::view", nil))
+	decision, err := langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.True(decision.Decision(), "decision should be allow")
+	assert.Equal([]string{"view-orders"}, decision.DeterminingPolicies(), "determining policies mismatch")
+
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::delete", nil))
+	decision, err = langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.False(decision.Decision(), "decision should be deny")
+	assert.Empty(decision.DeterminingPolicies(), "determining policies should be empty")
+}
+
+// TestReferencedActionsForSchema tests the actions referenced by a schema.
+func TestReferencedActionsForSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+
+	schema := `{"MagicFarmacia::Platform": {"entityTypes": {"Order": {}}, "actions": {"view": {}, "delete": {}}}}`
+	actions, err := langAbs.ReferencedActions(nil, cedarlang.LanguageCedarJSONID, cedarlang.LanguageSyntaxVersionID, cedarlang.LanguageSchemaTypeID, []byte(schema))
+	require.NoError(t, err, "ReferencedActions should not return an error")
+	assert.Equal([]string{"MagicFarmacia::Platform::Action::delete", "MagicFarmacia::Platform::Action::view"}, actions, "referenced actions mismatch")
+
+	_, err = langAbs.ReferencedActions(nil, cedarlang.LanguageCedarJSONID, cedarlang.LanguageSyntaxVersionID, cedarlang.LanguageSchemaTypeID, []byte("not-json"))
+	assert.Error(err, "ReferencedActions should fail for an invalid schema")
+}
+
+// TestAuthorizationCheckTemplateLinks tests that the template links are linked into the policy set.
+func TestAuthorizationCheckTemplateLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+
+	templates := `// The ?principal slot is bound by the template links.
+@id("share-order")
+permit(
+  principal == ?principal,
+  action == MagicFarmacia::Platform::Action::"view",
+  resource in ?resource
+) when { context.note != "?principal" };`
+	links := `[
+  {"template_id": "share-order", "link_id": "share-order-amy", "args": {
+    "?principal": {"type": "Permguard::Identity::User", "id": "amy"},
+    "?resource": {"type": "MagicFarmacia::Platform::Order", "id": "order-1"}
+  }}
+]`
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err, "NewObjectManager should not return an error")
+	policyStore := &authzen.PolicyStore{}
+
+	multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", "share.cedar", []byte(templates))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+	require.Len(t, multiSecObj.SectionObjects(), 1, "template sections mismatch")
+	secObj := multiSecObj.SectionObjects()[0]
+	require.NoError(t, secObj.Error(), "template section should not have errors")
+	assert.Equal(cedarlang.LanguageTemplateTypeID, secObj.MetadataUint32(objects.MetaKeyLanguageTypeID), "template language type mismatch")
+	objInfo, err := objMng.ObjectInfo(secObj.Object())
+	require.NoError(t, err, "ObjectInfo should not return an error")
+	policyStore.AddTemplate(secObj.Object().OID(), objInfo)
+
+	humanTemplate, err := langAbs.ConvertBytesToHumanLanguage(mfestLang, cedarlang.LanguageCedarJSONID, cedarlang.LanguageSyntaxVersionID,
+		cedarlang.LanguageTemplateTypeID, objInfo.Instance().([]byte))
+	require.NoError(t, err, "ConvertBytesToHumanLanguage should not return an error")
+	assert.Contains(string(humanTemplate), "principal == ?principal", "principal slot should be restored")
+	assert.Contains(string(humanTemplate), "resource in ?resource", "resource slot should be restored")
+	assert.NotContains(string(humanTemplate), templateSlotType, "slot placeholders should not be exposed")
+
+	multiSecObj, err = langAbs.CreatePolicyBlobObjects(mfestLang, "/", cedarlang.LanguageTemplateLinksFileName, []byte(links))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+	require.Len(t, multiSecObj.SectionObjects(), 1, "template link sections mismatch")
+	secObj = multiSecObj.SectionObjects()[0]
+	require.NoError(t, secObj.Error(), "template link section should not have errors")
+	assert.Equal("share-order-amy", secObj.ObjectName(), "template link name mismatch")
+	objInfo, err = objMng.ObjectInfo(secObj.Object())
+	require.NoError(t, err, "ObjectInfo should not return an error")
+	policyStore.AddTemplateLink(secObj.Object().OID(), objInfo)
+
+	authzCtx := &authzen.AuthorizationModel{}
+	require.NoError(t, authzCtx.SetSubject("user", "amy", "", nil))
+	require.NoError(t, authzCtx.SetResource("MagicFarmacia::Platform::Order", "order-1", nil))
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::view", nil))
+	require.NoError(t, authzCtx.SetContext(map[string]any{"note": "shared"}))
+	decision, err := langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.True(decision.Decision(), "decision should be allow")
+	assert.Equal([]string{"share-order-amy"}, decision.DeterminingPolicies(), "determining policies mismatch")
+
+	require.NoError(t, authzCtx.SetSubject("user", "bob", "", nil))
+	decision, err = langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.False(decision.Decision(), "decision should be deny")
+}
+
+// TestCreatePolicyBlobObjectsInvalidTemplates tests the errors of the templates and of the template links.
+func TestCreatePolicyBlobObjectsInvalidTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	tests := []struct {
+		name string
+		path string
+		data string
+	}{
+		{"slot in the wrong scope", "invalid.cedar", `@id("bad") permit(principal == ?resource, action, resource);`},
+		{"slot in a condition", "invalid.cedar", `@id("bad") permit(principal == ?principal, action, resource) when { resource == ?resource };`},
+		{"link without template", cedarlang.LanguageTemplateLinksFileName, `[{"link_id": "bad", "args": {"?principal": {"type": "User", "id": "amy"}}}]`},
+		{"link with invalid name", cedarlang.LanguageTemplateLinksFileName, `[{"template_id": "t", "link_id": "Bad", "args": {"?principal": {"type": "User", "id": "amy"}}}]`},
+	}
+	for _, test := range tests {
+		multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", test.path, []byte(test.data))
+		require.NoError(t, err, test.name)
+		require.Len(t, multiSecObj.SectionObjects(), 1, test.name)
+		assert.Error(multiSecObj.SectionObjects()[0].Error(), test.name)
+	}
+
+	template, err := parsePolicyList("share.cedar", []byte(`@id("t") permit(principal in ?principal, action, resource);`))
+	require.NoError(t, err, "parsePolicyList should not return an error")
+	_, err = linkTemplate(template[0], &templateLink{TemplateID: "t", LinkID: "l", Args: map[string]templateLinkEntity{
+		"?resource": {Type: "Order", ID: "1"},
+	}})
+	assert.Error(err, "linkTemplate should fail for an unknown slot")
+	_, err = linkTemplate(template[0], &templateLink{TemplateID: "t", LinkID: "l"})
+	assert.Error(err, "linkTemplate should fail for a missing slot")
+}
@@ -0,0 +1,355 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/types"
+	"github.com/cedar-policy/cedar-go/x/exp/ast"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+	cedarlang "github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+)
+
+// lintPolicy is a policy parsed from a source file.
+type lintPolicy struct {
+	source   *languages.LintSource
+	id       string
+	line     int
+	column   int
+	policy   *ast.Policy
+	body     string
+	template bool
+}
+
+// parseLintPolicies parses the policies of a source file, sources which do not parse are reported by the validation.
+func parseLintPolicies(source *languages.LintSource) []*lintPolicy {
+	policyList, err := parsePolicyList(source.Path, source.Content)
+	if err != nil {
+		return nil
+	}
+	policies := make([]*lintPolicy, 0, len(policyList))
+	for _, policy := range policyList {
+		position := policy.Position()
+		policyAST := *policy.AST()
+		policyAST.Annotations = nil
+		slots, _ := templateSlots(policy)
+		policies = append(policies, &lintPolicy{
+			source:   source,
+			id:       string(policy.Annotations()["id"]),
+			line:     position.Line,
+			column:   position.Column,
+			policy:   (*ast.Policy)(policy.AST()),
+			body:     string(cedar.NewPolicyFromAST(&policyAST).MarshalCedar()),
+			template: len(slots) > 0,
+		})
+	}
+	return policies
+}
+
+// newPolicyLintFinding creates a lint finding for a policy.
+func newPolicyLintFinding(rule, severity string, policy *lintPolicy, format string, args ...any) languages.LintFinding {
+	return languages.LintFinding{
+		Rule:      rule,
+		Severity:  severity,
+		Partition: policy.source.Partition,
+		Path:      policy.source.Path,
+		Line:      policy.line,
+		Column:    policy.column,
+		PolicyID:  policy.id,
+		Message:   fmt.Sprintf(format, args...),
+	}
+}
+
+// lintPolicyLabel returns the label used to reference a policy in the findings.
+func lintPolicyLabel(policy *lintPolicy) string {
+	return fmt.Sprintf("%s (%s:%d)", policy.id, policy.source.Path, policy.line)
+}
+
+// entityScopeCovers returns true if every request matching the scope b also matches the scope a.
+func entityScopeCovers(a, b any) bool {
+	switch sa := a.(type) {
+	case ast.ScopeTypeAll:
+		return true
+	case ast.ScopeTypeEq:
+		sb, ok := b.(ast.ScopeTypeEq)
+		return ok && sa.Entity == sb.Entity
+	case ast.ScopeTypeIn:
+		switch sb := b.(type) {
+		case ast.ScopeTypeEq:
+			return sa.Entity == sb.Entity
+		case ast.ScopeTypeIn:
+			return sa.Entity == sb.Entity
+		}
+	case ast.ScopeTypeIs:
+		return scopeType(b) == string(sa.Type)
+	case ast.ScopeTypeIsIn:
+		sb, ok := b.(ast.ScopeTypeIsIn)
+		return ok && sa.Type == sb.Type && sa.Entity == sb.Entity
+	}
+	return false
+}
+
+// actionScopeCovers returns true if every action matching the scope b also matches the scope a.
+func actionScopeCovers(a, b any, schema *policySchema) bool {
+	setA := actionScopeSet(a, schema)
+	if setA == nil {
+		return true
+	}
+	setB := actionScopeSet(b, schema)
+	if setB == nil {
+		return false
+	}
+	for action := range setB {
+		if !setA[action] {
+			return false
+		}
+	}
+	return true
+}
+
+// policyCovers returns true if the policy a applies to every request the policy b applies to.
+func policyCovers(a, b *lintPolicy, schema *policySchema) bool {
+	if len(a.policy.Conditions) > 0 {
+		return false
+	}
+	return entityScopeCovers(a.policy.Principal, b.policy.Principal) &&
+		actionScopeCovers(a.policy.Action, b.policy.Action, schema) &&
+		entityScopeCovers(a.policy.Resource, b.policy.Resource)
+}
+
+// lintDuplicatePolicyIDs reports the policy ids declared more than once.
+func lintDuplicatePolicyIDs(policies []*lintPolicy) []languages.LintFinding {
+	findings := []languages.LintFinding{}
+	declared := map[string]*lintPolicy{}
+	for _, policy := range policies {
+		if policy.id == "" {
+			continue
+		}
+		first, exists := declared[policy.id]
+		if !exists {
+			declared[policy.id] = policy
+			continue
+		}
+		findings = append(findings, newPolicyLintFinding(languages.LintRuleDuplicatePolicyID, languages.LintSeverityError, policy,
+			"policy id %s is already declared in %s:%d of partition %s", policy.id, first.source.Path, first.line, first.source.Partition))
+	}
+	return findings
+}
+
+// lintOverlappingPolicies reports the shadowed permits and the permits made dead by a forbid.
+func lintOverlappingPolicies(policies []*lintPolicy, schemas map[string]*policySchema) []languages.LintFinding {
+	findings := []languages.LintFinding{}
+	for i, permit := range policies {
+		// Templates only grant access once linked, so they are not compared with the other policies.
+		if permit.policy.Effect != ast.EffectPermit || permit.template {
+			continue
+		}
+		schema := schemas[permit.source.Partition]
+		for _, forbid := range policies {
+			if forbid.policy.Effect != ast.EffectForbid || forbid.template || !policyCovers(forbid, permit, schema) {
+				continue
+			}
+			findings = append(findings, newPolicyLintFinding(languages.LintRuleDeadPermit, languages.LintSeverityError, permit,
+				"permit %s never grants access because forbid %s always applies", permit.id, lintPolicyLabel(forbid)))
+			break
+		}
+		for j, other := range policies {
+			if i == j || other.policy.Effect != ast.EffectPermit || other.template {
+				continue
+			}
+			identical := permit.body == other.body
+			if !identical && !policyCovers(other, permit, schema) {
+				continue
+			}
+			// Equivalent permits are reported once, on the latter.
+			if (identical || policyCovers(permit, other, schema)) && j > i {
+				continue
+			}
+			findings = append(findings, newPolicyLintFinding(languages.LintRuleShadowedPermit, languages.LintSeverityWarning, permit,
+				"permit %s is redundant because permit %s already grants the same access", permit.id, lintPolicyLabel(other)))
+			break
+		}
+	}
+	return findings
+}
+
+// lintUnusedSchemaDeclarations reports the entity types and the actions of the schema which are never used by the policies.
+func lintUnusedSchemaDeclarations(source *languages.LintSource, schema *policySchema, policies []*lintPolicy) []languages.LintFinding {
+	usedActions := map[string]bool{}
+	usedTypes := map[string]bool{}
+	for _, policy := range policies {
+		actionSet := actionScopeSet(policy.policy.Action, schema)
+		if actionSet == nil {
+			for uid := range schema.actions {
+				usedActions[uid] = true
+			}
+		}
+		for uid := range actionSet {
+			usedActions[uid] = true
+		}
+		for _, scope := range []any{policy.policy.Principal, policy.policy.Resource} {
+			for _, entityType := range scopeEntityTypes(scope) {
+				usedTypes[entityType] = true
+			}
+		}
+		for _, condition := range policy.policy.Conditions {
+			ast.Inspect(ast.NewNode(condition.Body), func(node ast.IsNode) bool {
+				switch n := node.(type) {
+				case ast.NodeTypeIs:
+					usedTypes[string(n.EntityType)] = true
+				case ast.NodeTypeIsIn:
+					usedTypes[string(n.EntityType)] = true
+				case ast.NodeValue:
+					if uid, ok := n.Value.(types.EntityUID); ok {
+						usedTypes[string(uid.Type)] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	for uid := range usedActions {
+		if action, exists := schema.actions[uid]; exists {
+			for _, typeName := range append(append([]string{}, action.principalTypes...), action.resourceTypes...) {
+				usedTypes[typeName] = true
+			}
+		}
+	}
+	// Types referenced by the used types are used as well.
+	var referencedTypes func(schemaType *schemaTypeDecl, namespace string)
+	referencedTypes = func(schemaType *schemaTypeDecl, namespace string) {
+		if schemaType == nil {
+			return
+		}
+		if schemaType.Type == "Entity" && schemaType.Name != "" {
+			usedTypes[qualifySchemaName(namespace, schemaType.Name)] = true
+		}
+		referencedTypes(schemaType.Element, namespace)
+		for _, attr := range schemaType.Attributes {
+			referencedTypes(&attr, namespace)
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		size := len(usedTypes)
+		for typeName := range usedTypes {
+			entityType, exists := schema.entityTypes[typeName]
+			if !exists {
+				continue
+			}
+			for _, memberOfType := range entityType.memberOfTypes {
+				usedTypes[memberOfType] = true
+			}
+			namespace := ""
+			if idx := strings.LastIndex(typeName, "::"); idx >= 0 {
+				namespace = typeName[:idx]
+			}
+			referencedTypes(entityType.shape, namespace)
+		}
+		changed = len(usedTypes) != size
+	}
+	findings := []languages.LintFinding{}
+	for typeName, entityType := range schema.entityTypes {
+		if usedTypes[typeName] {
+			continue
+		}
+		findings = append(findings, languages.LintFinding{
+			Rule:      languages.LintRuleUnusedEntityType,
+			Severity:  languages.LintSeverityWarning,
+			Partition: source.Partition,
+			Path:      source.Path,
+			Line:      entityType.line,
+			Message:   fmt.Sprintf("entity type %s is declared in the schema but never used by the policies", typeName),
+		})
+	}
+	for uid, action := range schema.actions {
+		if usedActions[uid] {
+			continue
+		}
+		findings = append(findings, languages.LintFinding{
+			Rule:      languages.LintRuleUnusedAction,
+			Severity:  languages.LintSeverityWarning,
+			Partition: source.Partition,
+			Path:      source.Path,
+			Line:      action.line,
+			Message:   fmt.Sprintf("action %s is declared in the schema but never used by the policies", uid),
+		})
+	}
+	return findings
+}
+
+// LintSources runs the static analysis of the policy and schema sources.
+func (abs *LanguageAbstraction) LintSources(mfestLang *azmanifests.Language, sources []languages.LintSource) ([]languages.LintFinding, error) {
+	if mfestLang != nil && mfestLang.Name != cedarlang.LanguageCedar {
+		return nil, errors.New("cedar: unsupported human-readable language")
+	}
+	schemas := map[string]*policySchema{}
+	schemaSources := map[string]*languages.LintSource{}
+	policies := []*lintPolicy{}
+	partitionPolicies := map[string][]*lintPolicy{}
+	for i := range sources {
+		source := &sources[i]
+		if source.Schema {
+			if _, exists := schemas[source.Partition]; exists {
+				continue
+			}
+			schema, err := parsePolicySchema(source.Content)
+			if err != nil {
+				continue
+			}
+			schemas[source.Partition] = schema
+			schemaSources[source.Partition] = source
+			continue
+		}
+		sourcePolicies := parseLintPolicies(source)
+		policies = append(policies, sourcePolicies...)
+		partitionPolicies[source.Partition] = append(partitionPolicies[source.Partition], sourcePolicies...)
+	}
+	findings := lintDuplicatePolicyIDs(policies)
+	findings = append(findings, lintOverlappingPolicies(policies, schemas)...)
+	for _, policy := range policies {
+		if schema, exists := schemas[policy.source.Partition]; exists {
+			for _, typeError := range policyTypeErrors(policy.policy, schema) {
+				findings = append(findings, newPolicyLintFinding(languages.LintRuleTypeMismatch, languages.LintSeverityError, policy, "%s", typeError))
+			}
+		}
+	}
+	for partition, schema := range schemas {
+		findings = append(findings, lintUnusedSchemaDeclarations(schemaSources[partition], schema, partitionPolicies[partition])...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Message < b.Message
+	})
+	return findings, nil
+}
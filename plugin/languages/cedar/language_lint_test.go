@@ -0,0 +1,178 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+)
+
+const lintTestSchema = `{
+  "Shop": {
+    "entityTypes": {
+      "Customer": {
+        "shape": {
+          "type": "Record",
+          "attributes": {
+            "tier": { "type": "String" },
+            "age": { "type": "Long" }
+          }
+        }
+      },
+      "Order": {},
+      "Invoice": {}
+    },
+    "actions": {
+      "view": {
+        "appliesTo": { "principalTypes": ["Customer"], "resourceTypes": ["Order"] }
+      },
+      "pay": {
+        "appliesTo": { "principalTypes": ["Customer"], "resourceTypes": ["Order"] }
+      },
+      "archive": {
+        "appliesTo": { "principalTypes": ["Customer"], "resourceTypes": ["Invoice"] }
+      }
+    }
+  }
+}`
+
+// lintTestFindings returns the findings of the input rule.
+func lintTestFindings(findings []languages.LintFinding, rule string) []languages.LintFinding {
+	selected := []languages.LintFinding{}
+	for _, finding := range findings {
+		if finding.Rule == rule {
+			selected = append(selected, finding)
+		}
+	}
+	return selected
+}
+
+// TestLintSources tests the static analysis of the policies.
+func TestLintSources(t *testing.T) {
+	assert := assert.New(t)
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	mfestLang := &azmanifests.Language{Name: "cedar"}
+
+	orders := `@id("view-orders")
+permit(principal is Shop::Customer, action == Shop::Action::"view", resource is Shop::Order);
+
+@id("view-gold-orders")
+permit(principal is Shop::Customer, action == Shop::Action::"view", resource is Shop::Order)
+when { principal.tier == "gold" };
+
+@id("no-pay")
+forbid(principal, action == Shop::Action::"pay", resource);
+
+@id("pay-orders")
+permit(principal, action == Shop::Action::"pay", resource is Shop::Order);
+
+@id("view-invoices")
+permit(principal, action == Shop::Action::"view", resource is Shop::Invoice);
+
+@id("old-customers")
+permit(principal is Shop::Customer, action == Shop::Action::"view", resource)
+when { principal.age == "old" && principal.nickname == "bob" };
+`
+	others := `@id("view-orders")
+permit(principal, action == Shop::Action::"ship", resource);
+`
+	sources := []languages.LintSource{
+		{Partition: "/", Path: "schema.json", Schema: true, Content: []byte(lintTestSchema)},
+		{Partition: "/", Path: "orders.cedar", Content: []byte(orders)},
+		{Partition: "/", Path: "others.cedar", Content: []byte(others)},
+	}
+	findings, err := langAbs.LintSources(mfestLang, sources)
+	require.NoError(t, err)
+
+	duplicates := lintTestFindings(findings, languages.LintRuleDuplicatePolicyID)
+	require.Len(t, duplicates, 1)
+	assert.Equal("others.cedar", duplicates[0].Path)
+	assert.Equal(1, duplicates[0].Line)
+	assert.Equal(languages.LintSeverityError, duplicates[0].Severity)
+
+	shadowed := lintTestFindings(findings, languages.LintRuleShadowedPermit)
+	require.Len(t, shadowed, 1)
+	assert.Equal("view-gold-orders", shadowed[0].PolicyID)
+	assert.Equal(4, shadowed[0].Line)
+
+	dead := lintTestFindings(findings, languages.LintRuleDeadPermit)
+	require.Len(t, dead, 1)
+	assert.Equal("pay-orders", dead[0].PolicyID)
+	assert.Equal(11, dead[0].Line)
+
+	mismatches := lintTestFindings(findings, languages.LintRuleTypeMismatch)
+	messages := map[string]string{}
+	for _, mismatch := range mismatches {
+		messages[mismatch.Message] = mismatch.PolicyID
+	}
+	assert.Equal("view-invoices", messages["resource type Shop::Invoice is not applicable to the actions of the policy"])
+	assert.Equal("old-customers", messages["attribute principal.age of type Long is compared with a String value"])
+	assert.Equal("old-customers", messages["attribute principal.nickname is not declared in the schema"])
+	assert.Equal("view-orders", messages[`action Shop::Action::ship is not declared in the schema`])
+	assert.Len(mismatches, 4)
+
+	unusedActions := lintTestFindings(findings, languages.LintRuleUnusedAction)
+	require.Len(t, unusedActions, 1)
+	assert.Equal("schema.json", unusedActions[0].Path)
+	assert.Equal(23, unusedActions[0].Line)
+	assert.Contains(unusedActions[0].Message, "Shop::Action::archive")
+
+	assert.Empty(lintTestFindings(findings, languages.LintRuleUnusedEntityType))
+}
+
+// TestLintSourcesUnusedEntityTypes tests the detection of the unused entity types.
+func TestLintSourcesUnusedEntityTypes(t *testing.T) {
+	assert := assert.New(t)
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+
+	sources := []languages.LintSource{
+		{Partition: "/", Path: "schema.json", Schema: true, Content: []byte(lintTestSchema)},
+		{Partition: "/", Path: "orders.cedar", Content: []byte(`@id("view-orders")
+permit(principal, action == Shop::Action::"view", resource);
+`)},
+	}
+	findings, err := langAbs.LintSources(&azmanifests.Language{Name: "cedar"}, sources)
+	require.NoError(t, err)
+	unusedTypes := lintTestFindings(findings, languages.LintRuleUnusedEntityType)
+	require.Len(t, unusedTypes, 1)
+	assert.Contains(unusedTypes[0].Message, "Shop::Invoice")
+	assert.Equal(14, unusedTypes[0].Line)
+	assert.Len(lintTestFindings(findings, languages.LintRuleUnusedAction), 2)
+}
+
+// TestLintSourcesInvalidSources tests that sources which do not parse are ignored.
+func TestLintSourcesInvalidSources(t *testing.T) {
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	sources := []languages.LintSource{
+		{Partition: "/", Path: "schema.json", Schema: true, Content: []byte("{")},
+		{Partition: "/", Path: "orders.cedar", Content: []byte("permit(")},
+	}
+	findings, err := langAbs.LintSources(&azmanifests.Language{Name: "cedar"}, sources)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = langAbs.LintSources(&azmanifests.Language{Name: "rego"}, sources)
+	assert.Error(t, err)
+}
@@ -0,0 +1,330 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/types"
+	"github.com/cedar-policy/cedar-go/x/exp/ast"
+	"github.com/cedar-policy/cedar-go/x/exp/eval"
+
+	"github.com/permguard/permguard/pkg/authz/residuals"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+)
+
+// residualPattern converts the components of a cedar pattern into a residual wildcard pattern.
+// Wildcards are written as *, while the * and \ characters of the literals are escaped with a backslash.
+func residualPattern(pattern types.Pattern) (string, error) {
+	// The components of the pattern are only exposed through its cedar text, where literals are quoted and * are escaped.
+	text := string(pattern.MarshalCedar())
+	text = text[1 : len(text)-1]
+	var sb strings.Builder
+	for len(text) > 0 {
+		switch {
+		case text[0] == '*':
+			sb.WriteByte('*')
+			text = text[1:]
+		case strings.HasPrefix(text, `\*`):
+			sb.WriteString(`\*`)
+			text = text[2:]
+		default:
+			value, _, tail, err := strconv.UnquoteChar(text, '"')
+			if err != nil {
+				return "", fmt.Errorf("cedar: invalid like pattern: %w", err)
+			}
+			if value == '\\' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(value)
+			text = tail
+		}
+	}
+	return sb.String(), nil
+}
+
+// residualComparisons maps the cedar comparison nodes to the residual operators.
+func residualComparison(node ast.IsNode) (string, ast.BinaryNode, bool) {
+	switch n := node.(type) {
+	case ast.NodeTypeEquals:
+		return residuals.OpEq, n.BinaryNode, true
+	case ast.NodeTypeNotEquals:
+		return residuals.OpNe, n.BinaryNode, true
+	case ast.NodeTypeLessThan:
+		return residuals.OpLt, n.BinaryNode, true
+	case ast.NodeTypeLessThanOrEqual:
+		return residuals.OpLe, n.BinaryNode, true
+	case ast.NodeTypeGreaterThan:
+		return residuals.OpGt, n.BinaryNode, true
+	case ast.NodeTypeGreaterThanOrEqual:
+		return residuals.OpGe, n.BinaryNode, true
+	case ast.NodeTypeIn:
+		return residuals.OpIn, n.BinaryNode, true
+	case ast.NodeTypeContains:
+		return residuals.OpContains, n.BinaryNode, true
+	default:
+		return "", ast.BinaryNode{}, false
+	}
+}
+
+// residualConverter converts the partially evaluated cedar nodes into residual nodes.
+type residualConverter struct {
+	resourceType string
+}
+
+// value converts a cedar value into a residual node.
+func (c *residualConverter) value(value types.Value) (*residuals.Node, error) {
+	switch v := value.(type) {
+	case types.Boolean:
+		return residuals.Value(bool(v)), nil
+	case types.Long:
+		return residuals.Value(int64(v)), nil
+	case types.String:
+		return residuals.Value(string(v)), nil
+	case types.EntityUID:
+		return residuals.NewEntity(string(v.Type), string(v.ID)), nil
+	case types.Set:
+		elements := make([]*residuals.Node, 0, v.Len())
+		for element := range v.All() {
+			node, err := c.value(element)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, node)
+		}
+		return residuals.Set(elements...), nil
+	default:
+		return nil, fmt.Errorf("cedar: the %T value is not supported in a residual", value)
+	}
+}
+
+// node converts a partially evaluated cedar node into a residual node.
+func (c *residualConverter) node(node ast.IsNode) (*residuals.Node, error) {
+	if err, ok := eval.ToPartialError(node); ok {
+		return nil, errors.Join(errors.New("cedar: the partial evaluation has failed"), err)
+	}
+	if op, binary, ok := residualComparison(node); ok {
+		left, err := c.node(binary.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.node(binary.Right)
+		if err != nil {
+			return nil, err
+		}
+		return residuals.Binary(op, left, right), nil
+	}
+	switch n := node.(type) {
+	case ast.NodeValue:
+		return c.value(n.Value)
+	case ast.NodeTypeVariable:
+		return residuals.Attr(string(n.Name)), nil
+	case ast.NodeTypeAccess:
+		arg, err := c.node(n.Arg)
+		if err != nil {
+			return nil, err
+		}
+		if arg.Op != residuals.OpAttr {
+			return nil, fmt.Errorf("cedar: the access of the %s attribute is not supported in a residual", n.Value)
+		}
+		return residuals.Attr(append(slices.Clone(arg.Path), string(n.Value))...), nil
+	case ast.NodeTypeHas:
+		arg, err := c.node(n.Arg)
+		if err != nil {
+			return nil, err
+		}
+		if arg.Op != residuals.OpAttr {
+			return nil, fmt.Errorf("cedar: the test of the %s attribute is not supported in a residual", n.Value)
+		}
+		return &residuals.Node{Op: residuals.OpHas, Path: append(slices.Clone(arg.Path), string(n.Value))}, nil
+	case ast.NodeTypeLike:
+		arg, err := c.node(n.Arg)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := residualPattern(n.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &residuals.Node{Op: residuals.OpLike, Args: []*residuals.Node{arg}, Value: pattern}, nil
+	case ast.NodeTypeIsIn:
+		is, err := c.node(n.NodeTypeIs)
+		if err != nil {
+			return nil, err
+		}
+		in, err := c.node(ast.NodeTypeIn{BinaryNode: ast.BinaryNode{Left: n.Left, Right: n.Entity}})
+		if err != nil {
+			return nil, err
+		}
+		return residuals.And(is, in), nil
+	case ast.NodeTypeIs:
+		arg, err := c.node(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		// The entity type tests of the resource are resolved when the type of the resource is known.
+		if c.resourceType != "" && arg.Op == residuals.OpAttr && slices.Equal(arg.Path, []string{"resource"}) {
+			return residuals.Value(string(n.EntityType) == c.resourceType), nil
+		}
+		return &residuals.Node{Op: residuals.OpIs, Args: []*residuals.Node{arg}, Value: string(n.EntityType)}, nil
+	case ast.NodeTypeAnd:
+		left, err := c.node(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.node(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return residuals.And(left, right), nil
+	case ast.NodeTypeOr:
+		left, err := c.node(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.node(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return residuals.Or(left, right), nil
+	case ast.NodeTypeNot:
+		arg, err := c.node(n.Arg)
+		if err != nil {
+			return nil, err
+		}
+		return residuals.Not(arg), nil
+	case ast.NodeTypeIfThenElse:
+		condition, err := c.node(n.If)
+		if err != nil {
+			return nil, err
+		}
+		thenNode, err := c.node(n.Then)
+		if err != nil {
+			return nil, err
+		}
+		elseNode, err := c.node(n.Else)
+		if err != nil {
+			return nil, err
+		}
+		return residuals.Or(residuals.And(condition, thenNode), residuals.And(residuals.Not(condition), elseNode)), nil
+	case ast.NodeTypeSet:
+		elements := make([]*residuals.Node, 0, len(n.Elements))
+		for _, element := range n.Elements {
+			node, err := c.node(element)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, node)
+		}
+		return residuals.Set(elements...), nil
+	default:
+		return nil, fmt.Errorf("cedar: the %T expression is not supported in a residual", node)
+	}
+}
+
+// PartialEvaluation evaluates the authorization with an unknown resource and unknown context keys, returning the residual condition.
+func (abs *LanguageAbstraction) PartialEvaluation(_ *azmanifests.Language, policyStore *authzen.PolicyStore, authzCtx *authzen.AuthorizationModel, unknownContextKeys []string) (*residuals.Result, error) {
+	// Creates a new policy set.
+	ps, err := buildPolicySet(policyStore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract the subject, the action and the context from the authorization context.
+	pmgSubjectKind, subjectID, subjectProperties, err := requestSubject(authzCtx)
+	if err != nil {
+		return nil, err
+	}
+	actionType, actionID, actionProperties, err := requestAction(authzCtx)
+	if err != nil {
+		return nil, err
+	}
+	contextRecord, err := requestContext(authzCtx)
+	if err != nil {
+		return nil, err
+	}
+	if len(unknownContextKeys) > 0 {
+		contextMap := cedar.RecordMap{}
+		for key, value := range contextRecord.All() {
+			contextMap[key] = value
+		}
+		for _, key := range unknownContextKeys {
+			if isValid, err := verifyKey(key); !isValid {
+				return nil, errors.Join(errors.New("cedar: bad request for an invalid context key"), err)
+			}
+			contextMap[cedar.String(key)] = eval.Variable(cedar.String("context." + key))
+		}
+		contextRecord = cedar.NewRecord(contextMap)
+	}
+	entities, err := requestEntities(authzCtx, subjectProperties, actionProperties)
+	if err != nil {
+		return nil, err
+	}
+	principal := cedar.NewEntityUID(cedar.EntityType(pmgSubjectKind), cedar.String(subjectID))
+	if err := expandRoles(policyStore, entities, principal); err != nil {
+		return nil, err
+	}
+	var resourceType string
+	if resource := authzCtx.Resource(); resource != nil {
+		resourceType = strings.TrimSpace(resource.Type())
+	}
+
+	// Partially evaluate the policies with the resource as a variable.
+	env := eval.Env{
+		Entities:  entities,
+		Principal: principal,
+		Action:    cedar.NewEntityUID(cedar.EntityType(actionType), cedar.String(actionID)),
+		Resource:  eval.Variable("resource"),
+		Context:   contextRecord,
+	}
+	converter := &residualConverter{resourceType: resourceType}
+	policyMap := ps.Map()
+	policyIDs := make([]string, 0, len(policyMap))
+	for policyID := range policyMap {
+		policyIDs = append(policyIDs, string(policyID))
+	}
+	slices.Sort(policyIDs)
+	permits, forbids, policies := []*residuals.Node{}, []*residuals.Node{}, []string{}
+	for _, policyID := range policyIDs {
+		policy := policyMap[cedar.PolicyID(policyID)]
+		partialPolicy, keep := eval.PartialPolicy(env, (*ast.Policy)(policy.AST()))
+		if !keep {
+			continue
+		}
+		residual, err := converter.node(eval.PolicyToNode(partialPolicy).AsIsNode())
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("cedar: policy %s cannot be partially evaluated", policyID), err)
+		}
+		if residuals.IsFalse(residual) {
+			continue
+		}
+		policies = append(policies, policyID)
+		if partialPolicy.Effect == ast.EffectPermit {
+			permits = append(permits, residual)
+		} else {
+			forbids = append(forbids, residual)
+		}
+	}
+	residual := residuals.And(residuals.Or(permits...), residuals.Not(residuals.Or(forbids...)))
+	return residuals.NewResult(residual, policies), nil
+}
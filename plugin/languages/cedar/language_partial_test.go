@@ -0,0 +1,191 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"testing"
+
+	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/authz/residuals"
+	cedarlang "github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+// partialPolicyStore creates a policy store with the given policies.
+func partialPolicyStore(t *testing.T, langAbs *LanguageAbstraction, policies string) *authzen.PolicyStore {
+	t.Helper()
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", "orders.cedar", []byte(policies))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err, "NewObjectManager should not return an error")
+	policyStore := &authzen.PolicyStore{}
+	for _, secObj := range multiSecObj.SectionObjects() {
+		require.NoError(t, secObj.Error(), "section object should not have errors")
+		objInfo, err := objMng.ObjectInfo(secObj.Object())
+		require.NoError(t, err, "ObjectInfo should not return an error")
+		policyStore.AddPolicy(secObj.Object().OID(), objInfo)
+	}
+	return policyStore
+}
+
+// TestPartialEvaluation tests the residual conditions of the partial evaluation.
+func TestPartialEvaluation(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+
+	policies := `@id("view-own-orders")
+permit(
+  principal,
+  action == MagicFarmacia::Platform::Action::"view",
+  resource is MagicFarmacia::Platform::Order
+) when { resource.owner == principal.name && context.channel like "web*" };
+
+@id("view-shared-orders")
+permit(
+  principal == Permguard::Identity::User::"amy",
+  action == MagicFarmacia::Platform::Action::"view",
+  resource in MagicFarmacia::Platform::Branch::"milan"
+);
+
+@id("forbid-archived-orders")
+forbid(
+  principal,
+  action,
+  resource
+) when { resource has archived && resource.archived };
+
+@id("delete-orders")
+permit(
+  principal,
+  action == MagicFarmacia::Platform::Action::"delete",
+  resource
+);`
+	policyStore := partialPolicyStore(t, langAbs, policies)
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	authzCtx := &authzen.AuthorizationModel{}
+	require.NoError(t, authzCtx.SetSubject("user", "amy", "", map[string]any{"name": "amy"}))
+	require.NoError(t, authzCtx.SetResource("MagicFarmacia::Platform::Order", "", nil))
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::view", nil))
+	require.NoError(t, authzCtx.SetContext(map[string]any{}))
+	result, err := langAbs.PartialEvaluation(mfestLang, policyStore, authzCtx, []string{"channel"})
+	require.NoError(t, err, "PartialEvaluation should not return an error")
+	assert.Equal(residuals.DecisionConditional, result.Decision, "decision mismatch")
+	assert.Equal([]string{"forbid-archived-orders", "view-own-orders", "view-shared-orders"}, result.Policies, "policies mismatch")
+
+	expected := residuals.And(
+		residuals.Or(
+			residuals.And(
+				residuals.Binary(residuals.OpEq, residuals.Attr("resource", "owner"), residuals.Value("amy")),
+				&residuals.Node{Op: residuals.OpLike, Args: []*residuals.Node{residuals.Attr("context", "channel")}, Value: "web*"},
+			),
+			residuals.Binary(residuals.OpIn, residuals.Attr("resource"), residuals.NewEntity("MagicFarmacia::Platform::Branch", "milan")),
+		),
+		residuals.Not(residuals.And(
+			&residuals.Node{Op: residuals.OpHas, Path: []string{"resource", "archived"}},
+			residuals.Attr("resource", "archived"),
+		)),
+	)
+	assert.Equal(expected, result.Residual, "residual mismatch")
+
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::delete", nil))
+	result, err = langAbs.PartialEvaluation(mfestLang, policyStore, authzCtx, nil)
+	require.NoError(t, err, "PartialEvaluation should not return an error")
+	assert.Equal(residuals.DecisionConditional, result.Decision, "decision mismatch")
+	assert.Equal([]string{"delete-orders", "forbid-archived-orders"}, result.Policies, "policies mismatch")
+
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::update", nil))
+	result, err = langAbs.PartialEvaluation(mfestLang, policyStore, authzCtx, nil)
+	require.NoError(t, err, "PartialEvaluation should not return an error")
+	assert.Equal(residuals.DecisionDeny, result.Decision, "decision mismatch")
+	assert.Nil(result.Residual, "residual should be empty")
+}
+
+// TestPartialEvaluationAllow tests the partial evaluation when the policies do not depend on the resource.
+func TestPartialEvaluationAllow(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+	policyStore := partialPolicyStore(t, langAbs, `@id("view-all")
+permit(principal, action == MagicFarmacia::Platform::Action::"view", resource);`)
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	authzCtx := &authzen.AuthorizationModel{}
+	require.NoError(t, authzCtx.SetSubject("user", "amy", "", nil))
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::view", nil))
+	result, err := langAbs.PartialEvaluation(mfestLang, policyStore, authzCtx, nil)
+	require.NoError(t, err, "PartialEvaluation should not return an error")
+	assert.Equal(residuals.DecisionAllow, result.Decision, "decision mismatch")
+	assert.Equal([]string{"view-all"}, result.Policies, "policies mismatch")
+
+	_, err = langAbs.PartialEvaluation(mfestLang, policyStore, authzCtx, []string{"permguard"})
+	assert.Error(err, "reserved context keys should be rejected")
+}
+
+// TestResidualPattern tests the conversion of the cedar patterns into residual wildcard patterns.
+func TestResidualPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  types.Pattern
+		expected string
+	}{
+		{"wildcard", types.NewPattern(types.Wildcard{}), `*`},
+		{"prefix", types.NewPattern("web", types.Wildcard{}), `web*`},
+		{"quote", types.NewPattern(`a"b`, types.Wildcard{}), `a"b*`},
+		{"literal star", types.NewPattern("a*b", types.Wildcard{}), `a\*b*`},
+		{"literal backslash", types.NewPattern(`c:\dir\`, types.Wildcard{}), `c:\\dir\\*`},
+		{"unicode", types.NewPattern("città\n", types.Wildcard{}, "é"), "città\n*é"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pattern, err := residualPattern(test.pattern)
+			require.NoError(t, err, "residualPattern should not return an error")
+			assert.Equal(t, test.expected, pattern, "pattern mismatch")
+		})
+	}
+}
+
+// TestResidualLikeEscapes tests the residual and the SQL translation of parsed like patterns with escaped characters.
+func TestResidualLikeEscapes(t *testing.T) {
+	assert := assert.New(t)
+
+	var policy cedar.Policy
+	require.NoError(t, policy.UnmarshalCedar([]byte(`permit(principal, action, resource) when { resource.name like "a\"b*" || resource.path like "c:\\dir\\*\*" };`)), "UnmarshalCedar should not return an error")
+	converter := &residualConverter{}
+	residual, err := converter.node(policy.AST().Conditions[0].Body)
+	require.NoError(t, err, "node should not return an error")
+	expected := residuals.Or(
+		&residuals.Node{Op: residuals.OpLike, Args: []*residuals.Node{residuals.Attr("resource", "name")}, Value: `a"b*`},
+		&residuals.Node{Op: residuals.OpLike, Args: []*residuals.Node{residuals.Attr("resource", "path")}, Value: `c:\\dir\\*\*`},
+	)
+	assert.Equal(expected, residual, "residual mismatch")
+
+	where, args, err := residuals.ToSQL(residual, &residuals.SQLOptions{Columns: map[string]string{"resource.name": "name", "resource.path": "path"}})
+	require.NoError(t, err, "ToSQL should not return an error")
+	assert.Equal(`(name LIKE ? ESCAPE '\' OR path LIKE ? ESCAPE '\')`, where, "where mismatch")
+	assert.Equal([]any{`a"b%`, `c:\\dir\\%*`}, args, "args mismatch")
+}
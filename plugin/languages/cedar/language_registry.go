@@ -36,12 +36,18 @@ func NewCedarLanguageDescriptor() *langregistry.LanguageDescriptor {
 			cedarlang.LanguageSyntaxVersionID: cedarlang.LanguageSyntaxVersion,
 		},
 		TypeNames: map[uint32]string{
-			cedarlang.LanguageSchemaTypeID: cedarlang.LanguageSchemaType,
-			cedarlang.LanguagePolicyTypeID: cedarlang.LanguagePolicyType,
+			cedarlang.LanguageSchemaTypeID:       cedarlang.LanguageSchemaType,
+			cedarlang.LanguagePolicyTypeID:       cedarlang.LanguagePolicyType,
+			cedarlang.LanguageTemplateTypeID:     cedarlang.LanguageTemplateType,
+			cedarlang.LanguageTemplateLinkTypeID: cedarlang.LanguageTemplateLinkType,
+			cedarlang.LanguageRoleTypeID:         cedarlang.LanguageRoleType,
 		},
 		CodeTypeNames: map[uint32]string{
-			cedarlang.LanguageSchemaTypeID: cedarlang.LanguageSchemaType,
-			cedarlang.LanguagePolicyTypeID: cedarlang.LanguagePolicyType,
+			cedarlang.LanguageSchemaTypeID:       cedarlang.LanguageSchemaType,
+			cedarlang.LanguagePolicyTypeID:       cedarlang.LanguagePolicyType,
+			cedarlang.LanguageTemplateTypeID:     cedarlang.LanguageTemplateType,
+			cedarlang.LanguageTemplateLinkTypeID: cedarlang.LanguageTemplateLinkType,
+			cedarlang.LanguageRoleTypeID:         cedarlang.LanguageRoleType,
 		},
 		PluginMode: langregistry.PluginModeLocal,
 	}
@@ -0,0 +1,258 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/types"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+const (
+	// roleKindRole is the kind of the role definitions.
+	roleKindRole = "role"
+	// roleKindGroup is the kind of the group definitions.
+	roleKindGroup = "group"
+	// roleEntityType is the entity type of the roles.
+	roleEntityType = permguardNamespace + "Identity::Role"
+	// groupEntityType is the entity type of the groups.
+	groupEntityType = permguardNamespace + "Identity::Group"
+)
+
+// roleReference references a role or a group.
+type roleReference struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+// roleMember is a subject member of a role or a group.
+type roleMember struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// roleDefinition defines a role or a group, its parents form the hierarchy and its members are the subjects inheriting it.
+type roleDefinition struct {
+	Kind    string          `json:"kind"`
+	ID      string          `json:"id"`
+	Parents []roleReference `json:"parents,omitempty"`
+	Members []roleMember    `json:"members,omitempty"`
+}
+
+// roleEntityUID returns the entity of a role or a group.
+func roleEntityUID(kind, id string) (types.EntityUID, error) {
+	switch strings.ToLower(kind) {
+	case roleKindRole:
+		return cedar.NewEntityUID(cedar.EntityType(roleEntityType), cedar.String(id)), nil
+	case roleKindGroup:
+		return cedar.NewEntityUID(cedar.EntityType(groupEntityType), cedar.String(id)), nil
+	default:
+		return types.EntityUID{}, fmt.Errorf("cedar: invalid role kind %s, it must be either %s or %s", kind, roleKindRole, roleKindGroup)
+	}
+}
+
+// parseRoleDefinitions parses the role and group definitions.
+func parseRoleDefinitions(data []byte) ([]roleDefinition, error) {
+	var roles []roleDefinition
+	if err := json.Unmarshal(data, &roles); err != nil {
+		return nil, errors.Join(errors.New("cedar: invalid roles syntax"), err)
+	}
+	return roles, nil
+}
+
+// validateRoleDefinition validates the kind, the parents and the members of a role or group definition.
+func validateRoleDefinition(role *roleDefinition) error {
+	if _, err := roleEntityUID(role.Kind, role.ID); err != nil {
+		return err
+	}
+	for _, parent := range role.Parents {
+		if len(strings.TrimSpace(parent.ID)) == 0 {
+			return fmt.Errorf("cedar: missing the id of a parent of %s %s", role.Kind, role.ID)
+		}
+		if _, err := roleEntityUID(parent.Kind, parent.ID); err != nil {
+			return err
+		}
+		if strings.EqualFold(parent.Kind, role.Kind) && parent.ID == role.ID {
+			return fmt.Errorf("cedar: %s %s cannot be a parent of itself", role.Kind, role.ID)
+		}
+	}
+	for _, member := range role.Members {
+		if len(strings.TrimSpace(member.ID)) == 0 {
+			return fmt.Errorf("cedar: missing the id of a member of %s %s", role.Kind, role.ID)
+		}
+		if _, err := createPermguardSubjectKind(member.Type); err != nil {
+			return errors.Join(fmt.Errorf("cedar: invalid member %s of %s %s", member.ID, role.Kind, role.ID), err)
+		}
+	}
+	return nil
+}
+
+// roleHierarchyCycle returns the index of a role or group of the definitions being its own ancestor.
+func roleHierarchyCycle(roles []roleDefinition) (int, bool) {
+	parents := map[types.EntityUID][]types.EntityUID{}
+	indexes := map[types.EntityUID]int{}
+	for i, role := range roles {
+		uid, err := roleEntityUID(role.Kind, role.ID)
+		if err != nil {
+			continue
+		}
+		if _, exists := indexes[uid]; !exists {
+			indexes[uid] = i
+		}
+		for _, parent := range role.Parents {
+			if parentUID, err := roleEntityUID(parent.Kind, parent.ID); err == nil {
+				parents[uid] = append(parents[uid], parentUID)
+			}
+		}
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[types.EntityUID]int{}
+	// visit returns the role or group closing a cycle, which is always one of the definitions as it has parents.
+	var visit func(uid types.EntityUID) (types.EntityUID, bool)
+	visit = func(uid types.EntityUID) (types.EntityUID, bool) {
+		switch state[uid] {
+		case visiting:
+			return uid, true
+		case visited:
+			return types.EntityUID{}, false
+		}
+		state[uid] = visiting
+		for _, parent := range parents[uid] {
+			if cycleUID, exists := visit(parent); exists {
+				return cycleUID, true
+			}
+		}
+		state[uid] = visited
+		return types.EntityUID{}, false
+	}
+	for _, role := range roles {
+		uid, err := roleEntityUID(role.Kind, role.ID)
+		if err != nil {
+			continue
+		}
+		if cycleUID, exists := visit(uid); exists {
+			return indexes[cycleUID], true
+		}
+	}
+	return -1, false
+}
+
+// roleRelationErrors validates that no role or group is its own ancestor in the hierarchy defined across the role files of all the partitions.
+func roleRelationErrors(sources []languages.LintSource) []languages.SourceRelationError {
+	roles := []roleDefinition{}
+	roleSources := []*languages.LintSource{}
+	for i := range sources {
+		source := &sources[i]
+		if source.Schema || !isRolesFile(source.Path) {
+			continue
+		}
+		sourceRoles, err := parseRoleDefinitions(source.Content)
+		if err != nil {
+			continue
+		}
+		if _, exists := roleHierarchyCycle(sourceRoles); exists {
+			// Cycles within a file are already reported by the blobification of the file.
+			continue
+		}
+		for _, role := range sourceRoles {
+			roles = append(roles, role)
+			roleSources = append(roleSources, source)
+		}
+	}
+	index, exists := roleHierarchyCycle(roles)
+	if !exists {
+		return nil
+	}
+	role := roles[index]
+	return []languages.SourceRelationError{
+		{
+			Partition:  roleSources[index].Partition,
+			Path:       roleSources[index].Path,
+			ObjectName: role.ID,
+			Message:    fmt.Sprintf("cedar: %s %s is its own ancestor in the role hierarchy", role.Kind, role.ID),
+		},
+	}
+}
+
+// expandRoles adds the roles and the groups of the policy store to the entities, and the ones the principal is a member of to its parents.
+func expandRoles(policyStore *authzen.PolicyStore, entities cedar.EntityMap, principal types.EntityUID) error {
+	roleItems := policyStore.Roles()
+	if len(roleItems) == 0 {
+		return nil
+	}
+	memberships := []types.EntityUID{}
+	for _, item := range roleItems {
+		objInfo := item.ObjectInfo()
+		roleBytes, ok := objInfo.Instance().([]byte)
+		if !ok {
+			return errors.New("cedar: role object instance is not a byte slice")
+		}
+		var role roleDefinition
+		if err := json.Unmarshal(roleBytes, &role); err != nil {
+			return errors.Join(errors.New("cedar: role could not be unmarshalled"), err)
+		}
+		uid, err := roleEntityUID(role.Kind, role.ID)
+		if err != nil {
+			return errors.Join(fmt.Errorf("cedar: invalid role %s", objInfo.Header().MetadataString(objects.MetaKeyCodeID)), err)
+		}
+		parents := make([]types.EntityUID, 0, len(role.Parents))
+		for _, parent := range role.Parents {
+			parentUID, err := roleEntityUID(parent.Kind, parent.ID)
+			if err != nil {
+				return errors.Join(fmt.Errorf("cedar: invalid parent of %s %s", role.Kind, role.ID), err)
+			}
+			parents = append(parents, parentUID)
+		}
+		// The role is merged into the entity provided by the caller, keeping its attributes and its parents.
+		roleEntity, exists := entities[uid]
+		if !exists {
+			roleEntity = cedar.Entity{UID: uid}
+		}
+		roleEntity.Parents = cedar.NewEntityUIDSet(append(roleEntity.Parents.Slice(), parents...)...)
+		entities[uid] = roleEntity
+		for _, member := range role.Members {
+			memberKind, err := createPermguardSubjectKind(member.Type)
+			if err != nil {
+				return errors.Join(fmt.Errorf("cedar: invalid member of %s %s", role.Kind, role.ID), err)
+			}
+			if cedar.NewEntityUID(cedar.EntityType(memberKind), cedar.String(member.ID)) == principal {
+				memberships = append(memberships, uid)
+			}
+		}
+	}
+	if len(memberships) == 0 {
+		return nil
+	}
+	principalEntity, exists := entities[principal]
+	if !exists {
+		principalEntity = cedar.Entity{UID: principal}
+	}
+	principalEntity.Parents = cedar.NewEntityUIDSet(append(principalEntity.Parents.Slice(), memberships...)...)
+	entities[principal] = principalEntity
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"testing"
+
+	"github.com/cedar-policy/cedar-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+	cedarlang "github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+// TestAuthorizationCheckRoles tests the expansion of the roles and of the groups into the parents of the subject.
+func TestAuthorizationCheckRoles(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+
+	policies := `@id("view-orders")
+permit(
+  principal in Permguard::Identity::Role::"viewer",
+  action == MagicFarmacia::Platform::Action::"view",
+  resource
+);`
+	roles := `[
+  {"kind": "role", "id": "viewer"},
+  {"kind": "role", "id": "pharmacist", "parents": [{"kind": "role", "id": "viewer"}]},
+  {"kind": "group", "id": "milan-staff", "parents": [{"kind": "role", "id": "pharmacist"}], "members": [{"type": "user", "id": "amy"}]},
+  {"kind": "role", "id": "auditor", "members": [{"type": "workload", "id": "reports"}, {"type": "user", "id": "bob"}]}
+]`
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err, "NewObjectManager should not return an error")
+	policyStore := &authzen.PolicyStore{}
+
+	multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", "orders.cedar", []byte(policies))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+	for _, secObj := range multiSecObj.SectionObjects() {
+		require.NoError(t, secObj.Error(), "policy section should not have errors")
+		objInfo, err := objMng.ObjectInfo(secObj.Object())
+		require.NoError(t, err, "ObjectInfo should not return an error")
+		policyStore.AddPolicy(secObj.Object().OID(), objInfo)
+	}
+
+	multiSecObj, err = langAbs.CreatePolicyBlobObjects(mfestLang, "/", cedarlang.LanguageRolesFileName, []byte(roles))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+	require.Len(t, multiSecObj.SectionObjects(), 4, "role sections mismatch")
+	for _, secObj := range multiSecObj.SectionObjects() {
+		require.NoError(t, secObj.Error(), "role section should not have errors")
+		assert.Equal(cedarlang.LanguageRoleTypeID, secObj.MetadataUint32(objects.MetaKeyLanguageTypeID), "role language type mismatch")
+		objInfo, err := objMng.ObjectInfo(secObj.Object())
+		require.NoError(t, err, "ObjectInfo should not return an error")
+		policyStore.AddRole(secObj.Object().OID(), objInfo)
+	}
+	assert.Equal("milan-staff", multiSecObj.SectionObjects()[2].ObjectName(), "role name mismatch")
+
+	authzCtx := &authzen.AuthorizationModel{}
+	require.NoError(t, authzCtx.SetSubject("user", "amy", "", nil))
+	require.NoError(t, authzCtx.SetResource("MagicFarmacia::Platform::Order", "order-1", nil))
+	require.NoError(t, authzCtx.SetAction("MagicFarmacia::Platform::Action::view", nil))
+	decision, err := langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.True(decision.Decision(), "decision should be allow through the group and the role hierarchy")
+
+	require.NoError(t, authzCtx.SetSubject("user", "bob", "", nil))
+	decision, err = langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.False(decision.Decision(), "decision should be deny for a role outside the hierarchy")
+
+	require.NoError(t, authzCtx.SetSubject("workload", "amy", "", nil))
+	decision, err = langAbs.AuthorizationCheck(mfestLang, "", policyStore, authzCtx)
+	require.NoError(t, err, "AuthorizationCheck should not return an error")
+	assert.False(decision.Decision(), "decision should be deny for a member of another subject type")
+
+	content, fileName, err := langAbs.CreateRolesContentBytes(mfestLang, [][]byte{[]byte(`{"kind":"role","id":"viewer"}`)})
+	require.NoError(t, err, "CreateRolesContentBytes should not return an error")
+	assert.Equal(cedarlang.LanguageRolesFileName, fileName, "roles file name mismatch")
+	assert.JSONEq(`[{"kind": "role", "id": "viewer"}]`, string(content), "roles content mismatch")
+}
+
+// TestCreatePolicyBlobObjectsInvalidRoles tests the errors of the role and group definitions.
+func TestCreatePolicyBlobObjectsInvalidRoles(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid syntax", `{"kind": "role"}`},
+		{"invalid kind", `[{"kind": "team", "id": "ops"}]`},
+		{"invalid name", `[{"kind": "role", "id": "Ops"}]`},
+		{"invalid parent kind", `[{"kind": "role", "id": "ops", "parents": [{"kind": "team", "id": "all"}]}]`},
+		{"parent of itself", `[{"kind": "role", "id": "ops", "parents": [{"kind": "role", "id": "ops"}]}]`},
+		{"invalid member type", `[{"kind": "group", "id": "ops", "members": [{"type": "robot", "id": "r2"}]}]`},
+		{"missing member id", `[{"kind": "group", "id": "ops", "members": [{"type": "user"}]}]`},
+		{"cycle", `[{"kind": "role", "id": "a", "parents": [{"kind": "group", "id": "b"}]}, {"kind": "group", "id": "b", "parents": [{"kind": "role", "id": "a"}]}]`},
+	}
+	for _, test := range tests {
+		multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", cedarlang.LanguageRolesFileName, []byte(test.data))
+		require.NoError(t, err, test.name)
+		require.Len(t, multiSecObj.SectionObjects(), 1, test.name)
+		assert.Error(multiSecObj.SectionObjects()[0].Error(), test.name)
+	}
+}
+
+// TestExpandRolesMergeEntities tests that the roles are merged into the entities provided by the caller.
+func TestExpandRolesMergeEntities(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err, "NewObjectManager should not return an error")
+	policyStore := &authzen.PolicyStore{}
+
+	multiSecObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", cedarlang.LanguageRolesFileName, []byte(`[
+  {"kind": "role", "id": "viewer", "parents": [{"kind": "role", "id": "reader"}]},
+  {"kind": "role", "id": "reader"}
+]`))
+	require.NoError(t, err, "CreatePolicyBlobObjects should not return an error")
+	for _, secObj := range multiSecObj.SectionObjects() {
+		require.NoError(t, secObj.Error(), "role section should not have errors")
+		objInfo, err := objMng.ObjectInfo(secObj.Object())
+		require.NoError(t, err, "ObjectInfo should not return an error")
+		policyStore.AddRole(secObj.Object().OID(), objInfo)
+	}
+
+	viewer := cedar.NewEntityUID(cedar.EntityType(roleEntityType), cedar.String("viewer"))
+	reader := cedar.NewEntityUID(cedar.EntityType(roleEntityType), cedar.String("reader"))
+	ops := cedar.NewEntityUID(cedar.EntityType(groupEntityType), cedar.String("ops"))
+	attributes := cedar.NewRecord(cedar.RecordMap{"level": cedar.Long(2)})
+	entities := cedar.EntityMap{
+		viewer: {UID: viewer, Parents: cedar.NewEntityUIDSet(ops), Attributes: attributes},
+	}
+	principal := cedar.NewEntityUID(cedar.EntityType(permguardNamespace+"Identity::User"), cedar.String("amy"))
+	require.NoError(t, expandRoles(policyStore, entities, principal), "expandRoles should not return an error")
+
+	assert.True(entities[viewer].Attributes.Equal(attributes), "attributes of the caller should be kept")
+	assert.ElementsMatch([]cedar.EntityUID{ops, reader}, entities[viewer].Parents.Slice(), "parents should be the union of the caller and of the role ones")
+	assert.Equal(0, entities[reader].Parents.Len(), "role without parents should be added")
+}
+
+// TestValidateSourceRelationsRoles tests that the cycles of the role hierarchy are detected across the partitions.
+func TestValidateSourceRelationsRoles(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	sources := []languages.LintSource{
+		{Partition: "/auditors", Path: "auditors/" + cedarlang.LanguageRolesFileName, Content: []byte(`[{"kind": "role", "id": "auditor", "parents": [{"kind": "role", "id": "viewer"}]}]`)},
+		{Partition: "/", Path: cedarlang.LanguageRolesFileName, Content: []byte(`[{"kind": "role", "id": "viewer", "parents": [{"kind": "group", "id": "staff"}]}]`)},
+		{Partition: "/staff", Path: "staff/" + cedarlang.LanguageRolesFileName, Content: []byte(`[{"kind": "group", "id": "staff", "parents": [{"kind": "role", "id": "viewer"}]}]`)},
+	}
+	relationErrors, err := langAbs.ValidateSourceRelations(mfestLang, sources[:2])
+	require.NoError(t, err, "ValidateSourceRelations should not return an error")
+	assert.Empty(relationErrors, "hierarchy without cycles should be valid")
+
+	relationErrors, err = langAbs.ValidateSourceRelations(mfestLang, sources)
+	require.NoError(t, err, "ValidateSourceRelations should not return an error")
+	require.Len(t, relationErrors, 1, "cycle across the partitions should be reported")
+	assert.Equal("/", relationErrors[0].Partition, "cycle should be reported on a role of the cycle")
+	assert.Equal("viewer", relationErrors[0].ObjectName, "cycle should be reported on a role of the cycle")
+	assert.Equal("cedar: role viewer is its own ancestor in the role hierarchy", relationErrors[0].Message, "cycle message mismatch")
+
+	selfCycle := languages.LintSource{Partition: "/", Path: "self/" + cedarlang.LanguageRolesFileName, Content: []byte(`[{"kind": "role", "id": "a", "parents": [{"kind": "role", "id": "b"}]}, {"kind": "role", "id": "b", "parents": [{"kind": "role", "id": "a"}]}]`)}
+	relationErrors, err = langAbs.ValidateSourceRelations(mfestLang, append(sources[:2:2], selfCycle))
+	require.NoError(t, err, "ValidateSourceRelations should not return an error")
+	assert.Empty(relationErrors, "cycles within a file should be reported by the blobification")
+}
@@ -0,0 +1,649 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cedar-policy/cedar-go/types"
+	"github.com/cedar-policy/cedar-go/x/exp/ast"
+)
+
+// permguardNamespace is the namespace of the entity types provided by permguard.
+const permguardNamespace = "Permguard::"
+
+// schemaTypeDecl is the subset of a cedar JSON schema type used by the schema validation.
+type schemaTypeDecl struct {
+	Type                 string                    `json:"type"`
+	Name                 string                    `json:"name"`
+	Element              *schemaTypeDecl           `json:"element"`
+	Required             *bool                     `json:"required"`
+	Attributes           map[string]schemaTypeDecl `json:"attributes"`
+	AdditionalAttributes bool                      `json:"additionalAttributes"`
+}
+
+// schemaEntityTypeDecl is the subset of a cedar JSON schema entity type used by the schema validation.
+type schemaEntityTypeDecl struct {
+	MemberOfTypes []string        `json:"memberOfTypes"`
+	Shape         *schemaTypeDecl `json:"shape"`
+}
+
+// schemaAppliesToDecl is the subset of a cedar JSON schema action applies to used by the schema validation.
+type schemaAppliesToDecl struct {
+	PrincipalTypes []string        `json:"principalTypes"`
+	ResourceTypes  []string        `json:"resourceTypes"`
+	Context        *schemaTypeDecl `json:"context"`
+}
+
+// schemaActionRefDecl is a reference to a cedar JSON schema action.
+type schemaActionRefDecl struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// schemaActionDecl is the subset of a cedar JSON schema action used by the schema validation.
+type schemaActionDecl struct {
+	AppliesTo *schemaAppliesToDecl  `json:"appliesTo"`
+	MemberOf  []schemaActionRefDecl `json:"memberOf"`
+}
+
+// schemaNamespaceDecl is the subset of a cedar JSON schema namespace used by the schema validation.
+type schemaNamespaceDecl struct {
+	EntityTypes map[string]schemaEntityTypeDecl `json:"entityTypes"`
+	Actions     map[string]schemaActionDecl     `json:"actions"`
+}
+
+// schemaEntityType is an entity type declared in the schema.
+type schemaEntityType struct {
+	name          string
+	namespace     string
+	line          int
+	memberOfTypes []string
+	shape         *schemaTypeDecl
+}
+
+// schemaAction is an action declared in the schema.
+type schemaAction struct {
+	uid            string
+	namespace      string
+	line           int
+	appliesTo      bool
+	principalTypes []string
+	resourceTypes  []string
+	context        *schemaTypeDecl
+	memberOf       []string
+}
+
+// policySchema is the schema of a partition.
+type policySchema struct {
+	entityTypes map[string]*schemaEntityType
+	actions     map[string]*schemaAction
+}
+
+// qualifySchemaName qualifies a schema name with its namespace.
+func qualifySchemaName(namespace, name string) string {
+	if namespace == "" || strings.Contains(name, "::") {
+		return name
+	}
+	return namespace + "::" + name
+}
+
+// schemaKeyLine returns the line of the last key of the input sequence of JSON keys, zero if not found.
+func schemaKeyLine(content []byte, keys ...string) int {
+	offset, idx := 0, -1
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return 0
+		}
+		found := bytes.Index(content[offset:], keyJSON)
+		if found < 0 {
+			return 0
+		}
+		idx = offset + found
+		offset = idx + len(keyJSON)
+	}
+	if idx < 0 {
+		return 0
+	}
+	return bytes.Count(content[:idx], []byte("\n")) + 1
+}
+
+// parsePolicySchema parses the schemas of the input contents merging their declarations.
+func parsePolicySchema(contents ...[]byte) (*policySchema, error) {
+	schema := &policySchema{
+		entityTypes: map[string]*schemaEntityType{},
+		actions:     map[string]*schemaAction{},
+	}
+	for _, content := range contents {
+		if err := schema.addContent(content); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// addContent adds the declarations of a cedar JSON schema.
+func (schema *policySchema) addContent(content []byte) error {
+	var namespaces map[string]schemaNamespaceDecl
+	if err := json.Unmarshal(content, &namespaces); err != nil {
+		return err
+	}
+	for namespace, nsSchema := range namespaces {
+		for name, entityType := range nsSchema.EntityTypes {
+			qualifiedName := qualifySchemaName(namespace, name)
+			memberOfTypes := make([]string, 0, len(entityType.MemberOfTypes))
+			for _, memberOfType := range entityType.MemberOfTypes {
+				memberOfTypes = append(memberOfTypes, qualifySchemaName(namespace, memberOfType))
+			}
+			schema.entityTypes[qualifiedName] = &schemaEntityType{
+				name:          qualifiedName,
+				namespace:     namespace,
+				line:          schemaKeyLine(content, namespace, "entityTypes", name),
+				memberOfTypes: memberOfTypes,
+				shape:         entityType.Shape,
+			}
+		}
+		actionType := qualifySchemaName(namespace, "Action")
+		for name, action := range nsSchema.Actions {
+			uid := formatActionUID(types.NewEntityUID(types.EntityType(actionType), types.String(name)))
+			schemaAct := &schemaAction{
+				uid:       uid,
+				namespace: namespace,
+				line:      schemaKeyLine(content, namespace, "actions", name),
+			}
+			if action.AppliesTo != nil {
+				schemaAct.appliesTo = true
+				for _, principalType := range action.AppliesTo.PrincipalTypes {
+					schemaAct.principalTypes = append(schemaAct.principalTypes, qualifySchemaName(namespace, principalType))
+				}
+				for _, resourceType := range action.AppliesTo.ResourceTypes {
+					schemaAct.resourceTypes = append(schemaAct.resourceTypes, qualifySchemaName(namespace, resourceType))
+				}
+				schemaAct.context = action.AppliesTo.Context
+			}
+			for _, memberOf := range action.MemberOf {
+				memberOfType := actionType
+				if memberOf.Type != "" {
+					memberOfType = qualifySchemaName(namespace, memberOf.Type)
+				}
+				schemaAct.memberOf = append(schemaAct.memberOf, formatActionUID(types.NewEntityUID(types.EntityType(memberOfType), types.String(memberOf.ID))))
+			}
+			schema.actions[uid] = schemaAct
+		}
+	}
+	return nil
+}
+
+// scopeEntityTypes returns the entity types referenced by a principal or resource scope.
+func scopeEntityTypes(scope any) []string {
+	switch s := scope.(type) {
+	case ast.ScopeTypeEq:
+		return []string{string(s.Entity.Type)}
+	case ast.ScopeTypeIn:
+		return []string{string(s.Entity.Type)}
+	case ast.ScopeTypeIs:
+		return []string{string(s.Type)}
+	case ast.ScopeTypeIsIn:
+		return []string{string(s.Type), string(s.Entity.Type)}
+	}
+	return nil
+}
+
+// scopeType returns the entity type the principal or resource of a scope is constrained to, if any.
+func scopeType(scope any) string {
+	switch s := scope.(type) {
+	case ast.ScopeTypeEq:
+		return string(s.Entity.Type)
+	case ast.ScopeTypeIs:
+		return string(s.Type)
+	case ast.ScopeTypeIsIn:
+		return string(s.Type)
+	}
+	return ""
+}
+
+// actionScopeSet returns the actions matched by an action scope, nil if the scope matches all the actions.
+func actionScopeSet(scope any, schema *policySchema) map[string]bool {
+	actions := map[string]bool{}
+	switch s := scope.(type) {
+	case ast.ScopeTypeAll:
+		return nil
+	case ast.ScopeTypeEq:
+		actions[formatActionUID(s.Entity)] = true
+	case ast.ScopeTypeInSet:
+		for _, entity := range s.Entities {
+			actions[formatActionUID(entity)] = true
+		}
+	case ast.ScopeTypeIn:
+		actions[formatActionUID(s.Entity)] = true
+		if schema == nil {
+			break
+		}
+		// Expand the action groups until no new member is found.
+		for changed := true; changed; {
+			changed = false
+			for uid, action := range schema.actions {
+				if actions[uid] {
+					continue
+				}
+				for _, memberOf := range action.memberOf {
+					if actions[memberOf] {
+						actions[uid] = true
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// schemaAttributeType returns the type of an attribute declared by all the input shapes, known is false if any shape is open.
+func schemaAttributeType(shapes []*schemaTypeDecl, attribute string) (declared bool, attrType string, known bool) {
+	if len(shapes) == 0 {
+		return false, "", false
+	}
+	for i, shape := range shapes {
+		if shape == nil || shape.AdditionalAttributes {
+			return false, "", false
+		}
+		attr, exists := shape.Attributes[attribute]
+		if exists {
+			declared = true
+			if i == 0 || attrType == attr.Type {
+				attrType = attr.Type
+			} else {
+				attrType = ""
+			}
+		} else if i > 0 {
+			attrType = ""
+		}
+	}
+	return declared, attrType, true
+}
+
+// schemaValueType returns the schema type of a literal value.
+func schemaValueType(value types.Value) string {
+	switch value.(type) {
+	case types.String:
+		return "String"
+	case types.Long:
+		return "Long"
+	case types.Boolean:
+		return "Boolean"
+	}
+	return ""
+}
+
+// policyTypeErrors returns the type errors of a policy against the schema, a policy with type errors can never match.
+func policyTypeErrors(policy *ast.Policy, schema *policySchema) []string {
+	findings := []string{}
+	mismatch := func(format string, args ...any) {
+		findings = append(findings, fmt.Sprintf(format, args...))
+	}
+	scopes := []struct {
+		name  string
+		scope any
+	}{{"principal", policy.Principal}, {"resource", policy.Resource}}
+	for _, s := range scopes {
+		for _, entityType := range scopeEntityTypes(s.scope) {
+			if strings.HasPrefix(entityType, permguardNamespace) {
+				continue
+			}
+			if _, exists := schema.entityTypes[entityType]; !exists {
+				mismatch("%s type %s is not declared in the schema", s.name, entityType)
+			}
+		}
+	}
+	var actions []*schemaAction
+	actionSet := actionScopeSet(policy.Action, schema)
+	if actionSet == nil {
+		for _, action := range schema.actions {
+			actions = append(actions, action)
+		}
+	} else {
+		uids := make([]string, 0, len(actionSet))
+		for uid := range actionSet {
+			uids = append(uids, uid)
+		}
+		sort.Strings(uids)
+		for _, uid := range uids {
+			action, exists := schema.actions[uid]
+			if !exists {
+				mismatch("action %s is not declared in the schema", uid)
+				continue
+			}
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) == 0 {
+		return findings
+	}
+	for _, action := range actions {
+		if !action.appliesTo {
+			return findings
+		}
+	}
+	// Resolve the candidate shapes of the principal, the resource and the context.
+	candidates := func(constraint string, appliesTo func(*schemaAction) []string) ([]*schemaTypeDecl, bool) {
+		typeNames := map[string]bool{}
+		for _, action := range actions {
+			for _, typeName := range appliesTo(action) {
+				typeNames[typeName] = true
+			}
+		}
+		if constraint != "" {
+			if strings.HasPrefix(constraint, permguardNamespace) {
+				return nil, true
+			}
+			if !typeNames[constraint] {
+				return nil, false
+			}
+			typeNames = map[string]bool{constraint: true}
+		}
+		shapes := []*schemaTypeDecl{}
+		for typeName := range typeNames {
+			entityType, exists := schema.entityTypes[typeName]
+			if !exists {
+				return nil, true
+			}
+			shapes = append(shapes, entityType.shape)
+		}
+		return shapes, true
+	}
+	principalShapes, ok := candidates(scopeType(policy.Principal), func(a *schemaAction) []string { return a.principalTypes })
+	if !ok {
+		mismatch("principal type %s is not applicable to the actions of the policy", scopeType(policy.Principal))
+	}
+	resourceShapes, ok := candidates(scopeType(policy.Resource), func(a *schemaAction) []string { return a.resourceTypes })
+	if !ok {
+		mismatch("resource type %s is not applicable to the actions of the policy", scopeType(policy.Resource))
+	}
+	contextShapes := []*schemaTypeDecl{}
+	for _, action := range actions {
+		contextShapes = append(contextShapes, action.context)
+	}
+	shapes := map[string][]*schemaTypeDecl{"principal": principalShapes, "resource": resourceShapes, "context": contextShapes}
+	attributeOf := func(node ast.IsNode) (string, string, bool) {
+		access, ok := node.(ast.NodeTypeAccess)
+		if !ok {
+			return "", "", false
+		}
+		variable, ok := access.Arg.(ast.NodeTypeVariable)
+		if !ok {
+			return "", "", false
+		}
+		return string(variable.Name), string(access.Value), true
+	}
+	reported := map[string]bool{}
+	for _, condition := range policy.Conditions {
+		ast.Inspect(ast.NewNode(condition.Body), func(node ast.IsNode) bool {
+			if variable, attribute, ok := attributeOf(node); ok {
+				key := variable + "." + attribute
+				declared, _, known := schemaAttributeType(shapes[variable], attribute)
+				if known && !declared && !reported[key] {
+					reported[key] = true
+					mismatch("attribute %s is not declared in the schema", key)
+				}
+				return true
+			}
+			equals, ok := node.(ast.NodeTypeEquals)
+			if !ok {
+				return true
+			}
+			for _, sides := range [][2]ast.IsNode{{equals.Left, equals.Right}, {equals.Right, equals.Left}} {
+				variable, attribute, ok := attributeOf(sides[0])
+				value, isValue := sides[1].(ast.NodeValue)
+				if !ok || !isValue {
+					continue
+				}
+				_, attrType, known := schemaAttributeType(shapes[variable], attribute)
+				valueType := schemaValueType(value.Value)
+				if known && schemaPrimitive(attrType) != "" && valueType != "" && valueType != attrType {
+					mismatch("attribute %s.%s of type %s is compared with a %s value", variable, attribute, attrType, valueType)
+				}
+			}
+			return true
+		})
+	}
+	return findings
+}
+
+// schemaPrimitive returns the input schema type if it is a primitive type.
+func schemaPrimitive(schemaType string) string {
+	switch schemaType {
+	case "String", "Long", "Boolean":
+		return schemaType
+	}
+	return ""
+}
+
+// schemaIsRequired returns true if the attribute is required, attributes are required unless declared otherwise.
+func schemaIsRequired(schemaType *schemaTypeDecl) bool {
+	return schemaType.Required == nil || *schemaType.Required
+}
+
+// schemaEntityRefType returns the type of a JSON entity reference.
+func schemaEntityRefType(value any) (string, bool) {
+	ref, ok := value.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	if entity, ok := ref["__entity"].(map[string]any); ok {
+		ref = entity
+	}
+	refType, ok := ref["type"].(string)
+	return refType, ok
+}
+
+// schemaValueErrors returns the violations of a JSON value against a schema type.
+func schemaValueErrors(path string, value any, schemaType *schemaTypeDecl, namespace string) []string {
+	if schemaType == nil {
+		return nil
+	}
+	violation := func(format string, args ...any) []string {
+		return []string{fmt.Sprintf(format, args...)}
+	}
+	switch schemaType.Type {
+	case "String":
+		if _, ok := value.(string); !ok {
+			return violation("%s must be a String", path)
+		}
+	case "Long":
+		if number, ok := value.(float64); !ok || number != math.Trunc(number) {
+			return violation("%s must be a Long", path)
+		}
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return violation("%s must be a Boolean", path)
+		}
+	case "Set":
+		items, ok := value.([]any)
+		if !ok {
+			return violation("%s must be a Set", path)
+		}
+		violations := []string{}
+		for i, item := range items {
+			violations = append(violations, schemaValueErrors(fmt.Sprintf("%s[%d]", path, i), item, schemaType.Element, namespace)...)
+		}
+		return violations
+	case "Record":
+		record, ok := value.(map[string]any)
+		if !ok {
+			return violation("%s must be a Record", path)
+		}
+		return schemaRecordErrors(path, record, schemaType, namespace)
+	case "Entity":
+		refType, ok := schemaEntityRefType(value)
+		if !ok {
+			return violation("%s must be an entity reference", path)
+		}
+		if expected := qualifySchemaName(namespace, schemaType.Name); refType != expected {
+			return violation("%s must reference an entity of type %s, found %s", path, expected, refType)
+		}
+	}
+	return nil
+}
+
+// schemaRecordErrors returns the violations of a JSON record against a record schema type.
+func schemaRecordErrors(path string, record map[string]any, shape *schemaTypeDecl, namespace string) []string {
+	if shape == nil {
+		return nil
+	}
+	violations := []string{}
+	names := make([]string, 0, len(shape.Attributes)+len(record))
+	for name := range shape.Attributes {
+		names = append(names, name)
+	}
+	for name := range record {
+		if _, declared := shape.Attributes[name]; !declared {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attrPath := path + "." + name
+		attr, declared := shape.Attributes[name]
+		value, present := record[name]
+		switch {
+		case !declared:
+			if !shape.AdditionalAttributes {
+				violations = append(violations, fmt.Sprintf("%s is not declared in the schema", attrPath))
+			}
+		case !present:
+			if schemaIsRequired(&attr) {
+				violations = append(violations, fmt.Sprintf("%s is required by the schema", attrPath))
+			}
+		default:
+			violations = append(violations, schemaValueErrors(attrPath, value, &attr, namespace)...)
+		}
+	}
+	return violations
+}
+
+// schemaNormalizeJSON converts a value to its generic JSON representation.
+func schemaNormalizeJSON(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// schemaRequest is an authorization request to be validated against the schema.
+type schemaRequest struct {
+	principalType  string
+	principalAttrs map[string]any
+	resourceType   string
+	resourceAttrs  map[string]any
+	actionUID      string
+	context        map[string]any
+	entities       []map[string]any
+}
+
+// requestSchemaErrors returns the violations of an authorization request against the schema.
+func requestSchemaErrors(schema *policySchema, request *schemaRequest) ([]string, error) {
+	action, exists := schema.actions[request.actionUID]
+	if !exists {
+		return []string{fmt.Sprintf("action %s is not declared in the schema", request.actionUID)}, nil
+	}
+	violations := []string{}
+	if action.appliesTo {
+		if _, declared := schema.entityTypes[request.principalType]; declared && !slices.Contains(action.principalTypes, request.principalType) {
+			violations = append(violations, fmt.Sprintf("action %s does not apply to principal type %s", request.actionUID, request.principalType))
+		}
+		if !slices.Contains(action.resourceTypes, request.resourceType) {
+			violations = append(violations, fmt.Sprintf("action %s does not apply to resource type %s", request.actionUID, request.resourceType))
+		}
+	}
+	// The principal types are implicitly declared by permguard, their attributes are validated only when the schema declares them.
+	if principalType, declared := schema.entityTypes[request.principalType]; declared && principalType.shape != nil {
+		attrs, err := schemaNormalizeJSON(request.principalAttrs)
+		if err != nil {
+			return nil, err
+		}
+		record, _ := attrs.(map[string]any)
+		violations = append(violations, schemaRecordErrors("principal", record, principalType.shape, principalType.namespace)...)
+	}
+	if resourceType, declared := schema.entityTypes[request.resourceType]; !declared {
+		violations = append(violations, fmt.Sprintf("resource type %s is not declared in the schema", request.resourceType))
+	} else if resourceType.shape != nil {
+		attrs, err := schemaNormalizeJSON(request.resourceAttrs)
+		if err != nil {
+			return nil, err
+		}
+		record, _ := attrs.(map[string]any)
+		violations = append(violations, schemaRecordErrors("resource", record, resourceType.shape, resourceType.namespace)...)
+	}
+	if action.context != nil {
+		context, err := schemaNormalizeJSON(request.context)
+		if err != nil {
+			return nil, err
+		}
+		record, _ := context.(map[string]any)
+		violations = append(violations, schemaRecordErrors("context", record, action.context, action.namespace)...)
+	}
+	for _, item := range request.entities {
+		normalized, err := schemaNormalizeJSON(item)
+		if err != nil {
+			return nil, err
+		}
+		entity, _ := normalized.(map[string]any)
+		uid, _ := entity["uid"].(map[string]any)
+		uidType, _ := uid["type"].(string)
+		uidID, _ := uid["id"].(string)
+		if strings.HasPrefix(uidType, permguardNamespace) {
+			continue
+		}
+		entityPath := fmt.Sprintf("entity %s::%q", uidType, uidID)
+		entityType, declared := schema.entityTypes[uidType]
+		if !declared {
+			violations = append(violations, fmt.Sprintf("%s has a type not declared in the schema", entityPath))
+			continue
+		}
+		if entityType.shape != nil {
+			attrs, _ := entity["attrs"].(map[string]any)
+			violations = append(violations, schemaRecordErrors(entityPath, attrs, entityType.shape, entityType.namespace)...)
+		}
+		parents, _ := entity["parents"].([]any)
+		for _, parent := range parents {
+			parentType, ok := schemaEntityRefType(parent)
+			if !ok || strings.HasPrefix(parentType, permguardNamespace) {
+				continue
+			}
+			if !slices.Contains(entityType.memberOfTypes, parentType) {
+				violations = append(violations, fmt.Sprintf("%s cannot be a member of type %s", entityPath, parentType))
+			}
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+	cedarlang "github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	"github.com/permguard/permguard/ztauthstar/pkg/authzen"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+const strictTestSchema = `{
+  "Permguard::Identity": {
+    "entityTypes": {
+      "User": {
+        "shape": {
+          "type": "Record",
+          "attributes": {
+            "department": { "type": "String", "required": false }
+          }
+        }
+      }
+    },
+    "actions": {}
+  },
+  "Shop": {
+    "entityTypes": {
+      "Store": {},
+      "Order": {
+        "memberOfTypes": ["Store"],
+        "shape": {
+          "type": "Record",
+          "attributes": {
+            "status": { "type": "String" },
+            "total": { "type": "Long" },
+            "tags": { "type": "Set", "element": { "type": "String" }, "required": false }
+          }
+        }
+      }
+    },
+    "actions": {
+      "view": {
+        "appliesTo": {
+          "principalTypes": ["Permguard::Identity::User"],
+          "resourceTypes": ["Order"],
+          "context": { "type": "Record", "attributes": { "channel": { "type": "String" } } }
+        }
+      }
+    }
+  }
+}`
+
+// TestValidatePolicies tests the validation of the policies against the schema.
+func TestValidatePolicies(t *testing.T) {
+	assert := assert.New(t)
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	policies := `@id("view-orders")
+permit(principal, action == Shop::Action::"view", resource is Shop::Order)
+when { resource.status == "open" && context.channel == "web" };
+
+@id("view-stores")
+permit(principal, action == Shop::Action::"view", resource is Shop::Store);
+
+@id("view-closed")
+permit(principal, action == Shop::Action::"view", resource is Shop::Order)
+when { resource.closed == true || resource.total == "10" };
+`
+	policyErrors, err := langAbs.ValidatePolicies(mfestLang, []byte(strictTestSchema), "orders.cedar", []byte(policies))
+	require.NoError(t, err)
+	assert.NotContains(policyErrors, "view-orders")
+	assert.Equal([]string{"line 5, column 1: resource type Shop::Store is not applicable to the actions of the policy"}, policyErrors["view-stores"])
+	assert.ElementsMatch([]string{
+		"line 8, column 1: attribute resource.closed is not declared in the schema",
+		"line 8, column 1: attribute resource.total of type Long is compared with a String value",
+	}, policyErrors["view-closed"])
+
+	_, err = langAbs.ValidatePolicies(mfestLang, []byte("{"), "orders.cedar", []byte(policies))
+	assert.Error(err)
+}
+
+// strictTestPolicyStore builds a policy store with the test schema and a permit policy.
+func strictTestPolicyStore(t *testing.T, strict bool) *authzen.PolicyStore {
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err)
+
+	policyStore := &authzen.PolicyStore{}
+	schemaObj, err := langAbs.CreateSchemaBlobObjects(mfestLang, "/", "schema.json", []byte(strictTestSchema), strict)
+	require.NoError(t, err)
+	schemaSecObj := schemaObj.SectionObjects()[0]
+	require.NoError(t, schemaSecObj.Error())
+	schemaInfo, err := objMng.ObjectInfo(schemaSecObj.Object())
+	require.NoError(t, err)
+	assert.Equal(t, strict, schemaInfo.Header().MetadataBool(objects.MetaKeySchemaStrict))
+	policyStore.AddSchema(schemaSecObj.Object().OID(), schemaInfo)
+
+	policyObj, err := langAbs.CreatePolicyBlobObjects(mfestLang, "/", "orders.cedar", []byte(`@id("view-orders")
+permit(principal, action == Shop::Action::"view", resource is Shop::Order);`))
+	require.NoError(t, err)
+	policySecObj := policyObj.SectionObjects()[0]
+	require.NoError(t, policySecObj.Error())
+	policyInfo, err := objMng.ObjectInfo(policySecObj.Object())
+	require.NoError(t, err)
+	policyStore.AddPolicy(policySecObj.Object().OID(), policyInfo)
+	return policyStore
+}
+
+// TestAuthorizationCheckStrictSchema tests that requests not conforming to a strict schema are rejected.
+func TestAuthorizationCheckStrictSchema(t *testing.T) {
+	assert := assert.New(t)
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	tests := []struct {
+		name      string
+		subAttrs  map[string]any
+		resType   string
+		resAttrs  map[string]any
+		context   map[string]any
+		entities  []map[string]any
+		violation string
+	}{
+		{
+			name:     "conforming request",
+			resType:  "Shop::Order",
+			resAttrs: map[string]any{"status": "open", "total": 10},
+			context:  map[string]any{"channel": "web"},
+			entities: []map[string]any{{"uid": map[string]any{"type": "Shop::Store", "id": "s1"}, "attrs": map[string]any{}, "parents": []any{}}},
+		},
+		{
+			name:     "conforming subject",
+			subAttrs: map[string]any{"department": "sales"},
+			resType:  "Shop::Order",
+			resAttrs: map[string]any{"status": "open", "total": 10},
+			context:  map[string]any{"channel": "web"},
+		},
+		{
+			name:      "wrong subject attribute type",
+			subAttrs:  map[string]any{"department": 7},
+			resType:   "Shop::Order",
+			resAttrs:  map[string]any{"status": "open", "total": 10},
+			context:   map[string]any{"channel": "web"},
+			violation: "principal.department must be a String",
+		},
+		{
+			name:      "undeclared subject attribute",
+			subAttrs:  map[string]any{"age": 30},
+			resType:   "Shop::Order",
+			resAttrs:  map[string]any{"status": "open", "total": 10},
+			context:   map[string]any{"channel": "web"},
+			violation: "principal.age is not declared in the schema",
+		},
+		{
+			name:      "wrong attribute type",
+			resType:   "Shop::Order",
+			resAttrs:  map[string]any{"status": "open", "total": "10"},
+			context:   map[string]any{"channel": "web"},
+			violation: "resource.total must be a Long",
+		},
+		{
+			name:      "missing required attribute",
+			resType:   "Shop::Order",
+			resAttrs:  map[string]any{"status": "open"},
+			context:   map[string]any{"channel": "web"},
+			violation: "resource.total is required by the schema",
+		},
+		{
+			name:      "undeclared context attribute",
+			resType:   "Shop::Order",
+			resAttrs:  map[string]any{"status": "open", "total": 10},
+			context:   map[string]any{"channel": "web", "ip": "10.0.0.1"},
+			violation: "context.ip is not declared in the schema",
+		},
+		{
+			name:      "resource type not applicable",
+			resType:   "Shop::Store",
+			context:   map[string]any{"channel": "web"},
+			violation: "action Shop::Action::view does not apply to resource type Shop::Store",
+		},
+		{
+			name:      "invalid entity parent",
+			resType:   "Shop::Order",
+			resAttrs:  map[string]any{"status": "open", "total": 10},
+			context:   map[string]any{"channel": "web"},
+			entities:  []map[string]any{{"uid": map[string]any{"type": "Shop::Store", "id": "s1"}, "attrs": map[string]any{}, "parents": []any{map[string]any{"type": "Shop::Order", "id": "o1"}}}},
+			violation: `entity Shop::Store::"s1" cannot be a member of type Shop::Order`,
+		},
+	}
+	strictStore := strictTestPolicyStore(t, true)
+	relaxedStore := strictTestPolicyStore(t, false)
+	for _, test := range tests {
+		authzCtx := &authzen.AuthorizationModel{}
+		require.NoError(t, authzCtx.SetSubject("user", "amy", "", test.subAttrs))
+		require.NoError(t, authzCtx.SetResource(test.resType, "o1", test.resAttrs))
+		require.NoError(t, authzCtx.SetAction("Shop::Action::view", nil))
+		require.NoError(t, authzCtx.SetContext(test.context))
+		if test.entities != nil {
+			require.NoError(t, authzCtx.SetEntities("cedar", test.entities))
+		}
+		_, err := langAbs.AuthorizationCheck(mfestLang, "", strictStore, authzCtx)
+		if test.violation == "" {
+			assert.NoError(err, test.name)
+		} else {
+			assert.ErrorIs(err, languages.ErrSchemaViolation, test.name)
+			assert.ErrorContains(err, test.violation, test.name)
+		}
+		_, err = langAbs.AuthorizationCheck(mfestLang, "", relaxedStore, authzCtx)
+		assert.NoError(err, test.name)
+	}
+}
+
+// TestCreateSchemaBlobObjectsStrictInvalid tests that invalid strict schemas are reported as section errors.
+func TestCreateSchemaBlobObjectsStrictInvalid(t *testing.T) {
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err)
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+	multiSecObj, err := langAbs.CreateSchemaBlobObjects(mfestLang, "/", "schema.json", []byte(`{"Shop": {"entityTypes": 1}}`), true)
+	require.NoError(t, err)
+	assert.Error(t, multiSecObj.SectionObjects()[0].Error())
+}
@@ -0,0 +1,306 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cedar-policy/cedar-go"
+	"github.com/cedar-policy/cedar-go/types"
+	"github.com/cedar-policy/cedar-go/x/exp/ast"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+)
+
+const (
+	// templateSlotType is the entity type standing in for the slots of a template while it is parsed and stored.
+	templateSlotType = permguardNamespace + "Template::Slot"
+	// templateSlotPrincipal is the principal slot of a template.
+	templateSlotPrincipal = "principal"
+	// templateSlotResource is the resource slot of a template.
+	templateSlotResource = "resource"
+)
+
+// templateLinkEntity is the entity bound to a slot of a template.
+type templateLinkEntity struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// templateLink binds the slots of a template to entities, producing a linked policy.
+type templateLink struct {
+	TemplateID string                        `json:"template_id"`
+	LinkID     string                        `json:"link_id"`
+	Args       map[string]templateLinkEntity `json:"args"`
+}
+
+// templateSlotUID returns the placeholder entity of a slot.
+func templateSlotUID(slot string) types.EntityUID {
+	return cedar.NewEntityUID(cedar.EntityType(templateSlotType), cedar.String(slot))
+}
+
+// templateSlotText returns the cedar text of the placeholder entity of a slot.
+func templateSlotText(slot string) []byte {
+	return []byte(templateSlotUID(slot).String())
+}
+
+// isIdentByte reports whether the byte can be part of a cedar identifier.
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// replaceTemplateSlots replaces the ?principal and ?resource slots outside strings and comments with their placeholder entities.
+func replaceTemplateSlots(data []byte) []byte {
+	if !bytes.Contains(data, []byte("?")) {
+		return data
+	}
+	var out bytes.Buffer
+	out.Grow(len(data))
+	inString, inComment := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inComment:
+			if c == '\n' {
+				inComment = false
+			}
+		case inString:
+			if c == '\\' && i+1 < len(data) {
+				out.WriteByte(c)
+				i++
+				c = data[i]
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			inComment = true
+		case c == '?':
+			replaced := false
+			for _, slot := range []string{templateSlotPrincipal, templateSlotResource} {
+				end := i + 1 + len(slot)
+				if !bytes.HasPrefix(data[i+1:], []byte(slot)) || (end < len(data) && isIdentByte(data[end])) {
+					continue
+				}
+				out.Write(templateSlotText(slot))
+				i = end - 1
+				replaced = true
+				break
+			}
+			if replaced {
+				continue
+			}
+		}
+		out.WriteByte(c)
+	}
+	return out.Bytes()
+}
+
+// restoreTemplateSlots restores the ?principal and ?resource slots in the cedar text of a template.
+func restoreTemplateSlots(data []byte) []byte {
+	for _, slot := range []string{templateSlotPrincipal, templateSlotResource} {
+		data = bytes.ReplaceAll(data, templateSlotText(slot), []byte("?"+slot))
+	}
+	return data
+}
+
+// parsePolicyList parses the policies and the templates of a cedar source file.
+func parsePolicyList(path string, data []byte) (cedar.PolicyList, error) {
+	return cedar.NewPolicyListFromBytes(path, replaceTemplateSlots(data))
+}
+
+// scopeSlot returns the slot referenced by a principal or resource scope, if any.
+func scopeSlot(scope any) (string, bool) {
+	var entity types.EntityUID
+	switch s := scope.(type) {
+	case ast.ScopeTypeEq:
+		entity = s.Entity
+	case ast.ScopeTypeIn:
+		entity = s.Entity
+	case ast.ScopeTypeIsIn:
+		entity = s.Entity
+	default:
+		return "", false
+	}
+	if string(entity.Type) != templateSlotType {
+		return "", false
+	}
+	return string(entity.ID), true
+}
+
+// templateSlots returns the slots of a policy, an empty result means that the policy is not a template.
+func templateSlots(policy *cedar.Policy) ([]string, error) {
+	policyAST := policy.AST()
+	var slots []string
+	if slot, ok := scopeSlot(policyAST.Principal); ok {
+		if slot != templateSlotPrincipal {
+			return nil, fmt.Errorf("cedar: the ?%s slot cannot be used in the principal scope", slot)
+		}
+		slots = append(slots, slot)
+	}
+	if slot, ok := scopeSlot(policyAST.Resource); ok {
+		if slot != templateSlotResource {
+			return nil, fmt.Errorf("cedar: the ?%s slot cannot be used in the resource scope", slot)
+		}
+		slots = append(slots, slot)
+	}
+	policyJSON, err := policy.MarshalJSON()
+	if err != nil {
+		return nil, errors.Join(errors.New("cedar: invalid policy syntax"), err)
+	}
+	if bytes.Count(policyJSON, []byte(`"`+templateSlotType+`"`)) != len(slots) {
+		return nil, errors.New("cedar: template slots can only be used in the principal and resource scopes")
+	}
+	return slots, nil
+}
+
+// parseTemplateLinks parses the template links of a source file.
+func parseTemplateLinks(data []byte) ([]templateLink, error) {
+	var links []templateLink
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, errors.Join(errors.New("cedar: invalid template links syntax"), err)
+	}
+	return links, nil
+}
+
+// bindScope binds a principal or resource scope of a template to an entity.
+func bindScope(scope any, entity types.EntityUID) any {
+	switch s := scope.(type) {
+	case ast.ScopeTypeEq:
+		return ast.Scope{}.Eq(entity)
+	case ast.ScopeTypeIn:
+		return ast.Scope{}.In(entity)
+	case ast.ScopeTypeIsIn:
+		return ast.Scope{}.IsIn(s.Type, entity)
+	default:
+		return scope
+	}
+}
+
+// linkTemplate creates the policy linked to a template by binding its slots to the entities of the link.
+func linkTemplate(template *cedar.Policy, link *templateLink) (*cedar.Policy, error) {
+	slots, err := templateSlots(template)
+	if err != nil {
+		return nil, err
+	}
+	args := map[string]types.EntityUID{}
+	for key, entity := range link.Args {
+		slot := strings.TrimPrefix(key, "?")
+		if !slices.Contains(slots, slot) {
+			return nil, fmt.Errorf("cedar: template %s has no ?%s slot", link.TemplateID, slot)
+		}
+		if len(strings.TrimSpace(entity.Type)) == 0 || len(strings.TrimSpace(entity.ID)) == 0 {
+			return nil, fmt.Errorf("cedar: invalid entity for the ?%s slot of template link %s", slot, link.LinkID)
+		}
+		if entity.Type == templateSlotType {
+			return nil, fmt.Errorf("cedar: invalid entity type for the ?%s slot of template link %s", slot, link.LinkID)
+		}
+		args[slot] = cedar.NewEntityUID(cedar.EntityType(entity.Type), cedar.String(entity.ID))
+	}
+	for _, slot := range slots {
+		if _, exists := args[slot]; !exists {
+			return nil, fmt.Errorf("cedar: template link %s is missing the ?%s slot", link.LinkID, slot)
+		}
+	}
+	policyAST := *template.AST()
+	policyAST.Annotations = make([]ast.AnnotationType, 0, len(template.AST().Annotations))
+	for _, annotation := range template.AST().Annotations {
+		if annotation.Key == "id" {
+			continue
+		}
+		policyAST.Annotations = append(policyAST.Annotations, annotation)
+	}
+	policyAST.Annotations = append(policyAST.Annotations, ast.AnnotationType{Key: "id", Value: cedar.String(link.LinkID)})
+	if entity, exists := args[templateSlotPrincipal]; exists {
+		principal, ok := bindScope(policyAST.Principal, entity).(ast.IsPrincipalScopeNode)
+		if !ok {
+			return nil, fmt.Errorf("cedar: invalid principal scope of template %s", link.TemplateID)
+		}
+		policyAST.Principal = principal
+	}
+	if entity, exists := args[templateSlotResource]; exists {
+		resource, ok := bindScope(policyAST.Resource, entity).(ast.IsResourceScopeNode)
+		if !ok {
+			return nil, fmt.Errorf("cedar: invalid resource scope of template %s", link.TemplateID)
+		}
+		policyAST.Resource = resource
+	}
+	return cedar.NewPolicyFromAST(&policyAST), nil
+}
+
+// templateLinkErrors validates that the template of each template link exists and that the link binds exactly its slots.
+func templateLinkErrors(sources []languages.LintSource) []languages.SourceRelationError {
+	templates := map[string]*cedar.Policy{}
+	for i := range sources {
+		source := &sources[i]
+		if source.Schema || isTemplateLinksFile(source.Path) || isRolesFile(source.Path) {
+			continue
+		}
+		policyList, err := parsePolicyList(source.Path, source.Content)
+		if err != nil {
+			// Syntax errors are already reported by the blobification of the file.
+			continue
+		}
+		for _, policy := range policyList {
+			policyID, exists := policy.Annotations()["id"]
+			if !exists {
+				continue
+			}
+			if slots, err := templateSlots(policy); err == nil && len(slots) > 0 {
+				templates[string(policyID)] = policy
+			}
+		}
+	}
+	linkErrors := []languages.SourceRelationError{}
+	for i := range sources {
+		source := &sources[i]
+		if source.Schema || !isTemplateLinksFile(source.Path) {
+			continue
+		}
+		links, err := parseTemplateLinks(source.Content)
+		if err != nil {
+			continue
+		}
+		for j := range links {
+			link := &links[j]
+			if len(strings.TrimSpace(link.TemplateID)) == 0 || len(link.Args) == 0 {
+				continue
+			}
+			template, exists := templates[link.TemplateID]
+			if !exists {
+				err = fmt.Errorf("cedar: template %s of template link %s does not exist", link.TemplateID, link.LinkID)
+			} else {
+				_, err = linkTemplate(template, link)
+			}
+			if err != nil {
+				linkErrors = append(linkErrors, languages.SourceRelationError{
+					Partition:  source.Partition,
+					Path:       source.Path,
+					ObjectName: link.LinkID,
+					Message:    err.Error(),
+				})
+			}
+		}
+	}
+	return linkErrors
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cedar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/authz/languages"
+	cedarlang "github.com/permguard/permguard/ztauthstar-cedar/pkg/cedarlang"
+	azmanifests "github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/manifests"
+)
+
+// TestReplaceTemplateSlots tests that only the slots outside strings and comments are replaced by their placeholder entities.
+func TestReplaceTemplateSlots(t *testing.T) {
+	principal := string(templateSlotText(templateSlotPrincipal))
+	resource := string(templateSlotText(templateSlotResource))
+
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"no slots", `permit(principal, action, resource);`, `permit(principal, action, resource);`},
+		{"scopes", `permit(principal == ?principal, action, resource in ?resource);`, `permit(principal == ` + principal + `, action, resource in ` + resource + `);`},
+		{"end of data", `resource in ?resource`, `resource in ` + resource},
+		{"string", `when { context.note == "?principal" }`, `when { context.note == "?principal" }`},
+		{"escaped quote in string", `when { context.note == "\"?principal" }`, `when { context.note == "\"?principal" }`},
+		{"comment", "// bound to ?resource\npermit(principal == ?principal, action, resource);", "// bound to ?resource\npermit(principal == " + principal + ", action, resource);"},
+		{"longer identifier", `principal == ?principals`, `principal == ?principals`},
+		{"unknown slot", `principal == ?owner`, `principal == ?owner`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(replaceTemplateSlots([]byte(test.data))), "replaced data mismatch")
+		})
+	}
+}
+
+// TestRestoreTemplateSlots tests that the placeholder entities are restored to their slots.
+func TestRestoreTemplateSlots(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `@id("share-order")
+permit(principal == ?principal, action, resource in ?resource) when { context.note == "?principal" };`
+	assert.Equal(data, string(restoreTemplateSlots(replaceTemplateSlots([]byte(data)))), "slots should be restored")
+	assert.Equal(`principal == User::"amy"`, string(restoreTemplateSlots([]byte(`principal == User::"amy"`))), "data without placeholders should not change")
+}
+
+// TestLinkTemplate tests the binding of the slots of a template and the errors of the template links.
+func TestLinkTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	policies, err := parsePolicyList("share.cedar", []byte(`@id("share-order")
+@description("share an order")
+permit(principal == ?principal, action, resource in ?resource);
+@id("view-orders")
+permit(principal, action, resource);
+@id("bad-slot")
+permit(principal == ?principal, action, resource) when { resource == ?resource };`))
+	require.NoError(t, err, "parsePolicyList should not return an error")
+	template := policies[0]
+
+	linked, err := linkTemplate(template, &templateLink{TemplateID: "share-order", LinkID: "share-order-amy", Args: map[string]templateLinkEntity{
+		"?principal": {Type: "User", ID: "amy"},
+		"resource":   {Type: "Order", ID: "1"},
+	}})
+	require.NoError(t, err, "linkTemplate should not return an error")
+	linkedText := string(linked.MarshalCedar())
+	assert.Contains(linkedText, `principal == User::"amy"`, "principal slot should be bound")
+	assert.Contains(linkedText, `resource in Order::"1"`, "resource slot should be bound")
+	assert.Contains(linkedText, `@id("share-order-amy")`, "linked policy should have the link id")
+	assert.Contains(linkedText, `@description("share an order")`, "annotations of the template should be kept")
+	assert.NotContains(linkedText, `@id("share-order")`, "id of the template should be replaced")
+
+	tests := []struct {
+		name     string
+		template int
+		args     map[string]templateLinkEntity
+		errMsg   string
+	}{
+		{"unknown slot", 0, map[string]templateLinkEntity{"?principal": {Type: "User", ID: "amy"}, "?resource": {Type: "Order", ID: "1"}, "?owner": {Type: "User", ID: "bob"}}, "has no ?owner slot"},
+		{"missing slot", 0, map[string]templateLinkEntity{"?principal": {Type: "User", ID: "amy"}}, "is missing the ?resource slot"},
+		{"missing entity type", 0, map[string]templateLinkEntity{"?principal": {ID: "amy"}, "?resource": {Type: "Order", ID: "1"}}, "invalid entity for the ?principal slot"},
+		{"missing entity id", 0, map[string]templateLinkEntity{"?principal": {Type: "User", ID: " "}, "?resource": {Type: "Order", ID: "1"}}, "invalid entity for the ?principal slot"},
+		{"slot entity type", 0, map[string]templateLinkEntity{"?principal": {Type: templateSlotType, ID: "principal"}, "?resource": {Type: "Order", ID: "1"}}, "invalid entity type for the ?principal slot"},
+		{"not a template", 1, map[string]templateLinkEntity{"?principal": {Type: "User", ID: "amy"}}, "has no ?principal slot"},
+		{"invalid template", 2, map[string]templateLinkEntity{"?principal": {Type: "User", ID: "amy"}}, "can only be used in the principal and resource scopes"},
+	}
+	for _, test := range tests {
+		_, err := linkTemplate(policies[test.template], &templateLink{TemplateID: "share-order", LinkID: "link", Args: test.args})
+		assert.ErrorContains(err, test.errMsg, test.name)
+	}
+}
+
+// TestValidateSourceRelations tests that the template links are validated against the templates of all the partitions.
+func TestValidateSourceRelations(t *testing.T) {
+	assert := assert.New(t)
+
+	langAbs, err := NewCedarLanguageAbstraction()
+	require.NoError(t, err, "NewCedarLanguageAbstraction should not return an error")
+	mfestLang := &azmanifests.Language{Name: cedarlang.LanguageCedar}
+
+	links := `[
+  {"template_id": "share-order", "link_id": "share-order-amy", "args": {"?principal": {"type": "User", "id": "amy"}}},
+  {"template_id": "view-report", "link_id": "view-report-amy", "args": {"?resource": {"type": "Report", "id": "1"}}},
+  {"template_id": "missing", "link_id": "missing-amy", "args": {"?principal": {"type": "User", "id": "amy"}}},
+  {"template_id": "share-order", "link_id": "share-order-report", "args": {"?resource": {"type": "Report", "id": "1"}}},
+  {"template_id": "view-orders", "link_id": "view-orders-amy", "args": {"?principal": {"type": "User", "id": "amy"}}},
+  {"link_id": "no-template", "args": {"?principal": {"type": "User", "id": "amy"}}}
+]`
+	sources := []languages.LintSource{
+		{Partition: "/", Path: "share.cedar", Content: []byte(`@id("share-order")
+permit(principal == ?principal, action, resource);
+@id("view-orders")
+permit(principal, action, resource);`)},
+		{Partition: "/reports", Path: "reports/reports.cedar", Content: []byte(`@id("view-report") permit(principal, action, resource in ?resource);`)},
+		{Partition: "/", Path: "invalid.cedar", Content: []byte(`@id("broken") permit(`)},
+		{Partition: "/", Path: "schema.json", Schema: true, Content: []byte(`{}`)},
+		{Partition: "/", Path: cedarlang.LanguageTemplateLinksFileName, Content: []byte(links)},
+	}
+	relationErrors, err := langAbs.ValidateSourceRelations(mfestLang, sources)
+	require.NoError(t, err, "ValidateSourceRelations should not return an error")
+
+	messages := map[string]string{}
+	for _, relationError := range relationErrors {
+		assert.Equal("/", relationError.Partition, "partition mismatch")
+		assert.Equal(cedarlang.LanguageTemplateLinksFileName, relationError.Path, "path mismatch")
+		messages[relationError.ObjectName] = relationError.Message
+	}
+	require.Len(t, messages, 3, "relation errors mismatch")
+	assert.Contains(messages["missing-amy"], "template missing of template link missing-amy does not exist", "missing template should be reported")
+	assert.Contains(messages["share-order-report"], "template share-order has no ?resource slot", "mismatching slots should be reported")
+	assert.Contains(messages["view-orders-amy"], "template view-orders of template link view-orders-amy does not exist", "policies should not be linked")
+
+	_, err = langAbs.ValidateSourceRelations(&azmanifests.Language{Name: "rego"}, sources)
+	assert.Error(err, "ValidateSourceRelations should fail for an unsupported language")
+}
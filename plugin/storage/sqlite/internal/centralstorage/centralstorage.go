@@ -32,19 +32,39 @@ import (
 type SqliteRepo interface {
 	// UpsertZone creates or updates a zone.
 	UpsertZone(ctx context.Context, tx *sql.Tx, isCreate bool, zone *azrepos.Zone) (*azrepos.Zone, error)
-	// DeleteZone deletes a zone.
+	// ReadZone reads a live or a soft deleted zone.
+	ReadZone(ctx context.Context, tx *sql.Tx, zoneID int64, deleted bool) (*azrepos.Zone, error)
+	// DeleteZone soft deletes a zone.
 	DeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (*azrepos.Zone, error)
+	// UndeleteZone restores a soft deleted zone.
+	UndeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (*azrepos.Zone, error)
+	// PurgeZones permanently deletes the zones soft deleted before the given time.
+	PurgeZones(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) (int64, error)
 	// FetchZone fetches a zone.
-	FetchZones(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, filterID *int64, filterName *string) ([]azrepos.Zone, error)
+	FetchZones(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, filter *azrepos.ZoneFilter, options *azrepos.FetchOptions) ([]azrepos.Zone, error)
+	// CountZones counts the zones matching the filter.
+	CountZones(ctx context.Context, db *sqlx.DB, filter *azrepos.ZoneFilter) (int64, error)
 
 	// UpsertLedger creates or updates a ledger.
 	UpsertLedger(ctx context.Context, tx *sql.Tx, isCreate bool, ledger *azrepos.Ledger) (*azrepos.Ledger, error)
-	// DeleteLedger deletes a ledger.
+	// ReadLedger reads a live or a soft deleted ledger.
+	ReadLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string, deleted bool) (*azrepos.Ledger, error)
+	// DeleteLedger soft deletes a ledger.
 	DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*azrepos.Ledger, error)
+	// UndeleteLedger restores a soft deleted ledger.
+	UndeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*azrepos.Ledger, error)
+	// PurgeLedgers permanently deletes the ledgers soft deleted before the given time.
+	PurgeLedgers(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) (int64, error)
+	// CountLedgersWithHistory counts the live ledgers of a zone whose ref is not the given empty ref.
+	CountLedgersWithHistory(ctx context.Context, tx *sql.Tx, zoneID int64, emptyRef string) (int64, error)
 	// FetchLedgers fetches ledgers.
-	FetchLedgers(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, zoneID int64, filterID *string, filterName *string) ([]azrepos.Ledger, error)
+	FetchLedgers(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, zoneID int64, filter *azrepos.LedgerFilter, options *azrepos.FetchOptions) ([]azrepos.Ledger, error)
+	// CountLedgers counts the ledgers of a zone matching the filter.
+	CountLedgers(ctx context.Context, db *sqlx.DB, zoneID int64, filter *azrepos.LedgerFilter) (int64, error)
 	// UpdateLedgerRef updates the ledger ref and txid.
 	UpdateLedgerRef(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID, currentRef, newRef, txid string) error
+	// UpdateLedgerTrustedKeys replaces the keys trusted to sign the commits of a ledger.
+	UpdateLedgerTrustedKeys(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID, trustedKeys string) error
 
 	// UpsertKeyValue creates or updates a key value with txid association.
 	UpsertKeyValue(ctx context.Context, tx *sql.Tx, keyValue *azrepos.KeyValue, txid string) (*azrepos.KeyValue, error)
@@ -59,6 +79,8 @@ type SqliteRepo interface {
 	UpdateTransactionStatus(ctx context.Context, tx *sql.Tx, txid string, status string) error
 	// UpdateTransactionStatusNoTx updates the status without a transaction.
 	UpdateTransactionStatusNoTx(ctx context.Context, db *sqlx.DB, txid string, status string) error
+	// AddTransactionConfirmedObjects adds to the confirmed objects of a pending transaction and returns the new total.
+	AddTransactionConfirmedObjects(ctx context.Context, tx *sql.Tx, txid string, count int64) (int64, error)
 	// GetTransaction retrieves a transaction by txid.
 	GetTransaction(ctx context.Context, db *sqlx.DB, txid string) (*azrepos.Transaction, error)
 	// FetchStaleTransactions retrieves pending transactions older than the given threshold.
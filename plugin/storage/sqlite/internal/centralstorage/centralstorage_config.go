@@ -33,6 +33,8 @@ const (
 	maxPageSizeKey = "data-fetch-maxpagesize"
 	// maxPageSizeDefault is the default value for the maximum number of items to fetch per request.
 	maxPageSizeDefault = 10000
+	// requireSignedCommitsKey is the key for the flag to load policy stores from trusted signed commits only.
+	requireSignedCommitsKey = "require-signed-commits"
 )
 
 // SQLiteCentralStorageConfig is the SQLite central storage configuration.
@@ -77,3 +79,15 @@ func (c *SQLiteCentralStorageConfig) EnabledDefaultCreation() bool {
 	}
 	return enabledDefaultCreationDefault
 }
+
+// RequireSignedCommits returns the flag to load policy stores from commits signed by a trusted key only.
+func (c *SQLiteCentralStorageConfig) RequireSignedCommits() bool {
+	requireSignedCommits, err := c.configReader.Value(requireSignedCommitsKey)
+	if err != nil {
+		return false
+	}
+	if boolValue, ok := requireSignedCommits.(bool); ok {
+		return boolValue
+	}
+	return false
+}
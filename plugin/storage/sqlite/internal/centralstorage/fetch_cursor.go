@@ -0,0 +1,101 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package centralstorage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models"
+	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+)
+
+// fetchCursor is the decoded form of the opaque cursor returned to the clients.
+type fetchCursor struct {
+	SortBy   string `json:"s,omitempty"`
+	SortDesc bool   `json:"d,omitempty"`
+	Value    string `json:"v,omitempty"`
+	ID       string `json:"i"`
+}
+
+// encodeFetchCursor encodes the cursor resuming after the entry with the given sort value and id.
+func encodeFetchCursor(options *models.FetchOptions, value string, id string) (string, error) {
+	cursor := fetchCursor{ID: id}
+	if options != nil {
+		cursor.SortBy = options.SortBy
+		cursor.SortDesc = options.SortDesc
+	}
+	if cursor.SortBy == "" || cursor.SortBy == models.FetchSortByID {
+		cursor.SortBy = ""
+		value = ""
+	}
+	cursor.Value = value
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("storage: failed to encode the fetch cursor: %w", azstorage.ErrInternal)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// mapFetchOptions maps the fetch options to the repository options, the cursor must have been issued for the same sort.
+func mapFetchOptions(options *models.FetchOptions) (*azrepos.FetchOptions, *fetchCursor, error) {
+	if options == nil {
+		return nil, nil, nil
+	}
+	if !models.IsValidFetchSortBy(options.SortBy) {
+		return nil, nil, fmt.Errorf("storage: invalid client input - sort field %s is not valid: %w", options.SortBy, azstorage.ErrInvalidInput)
+	}
+	repoOptions := &azrepos.FetchOptions{SortDesc: options.SortDesc}
+	if options.SortBy != models.FetchSortByID {
+		repoOptions.SortColumn = options.SortBy
+	}
+	if options.Cursor == "" {
+		return repoOptions, nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(options.Cursor)
+	if err != nil {
+		return nil, nil, fmt.Errorf("storage: invalid client input - cursor is not valid: %w", azstorage.ErrInvalidInput)
+	}
+	var cursor fetchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, nil, fmt.Errorf("storage: invalid client input - cursor is not valid: %w", azstorage.ErrInvalidInput)
+	}
+	if cursor.SortBy != repoOptions.SortColumn || cursor.SortDesc != options.SortDesc {
+		return nil, nil, fmt.Errorf("storage: invalid client input - cursor was issued for a different sort: %w", azstorage.ErrInvalidInput)
+	}
+	repoOptions.AfterValue = cursor.Value
+	return repoOptions, &cursor, nil
+}
+
+// cursorSortValue returns the value of the sort column the cursor resumes after.
+func cursorSortValue(options *models.FetchOptions, name string, createdAt, updatedAt time.Time) string {
+	if options == nil {
+		return ""
+	}
+	switch options.SortBy {
+	case models.FetchSortByName:
+		return name
+	case models.FetchSortByCreatedAt:
+		return createdAt.UTC().Format(azrepos.TimestampLayout)
+	case models.FetchSortByUpdatedAt:
+		return updatedAt.UTC().Format(azrepos.TimestampLayout)
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package centralstorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/core/validators"
+)
+
+// mapLabelsToJSON validates the labels and encodes them as a JSON object, nil labels are mapped to nil.
+func mapLabelsToJSON(entity string, labels map[string]string) (*string, error) {
+	if labels == nil {
+		return nil, nil
+	}
+	if err := validators.ValidateLabels(entity, labels); err != nil {
+		return nil, fmt.Errorf("storage: invalid client input - %s labels are not valid: %w", entity, errors.Join(azstorage.ErrInvalidInput, err))
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to encode %s labels: %w", entity, azstorage.ErrInternal)
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
+
+// mapJSONToLabels decodes the labels stored as a JSON object, empty label sets are mapped to nil.
+func mapJSONToLabels(labels *string) (map[string]string, error) {
+	if labels == nil || *labels == "" {
+		return nil, nil
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(*labels), &decoded); err != nil {
+		return nil, fmt.Errorf("storage: failed to decode labels: %w", azstorage.ErrInternal)
+	}
+	if len(decoded) == 0 {
+		return nil, nil
+	}
+	return decoded, nil
+}
+
+// validateDescription validates the description, a nil description is valid.
+func validateDescription(entity string, description *string) error {
+	if description == nil {
+		return nil
+	}
+	if err := validators.ValidateDescription(entity, *description); err != nil {
+		return fmt.Errorf("storage: invalid client input - %s description is not valid: %w", entity, errors.Join(azstorage.ErrInvalidInput, err))
+	}
+	return nil
+}
+
+// mapDescription maps the stored description, empty descriptions are mapped to nil.
+func mapDescription(description *string) *string {
+	if description == nil || *description == "" {
+		return nil
+	}
+	return description
+}
+
+// labelsFilter reads the labels filter from the fields.
+func labelsFilter(fields map[string]any, field string) (map[string]string, error) {
+	value, ok := fields[field]
+	if !ok {
+		return nil, nil
+	}
+	labels, ok := value.(map[string]string)
+	if !ok {
+		return nil, fmt.Errorf("storage: invalid client input - labels filter is not valid: %w", azstorage.ErrInvalidInput)
+	}
+	return labels, nil
+}
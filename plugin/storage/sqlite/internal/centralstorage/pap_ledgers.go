@@ -25,8 +25,10 @@ import (
 
 	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
 const (
@@ -63,10 +65,24 @@ func (s SQLiteCentralStoragePAP) CreateLedger(ctx context.Context, ledger *pap.L
 	if err != nil {
 		return nil, fmt.Errorf("storage: invalid client input - ledger kind %s is not valid: %w", ledger.Kind, azstorage.ErrInvalidInput)
 	}
+	trustedKeys, err := joinTrustedKeys(ledger.TrustedKeys)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := validateDescription(azrepos.LedgerType, ledger.Description); err != nil {
+		return nil, rollback(tx, err)
+	}
+	labels, err := mapLabelsToJSON(azrepos.LedgerType, ledger.Labels)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
 	dbInLedger := &azrepos.Ledger{
-		ZoneID: ledger.ZoneID,
-		Name:   ledger.Name,
-		Kind:   kind,
+		ZoneID:      ledger.ZoneID,
+		Name:        ledger.Name,
+		Kind:        kind,
+		TrustedKeys: trustedKeys,
+		Description: ledger.Description,
+		Labels:      labels,
 	}
 	dbOutLedger, err := s.sqlRepo.UpsertLedger(ctx, tx, true, dbInLedger)
 	if err != nil {
@@ -107,11 +123,29 @@ func (s SQLiteCentralStoragePAP) UpdateLedger(ctx context.Context, ledger *pap.L
 	if err != nil {
 		return nil, fmt.Errorf("storage: invalid client input - ledger kind %s is not valid: %w", ledger.Kind, azstorage.ErrInvalidInput)
 	}
+	if err := validateDescription(azrepos.LedgerType, ledger.Description); err != nil {
+		return nil, rollback(tx, err)
+	}
+	labels, err := mapLabelsToJSON(azrepos.LedgerType, ledger.Labels)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
 	dbInLedger := &azrepos.Ledger{
-		LedgerID: ledger.LedgerID,
-		ZoneID:   ledger.ZoneID,
-		Kind:     kind,
-		Name:     ledger.Name,
+		LedgerID:    ledger.LedgerID,
+		ZoneID:      ledger.ZoneID,
+		Kind:        kind,
+		Name:        ledger.Name,
+		Description: ledger.Description,
+		Labels:      labels,
+	}
+	if ledger.TrustedKeys != nil {
+		trustedKeys, err := joinTrustedKeys(ledger.TrustedKeys)
+		if err != nil {
+			return nil, rollback(tx, err)
+		}
+		if err := s.sqlRepo.UpdateLedgerTrustedKeys(ctx, tx, ledger.ZoneID, ledger.LedgerID, trustedKeys); err != nil {
+			return nil, rollback(tx, err)
+		}
 	}
 	dbOutLedger, err := s.sqlRepo.UpsertLedger(ctx, tx, false, dbInLedger)
 	if err != nil {
@@ -123,8 +157,8 @@ func (s SQLiteCentralStoragePAP) UpdateLedger(ctx context.Context, ledger *pap.L
 	return mapLedgerToAgentLedger(dbOutLedger)
 }
 
-// DeleteLedger deletes a ledger.
-func (s SQLiteCentralStoragePAP) DeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (_ *pap.Ledger, retErr error) {
+// DeleteLedger soft deletes a ledger, a ledger holding policy history must be confirmed with its name or forced.
+func (s SQLiteCentralStoragePAP) DeleteLedger(ctx context.Context, zoneID int64, ledgerID string, options *models.DeleteOptions) (_ *pap.Ledger, retErr error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "storage.DeleteLedger")
 	defer span.End()
 	start := time.Now()
@@ -134,6 +168,9 @@ func (s SQLiteCentralStoragePAP) DeleteLedger(ctx context.Context, zoneID int64,
 		telemetry.LedgerOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("delete"), telemetry.StatusAttr(st))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", zoneID), attribute.String("ledger_id", ledgerID))
+	if options == nil {
+		options = &models.DeleteOptions{}
+	}
 	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
@@ -142,6 +179,16 @@ func (s SQLiteCentralStoragePAP) DeleteLedger(ctx context.Context, zoneID int64,
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
 	}
+	if !options.Force {
+		dbLedger, err := s.sqlRepo.ReadLedger(ctx, tx, zoneID, ledgerID, false)
+		if err != nil {
+			return nil, rollback(tx, err)
+		}
+		if dbLedger.Ref != objects.ZeroOID && options.Confirmation != dbLedger.Name {
+			err := fmt.Errorf("storage: ledger %s holds policy history, confirm the delete with the ledger name or force it: %w", ledgerID, azstorage.ErrConfirmationRequired)
+			return nil, rollback(tx, err)
+		}
+	}
 	dbOutLedger, err := s.sqlRepo.DeleteLedger(ctx, tx, zoneID, ledgerID)
 	if err != nil {
 		return nil, rollback(tx, err)
@@ -152,52 +199,169 @@ func (s SQLiteCentralStoragePAP) DeleteLedger(ctx context.Context, zoneID int64,
 	return mapLedgerToAgentLedger(dbOutLedger)
 }
 
-// FetchLedgers returns all ledgers.
-func (s SQLiteCentralStoragePAP) FetchLedgers(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any) (_ []pap.Ledger, retErr error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "storage.FetchLedgers")
+// UndeleteLedger restores a soft deleted ledger.
+func (s SQLiteCentralStoragePAP) UndeleteLedger(ctx context.Context, zoneID int64, ledgerID string) (_ *pap.Ledger, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.UndeleteLedger")
 	defer span.End()
 	start := time.Now()
 	defer func() {
 		st := telemetry.StatusFromErr(retErr)
-		telemetry.LedgerFetchTotal.Add(ctx, 1, telemetry.StatusAttr(st))
-		telemetry.LedgerOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("fetch"), telemetry.StatusAttr(st))
+		telemetry.LedgerOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("undelete"), telemetry.StatusAttr(st))
 	}()
-	span.SetAttributes(attribute.Int64("zone_id", zoneID))
-	if page <= 0 || pageSize <= 0 || pageSize > s.config.DataFetchMaxPageSize() {
-		return nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
-	}
+	span.SetAttributes(attribute.Int64("zone_id", zoneID), attribute.String("ledger_id", ledgerID))
 	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
 	}
-	var filterID *string
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
+	}
+	dbOutLedger, err := s.sqlRepo.UndeleteLedger(ctx, tx, zoneID, ledgerID)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+	}
+	return mapLedgerToAgentLedger(dbOutLedger)
+}
+
+// PurgeDeletedLedgers permanently deletes the ledgers soft deleted for longer than the retention.
+func (s SQLiteCentralStoragePAP) PurgeDeletedLedgers(ctx context.Context, retention time.Duration) (_ int64, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.PurgeDeletedLedgers")
+	defer span.End()
+	start := time.Now()
+	defer func() {
+		st := telemetry.StatusFromErr(retErr)
+		telemetry.LedgerOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("purge"), telemetry.StatusAttr(st))
+	}()
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return 0, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
+	}
+	purged, err := s.sqlRepo.PurgeLedgers(ctx, tx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+	}
+	span.SetAttributes(attribute.Int64("purged", purged))
+	return purged, nil
+}
+
+// ledgerFilter maps the fields to the ledgers filter.
+func ledgerFilter(fields map[string]any) (*azrepos.LedgerFilter, error) {
+	filter := &azrepos.LedgerFilter{}
 	if _, ok := fields[pap.FieldLedgerLedgerID]; ok {
 		ledgerID, ok := fields[pap.FieldLedgerLedgerID].(string)
 		if !ok {
 			return nil, fmt.Errorf("storage: invalid client input - ledger id is not valid (ledger id: %s): %w", ledgerID, azstorage.ErrInvalidInput)
 		}
-		filterID = &ledgerID
+		filter.ID = &ledgerID
 	}
-	var filterName *string
 	if _, ok := fields[pap.FieldLedgerName]; ok {
 		ledgerName, ok := fields[pap.FieldLedgerName].(string)
 		if !ok {
 			return nil, fmt.Errorf("storage: invalid client input - ledger name is not valid (ledger name: %s): %w", ledgerName, azstorage.ErrInvalidInput)
 		}
-		filterName = &ledgerName
+		filter.Name = &ledgerName
+	}
+	if _, ok := fields[pap.FieldLedgerNamePrefix]; ok {
+		namePrefix, ok := fields[pap.FieldLedgerNamePrefix].(string)
+		if !ok {
+			return nil, fmt.Errorf("storage: invalid client input - ledger name prefix is not valid (prefix: %s): %w", namePrefix, azstorage.ErrInvalidInput)
+		}
+		filter.NamePrefix = &namePrefix
 	}
-	dbLedgers, err := s.sqlRepo.FetchLedgers(ctx, db, page, pageSize, zoneID, filterID, filterName)
+	if _, ok := fields[pap.FieldLedgerDeleted]; ok {
+		deleted, ok := fields[pap.FieldLedgerDeleted].(bool)
+		if !ok {
+			return nil, fmt.Errorf("storage: invalid client input - ledger deleted filter is not valid: %w", azstorage.ErrInvalidInput)
+		}
+		filter.Deleted = deleted
+	}
+	filterLabels, err := labelsFilter(fields, pap.FieldLedgerLabels)
 	if err != nil {
 		return nil, err
 	}
+	filter.Labels = filterLabels
+	return filter, nil
+}
+
+// FetchLedgers returns all ledgers.
+func (s SQLiteCentralStoragePAP) FetchLedgers(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any) ([]pap.Ledger, error) {
+	ledgers, _, err := s.fetchLedgers(ctx, page, pageSize, zoneID, fields, nil, false)
+	return ledgers, err
+}
+
+// FetchLedgersPage returns a page of ledgers sorted and resumed as per the options, together with the page info.
+func (s SQLiteCentralStoragePAP) FetchLedgersPage(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any, options *models.FetchOptions) ([]pap.Ledger, *models.FetchPageInfo, error) {
+	return s.fetchLedgers(ctx, page, pageSize, zoneID, fields, options, true)
+}
+
+// fetchLedgers returns the ledgers and, when requested, the page info.
+func (s SQLiteCentralStoragePAP) fetchLedgers(ctx context.Context, page int32, pageSize int32, zoneID int64, fields map[string]any, options *models.FetchOptions, withPageInfo bool) (_ []pap.Ledger, _ *models.FetchPageInfo, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.FetchLedgers")
+	defer span.End()
+	start := time.Now()
+	defer func() {
+		st := telemetry.StatusFromErr(retErr)
+		telemetry.LedgerFetchTotal.Add(ctx, 1, telemetry.StatusAttr(st))
+		telemetry.LedgerOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("fetch"), telemetry.StatusAttr(st))
+	}()
+	span.SetAttributes(attribute.Int64("zone_id", zoneID))
+	if page <= 0 || pageSize <= 0 || pageSize > s.config.DataFetchMaxPageSize() {
+		return nil, nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
+	}
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return nil, nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	filter, err := ledgerFilter(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	repoOptions, cursor, err := mapFetchOptions(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cursor != nil {
+		repoOptions.AfterID = cursor.ID
+	}
+	dbLedgers, err := s.sqlRepo.FetchLedgers(ctx, db, page, pageSize, zoneID, filter, repoOptions)
+	if err != nil {
+		return nil, nil, err
+	}
 	ledgers := make([]pap.Ledger, len(dbLedgers))
 	for i, a := range dbLedgers {
 		ledger, err := mapLedgerToAgentLedger(&a)
 		if err != nil {
-			return nil, fmt.Errorf("storage: failed to convert ledger entity (%s): %w", azrepos.LogLedgerEntry(&a), azstorage.ErrInternal)
+			return nil, nil, fmt.Errorf("storage: failed to convert ledger entity (%s): %w", azrepos.LogLedgerEntry(&a), azstorage.ErrInternal)
 		}
 		ledgers[i] = *ledger
 	}
 	span.SetAttributes(attribute.Int("result_count", len(ledgers)))
-	return ledgers, nil
+	if !withPageInfo {
+		return ledgers, nil, nil
+	}
+	pageInfo := &models.FetchPageInfo{}
+	pageInfo.TotalCount, err = s.sqlRepo.CountLedgers(ctx, db, zoneID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(dbLedgers) == int(pageSize) {
+		last := dbLedgers[len(dbLedgers)-1]
+		sortValue := cursorSortValue(options, last.Name, last.CreatedAt, last.UpdatedAt)
+		pageInfo.NextCursor, err = encodeFetchCursor(options, sortValue, last.LedgerID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return ledgers, pageInfo, nil
 }
@@ -17,8 +17,15 @@
 package centralstorage
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
 // mapLedgerToAgentLedger maps a Ledger to a model Ledger.
@@ -27,13 +34,47 @@ func mapLedgerToAgentLedger(ledger *azrepos.Ledger) (*pap.Ledger, error) {
 	if err != nil {
 		return nil, err
 	}
+	labels, err := mapJSONToLabels(ledger.Labels)
+	if err != nil {
+		return nil, err
+	}
 	return &pap.Ledger{
-		LedgerID:  ledger.LedgerID,
-		CreatedAt: ledger.CreatedAt,
-		UpdatedAt: ledger.UpdatedAt,
-		ZoneID:    ledger.ZoneID,
-		Name:      ledger.Name,
-		Kind:      kind,
-		Ref:       ledger.Ref,
+		LedgerID:    ledger.LedgerID,
+		CreatedAt:   ledger.CreatedAt,
+		UpdatedAt:   ledger.UpdatedAt,
+		ZoneID:      ledger.ZoneID,
+		Name:        ledger.Name,
+		Kind:        kind,
+		Ref:         ledger.Ref,
+		TrustedKeys: splitTrustedKeys(ledger.TrustedKeys),
+		Description: mapDescription(ledger.Description),
+		Labels:      labels,
+		DeletedAt:   ledger.DeletedAt,
 	}, nil
 }
+
+// splitTrustedKeys splits the trusted keys stored one per line.
+func splitTrustedKeys(trustedKeys string) []string {
+	var keys []string
+	for key := range strings.SplitSeq(trustedKeys, "\n") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// joinTrustedKeys normalizes the trusted keys to the authorized keys format and joins them one per line.
+func joinTrustedKeys(trustedKeys []string) (string, error) {
+	keys := make([]string, 0, len(trustedKeys))
+	for _, trustedKey := range trustedKeys {
+		key, err := objects.NormalizeTrustedKey(trustedKey)
+		if err != nil {
+			return "", fmt.Errorf("storage: invalid client input - trusted key is not valid: %w", errors.Join(azstorage.ErrInvalidInput, err))
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return strings.Join(keys, "\n"), nil
+}
@@ -25,8 +25,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/pap"
 	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
 // TestCreateLedgerWithErrors tests the CreateLedger function with errors.
@@ -196,6 +199,44 @@ func TestUpdateLedgerWithSuccess(t *testing.T) {
 	assert.Equal(dbOutLedger.UpdatedAt, outLedgers.UpdatedAt, "updated at should be equal")
 }
 
+// TestUpdateLedgerTrustedKeys tests that the UpdateLedger function normalizes and replaces the trusted keys.
+func TestUpdateLedgerTrustedKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLitePAPCentralStorageWithMocks()
+
+	trustedKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHiqRThVKcxPSONSZqHkMCYTFfTkDBkzc3Hp0JkH1F2B"
+	dbOutLedger := &azrepos.Ledger{
+		ZoneID:      232956849236,
+		LedgerID:    azrepos.GenerateUUID(),
+		Name:        "rent-a-car1",
+		TrustedKeys: trustedKey,
+	}
+
+	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+	mockSQLDB.ExpectBegin()
+	mockSQLRepo.On("UpdateLedgerTrustedKeys", mock.Anything, dbOutLedger.ZoneID, dbOutLedger.LedgerID, trustedKey).Return(nil)
+	mockSQLRepo.On("UpsertLedger", mock.Anything, false, mock.Anything).Return(dbOutLedger, nil)
+	mockSQLDB.ExpectCommit().WillReturnError(nil)
+
+	inLedger := &pap.Ledger{
+		ZoneID:      dbOutLedger.ZoneID,
+		LedgerID:    dbOutLedger.LedgerID,
+		Name:        dbOutLedger.Name,
+		TrustedKeys: []string{trustedKey + " ci@permguard.com", trustedKey},
+	}
+	outLedger, err := storage.UpdateLedger(t.Context(), inLedger)
+	require.NoError(t, err, "error should be nil")
+	assert.Equal([]string{trustedKey}, outLedger.TrustedKeys, "trusted keys should be equal")
+	mockSQLRepo.AssertExpectations(t)
+
+	inLedger.TrustedKeys = []string{"not a key"}
+	mockSQLDB.ExpectBegin()
+	mockSQLDB.ExpectRollback()
+	_, err = storage.UpdateLedger(t.Context(), inLedger)
+	assert.ErrorIs(err, azstorage.ErrInvalidInput, "invalid trusted keys should be rejected")
+}
+
 // TestDeleteLedgerWithErrors tests the DeleteLedger function with errors.
 func TestDeleteLedgerWithErrors(t *testing.T) {
 	assert := assert.New(t)
@@ -231,7 +272,7 @@ func TestDeleteLedgerWithErrors(t *testing.T) {
 		}
 
 		inLedgerID := azrepos.GenerateUUID()
-		outLedgers, err := storage.DeleteLedger(t.Context(), azrepos.GenerateZoneID(), inLedgerID)
+		outLedgers, err := storage.DeleteLedger(t.Context(), azrepos.GenerateZoneID(), inLedgerID, &models.DeleteOptions{Force: true})
 		assert.Nil(outLedgers, "ledgers should be nil")
 		require.Error(t, err)
 		if multi, ok := err.(interface{ Unwrap() []error }); ok {
@@ -258,11 +299,12 @@ func TestDeleteLedgerWithSuccess(t *testing.T) {
 
 	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
 	mockSQLDB.ExpectBegin()
+	mockSQLRepo.On("ReadLedger", mock.Anything, mock.Anything, mock.Anything, false).Return(&azrepos.Ledger{Name: dbOutLedger.Name, Ref: objects.ZeroOID}, nil)
 	mockSQLRepo.On("DeleteLedger", mock.Anything, mock.Anything, mock.Anything).Return(dbOutLedger, nil)
 	mockSQLDB.ExpectCommit().WillReturnError(nil)
 
 	inLedgerID := azrepos.GenerateUUID()
-	outLedgers, err := storage.DeleteLedger(t.Context(), azrepos.GenerateZoneID(), inLedgerID)
+	outLedgers, err := storage.DeleteLedger(t.Context(), azrepos.GenerateZoneID(), inLedgerID, nil)
 	require.NoError(t, err, "error should be nil")
 	assert.NotNil(outLedgers, "ledgers should not be nil")
 	assert.Equal(dbOutLedger.LedgerID, outLedgers.LedgerID, "ledger id should be equal")
@@ -271,6 +313,71 @@ func TestDeleteLedgerWithSuccess(t *testing.T) {
 	assert.Equal(dbOutLedger.UpdatedAt, outLedgers.UpdatedAt, "updated at should be equal")
 }
 
+// TestDeleteLedgerWithConfirmation tests the DeleteLedger function on a ledger holding policy history.
+func TestDeleteLedgerWithConfirmation(t *testing.T) {
+	assert := assert.New(t)
+
+	dbOutLedger := &azrepos.Ledger{
+		ZoneID:    232956849236,
+		LedgerID:  azrepos.GenerateUUID(),
+		Name:      "rent-a-car1",
+		Ref:       "bafyreigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	{ // Test without confirmation
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLitePAPCentralStorageWithMocks()
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLDB.ExpectBegin()
+		mockSQLRepo.On("ReadLedger", mock.Anything, dbOutLedger.ZoneID, dbOutLedger.LedgerID, false).Return(dbOutLedger, nil)
+		mockSQLDB.ExpectRollback()
+
+		outLedger, err := storage.DeleteLedger(t.Context(), dbOutLedger.ZoneID, dbOutLedger.LedgerID, nil)
+		assert.Nil(outLedger, "ledger should be nil")
+		require.ErrorIs(t, err, azstorage.ErrConfirmationRequired)
+		mockSQLRepo.AssertNotCalled(t, "DeleteLedger", mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	{ // Test with the ledger name as confirmation
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLitePAPCentralStorageWithMocks()
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLDB.ExpectBegin()
+		mockSQLRepo.On("ReadLedger", mock.Anything, dbOutLedger.ZoneID, dbOutLedger.LedgerID, false).Return(dbOutLedger, nil)
+		mockSQLRepo.On("DeleteLedger", mock.Anything, dbOutLedger.ZoneID, dbOutLedger.LedgerID).Return(dbOutLedger, nil)
+		mockSQLDB.ExpectCommit()
+
+		outLedger, err := storage.DeleteLedger(t.Context(), dbOutLedger.ZoneID, dbOutLedger.LedgerID, &models.DeleteOptions{Confirmation: dbOutLedger.Name})
+		require.NoError(t, err)
+		assert.Equal(dbOutLedger.LedgerID, outLedger.LedgerID, "ledger id should be equal")
+	}
+}
+
+// TestUndeleteLedgerWithSuccess tests the UndeleteLedger function with success.
+func TestUndeleteLedgerWithSuccess(t *testing.T) {
+	assert := assert.New(t)
+
+	storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLitePAPCentralStorageWithMocks()
+
+	dbOutLedger := &azrepos.Ledger{
+		ZoneID:    232956849236,
+		LedgerID:  azrepos.GenerateUUID(),
+		Name:      "rent-a-car1",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+	mockSQLDB.ExpectBegin()
+	mockSQLRepo.On("UndeleteLedger", mock.Anything, dbOutLedger.ZoneID, dbOutLedger.LedgerID).Return(dbOutLedger, nil)
+	mockSQLDB.ExpectCommit()
+
+	outLedger, err := storage.UndeleteLedger(t.Context(), dbOutLedger.ZoneID, dbOutLedger.LedgerID)
+	require.NoError(t, err)
+	assert.Equal(dbOutLedger.LedgerID, outLedger.LedgerID, "ledger id should be equal")
+	assert.Nil(outLedger.DeletedAt, "deleted at should be nil")
+}
+
 // TestFetchLedgerWithErrors tests the FetchLedger function with errors.
 func TestFetchLedgerWithErrors(t *testing.T) {
 	assert := assert.New(t)
@@ -19,8 +19,11 @@ package centralstorage
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/attribute"
 
 	azstorage "github.com/permguard/permguard/pkg/agents/storage"
@@ -117,16 +120,19 @@ func (s SQLiteCentralStoragePAP) PullNegotiate(ctx context.Context, req *pap.Pul
 	if req.ZoneID <= 0 {
 		return nil, fmt.Errorf("storage: invalid zone id: %w", azstorage.ErrInvalidInput)
 	}
+	if len(req.HaveCommitIDs) > pap.MaxHaveCommits {
+		return nil, fmt.Errorf("storage: too many have commits, maximum is %d: %w", pap.MaxHaveCommits, azstorage.ErrInvalidInput)
+	}
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return nil, err
+	}
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
 	commitIDs := []string{}
 	if req.LocalCommitID != req.RemoteCommitID {
-		objMng, err := objects.NewObjectManager()
-		if err != nil {
-			return nil, err
-		}
-		db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
-		if err != nil {
-			return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
-		}
 		_, history, err := objMng.BuildCommitHistory(req.RemoteCommitID, req.LocalCommitID, true, func(oid string) (*objects.Object, error) {
 			return s.readObject(ctx, db, req.ZoneID, oid)
 		})
@@ -141,14 +147,78 @@ func (s SQLiteCentralStoragePAP) PullNegotiate(ctx context.Context, req *pap.Pul
 			commitIDs = append(commitIDs, obj.OID())
 		}
 	}
-	span.SetAttributes(attribute.Int("commits_count", len(commitIDs)))
+	// The local commit of the client is held by definition, the advertised ones are kept only if they are commits of the zone.
+	haveCommitIDs := []string{}
+	candidates := req.HaveCommitIDs
+	if req.LocalCommitID != "" && req.LocalCommitID != objects.ZeroOID {
+		candidates = append([]string{req.LocalCommitID}, candidates...)
+	}
+	for _, haveCommitID := range candidates {
+		if slices.Contains(haveCommitIDs, haveCommitID) {
+			continue
+		}
+		haveObj, err := s.readObject(ctx, db, req.ZoneID, haveCommitID)
+		if err != nil {
+			return nil, err
+		}
+		if haveObj == nil {
+			continue
+		}
+		if haveObjInfo, err := objMng.ObjectInfo(haveObj); err != nil || haveObjInfo.Type() != objects.ObjectTypeCommit {
+			continue
+		}
+		haveCommitIDs = append(haveCommitIDs, haveCommitID)
+	}
+	span.SetAttributes(attribute.Int("commits_count", len(commitIDs)), attribute.Int("have_commits_count", len(haveCommitIDs)))
 	return &pap.PullNegotiateResponse{
-		CommitIDs: commitIDs,
+		CommitIDs:     commitIDs,
+		HaveCommitIDs: haveCommitIDs,
 	}, nil
 }
 
-// collectObjectsForCommit collects all objects for a given commit.
-func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zoneID int64, commitID string) ([]pap.ObjectState, error) {
+// collectHeldObjects collects the oids of the objects reachable from the commits already held by the client.
+func (s SQLiteCentralStoragePAP) collectHeldObjects(ctx context.Context, db *sqlx.DB, objMng *objects.ObjectManager, zoneID int64, commitIDs []string) (map[string]struct{}, error) {
+	held := map[string]struct{}{}
+	for _, commitID := range commitIDs {
+		commitObj, err := s.readObject(ctx, db, zoneID, commitID)
+		if err != nil {
+			return nil, err
+		}
+		if commitObj == nil {
+			continue
+		}
+		commit, err := GetObjectForType[objects.Commit](objMng, commitObj)
+		if err != nil {
+			return nil, err
+		}
+		held[commitObj.OID()] = struct{}{}
+		if manifestOID := commit.Manifest().String(); manifestOID != "" && manifestOID != objects.ZeroOID {
+			held[manifestOID] = struct{}{}
+		}
+		for _, profile := range commit.Profiles() {
+			treeObj, err := s.readObject(ctx, db, zoneID, profile.Tree().String())
+			if err != nil {
+				return nil, err
+			}
+			if treeObj == nil {
+				continue
+			}
+			tree, err := GetObjectForType[objects.Tree](objMng, treeObj)
+			if err != nil {
+				return nil, err
+			}
+			held[treeObj.OID()] = struct{}{}
+			for _, entry := range tree.Entries() {
+				held[entry.OID()] = struct{}{}
+			}
+		}
+	}
+	return held, nil
+}
+
+// collectObjectsForCommit collects the objects for a given commit, skipping the ones already held by the client.
+// The held set is filled from the have commits when empty, and the collected objects are added to it.
+func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zoneID int64, commitID string, haveCommitIDs []string, held map[string]struct{}) ([]pap.ObjectState, error) {
 	objMng, err := objects.NewObjectManager()
 	if err != nil {
 		return nil, err
@@ -157,6 +227,29 @@ func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zo
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
 	}
+	if len(held) == 0 {
+		haveObjects, err := s.collectHeldObjects(ctx, db, objMng, zoneID, haveCommitIDs)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(held, haveObjects)
+	}
+	result, err := s.collectMissingObjects(ctx, db, objMng, zoneID, commitID, held)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range result {
+		held[obj.OID] = struct{}{}
+	}
+	return result, nil
+}
+
+// collectMissingObjects collects the objects of a commit which are not in the held set.
+func (s SQLiteCentralStoragePAP) collectMissingObjects(ctx context.Context, db *sqlx.DB, objMng *objects.ObjectManager, zoneID int64, commitID string, held map[string]struct{}) ([]pap.ObjectState, error) {
+	isHeld := func(oid string) bool {
+		_, ok := held[oid]
+		return ok
+	}
 	result := []pap.ObjectState{}
 
 	commitObj, err := s.readObject(ctx, db, zoneID, commitID)
@@ -175,7 +268,7 @@ func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zo
 
 	// Include manifest blob if present
 	manifestOID := commit.Manifest().String()
-	if manifestOID != "" && manifestOID != objects.ZeroOID {
+	if manifestOID != "" && manifestOID != objects.ZeroOID && !isHeld(manifestOID) {
 		manifestObj, err := s.readObject(ctx, db, zoneID, manifestOID)
 		if err != nil {
 			return nil, err
@@ -197,6 +290,9 @@ func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zo
 		if err != nil {
 			return nil, err
 		}
+		if isHeld(treeObj.OID()) {
+			continue
+		}
 		result = append(result, pap.ObjectState{
 			OID:     treeObj.OID(),
 			OType:   objects.ObjectTypeTree,
@@ -204,6 +300,9 @@ func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zo
 		})
 
 		for _, entry := range tree.Entries() {
+			if isHeld(entry.OID()) {
+				continue
+			}
 			obj, err := s.readObject(ctx, db, zoneID, entry.OID())
 			if err != nil {
 				return nil, err
@@ -218,8 +317,8 @@ func (s SQLiteCentralStoragePAP) collectObjectsForCommit(ctx context.Context, zo
 	return result, nil
 }
 
-// PullObjects handles the pull objects step.
-func (s SQLiteCentralStoragePAP) PullObjects(ctx context.Context, req *pap.PullObjectsRequest) (_ *pap.PullObjectsResponse, retErr error) {
+// PullObjects handles the pull objects step, the held set collects the objects held by the client within a pull session.
+func (s SQLiteCentralStoragePAP) PullObjects(ctx context.Context, req *pap.PullObjectsRequest, held map[string]struct{}) (_ *pap.PullObjectsResponse, retErr error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "storage.PullObjects")
 	defer span.End()
 	start := time.Now()
@@ -233,7 +332,10 @@ func (s SQLiteCentralStoragePAP) PullObjects(ctx context.Context, req *pap.PullO
 	if req.ZoneID <= 0 {
 		return nil, fmt.Errorf("storage: invalid zone id: %w", azstorage.ErrInvalidInput)
 	}
-	objs, err := s.collectObjectsForCommit(ctx, req.ZoneID, req.CommitID)
+	if held == nil {
+		held = map[string]struct{}{}
+	}
+	objs, err := s.collectObjectsForCommit(ctx, req.ZoneID, req.CommitID, req.HaveCommitIDs, held)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,184 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package centralstorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+	azmocks "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/testutils/mocks"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
+)
+
+// pullTestStore is an in-memory object store used to mock the key values of a zone.
+type pullTestStore struct {
+	objMng  *objects.ObjectManager
+	objects map[string][]byte
+}
+
+// addBlob adds a blob with the given content and returns its oid.
+func (s *pullTestStore) addBlob(t *testing.T, content string) string {
+	t.Helper()
+	header, err := objects.NewObjectHeader(objects.TreeDataTypePolicy, nil)
+	require.NoError(t, err)
+	obj, err := s.objMng.CreateBlobObject(header, []byte(content))
+	require.NoError(t, err)
+	s.objects[obj.OID()] = obj.Content()
+	return obj.OID()
+}
+
+// addCommit adds a commit with a single profile tree holding the given blobs and returns its oid.
+func (s *pullTestStore) addCommit(t *testing.T, predecessor, manifest string, blobs ...string) string {
+	t.Helper()
+	tree, err := objects.NewTree("default")
+	require.NoError(t, err)
+	for i, blob := range blobs {
+		entry, err := objects.NewTreeEntry(objects.ObjectTypeBlob, blob, "name"+string(rune('a'+i)), objects.TreeDataTypePolicy, map[string]any{objects.MetaKeyCodeID: blob})
+		require.NoError(t, err)
+		require.NoError(t, tree.AddEntry(entry))
+	}
+	treeObj, err := s.objMng.CreateTreeObject(tree)
+	require.NoError(t, err)
+	s.objects[treeObj.OID()] = treeObj.Content()
+	profile, err := objects.NewCommitProfile("default/default", objects.CID(treeObj.OID()))
+	require.NoError(t, err)
+	pred := objects.NullableString{String: predecessor, Valid: predecessor != ""}
+	commit, err := objects.NewCommit([]objects.CommitProfile{*profile}, objects.CID(manifest), pred, "author", time.Now(), "committer", time.Now(), "message")
+	require.NoError(t, err)
+	commitObj, err := s.objMng.CreateCommitObject(commit)
+	require.NoError(t, err)
+	s.objects[commitObj.OID()] = commitObj.Content()
+	return commitObj.OID()
+}
+
+// mock registers the objects of the store as the key values of the zone.
+func (s *pullTestStore) mock(mockSQLRepo *azmocks.MockSqliteRepo) {
+	for oid, content := range s.objects {
+		mockSQLRepo.On("KeyValue", mock.Anything, mock.Anything, oid).Return(&azrepos.KeyValue{Key: oid, Value: content}, nil)
+	}
+	mockSQLRepo.On("KeyValue", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+}
+
+// newPullTestStore creates a new in-memory object store.
+func newPullTestStore(t *testing.T) *pullTestStore {
+	t.Helper()
+	objMng, err := objects.NewObjectManager()
+	require.NoError(t, err)
+	return &pullTestStore{objMng: objMng, objects: map[string][]byte{}}
+}
+
+// pulledOIDs returns the oids of the pulled objects.
+func pulledOIDs(objs []pap.ObjectState) []string {
+	oids := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		oids = append(oids, obj.OID)
+	}
+	return oids
+}
+
+// TestPullNegotiateHaveCommits tests that the PullNegotiate function keeps only the advertised commits held by the zone.
+func TestPullNegotiateHaveCommits(t *testing.T) {
+	assert := assert.New(t)
+
+	storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, _ := createSQLitePAPCentralStorageWithMocks()
+	store := newPullTestStore(t)
+	manifest := store.addBlob(t, "manifest")
+	blobA := store.addBlob(t, "policy a")
+	commit1 := store.addCommit(t, "", manifest, blobA)
+	commit2 := store.addCommit(t, commit1, manifest, blobA, store.addBlob(t, "policy b"))
+	unknown := "bafyreiunknown0000000000000000000000000000000000000000000000000"
+	store.mock(mockSQLRepo)
+	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+
+	resp, err := storage.PullNegotiate(t.Context(), &pap.PullNegotiateRequest{
+		ZoneID:         azrepos.GenerateZoneID(),
+		LedgerID:       azrepos.GenerateUUID(),
+		LocalCommitID:  objects.ZeroOID,
+		RemoteCommitID: commit2,
+		HaveCommitIDs:  []string{unknown, blobA, commit1, commit1},
+	})
+	require.NoError(t, err)
+	assert.Equal([]string{commit1, commit2}, resp.CommitIDs, "all the remote commits should be listed")
+	assert.Equal([]string{commit1}, resp.HaveCommitIDs, "unknown, non commit and duplicated have commits should be filtered")
+
+	resp, err = storage.PullNegotiate(t.Context(), &pap.PullNegotiateRequest{
+		ZoneID:         azrepos.GenerateZoneID(),
+		LedgerID:       azrepos.GenerateUUID(),
+		LocalCommitID:  commit1,
+		RemoteCommitID: commit2,
+		HaveCommitIDs:  []string{unknown},
+	})
+	require.NoError(t, err)
+	assert.Equal([]string{commit1, commit2}, resp.CommitIDs, "the commits from the local one should be listed")
+	assert.Equal([]string{commit1}, resp.HaveCommitIDs, "the local commit should be held")
+
+	_, err = storage.PullNegotiate(t.Context(), &pap.PullNegotiateRequest{
+		ZoneID:        azrepos.GenerateZoneID(),
+		HaveCommitIDs: make([]string, pap.MaxHaveCommits+1),
+	})
+	require.ErrorIs(t, err, azstorage.ErrInvalidInput)
+}
+
+// TestPullObjectsSkipsHeldObjects tests that the PullObjects function leaves out the objects already held by the client.
+func TestPullObjectsSkipsHeldObjects(t *testing.T) {
+	assert := assert.New(t)
+
+	storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, _ := createSQLitePAPCentralStorageWithMocks()
+	store := newPullTestStore(t)
+	manifest := store.addBlob(t, "manifest")
+	blobA := store.addBlob(t, "policy a")
+	blobB := store.addBlob(t, "policy b")
+	blobC := store.addBlob(t, "policy c")
+	commit1 := store.addCommit(t, "", manifest, blobA)
+	commit2 := store.addCommit(t, commit1, manifest, blobA, blobB)
+	commit3 := store.addCommit(t, commit2, manifest, blobA, blobB, blobC)
+	store.mock(mockSQLRepo)
+	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+	zoneID := azrepos.GenerateZoneID()
+
+	resp, err := storage.PullObjects(t.Context(), &pap.PullObjectsRequest{ZoneID: zoneID, CommitID: commit2}, nil)
+	require.NoError(t, err)
+	assert.Len(resp.Objects, 5, "without held commits all the objects should be pulled")
+
+	held := map[string]struct{}{}
+	resp, err = storage.PullObjects(t.Context(), &pap.PullObjectsRequest{ZoneID: zoneID, CommitID: commit2, HaveCommitIDs: []string{commit1}}, held)
+	require.NoError(t, err)
+	oids := pulledOIDs(resp.Objects)
+	assert.Len(oids, 3, "only the commit, the new tree and the new blob should be pulled")
+	assert.Equal(commit2, oids[0], "the commit should be pulled first")
+	assert.Contains(oids, blobB, "the new blob should be pulled")
+	assert.NotContains(oids, blobA, "the held blob should not be pulled")
+	assert.NotContains(oids, manifest, "the held manifest should not be pulled")
+	for _, oid := range oids {
+		assert.Contains(held, oid, "the pulled objects should be added to the held objects")
+	}
+
+	// The held objects of the session are reused in place of the have commits.
+	resp, err = storage.PullObjects(t.Context(), &pap.PullObjectsRequest{ZoneID: zoneID, CommitID: commit3}, held)
+	require.NoError(t, err)
+	oids = pulledOIDs(resp.Objects)
+	assert.Len(oids, 3, "only the commit, the new tree and the new blob should be pulled")
+	assert.Contains(oids, blobC, "the new blob should be pulled")
+	assert.NotContains(oids, blobB, "the blob sent earlier in the session should not be pulled")
+}
@@ -43,7 +43,8 @@ func rollback(tx *sql.Tx, origErr error) error {
 }
 
 // PushAdvertise handles the push advertise step.
-// On success (no conflicts, not up-to-date), it generates a txid and creates a pending push transaction.
+// On success (no conflicts, not up-to-date), it resumes the requested pending push transaction when it
+// still matches the push, otherwise it generates a txid and creates a new pending push transaction.
 func (s SQLiteCentralStoragePAP) PushAdvertise(ctx context.Context, req *pap.PushAdvertiseRequest) (_ *pap.PushAdvertiseResponse, retErr error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "storage.PushAdvertise")
 	defer span.End()
@@ -99,46 +100,92 @@ func (s SQLiteCentralStoragePAP) PushAdvertise(ctx context.Context, req *pap.Pus
 		telemetry.PushConflictsTotal.Add(ctx, 1)
 		span.SetAttributes(attribute.Bool("has_conflicts", true))
 	}
-	// If push is allowed (no conflicts and not up-to-date), create a transaction.
+	// If push is allowed (no conflicts and not up-to-date), resume the pending transaction or create a new one.
 	var txid string
+	var confirmedObjects int64
 	if !hasConflicts && !isUpToDate {
-		txid = azrepos.GenerateUUID()
 		db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
 		if err != nil {
 			return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
 		}
-		tx, err := db.BeginTx(ctx, nil)
-		if err != nil {
-			return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
-		}
-		txn := &azrepos.Transaction{
-			TxID:     txid,
-			LedgerID: req.LedgerID,
-			ZoneID:   req.ZoneID,
-		}
-		if err := s.sqlRepo.CreateTransaction(ctx, tx, txn); err != nil {
-			return nil, rollback(tx, err)
+		logger := s.ctx.Logger()
+		if req.ResumeTxID != "" {
+			txn, err := s.sqlRepo.GetTransaction(ctx, db, req.ResumeTxID)
+			if err != nil && !errors.Is(err, azstorage.ErrNotFound) {
+				return nil, err
+			}
+			// A transaction is resumable only while pending and pushing the same commit on top of the same commit of the same ledger,
+			// the objects staged so far are verified again by the graph integrity check of the commit.
+			if txn != nil && txn.Status == azrepos.TxStatusPending && txn.ZoneID == req.ZoneID &&
+				txn.LedgerID == req.LedgerID && txn.RefPrevCommit == req.RefPrevCommit && txn.RefCommit == req.RefCommit {
+				txid = txn.TxID
+				confirmedObjects = txn.ConfirmedObjects
+				span.SetAttributes(attribute.String("txid", txid), attribute.Int64("confirmed_objects", confirmedObjects))
+				logger.Info("Push session resumed",
+					zap.String("txid", txid),
+					zap.Int64("confirmed_objects", confirmedObjects),
+					zap.String("ledger_id", req.LedgerID),
+					zap.Int64("zone_id", req.ZoneID))
+			}
 		}
-		if err := tx.Commit(); err != nil {
-			return nil, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+		if txid == "" {
+			txid = azrepos.GenerateUUID()
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
+			}
+			txn := &azrepos.Transaction{
+				TxID:          txid,
+				LedgerID:      req.LedgerID,
+				ZoneID:        req.ZoneID,
+				RefPrevCommit: req.RefPrevCommit,
+				RefCommit:     req.RefCommit,
+			}
+			if err := s.sqlRepo.CreateTransaction(ctx, tx, txn); err != nil {
+				return nil, rollback(tx, err)
+			}
+			if err := tx.Commit(); err != nil {
+				return nil, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+			}
+			telemetry.TxCreatedTotal.Add(ctx, 1)
+			span.SetAttributes(attribute.String("txid", txid))
+			logger.Info("Push session started",
+				zap.String("txid", txid),
+				zap.String("ledger_id", req.LedgerID),
+				zap.Int64("zone_id", req.ZoneID))
 		}
-		logger := s.ctx.Logger()
-		telemetry.TxCreatedTotal.Add(ctx, 1)
-		span.SetAttributes(attribute.String("txid", txid))
-		logger.Info("Push session started",
-			zap.String("txid", txid),
-			zap.String("ledger_id", req.LedgerID),
-			zap.Int64("zone_id", req.ZoneID))
 	}
 
 	return &pap.PushAdvertiseResponse{
-		TxID:         txid,
-		ServerCommit: headCommitID,
-		HasConflicts: hasConflicts,
-		IsUpToDate:   isUpToDate,
+		TxID:             txid,
+		ServerCommit:     headCommitID,
+		HasConflicts:     hasConflicts,
+		IsUpToDate:       isUpToDate,
+		ConfirmedObjects: confirmedObjects,
 	}, nil
 }
 
+// upsertPushObjects verifies and stores the objects received in a push.
+func (s SQLiteCentralStoragePAP) upsertPushObjects(ctx context.Context, tx *sql.Tx, zoneID int64, txid string, objs []pap.ObjectState) error {
+	for _, obj := range objs {
+		if err := objects.VerifyOID(obj.OID, obj.Content); err != nil {
+			return fmt.Errorf("storage: received corrupted object %s: %w", obj.OID, err)
+		}
+		if err := objects.ValidateObjectSize(obj.Content, objects.DefaultMaxObjectSize); err != nil {
+			return fmt.Errorf("storage: received oversized object %s: %w", obj.OID, err)
+		}
+		keyValue := &azrepos.KeyValue{
+			ZoneID: zoneID,
+			Key:    obj.OID,
+			Value:  obj.Content,
+		}
+		if _, err := s.sqlRepo.UpsertKeyValue(ctx, tx, keyValue, txid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // markTxFailed marks a transaction as failed. It is best-effort and logs errors.
 func (s SQLiteCentralStoragePAP) markTxFailed(ctx context.Context, txid string) {
 	if txid == "" {
@@ -163,6 +210,42 @@ func (s SQLiteCentralStoragePAP) markTxFailed(ctx context.Context, txid string)
 		zap.String("txid", txid))
 }
 
+// verifyPushSignatures verifies the signatures of the commits pushed on top of the server commit.
+// When the ledger has trusted keys every pushed commit must be signed by one of them,
+// otherwise only the signatures carried by the pushed commits are checked.
+func (s SQLiteCentralStoragePAP) verifyPushSignatures(ctx context.Context, tx *sql.Tx, ledger *pap.Ledger, remoteCommitID, serverCommitID string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.VerifyPushSignatures")
+	defer span.End()
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return err
+	}
+	hasMatch, history, err := objMng.BuildCommitHistory(remoteCommitID, serverCommitID, false, func(oid string) (*objects.Object, error) {
+		return s.readObjectTx(ctx, tx, ledger.ZoneID, oid)
+	})
+	if err != nil {
+		return err
+	}
+	// The server commit closes the history when matched and it has already been verified by a previous push.
+	if hasMatch && len(history) > 0 {
+		history = history[:len(history)-1]
+	}
+	span.SetAttributes(attribute.Int("commits_count", len(history)), attribute.Int("trusted_keys_count", len(ledger.TrustedKeys)))
+	for i := range history {
+		commit := &history[i]
+		metaData := commit.MetaData()
+		if len(ledger.TrustedKeys) > 0 {
+			err = objMng.VerifyTrustedCommit(commit, ledger.TrustedKeys)
+		} else if metaData.Signature() != nil {
+			_, err = objMng.VerifyCommitSignature(commit)
+		}
+		if err != nil {
+			return fmt.Errorf("storage: commit signature rejected for ledger %s: %w", ledger.LedgerID, errors.Join(azstorage.ErrInvalidInput, err))
+		}
+	}
+	return nil
+}
+
 // PushTransfer handles the push transfer step.
 func (s SQLiteCentralStoragePAP) PushTransfer(ctx context.Context, req *pap.PushTransferRequest) (_ *pap.PushTransferResponse, retErr error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "storage.PushTransfer")
@@ -209,30 +292,27 @@ func (s SQLiteCentralStoragePAP) PushTransfer(ctx context.Context, req *pap.Push
 		return nil, fmt.Errorf("storage: %w", err)
 	}
 
+	var ledger *pap.Ledger
+	if req.IsLast {
+		ledger, err = s.readLedger(ctx, req.ZoneID, req.LedgerID)
+		if err != nil {
+			s.markTxFailed(ctx, req.TxID)
+			return nil, err
+		}
+	}
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		s.markTxFailed(ctx, req.TxID)
 		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
 	}
-	for _, obj := range req.Objects {
-		if err := objects.VerifyOID(obj.OID, obj.Content); err != nil {
-			s.markTxFailed(ctx, req.TxID)
-			return nil, rollback(tx, fmt.Errorf("storage: received corrupted object %s: %w", obj.OID, err))
-		}
-		if err := objects.ValidateObjectSize(obj.Content, objects.DefaultMaxObjectSize); err != nil {
-			s.markTxFailed(ctx, req.TxID)
-			return nil, rollback(tx, fmt.Errorf("storage: received oversized object %s: %w", obj.OID, err))
-		}
-		keyValue := &azrepos.KeyValue{
-			ZoneID: req.ZoneID,
-			Key:    obj.OID,
-			Value:  obj.Content,
-		}
-		_, err = s.sqlRepo.UpsertKeyValue(ctx, tx, keyValue, req.TxID)
-		if err != nil {
-			s.markTxFailed(ctx, req.TxID)
-			return nil, rollback(tx, err)
-		}
+	if err := s.upsertPushObjects(ctx, tx, req.ZoneID, req.TxID, req.Objects); err != nil {
+		s.markTxFailed(ctx, req.TxID)
+		return nil, rollback(tx, err)
+	}
+	confirmedObjects, err := s.sqlRepo.AddTransactionConfirmedObjects(ctx, tx, req.TxID, int64(len(req.Objects)))
+	if err != nil {
+		s.markTxFailed(ctx, req.TxID)
+		return nil, rollback(tx, err)
 	}
 	committed := false
 	if req.IsLast {
@@ -253,6 +333,11 @@ func (s SQLiteCentralStoragePAP) PushTransfer(ctx context.Context, req *pap.Push
 			s.markTxFailed(ctx, req.TxID)
 			return nil, fmt.Errorf("storage: graph integrity check failed: %w", err)
 		}
+		if err := s.verifyPushSignatures(ctx, tx, ledger, req.RemoteCommitID, req.ExpectedServerCommit); err != nil {
+			_ = rollback(tx, err)
+			s.markTxFailed(ctx, req.TxID)
+			return nil, err
+		}
 		// Atomic commit: update key_values ref, update ledger ref+txid, mark push committed.
 		err = s.sqlRepo.UpdateLedgerRef(ctx, tx, req.ZoneID, req.LedgerID, req.ExpectedServerCommit, req.RemoteCommitID, req.TxID)
 		if err != nil {
@@ -281,6 +366,90 @@ func (s SQLiteCentralStoragePAP) PushTransfer(ctx context.Context, req *pap.Push
 		}
 	}
 	return &pap.PushTransferResponse{
-		Committed: committed,
+		Committed:        committed,
+		ConfirmedObjects: confirmedObjects,
+	}, nil
+}
+
+// PushCommit publishes the objects staged by a pending push transaction by updating the ledger ref.
+func (s SQLiteCentralStoragePAP) PushCommit(ctx context.Context, req *pap.PushCommitRequest) (_ *pap.PushCommitResponse, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.PushCommit")
+	defer span.End()
+	start := time.Now()
+	defer func() {
+		st := telemetry.StatusFromErr(retErr)
+		telemetry.PushTransferTotal.Add(ctx, 1, telemetry.StatusAttr(st))
+		telemetry.PushDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("commit"), telemetry.StatusAttr(st))
+	}()
+	if req == nil {
+		return nil, fmt.Errorf("storage: nil request: %w", azstorage.ErrInvalidInput)
+	}
+	if req.ZoneID <= 0 {
+		return nil, fmt.Errorf("storage: invalid zone id: %w", azstorage.ErrInvalidInput)
+	}
+	if req.TxID == "" {
+		return nil, fmt.Errorf("storage: txid is required: %w", azstorage.ErrInvalidInput)
+	}
+	if req.RemoteCommitID == "" || req.ExpectedServerCommit == "" {
+		return nil, fmt.Errorf("storage: remote commit and expected server commit are required: %w", azstorage.ErrInvalidInput)
+	}
+	span.SetAttributes(attribute.String("txid", req.TxID))
+
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	txn, err := s.sqlRepo.GetTransaction(ctx, db, req.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("storage: invalid transaction (txid: %s): %w", req.TxID, azstorage.ErrInvalidInput)
+	}
+	if txn.Status != azrepos.TxStatusPending || txn.ZoneID != req.ZoneID || txn.LedgerID != req.LedgerID {
+		return nil, fmt.Errorf("storage: transaction is not pending for the ledger (txid: %s, status: %s): %w", req.TxID, txn.Status, azstorage.ErrInvalidInput)
+	}
+	ledger, err := s.readLedger(ctx, req.ZoneID, req.LedgerID)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
+	}
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := objMng.VerifyCommitGraphIntegrity(req.RemoteCommitID, func(oid string) (*objects.Object, error) {
+		return s.readObjectTx(ctx, tx, req.ZoneID, oid)
+	}); err != nil {
+		_ = rollback(tx, err)
+		s.markTxFailed(ctx, req.TxID)
+		return nil, fmt.Errorf("storage: graph integrity check failed: %w", err)
+	}
+	if err := s.verifyPushSignatures(ctx, tx, ledger, req.RemoteCommitID, req.ExpectedServerCommit); err != nil {
+		_ = rollback(tx, err)
+		s.markTxFailed(ctx, req.TxID)
+		return nil, err
+	}
+	// The ref update fails with a conflict if the ledger moved since the push was advertised.
+	if err := s.sqlRepo.UpdateLedgerRef(ctx, tx, req.ZoneID, req.LedgerID, req.ExpectedServerCommit, req.RemoteCommitID, req.TxID); err != nil {
+		_ = rollback(tx, err)
+		s.markTxFailed(ctx, req.TxID)
+		return nil, err
+	}
+	if err := s.sqlRepo.UpdateTransactionStatus(ctx, tx, req.TxID, azrepos.TxStatusCommitted); err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+	}
+	telemetry.TxCommittedTotal.Add(ctx, 1)
+	logger := s.ctx.Logger()
+	logger.Info("Push session committed",
+		zap.String("txid", req.TxID),
+		zap.Int64("confirmed_objects", txn.ConfirmedObjects),
+		zap.String("ledger_id", req.LedgerID),
+		zap.Int64("zone_id", req.ZoneID))
+	return &pap.PushCommitResponse{
+		Committed: true,
 	}, nil
 }
@@ -0,0 +1,95 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package centralstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/permguard/permguard/pkg/transport/models/pap"
+	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+)
+
+// TestPushAdvertiseResume tests that the PushAdvertise function resumes a pending transaction only for the same commit on the same base.
+func TestPushAdvertiseResume(t *testing.T) {
+	zoneID := azrepos.GenerateZoneID()
+	ledgerID := azrepos.GenerateUUID()
+	baseCommit := "bafyreibase00000000000000000000000000000000000000000000000000000"
+	targetCommit := "bafyreitarget000000000000000000000000000000000000000000000000000"
+	otherCommit := "bafyreiother0000000000000000000000000000000000000000000000000000"
+	resumeTxID := azrepos.GenerateUUID()
+
+	tests := []struct {
+		name          string
+		refPrevCommit string
+		refCommit     string
+		resumed       bool
+	}{
+		{name: "same commit on the same base", refPrevCommit: baseCommit, refCommit: targetCommit, resumed: true},
+		{name: "different commit on the same base", refPrevCommit: baseCommit, refCommit: otherCommit},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, sqlMock := createSQLitePAPCentralStorageWithMocks()
+			mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+			mockSQLRepo.On("FetchLedgers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]azrepos.Ledger{{ZoneID: zoneID, LedgerID: ledgerID, Ref: baseCommit}}, nil)
+			mockSQLRepo.On("GetTransaction", sqlDB, resumeTxID).Return(&azrepos.Transaction{
+				TxID:             resumeTxID,
+				LedgerID:         ledgerID,
+				ZoneID:           zoneID,
+				Status:           azrepos.TxStatusPending,
+				RefPrevCommit:    baseCommit,
+				RefCommit:        targetCommit,
+				ConfirmedObjects: 7,
+			}, nil)
+			var created *azrepos.Transaction
+			if !test.resumed {
+				sqlMock.ExpectBegin()
+				mockSQLRepo.On("CreateTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					created = args.Get(1).(*azrepos.Transaction)
+				}).Return(nil)
+				sqlMock.ExpectCommit()
+			}
+
+			resp, err := storage.PushAdvertise(t.Context(), &pap.PushAdvertiseRequest{
+				ZoneID:        zoneID,
+				LedgerID:      ledgerID,
+				RefCommit:     test.refCommit,
+				RefPrevCommit: test.refPrevCommit,
+				ResumeTxID:    resumeTxID,
+			})
+			require.NoError(t, err)
+			if test.resumed {
+				assert.Equal(resumeTxID, resp.TxID, "the pending transaction should be resumed")
+				assert.Equal(int64(7), resp.ConfirmedObjects, "the confirmed objects should be returned")
+				mockSQLRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NotEqual(resumeTxID, resp.TxID, "a new transaction should be started")
+			assert.Zero(resp.ConfirmedObjects, "a new transaction should not have confirmed objects")
+			require.NotNil(t, created)
+			assert.Equal(resp.TxID, created.TxID, "the new transaction should be stored")
+			assert.Equal(test.refPrevCommit, created.RefPrevCommit, "the base commit should be stored")
+			assert.Equal(test.refCommit, created.RefCommit, "the pushed commit should be stored")
+			assert.NoError(sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -18,10 +18,12 @@ package centralstorage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/agents/telemetry"
@@ -91,37 +93,55 @@ func authorizationCheckReadTrees(ctx context.Context, s *SQLiteCentralStoragePDP
 	return trees, nil
 }
 
-// LoadPolicyStore loads the policy store for a given zone ID and store ID.
-func (s SQLiteCentralStoragePDP) LoadPolicyStore(ctx context.Context, zoneID int64, storeID string) (*authzen.PolicyStore, error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "storage.LoadPolicyStore")
-	defer span.End()
-	span.SetAttributes(attribute.Int64("zone_id", zoneID), attribute.String("store_id", storeID))
-	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
-	if err != nil {
-		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
-	}
-
-	dbLedgers, err := s.sqlRepo.FetchLedgers(ctx, db, 1, 2, zoneID, &storeID, nil)
+// authorizationCheckFetchLedger fetches the ledger used as policy store.
+func authorizationCheckFetchLedger(ctx context.Context, s *SQLiteCentralStoragePDP, db *sqlx.DB, zoneID int64, storeID string) (*azrepos.Ledger, error) {
+	dbLedgers, err := s.sqlRepo.FetchLedgers(ctx, db, 1, 2, zoneID, &azrepos.LedgerFilter{ID: &storeID}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("storage: bad request for either zone id or policy store id: %w", err)
 	}
 	if len(dbLedgers) != 1 {
 		return nil, fmt.Errorf("storage: bad request for either zone id or policy store id: %w", azstorage.ErrNotFound)
 	}
-	ledger := dbLedgers[0]
-	ledgerRef := ledger.Ref
-	if ledgerRef == objects.ZeroOID {
+	if dbLedgers[0].Ref == objects.ZeroOID {
 		return nil, fmt.Errorf("storage: server couldn't validate the ledger reference: %w", azstorage.ErrInvalidInput)
 	}
+	return &dbLedgers[0], nil
+}
 
+// authorizationCheckVerifyCommitSignature verifies that the commit is signed by a key trusted by the ledger.
+func authorizationCheckVerifyCommitSignature(ctx context.Context, s *SQLiteCentralStoragePDP, db *sqlx.DB, ledger *azrepos.Ledger, commitID string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.VerifyPolicyStoreSignature")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("zone_id", ledger.ZoneID), attribute.String("commit_id", commitID))
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return fmt.Errorf("storage: server couldn't create the object manager: %w", azstorage.ErrInternal)
+	}
+	ocontent, err := authorizationCheckReadBytes(ctx, s, db, objMng, ledger.ZoneID, commitID)
+	if err != nil {
+		return fmt.Errorf("storage: server couldn't read the commit %s: %w", commitID, err)
+	}
+	commit, err := objMng.DeserializeCommit(ocontent)
+	if err != nil {
+		return fmt.Errorf("storage: server couldn't deserialize the commit %s: %w", commitID, err)
+	}
+	if err := objMng.VerifyTrustedCommit(commit, splitTrustedKeys(ledger.TrustedKeys)); err != nil {
+		return fmt.Errorf("storage: commit %s is not signed by a key trusted by the policy store: %w", commitID, errors.Join(azstorage.ErrInvalidInput, err))
+	}
+	return nil
+}
+
+// authorizationCheckLoadPolicyStore loads the policy store as of the given commit.
+func authorizationCheckLoadPolicyStore(ctx context.Context, s *SQLiteCentralStoragePDP, db *sqlx.DB, zoneID int64, commitID string) (*authzen.PolicyStore, error) {
+	span := trace.SpanFromContext(ctx)
 	authzPolicyStore := &authzen.PolicyStore{}
-	authzPolicyStore.SetVersion(ledgerRef)
+	authzPolicyStore.SetVersion(commitID)
 
 	objMng, err := objects.NewObjectManager()
 	if err != nil {
 		return nil, fmt.Errorf("storage: server couldn't create the object manager: %w", azstorage.ErrInternal)
 	}
-	trees, err := authorizationCheckReadTrees(ctx, &s, db, objMng, zoneID, ledgerRef)
+	trees, err := authorizationCheckReadTrees(ctx, s, db, objMng, zoneID, commitID)
 	if err != nil {
 		return nil, fmt.Errorf("storage: server couldn't read the trees: %w", err)
 	}
@@ -130,7 +150,7 @@ func (s SQLiteCentralStoragePDP) LoadPolicyStore(ctx context.Context, zoneID int
 		span.SetAttributes(attribute.Int("policy_entries", len(entries)))
 		for _, entry := range entries {
 			entryID := entry.OID()
-			value, err2 := authorizationCheckReadKeyValue(ctx, &s, db, objMng, zoneID, entryID)
+			value, err2 := authorizationCheckReadKeyValue(ctx, s, db, objMng, zoneID, entryID)
 			if err2 != nil {
 				return nil, fmt.Errorf("storage: server couldn't read the key %s: %w", entryID, err2)
 			}
@@ -149,6 +169,12 @@ func (s SQLiteCentralStoragePDP) LoadPolicyStore(ctx context.Context, zoneID int
 				authzPolicyStore.AddSchema(oid, objInfo)
 			case types.ClassTypePolicyID:
 				authzPolicyStore.AddPolicy(oid, objInfo)
+			case types.ClassTypeTemplateID:
+				authzPolicyStore.AddTemplate(oid, objInfo)
+			case types.ClassTypeTemplateLinkID:
+				authzPolicyStore.AddTemplateLink(oid, objInfo)
+			case types.ClassTypeRoleID:
+				authzPolicyStore.AddRole(oid, objInfo)
 			default:
 				return nil, fmt.Errorf("storage: server couldn't process the code type id: %w", azstorage.ErrInternal)
 			}
@@ -156,3 +182,65 @@ func (s SQLiteCentralStoragePDP) LoadPolicyStore(ctx context.Context, zoneID int
 	}
 	return authzPolicyStore, nil
 }
+
+// LoadPolicyStore loads the policy store for a given zone ID and store ID.
+func (s SQLiteCentralStoragePDP) LoadPolicyStore(ctx context.Context, zoneID int64, storeID string) (*authzen.PolicyStore, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.LoadPolicyStore")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("zone_id", zoneID), attribute.String("store_id", storeID))
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	ledger, err := authorizationCheckFetchLedger(ctx, &s, db, zoneID, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if s.config.RequireSignedCommits() {
+		if err := authorizationCheckVerifyCommitSignature(ctx, &s, db, ledger, ledger.Ref); err != nil {
+			return nil, err
+		}
+	}
+	return authorizationCheckLoadPolicyStore(ctx, &s, db, zoneID, ledger.Ref)
+}
+
+// LoadPolicyStoreAtCommit loads the policy store for a given zone ID and store ID as of a historical commit.
+func (s SQLiteCentralStoragePDP) LoadPolicyStoreAtCommit(ctx context.Context, zoneID int64, storeID string, commitID string) (*authzen.PolicyStore, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.LoadPolicyStoreAtCommit")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("zone_id", zoneID), attribute.String("store_id", storeID), attribute.String("commit_id", commitID))
+	if commitID == "" || commitID == objects.ZeroOID {
+		return nil, fmt.Errorf("storage: invalid commit id: %w", azstorage.ErrInvalidInput)
+	}
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	ledger, err := authorizationCheckFetchLedger(ctx, &s, db, zoneID, storeID)
+	if err != nil {
+		return nil, err
+	}
+	objMng, err := objects.NewObjectManager()
+	if err != nil {
+		return nil, fmt.Errorf("storage: server couldn't create the object manager: %w", azstorage.ErrInternal)
+	}
+	hasMatch, _, err := objMng.BuildCommitHistory(ledger.Ref, commitID, false, func(oid string) (*objects.Object, error) {
+		keyValue, errK := s.sqlRepo.KeyValue(ctx, db, zoneID, oid)
+		if errK != nil || keyValue == nil || keyValue.Value == nil {
+			return nil, nil
+		}
+		return objects.NewObject(keyValue.Value)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to build commit history: %w", err)
+	}
+	if !hasMatch {
+		return nil, fmt.Errorf("storage: commit %s is not part of the ledger history: %w", commitID, azstorage.ErrNotFound)
+	}
+	if s.config.RequireSignedCommits() {
+		if err := authorizationCheckVerifyCommitSignature(ctx, &s, db, ledger, commitID); err != nil {
+			return nil, err
+		}
+	}
+	return authorizationCheckLoadPolicyStore(ctx, &s, db, zoneID, commitID)
+}
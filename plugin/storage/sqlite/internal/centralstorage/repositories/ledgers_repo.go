@@ -19,8 +19,10 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/attribute"
@@ -98,17 +100,23 @@ func (r *Repository) UpsertLedger(ctx context.Context, tx *sql.Tx, isCreate bool
 	var result sql.Result
 	var err error
 	if isCreate {
+		if _, err := r.ReadZone(ctx, tx, zoneID, false); err != nil {
+			return nil, err
+		}
 		ledgerID = GenerateUUID()
-		result, err = tx.ExecContext(ctx, "INSERT INTO ledgers (zone_id, ledger_id, kind, name) VALUES (?, ?, ?, ?)", zoneID, ledgerID, ledgerKind, ledgerName)
+		result, err = tx.ExecContext(ctx, "INSERT INTO ledgers (zone_id, ledger_id, kind, name, trusted_keys, description, labels) VALUES (?, ?, ?, ?, ?, ?, ?)", zoneID, ledgerID, ledgerKind, ledgerName, ledger.TrustedKeys, valueOrDefault(ledger.Description, ""), valueOrDefault(ledger.Labels, EmptyLabels))
 	} else {
-		result, err = tx.ExecContext(ctx, "UPDATE ledgers SET name = ? WHERE zone_id = ? and ledger_id = ?", ledgerName, zoneID, ledgerID)
+		result, err = tx.ExecContext(ctx, "UPDATE ledgers SET name = ?, description = COALESCE(?, description), labels = COALESCE(?, labels) WHERE zone_id = ? and ledger_id = ? AND deleted_at IS NULL", ledgerName, ledger.Description, ledger.Labels, zoneID, ledgerID)
 	}
 	if err != nil || result == nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to %s ledger - operation '%s-ledger' encountered an issue (%s)", action, action, LogLedgerEntry(ledger)), err)
 	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, fmt.Errorf("storage: ledger not found (zone_id: %d, ledger_id: %s): %w", zoneID, ledgerID, azstorage.ErrNotFound)
+	}
 
 	var dbLedger Ledger
-	err = tx.QueryRowContext(ctx, "SELECT zone_id, ledger_id, created_at, updated_at, kind, name, ref, txid FROM ledgers WHERE zone_id = ? and ledger_id = ?", zoneID, ledgerID).Scan(
+	err = tx.QueryRowContext(ctx, "SELECT zone_id, ledger_id, created_at, updated_at, kind, name, ref, txid, trusted_keys, description, labels FROM ledgers WHERE zone_id = ? and ledger_id = ?", zoneID, ledgerID).Scan(
 		&dbLedger.ZoneID,
 		&dbLedger.LedgerID,
 		&dbLedger.CreatedAt,
@@ -117,6 +125,9 @@ func (r *Repository) UpsertLedger(ctx context.Context, tx *sql.Tx, isCreate bool
 		&dbLedger.Name,
 		&dbLedger.Ref,
 		&dbLedger.TxID,
+		&dbLedger.TrustedKeys,
+		&dbLedger.Description,
+		&dbLedger.Labels,
 	)
 	if err != nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to retrieve ledger - operation 'retrieve-created-ledger' encountered an issue (%s)", LogLedgerEntry(ledger)), err)
@@ -144,7 +155,7 @@ func (r *Repository) UpdateLedgerRef(ctx context.Context, tx *sql.Tx, zoneID int
 	}
 
 	var dbCurrentRef string
-	err := tx.QueryRowContext(ctx, "SELECT ref FROM ledgers WHERE zone_id = ? AND ledger_id = ?", zoneID, ledgerID).Scan(&dbCurrentRef)
+	err := tx.QueryRowContext(ctx, "SELECT ref FROM ledgers WHERE zone_id = ? AND ledger_id = ? AND deleted_at IS NULL", zoneID, ledgerID).Scan(&dbCurrentRef)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("storage: ledger not found (zone_id: %d, ledger_id: %s): %w", zoneID, ledgerID, azstorage.ErrNotFound)
@@ -171,9 +182,34 @@ func (r *Repository) UpdateLedgerRef(ctx context.Context, tx *sql.Tx, zoneID int
 	return nil
 }
 
-// DeleteLedger deletes a ledger.
-func (r *Repository) DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*Ledger, error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "db.DeleteLedger")
+// UpdateLedgerTrustedKeys replaces the keys trusted to sign the commits of a ledger.
+func (r *Repository) UpdateLedgerTrustedKeys(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID, trustedKeys string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.UpdateLedgerTrustedKeys")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID), attribute.String("db.ledger_id", ledgerID))
+	if err := validators.ValidateCodeID(LedgerType, zoneID); err != nil {
+		return fmt.Errorf(errorMessageLedgerInvalidZoneID+": %w", zoneID, azstorage.ErrInvalidInput)
+	}
+	if err := validators.ValidateUUID(LedgerType, ledgerID); err != nil {
+		return fmt.Errorf("storage: invalid client input - ledger id is not valid (id: %s): %w", ledgerID, azstorage.ErrInvalidInput)
+	}
+	result, err := tx.ExecContext(ctx, "UPDATE ledgers SET trusted_keys = ? WHERE zone_id = ? AND ledger_id = ? AND deleted_at IS NULL", trustedKeys, zoneID, ledgerID)
+	if err != nil {
+		return WrapSqliteError("failed to update ledger trusted keys", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return WrapSqliteError("failed to get rows affected for update trusted keys", err)
+	}
+	if rows != 1 {
+		return fmt.Errorf("storage: ledger not found (zone_id: %d, ledger_id: %s): %w", zoneID, ledgerID, azstorage.ErrNotFound)
+	}
+	return nil
+}
+
+// ReadLedger reads a ledger, soft deleted ledgers are read only when deleted is true.
+func (r *Repository) ReadLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string, deleted bool) (*Ledger, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.ReadLedger")
 	defer span.End()
 	span.SetAttributes(attribute.Int64("db.zone_id", zoneID), attribute.String("db.ledger_id", ledgerID))
 	if err := validators.ValidateCodeID(LedgerType, zoneID); err != nil {
@@ -183,8 +219,12 @@ func (r *Repository) DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64,
 		return nil, fmt.Errorf("storage: invalid client input - ledger id is not valid (id: %s): %w", ledgerID, azstorage.ErrInvalidInput)
 	}
 
+	deletedCond := "deleted_at IS NULL"
+	if deleted {
+		deletedCond = "deleted_at IS NOT NULL"
+	}
 	var dbLedger Ledger
-	err := tx.QueryRowContext(ctx, "SELECT zone_id, ledger_id, created_at, updated_at, kind, name, ref, txid FROM ledgers WHERE zone_id = ? and ledger_id = ?", zoneID, ledgerID).Scan(
+	err := tx.QueryRowContext(ctx, "SELECT zone_id, ledger_id, created_at, updated_at, kind, name, ref, txid, trusted_keys, description, labels, deleted_at FROM ledgers WHERE zone_id = ? and ledger_id = ? AND "+deletedCond, zoneID, ledgerID).Scan(
 		&dbLedger.ZoneID,
 		&dbLedger.LedgerID,
 		&dbLedger.CreatedAt,
@@ -193,6 +233,10 @@ func (r *Repository) DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64,
 		&dbLedger.Name,
 		&dbLedger.Ref,
 		&dbLedger.TxID,
+		&dbLedger.TrustedKeys,
+		&dbLedger.Description,
+		&dbLedger.Labels,
+		&dbLedger.DeletedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -200,7 +244,18 @@ func (r *Repository) DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64,
 		}
 		return nil, WrapSqliteError(fmt.Sprintf("failed to retrieve ledger (id: %s)", ledgerID), err)
 	}
-	res, err := tx.ExecContext(ctx, "DELETE FROM ledgers WHERE zone_id = ? and ledger_id = ?", zoneID, ledgerID)
+	return &dbLedger, nil
+}
+
+// DeleteLedger soft deletes a ledger, its history is kept until the ledger is purged.
+func (r *Repository) DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*Ledger, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.DeleteLedger")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID), attribute.String("db.ledger_id", ledgerID))
+	if _, err := r.ReadLedger(ctx, tx, zoneID, ledgerID, false); err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, "UPDATE ledgers SET deleted_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW') WHERE zone_id = ? and ledger_id = ? AND deleted_at IS NULL", zoneID, ledgerID)
 	if err != nil || res == nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to delete ledger - operation 'delete-ledger' encountered an issue (id: %s)", ledgerID), err)
 	}
@@ -208,54 +263,145 @@ func (r *Repository) DeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64,
 	if err != nil || rows != 1 {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to delete ledger - operation 'delete-ledger' could not find the ledger (id: %s)", ledgerID), err)
 	}
-	return &dbLedger, nil
+	return r.ReadLedger(ctx, tx, zoneID, ledgerID, true)
 }
 
-// FetchLedgers retrieves ledgers.
-func (r *Repository) FetchLedgers(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, zoneID int64, filterID *string, filterName *string) ([]Ledger, error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "db.FetchLedgers")
+// UndeleteLedger restores a soft deleted ledger.
+func (r *Repository) UndeleteLedger(ctx context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*Ledger, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.UndeleteLedger")
 	defer span.End()
-	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
-	if page <= 0 || pageSize <= 0 {
-		return nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID), attribute.String("db.ledger_id", ledgerID))
+	if _, err := r.ReadZone(ctx, tx, zoneID, false); err != nil {
+		return nil, err
 	}
-	if err := validators.ValidateCodeID(LedgerType, zoneID); err != nil {
-		return nil, fmt.Errorf(errorMessageLedgerInvalidZoneID+": %w", zoneID, azstorage.ErrInvalidInput)
+	if _, err := r.ReadLedger(ctx, tx, zoneID, ledgerID, true); err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, "UPDATE ledgers SET deleted_at = NULL WHERE zone_id = ? and ledger_id = ? AND deleted_at IS NOT NULL", zoneID, ledgerID)
+	if err != nil || res == nil {
+		if err != nil && errors.Is(classifyError(err), azstorage.ErrAlreadyExists) {
+			return nil, fmt.Errorf("storage: ledger name is in use by another ledger of the zone, rename it before undeleting (id: %s): %w", ledgerID, azstorage.ErrAlreadyExists)
+		}
+		return nil, WrapSqliteError(fmt.Sprintf("failed to undelete ledger - operation 'undelete-ledger' encountered an issue (id: %s)", ledgerID), err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil || rows != 1 {
+		return nil, WrapSqliteError(fmt.Sprintf("failed to undelete ledger - operation 'undelete-ledger' could not find the ledger (id: %s)", ledgerID), err)
 	}
+	return r.ReadLedger(ctx, tx, zoneID, ledgerID, false)
+}
 
-	var dbLedgers []Ledger
+// PurgeLedgers permanently deletes the ledgers soft deleted before the given time.
+func (r *Repository) PurgeLedgers(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) (int64, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.PurgeLedgers")
+	defer span.End()
+	res, err := tx.ExecContext(ctx, "DELETE FROM ledgers WHERE deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore.UTC().Format(TimestampLayout))
+	if err != nil || res == nil {
+		return 0, WrapSqliteError("failed to purge ledgers - operation 'purge-ledgers' encountered an issue", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, WrapSqliteError("failed to purge ledgers - operation 'purge-ledgers' encountered an issue", err)
+	}
+	span.SetAttributes(attribute.Int64("db.purged_count", rows))
+	return rows, nil
+}
 
-	baseQuery := "SELECT * FROM ledgers"
-	var conditions []string
-	var args []any
+// CountLedgersWithHistory counts the live ledgers of a zone whose ref is not the given empty ref.
+func (r *Repository) CountLedgersWithHistory(ctx context.Context, tx *sql.Tx, zoneID int64, emptyRef string) (int64, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.CountLedgersWithHistory")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
+	var count int64
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ledgers WHERE zone_id = ? AND ref <> ? AND deleted_at IS NULL", zoneID, emptyRef).Scan(&count)
+	if err != nil {
+		return 0, WrapSqliteError(fmt.Sprintf("failed to count ledgers - operation 'count-ledgers-with-history' encountered an issue (zone id: %d)", zoneID), err)
+	}
+	return count, nil
+}
+
+// LedgerFilter holds the filters of a ledgers fetch.
+type LedgerFilter struct {
+	// ID matches the ledger with the given id.
+	ID *string
+	// Name matches the ledgers whose name contains the value.
+	Name *string
+	// NamePrefix matches the ledgers whose name starts with the value.
+	NamePrefix *string
+	// Labels matches the ledgers carrying all the given labels.
+	Labels map[string]string
+	// Deleted matches the soft deleted ledgers instead of the live ones.
+	Deleted bool
+}
 
-	conditions = append(conditions, "zone_id = ?")
-	args = append(args, zoneID)
+// ledgerConditions builds the conditions matching the ledgers of a zone and the ledger filter.
+func ledgerConditions(zoneID int64, filter *LedgerFilter) ([]string, []any, error) {
+	if err := validators.ValidateCodeID(LedgerType, zoneID); err != nil {
+		return nil, nil, fmt.Errorf(errorMessageLedgerInvalidZoneID+": %w", zoneID, azstorage.ErrInvalidInput)
+	}
+	conditions := []string{"zone_id = ?", "deleted_at IS NULL", "zone_id IN (SELECT zone_id FROM zones WHERE deleted_at IS NULL)"}
+	args := []any{zoneID}
+	if filter == nil {
+		return conditions, args, nil
+	}
+	if filter.Deleted {
+		conditions[1] = "deleted_at IS NOT NULL"
+	}
 
-	if filterID != nil {
-		ledgerID := *filterID
+	if filter.ID != nil {
+		ledgerID := *filter.ID
 		if err := validators.ValidateUUID(LedgerType, ledgerID); err != nil {
-			return nil, fmt.Errorf("storage: invalid client input - ledger id is not valid (id: %s): %w", ledgerID, azstorage.ErrInvalidInput)
+			return nil, nil, fmt.Errorf("storage: invalid client input - ledger id is not valid (id: %s): %w", ledgerID, azstorage.ErrInvalidInput)
 		}
 		conditions = append(conditions, "ledger_id = ?")
 		args = append(args, ledgerID)
 	}
 
-	if filterName != nil {
-		ledgerName := *filterName
-		if err := validators.ValidateName(LedgerType, ledgerName); err != nil {
-			return nil, fmt.Errorf("storage: invalid client input - ledger name is not valid (name: %s): %w", ledgerName, azstorage.ErrInvalidInput)
-		}
-		ledgerName = "%" + ledgerName + "%"
-		conditions = append(conditions, "name LIKE ?")
-		args = append(args, ledgerName)
+	nameConds, nameArgs, err := nameConditions(LedgerType, filter.Name, filter.NamePrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	conditions = append(conditions, nameConds...)
+	args = append(args, nameArgs...)
+
+	labelConds, labelArgs, err := labelConditions(LedgerType, filter.Labels)
+	if err != nil {
+		return nil, nil, err
+	}
+	conditions = append(conditions, labelConds...)
+	args = append(args, labelArgs...)
+	return conditions, args, nil
+}
+
+// FetchLedgers retrieves ledgers.
+func (r *Repository) FetchLedgers(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, zoneID int64, filter *LedgerFilter, options *FetchOptions) ([]Ledger, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.FetchLedgers")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
+	if page <= 0 || pageSize <= 0 {
+		return nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
 	}
 
-	if len(conditions) > 0 {
-		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	var dbLedgers []Ledger
+
+	baseQuery := "SELECT * FROM ledgers"
+	conditions, args, err := ledgerConditions(zoneID, filter)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, keysetCond, keysetArgs, err := options.orderAndKeyset("ledger_id")
+	if err != nil {
+		return nil, err
 	}
+	if keysetCond != "" {
+		conditions = append(conditions, keysetCond)
+		args = append(args, keysetArgs...)
+		page = 1
+	}
+
+	baseQuery += " WHERE " + strings.Join(conditions, " AND ")
 
-	baseQuery += " ORDER BY ledger_id ASC"
+	baseQuery += " ORDER BY " + orderBy
 
 	limit := pageSize
 	offset := (page - 1) * pageSize
@@ -263,7 +409,7 @@ func (r *Repository) FetchLedgers(ctx context.Context, db *sqlx.DB, page int32,
 
 	args = append(args, limit, offset)
 
-	err := db.SelectContext(ctx, &dbLedgers, baseQuery, args...)
+	err = db.SelectContext(ctx, &dbLedgers, baseQuery, args...)
 	if err != nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to retrieve ledgers - operation 'retrieve-ledgers' encountered an issue with parameters %v", args), err)
 	}
@@ -271,3 +417,21 @@ func (r *Repository) FetchLedgers(ctx context.Context, db *sqlx.DB, page int32,
 	span.SetAttributes(attribute.Int("db.result_count", len(dbLedgers)))
 	return dbLedgers, nil
 }
+
+// CountLedgers counts the ledgers of a zone matching the filter.
+func (r *Repository) CountLedgers(ctx context.Context, db *sqlx.DB, zoneID int64, filter *LedgerFilter) (int64, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.CountLedgers")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
+	conditions, args, err := ledgerConditions(zoneID, filter)
+	if err != nil {
+		return 0, err
+	}
+	baseQuery := "SELECT COUNT(*) FROM ledgers WHERE " + strings.Join(conditions, " AND ")
+	var count int64
+	if err := db.GetContext(ctx, &count, baseQuery, args...); err != nil {
+		return 0, WrapSqliteError(fmt.Sprintf("failed to count ledgers - operation 'count-ledgers' encountered an issue with parameters %v", args), err)
+	}
+	span.SetAttributes(attribute.Int64("db.total_count", count))
+	return count, nil
+}
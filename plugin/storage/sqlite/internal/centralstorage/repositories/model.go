@@ -28,6 +28,12 @@ type Zone struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	Name      string    `db:"name"`
+	// Description is the free-form description, nil leaves it unchanged on update.
+	Description *string `db:"description"`
+	// Labels holds the key/value labels as a JSON object, nil leaves them unchanged on update.
+	Labels *string `db:"labels"`
+	// DeletedAt is the time the zone was soft deleted, nil for live zones.
+	DeletedAt *time.Time `db:"deleted_at"`
 }
 
 // LogZoneEntry returns a string representation of the zone.
@@ -52,6 +58,14 @@ type Ledger struct {
 	Name      string    `db:"name"`
 	Ref       string    `db:"ref"`
 	TxID      string    `db:"txid"`
+	// TrustedKeys holds the public keys trusted to sign commits, one per line in authorized keys format.
+	TrustedKeys string `db:"trusted_keys"`
+	// Description is the free-form description, nil leaves it unchanged on update.
+	Description *string `db:"description"`
+	// Labels holds the key/value labels as a JSON object, nil leaves them unchanged on update.
+	Labels *string `db:"labels"`
+	// DeletedAt is the time the ledger was soft deleted, nil for live ledgers.
+	DeletedAt *time.Time `db:"deleted_at"`
 }
 
 // LogLedgerEntry returns a string representation of the ledger.
@@ -92,6 +106,12 @@ type Transaction struct {
 	ZoneID    int64     `db:"zone_id"`
 	StartedAt time.Time `db:"started_at"`
 	Status    string    `db:"status"`
+	// RefPrevCommit is the ledger commit the transaction builds on.
+	RefPrevCommit string `db:"ref_prev_commit"`
+	// RefCommit is the commit the transaction pushes.
+	RefCommit string `db:"ref_commit"`
+	// ConfirmedObjects is the number of objects staged and confirmed so far.
+	ConfirmedObjects int64 `db:"confirmed_objects"`
 }
 
 // Transaction status constants.
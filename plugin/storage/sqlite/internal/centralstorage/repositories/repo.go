@@ -17,11 +17,23 @@
 package repositories
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/core/validators"
 )
 
+// EmptyLabels is the JSON encoding of an empty label set.
+const EmptyLabels = "{}"
+
+// TimestampLayout is the layout the timestamps are stored with, comparisons against stored timestamps are made on this text form.
+const TimestampLayout = "2006-01-02 15:04:05.000"
+
 // Repository is the central storage repository.
 type Repository struct{}
 
@@ -30,3 +42,94 @@ func GenerateUUID() string {
 	id := uuid.NewString()
 	return strings.ReplaceAll(id, "-", "")
 }
+
+// Sort columns supported by the fetch options, the id column is used when none is set.
+const (
+	SortColumnName      = "name"
+	SortColumnCreatedAt = "created_at"
+	SortColumnUpdatedAt = "updated_at"
+)
+
+// FetchOptions are the sorting and keyset pagination options of a fetch.
+type FetchOptions struct {
+	// SortColumn is the column the entries are sorted by, empty sorts by id.
+	SortColumn string
+	// SortDesc sorts the entries in descending order.
+	SortDesc bool
+	// AfterValue is the sort column value of the last entry of the previous page, it is ignored when sorting by id.
+	AfterValue string
+	// AfterID is the id of the last entry of the previous page, nil starts from the first entry.
+	AfterID any
+}
+
+// orderAndKeyset returns the order by clause and the keyset condition resuming after the last entry of the previous page.
+// Ties on the sort column are broken by the id column so that the order is total and stable under concurrent inserts.
+func (o *FetchOptions) orderAndKeyset(idColumn string) (string, string, []any, error) {
+	if o == nil {
+		return idColumn + " ASC", "", nil, nil
+	}
+	direction, comparison := "ASC", ">"
+	if o.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+	switch o.SortColumn {
+	case "":
+		orderBy := idColumn + " " + direction
+		if o.AfterID == nil {
+			return orderBy, "", nil, nil
+		}
+		return orderBy, fmt.Sprintf("%s %s ?", idColumn, comparison), []any{o.AfterID}, nil
+	case SortColumnName, SortColumnCreatedAt, SortColumnUpdatedAt:
+		orderBy := fmt.Sprintf("%s %s, %s %s", o.SortColumn, direction, idColumn, direction)
+		if o.AfterID == nil {
+			return orderBy, "", nil, nil
+		}
+		keyset := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s %[2]s ?))", o.SortColumn, comparison, idColumn)
+		return orderBy, keyset, []any{o.AfterValue, o.AfterValue, o.AfterID}, nil
+	default:
+		return "", "", nil, fmt.Errorf("storage: invalid client input - sort column %s is not valid: %w", o.SortColumn, azstorage.ErrInvalidInput)
+	}
+}
+
+// nameConditions builds the conditions matching the names containing the search term or starting with the prefix.
+func nameConditions(entity string, search *string, prefix *string) ([]string, []any, error) {
+	var conditions []string
+	var args []any
+	if search != nil {
+		if err := validators.ValidateNameSearch(entity, *search); err != nil {
+			return nil, nil, fmt.Errorf("storage: invalid client input - %s name is not valid (name: %s): %w", entity, *search, azstorage.ErrInvalidInput)
+		}
+		conditions = append(conditions, "name LIKE ?")
+		args = append(args, "%"+*search+"%")
+	}
+	if prefix != nil {
+		if err := validators.ValidateNameSearch(entity, *prefix); err != nil {
+			return nil, nil, fmt.Errorf("storage: invalid client input - %s name prefix is not valid (prefix: %s): %w", entity, *prefix, azstorage.ErrInvalidInput)
+		}
+		conditions = append(conditions, "name LIKE ?")
+		args = append(args, *prefix+"%")
+	}
+	return conditions, args, nil
+}
+
+// valueOrDefault returns the value pointed to or the default value when nil.
+func valueOrDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// labelConditions builds the conditions matching entities carrying all the given labels.
+func labelConditions(entity string, labels map[string]string) ([]string, []any, error) {
+	var conditions []string
+	var args []any
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if err := validators.ValidateLabelKey(entity, key); err != nil {
+			return nil, nil, fmt.Errorf("storage: invalid client input - %s label key is not valid (key: %s): %w", entity, key, azstorage.ErrInvalidInput)
+		}
+		conditions = append(conditions, "json_extract(labels, ?) = ?")
+		args = append(args, fmt.Sprintf("$.%q", key), labels[key])
+	}
+	return conditions, args, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repositories
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+)
+
+// newTestDB creates a sqlite database set up for the migrations.
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite", filepath.Join(t.TempDir(), "permguard.db"))
+	require.NoError(t, err, "sqlite connection should not fail")
+	t.Cleanup(func() { _ = db.Close() })
+	db.SetMaxOpenConns(1)
+	goose.SetBaseFS(os.DirFS(filepath.Join("..", "..", "..", "migrations")))
+	t.Cleanup(func() { goose.SetBaseFS(nil) })
+	require.NoError(t, goose.SetDialect("sqlite"), "goose dialect should be set")
+	return db
+}
+
+// newMigratedTestDB creates a sqlite database with all the migrations applied.
+func newMigratedTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	db := newTestDB(t)
+	require.NoError(t, goose.Up(db.DB, "."), "migrations should be applied")
+	_, err := db.Exec("PRAGMA foreign_keys = ON;")
+	require.NoError(t, err, "foreign keys should be enabled")
+	return db
+}
+
+// inTx runs the function in a committed transaction.
+func inTx[T any](t *testing.T, db *sqlx.DB, fn func(tx *sqlx.Tx) (T, error)) (T, error) {
+	t.Helper()
+	tx, err := db.Beginx()
+	require.NoError(t, err, "transaction should begin")
+	value, err := fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return value, err
+	}
+	require.NoError(t, tx.Commit(), "transaction should commit")
+	return value, nil
+}
+
+// TestSoftDeletedZoneName tests that a soft deleted zone does not hold its name, and that it cannot be undeleted while the name is in use.
+func TestSoftDeletedZoneName(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	db := newMigratedTestDB(t)
+	repo := &Repository{}
+
+	zone, err := inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.UpsertZone(ctx, tx.Tx, true, &Zone{Name: "magicfarmacia"})
+	})
+	require.NoError(t, err, "zone should be created")
+	_, err = inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.UpsertZone(ctx, tx.Tx, true, &Zone{Name: "magicfarmacia"})
+	})
+	assert.ErrorIs(err, azstorage.ErrAlreadyExists, "live zone names should be unique")
+
+	_, err = inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.DeleteZone(ctx, tx.Tx, zone.ZoneID)
+	})
+	require.NoError(t, err, "zone should be deleted")
+	newZone, err := inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.UpsertZone(ctx, tx.Tx, true, &Zone{Name: "magicfarmacia"})
+	})
+	require.NoError(t, err, "the name of a deleted zone should be reusable")
+	assert.NotEqual(zone.ZoneID, newZone.ZoneID, "zone id mismatch")
+
+	_, err = inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.UndeleteZone(ctx, tx.Tx, zone.ZoneID)
+	})
+	assert.ErrorIs(err, azstorage.ErrAlreadyExists, "undelete should conflict with the live zone")
+	assert.ErrorContains(err, "rename it before undeleting", "undelete error should be explicit")
+
+	_, err = inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.DeleteZone(ctx, tx.Tx, newZone.ZoneID)
+	})
+	require.NoError(t, err, "zone should be deleted")
+	restored, err := inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.UndeleteZone(ctx, tx.Tx, zone.ZoneID)
+	})
+	require.NoError(t, err, "zone should be undeleted once the name is free")
+	assert.Nil(restored.DeletedAt, "undeleted zone should be live")
+}
+
+// TestSoftDeletedLedgerName tests that a soft deleted ledger does not hold its name, and that it cannot be undeleted while the name is in use.
+func TestSoftDeletedLedgerName(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	db := newMigratedTestDB(t)
+	repo := &Repository{}
+
+	zone, err := inTx(t, db, func(tx *sqlx.Tx) (*Zone, error) {
+		return repo.UpsertZone(ctx, tx.Tx, true, &Zone{Name: "magicfarmacia"})
+	})
+	require.NoError(t, err, "zone should be created")
+	newLedger := func() (*Ledger, error) {
+		return inTx(t, db, func(tx *sqlx.Tx) (*Ledger, error) {
+			return repo.UpsertLedger(ctx, tx.Tx, true, &Ledger{ZoneID: zone.ZoneID, Name: "v1", Kind: ledgersMap[LedgerTypePolicy]})
+		})
+	}
+	ledger, err := newLedger()
+	require.NoError(t, err, "ledger should be created")
+	_, err = newLedger()
+	assert.ErrorIs(err, azstorage.ErrAlreadyExists, "live ledger names should be unique in the zone")
+
+	_, err = inTx(t, db, func(tx *sqlx.Tx) (*Ledger, error) {
+		return repo.DeleteLedger(ctx, tx.Tx, zone.ZoneID, ledger.LedgerID)
+	})
+	require.NoError(t, err, "ledger should be deleted")
+	_, err = newLedger()
+	require.NoError(t, err, "the name of a deleted ledger should be reusable")
+
+	_, err = inTx(t, db, func(tx *sqlx.Tx) (*Ledger, error) {
+		return repo.UndeleteLedger(ctx, tx.Tx, zone.ZoneID, ledger.LedgerID)
+	})
+	assert.ErrorIs(err, azstorage.ErrAlreadyExists, "undelete should conflict with the live ledger")
+	assert.ErrorContains(err, "rename it before undeleting", "undelete error should be explicit")
+}
+
+// TestSoftDeleteMigration tests that the rebuild of the zones and ledgers tables keeps the existing rows.
+func TestSoftDeleteMigration(t *testing.T) {
+	assert := assert.New(t)
+	db := newTestDB(t)
+	require.NoError(t, goose.UpTo(db.DB, ".", 8), "migrations should be applied")
+	_, err := db.Exec("INSERT INTO zones (zone_id, name) VALUES (273165098782, 'magicfarmacia')")
+	require.NoError(t, err, "zone should be inserted")
+	_, err = db.Exec("INSERT INTO ledgers (ledger_id, zone_id, name, kind, txid) VALUES ('fd1ac44e4afa4fc4beec622494d3175a', 273165098782, 'v1', 1, 'tx1')")
+	require.NoError(t, err, "ledger should be inserted")
+	require.NoError(t, goose.Up(db.DB, "."), "migrations should be applied")
+
+	var count int
+	require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM ledgers WHERE zone_id = 273165098782 AND txid = 'tx1' AND deleted_at IS NULL"))
+	assert.Equal(1, count, "ledger should be kept")
+	require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM change_streams WHERE change_entity = 'ZONE' AND change_type = 'DELETE'"))
+	assert.Equal(0, count, "the rebuild should not be tracked as a delete")
+	_, err = db.Exec("UPDATE zones SET deleted_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW') WHERE zone_id = 273165098782")
+	require.NoError(t, err, "zone should be soft deleted")
+	_, err = db.Exec("INSERT INTO zones (zone_id, name) VALUES (273165098783, 'magicfarmacia')")
+	require.NoError(t, err, "the name of a deleted zone should be reusable")
+
+	require.NoError(t, goose.Down(db.DB, "."), "migration should be rolled back")
+	require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM zones"))
+	assert.Equal(1, count, "soft deleted zones should be purged on rollback")
+	_, err = db.Exec("INSERT INTO zones (zone_id, name) VALUES (273165098784, 'magicfarmacia')")
+	assert.Error(err, "zone names should be unique after the rollback")
+}
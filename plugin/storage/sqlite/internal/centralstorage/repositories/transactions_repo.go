@@ -39,8 +39,8 @@ func (r *Repository) CreateTransaction(ctx context.Context, tx *sql.Tx, txn *Tra
 	}
 	span.SetAttributes(attribute.String("db.txid", txn.TxID), attribute.Int64("db.zone_id", txn.ZoneID))
 	_, err := tx.ExecContext(ctx,
-		"INSERT INTO transactions (txid, ledger_id, zone_id, status) VALUES (?, ?, ?, ?)",
-		txn.TxID, txn.LedgerID, txn.ZoneID, TxStatusPending,
+		"INSERT INTO transactions (txid, ledger_id, zone_id, status, ref_prev_commit, ref_commit) VALUES (?, ?, ?, ?, ?, ?)",
+		txn.TxID, txn.LedgerID, txn.ZoneID, TxStatusPending, txn.RefPrevCommit, txn.RefCommit,
 	)
 	if err != nil {
 		return WrapSqliteError("failed to create transaction", err)
@@ -98,6 +98,28 @@ func (r *Repository) UpdateTransactionStatusNoTx(ctx context.Context, db *sqlx.D
 	return nil
 }
 
+// AddTransactionConfirmedObjects adds to the confirmed objects of a pending transaction and returns the new total.
+func (r *Repository) AddTransactionConfirmedObjects(ctx context.Context, tx *sql.Tx, txid string, count int64) (int64, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.AddTransactionConfirmedObjects")
+	defer span.End()
+	if txid == "" {
+		return 0, fmt.Errorf("storage: invalid txid: %w", azstorage.ErrInvalidInput)
+	}
+	span.SetAttributes(attribute.String("db.txid", txid), attribute.Int64("db.count", count))
+	var confirmed int64
+	err := tx.QueryRowContext(ctx,
+		"UPDATE transactions SET confirmed_objects = confirmed_objects + ? WHERE txid = ? AND status = ? RETURNING confirmed_objects",
+		count, txid, TxStatusPending,
+	).Scan(&confirmed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("pending transaction not found (txid: %s): %w", txid, azstorage.ErrNotFound)
+		}
+		return 0, WrapSqliteError("failed to update transaction confirmed objects", err)
+	}
+	return confirmed, nil
+}
+
 // GetTransaction retrieves a transaction by txid.
 func (r *Repository) GetTransaction(ctx context.Context, db *sqlx.DB, txid string) (*Transaction, error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "db.GetTransaction")
@@ -108,9 +130,9 @@ func (r *Repository) GetTransaction(ctx context.Context, db *sqlx.DB, txid strin
 	span.SetAttributes(attribute.String("db.txid", txid))
 	var txn Transaction
 	err := db.QueryRowContext(ctx,
-		"SELECT txid, ledger_id, zone_id, started_at, status FROM transactions WHERE txid = ?",
+		"SELECT txid, ledger_id, zone_id, started_at, status, ref_prev_commit, ref_commit, confirmed_objects FROM transactions WHERE txid = ?",
 		txid,
-	).Scan(&txn.TxID, &txn.LedgerID, &txn.ZoneID, &txn.StartedAt, &txn.Status)
+	).Scan(&txn.TxID, &txn.LedgerID, &txn.ZoneID, &txn.StartedAt, &txn.Status, &txn.RefPrevCommit, &txn.RefCommit, &txn.ConfirmedObjects)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("transaction not found (txid: %s): %w", txid, azstorage.ErrNotFound)
@@ -126,7 +148,7 @@ func (r *Repository) FetchStaleTransactions(ctx context.Context, db *sqlx.DB, ol
 	defer span.End()
 	var txs []Transaction
 	err := db.SelectContext(ctx, &txs,
-		"SELECT txid, ledger_id, zone_id, started_at, status FROM transactions WHERE status = ? AND started_at < ?",
+		"SELECT txid, ledger_id, zone_id, started_at, status, ref_prev_commit, ref_commit, confirmed_objects FROM transactions WHERE status = ? AND started_at < ?",
 		TxStatusPending, olderThan,
 	)
 	if err != nil {
@@ -21,9 +21,11 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/attribute"
@@ -74,20 +76,25 @@ func (r *Repository) UpsertZone(ctx context.Context, tx *sql.Tx, isCreate bool,
 	var err error
 	if isCreate {
 		zoneID = GenerateZoneID()
-		result, err = tx.ExecContext(ctx, "INSERT INTO zones (zone_id, name) VALUES (?, ?)", zoneID, zoneName)
+		result, err = tx.ExecContext(ctx, "INSERT INTO zones (zone_id, name, description, labels) VALUES (?, ?, ?, ?)", zoneID, zoneName, valueOrDefault(zone.Description, ""), valueOrDefault(zone.Labels, EmptyLabels))
 	} else {
-		result, err = tx.ExecContext(ctx, "UPDATE zones SET name = ? WHERE zone_id = ?", zoneName, zoneID)
+		result, err = tx.ExecContext(ctx, "UPDATE zones SET name = ?, description = COALESCE(?, description), labels = COALESCE(?, labels) WHERE zone_id = ? AND deleted_at IS NULL", zoneName, zone.Description, zone.Labels, zoneID)
 	}
 	if err != nil || result == nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to %s zone - operation '%s-zone' encountered an issue (%s)", action, action, LogZoneEntry(zone)), err)
 	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, fmt.Errorf("storage: zone not found (id: %d): %w", zoneID, azstorage.ErrNotFound)
+	}
 
 	var dbZone Zone
-	err = tx.QueryRowContext(ctx, "SELECT zone_id, created_at, updated_at, name FROM zones WHERE zone_id = ?", zoneID).Scan(
+	err = tx.QueryRowContext(ctx, "SELECT zone_id, created_at, updated_at, name, description, labels FROM zones WHERE zone_id = ?", zoneID).Scan(
 		&dbZone.ZoneID,
 		&dbZone.CreatedAt,
 		&dbZone.UpdatedAt,
 		&dbZone.Name,
+		&dbZone.Description,
+		&dbZone.Labels,
 	)
 	if err != nil {
 		return nil, WrapSqliteError(fmt.Sprintf("storage: failed to retrieve zone - operation 'retrieve-created-zone' encountered an issue (%s)", LogZoneEntry(zone)), err)
@@ -96,21 +103,28 @@ func (r *Repository) UpsertZone(ctx context.Context, tx *sql.Tx, isCreate bool,
 	return &dbZone, nil
 }
 
-// DeleteZone deletes a zone.
-func (r *Repository) DeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (*Zone, error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "db.DeleteZone")
+// ReadZone reads a zone, soft deleted zones are read only when deleted is true.
+func (r *Repository) ReadZone(ctx context.Context, tx *sql.Tx, zoneID int64, deleted bool) (*Zone, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.ReadZone")
 	defer span.End()
 	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
 	if err := validators.ValidateCodeID("zone", zoneID); err != nil {
 		return nil, fmt.Errorf("storage: invalid client input - zone id is not valid (id: %d): %w", zoneID, azstorage.ErrInvalidInput)
 	}
 
+	deletedCond := "deleted_at IS NULL"
+	if deleted {
+		deletedCond = "deleted_at IS NOT NULL"
+	}
 	var dbZone Zone
-	err := tx.QueryRowContext(ctx, "SELECT zone_id, created_at, updated_at, name FROM zones WHERE zone_id = ?", zoneID).Scan(
+	err := tx.QueryRowContext(ctx, "SELECT zone_id, created_at, updated_at, name, description, labels, deleted_at FROM zones WHERE zone_id = ? AND "+deletedCond, zoneID).Scan(
 		&dbZone.ZoneID,
 		&dbZone.CreatedAt,
 		&dbZone.UpdatedAt,
 		&dbZone.Name,
+		&dbZone.Description,
+		&dbZone.Labels,
+		&dbZone.DeletedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -118,7 +132,18 @@ func (r *Repository) DeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (
 		}
 		return nil, WrapSqliteError(fmt.Sprintf("failed to retrieve zone (id: %d)", zoneID), err)
 	}
-	res, err := tx.ExecContext(ctx, "DELETE FROM zones WHERE zone_id = ?", zoneID)
+	return &dbZone, nil
+}
+
+// DeleteZone soft deletes a zone, its ledgers and objects are kept until the zone is purged.
+func (r *Repository) DeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (*Zone, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.DeleteZone")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
+	if _, err := r.ReadZone(ctx, tx, zoneID, false); err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, "UPDATE zones SET deleted_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW') WHERE zone_id = ? AND deleted_at IS NULL", zoneID)
 	if err != nil || res == nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to delete zone - operation 'delete-zone' encountered an issue (id: %d)", zoneID), err)
 	}
@@ -126,46 +151,126 @@ func (r *Repository) DeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (
 	if err != nil || rows != 1 {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to delete zone - operation 'delete-zone' encountered an issue (id: %d)", zoneID), err)
 	}
-	return &dbZone, nil
+	return r.ReadZone(ctx, tx, zoneID, true)
 }
 
-// FetchZones retrieves zones.
-func (r *Repository) FetchZones(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, filterID *int64, filterName *string) ([]Zone, error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "db.FetchZones")
+// UndeleteZone restores a soft deleted zone.
+func (r *Repository) UndeleteZone(ctx context.Context, tx *sql.Tx, zoneID int64) (*Zone, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.UndeleteZone")
 	defer span.End()
-	if page <= 0 || pageSize <= 0 {
-		return nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
+	span.SetAttributes(attribute.Int64("db.zone_id", zoneID))
+	if _, err := r.ReadZone(ctx, tx, zoneID, true); err != nil {
+		return nil, err
 	}
-	var dbZones []Zone
+	res, err := tx.ExecContext(ctx, "UPDATE zones SET deleted_at = NULL WHERE zone_id = ? AND deleted_at IS NOT NULL", zoneID)
+	if err != nil || res == nil {
+		if err != nil && errors.Is(classifyError(err), azstorage.ErrAlreadyExists) {
+			return nil, fmt.Errorf("storage: zone name is in use by another zone, rename it before undeleting (id: %d): %w", zoneID, azstorage.ErrAlreadyExists)
+		}
+		return nil, WrapSqliteError(fmt.Sprintf("failed to undelete zone - operation 'undelete-zone' encountered an issue (id: %d)", zoneID), err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil || rows != 1 {
+		return nil, WrapSqliteError(fmt.Sprintf("failed to undelete zone - operation 'undelete-zone' encountered an issue (id: %d)", zoneID), err)
+	}
+	return r.ReadZone(ctx, tx, zoneID, false)
+}
 
-	baseQuery := "SELECT * FROM zones"
-	var conditions []string
+// PurgeZones permanently deletes the zones soft deleted before the given time, together with their ledgers and objects.
+func (r *Repository) PurgeZones(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) (int64, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.PurgeZones")
+	defer span.End()
+	res, err := tx.ExecContext(ctx, "DELETE FROM zones WHERE deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore.UTC().Format(TimestampLayout))
+	if err != nil || res == nil {
+		return 0, WrapSqliteError("failed to purge zones - operation 'purge-zones' encountered an issue", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, WrapSqliteError("failed to purge zones - operation 'purge-zones' encountered an issue", err)
+	}
+	span.SetAttributes(attribute.Int64("db.purged_count", rows))
+	return rows, nil
+}
+
+// ZoneFilter holds the filters of a zones fetch.
+type ZoneFilter struct {
+	// ID matches the zone with the given id.
+	ID *int64
+	// Name matches the zones whose name contains the value.
+	Name *string
+	// NamePrefix matches the zones whose name starts with the value.
+	NamePrefix *string
+	// Labels matches the zones carrying all the given labels.
+	Labels map[string]string
+	// Deleted matches the soft deleted zones instead of the live ones.
+	Deleted bool
+}
+
+// zoneConditions builds the conditions matching the zone filter.
+func zoneConditions(filter *ZoneFilter) ([]string, []any, error) {
+	conditions := []string{"deleted_at IS NULL"}
 	var args []any
+	if filter == nil {
+		return conditions, args, nil
+	}
+	if filter.Deleted {
+		conditions[0] = "deleted_at IS NOT NULL"
+	}
 
-	if filterID != nil {
-		zoneID := *filterID
+	if filter.ID != nil {
+		zoneID := *filter.ID
 		if err := validators.ValidateCodeID("zone", zoneID); err != nil {
-			return nil, fmt.Errorf("storage: invalid client input - zone id is not valid (id: %d): %w", zoneID, azstorage.ErrInvalidInput)
+			return nil, nil, fmt.Errorf("storage: invalid client input - zone id is not valid (id: %d): %w", zoneID, azstorage.ErrInvalidInput)
 		}
 		conditions = append(conditions, "zone_id = ?")
 		args = append(args, zoneID)
 	}
 
-	if filterName != nil {
-		zoneName := *filterName
-		if err := validators.ValidateName("zone", zoneName); err != nil {
-			return nil, fmt.Errorf("invalid client input - zone name is not valid (name: %s): %w", zoneName, azstorage.ErrInvalidInput)
-		}
-		zoneName = "%" + zoneName + "%"
-		conditions = append(conditions, "name LIKE ?")
-		args = append(args, zoneName)
+	nameConds, nameArgs, err := nameConditions("zone", filter.Name, filter.NamePrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	conditions = append(conditions, nameConds...)
+	args = append(args, nameArgs...)
+
+	labelConds, labelArgs, err := labelConditions("zone", filter.Labels)
+	if err != nil {
+		return nil, nil, err
+	}
+	conditions = append(conditions, labelConds...)
+	args = append(args, labelArgs...)
+	return conditions, args, nil
+}
+
+// FetchZones retrieves zones.
+func (r *Repository) FetchZones(ctx context.Context, db *sqlx.DB, page int32, pageSize int32, filter *ZoneFilter, options *FetchOptions) ([]Zone, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.FetchZones")
+	defer span.End()
+	if page <= 0 || pageSize <= 0 {
+		return nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
+	}
+	var dbZones []Zone
+
+	baseQuery := "SELECT * FROM zones"
+	conditions, args, err := zoneConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, keysetCond, keysetArgs, err := options.orderAndKeyset("zone_id")
+	if err != nil {
+		return nil, err
+	}
+	if keysetCond != "" {
+		conditions = append(conditions, keysetCond)
+		args = append(args, keysetArgs...)
+		page = 1
 	}
 
 	if len(conditions) > 0 {
 		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	baseQuery += " ORDER BY zone_id ASC"
+	baseQuery += " ORDER BY " + orderBy
 
 	limit := pageSize
 	offset := (page - 1) * pageSize
@@ -173,7 +278,7 @@ func (r *Repository) FetchZones(ctx context.Context, db *sqlx.DB, page int32, pa
 
 	args = append(args, limit, offset)
 
-	err := db.SelectContext(ctx, &dbZones, baseQuery, args...)
+	err = db.SelectContext(ctx, &dbZones, baseQuery, args...)
 	if err != nil {
 		return nil, WrapSqliteError(fmt.Sprintf("failed to retrieve zones - operation 'retrieve-zones' encountered an issue with parameters %v", args), err)
 	}
@@ -181,3 +286,23 @@ func (r *Repository) FetchZones(ctx context.Context, db *sqlx.DB, page int32, pa
 	span.SetAttributes(attribute.Int("db.result_count", len(dbZones)))
 	return dbZones, nil
 }
+
+// CountZones counts the zones matching the filter.
+func (r *Repository) CountZones(ctx context.Context, db *sqlx.DB, filter *ZoneFilter) (int64, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "db.CountZones")
+	defer span.End()
+	baseQuery := "SELECT COUNT(*) FROM zones"
+	conditions, args, err := zoneConditions(filter)
+	if err != nil {
+		return 0, err
+	}
+	if len(conditions) > 0 {
+		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	var count int64
+	if err := db.GetContext(ctx, &count, baseQuery, args...); err != nil {
+		return 0, WrapSqliteError(fmt.Sprintf("failed to count zones - operation 'count-zones' encountered an issue with parameters %v", args), err)
+	}
+	span.SetAttributes(attribute.Int64("db.total_count", count))
+	return count, nil
+}
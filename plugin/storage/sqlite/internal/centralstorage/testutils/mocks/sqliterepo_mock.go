@@ -57,9 +57,39 @@ func (m *MockSqliteRepo) DeleteZone(_ context.Context, tx *sql.Tx, zoneID int64)
 	return r0, args.Error(1)
 }
 
+// ReadZone reads a zone.
+func (m *MockSqliteRepo) ReadZone(_ context.Context, tx *sql.Tx, zoneID int64, deleted bool) (*azrepos.Zone, error) {
+	args := m.Called(tx, zoneID, deleted)
+	var r0 *azrepos.Zone
+	if val, ok := args.Get(0).(*azrepos.Zone); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// UndeleteZone restores a zone.
+func (m *MockSqliteRepo) UndeleteZone(_ context.Context, tx *sql.Tx, zoneID int64) (*azrepos.Zone, error) {
+	args := m.Called(tx, zoneID)
+	var r0 *azrepos.Zone
+	if val, ok := args.Get(0).(*azrepos.Zone); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// PurgeZones purges zones.
+func (m *MockSqliteRepo) PurgeZones(_ context.Context, tx *sql.Tx, deletedBefore time.Time) (int64, error) {
+	args := m.Called(tx, deletedBefore)
+	var r0 int64
+	if val, ok := args.Get(0).(int64); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
 // FetchZones fetches zones.
-func (m *MockSqliteRepo) FetchZones(_ context.Context, db *sqlx.DB, page int32, pageSize int32, filterID *int64, filterName *string) ([]azrepos.Zone, error) {
-	args := m.Called(db, page, pageSize, filterID, filterName)
+func (m *MockSqliteRepo) FetchZones(_ context.Context, db *sqlx.DB, page int32, pageSize int32, filter *azrepos.ZoneFilter, options *azrepos.FetchOptions) ([]azrepos.Zone, error) {
+	args := m.Called(db, page, pageSize, filter, options)
 	var r0 []azrepos.Zone
 	if val, ok := args.Get(0).([]azrepos.Zone); ok {
 		r0 = val
@@ -67,6 +97,16 @@ func (m *MockSqliteRepo) FetchZones(_ context.Context, db *sqlx.DB, page int32,
 	return r0, args.Error(1)
 }
 
+// CountZones counts zones.
+func (m *MockSqliteRepo) CountZones(_ context.Context, db *sqlx.DB, filter *azrepos.ZoneFilter) (int64, error) {
+	args := m.Called(db, filter)
+	var r0 int64
+	if val, ok := args.Get(0).(int64); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
 // UpsertLedger creates or updates a ledger.
 func (m *MockSqliteRepo) UpsertLedger(_ context.Context, tx *sql.Tx, isCreate bool, ledger *azrepos.Ledger) (*azrepos.Ledger, error) {
 	args := m.Called(tx, isCreate, ledger)
@@ -83,6 +123,12 @@ func (m *MockSqliteRepo) UpdateLedgerRef(_ context.Context, tx *sql.Tx, zoneID i
 	return args.Error(1)
 }
 
+// UpdateLedgerTrustedKeys replaces the keys trusted to sign the commits of a ledger.
+func (m *MockSqliteRepo) UpdateLedgerTrustedKeys(_ context.Context, tx *sql.Tx, zoneID int64, ledgerID, trustedKeys string) error {
+	args := m.Called(tx, zoneID, ledgerID, trustedKeys)
+	return args.Error(0)
+}
+
 // DeleteLedger deletes a ledger.
 func (m *MockSqliteRepo) DeleteLedger(_ context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*azrepos.Ledger, error) {
 	args := m.Called(tx, zoneID, ledgerID)
@@ -93,9 +139,49 @@ func (m *MockSqliteRepo) DeleteLedger(_ context.Context, tx *sql.Tx, zoneID int6
 	return r0, args.Error(1)
 }
 
+// ReadLedger reads a ledger.
+func (m *MockSqliteRepo) ReadLedger(_ context.Context, tx *sql.Tx, zoneID int64, ledgerID string, deleted bool) (*azrepos.Ledger, error) {
+	args := m.Called(tx, zoneID, ledgerID, deleted)
+	var r0 *azrepos.Ledger
+	if val, ok := args.Get(0).(*azrepos.Ledger); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// UndeleteLedger restores a ledger.
+func (m *MockSqliteRepo) UndeleteLedger(_ context.Context, tx *sql.Tx, zoneID int64, ledgerID string) (*azrepos.Ledger, error) {
+	args := m.Called(tx, zoneID, ledgerID)
+	var r0 *azrepos.Ledger
+	if val, ok := args.Get(0).(*azrepos.Ledger); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// PurgeLedgers purges ledgers.
+func (m *MockSqliteRepo) PurgeLedgers(_ context.Context, tx *sql.Tx, deletedBefore time.Time) (int64, error) {
+	args := m.Called(tx, deletedBefore)
+	var r0 int64
+	if val, ok := args.Get(0).(int64); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
+// CountLedgersWithHistory counts the ledgers with history.
+func (m *MockSqliteRepo) CountLedgersWithHistory(_ context.Context, tx *sql.Tx, zoneID int64, emptyRef string) (int64, error) {
+	args := m.Called(tx, zoneID, emptyRef)
+	var r0 int64
+	if val, ok := args.Get(0).(int64); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
 // FetchLedgers fetches ledgers.
-func (m *MockSqliteRepo) FetchLedgers(_ context.Context, db *sqlx.DB, page int32, pageSize int32, zoneID int64, filterID *string, filterName *string) ([]azrepos.Ledger, error) {
-	args := m.Called(db, page, pageSize, zoneID, filterID, filterName)
+func (m *MockSqliteRepo) FetchLedgers(_ context.Context, db *sqlx.DB, page int32, pageSize int32, zoneID int64, filter *azrepos.LedgerFilter, options *azrepos.FetchOptions) ([]azrepos.Ledger, error) {
+	args := m.Called(db, page, pageSize, zoneID, filter, options)
 	var r0 []azrepos.Ledger
 	if val, ok := args.Get(0).([]azrepos.Ledger); ok {
 		r0 = val
@@ -103,6 +189,16 @@ func (m *MockSqliteRepo) FetchLedgers(_ context.Context, db *sqlx.DB, page int32
 	return r0, args.Error(1)
 }
 
+// CountLedgers counts ledgers.
+func (m *MockSqliteRepo) CountLedgers(_ context.Context, db *sqlx.DB, zoneID int64, filter *azrepos.LedgerFilter) (int64, error) {
+	args := m.Called(db, zoneID, filter)
+	var r0 int64
+	if val, ok := args.Get(0).(int64); ok {
+		r0 = val
+	}
+	return r0, args.Error(1)
+}
+
 // UpsertKeyValue creates or updates a key-value pair.
 func (m *MockSqliteRepo) UpsertKeyValue(_ context.Context, tx *sql.Tx, keyValue *azrepos.KeyValue, txid string) (*azrepos.KeyValue, error) {
 	args := m.Called(tx, keyValue, txid)
@@ -151,6 +247,12 @@ func (m *MockSqliteRepo) UpdateTransactionStatusNoTx(_ context.Context, db *sqlx
 	return args.Error(0)
 }
 
+// AddTransactionConfirmedObjects adds to the confirmed objects of a pending transaction.
+func (m *MockSqliteRepo) AddTransactionConfirmedObjects(_ context.Context, tx *sql.Tx, txid string, count int64) (int64, error) {
+	args := m.Called(tx, txid, count)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // GetTransaction retrieves a transaction by txid.
 func (m *MockSqliteRepo) GetTransaction(_ context.Context, db *sqlx.DB, txid string) (*azrepos.Transaction, error) {
 	args := m.Called(db, txid)
@@ -19,14 +19,17 @@ package centralstorage
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 
 	azstorage "github.com/permguard/permguard/pkg/agents/storage"
 	"github.com/permguard/permguard/pkg/agents/telemetry"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
 // CreateZone creates a new zone.
@@ -51,9 +54,9 @@ func (s SQLiteCentralStorageZAP) CreateZone(ctx context.Context, zone *zap.Zone)
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
 	}
-	dbInZone := &azrepos.Zone{
-		ZoneID: zone.ZoneID,
-		Name:   zone.Name,
+	dbInZone, err := mapAgentZoneToZone(zone)
+	if err != nil {
+		return nil, rollback(tx, err)
 	}
 	dbOutZone, err := s.sqlRepo.UpsertZone(ctx, tx, true, dbInZone)
 	if s.config.EnabledDefaultCreation() {
@@ -96,9 +99,9 @@ func (s SQLiteCentralStorageZAP) UpdateZone(ctx context.Context, zone *zap.Zone)
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
 	}
-	dbInZone := &azrepos.Zone{
-		ZoneID: zone.ZoneID,
-		Name:   zone.Name,
+	dbInZone, err := mapAgentZoneToZone(zone)
+	if err != nil {
+		return nil, rollback(tx, err)
 	}
 	dbOutzone, err := s.sqlRepo.UpsertZone(ctx, tx, false, dbInZone)
 	if err != nil {
@@ -110,8 +113,8 @@ func (s SQLiteCentralStorageZAP) UpdateZone(ctx context.Context, zone *zap.Zone)
 	return mapZoneToAgentZone(dbOutzone)
 }
 
-// DeleteZone deletes a zone.
-func (s SQLiteCentralStorageZAP) DeleteZone(ctx context.Context, zoneID int64) (_ *zap.Zone, retErr error) {
+// DeleteZone soft deletes a zone, a zone holding ledgers with policy history must be confirmed with its name or forced.
+func (s SQLiteCentralStorageZAP) DeleteZone(ctx context.Context, zoneID int64, options *models.DeleteOptions) (_ *zap.Zone, retErr error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "storage.DeleteZone")
 	defer span.End()
 	start := time.Now()
@@ -121,6 +124,9 @@ func (s SQLiteCentralStorageZAP) DeleteZone(ctx context.Context, zoneID int64) (
 		telemetry.ZoneOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("delete"), telemetry.StatusAttr(st))
 	}()
 	span.SetAttributes(attribute.Int64("zone_id", zoneID))
+	if options == nil {
+		options = &models.DeleteOptions{}
+	}
 	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
@@ -129,6 +135,20 @@ func (s SQLiteCentralStorageZAP) DeleteZone(ctx context.Context, zoneID int64) (
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
 	}
+	if !options.Force {
+		dbZone, err := s.sqlRepo.ReadZone(ctx, tx, zoneID, false)
+		if err != nil {
+			return nil, rollback(tx, err)
+		}
+		count, err := s.sqlRepo.CountLedgersWithHistory(ctx, tx, zoneID, objects.ZeroOID)
+		if err != nil {
+			return nil, rollback(tx, err)
+		}
+		if count > 0 && options.Confirmation != dbZone.Name {
+			err := fmt.Errorf("storage: zone %d holds %d ledgers with policy history, confirm the delete with the zone name or force it: %w", zoneID, count, azstorage.ErrConfirmationRequired)
+			return nil, rollback(tx, err)
+		}
+	}
 	dbOutzone, err := s.sqlRepo.DeleteZone(ctx, tx, zoneID)
 	if err != nil {
 		return nil, rollback(tx, err)
@@ -139,51 +159,172 @@ func (s SQLiteCentralStorageZAP) DeleteZone(ctx context.Context, zoneID int64) (
 	return mapZoneToAgentZone(dbOutzone)
 }
 
-// FetchZones returns all zones.
-func (s SQLiteCentralStorageZAP) FetchZones(ctx context.Context, page int32, pageSize int32, fields map[string]any) (_ []zap.Zone, retErr error) {
-	ctx, span := telemetry.Tracer().Start(ctx, "storage.FetchZones")
+// UndeleteZone restores a soft deleted zone.
+func (s SQLiteCentralStorageZAP) UndeleteZone(ctx context.Context, zoneID int64) (_ *zap.Zone, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.UndeleteZone")
 	defer span.End()
 	start := time.Now()
 	defer func() {
 		st := telemetry.StatusFromErr(retErr)
-		telemetry.ZoneFetchTotal.Add(ctx, 1, telemetry.StatusAttr(st))
-		telemetry.ZoneOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("fetch"), telemetry.StatusAttr(st))
+		telemetry.ZoneOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("undelete"), telemetry.StatusAttr(st))
 	}()
-	if page <= 0 || pageSize <= 0 || pageSize > s.config.DataFetchMaxPageSize() {
-		return nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
-	}
+	span.SetAttributes(attribute.Int64("zone_id", zoneID))
 	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
 	if err != nil {
 		return nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
 	}
-	var filterID *int64
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
+	}
+	dbOutzone, err := s.sqlRepo.UndeleteZone(ctx, tx, zoneID)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+	}
+	return mapZoneToAgentZone(dbOutzone)
+}
+
+// PurgeDeletedZones permanently deletes the zones soft deleted for longer than the retention.
+func (s SQLiteCentralStorageZAP) PurgeDeletedZones(ctx context.Context, retention time.Duration) (_ int64, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.PurgeDeletedZones")
+	defer span.End()
+	start := time.Now()
+	defer func() {
+		st := telemetry.StatusFromErr(retErr)
+		telemetry.ZoneOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("purge"), telemetry.StatusAttr(st))
+	}()
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return 0, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, azrepos.WrapSqliteError(errorMessageCannotBeginTransaction, err)
+	}
+	purged, err := s.sqlRepo.PurgeZones(ctx, tx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, azrepos.WrapSqliteError(errorMessageCannotCommitTransaction, err)
+	}
+	span.SetAttributes(attribute.Int64("purged", purged))
+	return purged, nil
+}
+
+// zoneFilter maps the fields to the zones filter.
+func zoneFilter(fields map[string]any) (*azrepos.ZoneFilter, error) {
+	filter := &azrepos.ZoneFilter{}
 	if _, ok := fields[zap.FieldZoneZoneID]; ok {
 		zoneID, ok := fields[zap.FieldZoneZoneID].(int64)
 		if !ok {
 			return nil, fmt.Errorf("storage: invalid client input - zone id is not valid (zone id: %d): %w", zoneID, azstorage.ErrInvalidInput)
 		}
-		filterID = &zoneID
+		filter.ID = &zoneID
 	}
-	var filterName *string
 	if _, ok := fields[zap.FieldZoneName]; ok {
 		zoneName, ok := fields[zap.FieldZoneName].(string)
 		if !ok {
 			return nil, fmt.Errorf("storage: invalid client input - zone name is not valid (zone name: %s): %w", zoneName, azstorage.ErrInvalidInput)
 		}
-		filterName = &zoneName
+		filter.Name = &zoneName
+	}
+	if _, ok := fields[zap.FieldZoneNamePrefix]; ok {
+		namePrefix, ok := fields[zap.FieldZoneNamePrefix].(string)
+		if !ok {
+			return nil, fmt.Errorf("storage: invalid client input - zone name prefix is not valid (prefix: %s): %w", namePrefix, azstorage.ErrInvalidInput)
+		}
+		filter.NamePrefix = &namePrefix
 	}
-	dbZones, err := s.sqlRepo.FetchZones(ctx, db, page, pageSize, filterID, filterName)
+	if _, ok := fields[zap.FieldZoneDeleted]; ok {
+		deleted, ok := fields[zap.FieldZoneDeleted].(bool)
+		if !ok {
+			return nil, fmt.Errorf("storage: invalid client input - zone deleted filter is not valid: %w", azstorage.ErrInvalidInput)
+		}
+		filter.Deleted = deleted
+	}
+	filterLabels, err := labelsFilter(fields, zap.FieldZoneLabels)
 	if err != nil {
 		return nil, err
 	}
+	filter.Labels = filterLabels
+	return filter, nil
+}
+
+// FetchZones returns all zones.
+func (s SQLiteCentralStorageZAP) FetchZones(ctx context.Context, page int32, pageSize int32, fields map[string]any) ([]zap.Zone, error) {
+	zones, _, err := s.fetchZones(ctx, page, pageSize, fields, nil, false)
+	return zones, err
+}
+
+// FetchZonesPage returns a page of zones sorted and resumed as per the options, together with the page info.
+func (s SQLiteCentralStorageZAP) FetchZonesPage(ctx context.Context, page int32, pageSize int32, fields map[string]any, options *models.FetchOptions) ([]zap.Zone, *models.FetchPageInfo, error) {
+	return s.fetchZones(ctx, page, pageSize, fields, options, true)
+}
+
+// fetchZones returns the zones and, when requested, the page info.
+func (s SQLiteCentralStorageZAP) fetchZones(ctx context.Context, page int32, pageSize int32, fields map[string]any, options *models.FetchOptions, withPageInfo bool) (_ []zap.Zone, _ *models.FetchPageInfo, retErr error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.FetchZones")
+	defer span.End()
+	start := time.Now()
+	defer func() {
+		st := telemetry.StatusFromErr(retErr)
+		telemetry.ZoneFetchTotal.Add(ctx, 1, telemetry.StatusAttr(st))
+		telemetry.ZoneOpDuration.Record(ctx, telemetry.ElapsedSeconds(start), telemetry.OpAttr("fetch"), telemetry.StatusAttr(st))
+	}()
+	if page <= 0 || pageSize <= 0 || pageSize > s.config.DataFetchMaxPageSize() {
+		return nil, nil, fmt.Errorf("storage: invalid client input - page number %d or page size %d is not valid: %w", page, pageSize, azstorage.ErrInvalidInput)
+	}
+	db, err := s.sqlExec.Connect(s.ctx, s.sqliteConnector)
+	if err != nil {
+		return nil, nil, azrepos.WrapSqliteError(errorMessageCannotConnect, err)
+	}
+	filter, err := zoneFilter(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	repoOptions, cursor, err := mapFetchOptions(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cursor != nil {
+		afterID, err := strconv.ParseInt(cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("storage: invalid client input - cursor is not valid: %w", azstorage.ErrInvalidInput)
+		}
+		repoOptions.AfterID = afterID
+	}
+	dbZones, err := s.sqlRepo.FetchZones(ctx, db, page, pageSize, filter, repoOptions)
+	if err != nil {
+		return nil, nil, err
+	}
 	zones := make([]zap.Zone, len(dbZones))
 	for i, a := range dbZones {
 		zone, err := mapZoneToAgentZone(&a)
 		if err != nil {
-			return nil, fmt.Errorf("storage: failed to convert zone entity (%s): %w", azrepos.LogZoneEntry(&a), azstorage.ErrInternal)
+			return nil, nil, fmt.Errorf("storage: failed to convert zone entity (%s): %w", azrepos.LogZoneEntry(&a), azstorage.ErrInternal)
 		}
 		zones[i] = *zone
 	}
 	span.SetAttributes(attribute.Int("result_count", len(zones)))
-	return zones, nil
+	if !withPageInfo {
+		return zones, nil, nil
+	}
+	pageInfo := &models.FetchPageInfo{}
+	pageInfo.TotalCount, err = s.sqlRepo.CountZones(ctx, db, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(dbZones) == int(pageSize) {
+		last := dbZones[len(dbZones)-1]
+		sortValue := cursorSortValue(options, last.Name, last.CreatedAt, last.UpdatedAt)
+		pageInfo.NextCursor, err = encodeFetchCursor(options, sortValue, strconv.FormatInt(last.ZoneID, 10))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return zones, pageInfo, nil
 }
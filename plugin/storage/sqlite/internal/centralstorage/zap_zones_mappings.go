@@ -22,11 +22,35 @@ import (
 )
 
 // mapZoneToAgentZone maps a zone to a model Zone.
-func mapZoneToAgentZone(zone *azrepos.Zone) (*zap.Zone, error) { //nolint:unparam // error kept for API consistency with mapLedgerToAgentLedger
+func mapZoneToAgentZone(zone *azrepos.Zone) (*zap.Zone, error) {
+	labels, err := mapJSONToLabels(zone.Labels)
+	if err != nil {
+		return nil, err
+	}
 	return &zap.Zone{
-		ZoneID:    zone.ZoneID,
-		CreatedAt: zone.CreatedAt,
-		UpdatedAt: zone.UpdatedAt,
-		Name:      zone.Name,
+		ZoneID:      zone.ZoneID,
+		CreatedAt:   zone.CreatedAt,
+		UpdatedAt:   zone.UpdatedAt,
+		Name:        zone.Name,
+		Description: mapDescription(zone.Description),
+		Labels:      labels,
+		DeletedAt:   zone.DeletedAt,
+	}, nil
+}
+
+// mapAgentZoneToZone maps a model Zone to a zone, validating its description and labels.
+func mapAgentZoneToZone(zone *zap.Zone) (*azrepos.Zone, error) {
+	if err := validateDescription("zone", zone.Description); err != nil {
+		return nil, err
+	}
+	labels, err := mapLabelsToJSON("zone", zone.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &azrepos.Zone{
+		ZoneID:      zone.ZoneID,
+		Name:        zone.Name,
+		Description: zone.Description,
+		Labels:      labels,
 	}, nil
 }
@@ -25,8 +25,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	azstorage "github.com/permguard/permguard/pkg/agents/storage"
+	"github.com/permguard/permguard/pkg/transport/models"
 	"github.com/permguard/permguard/pkg/transport/models/zap"
 	azrepos "github.com/permguard/permguard/plugin/storage/sqlite/internal/centralstorage/repositories"
+	"github.com/permguard/permguard/ztauthstar/pkg/ztauthstar/authstarmodels/objects"
 )
 
 // TestCreateZoneWithErrors tests the CreateZone function with errors.
@@ -241,7 +244,7 @@ func TestDeleteZoneWithErrors(t *testing.T) {
 		}
 
 		inZoneID := int64(232956849236)
-		outZones, err := storage.DeleteZone(t.Context(), inZoneID)
+		outZones, err := storage.DeleteZone(t.Context(), inZoneID, &models.DeleteOptions{Force: true})
 		assert.Nil(outZones, "zones should be nil")
 		require.Error(t, err)
 		if multi, ok := err.(interface{ Unwrap() []error }); ok {
@@ -267,11 +270,13 @@ func TestDeleteZoneWithSuccess(t *testing.T) {
 
 	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
 	mockSQLDB.ExpectBegin()
+	mockSQLRepo.On("ReadZone", mock.Anything, mock.Anything, false).Return(dbOutZone, nil)
+	mockSQLRepo.On("CountLedgersWithHistory", mock.Anything, mock.Anything, objects.ZeroOID).Return(int64(0), nil)
 	mockSQLRepo.On("DeleteZone", mock.Anything, mock.Anything).Return(dbOutZone, nil)
 	mockSQLDB.ExpectCommit().WillReturnError(nil)
 
 	inZoneID := int64(232956849236)
-	outZones, err := storage.DeleteZone(t.Context(), inZoneID)
+	outZones, err := storage.DeleteZone(t.Context(), inZoneID, nil)
 	require.NoError(t, err, "error should be nil")
 	assert.NotNil(outZones, "zones should not be nil")
 	assert.Equal(dbOutZone.ZoneID, outZones.ZoneID, "zone id should be equal")
@@ -280,6 +285,70 @@ func TestDeleteZoneWithSuccess(t *testing.T) {
 	assert.Equal(dbOutZone.UpdatedAt, outZones.UpdatedAt, "updated at should be equal")
 }
 
+// TestDeleteZoneWithConfirmation tests the DeleteZone function on a zone holding ledgers with policy history.
+func TestDeleteZoneWithConfirmation(t *testing.T) {
+	assert := assert.New(t)
+
+	dbOutZone := &azrepos.Zone{
+		ZoneID:    232956849236,
+		Name:      "rent-a-car1",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	{ // Test without confirmation
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLiteZAPCentralStorageWithMocks()
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLDB.ExpectBegin()
+		mockSQLRepo.On("ReadZone", mock.Anything, dbOutZone.ZoneID, false).Return(dbOutZone, nil)
+		mockSQLRepo.On("CountLedgersWithHistory", mock.Anything, dbOutZone.ZoneID, objects.ZeroOID).Return(int64(2), nil)
+		mockSQLDB.ExpectRollback()
+
+		outZone, err := storage.DeleteZone(t.Context(), dbOutZone.ZoneID, &models.DeleteOptions{Confirmation: "rent-a-car2"})
+		assert.Nil(outZone, "zone should be nil")
+		require.ErrorIs(t, err, azstorage.ErrConfirmationRequired)
+		mockSQLRepo.AssertNotCalled(t, "DeleteZone", mock.Anything, mock.Anything)
+	}
+
+	{ // Test with the zone name as confirmation
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLiteZAPCentralStorageWithMocks()
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLDB.ExpectBegin()
+		mockSQLRepo.On("ReadZone", mock.Anything, dbOutZone.ZoneID, false).Return(dbOutZone, nil)
+		mockSQLRepo.On("CountLedgersWithHistory", mock.Anything, dbOutZone.ZoneID, objects.ZeroOID).Return(int64(2), nil)
+		mockSQLRepo.On("DeleteZone", mock.Anything, dbOutZone.ZoneID).Return(dbOutZone, nil)
+		mockSQLDB.ExpectCommit()
+
+		outZone, err := storage.DeleteZone(t.Context(), dbOutZone.ZoneID, &models.DeleteOptions{Confirmation: dbOutZone.Name})
+		require.NoError(t, err)
+		assert.Equal(dbOutZone.ZoneID, outZone.ZoneID, "zone id should be equal")
+	}
+}
+
+// TestUndeleteZoneWithSuccess tests the UndeleteZone function with success.
+func TestUndeleteZoneWithSuccess(t *testing.T) {
+	assert := assert.New(t)
+
+	storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLiteZAPCentralStorageWithMocks()
+
+	dbOutZone := &azrepos.Zone{
+		ZoneID:    232956849236,
+		Name:      "rent-a-car1",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+	mockSQLDB.ExpectBegin()
+	mockSQLRepo.On("UndeleteZone", mock.Anything, dbOutZone.ZoneID).Return(dbOutZone, nil)
+	mockSQLDB.ExpectCommit()
+
+	outZone, err := storage.UndeleteZone(t.Context(), dbOutZone.ZoneID)
+	require.NoError(t, err)
+	assert.Equal(dbOutZone.ZoneID, outZone.ZoneID, "zone id should be equal")
+	assert.Nil(outZone.DeletedAt, "deleted at should be nil")
+}
+
 // TestFetchZoneWithErrors tests the FetchZone function with errors.
 func TestFetchZoneWithErrors(t *testing.T) {
 	assert := assert.New(t)
@@ -369,3 +438,112 @@ func TestFetchZoneWithSuccess(t *testing.T) {
 		assert.Equal(dbOutZones[i].UpdatedAt, outZone.UpdatedAt, "updated at should be equal")
 	}
 }
+
+// TestZoneLabelsAndDescription tests that labels and descriptions are validated, stored and filtered.
+func TestZoneLabelsAndDescription(t *testing.T) {
+	assert := assert.New(t)
+
+	{ // Test with invalid labels
+		storage, mockStorageCtx, mockConnector, _, mockSQLExec, sqlDB, mockSQLDB := createSQLiteZAPCentralStorageWithMocks()
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLDB.ExpectBegin()
+		mockSQLDB.ExpectRollback()
+		outZone, err := storage.CreateZone(t.Context(), &zap.Zone{Name: "rent-a-car1", Labels: map[string]string{"Owner": "team-a"}})
+		assert.Nil(outZone, "zone should be nil")
+		require.ErrorIs(t, err, azstorage.ErrInvalidInput, "error should be invalid input")
+	}
+
+	{ // Test with labels and description
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, mockSQLDB := createSQLiteZAPCentralStorageWithMocks()
+		description := "orders tenant"
+		labels := `{"cost-centre":"cc-42","env":"prod"}`
+		dbOutZone := &azrepos.Zone{
+			ZoneID:      232956849236,
+			Name:        "rent-a-car1",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+			Description: &description,
+			Labels:      &labels,
+		}
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLDB.ExpectBegin()
+		mockSQLRepo.On("UpsertZone", mock.Anything, false, mock.MatchedBy(func(zone *azrepos.Zone) bool {
+			return zone.Labels != nil && *zone.Labels == labels && zone.Description == nil
+		})).Return(dbOutZone, nil)
+		mockSQLDB.ExpectCommit().WillReturnError(nil)
+
+		outZone, err := storage.UpdateZone(t.Context(), &zap.Zone{ZoneID: 232956849236, Name: "rent-a-car1", Labels: map[string]string{"env": "prod", "cost-centre": "cc-42"}})
+		require.NoError(t, err, "error should be nil")
+		assert.Equal(map[string]string{"env": "prod", "cost-centre": "cc-42"}, outZone.Labels, "labels should be equal")
+		assert.Equal(description, *outZone.Description, "description should be equal")
+	}
+
+	{ // Test filtering by labels
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, _ := createSQLiteZAPCentralStorageWithMocks()
+		filter := map[string]string{"env": "prod"}
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLRepo.On("FetchZones", mock.Anything, mock.Anything, mock.Anything, &azrepos.ZoneFilter{Labels: filter}, (*azrepos.FetchOptions)(nil)).Return([]azrepos.Zone{}, nil)
+		_, err := storage.FetchZones(t.Context(), 1, 100, map[string]any{zap.FieldZoneLabels: filter})
+		require.NoError(t, err, "error should be nil")
+
+		_, err = storage.FetchZones(t.Context(), 1, 100, map[string]any{zap.FieldZoneLabels: "env=prod"})
+		require.ErrorIs(t, err, azstorage.ErrInvalidInput, "error should be invalid input")
+	}
+}
+
+// TestFetchZonesPage tests that the page info is returned and the cursor resumes after the last zone of the page.
+func TestFetchZonesPage(t *testing.T) {
+	assert := assert.New(t)
+
+	createdAt := time.Date(2026, 10, 19, 8, 30, 15, 123000000, time.UTC)
+	dbOutZones := []azrepos.Zone{
+		{ZoneID: 232956849236, Name: "rent-a-car1", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ZoneID: 506074038324, Name: "rent-a-car2", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	var cursor string
+	{ // Test a full page returns the next cursor and the total count
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, _ := createSQLiteZAPCentralStorageWithMocks()
+		namePrefix := "rent"
+		filter := &azrepos.ZoneFilter{NamePrefix: &namePrefix}
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLRepo.On("FetchZones", mock.Anything, int32(1), int32(2), filter, &azrepos.FetchOptions{SortColumn: azrepos.SortColumnCreatedAt}).Return(dbOutZones, nil)
+		mockSQLRepo.On("CountZones", mock.Anything, filter).Return(int64(5), nil)
+
+		options := &models.FetchOptions{SortBy: models.FetchSortByCreatedAt}
+		outZones, pageInfo, err := storage.FetchZonesPage(t.Context(), 1, 2, map[string]any{zap.FieldZoneNamePrefix: "rent"}, options)
+		require.NoError(t, err, "error should be nil")
+		assert.Len(outZones, 2, "zones should have the page size length")
+		assert.Equal(int64(5), pageInfo.TotalCount, "total count should be equal")
+		assert.NotEmpty(pageInfo.NextCursor, "next cursor should be set")
+		cursor = pageInfo.NextCursor
+	}
+
+	{ // Test the cursor resumes after the last zone of the previous page
+		storage, mockStorageCtx, mockConnector, mockSQLRepo, mockSQLExec, sqlDB, _ := createSQLiteZAPCentralStorageWithMocks()
+		repoOptions := &azrepos.FetchOptions{SortColumn: azrepos.SortColumnCreatedAt, AfterValue: "2026-10-19 08:30:15.123", AfterID: int64(506074038324)}
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+		mockSQLRepo.On("FetchZones", mock.Anything, int32(1), int32(2), mock.Anything, repoOptions).Return(dbOutZones[:1], nil)
+		mockSQLRepo.On("CountZones", mock.Anything, mock.Anything).Return(int64(5), nil)
+
+		options := &models.FetchOptions{SortBy: models.FetchSortByCreatedAt, Cursor: cursor}
+		outZones, pageInfo, err := storage.FetchZonesPage(t.Context(), 1, 2, nil, options)
+		require.NoError(t, err, "error should be nil")
+		assert.Len(outZones, 1, "zones should have the remaining length")
+		assert.Empty(pageInfo.NextCursor, "next cursor should be empty on the last page")
+	}
+
+	{ // Test invalid sort and cursors
+		storage, mockStorageCtx, mockConnector, _, mockSQLExec, sqlDB, _ := createSQLiteZAPCentralStorageWithMocks()
+		mockSQLExec.On("Connect", mockStorageCtx, mockConnector).Return(sqlDB, nil)
+
+		_, _, err := storage.FetchZonesPage(t.Context(), 1, 2, nil, &models.FetchOptions{SortBy: "kind"})
+		require.ErrorIs(t, err, azstorage.ErrInvalidInput, "error should be invalid input")
+
+		_, _, err = storage.FetchZonesPage(t.Context(), 1, 2, nil, &models.FetchOptions{SortBy: models.FetchSortByName, Cursor: cursor})
+		require.ErrorIs(t, err, azstorage.ErrInvalidInput, "error should be invalid input for a cursor of a different sort")
+
+		_, _, err = storage.FetchZonesPage(t.Context(), 1, 2, nil, &models.FetchOptions{Cursor: "not-a-cursor"})
+		require.ErrorIs(t, err, azstorage.ErrInvalidInput, "error should be invalid input")
+	}
+}
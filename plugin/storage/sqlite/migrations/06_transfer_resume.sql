@@ -0,0 +1,25 @@
+-- Copyright 2024 Nitro Agility S.r.l.
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+--
+-- SPDX-License-Identifier: Apache-2.0
+
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN ref_prev_commit TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN ref_commit TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN confirmed_objects INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN confirmed_objects;
+ALTER TABLE transactions DROP COLUMN ref_commit;
+ALTER TABLE transactions DROP COLUMN ref_prev_commit;
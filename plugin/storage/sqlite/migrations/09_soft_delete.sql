@@ -0,0 +1,163 @@
+-- Copyright 2024 Nitro Agility S.r.l.
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+--
+-- SPDX-License-Identifier: Apache-2.0
+
+-- +goose Up
+ALTER TABLE zones ADD COLUMN deleted_at TIMESTAMP NULL;
+ALTER TABLE ledgers ADD COLUMN deleted_at TIMESTAMP NULL;
+
+-- Names are unique among the live zones and ledgers only, so that a soft deleted one does not hold its name
+-- until it is purged. The column and table unique constraints cannot be dropped in SQLite, hence the tables
+-- are rebuilt; the migrations run with the foreign keys off, so dropping the old tables does not cascade.
+CREATE TABLE zones_new (
+    zone_id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT(STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW')) NOT NULL,
+    updated_at TIMESTAMP DEFAULT(STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW')) NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    labels TEXT NOT NULL DEFAULT '{}',
+    deleted_at TIMESTAMP NULL
+);
+INSERT INTO zones_new (zone_id, created_at, updated_at, name, description, labels, deleted_at)
+    SELECT zone_id, created_at, updated_at, name, description, labels, deleted_at FROM zones;
+DROP TABLE zones;
+ALTER TABLE zones_new RENAME TO zones;
+
+CREATE INDEX zones_name_idx ON zones(name);
+CREATE INDEX zones_deletedat_idx ON zones(deleted_at);
+CREATE UNIQUE INDEX zones_name_live_key ON zones(name) WHERE deleted_at IS NULL;
+
+-- Trigger to track changes in the `zones` table after insert
+-- +goose StatementBegin
+CREATE TRIGGER zones_change_streams_after_insert
+AFTER INSERT ON zones
+FOR EACH ROW
+BEGIN
+    INSERT INTO change_streams (change_entity, change_type, change_entity_id, zone_id, payload)
+		VALUES ('ZONE', 'INSERT', NEW.zone_id, NEW.zone_id,
+				'{"zone_id": ' || NEW.zone_id || ', "created_at": "' || NEW.created_at ||
+				'", "updated_at": "' || NEW.updated_at || '", "name": "' || NEW.name || '"}');
+END;
+-- +goose StatementEnd
+
+-- Trigger to track changes in the `zones` table after update
+-- +goose StatementBegin
+CREATE TRIGGER zones_change_streams_after_update
+AFTER UPDATE ON zones
+FOR EACH ROW
+BEGIN
+    UPDATE zones SET updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW') WHERE zone_id = OLD.zone_id;
+    INSERT INTO change_streams (change_entity, change_type, change_entity_id, zone_id, payload)
+		VALUES ('ZONE', 'UPDATE', NEW.zone_id, NEW.zone_id,
+				'{"zone_id": ' || NEW.zone_id || ', "created_at": "' || NEW.created_at ||
+				'", "updated_at": "' || NEW.updated_at || '", "name": "' || NEW.name || '"}');
+END;
+-- +goose StatementEnd
+
+-- Trigger to track changes in the `zones` table after delete
+-- +goose StatementBegin
+CREATE TRIGGER zones_change_streams_after_delete
+AFTER DELETE ON zones
+FOR EACH ROW
+BEGIN
+    INSERT INTO change_streams (change_entity, change_type, change_entity_id, zone_id, payload)
+		VALUES ('ZONE', 'DELETE', OLD.zone_id, OLD.zone_id,
+				'{"zone_id": ' || OLD.zone_id || ', "created_at": "' || OLD.created_at ||
+				'", "updated_at": "' || OLD.updated_at || '", "name": "' || OLD.name || '"}');
+END;
+-- +goose StatementEnd
+
+CREATE TABLE ledgers_new (
+    ledger_id TEXT NOT NULL PRIMARY KEY,
+    created_at TIMESTAMP DEFAULT(STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW')) NOT NULL,
+    updated_at TIMESTAMP DEFAULT(STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW')) NOT NULL,
+    name TEXT NOT NULL,
+	kind INTEGER NOT NULL,
+	ref  TEXT NOT NULL DEFAULT 'bafyreiaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa',
+	txid TEXT NOT NULL DEFAULT '',
+	trusted_keys TEXT NOT NULL DEFAULT '',
+	description TEXT NOT NULL DEFAULT '',
+	labels TEXT NOT NULL DEFAULT '{}',
+	deleted_at TIMESTAMP NULL,
+	-- REFERENCES
+	zone_id INTEGER NOT NULL REFERENCES zones(zone_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+INSERT INTO ledgers_new (ledger_id, created_at, updated_at, name, kind, ref, txid, trusted_keys, description, labels, deleted_at, zone_id)
+    SELECT ledger_id, created_at, updated_at, name, kind, ref, txid, trusted_keys, description, labels, deleted_at, zone_id FROM ledgers;
+DROP TABLE ledgers;
+ALTER TABLE ledgers_new RENAME TO ledgers;
+
+CREATE INDEX ledgers_name_idx ON ledgers(name);
+CREATE INDEX ledgers_zoneid_idx ON ledgers(zone_id);
+CREATE INDEX ledgers_deletedat_idx ON ledgers(deleted_at);
+CREATE UNIQUE INDEX ledgers_zoneid_name_live_key ON ledgers(zone_id, name) WHERE deleted_at IS NULL;
+
+-- Trigger to track changes in the `ledgers` table after insert
+-- +goose StatementBegin
+CREATE TRIGGER ledgers_change_streams_after_insert
+AFTER INSERT ON ledgers
+FOR EACH ROW
+BEGIN
+    INSERT INTO change_streams (change_entity, change_type, change_entity_id, zone_id, payload)
+		VALUES ('LEDGER', 'INSERT', NEW.ledger_id, NEW.zone_id,
+				'{"ledger_id": "' || NEW.ledger_id || '", "created_at": "' || NEW.created_at ||
+				'", "updated_at": "' || NEW.updated_at || '", "name": "' || NEW.name || '", "kind": "' || NEW.kind ||
+				'", "zone_id": ' || NEW.zone_id || ', "ref": "' || NEW.ref || '"}');
+END;
+-- +goose StatementEnd
+
+-- Trigger to track changes in the `ledgers` table after update
+-- +goose StatementBegin
+CREATE TRIGGER ledgers_change_streams_after_update
+AFTER UPDATE ON ledgers
+FOR EACH ROW
+BEGIN
+    UPDATE ledgers SET updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW') WHERE ledger_id = OLD.ledger_id;
+    INSERT INTO change_streams (change_entity, change_type, change_entity_id, zone_id, payload)
+		VALUES ('LEDGER', 'UPDATE', NEW.ledger_id, NEW.zone_id,
+				'{"ledger_id": "' || NEW.ledger_id || '", "created_at": "' || NEW.created_at ||
+				'", "updated_at": "' || NEW.updated_at || '", "name": "' || NEW.name || '", "kind": "' || NEW.kind ||
+				'", "zone_id": ' || NEW.zone_id || ', "ref": "' || NEW.ref || '"}');
+END;
+-- +goose StatementEnd
+
+-- Trigger to track changes in the `ledgers` table after delete
+-- +goose StatementBegin
+CREATE TRIGGER ledgers_change_streams_after_delete
+AFTER DELETE ON ledgers
+FOR EACH ROW
+BEGIN
+    INSERT INTO change_streams (change_entity, change_type, change_entity_id, zone_id, payload)
+		VALUES ('LEDGER', 'DELETE', OLD.ledger_id, OLD.zone_id,
+				'{"ledger_id": "' || OLD.ledger_id || '", "created_at": "' || OLD.created_at ||
+				'", "updated_at": "' || OLD.updated_at || '", "name": "' || OLD.name || '", "kind": "' || OLD.kind ||
+				'", "zone_id": ' || OLD.zone_id || ', "ref": "' || OLD.ref || '"}');
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP INDEX IF EXISTS ledgers_zoneid_name_live_key;
+DROP INDEX IF EXISTS zones_name_live_key;
+DROP INDEX IF EXISTS ledgers_deletedat_idx;
+DROP INDEX IF EXISTS zones_deletedat_idx;
+-- Soft deleted rows are purged, as they may hold the names of live rows.
+DELETE FROM key_values WHERE zone_id IN (SELECT zone_id FROM zones WHERE deleted_at IS NOT NULL);
+DELETE FROM transactions WHERE zone_id IN (SELECT zone_id FROM zones WHERE deleted_at IS NOT NULL);
+DELETE FROM ledgers WHERE deleted_at IS NOT NULL OR zone_id IN (SELECT zone_id FROM zones WHERE deleted_at IS NOT NULL);
+DELETE FROM zones WHERE deleted_at IS NOT NULL;
+ALTER TABLE ledgers DROP COLUMN deleted_at;
+ALTER TABLE zones DROP COLUMN deleted_at;
+CREATE UNIQUE INDEX ledgers_zoneid_name_key ON ledgers(zone_id, name);
+CREATE UNIQUE INDEX zones_name_key ON zones(name);
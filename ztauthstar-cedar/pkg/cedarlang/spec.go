@@ -47,9 +47,25 @@ const (
 	LanguagePolicyType = "policy"
 	// LanguagePolicyTypeID specifies the policy type ID for Cedar language.
 	LanguagePolicyTypeID = uint32(2)
+	// LanguageTemplateType specifies the policy template type for Cedar language.
+	LanguageTemplateType = "template"
+	// LanguageTemplateTypeID specifies the policy template type ID for Cedar language.
+	LanguageTemplateTypeID = uint32(3)
+	// LanguageTemplateLinkType specifies the template link type for Cedar language.
+	LanguageTemplateLinkType = "template-link"
+	// LanguageTemplateLinkTypeID specifies the template link type ID for Cedar language.
+	LanguageTemplateLinkTypeID = uint32(4)
+	// LanguageRoleType specifies the role and group definition type for Cedar language.
+	LanguageRoleType = "role"
+	// LanguageRoleTypeID specifies the role and group definition type ID for Cedar language.
+	LanguageRoleTypeID = uint32(5)
 
 	// LanguageFileExtension specifies the standard file extension for Cedar language files.
 	LanguageFileExtension = ".cedar"
 	// LanguageSchemaFileName defines the default filename for the schema definition associated with Cedar.
 	LanguageSchemaFileName = "schema.json"
+	// LanguageTemplateLinksFileName defines the default filename for the template links associated with Cedar.
+	LanguageTemplateLinksFileName = "template-links.json"
+	// LanguageRolesFileName defines the default filename for the role and group definitions associated with Cedar.
+	LanguageRolesFileName = "roles.json"
 )
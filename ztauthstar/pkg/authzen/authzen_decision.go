@@ -42,10 +42,11 @@ func NewAuthorizationError(code string, message string) (*AuthorizationError, er
 
 // AuthorizationDecision represents the authorization decision.
 type AuthorizationDecision struct {
-	id         string
-	decision   bool
-	adminError *AuthorizationError
-	userError  *AuthorizationError
+	id                  string
+	decision            bool
+	adminError          *AuthorizationError
+	userError           *AuthorizationError
+	determiningPolicies []string
 }
 
 // NewAuthorizationDecision creates a new authorization decision.
@@ -77,3 +78,13 @@ func (a *AuthorizationDecision) AdminError() *AuthorizationError {
 func (a *AuthorizationDecision) UserError() *AuthorizationError {
 	return a.userError
 }
+
+// SetDeterminingPolicies sets the ids of the policies that determined the decision.
+func (a *AuthorizationDecision) SetDeterminingPolicies(policyIDs []string) {
+	a.determiningPolicies = policyIDs
+}
+
+// DeterminingPolicies returns the ids of the policies that determined the decision.
+func (a *AuthorizationDecision) DeterminingPolicies() []string {
+	return a.determiningPolicies
+}
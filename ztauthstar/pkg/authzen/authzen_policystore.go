@@ -32,9 +32,12 @@ func (s *StoreItem) ObjectInfo() *objects.ObjectInfo {
 
 // PolicyStore represents the policy store.
 type PolicyStore struct {
-	schemas  []StoreItem
-	version  string
-	policies []StoreItem
+	schemas       []StoreItem
+	version       string
+	policies      []StoreItem
+	templates     []StoreItem
+	templateLinks []StoreItem
+	roles         []StoreItem
 }
 
 // AddSchema adds a schema to the policy store.
@@ -68,3 +71,36 @@ func (ps *PolicyStore) AddPolicy(policyID string, objectInfo *objects.ObjectInfo
 func (ps *PolicyStore) Policies() []StoreItem {
 	return ps.policies
 }
+
+// AddTemplate adds a policy template to the policy store.
+func (ps *PolicyStore) AddTemplate(templateID string, objectInfo *objects.ObjectInfo) {
+	template := StoreItem{objectInfo: objectInfo}
+	ps.templates = append(ps.templates, template)
+}
+
+// Templates returns the policy templates of the policy store.
+func (ps *PolicyStore) Templates() []StoreItem {
+	return ps.templates
+}
+
+// AddTemplateLink adds a template link to the policy store.
+func (ps *PolicyStore) AddTemplateLink(linkID string, objectInfo *objects.ObjectInfo) {
+	link := StoreItem{objectInfo: objectInfo}
+	ps.templateLinks = append(ps.templateLinks, link)
+}
+
+// TemplateLinks returns the template links of the policy store.
+func (ps *PolicyStore) TemplateLinks() []StoreItem {
+	return ps.templateLinks
+}
+
+// AddRole adds a role or group definition to the policy store.
+func (ps *PolicyStore) AddRole(roleID string, objectInfo *objects.ObjectInfo) {
+	role := StoreItem{objectInfo: objectInfo}
+	ps.roles = append(ps.roles, role)
+}
+
+// Roles returns the role and group definitions of the policy store.
+func (ps *PolicyStore) Roles() []StoreItem {
+	return ps.roles
+}
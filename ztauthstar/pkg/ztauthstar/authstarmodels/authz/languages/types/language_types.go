@@ -26,4 +26,19 @@ const (
 	ClassTypePolicy = "policy"
 	// ClassTypePolicyID is the type id for policies.
 	ClassTypePolicyID = uint32(2)
+
+	// ClassTypeTemplate is the type for policy templates.
+	ClassTypeTemplate = "template"
+	// ClassTypeTemplateID is the type id for policy templates.
+	ClassTypeTemplateID = uint32(3)
+
+	// ClassTypeTemplateLink is the type for the links binding the slots of a policy template.
+	ClassTypeTemplateLink = "template-link"
+	// ClassTypeTemplateLinkID is the type id for the links binding the slots of a policy template.
+	ClassTypeTemplateLinkID = uint32(4)
+
+	// ClassTypeRole is the type for the role and group definitions expanded into the parents of the subjects.
+	ClassTypeRole = "role"
+	// ClassTypeRoleID is the type id for the role and group definitions expanded into the parents of the subjects.
+	ClassTypeRoleID = uint32(5)
 )
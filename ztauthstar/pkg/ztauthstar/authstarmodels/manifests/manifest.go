@@ -59,4 +59,5 @@ type Runtime struct {
 type Partition struct {
 	Runtime string `json:"runtime" yaml:"runtime"`
 	Schema  bool   `json:"schema" yaml:"schema"`
+	Strict  bool   `json:"strict,omitempty" yaml:"strict,omitempty"`
 }
@@ -98,6 +98,9 @@ func ValidateManifest(manifest *Manifest) (bool, error) {
 			if _, ok := manifest.Runtimes[partition.Runtime]; !ok {
 				return false, fmt.Errorf("[ztas] profile %q partition %q references undefined runtime %q", profileKey, partKey, partition.Runtime)
 			}
+			if partition.Strict && !partition.Schema {
+				return false, fmt.Errorf("[ztas] profile %q partition %q enables strict mode without schema", profileKey, partKey)
+			}
 			if ownerProfile, exists := seenPartitions[partKey]; exists {
 				return false, fmt.Errorf("[ztas] partition %q is defined in both profile %q and %q, partitions must be unique across profiles", partKey, ownerProfile, profileKey)
 			}
@@ -192,3 +192,19 @@ func TestValidateManifestUndefinedRuntime(t *testing.T) {
 	assert.Error(err)
 	assert.False(ok)
 }
+
+func TestValidateManifestStrictWithoutSchema(t *testing.T) {
+	assert := assert.New(t)
+	m := newValidManifest()
+	p := m.Profiles["default"]
+	p.Partitions["/"] = Partition{Runtime: "cedar", Schema: false, Strict: true}
+	m.Profiles["default"] = p
+	ok, err := ValidateManifest(m)
+	assert.Error(err)
+	assert.False(ok)
+
+	p.Partitions["/"] = Partition{Runtime: "cedar", Schema: true, Strict: true}
+	ok, err = ValidateManifest(m)
+	assert.NoError(err)
+	assert.True(ok)
+}
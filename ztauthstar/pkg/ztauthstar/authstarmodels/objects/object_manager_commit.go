@@ -28,16 +28,25 @@ type cborCommitProfile struct {
 	Tree string `cbor:"2,keyasint"`
 }
 
+// cborCommitSignature is the CBOR-serializable representation of a commit signature.
+type cborCommitSignature struct {
+	Format    string `cbor:"1,keyasint"`
+	PublicKey string `cbor:"2,keyasint"`
+	Blob      []byte `cbor:"3,keyasint"`
+}
+
 // cborCommit is the CBOR-serializable representation of a commit.
+// The signature is omitted when empty so that unsigned commits keep their OID.
 type cborCommit struct {
-	Profiles           []cborCommitProfile `cbor:"1,keyasint"`
-	Predecessor        string              `cbor:"2,keyasint"`
-	Author             string              `cbor:"3,keyasint"`
-	AuthorTimestamp    int64               `cbor:"4,keyasint"`
-	Committer          string              `cbor:"5,keyasint"`
-	CommitterTimestamp int64               `cbor:"6,keyasint"`
-	Message            string              `cbor:"7,keyasint"`
-	Manifest           string              `cbor:"8,keyasint"`
+	Profiles           []cborCommitProfile  `cbor:"1,keyasint"`
+	Predecessor        string               `cbor:"2,keyasint"`
+	Author             string               `cbor:"3,keyasint"`
+	AuthorTimestamp    int64                `cbor:"4,keyasint"`
+	Committer          string               `cbor:"5,keyasint"`
+	CommitterTimestamp int64                `cbor:"6,keyasint"`
+	Message            string               `cbor:"7,keyasint"`
+	Manifest           string               `cbor:"8,keyasint"`
+	Signature          *cborCommitSignature `cbor:"9,keyasint,omitempty"`
 }
 
 // SerializeCommit serializes a commit object to CBOR.
@@ -71,6 +80,13 @@ func (m *ObjectManager) SerializeCommit(commit *Commit) ([]byte, error) {
 		Message:            commit.message,
 		Manifest:           manifestOID,
 	}
+	if sig := commit.metaData.signature; sig != nil {
+		c.Signature = &cborCommitSignature{
+			Format:    sig.format,
+			PublicKey: sig.publicKey,
+			Blob:      sig.blob,
+		}
+	}
 	return m.encMode.Marshal(c)
 }
 
@@ -101,6 +117,14 @@ func (m *ObjectManager) DeserializeCommit(data []byte) (*Commit, error) {
 			tree: CID(p.Tree),
 		}
 	}
+	var signature *CommitSignature
+	if c.Signature != nil {
+		signature = &CommitSignature{
+			format:    c.Signature.Format,
+			publicKey: c.Signature.PublicKey,
+			blob:      c.Signature.Blob,
+		}
+	}
 	return &Commit{
 		profiles:    profiles,
 		manifest:    manifest,
@@ -110,6 +134,7 @@ func (m *ObjectManager) DeserializeCommit(data []byte) (*Commit, error) {
 			authorTimestamp:    time.Unix(c.AuthorTimestamp, 0),
 			committer:          c.Committer,
 			committerTimestamp: time.Unix(c.CommitterTimestamp, 0),
+			signature:          signature,
 		},
 		message: c.Message,
 	}, nil
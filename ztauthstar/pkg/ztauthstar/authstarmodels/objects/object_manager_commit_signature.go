@@ -0,0 +1,178 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objects
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	// ErrCommitNotSigned is returned when a commit carries no signature.
+	ErrCommitNotSigned = errors.New("objects: commit is not signed")
+	// ErrCommitSignatureInvalid is returned when a commit signature does not match its content.
+	ErrCommitSignatureInvalid = errors.New("objects: commit signature is invalid")
+	// ErrCommitSignerNotTrusted is returned when a commit is signed by a key that is not trusted.
+	ErrCommitSignerNotTrusted = errors.New("objects: commit signer is not trusted")
+)
+
+// ParseSigningKey parses a private key used to sign commits.
+// Both OpenSSH and PEM encoded keys (PKCS#8 ed25519, PKCS#1 rsa, ec) are supported.
+func ParseSigningKey(data []byte) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		var passErr *ssh.PassphraseMissingError
+		if errors.As(err, &passErr) {
+			return nil, errors.New("objects: passphrase protected signing keys are not supported, use an ssh agent instead")
+		}
+		return nil, fmt.Errorf("objects: invalid signing key: %w", err)
+	}
+	return signer, nil
+}
+
+// ParseTrustedKey parses a public key either in authorized keys format or as a PEM encoded PKIX public key.
+func ParseTrustedKey(key string) (ssh.PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.New("objects: trusted key is empty")
+	}
+	if block, _ := pem.Decode([]byte(key)); block != nil {
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("objects: unsupported trusted key pem block %q", block.Type)
+		}
+		rawKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("objects: invalid trusted key: %w", err)
+		}
+		pubKey, err := ssh.NewPublicKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("objects: invalid trusted key: %w", err)
+		}
+		return pubKey, nil
+	}
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("objects: invalid trusted key: %w", err)
+	}
+	return pubKey, nil
+}
+
+// FormatPublicKey formats a public key in authorized keys format without comment.
+func FormatPublicKey(pubKey ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pubKey)))
+}
+
+// NormalizeTrustedKey parses a trusted key and returns it in authorized keys format without comment.
+func NormalizeTrustedKey(key string) (string, error) {
+	pubKey, err := ParseTrustedKey(key)
+	if err != nil {
+		return "", err
+	}
+	return FormatPublicKey(pubKey), nil
+}
+
+// CommitSigningPayload returns the bytes covered by the signature of a commit,
+// that is the commit serialized without its signature.
+func (m *ObjectManager) CommitSigningPayload(commit *Commit) ([]byte, error) {
+	if commit == nil {
+		return nil, errors.New("objects: commit is nil")
+	}
+	unsigned := *commit
+	unsigned.metaData.signature = nil
+	return m.SerializeCommit(&unsigned)
+}
+
+// SignCommit signs the commit and stores the signature in the commit metadata.
+func (m *ObjectManager) SignCommit(commit *Commit, signer ssh.Signer) error {
+	if signer == nil {
+		return errors.New("objects: signer is nil")
+	}
+	payload, err := m.CommitSigningPayload(commit)
+	if err != nil {
+		return err
+	}
+	var sig *ssh.Signature
+	algSigner, ok := signer.(ssh.AlgorithmSigner)
+	if ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// ssh-rsa signatures use sha1, hence the stronger algorithm is forced for rsa keys.
+		sig, err = algSigner.SignWithAlgorithm(rand.Reader, payload, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = signer.Sign(rand.Reader, payload)
+	}
+	if err != nil {
+		return fmt.Errorf("objects: failed to sign commit: %w", err)
+	}
+	signature, err := NewCommitSignature(sig.Format, FormatPublicKey(signer.PublicKey()), sig.Blob)
+	if err != nil {
+		return err
+	}
+	commit.metaData.signature = signature
+	return nil
+}
+
+// VerifyCommitSignature verifies that the commit signature matches the commit content and returns the signer public key.
+func (m *ObjectManager) VerifyCommitSignature(commit *Commit) (ssh.PublicKey, error) {
+	if commit == nil {
+		return nil, errors.New("objects: commit is nil")
+	}
+	signature := commit.metaData.signature
+	if signature == nil {
+		return nil, ErrCommitNotSigned
+	}
+	pubKey, err := ParseTrustedKey(signature.publicKey)
+	if err != nil {
+		return nil, errors.Join(ErrCommitSignatureInvalid, err)
+	}
+	// ssh-rsa signatures use sha1, hence only the sha2 algorithms are accepted for rsa keys.
+	if pubKey.Type() == ssh.KeyAlgoRSA && signature.format != ssh.KeyAlgoRSASHA256 && signature.format != ssh.KeyAlgoRSASHA512 {
+		return nil, fmt.Errorf("%w: unsupported signature algorithm %s", ErrCommitSignatureInvalid, signature.format)
+	}
+	payload, err := m.CommitSigningPayload(commit)
+	if err != nil {
+		return nil, err
+	}
+	if err := pubKey.Verify(payload, &ssh.Signature{Format: signature.format, Blob: signature.blob}); err != nil {
+		return nil, errors.Join(ErrCommitSignatureInvalid, err)
+	}
+	return pubKey, nil
+}
+
+// VerifyTrustedCommit verifies that the commit is validly signed by one of the trusted keys.
+func (m *ObjectManager) VerifyTrustedCommit(commit *Commit, trustedKeys []string) error {
+	pubKey, err := m.VerifyCommitSignature(commit)
+	if err != nil {
+		return err
+	}
+	signerKey := pubKey.Marshal()
+	for _, trustedKey := range trustedKeys {
+		trustedPubKey, err := ParseTrustedKey(trustedKey)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(trustedPubKey.Marshal(), signerKey) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrCommitSignerNotTrusted, ssh.FingerprintSHA256(pubKey))
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Nitro Agility S.r.l.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package objects
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestSigningCommit creates a commit to be signed in tests.
+func newTestSigningCommit() *Commit {
+	return &Commit{
+		profiles: []CommitProfile{
+			{key: "ztas_app/", tree: CID("bafyreib52786751f4b6f9839953fe3dcc2278c66648f0d0193f98088b7e4d0c")},
+		},
+		predecessor: NullableString{Valid: false},
+		metaData: CommitMetaData{
+			author:             "Nicola Gallo",
+			authorTimestamp:    time.Unix(1628704800, 0),
+			committer:          "Nicola Gallo",
+			committerTimestamp: time.Unix(1628704800, 0),
+		},
+		message: "Initial commit",
+	}
+}
+
+// newTestEd25519PEMKey generates an ed25519 key encoded as PKCS#8 and PKIX pem blocks.
+func newTestEd25519PEMKey(t *testing.T) ([]byte, string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}), string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
+}
+
+// TestSignAndVerifyCommit tests that a signed commit survives a round-trip and is verified against trusted keys.
+func TestSignAndVerifyCommit(t *testing.T) {
+	assert := assert.New(t)
+	objectManager, _ := NewObjectManager()
+
+	privPEM, pubPEM := newTestEd25519PEMKey(t)
+	signer, err := ParseSigningKey(privPEM)
+	assert.NoError(err)
+
+	commit := newTestSigningCommit()
+	assert.NoError(objectManager.SignCommit(commit, signer))
+	assert.NotNil(commit.metaData.Signature())
+	assert.Equal(ssh.KeyAlgoED25519, commit.metaData.Signature().Format())
+
+	obj, err := objectManager.CreateCommitObject(commit)
+	assert.NoError(err)
+	objInfo, err := objectManager.ObjectInfo(obj)
+	assert.NoError(err)
+	decoded := objInfo.Instance().(*Commit)
+
+	pubKey, err := objectManager.VerifyCommitSignature(decoded)
+	assert.NoError(err)
+	assert.Equal(FormatPublicKey(signer.PublicKey()), FormatPublicKey(pubKey))
+	assert.NoError(objectManager.VerifyTrustedCommit(decoded, []string{pubPEM}))
+	assert.NoError(objectManager.VerifyTrustedCommit(decoded, []string{FormatPublicKey(signer.PublicKey()) + " ci@example.com"}))
+
+	_, otherPubPEM := newTestEd25519PEMKey(t)
+	assert.ErrorIs(objectManager.VerifyTrustedCommit(decoded, []string{otherPubPEM}), ErrCommitSignerNotTrusted)
+	assert.ErrorIs(objectManager.VerifyTrustedCommit(decoded, nil), ErrCommitSignerNotTrusted)
+}
+
+// TestVerifyCommitSignatureFailures tests unsigned and tampered commits.
+func TestVerifyCommitSignatureFailures(t *testing.T) {
+	assert := assert.New(t)
+	objectManager, _ := NewObjectManager()
+
+	unsigned := newTestSigningCommit()
+	_, err := objectManager.VerifyCommitSignature(unsigned)
+	assert.ErrorIs(err, ErrCommitNotSigned)
+
+	privPEM, _ := newTestEd25519PEMKey(t)
+	signer, err := ParseSigningKey(privPEM)
+	assert.NoError(err)
+	commit := newTestSigningCommit()
+	assert.NoError(objectManager.SignCommit(commit, signer))
+	commit.message = "Tampered commit"
+	_, err = objectManager.VerifyCommitSignature(commit)
+	assert.ErrorIs(err, ErrCommitSignatureInvalid)
+}
+
+// TestVerifyCommitSignatureRSA tests that rsa commits are accepted only with sha2 signatures.
+func TestVerifyCommitSignatureRSA(t *testing.T) {
+	assert := assert.New(t)
+	objectManager, _ := NewObjectManager()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	signer, err := ssh.NewSignerFromKey(rsaKey)
+	assert.NoError(err)
+
+	commit := newTestSigningCommit()
+	assert.NoError(objectManager.SignCommit(commit, signer))
+	assert.Equal(ssh.KeyAlgoRSASHA512, commit.metaData.Signature().Format())
+	_, err = objectManager.VerifyCommitSignature(commit)
+	assert.NoError(err)
+
+	payload, err := objectManager.CommitSigningPayload(commit)
+	assert.NoError(err)
+	for _, algorithm := range []string{ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA} {
+		sig, err := signer.(ssh.AlgorithmSigner).SignWithAlgorithm(rand.Reader, payload, algorithm)
+		assert.NoError(err)
+		commit.metaData.signature, err = NewCommitSignature(sig.Format, FormatPublicKey(signer.PublicKey()), sig.Blob)
+		assert.NoError(err)
+		_, err = objectManager.VerifyCommitSignature(commit)
+		if algorithm == ssh.KeyAlgoRSA {
+			assert.ErrorIs(err, ErrCommitSignatureInvalid)
+		} else {
+			assert.NoError(err)
+		}
+	}
+}
+
+// TestUnsignedCommitOIDIsStable tests that the signature field does not change the encoding of unsigned commits.
+func TestUnsignedCommitOIDIsStable(t *testing.T) {
+	assert := assert.New(t)
+	objectManager, _ := NewObjectManager()
+
+	commit := newTestSigningCommit()
+	payload, err := objectManager.CommitSigningPayload(commit)
+	assert.NoError(err)
+	serialized, err := objectManager.SerializeCommit(commit)
+	assert.NoError(err)
+	assert.Equal(serialized, payload)
+
+	privPEM, _ := newTestEd25519PEMKey(t)
+	signer, _ := ParseSigningKey(privPEM)
+	assert.NoError(objectManager.SignCommit(commit, signer))
+	signedPayload, err := objectManager.CommitSigningPayload(commit)
+	assert.NoError(err)
+	assert.Equal(payload, signedPayload)
+}
+
+// TestParseTrustedKey tests the supported trusted key formats.
+func TestParseTrustedKey(t *testing.T) {
+	assert := assert.New(t)
+	_, pubPEM := newTestEd25519PEMKey(t)
+	normalized, err := NormalizeTrustedKey(pubPEM)
+	assert.NoError(err)
+	assert.Contains(normalized, "ssh-ed25519 ")
+
+	again, err := NormalizeTrustedKey(normalized + " comment")
+	assert.NoError(err)
+	assert.Equal(normalized, again)
+
+	_, err = NormalizeTrustedKey("")
+	assert.Error(err)
+	_, err = NormalizeTrustedKey("not a key")
+	assert.Error(err)
+}
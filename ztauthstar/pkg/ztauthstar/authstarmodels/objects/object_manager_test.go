@@ -109,6 +109,7 @@ func TestObjectManager(t *testing.T) {
 			MetaKeyLanguageTypeID:    uint32(1),
 			MetaKeyCodeID:            "my-custom-id",
 			MetaKeyCodeTypeID:        uint32(1),
+			MetaKeySchemaStrict:      true,
 		})
 		blobObj, err := objectManager.CreateBlobObject(header, blobData)
 		assert.NoError(err)
@@ -132,6 +133,8 @@ func TestObjectManager(t *testing.T) {
 		assert.Equal(uint32(1), objectInfo.header.MetadataUint32(MetaKeyLanguageTypeID))
 		assert.Equal("my-custom-id", objectInfo.header.MetadataString(MetaKeyCodeID))
 		assert.Equal(uint32(1), objectInfo.header.MetadataUint32(MetaKeyCodeTypeID))
+		assert.True(objectInfo.header.MetadataBool(MetaKeySchemaStrict))
+		assert.False(objectInfo.header.MetadataBool(MetaKeyFormat))
 	})
 
 	// Test for invalid data
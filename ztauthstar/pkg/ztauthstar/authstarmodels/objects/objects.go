@@ -68,6 +68,8 @@ const (
 	MetaKeyCodeTypeID = "code-type-id"
 	// MetaKeyFormat is the metadata key for the content format.
 	MetaKeyFormat = "format"
+	// MetaKeySchemaStrict is the metadata key for the schema-strict validation mode.
+	MetaKeySchemaStrict = "schema-strict"
 )
 
 // DataTypeName returns the display name for a content kind ID.
@@ -152,6 +154,20 @@ func (o *ObjectHeader) MetadataUint32(key string) uint32 {
 	}
 }
 
+// MetadataBool returns a metadata value as a bool.
+// Returns false if the key is missing or not a bool.
+func (o *ObjectHeader) MetadataBool(key string) bool {
+	v, ok := o.metadata[key]
+	if !ok {
+		return false
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false
+	}
+	return b
+}
+
 // NewObjectHeader creates a new object header.
 func NewObjectHeader(dataType uint32, metadata map[string]any) (*ObjectHeader, error) {
 	if metadata == nil {
@@ -260,6 +276,7 @@ type CommitMetaData struct {
 	authorTimestamp    time.Time
 	committer          string
 	committerTimestamp time.Time
+	signature          *CommitSignature
 }
 
 // Author returns the author of the commit info.
@@ -282,6 +299,49 @@ func (c *CommitMetaData) CommitterTimestamp() time.Time {
 	return c.committerTimestamp
 }
 
+// Signature returns the signature of the commit, or nil if the commit is not signed.
+func (c *CommitMetaData) Signature() *CommitSignature {
+	return c.signature
+}
+
+// CommitSignature represents the signature of a commit.
+type CommitSignature struct {
+	format    string
+	publicKey string
+	blob      []byte
+}
+
+// NewCommitSignature creates a new commit signature.
+func NewCommitSignature(format, publicKey string, blob []byte) (*CommitSignature, error) {
+	if strings.TrimSpace(format) == "" {
+		return nil, errors.New("objects: commit signature format is empty")
+	} else if strings.TrimSpace(publicKey) == "" {
+		return nil, errors.New("objects: commit signature public key is empty")
+	} else if len(blob) == 0 {
+		return nil, errors.New("objects: commit signature is empty")
+	}
+	return &CommitSignature{
+		format:    format,
+		publicKey: publicKey,
+		blob:      blob,
+	}, nil
+}
+
+// Format returns the signature format (e.g. ssh-ed25519).
+func (s *CommitSignature) Format() string {
+	return s.format
+}
+
+// PublicKey returns the public key of the signer in authorized keys format.
+func (s *CommitSignature) PublicKey() string {
+	return s.publicKey
+}
+
+// Blob returns the signature bytes.
+func (s *CommitSignature) Blob() []byte {
+	return s.blob
+}
+
 // CommitProfile represents a profile entry in a commit, mapping a profile/partition key to a tree.
 type CommitProfile struct {
 	key  string